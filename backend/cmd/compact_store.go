@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var compactDryRun bool
+
+var compactStoreCmd = &cobra.Command{
+	Use:   "compact-store [dir]",
+	Short: "Rewrite every DAG file of a directory in canonical form",
+	Long: `Load every DAG file of a directory and rewrite it as indented JSON with nodes
+sorted by ID, so that files written by different versions stay diff friendly.
+
+Examples:
+  jurigen compact-store data
+  jurigen compact-store data --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCompactStore,
+}
+
+func init() {
+	compactStoreCmd.Flags().BoolVar(&compactDryRun, "dry-run", false, "Report the files to rewrite without modifying them")
+
+	rootCmd.AddCommand(compactStoreCmd)
+}
+
+func runCompactStore(cmd *cobra.Command, args []string) error {
+	changed, total, err := compactStore(args[0], compactDryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(changed) == 0 {
+		fmt.Printf("✅ All %d DAG file(s) already in canonical form\n", total)
+		return nil
+	}
+
+	if compactDryRun {
+		fmt.Printf("🔍 %d of %d DAG file(s) would be rewritten (dry run):\n", len(changed), total)
+	} else {
+		fmt.Printf("🔧 %d of %d DAG file(s) rewritten:\n", len(changed), total)
+	}
+	for _, file := range changed {
+		fmt.Printf("   %s\n", file)
+	}
+
+	return nil
+}
+
+// compactStore rewrites the DAG files of dir whose content differs from their canonical form.
+// It returns the sorted names of the files changed and the number of DAG files scanned.
+func compactStore(dir string, dryRun bool) ([]string, int, error) {
+	files, err := listDAGFiles(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var changed []string
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return changed, len(files), fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+
+		var dagData model.DAG
+		if err := json.Unmarshal(data, &dagData); err != nil {
+			return changed, len(files), fmt.Errorf("failed to parse JSON from %s: %w", file, err)
+		}
+
+		canonical, err := dagData.MarshalCanonicalJSON()
+		if err != nil {
+			return changed, len(files), fmt.Errorf("failed to marshal DAG from %s: %w", file, err)
+		}
+
+		if bytes.Equal(data, canonical) {
+			continue
+		}
+		changed = append(changed, file)
+
+		if dryRun {
+			continue
+		}
+		if err := os.WriteFile(path, canonical, 0644); err != nil {
+			return changed, len(files), fmt.Errorf("failed to write file %s: %w", file, err)
+		}
+	}
+
+	return changed, len(files), nil
+}
+
+// listDAGFiles returns the sorted names of the JSON files of dir, sub-directories are ignored
+func listDAGFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		files = append(files, entry.Name())
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
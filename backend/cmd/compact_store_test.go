@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactStore(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (string, map[string][]byte) {
+		dir := t.TempDir()
+		files := make(map[string][]byte)
+
+		// Compact JSON as written by the file repository
+		compact := dagtest.ValidSingleRoot()
+		data, err := compact.MarshalJSON()
+		require.NoError(t, err)
+		files[compact.Id.String()+".json"] = data
+
+		// Already canonical file
+		canonical := dagtest.LinearChain(3)
+		data, err = canonical.MarshalCanonicalJSON()
+		require.NoError(t, err)
+		files[canonical.Id.String()+".json"] = data
+
+		for name, content := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0644))
+		}
+		// Non DAG files are ignored
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("notes"), 0644))
+
+		return dir, files
+	}
+
+	t.Run("rewrites non canonical files", func(t *testing.T) {
+		t.Parallel()
+
+		dir, files := setup(t)
+
+		changed, total, err := compactStore(dir, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, changed, 1)
+
+		for name := range files {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "\n  \"id\"")
+		}
+
+		// Running again finds every file normalized
+		changed, _, err = compactStore(dir, false)
+		require.NoError(t, err)
+		assert.Empty(t, changed)
+	})
+
+	t.Run("dry run does not modify files", func(t *testing.T) {
+		t.Parallel()
+
+		dir, files := setup(t)
+
+		changed, _, err := compactStore(dir, true)
+		require.NoError(t, err)
+		assert.Len(t, changed, 1)
+
+		for name, content := range files {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			assert.Equal(t, content, data)
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"davidterranova/jurigen/backend/pkg/xhttp"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix prefixes the environment variables named after the flags, e.g. JURIGEN_DAG_PATH for --dag-path
+const envPrefix = "JURIGEN_"
+
+// configFlag names the flag of the config file, which cannot be set by the config file itself
+const configFlag = "config"
+
+// Log formats selected by --log-format
+const (
+	logFormatJSON    = "json"
+	logFormatConsole = "console"
+)
+
+// Sources of the settings reported by config print-effective
+const (
+	sourceDefault = "default"
+	sourceFlag    = "flag"
+)
+
+// configSection groups settings of the config file, whose keys are the flag names
+type configSection struct {
+	name  string
+	flags []string
+}
+
+// configSections lists the sections of the config file in print order, every server flag belongs to one of them
+var configSections = []configSection{
+	{name: "server", flags: []string{"address", "shutdown-timeout", "profile", "max-concurrent-walks", "preserve-whitespace", "response-cache-size", "auto-validate-interval", "trash-retention", "rate-limit-dags", "rate-limit-sessions", "rate-limit-webhooks", "rate-limit-burst"}},
+	{name: "repository", flags: []string{"repository", "dag-path", "write-through", "sync-on-shutdown", "sync-interval", "locking", "cache", "cache-ttl", "redis-addr", "redis-password"}},
+	{name: "attachments", flags: []string{"attachment-max-size", "attachment-content-types"}},
+	{name: "auth", flags: []string{"users"}},
+	{name: "cors", flags: []string{"cors-allowed-origins"}},
+	{name: "logging", flags: []string{"log-level", "log-format"}},
+	{name: "llm", flags: []string{"llm-provider", "llm-model", "llm-api-key", "llm-base-url", "llm-timeout"}},
+	{name: "webhooks", flags: []string{"webhook-max-attempts", "webhook-timeout"}},
+	{name: "jobs", flags: []string{"job-workers", "job-queue-size"}},
+}
+
+// secretFlags are redacted from the printed configuration
+var secretFlags = []string{"redis-password", "llm-api-key"}
+
+// applyConfig sets the flags left unset on the command line from their environment variable, otherwise from the
+// config file (JSON or YAML). The command line takes precedence over the environment, which takes precedence over
+// the config file. It returns the source of every flag.
+func applyConfig(flags *pflag.FlagSet, configFile string) (map[string]string, error) {
+	values, err := loadConfigFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	for key := range values {
+		if key == configFlag || flags.Lookup(key) == nil {
+			return nil, fmt.Errorf("unknown setting %q in config file %s", key, configFile)
+		}
+	}
+
+	sources := make(map[string]string)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err != nil {
+			return
+		}
+		if flag.Changed {
+			sources[flag.Name] = sourceFlag
+			return
+		}
+		sources[flag.Name] = sourceDefault
+		if flag.Name == configFlag {
+			return
+		}
+
+		source := envName(flag.Name)
+		value, ok := os.LookupEnv(source)
+		if !ok {
+			fileValue, inFile := values[flag.Name]
+			if !inFile {
+				return
+			}
+			source = configFile
+			value = configValue(fileValue)
+		}
+
+		if setErr := flags.Set(flag.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid %s %q from %s: %w", flag.Name, value, source, setErr)
+			return
+		}
+		sources[flag.Name] = source
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+// loadConfigFile reads the settings of a config file, none when path is empty. The settings are either grouped in
+// sections or at the top level.
+func loadConfigFile(path string) (map[string]any, error) {
+	values := make(map[string]any)
+	if path == "" {
+		return values, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var document map[string]any
+	if isYAMLFile(path) {
+		err = yaml.Unmarshal(data, &document)
+	} else {
+		err = json.Unmarshal(data, &document)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	for key, value := range document {
+		sectionIndex := slices.IndexFunc(configSections, func(section configSection) bool { return section.name == key })
+		settings, isMap := value.(map[string]any)
+		if sectionIndex < 0 || !isMap {
+			values[key] = value
+			continue
+		}
+
+		for name, setting := range settings {
+			if !slices.Contains(configSections[sectionIndex].flags, name) {
+				return nil, fmt.Errorf("unknown setting %q in section %s of config file %s", name, key, path)
+			}
+			values[name] = setting
+		}
+	}
+
+	return values, nil
+}
+
+// configValue formats a config file value as a flag value, lists as comma separated values
+func configValue(value any) string {
+	list, ok := value.([]any)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+
+	items := make([]string, len(list))
+	for i, item := range list {
+		items[i] = fmt.Sprint(item)
+	}
+	return strings.Join(items, ",")
+}
+
+// envName returns the environment variable of a flag
+func envName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// validateServerConfig reports every invalid server setting at once
+func validateServerConfig() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if _, portStr, err := net.SplitHostPort(address); err != nil {
+		invalid("invalid address %q: %w", address, err)
+	} else if port, err := strconv.Atoi(portStr); err != nil || port < 0 || port > 65535 {
+		invalid("invalid port %q in address %q", portStr, address)
+	}
+
+	if _, ok := dagStores[repositoryKind]; !ok {
+		invalid("unknown repository %q, expected one of %v", repositoryKind, repositoryKinds())
+	}
+	if attachmentMaxSize < 1 {
+		invalid("--attachment-max-size must be at least 1, got %d", attachmentMaxSize)
+	}
+	if cacheKind != "" && cacheKind != cacheRedis {
+		invalid("unknown cache %q, expected %s or none", cacheKind, cacheRedis)
+	}
+
+	for _, origin := range corsAllowedOrigins {
+		if origin == xhttp.AnyOrigin {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" {
+			invalid("invalid CORS origin %q, expected * or scheme://host[:port]", origin)
+		}
+	}
+
+	if _, err := zerolog.ParseLevel(logLevel); err != nil || logLevel == "" {
+		invalid("invalid log level %q, expected trace, debug, info, warn or error", logLevel)
+	}
+	if logFormat != logFormatJSON && logFormat != logFormatConsole {
+		invalid("invalid log format %q, expected %s or %s", logFormat, logFormatJSON, logFormatConsole)
+	}
+
+	for name, value := range map[string]time.Duration{"shutdown-timeout": shutdownTimeout, "llm-timeout": llmTimeout, "webhook-timeout": webhookTimeout} {
+		if value <= 0 {
+			invalid("--%s must be positive, got %s", name, value)
+		}
+	}
+	for name, value := range map[string]time.Duration{"sync-interval": syncInterval, "cache-ttl": cacheTTL, "trash-retention": trashRetention, "auto-validate-interval": autoValidateInterval} {
+		if value < 0 {
+			invalid("--%s cannot be negative, got %s", name, value)
+		}
+	}
+	for name, value := range map[string]float64{"rate-limit-dags": rateLimitDAGs, "rate-limit-sessions": rateLimitSessions, "rate-limit-webhooks": rateLimitWebhooks} {
+		if value < 0 {
+			invalid("--%s cannot be negative, got %g", name, value)
+		}
+	}
+	for name, value := range map[string]int{"max-concurrent-walks": maxConcurrentWalks, "response-cache-size": responseCacheSize} {
+		if value < 0 {
+			invalid("--%s cannot be negative, got %d", name, value)
+		}
+	}
+	if webhookMaxAttempts < 1 {
+		invalid("--webhook-max-attempts must be at least 1, got %d", webhookMaxAttempts)
+	}
+	for name, value := range map[string]int{"job-workers": jobWorkers, "job-queue-size": jobQueueSize} {
+		if value < 1 {
+			invalid("--%s must be at least 1, got %d", name, value)
+		}
+	}
+	if rateLimitBurst < 1 {
+		invalid("--rate-limit-burst must be at least 1, got %d", rateLimitBurst)
+	}
+
+	// Maps are iterated in random order, the errors are sorted to be reported the same way every time
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+// newLogger returns the server logger at the configured level and format, the global logger is aligned on it
+func newLogger(level, format string) (zerolog.Logger, error) {
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return zerolog.Logger{}, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	zerolog.SetGlobalLevel(parsed)
+
+	var out io.Writer = os.Stdout
+	if format == logFormatConsole {
+		out = zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
+	}
+	log.Logger = zerolog.New(out).With().Timestamp().Logger()
+
+	return log.Logger, nil
+}
+
+// writeEffectiveConfig writes the settings as a YAML config file, grouped in sections, each setting followed by
+// its source. The secrets are redacted.
+func writeEffectiveConfig(w io.Writer, flags *pflag.FlagSet, sources map[string]string) error {
+	for _, section := range configSections {
+		if _, err := fmt.Fprintf(w, "%s:\n", section.name); err != nil {
+			return err
+		}
+		for _, name := range section.flags {
+			flag := flags.Lookup(name)
+			if flag == nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "  %s: %s # %s\n", name, yamlValue(flag), sources[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// yamlValue formats the value of a flag as a YAML scalar or flow sequence
+func yamlValue(flag *pflag.Flag) string {
+	if slices.Contains(secretFlags, flag.Name) && flag.Value.String() != "" {
+		return strconv.Quote("<redacted>")
+	}
+
+	switch value := flag.Value.(type) {
+	case pflag.SliceValue:
+		items := value.GetSlice()
+		quoted := make([]string, len(items))
+		for i, item := range items {
+			quoted[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	}
+
+	switch flag.Value.Type() {
+	case "bool", "int", "float64":
+		return flag.Value.String()
+	default:
+		return strconv.Quote(flag.Value.String())
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the server configuration",
+}
+
+var configPrintEffectiveCmd = &cobra.Command{
+	Use:   "print-effective",
+	Short: "Print the server configuration merged from the flags, the environment and the config file",
+	Long: `Print the settings the server would run with, as a YAML config file grouped in sections.
+Each setting is followed by its source: flag, the environment variable, the config file or default.
+Secrets are redacted. The command fails when a setting is invalid, after printing the configuration.
+
+Examples:
+  jurigen config print-effective --config server.yaml
+  JURIGEN_LOG_LEVEL=debug jurigen config print-effective --config server.yaml --address :9090`,
+	Args: cobra.NoArgs,
+	RunE: runConfigPrintEffective,
+}
+
+func init() {
+	// The server flags are defined here too, so that they are merged the same way
+	addServerFlags(configPrintEffectiveCmd.Flags())
+
+	configCmd.AddCommand(configPrintEffectiveCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigPrintEffective(cmd *cobra.Command, args []string) error {
+	sources, err := applyConfig(cmd.Flags(), serverConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := writeEffectiveConfig(cmd.OutOrStdout(), cmd.Flags(), sources); err != nil {
+		return err
+	}
+	return validateServerConfig()
+}
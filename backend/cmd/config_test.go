@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"davidterranova/jurigen/backend/internal/usecase"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplyConfig(t *testing.T) {
+	newFlags := func() (*pflag.FlagSet, *string, *bool, *time.Duration) {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		path := flags.String("dag-path", "data", "")
+		through := flags.Bool("write-through", true, "")
+		ttl := flags.Duration("cache-ttl", time.Minute, "")
+		flags.String(configFlag, "", "")
+		return flags, path, through, ttl
+	}
+
+	writeConfig := func(t *testing.T, name, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("keeps the defaults without environment nor config file", func(t *testing.T) {
+		flags, path, through, ttl := newFlags()
+
+		sources, err := applyConfig(flags, "")
+		require.NoError(t, err)
+		assert.Equal(t, "data", *path)
+		assert.True(t, *through)
+		assert.Equal(t, time.Minute, *ttl)
+		assert.Equal(t, sourceDefault, sources["dag-path"])
+	})
+
+	t.Run("takes the command line over the environment over the config file", func(t *testing.T) {
+		flags, path, through, ttl := newFlags()
+		config := writeConfig(t, "server.yaml", "dag-path: from-file\nwrite-through: false\ncache-ttl: 5m\n")
+		t.Setenv("JURIGEN_DAG_PATH", "from-env")
+		t.Setenv("JURIGEN_CACHE_TTL", "10m")
+		require.NoError(t, flags.Parse([]string{"--cache-ttl", "1h"}))
+
+		sources, err := applyConfig(flags, config)
+		require.NoError(t, err)
+		assert.Equal(t, "from-env", *path)
+		assert.False(t, *through)
+		assert.Equal(t, time.Hour, *ttl)
+		assert.Equal(t, map[string]string{
+			"dag-path":      "JURIGEN_DAG_PATH",
+			"write-through": config,
+			"cache-ttl":     sourceFlag,
+			configFlag:      sourceDefault,
+		}, sources)
+	})
+
+	t.Run("reads JSON config files", func(t *testing.T) {
+		flags, path, through, _ := newFlags()
+		config := writeConfig(t, "server.json", `{"dag-path": "from-json", "write-through": false}`)
+
+		_, err := applyConfig(flags, config)
+		require.NoError(t, err)
+		assert.Equal(t, "from-json", *path)
+		assert.False(t, *through)
+	})
+
+	t.Run("reads the settings grouped in sections", func(t *testing.T) {
+		flags, path, through, _ := newFlags()
+		origins := flags.StringSlice("cors-allowed-origins", nil, "")
+		config := writeConfig(t, "server.yaml", "repository:\n  dag-path: from-section\n  write-through: false\ncors:\n  cors-allowed-origins: [https://a.example, https://b.example]\n")
+
+		_, err := applyConfig(flags, config)
+		require.NoError(t, err)
+		assert.Equal(t, "from-section", *path)
+		assert.False(t, *through)
+		assert.Equal(t, []string{"https://a.example", "https://b.example"}, *origins)
+	})
+
+	t.Run("rejects the settings of another section", func(t *testing.T) {
+		flags, _, _, _ := newFlags()
+		config := writeConfig(t, "server.yaml", "logging:\n  dag-path: data\n")
+
+		_, err := applyConfig(flags, config)
+		assert.ErrorContains(t, err, `unknown setting "dag-path" in section logging`)
+	})
+
+	t.Run("rejects unknown settings", func(t *testing.T) {
+		flags, _, _, _ := newFlags()
+		config := writeConfig(t, "server.yaml", "dag-pth: typo\n")
+
+		_, err := applyConfig(flags, config)
+		assert.ErrorContains(t, err, `unknown setting "dag-pth"`)
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		flags, _, _, _ := newFlags()
+		t.Setenv("JURIGEN_WRITE_THROUGH", "sometimes")
+
+		_, err := applyConfig(flags, "")
+		assert.ErrorContains(t, err, "JURIGEN_WRITE_THROUGH")
+	})
+}
+
+func TestConfigSections(t *testing.T) {
+	sectionOf := make(map[string]string)
+	for _, section := range configSections {
+		for _, name := range section.flags {
+			assert.Empty(t, sectionOf[name], "%s is in sections %s and %s", name, sectionOf[name], section.name)
+			sectionOf[name] = section.name
+		}
+	}
+
+	serverCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Name != configFlag {
+			assert.NotEmpty(t, sectionOf[flag.Name], "--%s belongs to no config section", flag.Name)
+		}
+	})
+}
+
+func TestValidateServerConfig(t *testing.T) {
+	// The flags are reset to their defaults after the test
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	addServerFlags(flags)
+	t.Cleanup(func() {
+		flags.VisitAll(func(flag *pflag.Flag) { _ = flag.Value.Set(flag.DefValue) })
+		corsAllowedOrigins = []string{"*"}
+		attachmentContentTypes = usecase.DefaultAttachmentPolicy().ContentTypes
+	})
+
+	require.NoError(t, validateServerConfig(), "the defaults are valid")
+
+	require.NoError(t, flags.Parse([]string{
+		"--address", "8080",
+		"--repository", "s3",
+		"--cors-allowed-origins", "*,app.example.com",
+		"--log-level", "verbose",
+		"--shutdown-timeout", "0s",
+		"--rate-limit-burst", "0",
+		"--attachment-max-size", "0",
+	}))
+	err := validateServerConfig()
+	require.Error(t, err)
+	for _, expected := range []string{
+		`invalid address "8080"`,
+		`unknown repository "s3"`,
+		`invalid CORS origin "app.example.com"`,
+		`invalid log level "verbose"`,
+		"--shutdown-timeout must be positive",
+		"--rate-limit-burst must be at least 1",
+		"--attachment-max-size must be at least 1",
+	} {
+		assert.ErrorContains(t, err, expected)
+	}
+}
+
+func TestWriteEffectiveConfig(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	addServerFlags(flags)
+	t.Cleanup(func() {
+		flags.VisitAll(func(flag *pflag.Flag) { _ = flag.Value.Set(flag.DefValue) })
+		corsAllowedOrigins = []string{"*"}
+	})
+	t.Setenv("JURIGEN_REDIS_PASSWORD", "secret")
+	require.NoError(t, flags.Parse([]string{"--address", ":9090"}))
+
+	sources, err := applyConfig(flags, "")
+	require.NoError(t, err)
+
+	var out strings.Builder
+	require.NoError(t, writeEffectiveConfig(&out, flags, sources))
+
+	printed := out.String()
+	assert.Contains(t, printed, "server:\n  address: \":9090\" # flag\n")
+	assert.Contains(t, printed, "  write-through: true # default\n")
+	assert.Contains(t, printed, "  redis-password: \"<redacted>\" # JURIGEN_REDIS_PASSWORD\n")
+	assert.Contains(t, printed, "cors:\n  cors-allowed-origins: [\"*\"] # default\n")
+	assert.NotContains(t, printed, "secret")
+
+	// The printed configuration is a valid config file
+	var document map[string]map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(printed), &document))
+	assert.Equal(t, ":9090", document["server"]["address"])
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/document"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextExportAnswers []string
+	contextExportFormat  string
+	contextExportOutput  string
+	contextExportCollect bool
+)
+
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Build case context documents",
+}
+
+var contextExportCmd = &cobra.Command{
+	Use:   "export [path]",
+	Short: "Walk a DAG file and export the answers as a case context document",
+	Long: `Walk a DAG file and render the answered questions as a shareable Markdown or PDF
+document, listing for every answer the user context, confidence, tags and evidence
+sources found in its metadata. The walk is interactive unless the answers are given,
+its prompts are printed on stdout so the document is best written to a file.
+
+Examples:
+  jurigen context export data/my-dag.json --context -o case.md
+  jurigen context export data/my-dag.json --format pdf -o case.pdf
+  jurigen context export data/my-dag.json --answers <answer-id>,<answer-id> --format pdf -o case.pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContextExport,
+}
+
+func init() {
+	contextExportCmd.Flags().StringSliceVar(&contextExportAnswers, "answers", nil, "Answer IDs of a complete path from the root, walk interactively when omitted")
+	contextExportCmd.Flags().StringVar(&contextExportFormat, "format", document.FormatMarkdown, "Document format: "+strings.Join(document.Formats(), ", "))
+	contextExportCmd.Flags().StringVarP(&contextExportOutput, "output", "o", "", "Write the document to this file instead of stdout")
+	contextExportCmd.Flags().BoolVarP(&contextExportCollect, "context", "c", false, "Collect additional context and metadata for each answer during the interactive walk")
+
+	contextCmd.AddCommand(contextExportCmd)
+	rootCmd.AddCommand(contextCmd)
+}
+
+func runContextExport(cmd *cobra.Command, args []string) error {
+	data, err := contextDocumentFile(args[0], contextExportAnswers, contextExportFormat, contextExportCollect)
+	if err != nil {
+		return err
+	}
+
+	return writeExport(contextExportOutput, data)
+}
+
+// contextDocumentFile renders the context document of a walk through the DAG file at path,
+// following the given answers or prompting for them when there are none
+func contextDocumentFile(path string, answers []string, format string, collect bool) ([]byte, error) {
+	d, err := readDAGFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []model.WalkStep
+	if len(answers) > 0 {
+		steps, err = resolveAnswerPath(d, answers)
+	} else {
+		steps, err = walkInteractively(d, collect)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := document.Render(model.NewContextDocument(d, steps, time.Now()), format)
+	if err != nil {
+		return nil, err
+	}
+
+	return rendered.Data, nil
+}
+
+// resolveAnswerPath returns the steps of the complete path described by answer IDs
+func resolveAnswerPath(d *model.DAG, answers []string) ([]model.WalkStep, error) {
+	answerIds := make([]uuid.UUID, len(answers))
+	for i, answer := range answers {
+		id, err := uuid.Parse(strings.TrimSpace(answer))
+		if err != nil {
+			return nil, fmt.Errorf("invalid answer ID %q: %w", answer, err)
+		}
+		answerIds[i] = id
+	}
+
+	path, err := d.ResolvePath(answerIds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid answer path: %w", err)
+	}
+
+	return path.Steps, nil
+}
+
+// walkInteractively prompts for an answer to each question from the root node
+func walkInteractively(d *model.DAG, collect bool) ([]model.WalkStep, error) {
+	root, err := d.GetRootNode()
+	if err != nil {
+		return nil, fmt.Errorf("error finding root node: %w", err)
+	}
+
+	answerProvider := model.CLIFnAnswers(model.DefaultPromptConfig())
+	if collect {
+		answerProvider = model.CLIFnAnswersWithContext(model.DefaultPromptConfig())
+	}
+
+	path, err := d.WalkSelect(root.Id, answerProvider)
+	if err != nil {
+		return nil, fmt.Errorf("error walking through DAG: %w", err)
+	}
+
+	steps := make([]model.WalkStep, 0, len(path))
+	for _, answer := range path {
+		step := model.WalkStep{Answer: answer}
+		if answer.ParentNode != nil {
+			step.NodeId = answer.ParentNode.Id
+			step.Question = answer.ParentNode.Question
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextDocumentFile(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+
+	data, err := d.MarshalJSON()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "dag.json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	answers := make([]string, len(paths[0].Steps))
+	for i, step := range paths[0].Steps {
+		answers[i] = step.Answer.Id.String()
+	}
+
+	t.Run("renders the given answers as markdown", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := contextDocumentFile(path, answers, "md", false)
+		require.NoError(t, err)
+
+		content := string(document)
+		assert.Contains(t, content, "# Case context: "+d.Title)
+		for _, step := range paths[0].Steps {
+			assert.Contains(t, content, step.Question)
+			assert.Contains(t, content, "**Answer:** "+step.Answer.Statement)
+		}
+	})
+
+	t.Run("renders the given answers as pdf", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := contextDocumentFile(path, answers, "pdf", false)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(document), "%PDF-"))
+	})
+
+	t.Run("rejects incomplete or invalid paths", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := contextDocumentFile(path, answers[:len(answers)-1], "md", false)
+		assert.ErrorContains(t, err, "invalid answer path")
+
+		_, err = contextDocumentFile(path, []string{"not-a-uuid"}, "md", false)
+		assert.ErrorContains(t, err, "invalid answer ID")
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := contextDocumentFile(path, answers, "docx", false)
+		assert.ErrorContains(t, err, "unknown document format")
+	})
+}
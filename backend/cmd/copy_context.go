@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var copyContextDryRun bool
+
+var copyContextCmd = &cobra.Command{
+	Use:   "copy-context [from] [to]",
+	Short: "Copy answer context and metadata between structurally equal DAG files",
+	Long: `Carry forward the user context and metadata of a filled-out DAG onto a structurally
+equal DAG, for instance a case tree cloned for a similar matter. Answers are matched by
+statement within paired nodes, the target file is rewritten in canonical form.
+
+Examples:
+  jurigen copy-context data/previous-case.json data/new-case.json
+  jurigen copy-context data/previous-case.json data/new-case.json --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCopyContext,
+}
+
+func init() {
+	copyContextCmd.Flags().BoolVar(&copyContextDryRun, "dry-run", false, "Report the number of answers to update without modifying the target file")
+
+	rootCmd.AddCommand(copyContextCmd)
+}
+
+func runCopyContext(cmd *cobra.Command, args []string) error {
+	copied, err := copyContextFiles(args[0], args[1], copyContextDryRun)
+	if err != nil {
+		return err
+	}
+
+	if copyContextDryRun {
+		fmt.Printf("🔍 %d answer(s) of %s would receive context from %s (dry run)\n", copied, args[1], args[0])
+	} else {
+		fmt.Printf("🔧 %d answer(s) of %s received context from %s\n", copied, args[1], args[0])
+	}
+
+	return nil
+}
+
+// copyContextFiles copies the answer context of the DAG file fromPath onto the DAG file toPath
+func copyContextFiles(fromPath string, toPath string, dryRun bool) (int, error) {
+	from, err := readDAGFile(fromPath)
+	if err != nil {
+		return 0, err
+	}
+	to, err := readDAGFile(toPath)
+	if err != nil {
+		return 0, err
+	}
+
+	copied, err := model.CopyContext(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	if dryRun {
+		return copied, nil
+	}
+
+	data, err := marshalDAGFile(toPath, to)
+	if err != nil {
+		return copied, fmt.Errorf("failed to marshal DAG from %s: %w", toPath, err)
+	}
+	if err := os.WriteFile(toPath, data, 0644); err != nil {
+		return copied, fmt.Errorf("failed to write file %s: %w", toPath, err)
+	}
+
+	return copied, nil
+}
+
+// readDAGFile reads a JSON or YAML DAG file, the format is given by the file extension
+func readDAGFile(path string) (*model.DAG, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	var dagData model.DAG
+	if isYAMLFile(path) {
+		if err := yaml.Unmarshal(data, &dagData); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML from %s: %w", path, err)
+		}
+		return &dagData, nil
+	}
+
+	if err := json.Unmarshal(data, &dagData); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON from %s: %w", path, err)
+	}
+
+	return &dagData, nil
+}
+
+// marshalDAGFile renders a DAG in canonical form, in the format given by the file extension
+func marshalDAGFile(path string, d *model.DAG) ([]byte, error) {
+	if isYAMLFile(path) {
+		return d.MarshalCanonicalYAML()
+	}
+	return d.MarshalCanonicalJSON()
+}
+
+func isYAMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
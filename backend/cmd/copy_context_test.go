@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyContextFiles(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (string, string, []byte) {
+		dir := t.TempDir()
+
+		from := dagtest.ValidSingleRoot()
+		root := dagtest.Root(from)
+		root.Answers[0].UserContext = "Signed in 2021"
+		root.Answers[0].Metadata = map[string]interface{}{"source": "contract"}
+
+		fromData, err := from.MarshalJSON()
+		require.NoError(t, err)
+
+		// Same structure under fresh IDs, without context
+		toData := withFreshIds(string(fromData), from)
+		toData = strings.ReplaceAll(toData, `"user_context":"Signed in 2021",`, "")
+		toData = strings.ReplaceAll(toData, `"metadata":{"source":"contract"},`, "")
+
+		fromPath := filepath.Join(dir, "from.json")
+		toPath := filepath.Join(dir, "to.json")
+		require.NoError(t, os.WriteFile(fromPath, fromData, 0644))
+		require.NoError(t, os.WriteFile(toPath, []byte(toData), 0644))
+
+		return fromPath, toPath, []byte(toData)
+	}
+
+	t.Run("writes the context into the target file", func(t *testing.T) {
+		t.Parallel()
+
+		fromPath, toPath, original := setup(t)
+		assert.NotContains(t, string(original), "Signed in 2021")
+
+		copied, err := copyContextFiles(fromPath, toPath, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, copied)
+
+		updated, err := readDAGFile(toPath)
+		require.NoError(t, err)
+		answer := dagtest.Root(updated).Answers[0]
+		assert.Equal(t, "Signed in 2021", answer.UserContext)
+		assert.Equal(t, map[string]interface{}{"source": "contract"}, answer.Metadata)
+	})
+
+	t.Run("dry run leaves the target file untouched", func(t *testing.T) {
+		t.Parallel()
+
+		fromPath, toPath, original := setup(t)
+
+		copied, err := copyContextFiles(fromPath, toPath, true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, copied)
+
+		data, err := os.ReadFile(toPath)
+		require.NoError(t, err)
+		assert.Equal(t, original, data)
+	})
+
+	t.Run("keeps the YAML format of the target file", func(t *testing.T) {
+		t.Parallel()
+
+		fromPath, toPath, _ := setup(t)
+		to, err := readDAGFile(toPath)
+		require.NoError(t, err)
+		yamlData, err := to.MarshalCanonicalYAML()
+		require.NoError(t, err)
+		yamlPath := strings.TrimSuffix(toPath, ".json") + ".yaml"
+		require.NoError(t, os.WriteFile(yamlPath, yamlData, 0644))
+
+		copied, err := copyContextFiles(fromPath, yamlPath, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, copied)
+
+		data, err := os.ReadFile(yamlPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "user_context: Signed in 2021\n")
+	})
+
+	t.Run("rejects a missing target file", func(t *testing.T) {
+		t.Parallel()
+
+		fromPath, _, _ := setup(t)
+
+		_, err := copyContextFiles(fromPath, filepath.Join(t.TempDir(), "missing.json"), false)
+		assert.ErrorContains(t, err, "failed to read file")
+	})
+}
+
+func TestReadDAGFile(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	jsonData, err := d.MarshalJSON()
+	require.NoError(t, err)
+	yamlData, err := d.MarshalCanonicalYAML()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	files := map[string][]byte{"dag.json": jsonData, "dag.yaml": yamlData, "dag.YML": yamlData}
+	for name, data := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+	}
+
+	for name := range files {
+		t.Run("reads "+name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := readDAGFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			assert.Equal(t, d.Id, got.Id)
+			assert.Len(t, got.Nodes, len(d.Nodes))
+		})
+	}
+
+	t.Run("reports the expected format on parse errors", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "broken.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("nodes: [unclosed"), 0644))
+
+		_, err := readDAGFile(path)
+		assert.ErrorContains(t, err, "failed to parse YAML")
+	})
+}
+
+// withFreshIds replaces every ID of the DAG in its JSON encoding, yielding a structurally equal DAG
+func withFreshIds(data string, d *model.DAG) string {
+	ids := []uuid.UUID{d.Id}
+	for _, node := range d.Nodes {
+		ids = append(ids, node.Id)
+		for _, answer := range node.Answers {
+			ids = append(ids, answer.Id)
+		}
+	}
+
+	for _, id := range ids {
+		data = strings.ReplaceAll(data, id.String(), uuid.New().String())
+	}
+
+	return data
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"davidterranova/jurigen/backend/internal/model"
 	"fmt"
 	"log"
 	"os"
@@ -27,15 +26,9 @@ var dagCmd = &cobra.Command{
 			log.Fatalf("DAG file '%s' does not exist", dagFile)
 		}
 
-		data, err := os.ReadFile(dagFile)
+		dag, err := readDAGFile(dagFile)
 		if err != nil {
-			log.Fatalf("error reading file '%s': %v", dagFile, err)
-		}
-
-		var dag = model.NewDAG("Sample DAG")
-		err = dag.UnmarshalJSON(data)
-		if err != nil {
-			log.Fatalf("error unmarshalling file '%s': %v", dagFile, err)
+			log.Fatalf("error loading DAG: %v", err)
 		}
 
 		fmt.Println(dag)
@@ -43,7 +36,7 @@ var dagCmd = &cobra.Command{
 }
 
 func init() {
-	dagCmd.Flags().StringVarP(&dagFile, "dag", "d", "", "Path to the DAG JSON file (required)")
+	dagCmd.Flags().StringVarP(&dagFile, "dag", "d", "", "Path to the DAG JSON or YAML file (required)")
 	err := dagCmd.MarkFlagRequired("dag")
 	if err != nil {
 		log.Fatalf("error marking flag as required: %v", err)
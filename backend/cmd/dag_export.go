@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/export"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat          string
+	exportOutput          string
+	exportHighlightLeaves bool
+	exportHighlightCycles bool
+)
+
+var dagExportCmd = &cobra.Command{
+	Use:   "export [path]",
+	Short: "Export a DAG file for visualization",
+	Long: `Render a DAG file for visualization and documentation tools, where every node is a
+question and every edge an answer statement. Supported formats are Graphviz DOT, GraphML
+(yEd, Gephi), Mermaid flowchart and canonical JSON.
+
+Examples:
+  jurigen dag export data/my-dag.json | dot -Tsvg > my-dag.svg
+  jurigen dag export data/my-dag.json --highlight-leaves --highlight-cycles
+  jurigen dag export data/my-dag.json --format mermaid -o my-dag.mmd
+  jurigen dag export data/my-dag.json --format graphml -o my-dag.graphml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDAGExport,
+}
+
+func init() {
+	formats := strings.Join(export.DefaultRegistry().Formats(), ", ")
+
+	dagExportCmd.Flags().StringVar(&exportFormat, "format", export.FormatDOT, "Export format: "+formats)
+	dagExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write the export to this file instead of stdout")
+	dagExportCmd.Flags().BoolVar(&exportHighlightLeaves, "highlight-leaves", false, "Mark the leaf nodes")
+	dagExportCmd.Flags().BoolVar(&exportHighlightCycles, "highlight-cycles", false, "Mark the edges of the cycles detected by the validator")
+
+	dagCmd.AddCommand(dagExportCmd)
+}
+
+func runDAGExport(cmd *cobra.Command, args []string) error {
+	data, err := exportDAGFile(args[0], exportFormat, exportHighlightLeaves, exportHighlightCycles)
+	if err != nil {
+		return err
+	}
+
+	return writeExport(exportOutput, data)
+}
+
+// exportDAGFile renders the DAG file at path with the exporter registered for format
+func exportDAGFile(path string, format string, highlightLeaves bool, highlightCycles bool) ([]byte, error) {
+	d, err := readDAGFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportDAG(d, format, highlightLeaves, highlightCycles)
+}
+
+func exportDAG(d *model.DAG, format string, highlightLeaves bool, highlightCycles bool) ([]byte, error) {
+	registry := export.DefaultRegistry()
+	exporter, ok := registry.Get(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported export format %q, supported formats: %s", format, strings.Join(registry.Formats(), ", "))
+	}
+
+	options := export.Options{HighlightLeaves: highlightLeaves}
+	if highlightCycles {
+		options.Cycles = usecase.NewDAGValidator().Cycles(d)
+	}
+
+	return exporter.Export(d, options)
+}
+
+// writeExport writes an export to the output file, or to stdout when no file is given
+func writeExport(output string, data []byte) error {
+	if output == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", output, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/export"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDAGFile(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.Cyclic()
+	data, err := d.MarshalJSON()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "cyclic.json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	t.Run("renders the DAG file with the registered exporters", func(t *testing.T) {
+		t.Parallel()
+
+		for _, format := range export.DefaultRegistry().Formats() {
+			exporter, _ := export.DefaultRegistry().Get(format)
+			expected, err := exporter.Export(d, export.Options{})
+			require.NoError(t, err)
+
+			exported, err := exportDAGFile(path, format, false, false)
+			require.NoError(t, err, format)
+			assert.Equal(t, string(expected), string(exported), format)
+		}
+	})
+
+	t.Run("highlights the cycles detected by the validator", func(t *testing.T) {
+		t.Parallel()
+
+		dot, err := exportDAGFile(path, export.FormatDOT, false, true)
+		require.NoError(t, err)
+		assert.Contains(t, string(dot), "color=red")
+	})
+
+	t.Run("rejects unsupported format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exportDAGFile(path, "svg", false, false)
+		assert.ErrorContains(t, err, "unsupported export format")
+	})
+
+	t.Run("fails on missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := exportDAGFile(filepath.Join(t.TempDir(), "missing.json"), export.FormatDOT, false, false)
+		assert.Error(t, err)
+	})
+}
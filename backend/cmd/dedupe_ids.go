@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var dedupeDryRun bool
+
+var dedupeIDsCmd = &cobra.Command{
+	Use:   "dedupe-ids [dir]",
+	Short: "Assign fresh IDs to DAG files with mismatched or colliding IDs",
+	Long: `Scan a DAG directory for files whose internal id does not match their filename
+or collides with the id of another file, and assign them a fresh id.
+Affected files are rewritten under their new filename.
+
+Examples:
+  jurigen dedupe-ids data
+  jurigen dedupe-ids data --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDedupeIDs,
+}
+
+// idChange describes the remediation applied to a DAG file
+type idChange struct {
+	File    string
+	OldID   uuid.UUID
+	NewID   uuid.UUID
+	NewFile string
+	Reason  string
+}
+
+func init() {
+	dedupeIDsCmd.Flags().BoolVar(&dedupeDryRun, "dry-run", false, "Report the changes without modifying any file")
+
+	rootCmd.AddCommand(dedupeIDsCmd)
+}
+
+func runDedupeIDs(cmd *cobra.Command, args []string) error {
+	changes, err := dedupeDAGIDs(args[0], dedupeDryRun, uuid.New)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("✅ No mismatched or colliding DAG IDs found")
+		return nil
+	}
+
+	if dedupeDryRun {
+		fmt.Printf("🔍 %d DAG file(s) would be re-identified (dry run):\n", len(changes))
+	} else {
+		fmt.Printf("🔧 %d DAG file(s) re-identified:\n", len(changes))
+	}
+	for _, change := range changes {
+		fmt.Printf("   %s (%s): %s -> %s (%s)\n", change.File, change.Reason, change.OldID, change.NewID, change.NewFile)
+	}
+
+	return nil
+}
+
+// dedupeDAGIDs assigns fresh IDs to DAG files whose ID does not match their filename or is
+// already used by another file. A file whose name matches its ID keeps it.
+func dedupeDAGIDs(dir string, dryRun bool, newID func() uuid.UUID) ([]idChange, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	dags := make(map[string]*model.DAG)
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", entry.Name(), err)
+		}
+
+		var dagData model.DAG
+		if err := json.Unmarshal(data, &dagData); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON from %s: %w", entry.Name(), err)
+		}
+
+		dags[entry.Name()] = &dagData
+		files = append(files, entry.Name())
+	}
+	sort.Strings(files)
+
+	// Files named after their ID claim it first
+	claimed := make(map[uuid.UUID]string)
+	for _, file := range files {
+		if matchesFilename(file, dags[file].Id) {
+			claimed[dags[file].Id] = file
+		}
+	}
+
+	var changes []idChange
+	for _, file := range files {
+		dagData := dags[file]
+		if claimed[dagData.Id] == file {
+			continue
+		}
+
+		reason := "id does not match filename"
+		if _, taken := claimed[dagData.Id]; taken {
+			reason = "id collides with " + claimed[dagData.Id]
+		}
+
+		change := idChange{File: file, OldID: dagData.Id, NewID: newID(), Reason: reason}
+		change.NewFile = change.NewID.String() + ".json"
+		claimed[change.NewID] = change.NewFile
+		changes = append(changes, change)
+
+		if dryRun {
+			continue
+		}
+
+		dagData.Id = change.NewID
+		data, err := dagData.MarshalJSON()
+		if err != nil {
+			return changes, fmt.Errorf("failed to marshal DAG from %s: %w", file, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, change.NewFile), data, 0644); err != nil {
+			return changes, fmt.Errorf("failed to write file %s: %w", change.NewFile, err)
+		}
+		if err := os.Remove(filepath.Join(dir, file)); err != nil {
+			return changes, fmt.Errorf("failed to remove file %s: %w", file, err)
+		}
+	}
+
+	return changes, nil
+}
+
+// matchesFilename reports whether the file is named after the given DAG ID
+func matchesFilename(file string, id uuid.UUID) bool {
+	fileID, err := uuid.Parse(strings.TrimSuffix(file, ".json"))
+	return err == nil && fileID == id
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeDAGIDs(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (string, uuid.UUID) {
+		dir := t.TempDir()
+		sharedID := uuid.New()
+
+		// Correctly named file owning the shared ID
+		writeDAGFile(t, dir, sharedID.String()+".json", sharedID, "Original")
+		// Imported copy colliding with the original
+		writeDAGFile(t, dir, uuid.New().String()+".json", sharedID, "Colliding copy")
+		// File whose name is not its ID
+		writeDAGFile(t, dir, "imported.json", uuid.New(), "Mismatched")
+
+		return dir, sharedID
+	}
+
+	newIDs := func() func() uuid.UUID {
+		ids := []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000001"), uuid.MustParse("00000000-0000-0000-0000-000000000002")}
+		return func() uuid.UUID {
+			id := ids[0]
+			ids = ids[1:]
+			return id
+		}
+	}
+
+	t.Run("re-identifies mismatched and colliding files", func(t *testing.T) {
+		t.Parallel()
+
+		dir, sharedID := setup(t)
+
+		changes, err := dedupeDAGIDs(dir, false, newIDs())
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+
+		reasons := []string{changes[0].Reason, changes[1].Reason}
+		assert.Contains(t, reasons, "id collides with "+sharedID.String()+".json")
+		assert.Contains(t, reasons, "id does not match filename")
+
+		// Original untouched, every remaining file named after its ID
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+
+		titles := make(map[string]bool)
+		for _, entry := range entries {
+			var d model.DAG
+			data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			require.NoError(t, err)
+			require.NoError(t, d.UnmarshalJSON(data))
+			assert.Equal(t, d.Id.String()+".json", entry.Name())
+			titles[d.Title] = true
+		}
+		assert.Equal(t, map[string]bool{"Original": true, "Colliding copy": true, "Mismatched": true}, titles)
+
+		// Running again finds nothing left to fix
+		changes, err = dedupeDAGIDs(dir, false, uuid.New)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("dry run does not modify files", func(t *testing.T) {
+		t.Parallel()
+
+		dir, _ := setup(t)
+		before, err := os.ReadDir(dir)
+		require.NoError(t, err)
+
+		changes, err := dedupeDAGIDs(dir, true, newIDs())
+		require.NoError(t, err)
+		assert.Len(t, changes, 2)
+
+		after, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+}
+
+func writeDAGFile(t *testing.T, dir string, name string, id uuid.UUID, title string) {
+	t.Helper()
+
+	d := model.NewDAG(title)
+	d.Id = id
+	data, err := d.MarshalJSON()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var duplicatesFormat string
+
+var findDuplicatesCmd = &cobra.Command{
+	Use:   "find-duplicates [dir]",
+	Short: "Group the DAG files of a directory sharing the same structure",
+	Long: `Compute a structural hash of every DAG file of a directory, ignoring IDs, titles and
+answer order, and report the clusters of files describing the same case tree so that
+redundant imports can be consolidated.
+
+Examples:
+  jurigen find-duplicates data
+  jurigen find-duplicates data --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFindDuplicates,
+}
+
+// duplicateCluster is a group of DAG files sharing the same structural hash
+type duplicateCluster struct {
+	Hash  string   `json:"hash"`
+	Files []string `json:"files"`
+}
+
+func init() {
+	findDuplicatesCmd.Flags().StringVar(&duplicatesFormat, "format", "text", "Output format: text, json")
+
+	rootCmd.AddCommand(findDuplicatesCmd)
+}
+
+func runFindDuplicates(cmd *cobra.Command, args []string) error {
+	clusters, total, err := findDuplicates(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch duplicatesFormat {
+	case "json":
+		jsonData, err := json.MarshalIndent(clusters, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal duplicate clusters: %w", err)
+		}
+		fmt.Println(string(jsonData))
+	default:
+		if len(clusters) == 0 {
+			fmt.Printf("✅ No duplicates among %d DAG file(s)\n", total)
+			return nil
+		}
+
+		fmt.Printf("🔍 %d cluster(s) of structurally identical DAGs among %d DAG file(s):\n", len(clusters), total)
+		for _, cluster := range clusters {
+			fmt.Printf("\n   %s\n", cluster.Hash[:12])
+			for _, file := range cluster.Files {
+				fmt.Printf("   - %s\n", file)
+			}
+		}
+	}
+
+	return nil
+}
+
+// findDuplicates groups the DAG files of dir by structural hash. It returns the clusters of at least
+// two files, ordered by their first file, and the number of DAG files scanned.
+func findDuplicates(dir string) ([]duplicateCluster, int, error) {
+	files, err := listDAGFiles(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filesByHash := make(map[string][]string)
+	for _, file := range files {
+		dagData, err := readDAGFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, len(files), err
+		}
+
+		hash, err := dagData.StructuralHash()
+		if err != nil {
+			return nil, len(files), fmt.Errorf("failed to hash DAG from %s: %w", file, err)
+		}
+		filesByHash[hash] = append(filesByHash[hash], file)
+	}
+
+	clusters := []duplicateCluster{}
+	for hash, clustered := range filesByHash {
+		if len(clustered) < 2 {
+			continue
+		}
+		clusters = append(clusters, duplicateCluster{Hash: hash, Files: clustered})
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Files[0] < clusters[j].Files[0]
+	})
+
+	return clusters, len(files), nil
+}
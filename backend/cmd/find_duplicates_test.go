@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("groups a DAG with its clone", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		original := dagtest.ValidSingleRoot()
+		data, err := original.MarshalJSON()
+		require.NoError(t, err)
+
+		other := dagtest.LinearChain(3)
+		otherData, err := other.MarshalJSON()
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "original.json"), data, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "clone.json"), []byte(withFreshIds(string(data), original)), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "other.json"), otherData, 0644))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "analytics"), 0755))
+
+		clusters, total, err := findDuplicates(dir)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, clusters, 1)
+		assert.Equal(t, []string{"clone.json", "original.json"}, clusters[0].Files)
+		assert.NotEmpty(t, clusters[0].Hash)
+	})
+
+	t.Run("reports no cluster for distinct DAGs", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		for name, d := range map[string]*model.DAG{
+			"single.json": dagtest.ValidSingleRoot(),
+			"chain.json":  dagtest.LinearChain(3),
+		} {
+			data, err := d.MarshalJSON()
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+		}
+
+		clusters, total, err := findDuplicates(dir)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Empty(t, clusters)
+	})
+
+	t.Run("rejects an unparsable file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644))
+
+		_, _, err := findDuplicates(dir)
+		assert.ErrorContains(t, err, "failed to parse JSON")
+	})
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateCount int
+	generateNodes int
+	generateOut   string
+	generateSeed  int64
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate random valid DAG files for load testing",
+	Long: `Generate random DAGs with a single root and no cycles, written as one file per DAG
+named after its ID, so that the file repository and the server can be benchmarked
+against realistic datasets. The same seed always generates the same DAGs.
+
+Examples:
+  jurigen generate --count 100 --out data/bench
+  jurigen generate --count 10 --nodes 50 --out data/bench --seed 42`,
+	Args: cobra.NoArgs,
+	RunE: runGenerate,
+}
+
+func init() {
+	generateCmd.Flags().IntVar(&generateCount, "count", 10, "Number of DAGs to generate")
+	generateCmd.Flags().IntVar(&generateNodes, "nodes", 20, "Approximate number of nodes per DAG")
+	generateCmd.Flags().StringVar(&generateOut, "out", "data/generated", "Directory to write the DAG files to")
+	generateCmd.Flags().Int64Var(&generateSeed, "seed", 0, "Random seed for reproducible datasets (defaults to the current time)")
+
+	rootCmd.AddCommand(generateCmd)
+}
+
+func runGenerate(cmd *cobra.Command, args []string) error {
+	seed := generateSeed
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+
+	files, err := generateDAGs(generateOut, generateCount, generateNodes, seed)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🎲 %d DAG file(s) generated in %s (seed %d)\n", len(files), generateOut, seed)
+	return nil
+}
+
+// generateDAGs writes count random DAGs of roughly nodes nodes each to dir and returns the file names written
+func generateDAGs(dir string, count int, nodes int, seed int64) ([]string, error) {
+	if count < 0 {
+		return nil, fmt.Errorf("count must not be negative, got %d", count)
+	}
+	if nodes < 1 {
+		return nil, fmt.Errorf("nodes must be at least 1, got %d", nodes)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	files := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		d, err := randomDAG(rng, fmt.Sprintf("Generated DAG %d", i+1), nodes)
+		if err != nil {
+			return files, err
+		}
+
+		data, err := d.MarshalCanonicalJSON()
+		if err != nil {
+			return files, fmt.Errorf("failed to marshal DAG %s: %w", d.Id, err)
+		}
+
+		file := d.Id.String() + ".json"
+		if err := os.WriteFile(filepath.Join(dir, file), data, 0644); err != nil {
+			return files, fmt.Errorf("failed to write file %s: %w", file, err)
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// randomDAG builds a DAG whose node count varies by up to a quarter around nodes.
+// Every node but the first is reached from an earlier one and answers only point
+// forward, which keeps a single root and no cycles.
+func randomDAG(rng *rand.Rand, title string, nodes int) (*model.DAG, error) {
+	size := nodes
+	if spread := nodes / 4; spread > 0 {
+		size += rng.Intn(2*spread+1) - spread
+	}
+
+	newID := func() (uuid.UUID, error) {
+		id, err := uuid.NewRandomFromReader(rng)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to generate ID: %w", err)
+		}
+		return id, nil
+	}
+
+	d := model.NewDAG(title)
+	dagID, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	d.Id = dagID
+
+	nodeIDs := make([]uuid.UUID, size)
+	for i := range nodeIDs {
+		if nodeIDs[i], err = newID(); err != nil {
+			return nil, err
+		}
+	}
+
+	answers := make([][]model.Answer, size)
+	addAnswer := func(from int, to *uuid.UUID) error {
+		id, err := newID()
+		if err != nil {
+			return err
+		}
+		statement := "Conclude"
+		if to != nil {
+			statement = fmt.Sprintf("Option %d", len(answers[from])+1)
+		}
+		answers[from] = append(answers[from], model.Answer{Id: id, Statement: statement, NextNode: to})
+		return nil
+	}
+
+	for i := 1; i < size; i++ {
+		if err := addAnswer(rng.Intn(i), &nodeIDs[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i < size; i++ {
+		if i+1 < size && rng.Intn(3) == 0 {
+			target := i + 1 + rng.Intn(size-i-1)
+			if err := addAnswer(i, &nodeIDs[target]); err != nil {
+				return nil, err
+			}
+		}
+		if len(answers[i]) == 0 || rng.Intn(2) == 0 {
+			if err := addAnswer(i, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i, id := range nodeIDs {
+		d.Nodes[id] = model.Node{
+			Id:       id,
+			Question: fmt.Sprintf("Question %d?", i+1),
+			Answers:  answers[i],
+		}
+	}
+
+	return d, nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/usecase"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDAGs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generates valid DAGs", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		files, err := generateDAGs(dir, 5, 12, 42)
+		require.NoError(t, err)
+		require.Len(t, files, 5)
+
+		validator := usecase.NewDAGValidator()
+		for _, file := range files {
+			d, err := readDAGFile(filepath.Join(dir, file))
+			require.NoError(t, err)
+
+			assert.Equal(t, d.Id.String()+".json", file)
+			assert.InDelta(t, 12, len(d.Nodes), 3)
+
+			result := validator.ValidateDAG(d)
+			assert.True(t, result.IsValid, "%s: %v", file, result.Errors)
+		}
+	})
+
+	t.Run("same seed generates the same DAGs", func(t *testing.T) {
+		t.Parallel()
+
+		first, second := t.TempDir(), t.TempDir()
+		firstFiles, err := generateDAGs(first, 3, 8, 7)
+		require.NoError(t, err)
+		secondFiles, err := generateDAGs(second, 3, 8, 7)
+		require.NoError(t, err)
+		require.Equal(t, firstFiles, secondFiles)
+
+		for _, file := range firstFiles {
+			firstData, err := os.ReadFile(filepath.Join(first, file))
+			require.NoError(t, err)
+			secondData, err := os.ReadFile(filepath.Join(second, file))
+			require.NoError(t, err)
+			assert.Equal(t, string(firstData), string(secondData))
+		}
+	})
+
+	t.Run("single node DAGs", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		files, err := generateDAGs(dir, 2, 1, 1)
+		require.NoError(t, err)
+
+		for _, file := range files {
+			d, err := readDAGFile(filepath.Join(dir, file))
+			require.NoError(t, err)
+			assert.Len(t, d.Nodes, 1)
+			assert.True(t, usecase.NewDAGValidator().ValidateDAG(d).IsValid)
+		}
+	})
+
+	t.Run("rejects invalid sizes", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := generateDAGs(t.TempDir(), 1, 0, 1)
+		assert.Error(t, err)
+
+		_, err = generateDAGs(t.TempDir(), -1, 5, 1)
+		assert.Error(t, err)
+	})
+}
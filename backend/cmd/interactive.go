@@ -2,17 +2,24 @@ package cmd
 
 import (
 	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
 var (
 	interactiveDagFile string
 	collectContext     bool
+	interactiveLocale  string
+	interactiveSave    string
+	interactiveResume  string
 )
 
 var interactiveCmd = &cobra.Command{
@@ -29,16 +36,15 @@ var interactiveCmd = &cobra.Command{
 			log.Fatalf("DAG file '%s' does not exist", interactiveDagFile)
 		}
 
-		// Load DAG from file
-		data, err := os.ReadFile(interactiveDagFile)
+		// Load DAG from the JSON or YAML file
+		d, err := readDAGFile(interactiveDagFile)
 		if err != nil {
-			log.Fatalf("error reading file '%s': %v", interactiveDagFile, err)
+			log.Fatalf("error loading DAG: %v", err)
 		}
 
-		var d = model.NewDAG("Interactive DAG")
-		err = d.UnmarshalJSON(data)
+		printer, err := newLocalePrinter(interactiveLocale)
 		if err != nil {
-			log.Fatalf("error unmarshalling file '%s': %v", interactiveDagFile, err)
+			log.Fatalf("invalid locale '%s': %v", interactiveLocale, err)
 		}
 
 		// Find the root node
@@ -47,75 +53,67 @@ var interactiveCmd = &cobra.Command{
 			log.Fatalf("error finding root node: %v", err)
 		}
 
+		state := model.NewWalkState(d, rootNode.Id)
+		if interactiveResume != "" {
+			state, err = readWalkState(interactiveResume)
+			if err != nil {
+				log.Fatalf("error loading session: %v", err)
+			}
+		}
+
 		fmt.Println("=== Interactive Legal Case Context Builder ===")
 		fmt.Println("Answer the following questions to build your case context.")
-		fmt.Println("Enter the number corresponding to your choice.")
+		fmt.Println("Enter the number corresponding to your choice, comma-separated numbers when several answers apply.")
+		fmt.Println("Type 'back' to answer the previous question again, 'restart' to start over or 'goto <n>' to go back to question n.")
 		fmt.Println()
 
 		// Choose the appropriate answer provider based on context flag
-		var answerProvider func(model.Node) (model.Answer, error)
+		var answerProvider func(model.Node) ([]model.Answer, error)
 		if collectContext {
-			answerProvider = model.CLIFnAnswerWithContext
+			answerProvider = model.CLIFnAnswersWithContext(model.DefaultPromptConfig())
 			fmt.Println("📝 Context collection enabled - you'll be prompted for additional details.")
 			fmt.Println()
 		} else {
-			answerProvider = model.CLIFnAnswer
+			answerProvider = model.CLIFnAnswers(model.DefaultPromptConfig())
 		}
 
-		// Use the DAG's Walk function with the selected answer provider
-		path, err := d.Walk(rootNode.Id, answerProvider)
+		// Resumed sessions keep being saved to the file they were resumed from unless saved elsewhere
+		savePath := interactiveSave
+		if savePath == "" {
+			savePath = interactiveResume
+		}
+		var onChange func(model.WalkState) error
+		if savePath != "" {
+			onChange = saveWalkState(savePath)
+			fmt.Printf("💾 Session saved to %s after each answer.\n\n", savePath)
+		}
+		if len(state.Answers) > 0 {
+			fmt.Printf("⏩ Resuming the session after %d answer(s).\n\n", len(state.Answers))
+		}
+
+		// Walk the DAG with the selected answer provider, multi-select questions accepting several answers
+		// and earlier answers being revised with the navigation commands
+		path, err := d.WalkSelectRevisable(&state, numberQuestions(answerProvider, &state), onChange)
 		if err != nil {
 			log.Fatalf("error walking through DAG: %v", err)
 		}
 
-		// Display the final context
-		fmt.Println("\n" + strings.Repeat("=", 60))
-		fmt.Println("CASE CONTEXT SUMMARY")
-		fmt.Println(strings.Repeat("=", 60))
-
-		for i, answer := range path {
-			fmt.Printf("%d. Q: %s\n", i+1, answer.ParentNode.Question)
-			fmt.Printf("   A: %s\n", answer.Statement)
-
-			// Display additional context if available
-			if answer.UserContext != "" {
-				fmt.Printf("   📝 Notes: %s\n", answer.UserContext)
-			}
-
-			if len(answer.Metadata) > 0 {
-				if conf, ok := answer.Metadata["confidence"].(float64); ok {
-					fmt.Printf("   📊 Confidence: %.1f/1.0\n", conf)
-				}
-				if tagsRaw, ok := answer.Metadata["tags"]; ok {
-					var tagStrs []string
-					switch tags := tagsRaw.(type) {
-					case []string:
-						tagStrs = tags
-					case []interface{}:
-						tagStrs = make([]string, len(tags))
-						for i, tag := range tags {
-							if tagStr, ok := tag.(string); ok {
-								tagStrs[i] = tagStr
-							}
-						}
-					}
-					if len(tagStrs) > 0 {
-						fmt.Printf("   🏷️  Tags: %s\n", strings.Join(tagStrs, ", "))
-					}
-				}
-			}
-
-			fmt.Println()
+		// Display the outcome the walk concluded with, if any, then the final context
+		if outcome, ok := d.ReachedOutcome(path); ok {
+			model.DefaultPromptConfig().RenderOutcome(os.Stdout, outcome)
 		}
-
-		fmt.Println(strings.Repeat("=", 60))
-		fmt.Printf("Context built successfully with %d question-answer pairs.\n", len(path))
+		writeCaseSummary(os.Stdout, printer, path)
+		writeScore(os.Stdout, printer, d.Score(path))
+		writeCoverage(os.Stdout, *d, d.Coverage(path))
 	},
 }
 
 func init() {
-	interactiveCmd.Flags().StringVarP(&interactiveDagFile, "dag", "d", "", "Path to the DAG JSON file (required)")
+	interactiveCmd.Flags().StringVarP(&interactiveDagFile, "dag", "d", "", "Path to the DAG JSON or YAML file (required)")
 	interactiveCmd.Flags().BoolVarP(&collectContext, "context", "c", false, "Collect additional context and metadata for each answer")
+	interactiveCmd.Flags().StringVar(&interactiveLocale, "locale", "C", "Locale used to format numbers in the summary (e.g. en, fr)")
+	interactiveCmd.Flags().StringVar(&interactiveSave, "save", "", "Save the session to this JSON file after each answer, to resume it later")
+	interactiveCmd.Flags().StringVar(&interactiveResume, "resume", "", "Resume the session saved in this JSON file, which keeps being saved unless --save is set")
 	err := interactiveCmd.MarkFlagRequired("dag")
 	if err != nil {
 		log.Fatalf("error marking flag as required: %v", err)
@@ -123,3 +121,138 @@ func init() {
 
 	rootCmd.AddCommand(interactiveCmd)
 }
+
+// numberQuestions prints the number of each question before answerProvider asks it, the number goto goes back to
+func numberQuestions(answerProvider func(model.Node) ([]model.Answer, error), state *model.WalkState) func(model.Node) ([]model.Answer, error) {
+	return func(node model.Node) ([]model.Answer, error) {
+		fmt.Printf("[%d] ", state.Steps()+1)
+		return answerProvider(node)
+	}
+}
+
+// saveWalkState returns the callback saving the walk state to path each time it changes
+func saveWalkState(path string) func(model.WalkState) error {
+	return func(state model.WalkState) error {
+		return writeWalkState(path, state)
+	}
+}
+
+// readWalkState reads a walk state saved by writeWalkState
+func readWalkState(path string) (model.WalkState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return model.WalkState{}, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	var state model.WalkState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return model.WalkState{}, fmt.Errorf("failed to parse JSON from %s: %w", path, err)
+	}
+
+	return state, nil
+}
+
+// writeWalkState saves a walk state as JSON
+func writeWalkState(path string, state model.WalkState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// newLocalePrinter returns a printer formatting numbers for the given locale,
+// "C" and empty locales fall back to English
+func newLocalePrinter(locale string) (*message.Printer, error) {
+	if locale == "" || locale == "C" {
+		return message.NewPrinter(language.English), nil
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	return message.NewPrinter(tag), nil
+}
+
+// writeCaseSummary prints the answered path as a case context summary
+func writeCaseSummary(w io.Writer, p *message.Printer, path []model.Answer) {
+	fmt.Fprintln(w, "\n"+strings.Repeat("=", 60))
+	fmt.Fprintln(w, "CASE CONTEXT SUMMARY")
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+
+	for i, answer := range path {
+		if answer.ParentNode != nil {
+			fmt.Fprintf(w, "%d. Q: %s\n", i+1, answer.ParentNode.Question)
+		}
+		fmt.Fprintf(w, "   A: %s\n", answer.Statement)
+
+		// Display additional context if available
+		if answer.UserContext != "" {
+			fmt.Fprintf(w, "   📝 Notes: %s\n", answer.UserContext)
+		}
+
+		if len(answer.Metadata) > 0 {
+			if conf, ok := answer.Metadata["confidence"].(float64); ok {
+				fmt.Fprintf(w, "   📊 Confidence: %s\n", formatConfidence(p, conf))
+			}
+			if tagStrs := metadataTags(answer.Metadata); len(tagStrs) > 0 {
+				fmt.Fprintf(w, "   🏷️  Tags: %s\n", strings.Join(tagStrs, ", "))
+			}
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+	fmt.Fprintf(w, "Context built successfully with %d question-answer pairs.\n", len(path))
+}
+
+// writeCoverage lists the required questions the walk missed, if any
+func writeCoverage(w io.Writer, d model.DAG, report model.CoverageReport) {
+	if len(report.MissedRequired) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "⚠️  %d required question(s) not answered:\n", len(report.MissedRequired))
+	for _, nodeId := range report.MissedRequired {
+		fmt.Fprintf(w, "   - %s\n", d.Nodes[nodeId].Question)
+	}
+}
+
+// writeScore prints the score of the answered path per dimension, nothing when no answer contributes to a score
+func writeScore(w io.Writer, p *message.Printer, score model.Score) {
+	if len(score.Dimensions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "📈 Scores:")
+	for _, dimension := range score.Dimensions {
+		label := dimension.Label
+		if label == "" {
+			label = dimension.Name
+		}
+		line := p.Sprintf("   - %s: %.1f", label, dimension.Value)
+		if dimension.Band != "" {
+			line += fmt.Sprintf(" (%s)", dimension.Band)
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w, p.Sprintf("   Total: %.1f", score.Total))
+}
+
+// formatConfidence formats a confidence score using the printer's locale
+func formatConfidence(p *message.Printer, confidence float64) string {
+	return p.Sprintf("%.1f/%.1f", confidence, 1.0)
+}
+
+// metadataTags extracts tags from answer metadata, whether decoded from JSON or set in code
+func metadataTags(metadata map[string]interface{}) []string {
+	return model.MetadataStrings(metadata, "tags")
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatConfidence(t *testing.T) {
+	t.Parallel()
+
+	en, err := newLocalePrinter("en")
+	require.NoError(t, err)
+	fr, err := newLocalePrinter("fr")
+	require.NoError(t, err)
+	c, err := newLocalePrinter("C")
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.9/1.0", formatConfidence(en, 0.9))
+	assert.Equal(t, "0,9/1,0", formatConfidence(fr, 0.9))
+	assert.Equal(t, formatConfidence(en, 0.9), formatConfidence(c, 0.9))
+}
+
+func TestNewLocalePrinter_InvalidLocale(t *testing.T) {
+	t.Parallel()
+
+	_, err := newLocalePrinter("not a locale!")
+	assert.Error(t, err)
+}
+
+func TestWriteCaseSummary(t *testing.T) {
+	t.Parallel()
+
+	node := model.Node{Id: uuid.New(), Question: "Were you dismissed?"}
+	path := []model.Answer{
+		{
+			Id:          uuid.New(),
+			Statement:   "Yes",
+			ParentNode:  &node,
+			UserContext: "Last Friday",
+			Metadata: map[string]interface{}{
+				"confidence": 0.8,
+				"tags":       []interface{}{"dismissal", "urgent"},
+			},
+		},
+	}
+
+	fr, err := newLocalePrinter("fr")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	writeCaseSummary(&buf, fr, path)
+
+	out := buf.String()
+	assert.Contains(t, out, "1. Q: Were you dismissed?")
+	assert.Contains(t, out, "A: Yes")
+	assert.Contains(t, out, "Notes: Last Friday")
+	assert.Contains(t, out, "Confidence: 0,8/1,0")
+	assert.Contains(t, out, "Tags: dismissal, urgent")
+	assert.Contains(t, out, "with 1 question-answer pairs")
+}
+
+func TestWriteScore(t *testing.T) {
+	t.Parallel()
+
+	fr, err := newLocalePrinter("fr")
+	require.NoError(t, err)
+
+	t.Run("lists the score of each dimension", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		writeScore(&buf, fr, model.Score{
+			Dimensions: []model.DimensionScore{
+				{Name: "claim_strength", Label: "Claim strength", Value: 7.5, Band: "strong"},
+				{Name: "evidence", Value: 2},
+			},
+			Total: 9.5,
+		})
+		out := buf.String()
+		assert.Contains(t, out, "- Claim strength: 7,5 (strong)")
+		assert.Contains(t, out, "- evidence: 2,0\n")
+		assert.Contains(t, out, "Total: 9,5")
+	})
+
+	t.Run("stays silent without dimensions", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		writeScore(&buf, fr, model.Score{})
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestWriteCoverage(t *testing.T) {
+	t.Parallel()
+
+	requiredId := uuid.New()
+	d := model.DAG{Nodes: map[uuid.UUID]model.Node{
+		requiredId: {Id: requiredId, Question: "Did you sign the contract?", Required: true},
+	}}
+
+	t.Run("lists the missed required questions", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		writeCoverage(&buf, d, model.CoverageReport{MissedRequired: []uuid.UUID{requiredId}})
+		assert.Contains(t, buf.String(), "1 required question(s) not answered")
+		assert.Contains(t, buf.String(), "- Did you sign the contract?")
+	})
+
+	t.Run("stays silent when nothing was missed", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		writeCoverage(&buf, d, model.CoverageReport{Answered: []uuid.UUID{requiredId}})
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestSaveWalkState(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	root := dagtest.Root(d)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	state := model.NewWalkState(d, root.Id)
+	_, err := d.WalkSelectRevisable(&state, func(node model.Node) ([]model.Answer, error) {
+		if node.Id != root.Id {
+			return nil, errors.New("interrupted")
+		}
+		return node.Answers[:1], nil
+	}, saveWalkState(path))
+	require.ErrorContains(t, err, "interrupted")
+
+	saved, err := readWalkState(path)
+	require.NoError(t, err)
+	assert.Equal(t, d.Id, saved.DAGId)
+	require.Len(t, saved.Answers, 1)
+	assert.Equal(t, root.Answers[0].Id, saved.Answers[0].AnswerId)
+
+	resumed, err := d.WalkSelectFrom(saved, func(node model.Node) ([]model.Answer, error) {
+		assert.NotEqual(t, root.Id, node.Id, "the saved answer is not asked again")
+		return node.Answers[:1], nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, root.Answers[0].Id, resumed[0].Id)
+}
+
+func TestReadWalkState_InvalidFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := readWalkState(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read file")
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0644))
+	_, err = readWalkState(path)
+	assert.ErrorContains(t, err, "failed to parse JSON")
+}
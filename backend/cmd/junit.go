@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// junitTestSuite is the root element of a JUnit XML report
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// renderJUnitReport renders a validation result as a JUnit testsuite:
+// every error is a failed testcase, every warning a skipped one, and a valid DAG without warnings yields a single passing testcase
+func renderJUnitReport(suiteName string, result usecase.ValidationResult) ([]byte, error) {
+	suite := junitTestSuite{Name: suiteName}
+
+	for _, e := range result.Errors {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      junitTestCaseName(e.Code, e.NodeID, e.AnswerID),
+			ClassName: suiteName,
+			Failure:   &junitMessage{Message: e.Message, Type: e.Code, Text: e.Message},
+		})
+		suite.Failures++
+	}
+
+	for _, w := range result.Warnings {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      junitTestCaseName(w.Code, w.NodeID, w.AnswerID),
+			ClassName: suiteName,
+			Skipped:   &junitMessage{Message: w.Message},
+		})
+		suite.Skipped++
+	}
+
+	// Validation walks nodes in map order, sort for a stable report
+	sort.SliceStable(suite.TestCases, func(i, j int) bool {
+		return suite.TestCases[i].Name < suite.TestCases[j].Name
+	})
+
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{Name: "DAG_VALID", ClassName: suiteName})
+	}
+	suite.Tests = len(suite.TestCases)
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// junitTestCaseName identifies a testcase by rule code and the node and answer it applies to
+func junitTestCaseName(code string, nodeID string, answerID string) string {
+	parts := []string{code}
+	if nodeID != "" {
+		parts = append(parts, "node="+nodeID)
+	}
+	if answerID != "" {
+		parts = append(parts, "answer="+answerID)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func outputJUnitResults(filePath string, result usecase.ValidationResult) error {
+	data, err := renderJUnitReport(filePath, result)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(data))
+	return nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestRenderJUnitReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid DAG matches golden file", func(t *testing.T) {
+		t.Parallel()
+
+		rootID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		leafID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+		missingID := uuid.MustParse("00000000-0000-0000-0000-0000000000ff")
+
+		d := &model.DAG{
+			Id:    uuid.MustParse("00000000-0000-0000-0000-0000000000aa"),
+			Title: "Invalid DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {
+					Id:       rootID,
+					Question: "",
+					Answers: []model.Answer{
+						{Id: uuid.MustParse("00000000-0000-0000-0000-000000000011"), Statement: "Yes", NextNode: &leafID},
+						{Id: uuid.MustParse("00000000-0000-0000-0000-000000000012"), Statement: "Maybe", NextNode: &missingID},
+						{Id: uuid.MustParse("00000000-0000-0000-0000-000000000013"), Statement: ""},
+					},
+				},
+				leafID: {Id: leafID, Question: "Done?", Answers: []model.Answer{}},
+			},
+		}
+
+		data, err := renderJUnitReport("invalid.json", usecase.NewDAGValidator().ValidateDAG(d))
+		require.NoError(t, err)
+
+		golden := filepath.Join("testdata", "junit_invalid.golden")
+		if *updateGolden {
+			require.NoError(t, os.WriteFile(golden, data, 0644))
+		}
+
+		expected, err := os.ReadFile(golden)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(data))
+	})
+
+	t.Run("valid DAG yields a single passing testcase", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := renderJUnitReport("valid.json", usecase.ValidationResult{IsValid: true})
+		require.NoError(t, err)
+
+		assert.Contains(t, string(data), `<testsuite name="valid.json" tests="1" failures="0" skipped="0">`)
+		assert.Contains(t, string(data), `<testcase name="DAG_VALID" classname="valid.json"></testcase>`)
+	})
+}
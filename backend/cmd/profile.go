@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadValidationProfile reads a validation profile from a JSON or YAML file,
+// falling back to the default profile when no path is given
+func loadValidationProfile(path string) (usecase.ValidationProfile, error) {
+	if path == "" {
+		return usecase.DefaultValidationProfile(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return usecase.ValidationProfile{}, fmt.Errorf("failed to read validation profile %s: %w", path, err)
+	}
+
+	var profile usecase.ValidationProfile
+	if isYAMLFile(path) {
+		err = yaml.Unmarshal(data, &profile)
+	} else {
+		err = json.Unmarshal(data, &profile)
+	}
+	if err != nil {
+		return usecase.ValidationProfile{}, fmt.Errorf("failed to parse validation profile %s: %w", path, err)
+	}
+
+	if err := profile.Check(); err != nil {
+		return usecase.ValidationProfile{}, fmt.Errorf("invalid validation profile %s: %w", path, err)
+	}
+
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/usecase"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadValidationProfile(t *testing.T) {
+	t.Parallel()
+
+	expected := usecase.ValidationProfile{
+		Name:             "legal",
+		MaxNodes:         50,
+		RequireTree:      true,
+		AllowedTags:      []string{"contract", "tort"},
+		RequiredMetadata: []string{"evidence"},
+		MinConfidence:    0.5,
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected usecase.ValidationProfile
+	}{
+		{
+			name:     "json profile",
+			file:     "legal.json",
+			content:  `{"name":"legal","max_nodes":50,"require_tree":true,"allowed_tags":["contract","tort"],"required_metadata":["evidence"],"min_confidence":0.5}`,
+			expected: expected,
+		},
+		{
+			name: "yaml profile",
+			file: "legal.yaml",
+			content: `name: legal
+max_nodes: 50
+require_tree: true
+allowed_tags: [contract, tort]
+required_metadata: [evidence]
+min_confidence: 0.5
+`,
+			expected: expected,
+		},
+		{
+			name:     "profile named after its file",
+			file:     "forest.json",
+			content:  `{"allow_forest":true}`,
+			expected: usecase.ValidationProfile{Name: "forest", AllowForest: true},
+		},
+		{
+			name: "rule overrides",
+			file: "draft.yaml",
+			content: `rules:
+  DAG_MULTIPLE_ROOTS: warning
+  NODE_SINGLE_ANSWER: off
+`,
+			expected: usecase.ValidationProfile{Name: "draft", Rules: map[string]usecase.Severity{
+				"DAG_MULTIPLE_ROOTS": usecase.SeverityWarning,
+				"NODE_SINGLE_ANSWER": usecase.SeverityOff,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			profile, err := loadValidationProfile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, profile)
+		})
+	}
+
+	t.Run("default profile without path", func(t *testing.T) {
+		t.Parallel()
+
+		profile, err := loadValidationProfile("")
+		require.NoError(t, err)
+		assert.Equal(t, usecase.DefaultValidationProfile(), profile)
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "broken.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+		_, err := loadValidationProfile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "draft.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"rules":{"DAG_TOO_COLORFUL":"warning"}}`), 0600))
+
+		_, err := loadValidationProfile(path)
+		assert.ErrorContains(t, err, `unknown validation rule "DAG_TOO_COLORFUL"`)
+	})
+}
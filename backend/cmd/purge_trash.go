@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeRetention time.Duration
+	purgeDryRun    bool
+)
+
+var purgeTrashCmd = &cobra.Command{
+	Use:   "purge-trash [dir]",
+	Short: "Permanently remove the deleted DAGs of a directory kept in the trash too long",
+	Long: `Remove the DAG files of a directory deleted for longer than the retention,
+along with their walk analytics and versions. Deleted DAGs stay in the trash,
+restorable through the API, until they are purged.
+
+Examples:
+  jurigen purge-trash data
+  jurigen purge-trash data --retention 168h
+  jurigen purge-trash data --retention 0 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPurgeTrash,
+}
+
+func init() {
+	purgeTrashCmd.Flags().DurationVar(&purgeRetention, "retention", 30*24*time.Hour, "Purge the DAGs kept in the trash for longer than this duration")
+	purgeTrashCmd.Flags().BoolVar(&purgeDryRun, "dry-run", false, "Report the DAGs to purge without removing them")
+
+	rootCmd.AddCommand(purgeTrashCmd)
+}
+
+func runPurgeTrash(cmd *cobra.Command, args []string) error {
+	purged, err := purgeTrash(cmd.Context(), args[0], purgeRetention, purgeDryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(purged) == 0 {
+		fmt.Printf("✅ No deleted DAG kept longer than %s\n", purgeRetention)
+		return nil
+	}
+
+	if purgeDryRun {
+		fmt.Printf("🔍 %d deleted DAG(s) would be purged (dry run):\n", len(purged))
+	} else {
+		fmt.Printf("🗑️  %d deleted DAG(s) purged:\n", len(purged))
+	}
+	for _, id := range purged {
+		fmt.Printf("   %s\n", id)
+	}
+
+	return nil
+}
+
+// purgeTrash purges the deleted DAGs of dir laid out like the server stores them,
+// with the walk analytics in a sidecar directory and the versions next to the DAG files
+func purgeTrash(ctx context.Context, dir string, retention time.Duration, dryRun bool) ([]uuid.UUID, error) {
+	purge := usecase.NewPurgeDeletedDAGsUseCase(
+		port.NewFileDAGRepository(dir).WithLocking(),
+		port.NewFileWalkAnalyticsRepository(filepath.Join(dir, "analytics")),
+		port.NewFileDAGVersionRepository(dir),
+	)
+
+	return purge.Execute(ctx, usecase.CmdPurgeDeletedDAGs{
+		Retention: retention,
+		DryRun:    dryRun,
+	})
+}
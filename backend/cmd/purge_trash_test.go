@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeTrash(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (string, uuid.UUID, uuid.UUID) {
+		dir := t.TempDir()
+
+		live := dagtest.ValidSingleRoot()
+		trashed := dagtest.LinearChain(3)
+		deletedAt := time.Now().Add(-48 * time.Hour)
+		trashed.DeletedAt = &deletedAt
+
+		write := func(id uuid.UUID, data []byte) {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, id.String()+".json"), data, 0644))
+		}
+		data, err := live.MarshalJSON()
+		require.NoError(t, err)
+		write(live.Id, data)
+		data, err = trashed.MarshalJSON()
+		require.NoError(t, err)
+		write(trashed.Id, data)
+
+		return dir, live.Id, trashed.Id
+	}
+
+	t.Run("purges the DAGs past the retention", func(t *testing.T) {
+		t.Parallel()
+
+		dir, live, trashed := setup(t)
+
+		purged, err := purgeTrash(context.Background(), dir, 24*time.Hour, false)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{trashed}, purged)
+		assert.NoFileExists(t, filepath.Join(dir, trashed.String()+".json"))
+		assert.FileExists(t, filepath.Join(dir, live.String()+".json"))
+	})
+
+	t.Run("keeps the DAGs within the retention", func(t *testing.T) {
+		t.Parallel()
+
+		dir, _, trashed := setup(t)
+
+		purged, err := purgeTrash(context.Background(), dir, 72*time.Hour, false)
+		require.NoError(t, err)
+		assert.Empty(t, purged)
+		assert.FileExists(t, filepath.Join(dir, trashed.String()+".json"))
+	})
+
+	t.Run("leaves the files untouched on dry runs", func(t *testing.T) {
+		t.Parallel()
+
+		dir, _, trashed := setup(t)
+
+		purged, err := purgeTrash(context.Background(), dir, 0, true)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{trashed}, purged)
+		assert.FileExists(t, filepath.Join(dir, trashed.String()+".json"))
+	})
+}
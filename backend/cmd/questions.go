@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var questionsFormat string
+
+var questionsCmd = &cobra.Command{
+	Use:   "questions [path]",
+	Short: "Extract all questions of a DAG file as a flat question bank",
+	Long: `Extract every node question of a DAG file as a flat, sorted question bank.
+
+Examples:
+  jurigen questions data/my-dag.json
+  jurigen questions data/my-dag.json --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: extractQuestions,
+}
+
+// questionEntry is the JSON representation of a question in the question bank
+type questionEntry struct {
+	NodeID   string `json:"node_id"`
+	Question string `json:"question"`
+}
+
+func init() {
+	questionsCmd.Flags().StringVar(&questionsFormat, "format", "text", "Output format: text, json")
+
+	rootCmd.AddCommand(questionsCmd)
+}
+
+func extractQuestions(cmd *cobra.Command, args []string) error {
+	filePath := args[0]
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var dagData model.DAG
+	if err := json.Unmarshal(data, &dagData); err != nil {
+		return fmt.Errorf("failed to parse JSON from %s: %w", filePath, err)
+	}
+
+	switch questionsFormat {
+	case "json":
+		jsonData, err := json.MarshalIndent(questionEntries(dagData), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal questions: %w", err)
+		}
+		fmt.Println(string(jsonData))
+	default:
+		for _, question := range dagData.Questions() {
+			fmt.Println(question)
+		}
+	}
+
+	return nil
+}
+
+// questionEntries lists one entry per node, sorted by question then node ID
+func questionEntries(d model.DAG) []questionEntry {
+	entries := make([]questionEntry, 0, len(d.Nodes))
+	for _, node := range d.Nodes {
+		entries = append(entries, questionEntry{
+			NodeID:   node.Id.String(),
+			Question: node.Question,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Question != entries[j].Question {
+			return entries[i].Question < entries[j].Question
+		}
+		return entries[i].NodeID < entries[j].NodeID
+	})
+
+	return entries
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xredis"
+
+	"github.com/rs/zerolog"
+)
+
+// DAG storages selected by --repository
+const (
+	repositoryFile   = "file"
+	repositoryMemory = "memory"
+)
+
+// cacheRedis caches the DAG reads in Redis when selected by --cache
+const cacheRedis = "redis"
+
+// repositoryConfig gathers the settings of the DAG repository, resolved from the flags, the environment and the
+// config file
+type repositoryConfig struct {
+	Kind          string
+	Path          string
+	WriteThrough  bool
+	Locking       bool
+	Cache         string
+	CacheTTL      time.Duration
+	RedisAddr     string
+	RedisPassword string
+}
+
+// dagStores builds the storages selectable by --repository, which the hybrid repository serves from memory.
+// A new backend only needs an entry here. The SQL backends are not available yet, see the README.
+var dagStores = map[string]func(config repositoryConfig) (port.PersistentDAGRepository, error){
+	repositoryFile: func(config repositoryConfig) (port.PersistentDAGRepository, error) {
+		files := port.NewFileDAGRepository(config.Path)
+		if config.Locking {
+			files.WithLocking()
+		}
+		return files, nil
+	},
+	// Nothing outlives the process, e.g. for demos and tests
+	repositoryMemory: func(repositoryConfig) (port.PersistentDAGRepository, error) {
+		return port.NewInMemoryDAGRepository(), nil
+	},
+}
+
+// dagRepository is the DAG repository of the server: the hybrid repository serving the DAGs of the storage from
+// memory, optionally behind a cache shared by the server replicas
+type dagRepository struct {
+	// dags serves the use cases
+	dags   usecase.DAGRepository
+	hybrid *port.HybridDAGRepository
+	// cached is the shared cache, nil when disabled, it must be run to receive the changes of the other replicas
+	cached  *port.RedisCachedDAGRepository
+	closers []func()
+}
+
+// openDAGRepository builds the DAG repository described by config and loads the DAGs into memory
+func openDAGRepository(ctx context.Context, config repositoryConfig, logger zerolog.Logger) (_ *dagRepository, err error) {
+	newStore, ok := dagStores[config.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown repository %q, expected one of %v", config.Kind, repositoryKinds())
+	}
+	if config.Cache != "" && config.Cache != cacheRedis {
+		return nil, fmt.Errorf("unknown cache %q, expected %s or none", config.Cache, cacheRedis)
+	}
+
+	repo := &dagRepository{}
+	defer func() {
+		if err != nil {
+			repo.Close()
+		}
+	}()
+
+	// Own the DAG directory, so that a second server does not serve diverging copies of the DAGs
+	if config.Locking {
+		lock, err := port.LockDirectory(config.Path)
+		if err != nil {
+			logger.Error().Err(err).Str("dag_path", config.Path).Msg("Failed to lock DAG directory")
+			return nil, fmt.Errorf("failed to lock DAG directory: %w", err)
+		}
+		if lock.Stale != nil {
+			logger.Warn().Str("holder", lock.Stale.String()).Msg("Took over a stale DAG directory lock, the previous server did not stop cleanly")
+		}
+		repo.closers = append(repo.closers, func() {
+			if err := lock.Release(); err != nil {
+				logger.Warn().Err(err).Msg("Failed to release DAG directory lock")
+			}
+		})
+	}
+
+	store, err := newStore(config)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.hybrid = port.NewHybridDAGRepository(port.HybridDAGRepositoryConfig{
+		FilePath:     config.Path,
+		WriteThrough: config.WriteThrough,
+		Logger:       &logger,
+		Store:        store,
+	})
+	repo.dags = repo.hybrid
+
+	// Initialize repository (load DAGs from the storage into memory)
+	logger.Info().Msg("Initializing hybrid repository...")
+	loadFailures, err := repo.hybrid.Initialize(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to initialize hybrid repository")
+		return nil, fmt.Errorf("failed to initialize hybrid repository: %w", err)
+	}
+	for _, failure := range loadFailures {
+		logger.Warn().
+			Str("dag_id", failure.DAGId.String()).
+			Str("file", failure.File).
+			Str("quarantined_to", failure.QuarantinedTo).
+			Err(failure.Err).
+			Msg("DAG file could not be loaded and is not served")
+	}
+
+	// Display repository statistics
+	stats, err := repo.hybrid.GetStats(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to get repository stats")
+	} else {
+		logger.Info().
+			Int("memory_dags", stats.MemoryDAGCount).
+			Int("file_dags", stats.FileDAGCount).
+			Bool("write_through", stats.WriteThrough).
+			Msg("Repository initialized successfully")
+	}
+
+	// DAG reads are cached in Redis, the writes of the other servers sharing the cache are reloaded into memory
+	if config.Cache == cacheRedis {
+		redisClient := xredis.New(config.RedisAddr, xredis.Options{Password: config.RedisPassword})
+		repo.closers = append(repo.closers, func() { _ = redisClient.Close() })
+		repo.cached = port.NewRedisCachedDAGRepository(repo.hybrid, redisClient, config.CacheTTL, logger)
+		repo.dags = repo.cached
+
+		logger.Info().Str("redis_addr", config.RedisAddr).Dur("ttl", config.CacheTTL).Msg("Caching DAG reads in Redis")
+	}
+
+	return repo, nil
+}
+
+// Close releases the resources of the repository, in the reverse order of their acquisition
+func (r *dagRepository) Close() {
+	for _, closeFn := range slices.Backward(r.closers) {
+		closeFn()
+	}
+}
+
+func repositoryKinds() []string {
+	kinds := make([]string, 0, len(dagStores))
+	for kind := range dagStores {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenDAGRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves the DAG files from memory", func(t *testing.T) {
+		dir := t.TempDir()
+		stored := dagtest.ValidSingleRoot()
+		data, err := stored.MarshalJSON()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, stored.Id.String()+".json"), data, 0644))
+
+		repo, err := openDAGRepository(ctx, repositoryConfig{Kind: repositoryFile, Path: dir, WriteThrough: true, Locking: true}, zerolog.Nop())
+		require.NoError(t, err)
+		defer repo.Close()
+
+		loaded, err := repo.dags.Get(ctx, stored.Id)
+		require.NoError(t, err)
+		assert.Equal(t, stored.Title, loaded.Title)
+
+		_, err = openDAGRepository(ctx, repositoryConfig{Kind: repositoryFile, Path: dir, Locking: true}, zerolog.Nop())
+		assert.ErrorContains(t, err, "failed to lock DAG directory", "the directory is owned by the first repository")
+	})
+
+	t.Run("keeps the DAGs in memory only", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := openDAGRepository(ctx, repositoryConfig{Kind: repositoryMemory, Path: dir, WriteThrough: true}, zerolog.Nop())
+		require.NoError(t, err)
+		defer repo.Close()
+
+		require.NoError(t, repo.dags.Create(ctx, dagtest.ValidSingleRoot()))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("rejects unknown backends", func(t *testing.T) {
+		_, err := openDAGRepository(ctx, repositoryConfig{Kind: "postgres"}, zerolog.Nop())
+		assert.ErrorContains(t, err, "expected one of [file memory]")
+
+		_, err = openDAGRepository(ctx, repositoryConfig{Kind: repositoryFile, Path: t.TempDir(), Cache: "memcached"}, zerolog.Nop())
+		assert.ErrorContains(t, err, `unknown cache "memcached"`)
+	})
+}
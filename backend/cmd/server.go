@@ -6,24 +6,88 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
+	"sync"
 	"syscall"
+	"time"
 
 	pkg "davidterranova/jurigen/backend/internal"
 	"davidterranova/jurigen/backend/internal/adapter/http"
+	"davidterranova/jurigen/backend/internal/adapter/llm"
+	"davidterranova/jurigen/backend/internal/eventbus"
+	"davidterranova/jurigen/backend/internal/job"
 	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/internal/webhook"
+	"davidterranova/jurigen/backend/pkg/buildinfo"
 	"davidterranova/jurigen/backend/pkg/xhttp"
 
-	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // Server configuration flags
 var (
-	dagPath        string
-	writeThrough   bool
-	syncOnShutdown bool
-	address        string
+	serverConfig    string
+	dagPath         string
+	repositoryKind  string
+	cacheKind       string
+	cacheTTL        time.Duration
+	redisAddr       string
+	redisPassword   string
+	writeThrough    bool
+	syncOnShutdown  bool
+	locking         bool
+	syncInterval    time.Duration
+	shutdownTimeout time.Duration
+	address         string
+	serverProfile   string
+	serverUsers     string
+
+	corsAllowedOrigins []string
+	logLevel           string
+	logFormat          string
+
+	autoValidateInterval time.Duration
+	maxConcurrentWalks   int
+	preserveWhitespace   bool
+	responseCacheSize    int
+	trashRetention       time.Duration
+
+	llmProvider string
+	llmModel    string
+	llmAPIKey   string
+	llmBaseURL  string
+	llmTimeout  time.Duration
+
+	webhookMaxAttempts int
+	webhookTimeout     time.Duration
+
+	jobWorkers   int
+	jobQueueSize int
+
+	attachmentMaxSize      int64
+	attachmentContentTypes []string
+
+	rateLimitDAGs     float64
+	rateLimitSessions float64
+	rateLimitWebhooks float64
+	rateLimitBurst    int
+)
+
+// trashPurgeInterval is how often the DAGs kept in the trash longer than the retention are purged
+const trashPurgeInterval = time.Hour
+
+// envRedisPassword is the Redis password when --redis-password is not set
+const envRedisPassword = "JURIGEN_REDIS_PASSWORD"
+
+// Environment variables configuring the LLM provider when the matching flag is not set
+const (
+	envLLMProvider = "JURIGEN_LLM_PROVIDER"
+	envLLMModel    = "JURIGEN_LLM_MODEL"
+	envLLMAPIKey   = "JURIGEN_LLM_API_KEY"
+	envLLMBaseURL  = "JURIGEN_LLM_BASE_URL"
 )
 
 var serverCmd = &cobra.Command{
@@ -35,64 +99,135 @@ The hybrid repository:
 - Loads DAGs from files at startup for persistence
 - Serves DAGs from memory for fast runtime access  
 - Optionally writes changes back to files for durability
-- Provides statistics and sync capabilities`,
+- Provides statistics and sync capabilities
+
+Each flag not set on the command line is read from its JURIGEN_ environment variable, e.g. JURIGEN_DAG_PATH
+for --dag-path, otherwise from the --config file (JSON or YAML). The keys of the config file are the flag
+names, grouped in the server, repository, attachments, auth, cors, logging, llm, webhooks and jobs sections or at
+the top level.
+Run "jurigen config print-effective" with the same flags to see the merged configuration.`,
 	Example: `  # Start server with write-through enabled (changes immediately persisted)
   jurigen server --dag-path ./data --write-through
 
-  # Start server with write-through disabled (manual sync required)
-  jurigen server --dag-path ./data --write-through=false
+  # Start server with write-through disabled, changed DAGs persisted every minute
+  jurigen server --dag-path ./data --write-through=false --sync-interval 1m
 
   # Start server with custom address and sync-on-shutdown
-  jurigen server --dag-path ./data --address :8081 --sync-on-shutdown`,
+  jurigen server --dag-path ./data --address :8081 --sync-on-shutdown
+
+  # Start server replicas sharing a Redis cache of the DAG reads, kept consistent on every write
+  jurigen server --dag-path /mnt/shared/dags --locking=false --cache redis --redis-addr redis:6379
+
+  # Start server configured by a file, the environment overriding its settings
+  JURIGEN_WRITE_THROUGH=false jurigen server --config server.yaml
+
+  # Start server validating DAGs against a domain specific profile
+  jurigen server --dag-path ./data --profile legal.yaml
+
+  # Start server authenticating users declared with their viewer, editor or admin role
+  jurigen server --dag-path ./data --users users.yaml
+
+  # Start server allowing each client 10 DAG requests per second, in bursts of up to 20
+  jurigen server --dag-path ./data --rate-limit-dags 10 --rate-limit-burst 20
+
+  # Start server re-validating changed DAGs every minute
+  jurigen server --dag-path ./data --auto-validate-interval 1m
+
+  # Start server assessing case sessions with an OpenAI model, the API key read from the environment
+  JURIGEN_LLM_API_KEY=sk-... jurigen server --dag-path ./data --llm-provider openai --llm-model gpt-4o`,
 	RunE: runServer,
 }
 
 func runServer(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	// The flags not set on the command line come from the environment, then from the config file
+	if _, err := applyConfig(cmd.Flags(), serverConfig); err != nil {
+		return err
+	}
+	if err := validateServerConfig(); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
 	// Set up structured logging
-	logger := zerolog.New(os.Stdout).
-		With().
-		Timestamp().
-		Str("component", "server").
-		Logger()
+	baseLogger, err := newLogger(logLevel, logFormat)
+	if err != nil {
+		return err
+	}
+	logger := baseLogger.With().Str("component", "server").Logger()
+
+	info := buildinfo.Get()
+	logger.Info().
+		Str("version", info.Version).
+		Str("commit", info.Commit).
+		Str("build_date", info.BuildDate).
+		Str("go_version", info.GoVersion).
+		Msg("jurigen server build info")
 
 	logger.Info().
 		Str("dag_path", dagPath).
+		Str("repository", repositoryKind).
 		Bool("write_through", writeThrough).
 		Bool("sync_on_shutdown", syncOnShutdown).
+		Bool("locking", locking).
+		Dur("sync_interval", syncInterval).
+		Dur("shutdown_timeout", shutdownTimeout).
 		Str("address", address).
 		Msg("Starting server with hybrid DAG repository")
 
-	// Create hybrid repository
-	hybridRepo := port.NewHybridDAGRepository(port.HybridDAGRepositoryConfig{
-		FilePath:     dagPath,
-		WriteThrough: writeThrough,
-		Logger:       &logger,
-	})
+	validationProfile, err := loadValidationProfile(serverProfile)
+	if err != nil {
+		logger.Error().Err(err).Str("profile", serverProfile).Msg("Failed to load validation profile")
+		return err
+	}
+	logger.Info().Str("profile", validationProfile.Name).Msg("Using validation profile")
 
-	// Initialize repository (load DAGs from files into memory)
-	logger.Info().Msg("Initializing hybrid repository...")
-	err := hybridRepo.Initialize(ctx)
+	repo, err := openDAGRepository(ctx, newRepositoryConfig(), logger)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to initialize hybrid repository")
-		return fmt.Errorf("failed to initialize hybrid repository: %w", err)
+		logger.Error().Err(err).Str("repository", repositoryKind).Msg("Failed to open DAG repository")
+		return err
 	}
+	defer repo.Close()
+	hybridRepo := repo.hybrid
+
+	// Walk analytics are persisted next to the DAG files, in a sidecar directory
+	analyticsRepo := port.NewFileWalkAnalyticsRepository(filepath.Join(dagPath, "analytics"))
+	sessionRepo := port.NewFileSessionRepository(filepath.Join(dagPath, "sessions"))
+	// DAG versions are stored alongside the DAG files, in a <id>.versions directory
+	versionRepo := port.NewFileDAGVersionRepository(dagPath)
+	webhookRepo := port.NewFileWebhookRepository(filepath.Join(dagPath, "webhooks"))
+	// Webhook deliveries are persisted so that the ones pending on shutdown are resumed on the next start
+	deliveryRepo := port.NewFileWebhookDeliveryRepository(filepath.Join(dagPath, "webhook-deliveries"), port.DefaultDeliveryHistorySize)
+	templateRepo := port.NewFileTemplateRepository(filepath.Join(dagPath, "templates"))
+	dispatcher := webhook.NewDispatcher(webhookRepo, deliveryRepo, webhook.Config{
+		MaxAttempts: webhookMaxAttempts,
+		Timeout:     webhookTimeout,
+	})
+	// Long-running tasks, such as the validation of large DAGs, are run in the background and polled by the clients
+	jobRepo := port.NewInMemoryJobRepository(port.DefaultJobHistorySize)
+	jobQueue := job.NewQueue(jobRepo, job.Config{
+		Workers:   jobWorkers,
+		QueueSize: jobQueueSize,
+	})
+	// The use cases publish their events on the bus, which forwards them to the webhooks and the DAG event streams
+	events := eventbus.New(dispatcher)
 
-	// Display repository statistics
-	stats, err := hybridRepo.GetStats(ctx)
+	assessmentProvider, err := newAssessmentProvider(cmd)
 	if err != nil {
-		logger.Warn().Err(err).Msg("Failed to get repository stats")
-	} else {
+		logger.Error().Err(err).Msg("Invalid LLM provider configuration")
+		return err
+	}
+	if assessmentProvider != nil {
 		logger.Info().
-			Int("memory_dags", stats.MemoryDAGCount).
-			Int("file_dags", stats.FileDAGCount).
-			Bool("write_through", stats.WriteThrough).
-			Msg("Repository initialized successfully")
+			Str("provider", assessmentProvider.Name()).
+			Str("model", assessmentProvider.Model()).
+			Msg("Assessing case sessions with LLM provider")
 	}
 
+	attachmentStorage := port.NewFileAttachmentStorage(filepath.Join(dagPath, "attachments"))
+
 	// Create application layer
-	appLayer := pkg.New(hybridRepo)
+	appLayer := pkg.New(repo.dags, hybridRepo, analyticsRepo, versionRepo, sessionRepo, assessmentProvider, attachmentStorage, newAttachmentPolicy(), webhookRepo, deliveryRepo, templateRepo, jobQueue, jobRepo, events, validationProfile)
 
 	// Parse address to extract host and port
 	host, portStr, err := net.SplitHostPort(address)
@@ -107,61 +242,248 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid port number: %w", err)
 	}
 
-	// Create HTTP server
-	router := http.New(appLayer, nil) // No authentication for now
-	server := xhttp.NewServer(router, host, port)
+	// Background tasks are stopped on shutdown, once the in-flight requests are drained
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+	var background sync.WaitGroup
+	runInBackground := func(run func(ctx context.Context)) {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			run(backgroundCtx)
+		}()
+	}
+
+	if repo.cached != nil {
+		runInBackground(repo.cached.Run)
+	}
+	runInBackground(jobQueue.Run)
+
+	// Cached DAG reads are invalidated synchronously on every repository change, a watcher could miss some
+	var responseCache *http.ResponseCache
+	if responseCacheSize > 0 {
+		responseCache = http.NewResponseCache(responseCacheSize)
+		stopObserving := repo.dags.Observe(responseCache.OnChange)
+		defer stopObserving()
+
+		logger.Info().Int("size", responseCacheSize).Msg("Caching DAG read responses")
+	}
 
-	// Set up graceful shutdown
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	// Webhook deliveries are attempted in the background, the queued ones are sent on shutdown
+	go dispatcher.Run(context.WithoutCancel(ctx))
+
+	// Authentication and role checks are only enabled when users are configured
+	var authFn xhttp.AuthFn
+	if serverUsers != "" {
+		users, err := loadUsers(serverUsers)
+		if err != nil {
+			logger.Error().Err(err).Str("users", serverUsers).Msg("Failed to load users")
+			return err
+		}
+		authFn = xhttp.StaticUsersFn(users)
+
+		logger.Info().Int("users", len(users)).Msg("Authenticating API users")
+	}
+
+	// Clients are throttled per route group, the throttled requests are counted on /metrics
+	rateLimits := map[string]xhttp.RateLimit{
+		http.RouteGroupDAGs:     {Rate: rateLimitDAGs, Burst: rateLimitBurst},
+		http.RouteGroupSessions: {Rate: rateLimitSessions, Burst: rateLimitBurst},
+		http.RouteGroupWebhooks: {Rate: rateLimitWebhooks, Burst: rateLimitBurst},
+	}
+	var throttleCounter *xhttp.ThrottleCounter
+	for group, limit := range rateLimits {
+		if !limit.Enabled() {
+			continue
+		}
+		if throttleCounter == nil {
+			throttleCounter = xhttp.NewThrottleCounter()
+		}
+		logger.Info().Str("group", group).Float64("rate", limit.Rate).Int("burst", limit.Burst).Msg("Rate limiting clients")
+	}
+
+	router := http.New(appLayer, authFn, http.Config{
+		MaxConcurrentWalks: maxConcurrentWalks,
+		PreserveWhitespace: preserveWhitespace,
+		ResponseCache:      responseCache,
+		Events:             events,
+		RateLimits:         rateLimits,
+		ThrottleCounter:    throttleCounter,
+		MaxAttachmentSize:  attachmentMaxSize,
+		Sync:               hybridRepo,
+	})
+	// The DAG event streams are ended on shutdown so that they do not hold the drain of the requests
+	server := xhttp.NewServer(router, host, port).
+		WithShutdownTimeout(shutdownTimeout).
+		WithAllowedOrigins(corsAllowedOrigins).
+		OnShutdown(events.Close)
 
 	// Handle shutdown signals
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start server in a goroutine
-	serverErrChan := make(chan error, 1)
+	// Keep the validation metadata of changed DAGs current in the background
+	if autoValidateInterval > 0 {
+		liveRepo := usecase.NewLiveDAGRepository(repo.dags)
+		validateStoredDAG := usecase.NewValidateStoredDAGUseCase(liveRepo, usecase.NewDAGValidatorFromProfile(validationProfile), events)
+		sweeper := usecase.NewValidationSweeper(liveRepo, validateStoredDAG, autoValidateInterval)
+
+		logger.Info().Dur("interval", autoValidateInterval).Msg("Starting background validation sweeper")
+		runInBackground(sweeper.Run)
+	}
+
+	// Without write-through, the changed DAGs are persisted periodically rather than on shutdown only
+	if !writeThrough && syncInterval > 0 {
+		logger.Info().Dur("interval", syncInterval).Msg("Starting periodic DAG sync")
+		runInBackground(func(ctx context.Context) { hybridRepo.RunSync(ctx, syncInterval) })
+	}
+
+	// Permanently remove the DAGs kept in the trash for longer than the retention
+	if trashRetention > 0 {
+		purger := usecase.NewTrashPurger(usecase.NewPurgeDeletedDAGsUseCase(repo.dags, analyticsRepo, versionRepo), trashRetention, trashPurgeInterval)
+
+		logger.Info().Dur("retention", trashRetention).Msg("Starting background trash purger")
+		runInBackground(purger.Run)
+	}
+
+	// Start server in a goroutine, it returns once stopped and drained
+	serverCtx, stopServer := context.WithCancel(ctx)
+	defer stopServer()
+	serverDone := make(chan error, 1)
 	go func() {
 		logger.Info().Str("address", server.Address()).Msg("HTTP server starting")
-		if err := server.Serve(ctx); err != nil {
-			serverErrChan <- fmt.Errorf("server failed to start: %w", err)
-		}
+		serverDone <- server.Serve(serverCtx)
 	}()
 
 	// Wait for shutdown signal or server error
 	select {
 	case sig := <-signalChan:
 		logger.Info().Str("signal", sig.String()).Msg("Received shutdown signal")
-		cancel()
-
-		// Perform graceful shutdown
-		if syncOnShutdown && !writeThrough {
-			logger.Info().Msg("Syncing in-memory DAGs to files before shutdown...")
-			if err := hybridRepo.Sync(ctx); err != nil {
-				logger.Error().Err(err).Msg("Failed to sync DAGs to files during shutdown")
-			} else {
-				logger.Info().Msg("Successfully synced DAGs to files")
-			}
-		}
+	case err := <-serverDone:
+		logger.Error().Err(err).Msg("Server error")
+		return fmt.Errorf("server stopped unexpectedly: %w", err)
+	}
 
-		logger.Info().Msg("Server shutdown completed")
+	// Stop accepting requests and drain the in-flight ones before the DAGs are synced, so that their
+	// changes are persisted, then send the webhook deliveries they queued
+	steps := []shutdownStep{
+		{name: "http server", run: func(context.Context) error {
+			stopServer()
+			return <-serverDone
+		}},
+		{name: "background tasks", timeout: shutdownTimeout, run: func(ctx context.Context) error {
+			stopBackground()
+			return waitGroup(ctx, &background)
+		}},
+	}
+	if syncOnShutdown && !writeThrough {
+		steps = append(steps, shutdownStep{name: "DAG sync", run: hybridRepo.Sync})
+	}
+	steps = append(steps, shutdownStep{name: "webhook deliveries", timeout: shutdownTimeout, run: dispatcher.Shutdown})
 
-	case err := <-serverErrChan:
-		logger.Error().Err(err).Msg("Server error")
-		cancel()
-		return err
+	if err := shutdown(context.WithoutCancel(ctx), logger, steps); err != nil {
+		return fmt.Errorf("failed to shut down gracefully: %w", err)
 	}
 
+	logger.Info().Msg("Server shutdown completed")
 	return nil
 }
 
+// newAssessmentProvider returns the LLM provider configured by the flags and environment,
+// nil when no provider is configured so that case sessions cannot be assessed
+func newAssessmentProvider(cmd *cobra.Command) (usecase.AssessmentProvider, error) {
+	cfg := llm.Config{
+		Provider: flagOrEnv(cmd, "llm-provider", llmProvider, envLLMProvider),
+		Model:    flagOrEnv(cmd, "llm-model", llmModel, envLLMModel),
+		APIKey:   flagOrEnv(cmd, "llm-api-key", llmAPIKey, envLLMAPIKey),
+		BaseURL:  flagOrEnv(cmd, "llm-base-url", llmBaseURL, envLLMBaseURL),
+		Timeout:  llmTimeout,
+	}
+	if cfg.Provider == "" {
+		return nil, nil
+	}
+
+	provider, err := llm.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LLM provider configuration: %w", err)
+	}
+	return provider, nil
+}
+
+// newAttachmentPolicy gathers the attachment restriction flags
+func newAttachmentPolicy() usecase.AttachmentPolicy {
+	return usecase.AttachmentPolicy{
+		MaxSize:      attachmentMaxSize,
+		ContentTypes: attachmentContentTypes,
+	}
+}
+
+// newRepositoryConfig gathers the DAG repository flags
+func newRepositoryConfig() repositoryConfig {
+	return repositoryConfig{
+		Kind:          repositoryKind,
+		Path:          dagPath,
+		WriteThrough:  writeThrough,
+		Locking:       locking,
+		Cache:         cacheKind,
+		CacheTTL:      cacheTTL,
+		RedisAddr:     redisAddr,
+		RedisPassword: redisPassword,
+	}
+}
+
+// flagOrEnv returns the value of a flag set on the command line, the environment variable otherwise
+func flagOrEnv(cmd *cobra.Command, flag, value, env string) string {
+	if cmd.Flags().Changed(flag) {
+		return value
+	}
+	return os.Getenv(env)
+}
+
 func init() {
 	// Add the command to the root command
 	rootCmd.AddCommand(serverCmd)
+	addServerFlags(serverCmd.Flags())
+}
 
-	// Add configuration flags
-	serverCmd.Flags().StringVar(&dagPath, "dag-path", "data", "Directory path for DAG files")
-	serverCmd.Flags().BoolVar(&writeThrough, "write-through", true, "Enable write-through to files (immediate persistence)")
-	serverCmd.Flags().BoolVar(&syncOnShutdown, "sync-on-shutdown", true, "Sync in-memory DAGs to files on graceful shutdown")
-	serverCmd.Flags().StringVar(&address, "address", ":8080", "Server address (host:port)")
+// addServerFlags defines the server configuration flags, shared with config print-effective
+func addServerFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&serverConfig, configFlag, "", "Config file (JSON or YAML) setting the flags by name, overridden by the command line and the environment")
+	flags.StringVar(&dagPath, "dag-path", "data", "Directory path for DAG files")
+	flags.StringVar(&repositoryKind, "repository", repositoryFile, "Storage of the DAGs: file, in --dag-path, or memory, lost on exit (other data stays in --dag-path)")
+	flags.StringVar(&cacheKind, "cache", "", "Cache of the DAG reads shared by the server replicas: redis (disabled when empty)")
+	flags.DurationVar(&cacheTTL, "cache-ttl", 5*time.Minute, "Expiry of the cached DAG reads with --cache=redis")
+	flags.StringVar(&redisAddr, "redis-addr", "localhost:6379", "Redis server address (host:port) with --cache=redis")
+	flags.StringVar(&redisPassword, "redis-password", "", "Redis password, prefer the "+envRedisPassword+" environment variable")
+	flags.BoolVar(&writeThrough, "write-through", true, "Enable write-through to files (immediate persistence)")
+	flags.BoolVar(&syncOnShutdown, "sync-on-shutdown", true, "Sync in-memory DAGs to files on graceful shutdown")
+	flags.BoolVar(&locking, "locking", true, "Lock the DAG directory against other servers and each DAG file while written, against CLI runs")
+	flags.DurationVar(&syncInterval, "sync-interval", 30*time.Second, "Persist the DAGs changed in memory at this interval when write-through is disabled (on shutdown only when 0)")
+	flags.DurationVar(&shutdownTimeout, "shutdown-timeout", 15*time.Second, "Maximum wait for the in-flight requests, then for the background tasks and the queued webhook deliveries, on shutdown")
+	flags.StringVar(&address, "address", ":8080", "Server address (host:port)")
+	flags.StringVar(&serverProfile, "profile", "", "Validation profile file (JSON or YAML)")
+	flags.StringSliceVar(&corsAllowedOrigins, "cors-allowed-origins", []string{xhttp.AnyOrigin}, "Origins allowed to send cross-origin requests, e.g. https://app.example.com (any origin with *)")
+	flags.StringVar(&logLevel, "log-level", "info", "Minimum level of the logged messages: trace, debug, info, warn or error")
+	flags.StringVar(&logFormat, "log-format", logFormatJSON, "Format of the logs: json or console")
+	flags.StringVar(&serverUsers, "users", "", "Users file (JSON or YAML) granting each API user a viewer, editor or admin role (no authentication when empty)")
+	flags.IntVar(&maxConcurrentWalks, "max-concurrent-walks", 0, "Maximum walks computed at the same time on a DAG, further walks get 503 (unlimited when 0)")
+	flags.BoolVar(&preserveWhitespace, "preserve-whitespace", false, "Store submitted questions and statements as is instead of trimming and collapsing their whitespace")
+	flags.IntVar(&responseCacheSize, "response-cache-size", 0, "Number of DAG read responses cached in memory (disabled when 0)")
+	flags.StringVar(&llmProvider, "llm-provider", "", "LLM provider assessing case sessions: openai, anthropic or local (disabled when empty, env "+envLLMProvider+")")
+	flags.StringVar(&llmModel, "llm-model", "", "Language model of the LLM provider (env "+envLLMModel+")")
+	flags.StringVar(&llmAPIKey, "llm-api-key", "", "API key of the LLM provider, prefer the "+envLLMAPIKey+" environment variable")
+	flags.StringVar(&llmBaseURL, "llm-base-url", "", "Endpoint of the LLM provider API, e.g. an OpenAI compatible server (env "+envLLMBaseURL+")")
+	flags.DurationVar(&llmTimeout, "llm-timeout", 2*time.Minute, "Maximum duration of an assessment request to the LLM provider")
+	flags.IntVar(&webhookMaxAttempts, "webhook-max-attempts", 5, "Attempts to deliver an event to a webhook before giving up")
+	flags.DurationVar(&webhookTimeout, "webhook-timeout", 10*time.Second, "Maximum duration of a webhook delivery attempt")
+	flags.IntVar(&jobWorkers, "job-workers", 2, "Background jobs, such as asynchronous DAG validations, run at the same time")
+	flags.IntVar(&jobQueueSize, "job-queue-size", 64, "Background jobs waiting for a worker, further jobs are rejected with 503")
+	flags.Int64Var(&attachmentMaxSize, "attachment-max-size", usecase.DefaultAttachmentPolicy().MaxSize, "Maximum size in bytes of an uploaded session attachment")
+	flags.StringSliceVar(&attachmentContentTypes, "attachment-content-types", usecase.DefaultAttachmentPolicy().ContentTypes, "Content types accepted for the session attachments, detected from their content")
+	flags.Float64Var(&rateLimitDAGs, "rate-limit-dags", 0, "Requests per second allowed to each client on the DAG endpoints (unlimited when 0)")
+	flags.Float64Var(&rateLimitSessions, "rate-limit-sessions", 0, "Requests per second allowed to each client on the session endpoints (unlimited when 0)")
+	flags.Float64Var(&rateLimitWebhooks, "rate-limit-webhooks", 0, "Requests per second allowed to each client on the webhook endpoints (unlimited when 0)")
+	flags.IntVar(&rateLimitBurst, "rate-limit-burst", 20, "Requests a client can send at once before being rate limited")
+	flags.DurationVar(&trashRetention, "trash-retention", 30*24*time.Hour, "Purge deleted DAGs kept in the trash for longer than this duration (never purged when 0)")
+	flags.DurationVar(&autoValidateInterval, "auto-validate-interval", 0, "Re-validate changed DAGs in the background at this interval (disabled when 0)")
 }
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/llm"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagOrEnv(t *testing.T) {
+	t.Setenv(envLLMModel, "from-env")
+
+	var model string
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&model, "llm-model", "", "")
+
+	assert.Equal(t, "from-env", flagOrEnv(cmd, "llm-model", model, envLLMModel))
+
+	require.NoError(t, cmd.Flags().Set("llm-model", "from-flag"))
+	assert.Equal(t, "from-flag", flagOrEnv(cmd, "llm-model", model, envLLMModel))
+}
+
+func TestNewAssessmentProvider(t *testing.T) {
+	t.Run("disabled without provider", func(t *testing.T) {
+		t.Setenv(envLLMProvider, "")
+
+		provider, err := newAssessmentProvider(serverCmd)
+		require.NoError(t, err)
+		assert.Nil(t, provider)
+	})
+
+	t.Run("configured from the environment", func(t *testing.T) {
+		t.Setenv(envLLMProvider, llm.ProviderAnthropic)
+		t.Setenv(envLLMModel, "claude-model")
+		t.Setenv(envLLMAPIKey, "secret")
+
+		provider, err := newAssessmentProvider(serverCmd)
+		require.NoError(t, err)
+		require.NotNil(t, provider)
+		assert.Equal(t, llm.ProviderAnthropic, provider.Name())
+		assert.Equal(t, "claude-model", provider.Model())
+	})
+
+	t.Run("rejects an incomplete configuration", func(t *testing.T) {
+		t.Setenv(envLLMProvider, llm.ProviderOpenAI)
+		t.Setenv(envLLMModel, "gpt-4o")
+		t.Setenv(envLLMAPIKey, "")
+
+		_, err := newAssessmentProvider(serverCmd)
+		assert.ErrorIs(t, err, llm.ErrMissingConfig)
+	})
+}
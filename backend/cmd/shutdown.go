@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// shutdownStep is a stage of the graceful shutdown
+type shutdownStep struct {
+	name string
+	// timeout bounds the step, which is unbounded when 0
+	timeout time.Duration
+	run     func(ctx context.Context) error
+}
+
+// shutdown runs the steps in order, each once the previous one returned. A failed step is logged and the next
+// ones still run, so that the DAGs are synced to files even when the in-flight requests could not be drained.
+func shutdown(ctx context.Context, logger zerolog.Logger, steps []shutdownStep) error {
+	var errs []error
+	for _, step := range steps {
+		stepCtx, cancel := context.WithCancel(ctx)
+		if step.timeout > 0 {
+			stepCtx, cancel = context.WithTimeout(ctx, step.timeout)
+		}
+
+		start := time.Now()
+		err := step.run(stepCtx)
+		cancel()
+		if err != nil {
+			logger.Error().Err(err).Str("step", step.name).Msg("Shutdown step failed")
+			errs = append(errs, fmt.Errorf("%s: %w", step.name, err))
+			continue
+		}
+
+		logger.Info().Str("step", step.name).Dur("duration", time.Since(start)).Msg("Shutdown step completed")
+	}
+
+	return errors.Join(errs...)
+}
+
+// waitGroup waits for the goroutines of wg until ctx is done
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
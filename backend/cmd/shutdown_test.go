@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdown(t *testing.T) {
+	logger := zerolog.New(io.Discard)
+
+	t.Run("runs the steps in order despite failures", func(t *testing.T) {
+		var ran []string
+		step := func(name string, err error) shutdownStep {
+			return shutdownStep{name: name, run: func(context.Context) error {
+				ran = append(ran, name)
+				return err
+			}}
+		}
+
+		failure := errors.New("drain failed")
+		err := shutdown(context.Background(), logger, []shutdownStep{
+			step("http server", failure),
+			step("DAG sync", nil),
+			step("webhook deliveries", nil),
+		})
+
+		assert.Equal(t, []string{"http server", "DAG sync", "webhook deliveries"}, ran)
+		assert.ErrorIs(t, err, failure)
+		assert.ErrorContains(t, err, "http server: drain failed")
+	})
+
+	t.Run("bounds the steps by their timeout", func(t *testing.T) {
+		var bounded, unbounded bool
+		err := shutdown(context.Background(), logger, []shutdownStep{
+			{name: "bounded", timeout: 10 * time.Millisecond, run: func(ctx context.Context) error {
+				_, bounded = ctx.Deadline()
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+			{name: "unbounded", run: func(ctx context.Context) error {
+				_, hasDeadline := ctx.Deadline()
+				unbounded = !hasDeadline && ctx.Err() == nil
+				return nil
+			}},
+		})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, bounded)
+		assert.True(t, unbounded, "a step is not cut short by the timeout of the previous one")
+	})
+}
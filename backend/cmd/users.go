@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// usersFile lists the accounts allowed to use the API
+type usersFile struct {
+	Users []auth.StaticUser `json:"users" yaml:"users"`
+}
+
+// loadUsers reads the API accounts and their role from a JSON or YAML file
+func loadUsers(path string) ([]auth.StaticUser, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users %s: %w", path, err)
+	}
+
+	var file usersFile
+	if isYAMLFile(path) {
+		err = yaml.Unmarshal(data, &file)
+	} else {
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse users %s: %w", path, err)
+	}
+
+	if len(file.Users) == 0 {
+		return nil, fmt.Errorf("no user declared in %s", path)
+	}
+	seen := make(map[string]bool, len(file.Users))
+	for _, u := range file.Users {
+		if u.Username == "" || u.Password == "" {
+			return nil, fmt.Errorf("user without username or password in %s", path)
+		}
+		if seen[u.Username] {
+			return nil, fmt.Errorf("user %s declared twice in %s", u.Username, path)
+		}
+		seen[u.Username] = true
+		if _, err := user.ParseRole(string(u.Role)); err != nil {
+			return nil, fmt.Errorf("user %s in %s: %w", u.Username, path, err)
+		}
+	}
+
+	return file.Users, nil
+}
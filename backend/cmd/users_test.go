@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadUsers(t *testing.T) {
+	t.Parallel()
+
+	expected := []auth.StaticUser{
+		{Username: "alice", Password: "secret", Role: user.RoleAdmin},
+		{Username: "bob", Password: "hunter2", Role: user.RoleViewer},
+	}
+
+	tests := []struct {
+		name          string
+		file          string
+		content       string
+		expected      []auth.StaticUser
+		expectedError string
+	}{
+		{
+			name:     "json users",
+			file:     "users.json",
+			content:  `{"users":[{"username":"alice","password":"secret","role":"admin"},{"username":"bob","password":"hunter2","role":"viewer"}]}`,
+			expected: expected,
+		},
+		{
+			name: "yaml users",
+			file: "users.yaml",
+			content: `users:
+  - username: alice
+    password: secret
+    role: admin
+  - username: bob
+    password: hunter2
+    role: viewer
+`,
+			expected: expected,
+		},
+		{
+			name:          "unknown role",
+			file:          "users.json",
+			content:       `{"users":[{"username":"alice","password":"secret","role":"owner"}]}`,
+			expectedError: "unknown role",
+		},
+		{
+			name:          "duplicated user",
+			file:          "users.json",
+			content:       `{"users":[{"username":"alice","password":"a","role":"admin"},{"username":"alice","password":"b","role":"viewer"}]}`,
+			expectedError: "declared twice",
+		},
+		{
+			name:          "missing password",
+			file:          "users.json",
+			content:       `{"users":[{"username":"alice","role":"admin"}]}`,
+			expectedError: "without username or password",
+		},
+		{
+			name:          "no user",
+			file:          "users.json",
+			content:       `{"users":[]}`,
+			expectedError: "no user declared",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			users, err := loadUsers(path)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, users)
+		})
+	}
+}
@@ -1,11 +1,12 @@
 package cmd
 
 import (
-	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/export"
 	"davidterranova/jurigen/backend/internal/usecase"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -26,12 +27,16 @@ This command will check the DAG structure and provide detailed validation result
 var validateFileCmd = &cobra.Command{
 	Use:   "file [path]",
 	Short: "Validate a DAG file",
-	Long: `Validate a DAG file to ensure it meets all structural requirements.
+	Long: `Validate a JSON or YAML DAG file to ensure it meets all structural requirements.
 	
 Examples:
   jurigen validate file data/my-dag.json
+  jurigen validate file data/my-dag.yaml
   jurigen validate file data/my-dag.json --detailed
-  jurigen validate file data/my-dag.json --stats-only`,
+  jurigen validate file data/my-dag.json --stats-only
+  jurigen validate file data/my-dag.json --format junit > report.xml
+  jurigen validate file data/my-dag.json --profile legal.json
+  jurigen validate file data/my-dag.json --export-to my-dag.mmd --export-format mermaid`,
 	Args: cobra.ExactArgs(1),
 	RunE: validateDAGFile,
 }
@@ -40,12 +45,18 @@ var (
 	detailedOutput bool
 	statsOnly      bool
 	outputFormat   string
+	profilePath    string
+	exportTo       string
+	exportAs       string
 )
 
 func init() {
 	validateFileCmd.Flags().BoolVar(&detailedOutput, "detailed", false, "Show detailed validation errors and warnings")
 	validateFileCmd.Flags().BoolVar(&statsOnly, "stats-only", false, "Show only DAG statistics")
-	validateFileCmd.Flags().StringVar(&outputFormat, "format", "text", "Output format: text, json")
+	validateFileCmd.Flags().StringVar(&outputFormat, "format", "text", "Output format: text, json, junit")
+	validateFileCmd.Flags().StringVar(&profilePath, "profile", "", "Validation profile file (JSON or YAML)")
+	validateFileCmd.Flags().StringVar(&exportTo, "export-to", "", "Also export the DAG to this file, with leaf nodes and cycles highlighted")
+	validateFileCmd.Flags().StringVar(&exportAs, "export-format", export.FormatDOT, "Format of the --export-to file: "+strings.Join(export.DefaultRegistry().Formats(), ", "))
 
 	validateCmd.AddCommand(validateFileCmd)
 	rootCmd.AddCommand(validateCmd)
@@ -54,26 +65,37 @@ func init() {
 func validateDAGFile(cmd *cobra.Command, args []string) error {
 	filePath := args[0]
 
-	// Read the DAG file
-	data, err := os.ReadFile(filePath)
+	// Read the JSON or YAML DAG file
+	dagData, err := readDAGFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read file %s: %w", filePath, err)
+		return err
 	}
 
-	// Parse JSON
-	var dagData model.DAG
-	if err := json.Unmarshal(data, &dagData); err != nil {
-		return fmt.Errorf("failed to parse JSON from %s: %w", filePath, err)
+	profile, err := loadValidationProfile(profilePath)
+	if err != nil {
+		return err
 	}
 
 	// Validate DAG
-	validator := usecase.NewDAGValidator()
-	result := validator.ValidateDAG(&dagData)
+	validator := usecase.NewDAGValidatorFromProfile(profile)
+	result := validator.ValidateDAG(dagData)
+
+	if exportTo != "" {
+		data, err := exportDAG(dagData, exportAs, true, true)
+		if err != nil {
+			return err
+		}
+		if err := writeExport(exportTo, data); err != nil {
+			return err
+		}
+	}
 
 	// Output results based on format and options
 	switch outputFormat {
 	case "json":
 		return outputJSONResults(result)
+	case "junit":
+		return outputJUnitResults(filePath, result)
 	default:
 		return outputTextResults(filePath, result)
 	}
@@ -116,8 +138,33 @@ func outputTextResults(filePath string, result usecase.ValidationResult) error {
 			fmt.Printf("   Root Node IDs: %v\n", result.Statistics.RootNodeIDs)
 		}
 
+		if len(result.Statistics.BranchingHistogram) > 0 {
+			fmt.Printf("   Avg Branching Factor: %.2f\n", result.Statistics.AvgBranchingFactor)
+			fmt.Printf("   Branching Distribution:\n")
+			answerCounts := make([]int, 0, len(result.Statistics.BranchingHistogram))
+			for answers := range result.Statistics.BranchingHistogram {
+				answerCounts = append(answerCounts, answers)
+			}
+			sort.Ints(answerCounts)
+			for _, answers := range answerCounts {
+				fmt.Printf("     - %d answer(s): %d node(s)\n", answers, result.Statistics.BranchingHistogram[answers])
+			}
+		}
+
+		if result.Statistics.TotalPaths > 0 {
+			fmt.Printf("   Total Paths: %d\n", result.Statistics.TotalPaths)
+			fmt.Printf("   Path Length: min %d, max %d, avg %.2f\n", result.Statistics.MinPathLength, result.Statistics.MaxPathLength, result.Statistics.AvgPathLength)
+			fmt.Printf("   Leaf Paths:\n")
+			for _, leaf := range result.Statistics.LeafPaths {
+				fmt.Printf("     - %s: %d path(s), depth min %d, max %d, avg %.2f\n", leaf.LeafNodeID, leaf.Paths, leaf.MinDepth, leaf.MaxDepth, leaf.AvgDepth)
+			}
+		}
+
 		if len(result.Statistics.CyclePaths) > 0 {
-			fmt.Printf("   Cycle Paths: %v\n", result.Statistics.CyclePaths)
+			fmt.Printf("   Cycle Paths:\n")
+			for _, cycle := range result.Statistics.CyclePaths {
+				fmt.Printf("     - %s (closed by answer %s)\n", strings.Join(cycle.NodeIDs, " -> "), cycle.ClosingAnswerID)
+			}
 		}
 		fmt.Println()
 	}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateAllDAGPath string
+	validateAllWorkers int
+)
+
+var validateAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Validate every DAG of a directory",
+	Long: `Validate every DAG stored in a directory, persisting the validation results
+to the metadata of each DAG like the server does, and report the invalid DAGs
+with their most frequent error codes. Deleted DAGs are skipped.
+
+Examples:
+  jurigen validate all --dag-path data
+  jurigen validate all --dag-path data --workers 8
+  jurigen validate all --dag-path data --profile legal.json --format json`,
+	Args: cobra.NoArgs,
+	RunE: runValidateAll,
+}
+
+func init() {
+	validateAllCmd.Flags().StringVar(&validateAllDAGPath, "dag-path", "data", "Directory of the DAG files")
+	validateAllCmd.Flags().IntVar(&validateAllWorkers, "workers", 1, fmt.Sprintf("Number of DAGs validated in parallel, up to %d", usecase.MaxValidationWorkers))
+	validateAllCmd.Flags().StringVar(&profilePath, "profile", "", "Validation profile file (JSON or YAML)")
+	validateAllCmd.Flags().StringVar(&outputFormat, "format", "text", "Output format: text, json")
+
+	validateCmd.AddCommand(validateAllCmd)
+}
+
+func runValidateAll(cmd *cobra.Command, args []string) error {
+	profile, err := loadValidationProfile(profilePath)
+	if err != nil {
+		return err
+	}
+
+	report, err := validateAllDAGs(cmd.Context(), validateAllDAGPath, profile, validateAllWorkers)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation report: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	outputBulkValidationReport(validateAllDAGPath, report)
+	if len(report.Invalid) > 0 || len(report.Failed) > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// validateAllDAGs validates the live DAGs of dir and persists their validation metadata
+func validateAllDAGs(ctx context.Context, dir string, profile usecase.ValidationProfile, workers int) (*usecase.BulkValidationReport, error) {
+	dagRepository := usecase.NewLiveDAGRepository(port.NewFileDAGRepository(dir).WithLocking())
+	validateStoredDAG := usecase.NewValidateStoredDAGUseCase(dagRepository, usecase.NewDAGValidatorFromProfile(profile), nil)
+
+	return usecase.NewValidateAllDAGsUseCase(dagRepository, validateStoredDAG).Execute(ctx, usecase.CmdValidateAllDAGs{
+		Workers: workers,
+	})
+}
+
+func outputBulkValidationReport(dir string, report *usecase.BulkValidationReport) {
+	fmt.Printf("🔍 DAG Validation Results for: %s\n", dir)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("✅ %d/%d DAG(s) VALID\n", report.Valid, report.Total)
+	fmt.Println()
+
+	if len(report.Invalid) > 0 {
+		fmt.Printf("❌ Invalid DAGs (%d):\n", len(report.Invalid))
+		for i, invalid := range report.Invalid {
+			fmt.Printf("   %d. %s %q: %d error(s), %d warning(s) [%s]\n",
+				i+1, invalid.DAGId, invalid.Title, invalid.Errors, invalid.Warnings, strings.Join(invalid.TopErrorCodes, ", "))
+		}
+		fmt.Println()
+	}
+
+	if len(report.ErrorCodes) > 0 {
+		fmt.Println("📊 Errors by code:")
+		for _, count := range report.ErrorCodes {
+			fmt.Printf("   %s: %d\n", count.Code, count.Count)
+		}
+		fmt.Println()
+	}
+
+	if len(report.Failed) > 0 {
+		fmt.Printf("⚠️  DAGs which could not be validated (%d):\n", len(report.Failed))
+		for i, failed := range report.Failed {
+			fmt.Printf("   %d. %s: %s\n", i+1, failed.DAGId, failed.Error)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAllDAGs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	valid := dagtest.ValidSingleRoot()
+	invalid := dagtest.MultipleRoots()
+	trashed := dagtest.MultipleRoots()
+	deletedAt := time.Now().Add(-time.Hour)
+	trashed.DeletedAt = &deletedAt
+	for _, d := range []*model.DAG{valid, invalid, trashed} {
+		data, err := d.MarshalJSON()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, d.Id.String()+".json"), data, 0644))
+	}
+
+	report, err := validateAllDAGs(context.Background(), dir, usecase.DefaultValidationProfile(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 1, report.Valid)
+	require.Len(t, report.Invalid, 1)
+	assert.Equal(t, invalid.Id, report.Invalid[0].DAGId)
+	assert.Contains(t, report.Invalid[0].TopErrorCodes, "DAG_MULTIPLE_ROOTS")
+
+	stored, err := port.NewFileDAGRepository(dir).Get(context.Background(), invalid.Id)
+	require.NoError(t, err)
+	require.NotNil(t, stored.Metadata)
+	assert.False(t, stored.Metadata.IsValid)
+	assert.False(t, stored.Metadata.LastValidatedAt.IsZero())
+}
@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"davidterranova/jurigen/backend/pkg/buildinfo"
 	"fmt"
-	"runtime"
 
 	"github.com/spf13/cobra"
 )
@@ -11,13 +11,6 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 }
 
-var (
-	// Version is populated at compile time
-	Version string = "na"
-	// BuildTime is populated at compile time
-	BuildTime string = "na"
-)
-
 // versionCmd prints the version of the program
 var versionCmd = &cobra.Command{
 	Use:   "version",
@@ -26,5 +19,6 @@ var versionCmd = &cobra.Command{
 }
 
 func runVersion(cmd *cobra.Command, args []string) {
-	fmt.Printf("jurigen build %s.%s on %s\n", Version, BuildTime, runtime.Version())
+	info := buildinfo.Get()
+	fmt.Printf("jurigen %s (commit %s, built %s) on %s\n", info.Version, info.Commit, info.BuildDate, info.GoVersion)
 }
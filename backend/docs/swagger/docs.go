@@ -22,6 +22,43 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Write the DAGs changed in memory since the last sync to their files, and remove the files of the deleted DAGs.\nDAGs are persisted on every change with write-through, there is then nothing to sync.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Sync DAGs to files",
+                "responses": {
+                    "200": {
+                        "description": "DAGs synced",
+                        "schema": {
+                            "$ref": "#/definitions/http.SyncPresenter"
+                        }
+                    },
+                    "500": {
+                        "description": "Some DAGs could not be synced",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Sync unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/dags": {
             "get": {
                 "security": [
@@ -29,7 +66,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of all available Legal Case DAGs with ID, title, and validation status",
+                "description": "Retrieve the available Legal Case DAGs with ID, title, and validation status, optionally filtered by title or update time, sorted and paginated. The response carries the total number of matching DAGs.",
                 "consumes": [
                     "application/json"
                 ],
@@ -40,11 +77,63 @@ const docTemplate = `{
                     "DAGs"
                 ],
                 "summary": "List Legal Case DAGs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "title",
+                            "-title",
+                            "updated_at",
+                            "-updated_at",
+                            "updated",
+                            "-updated"
+                        ],
+                        "type": "string",
+                        "description": "Order by title or update time, prefix with - for descending order; updated and -updated are kept as aliases of updated_at and -updated_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list DAGs updated at or after this RFC3339 time",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list DAGs whose title contains this text, case insensitive",
+                        "name": "title_contains",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1-based page to return, every DAG is listed when neither page nor page_size is set",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of DAGs per page, 20 when only page is set, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Compute the validity of DAGs without validation metadata instead of reporting it as unknown",
+                        "name": "compute_missing",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Successfully retrieved DAG list with summary information",
                         "schema": {
-                            "$ref": "#/definitions/http.DAGSummaryListPresenter"
+                            "$ref": "#/definitions/http.DAGSummaryPagePresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort, updated_since, page, page_size or compute_missing parameter",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
                     },
                     "500": {
@@ -54,16 +143,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/dags/validate": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Validate a DAG structure to ensure it meets all requirements (single root node, acyclic, valid relationships)",
+                "description": "Create a Legal Case DAG from its questions and answers. The server assigns an ID when the payload carries none.",
                 "consumes": [
                     "application/json"
                 ],
@@ -73,27 +160,33 @@ const docTemplate = `{
                 "tags": [
                     "DAGs"
                 ],
-                "summary": "Validate Legal Case DAG",
+                "summary": "Create Legal Case DAG",
                 "parameters": [
                     {
-                        "description": "DAG structure to validate",
+                        "type": "boolean",
+                        "description": "Reject payloads containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    },
+                    {
+                        "description": "DAG structure to create",
                         "name": "dag",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.ValidateRequest"
+                            "$ref": "#/definitions/http.DAGPresenter"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "DAG validation completed (may contain errors)",
+                    "201": {
+                        "description": "Successfully created DAG",
                         "schema": {
-                            "$ref": "#/definitions/http.ValidationResultPresenter"
+                            "$ref": "#/definitions/http.DAGPresenter"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body, invalid DAG or DAG ID already in use",
                         "schema": {
                             "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
@@ -107,14 +200,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/dags/{dagId}": {
-            "get": {
+        "/dags/diff": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve DAG metadata including ID, title, validation status, and statistics (without content)",
+                "description": "Compare two DAGs, each given as a payload or as a version reference, and return the added, removed and modified nodes and answers, changed next node links and title change. Nodes and answers are matched by ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -124,31 +217,39 @@ const docTemplate = `{
                 "tags": [
                     "DAGs"
                 ],
-                "summary": "Get Legal Case DAG metadata",
+                "summary": "Compare two DAGs",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "DAG unique identifier (UUID)",
-                        "name": "dagId",
-                        "in": "path",
-                        "required": true
+                        "description": "DAGs to compare",
+                        "name": "diff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.DiffRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved DAG metadata",
+                        "description": "Successfully compared DAGs",
                         "schema": {
-                            "$ref": "#/definitions/http.DAGMetadataPresenter"
+                            "$ref": "#/definitions/http.DAGChangeSetPresenter"
                         }
                     },
                     "400": {
-                        "description": "Invalid DAG ID format",
+                        "description": "Invalid request body or operands",
                         "schema": {
                             "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "DAG not found",
+                        "description": "DAG version not found",
                         "schema": {
                             "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
@@ -160,16 +261,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/dags/import": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update a complete Legal Case DAG structure including questions, answers, and context",
+                "description": "Convert a JSON, YAML or GraphML document into a DAG and validate it. The format is detected from the content unless given.\nValid DAGs are stored unless dry_run is set, invalid ones are never stored and are returned with their validation errors.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/plain",
+                    "text/xml"
                 ],
                 "produces": [
                     "application/json"
@@ -177,42 +282,64 @@ const docTemplate = `{
                 "tags": [
                     "DAGs"
                 ],
-                "summary": "Update Legal Case DAG",
+                "summary": "Import Legal Case DAG",
                 "parameters": [
                     {
+                        "enum": [
+                            "json",
+                            "yaml",
+                            "graphml"
+                        ],
                         "type": "string",
-                        "description": "DAG unique identifier (UUID)",
-                        "name": "dagId",
-                        "in": "path",
-                        "required": true
+                        "description": "Format of the document, detected when omitted",
+                        "name": "format",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated DAG structure",
-                        "name": "dag",
+                        "type": "boolean",
+                        "description": "Validate the imported DAG without storing it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Store the DAG without recording its validation in its metadata, e.g. for bulk imports validated afterwards through /dags/validate-all",
+                        "name": "skip_validation_metadata",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Document to import",
+                        "name": "document",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.DAGPresenter"
+                            "type": "string"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated DAG",
+                        "description": "DAG converted and validated, not stored (dry run)",
                         "schema": {
-                            "$ref": "#/definitions/http.DAGPresenter"
+                            "$ref": "#/definitions/http.ImportResultPresenter"
+                        }
+                    },
+                    "201": {
+                        "description": "DAG converted, validated and stored",
+                        "schema": {
+                            "$ref": "#/definitions/http.ImportResultPresenter"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or DAG ID format",
+                        "description": "Unreadable or empty body, unknown format, malformed document or DAG ID already in use",
                         "schema": {
                             "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "DAG not found",
+                    "422": {
+                        "description": "Imported DAG is invalid, validation errors are returned",
                         "schema": {
-                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                            "$ref": "#/definitions/http.ImportResultPresenter"
                         }
                     },
                     "500": {
@@ -224,51 +351,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/dags/{dagId}/content": {
+        "/dags/search": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve complete Legal Case DAG content including ID, title, and all questions with answers",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Search node questions, answer statements and answer user contexts across all DAGs.\nEvery word of the query must match the start of a word of the text, case insensitive. Hits are ranked by number of matching words.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "DAGs"
                 ],
-                "summary": "Get Legal Case DAG content",
+                "summary": "Search Legal Case DAGs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "DAG unique identifier (UUID)",
-                        "name": "dagId",
-                        "in": "path",
+                        "description": "Words to search for",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of hits to return, 20 when omitted, at most 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved DAG content",
+                        "description": "Matching node texts with a snippet of the match",
                         "schema": {
-                            "$ref": "#/definitions/http.DAGContentPresenter"
+                            "$ref": "#/definitions/http.SearchResultPresenter"
                         }
                     },
                     "400": {
-                        "description": "Invalid DAG ID format",
+                        "description": "Missing query or invalid limit",
                         "schema": {
                             "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "DAG not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/dags/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the DAGs in the trash, the most recently deleted first. They are purged once kept longer than the server retention.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "List deleted Legal Case DAGs",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved deleted DAGs",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGSummaryListPresenter"
+                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -279,14 +434,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/dags/{dagId}/validate": {
+        "/dags/validate": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Validates an existing Legal Case DAG by ID, persisting validation results including status and statistics to the DAG metadata",
+                "description": "Validate a DAG structure to ensure it meets all requirements (single root node, acyclic, valid relationships). The severity of the validation rules can be overridden by code, e.g. to accept several roots in a draft DAG.",
                 "consumes": [
                     "application/json"
                 ],
@@ -296,7 +451,109 @@ const docTemplate = `{
                 "tags": [
                     "DAGs"
                 ],
-                "summary": "Validate stored Legal Case DAG",
+                "summary": "Validate Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Reject payloads containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    },
+                    {
+                        "description": "DAG structure to validate",
+                        "name": "dag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ValidateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "DAG validation completed (may contain errors)",
+                        "schema": {
+                            "$ref": "#/definitions/http.ValidationResultPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or rule overrides",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/validate-all": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Validates every stored DAG, persisting the validation results to the metadata of each, and reports the number of valid DAGs, the invalid DAGs with their most frequent error codes and the errors counted by code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Validate every stored Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of DAGs validated in parallel, from 1 to 32, one at a time by default",
+                        "name": "workers",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "DAGs validated successfully (some may be invalid)",
+                        "schema": {
+                            "$ref": "#/definitions/http.BulkValidationReportPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid workers parameter",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during validation",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve DAG metadata including ID, title, validation status, and statistics (without content)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Get Legal Case DAG metadata",
                 "parameters": [
                     {
                         "type": "string",
@@ -308,9 +565,15 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "DAG validation completed successfully (may contain validation errors)",
+                        "description": "Successfully retrieved DAG metadata",
                         "schema": {
-                            "$ref": "#/definitions/http.ValidationResultPresenter"
+                            "$ref": "#/definitions/http.DAGMetadataPresenter"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Revision of the DAG, to send back in If-Match on updates"
+                            }
                         }
                     },
                     "400": {
@@ -326,96 +589,4634 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error during validation",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/xhttp.ErrorResponse"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "http.AnswerPresenter": {
-            "description": "An answer to a legal question with optional user context and structured metadata for evidence tracking",
-            "type": "object",
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a complete Legal Case DAG structure including questions, answers, and context.\nWith dry_run the update is validated but not persisted, include_stats then returns the statistics before and after the update.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Update Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the update without persisting it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With dry_run, return the statistics before and after the update",
+                        "name": "include_stats",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject payloads containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the DAG revision the update applies to",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Updated DAG structure",
+                        "name": "dag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated DAG",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Revision of the updated DAG"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, DAG ID format or If-Match header",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "DAG was modified since the If-Match revision",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move a Legal Case DAG to the trash, it can be restored until it is purged along with its walk analytics and versions. DAGs with recorded walks are only deleted with force.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Delete Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the DAG even when walks were recorded on it",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully moved DAG to the trash"
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format or force parameter",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Walks were recorded on the DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/analytics/paths": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the complete paths of a DAG ranked by the number of recorded walks that followed them, most common first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get path analytics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of paths to return, all paths when omitted",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved path analytics",
+                        "schema": {
+                            "$ref": "#/definitions/http.PathAnalyticsPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format or limit",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/answers/{answerId}/insert-node": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new information node, repoint the answer to it and give the new node a single answer leading to the original target. The resulting DAG is re-validated before being stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Insert node after answer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Answer unique identifier (UUID)",
+                        "name": "answerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Node to insert",
+                        "name": "node",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.InsertNodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully inserted node",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, identifier format or resulting DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG or answer not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/answers/{answerId}/metadata": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Merge the provided metadata keys into an answer without replacing the whole DAG, so concurrent edits of different answers do not conflict. Keys set to null are removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Merge answer metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Answer unique identifier (UUID)",
+                        "name": "answerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Metadata keys to merge",
+                        "name": "metadata",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AnswerMetadataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully merged answer metadata",
+                        "schema": {
+                            "$ref": "#/definitions/http.AnswerPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or identifier format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG or answer not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Copy a DAG into a new DAG. The DAG, its nodes and its answers get fresh IDs and the links between nodes are remapped onto the new node IDs. The clone is validated and announced like any created DAG.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Clone Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Options of the clone",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.CloneRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created clone",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format, request body or title, or the clone is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/content": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve complete Legal Case DAG content including ID, title, and all questions with answers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Get Legal Case DAG content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code used to render translated questions and answers, falling back to the default text",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved DAG content",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGContentPresenter"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Revision of the DAG, to send back in If-Match on updates"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the events of a DAG (updates, validations, deletion, completed sessions) as Server-Sent Events until the client disconnects.\nEach message carries the event ID, the event type as SSE event name and the event as JSON data. The stream ends after the DAG deletion.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Stream Legal Case DAG events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of DAG events",
+                        "schema": {
+                            "$ref": "#/definitions/http.EventPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Event stream unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Render a DAG for visualization tools: Graphviz DOT, GraphML (yEd, Gephi), Mermaid flowchart or canonical JSON. Every node is a question and every edge an answer statement.",
+                "produces": [
+                    "text/plain",
+                    "text/xml",
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Export Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "dot",
+                            "graphml",
+                            "mermaid",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "dot",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Mark the leaf nodes",
+                        "name": "highlight_leaves",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Mark the edges of the cycles detected by the validator",
+                        "name": "highlight_cycles",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "DAG rendered in the requested format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format, format or highlight parameter",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Attach a copy of the source DAG beneath an answer of the DAG which leads nowhere yet, the answer then leads to the root of the source. Source node and answer IDs already used by the DAG are given fresh IDs, which are returned. The source DAG is left unchanged and the resulting DAG is re-validated before being stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Merge Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source DAG and target answer",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.MergeRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully merged DAG",
+                        "schema": {
+                            "$ref": "#/definitions/http.MergeResultPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, identifier format, source DAG without a single root or resulting DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Target DAG, source DAG or answer not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Answer already leads to a node",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/metadata-schema": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the fields, types and allowed values the metadata of the answers of a DAG must match",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Get metadata schema",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved metadata schema",
+                        "schema": {
+                            "$ref": "#/definitions/http.MetadataSchemaPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found or without metadata schema",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Declare the fields, types and allowed values of answer metadata. The DAG is re-validated, so the schema is rejected when the metadata of existing answers does not match it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Set metadata schema",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the DAG revision the change applies to",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Metadata schema",
+                        "name": "schema",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.MetadataSchemaPresenter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully set metadata schema",
+                        "schema": {
+                            "$ref": "#/definitions/http.MetadataSchemaPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, schema or If-Match header, or answer metadata not matching the schema",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "DAG was modified since the If-Match revision",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove the metadata schema of a DAG, any answer metadata is then accepted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Delete metadata schema",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the DAG revision the change applies to",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Metadata schema deleted"
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format or If-Match header",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "DAG was modified since the If-Match revision",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/nodes/check-references": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Report which next_node references of a node, typically copied from another DAG, exist in the target DAG and which are dangling. The DAG is not modified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Check node references",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Node to check",
+                        "name": "node",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.NodePresenter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully checked node references",
+                        "schema": {
+                            "$ref": "#/definitions/http.NodeReferencesPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or DAG ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/nodes/{nodeId}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the question or flags of a node without replacing the whole DAG, so concurrent edits of different nodes do not conflict. Omitted fields are left untouched and the resulting DAG is re-validated before being stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Edit node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node unique identifier (UUID)",
+                        "name": "nodeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the DAG revision the change applies to",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Node fields to change",
+                        "name": "node",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateNodeRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated node",
+                        "schema": {
+                            "$ref": "#/definitions/http.NodePresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, identifier format, If-Match header or resulting DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG or node not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "DAG was modified since the If-Match revision",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/nodes/{nodeId}/answers/{answerId}": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the statement, target, context or disabled flag of an answer without replacing the whole DAG. Omitted fields are left untouched and the resulting DAG is re-validated, rejecting dangling references and cycles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Edit answer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node unique identifier (UUID)",
+                        "name": "nodeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Answer unique identifier (UUID)",
+                        "name": "answerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the DAG revision the change applies to",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Answer fields to change",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateAnswerRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated answer",
+                        "schema": {
+                            "$ref": "#/definitions/http.AnswerPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, identifier format, If-Match header or resulting DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG, node or answer not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "DAG was modified since the If-Match revision",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/nodes/{nodeId}/split": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move the answers of a node to one new intermediate node per bucket and give the node one answer per bucket leading to it. Every answer must belong to exactly one bucket and keeps its identifier and target. The resulting DAG is re-validated before being stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Split node into sub-questions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node unique identifier (UUID)",
+                        "name": "nodeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Grouping of the node answers",
+                        "name": "split",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SplitNodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully split node",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, identifier format, grouping or resulting DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG or node not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/paths": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Enumerate the paths from the root node with the question and chosen answer of each step, for path review.\nThe number of paths grows exponentially with the depth of a DAG, truncated is set when paths were left out by the limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "List Legal Case DAG paths",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of paths to return, 100 when omitted, at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully enumerated DAG paths",
+                        "schema": {
+                            "$ref": "#/definitions/http.PathListPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format, invalid limit or DAG cannot be walked",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Take a DAG out of the trash along with its walk analytics and versions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Restore deleted Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully restored DAG",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "DAG is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/save-as-template": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Snapshot the current structure of a DAG into a new template. Later changes to the DAG are not reflected in the template.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Save DAG as template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template name and description",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SaveAsTemplateRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully saved template",
+                        "schema": {
+                            "$ref": "#/definitions/http.TemplatePresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format, request body or template name",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/sessions": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start a walkthrough of a DAG stored server side, positioned on its root question",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Start case session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully started session",
+                        "schema": {
+                            "$ref": "#/definitions/http.CaseSessionPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format or DAG without a single root",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/subtree/{nodeId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Extract the nodes reachable from a node as a standalone DAG rooted at the node, for review or reuse. The subtree keeps the node and answer IDs of the DAG, gets a fresh DAG ID and is not stored.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Get subtree of node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node unique identifier (UUID)",
+                        "name": "nodeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subtree rooted at the node",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG or node not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Store the nodes reachable from a node as a new DAG rooted at the node. Its nodes and answers get fresh IDs, it is validated and announced like any created DAG.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Materialize subtree of node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node unique identifier (UUID)",
+                        "name": "nodeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Options of the new DAG",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.SubtreeRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created DAG",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier format, request body, title or resulting DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG or node not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/validate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Validates an existing Legal Case DAG by ID, persisting validation results including status and statistics to the DAG metadata",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DAGs"
+                ],
+                "summary": "Validate stored Legal Case DAG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate in the background, the job to poll on /jobs/{jobId} is returned right away",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "DAG validation completed successfully (may contain validation errors)",
+                        "schema": {
+                            "$ref": "#/definitions/http.ValidationResultPresenter"
+                        }
+                    },
+                    "202": {
+                        "description": "DAG validation enqueued",
+                        "schema": {
+                            "$ref": "#/definitions/http.JobPresenter"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format or async parameter",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during validation",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Job queue full or not configured",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the versions of a DAG, recorded every time the DAG is replaced, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versions"
+                ],
+                "summary": "List DAG versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved DAG versions",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGVersionListPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/versions/{version}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the snapshot of a DAG at a given version",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versions"
+                ],
+                "summary": "Get DAG version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version number, starting at 1",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved DAG version",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGVersionPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid DAG ID format or version number",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG version not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/versions/{version}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace a DAG with the snapshot of one of its versions. The history is kept, the restored DAG is recorded as a new version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versions"
+                ],
+                "summary": "Restore DAG version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version number, starting at 1",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully restored DAG version",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid version number or version failing the current validation",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG or version not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dags/{dagId}/walk-complete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record the answers chosen during a complete walk, from the root node to a terminal answer or leaf node, to accumulate path analytics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Record completed walk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DAG unique identifier (UUID)",
+                        "name": "dagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Answers chosen during the walk",
+                        "name": "walk",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.WalkCompleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully recorded walk, with its coverage of the required questions",
+                        "schema": {
+                            "$ref": "#/definitions/http.WalkCompletePresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, identifier format or incomplete walk",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many concurrent walks on the DAG, retry after the Retry-After delay",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{jobId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status of a background job, along with its result once it succeeded or its error once it failed. Jobs are kept in memory, they are lost on restart.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job unique identifier (UUID)",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job",
+                        "schema": {
+                            "$ref": "#/definitions/http.JobPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a session with the answers given so far and the next question to answer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Get case session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved session",
+                        "schema": {
+                            "$ref": "#/definitions/http.CaseSessionPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/answers": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record the answer to the current question of a session, with the context provided by the user, and move to the question it leads to. Multi-select questions accept several answers through answer_ids, input questions take the entered value instead of an answer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Answer session question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Answer to the current question",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SessionAnswerRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully answered question",
+                        "schema": {
+                            "$ref": "#/definitions/http.CaseSessionPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or answer not valid for the current question",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session or DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session has no question left to answer",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/answers/{answerId}/attachments": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upload a file supporting an answer given during the session, as the file field of a multipart form. The content type is detected from the content and must be accepted by the server, along with the size. The checksum of the content is recorded and checked on download.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Upload session attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Answer unique identifier (UUID)",
+                        "name": "answerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Evidence supporting the answer",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully uploaded attachment",
+                        "schema": {
+                            "$ref": "#/definitions/http.AttachmentPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, missing file or content type not accepted",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found or answer not given during the session",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Attachment storage unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/answers/{nodeId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Discard the answer given to a question of a session along with every answer given after it, so that the question is asked again and the walk may take another branch. A completed session is reopened and its assessment discarded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Revise case session answer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Question to answer again (UUID)",
+                        "name": "nodeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully discarded the answers",
+                        "schema": {
+                            "$ref": "#/definitions/http.CaseSessionPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session or node ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found or question not answered during the session",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/assess": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send the prompt built from a completed session to the configured LLM provider and store the returned legal assessment on the session, replacing the previous one. The request body is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Assess case session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Instructions of the assessment",
+                        "name": "assessment",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.AssessSessionRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session along with its assessment",
+                        "schema": {
+                            "$ref": "#/definitions/http.CaseSessionPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session or its DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session is not completed or no longer matches its DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No LLM provider is configured or the provider failed",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/attachments/{attachmentId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download the content of an attachment, checked against the checksum recorded on upload",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Download session attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment unique identifier (UUID)",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment content, sent with its detected content type",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session or attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error or corrupted attachment",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Attachment storage unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mark a session as completed once all its questions were answered",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Complete case session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully completed session",
+                        "schema": {
+                            "$ref": "#/definitions/http.CaseSessionPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session still has questions to answer or is already completed",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/document": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Render the questions answered during a completed session, with the user context, confidence, tags and evidence sources of each answer, as a Markdown or PDF document",
+                "produces": [
+                    "text/markdown",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Get case session document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "md",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Document format, md when omitted",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Context document, sent as an attachment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID format or unsupported format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session or its DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session is not completed or no longer matches its DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/prompt": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Convert the path answered during a completed session into system and user messages for a language model. Templates are Go text/template sources rendered with the Title, DAGId, SessionId, GeneratedAt and Entries (Question, Answer, UserContext, Confidence, Tags, Sources) of the case context, and the Task. The request body is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Build case session prompt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Templates and task of the prompt",
+                        "name": "prompt",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.BuildPromptRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully built prompt",
+                        "schema": {
+                            "$ref": "#/definitions/http.PromptPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID format, request body or template",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session or its DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session is not completed or no longer matches its DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/score": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sum the score contributions of the answers given during a completed session along the scoring dimensions of its DAG. Without scoring configuration, every dimension an answer contributes to is scored with a weight of 1.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Score case session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session unique identifier (UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scores of the session",
+                        "schema": {
+                            "$ref": "#/definitions/http.ScorePresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session or its DAG not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session is not completed or no longer matches its DAG",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the DAG templates, ordered by name. The template DAGs are summarized by their title and node count.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "List templates",
+                "responses": {
+                    "200": {
+                        "description": "DAG templates",
+                        "schema": {
+                            "$ref": "#/definitions/http.TemplateListPresenter"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{templateId}/instantiate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new DAG with the structure of a template. The DAG, its nodes and its answers get fresh IDs while the links between nodes are preserved. The DAG is validated and announced like any created DAG.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Instantiate template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template unique identifier (UUID)",
+                        "name": "templateId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Options of the new DAG",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.InstantiateTemplateRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created DAG",
+                        "schema": {
+                            "$ref": "#/definitions/http.DAGPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid template ID format, request body or title, or the template DAG is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Retrieve the version, commit and build date of the running server",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Version"
+                ],
+                "summary": "Get server version",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved version information",
+                        "schema": {
+                            "$ref": "#/definitions/http.VersionPresenter"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the registered webhooks, ordered by creation date. Secrets are not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "Registered webhooks",
+                        "schema": {
+                            "$ref": "#/definitions/http.WebhookListPresenter"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register an endpoint notified of DAG and session events. Events are posted as JSON with the X-Jurigen-Event, X-Jurigen-Delivery and X-Jurigen-Timestamp headers, and X-Jurigen-Signature holding \"sha256=\" followed by the hex HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the webhook secret. The secret is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook endpoint and subscriptions",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.WebhookRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully registered webhook, along with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/http.WebhookPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, URL, event type or secret",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a registered webhook. Its secret is not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook unique identifier (UUID)",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/http.WebhookPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the URL and subscribed events of a webhook. The secret is rotated when given, and returned in the response only then. Pending retries use the updated webhook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook unique identifier (UUID)",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook endpoint and subscriptions",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.WebhookRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject request bodies containing unknown fields",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated webhook",
+                        "schema": {
+                            "$ref": "#/definitions/http.WebhookPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID format, request body, URL, event type or secret",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Unregister a webhook along with its delivery history, pending retries are abandoned",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook unique identifier (UUID)",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted webhook"
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID format",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the latest deliveries of a webhook with their status, attempts and last error, most recent first. The delivery history is kept in memory and lost on restart.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook unique identifier (UUID)",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries to return, 20 when omitted, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Latest deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/http.WebhookDeliveryListPresenter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID format or limit",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.AnswerChangePresenter": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "$ref": "#/definitions/http.TextChangePresenter"
+                },
+                "answer_id": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "next_node": {
+                    "$ref": "#/definitions/http.LinkChangePresenter"
+                }
+            }
+        },
+        "http.AnswerMetadataRequest": {
+            "description": "Metadata keys to merge into an answer, null values remove the key",
+            "type": "object",
+            "required": [
+                "metadata"
+            ],
+            "properties": {
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "http.AnswerPresenter": {
+            "description": "An answer to a legal question with optional user context and structured metadata for evidence tracking",
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "Yes, age discrimination occurred"
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.BranchPresenter"
+                    }
+                },
+                "disabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "string",
+                    "example": "fc28c4b6-d185-cf56-a7e4-dead499ff1e8"
+                },
+                "latest_metadata_change": {
+                    "$ref": "#/definitions/http.MetadataSnapshotPresenter"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "metadata_history_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "next_node": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "parent_node_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "scores": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "user_context": {
+                    "type": "string",
+                    "example": "Manager explicitly mentioned my age during termination"
+                }
+            }
+        },
+        "http.AnswerRefPresenter": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "answer_id": {
+                    "type": "string"
+                },
+                "next_node": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.AssessSessionRequest": {
+            "description": "Instructions of the assessment, every field is optional",
+            "type": "object",
+            "properties": {
+                "task": {
+                    "type": "string",
+                    "example": "Assess the chances of success of an unfair dismissal claim."
+                }
+            }
+        },
+        "http.AssessmentPresenter": {
+            "description": "Legal assessment of a completed case session written by a language model",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "The dismissal without notice during the probation period is lawful unless..."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:40:00Z"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "gpt-4o"
+                },
+                "provider": {
+                    "type": "string",
+                    "enum": [
+                        "openai",
+                        "anthropic",
+                        "local"
+                    ],
+                    "example": "openai"
+                }
+            }
+        },
+        "http.AttachmentPresenter": {
+            "description": "Attachment of an answer, its content being downloaded from its link",
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "string",
+                    "example": "9c118df5-c787-6gc4-a0a4-g6g7d52dc766"
+                },
+                "checksum": {
+                    "type": "string",
+                    "example": "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "dismissal-letter.pdf"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "c3d4e5f6-a7b8-9012-cdef-123456789012"
+                },
+                "link": {
+                    "type": "string",
+                    "example": "/v1/sessions/a1b2c3d4-e5f6-7890-abcd-ef1234567890/attachments/c3d4e5f6-a7b8-9012-cdef-123456789012"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                },
+                "uploaded_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:32:00Z"
+                }
+            }
+        },
+        "http.BranchPresenter": {
+            "description": "Next node selected when the condition holds over the metadata collected along the walk",
+            "type": "object",
+            "properties": {
+                "next_node": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "when": {
+                    "type": "string",
+                    "example": "confidence \u003e 0.7 \u0026\u0026 \"urgent\" in tags"
+                }
+            }
+        },
+        "http.BuildPromptRequest": {
+            "description": "Templates and task of the prompt, every field is optional",
+            "type": "object",
+            "properties": {
+                "system_template": {
+                    "type": "string",
+                    "example": "You are a lawyer reviewing the {{.Title}} case."
+                },
+                "task": {
+                    "type": "string",
+                    "example": "Draft a demand letter to the employer."
+                },
+                "user_template": {
+                    "type": "string",
+                    "example": "{{range .Entries}}{{.Question}} {{.Answer}}\n{{end}}"
+                }
+            }
+        },
+        "http.BulkValidationReportPresenter": {
+            "description": "Number of valid DAGs, invalid DAGs with their most frequent error codes and errors counted by code",
+            "type": "object",
+            "properties": {
+                "error_codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ErrorCodeCountPresenter"
+                    }
+                },
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.FailedDAGValidationPresenter"
+                    }
+                },
+                "invalid": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.InvalidDAGPresenter"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "valid": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "http.CaseSessionPresenter": {
+            "description": "Case session holding the answers given so far and the next question to answer",
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.SessionAnswerPresenter"
+                    }
+                },
+                "assessment": {
+                    "$ref": "#/definitions/http.AssessmentPresenter"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AttachmentPresenter"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "current_node_id": {
+                    "type": "string"
+                },
+                "dag_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-abcd-ef1234567890"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "in_progress",
+                        "completed"
+                    ],
+                    "example": "in_progress"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:35:00Z"
+                }
+            }
+        },
+        "http.CloneRequest": {
+            "description": "Options of the DAG clone",
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "Employment Discrimination Case (copy)"
+                }
+            }
+        },
+        "http.CoveragePresenter": {
+            "description": "Nodes answered during a walk and required nodes reachable from them that the walk never reached",
+            "type": "object",
+            "properties": {
+                "answered": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "missed_required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.CyclePathPresenter": {
+            "description": "Nodes of a cycle, closed on its first node, and the answer leading back to its first node",
+            "type": "object",
+            "properties": {
+                "closing_answer_id": {
+                    "type": "string",
+                    "example": "fc28c4b6-d185-cf56-a7e4-dead499ff1e8"
+                },
+                "node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000",
+                        "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                }
+            }
+        },
+        "http.DAGChangeSetPresenter": {
+            "description": "Changes turning the before DAG into the after DAG, nodes sorted by ID",
+            "type": "object",
+            "properties": {
+                "added_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.NodeRefPresenter"
+                    }
+                },
+                "has_changes": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "modified_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.NodeChangePresenter"
+                    }
+                },
+                "removed_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.NodeRefPresenter"
+                    }
+                },
+                "title": {
+                    "$ref": "#/definitions/http.TextChangePresenter"
+                }
+            }
+        },
+        "http.DAGContentPresenter": {
+            "description": "DAG content including ID, title, and all nodes with answers",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.NodePresenter"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Employment Discrimination Case"
+                }
+            }
+        },
+        "http.DAGMetadataPresenter": {
+            "description": "DAG metadata including ID, title, validation status, and statistics",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "is_valid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "statistics": {
+                    "$ref": "#/definitions/http.ValidationStatisticsPresenter"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Employment Discrimination Case"
+                }
+            }
+        },
+        "http.DAGPresenter": {
+            "description": "Legal Case DAG with questions, answers, and context",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "metadata_schema": {
+                    "$ref": "#/definitions/http.MetadataSchemaPresenter"
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.NodePresenter"
+                    }
+                },
+                "scoring": {
+                    "$ref": "#/definitions/http.ScoringPresenter"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Employment Discrimination Case"
+                }
+            }
+        },
+        "http.DAGSummaryListPresenter": {
+            "description": "List of DAG summaries with essential information for efficient overview",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "dags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DAGSummaryPresenter"
+                    }
+                }
+            }
+        },
+        "http.DAGSummaryPagePresenter": {
+            "description": "Page of DAG summaries along with the number of DAGs matching the listing",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "dags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DAGSummaryPresenter"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.DAGSummaryPresenter": {
+            "description": "Summary information for a DAG including ID, title, and validation status",
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2024-01-16T08:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "is_valid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Employment Discrimination Case"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "validation_status": {
+                    "type": "string",
+                    "enum": [
+                        "valid",
+                        "invalid",
+                        "unknown"
+                    ],
+                    "example": "valid"
+                }
+            }
+        },
+        "http.DAGVersionListPresenter": {
+            "description": "Recorded versions of a DAG, oldest first",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DAGVersionSummaryPresenter"
+                    }
+                }
+            }
+        },
+        "http.DAGVersionPresenter": {
+            "description": "Snapshot of a DAG at a given version",
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "dag": {
+                    "$ref": "#/definitions/http.DAGPresenter"
+                },
+                "node_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Employment Discrimination Case"
+                }
+            }
+        },
+        "http.DAGVersionSummaryPresenter": {
+            "description": "Version of a DAG, recorded every time the DAG is replaced",
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "node_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Employment Discrimination Case"
+                }
+            }
+        },
+        "http.DiffOperandRequest": {
+            "description": "Either a DAG payload or a version number",
+            "type": "object",
+            "properties": {
+                "dag": {
+                    "$ref": "#/definitions/http.DAGPresenter"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "http.DiffRequest": {
+            "description": "Two DAGs to compare, each given as a DAG payload or as a version of the DAG identified by dag_id",
+            "type": "object",
+            "properties": {
+                "after": {
+                    "$ref": "#/definitions/http.DiffOperandRequest"
+                },
+                "before": {
+                    "$ref": "#/definitions/http.DiffOperandRequest"
+                },
+                "dag_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "http.DimensionScorePresenter": {
+            "description": "Score of a session along a scoring dimension, with the band it reaches",
+            "type": "object",
+            "properties": {
+                "band": {
+                    "type": "string",
+                    "example": "strong"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Claim strength"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "claim_strength"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 8
+                }
+            }
+        },
+        "http.ErrorCodeCountPresenter": {
+            "description": "Number of errors of a code across the invalid DAGs",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "DAG_HAS_CYCLES"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "http.EventPresenter": {
+            "description": "Event posted to webhooks, signed in the X-Jurigen-Signature header, and streamed to the DAG event subscribers",
+            "type": "object",
+            "properties": {
+                "dag_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f6a7b8c9-d0e1-4f2a-8b3c-4d5e6f708192"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-abcd-ef1234567890"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "dag.created",
+                        "dag.updated",
+                        "dag.deleted",
+                        "dag.restored",
+                        "dag.validated",
+                        "session.completed"
+                    ],
+                    "example": "session.completed"
+                }
+            }
+        },
+        "http.FailedDAGValidationPresenter": {
+            "description": "DAG whose validation failed, e.g. because its metadata could not be persisted",
+            "type": "object",
+            "properties": {
+                "dag_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to update DAG"
+                }
+            }
+        },
+        "http.ImportResultPresenter": {
+            "description": "Detected format, converted DAG and its validation result, with whether the DAG was stored",
+            "type": "object",
+            "properties": {
+                "dag": {
+                    "$ref": "#/definitions/http.DAGPresenter"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "graphml"
+                },
+                "stored": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "validation": {
+                    "$ref": "#/definitions/http.ValidationResultPresenter"
+                }
+            }
+        },
+        "http.InputPresenter": {
+            "description": "Kind of value entered on an input node, the variable it is stored under and its validation rules",
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "date"
+                    ],
+                    "example": "date"
+                },
+                "max": {
+                    "type": "string",
+                    "example": "2030-12-31"
+                },
+                "min": {
+                    "type": "string",
+                    "example": "2000-01-01"
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "^[0-9]{4}-"
+                },
+                "variable": {
+                    "type": "string",
+                    "example": "termination_date"
+                }
+            }
+        },
+        "http.InsertNodeRequest": {
+            "description": "New node inserted between an answer and its current target",
+            "type": "object",
+            "required": [
+                "answer",
+                "question"
+            ],
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "Continue"
+                },
+                "question": {
+                    "type": "string",
+                    "example": "Did you report the incident?"
+                }
+            }
+        },
+        "http.InstantiateTemplateRequest": {
+            "description": "Options of the DAG created from a template",
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "Smith v. Acme Corp"
+                }
+            }
+        },
+        "http.InvalidDAGPresenter": {
+            "description": "Invalid DAG with its number of errors and warnings and its most frequent error codes",
+            "type": "object",
+            "properties": {
+                "dag_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "errors": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Employment Law"
+                },
+                "top_error_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "DAG_HAS_CYCLES"
+                    ]
+                },
+                "warnings": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.JobPresenter": {
+            "description": "Long-running task run in the background, such as the validation of a large DAG, with its result once done",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "dag_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to retrieve DAG for validation"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:05Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b7c8d9e0-f1a2-4b3c-8d4e-5f6071829304"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:01Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ],
+                    "example": "succeeded"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "dag.validate"
+                    ],
+                    "example": "dag.validate"
+                }
+            }
+        },
+        "http.LeafPathsPresenter": {
+            "description": "Number and depth of the paths from the root node ending on a leaf or on a node holding terminal answers",
+            "type": "object",
+            "properties": {
+                "avg_depth": {
+                    "type": "number",
+                    "example": 3
+                },
+                "leaf_node_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "max_depth": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "min_depth": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "paths": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "http.LinkChangePresenter": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.MergeRequest": {
+            "description": "Source DAG to graft and answer of the target DAG it is grafted beneath",
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "string",
+                    "example": "c4d5e6f7-a8b9-4c0d-9e1f-2a3b4c5d6e7f"
+                },
+                "source_dag_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                }
+            }
+        },
+        "http.MergeResultPresenter": {
+            "description": "Merged DAG along with the IDs given to the grafted nodes and answers",
+            "type": "object",
+            "properties": {
+                "dag": {
+                    "$ref": "#/definitions/http.DAGPresenter"
+                },
+                "grafted_root_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "remapped_ids": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.MetadataFieldPresenter": {
+            "description": "Name, type and allowed values of an answer metadata field",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Severity of the situation"
+                },
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "severity"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "boolean",
+                        "string_list"
+                    ],
+                    "example": "string"
+                }
+            }
+        },
+        "http.MetadataSchemaPresenter": {
+            "description": "Fields the metadata of the answers of a DAG may hold",
+            "type": "object",
+            "properties": {
+                "additional_fields": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.MetadataFieldPresenter"
+                    }
+                }
+            }
+        },
+        "http.MetadataSnapshotPresenter": {
+            "description": "Answer metadata as recorded after a change, with its author and timestamp",
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "by": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "http.NodeChangePresenter": {
+            "type": "object",
+            "properties": {
+                "added_answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AnswerRefPresenter"
+                    }
+                },
+                "modified_answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AnswerChangePresenter"
+                    }
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "question": {
+                    "$ref": "#/definitions/http.TextChangePresenter"
+                },
+                "removed_answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AnswerRefPresenter"
+                    }
+                }
+            }
+        },
+        "http.NodePresenter": {
+            "description": "A question node with potential answers for legal case context building",
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AnswerPresenter"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "input": {
+                    "$ref": "#/definitions/http.InputPresenter"
+                },
+                "multi_select": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "multi_select_next": {
+                    "type": "string",
+                    "enum": [
+                        "priority",
+                        "convergence"
+                    ],
+                    "example": "priority"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/http.OutcomePresenter"
+                },
+                "question": {
+                    "type": "string",
+                    "example": "Were you discriminated against in the workplace?"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "information",
+                        "outcome",
+                        "input"
+                    ],
+                    "example": "question"
+                }
+            }
+        },
+        "http.NodeRefPresenter": {
+            "type": "object",
             "properties": {
-                "answer": {
+                "node_id": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.NodeReferencesPresenter": {
+            "description": "References of a node resolving to nodes of the target DAG and dangling ones",
+            "type": "object",
+            "properties": {
+                "dangling": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "existing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.OutcomePresenter": {
+            "description": "Assessment label, recommended actions and statutes an outcome node concludes a walk with",
+            "type": "object",
+            "properties": {
+                "assessment": {
                     "type": "string",
-                    "example": "Yes, age discrimination occurred"
+                    "example": "Likely unfair dismissal"
                 },
-                "id": {
+                "recommended_actions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "File a claim within 12 months"
+                    ]
+                },
+                "statutes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Code du travail L1235-1"
+                    ]
+                }
+            }
+        },
+        "http.PathAnalyticsPresenter": {
+            "description": "Complete paths of a DAG ranked by the number of recorded walks, most common first",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "paths": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.RankedPathPresenter"
+                    }
+                }
+            }
+        },
+        "http.PathListPresenter": {
+            "description": "The first paths of a DAG from its root node",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "paths": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.PathPresenter"
+                    }
+                },
+                "truncated": {
+                    "description": "Truncated is set when the DAG has more paths than the limit",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "http.PathPresenter": {
+            "description": "Ordered steps from the root node to the node ending the path",
+            "type": "object",
+            "properties": {
+                "leaf_node_id": {
                     "type": "string",
-                    "example": "fc28c4b6-d185-cf56-a7e4-dead499ff1e8"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.WalkStepPresenter"
+                    }
+                }
+            }
+        },
+        "http.PromptMessagePresenter": {
+            "description": "Chat message to send to a language model",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "You are a legal assistant analysing a client's case."
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "system",
+                        "user"
+                    ],
+                    "example": "system"
+                }
+            }
+        },
+        "http.PromptPresenter": {
+            "description": "Structured prompt summarizing the path answered in a case session",
+            "type": "object",
+            "properties": {
+                "dag_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.PromptMessagePresenter"
+                    }
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-abcd-ef1234567890"
+                }
+            }
+        },
+        "http.RankedPathPresenter": {
+            "description": "Complete path with the number of recorded walks that followed it",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "path": {
+                    "$ref": "#/definitions/http.PathPresenter"
+                }
+            }
+        },
+        "http.SaveAsTemplateRequest": {
+            "description": "Name and description of the template",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Intake questions for unfair dismissal claims"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Employment dismissal"
+                }
+            }
+        },
+        "http.ScoreBandPresenter": {
+            "description": "Label given to the scores reaching a minimum",
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "strong"
+                },
+                "min": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "http.ScoreDimensionPresenter": {
+            "description": "Axis scored along a path, e.g. the strength of a claim",
+            "type": "object",
+            "properties": {
+                "bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ScoreBandPresenter"
+                    }
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Claim strength"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "claim_strength"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 2
+                }
+            }
+        },
+        "http.ScorePresenter": {
+            "description": "Scores of the answers given during a session, per dimension and in total",
+            "type": "object",
+            "properties": {
+                "dimensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DimensionScorePresenter"
+                    }
+                },
+                "total": {
+                    "type": "number",
+                    "example": 12.5
+                }
+            }
+        },
+        "http.ScoringPresenter": {
+            "description": "Dimensions the answers of a DAG contribute scores to",
+            "type": "object",
+            "properties": {
+                "dimensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ScoreDimensionPresenter"
+                    }
+                }
+            }
+        },
+        "http.SearchHitPresenter": {
+            "description": "Node text matching a search, the answer ID is set for answer statements and user contexts",
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "string",
+                    "example": "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+                },
+                "dag_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "field": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "user_context"
+                    ],
+                    "example": "question"
+                },
+                "node_id": {
+                    "type": "string",
+                    "example": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "snippet": {
+                    "type": "string",
+                    "example": "Were you dismissed by your employer?"
+                }
+            }
+        },
+        "http.SearchResultPresenter": {
+            "description": "Node texts matching a search, best matches first",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.SearchHitPresenter"
+                    }
+                }
+            }
+        },
+        "http.SessionAnswerPresenter": {
+            "description": "Answer given during a case session, along with the context provided by the user",
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "string",
+                    "example": "9c118df5-c787-6gc4-a0a4-g6g7d52dc766"
+                },
+                "answered_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
                 },
                 "metadata": {
                     "type": "object",
                     "additionalProperties": true
                 },
-                "next_node": {
+                "node_id": {
                     "type": "string",
                     "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
                 },
-                "user_context": {
+                "user_context": {
+                    "type": "string",
+                    "example": "Dismissed by email on March 3rd"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "2024-03-03"
+                }
+            }
+        },
+        "http.SessionAnswerRequest": {
+            "description": "Answer to the current question of a case session",
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "string",
+                    "example": "9c118df5-c787-6gc4-a0a4-g6g7d52dc766"
+                },
+                "answer_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "9c118df5-c787-6gc4-a0a4-g6g7d52dc766"
+                    ]
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "user_context": {
+                    "type": "string",
+                    "example": "Dismissed by email on March 3rd"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "2024-03-03"
+                }
+            }
+        },
+        "http.SplitBucketRequest": {
+            "description": "Answers moved under a new sub-question, reached from the split node through a new answer",
+            "type": "object",
+            "required": [
+                "answer",
+                "answer_ids",
+                "question"
+            ],
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "Employment matter"
+                },
+                "answer_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fc28c4b6-d185-cf56-a7e4-dead499ff1e8"
+                    ]
+                },
+                "question": {
+                    "type": "string",
+                    "example": "Which employment matter?"
+                }
+            }
+        },
+        "http.SplitNodeRequest": {
+            "description": "Grouping of all the answers of a node into buckets, each bucket becoming a sub-question",
+            "type": "object",
+            "required": [
+                "buckets"
+            ],
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.SplitBucketRequest"
+                    }
+                }
+            }
+        },
+        "http.SubtreeRequest": {
+            "description": "Options of the DAG created from a subtree",
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "Notice period"
+                }
+            }
+        },
+        "http.SyncPresenter": {
+            "description": "Outcome of a sync of the DAGs changed in memory to files",
+            "type": "object",
+            "properties": {
+                "dirty": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "last_sync_at": {
                     "type": "string",
-                    "example": "Manager explicitly mentioned my age during termination"
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "synced": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "http.DAGContentPresenter": {
-            "description": "DAG content including ID, title, and all nodes with answers",
+        "http.TemplateListPresenter": {
+            "description": "DAG templates, ordered by name",
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "count": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "nodes": {
+                "templates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/http.NodePresenter"
+                        "$ref": "#/definitions/http.TemplatePresenter"
                     }
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Employment Discrimination Case"
                 }
             }
         },
-        "http.DAGMetadataPresenter": {
-            "description": "DAG metadata including ID, title, validation status, and statistics",
+        "http.TemplatePresenter": {
+            "description": "Reusable snapshot of a DAG, instantiated into new DAGs",
             "type": "object",
             "properties": {
-                "id": {
+                "created_at": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "is_valid": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "statistics": {
-                    "$ref": "#/definitions/http.ValidationStatisticsPresenter"
+                    "example": "2024-01-15T10:30:00Z"
                 },
-                "title": {
+                "description": {
                     "type": "string",
-                    "example": "Employment Discrimination Case"
-                }
-            }
-        },
-        "http.DAGPresenter": {
-            "description": "Legal Case DAG with questions, answers, and context",
-            "type": "object",
-            "properties": {
+                    "example": "Intake questions for unfair dismissal claims"
+                },
                 "id": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "c3d4e5f6-a7b8-4c9d-8e0f-1a2b3c4d5e6f"
                 },
-                "nodes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/http.NodePresenter"
-                    }
+                "name": {
+                    "type": "string",
+                    "example": "Employment dismissal"
+                },
+                "node_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "source_dag_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
                 },
                 "title": {
                     "type": "string",
@@ -423,56 +5224,62 @@ const docTemplate = `{
                 }
             }
         },
-        "http.DAGSummaryListPresenter": {
-            "description": "List of DAG summaries with essential information for efficient overview",
+        "http.TextChangePresenter": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "after": {
+                    "type": "string"
                 },
-                "dags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/http.DAGSummaryPresenter"
-                    }
+                "before": {
+                    "type": "string"
                 }
             }
         },
-        "http.DAGSummaryPresenter": {
-            "description": "Summary information for a DAG including ID, title, and validation status",
+        "http.UpdateAnswerRequest": {
+            "description": "Answer fields to change, omitted fields are left untouched. An empty next_node makes the answer terminal.",
             "type": "object",
             "properties": {
-                "id": {
+                "answer": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "Yes, by email"
                 },
-                "is_valid": {
+                "disabled": {
                     "type": "boolean",
-                    "example": true
+                    "example": false
                 },
-                "title": {
+                "next_node": {
                     "type": "string",
-                    "example": "Employment Discrimination Case"
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "user_context": {
+                    "type": "string",
+                    "example": "Manager explicitly mentioned my age during termination"
                 }
             }
         },
-        "http.NodePresenter": {
-            "description": "A question node with potential answers for legal case context building",
+        "http.UpdateNodeRequest": {
+            "description": "Node fields to change, omitted fields are left untouched",
             "type": "object",
             "properties": {
-                "answers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/http.AnswerPresenter"
-                    }
+                "multi_select": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "id": {
+                "multi_select_next": {
                     "type": "string",
-                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                    "enum": [
+                        "priority",
+                        "convergence"
+                    ],
+                    "example": "priority"
                 },
                 "question": {
                     "type": "string",
-                    "example": "Were you discriminated against in the workplace?"
+                    "example": "Were you dismissed in writing?"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
@@ -485,6 +5292,16 @@ const docTemplate = `{
             "properties": {
                 "dag": {
                     "$ref": "#/definitions/http.DAGPresenter"
+                },
+                "rules": {
+                    "description": "Rules overrides the severity of validation rules for this request only",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "DAG_MULTIPLE_ROOTS": "warning"
+                    }
                 }
             }
         },
@@ -543,10 +5360,24 @@ const docTemplate = `{
             "description": "Statistical information about the DAG structure and validation results",
             "type": "object",
             "properties": {
+                "avg_branching_factor": {
+                    "type": "number",
+                    "example": 2.5
+                },
+                "avg_path_length": {
+                    "type": "number",
+                    "example": 3.5
+                },
+                "branching_histogram": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
                 "cycle_paths": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/http.CyclePathPresenter"
                     }
                 },
                 "has_cycles": {
@@ -563,10 +5394,24 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 2
                 },
+                "leaf_paths": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.LeafPathsPresenter"
+                    }
+                },
                 "max_depth": {
                     "type": "integer",
                     "example": 3
                 },
+                "max_path_length": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "min_path_length": {
+                    "type": "integer",
+                    "example": 2
+                },
                 "root_node_ids": {
                     "type": "array",
                     "items": {
@@ -581,9 +5426,21 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 12
                 },
+                "total_estimated_damages": {
+                    "type": "number",
+                    "example": 125000
+                },
+                "total_estimated_duration": {
+                    "type": "number",
+                    "example": 180
+                },
                 "total_nodes": {
                     "type": "integer",
                     "example": 5
+                },
+                "total_paths": {
+                    "type": "integer",
+                    "example": 8
                 }
             }
         },
@@ -609,6 +5466,232 @@ const docTemplate = `{
                 }
             }
         },
+        "http.VersionPresenter": {
+            "description": "Version and build information of the running server",
+            "type": "object",
+            "properties": {
+                "build_date": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "commit": {
+                    "type": "string",
+                    "example": "a1b2c3d"
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "go1.24.0"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.2.0"
+                }
+            }
+        },
+        "http.WalkCompletePresenter": {
+            "description": "Complete path followed by a recorded walk along with its coverage of the required questions",
+            "type": "object",
+            "properties": {
+                "coverage": {
+                    "$ref": "#/definitions/http.CoveragePresenter"
+                },
+                "leaf_node_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.WalkStepPresenter"
+                    }
+                }
+            }
+        },
+        "http.WalkCompleteRequest": {
+            "description": "Answers chosen from the root node to the end of the walk, in order",
+            "type": "object",
+            "required": [
+                "answer_ids"
+            ],
+            "properties": {
+                "answer_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fc28c4b6-d185-cf56-a7e4-dead499ff1e8"
+                    ]
+                }
+            }
+        },
+        "http.WalkStepPresenter": {
+            "description": "Question asked at a node and the answer chosen to leave it",
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "$ref": "#/definitions/http.AnswerPresenter"
+                },
+                "node_id": {
+                    "type": "string",
+                    "example": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655"
+                },
+                "question": {
+                    "type": "string",
+                    "example": "Were you discriminated against?"
+                }
+            }
+        },
+        "http.WebhookDeliveryListPresenter": {
+            "description": "Latest deliveries of a webhook, most recent first",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.WebhookDeliveryPresenter"
+                    }
+                }
+            }
+        },
+        "http.WebhookDeliveryPresenter": {
+            "description": "Attempts to deliver an event to a webhook",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "event": {
+                    "$ref": "#/definitions/http.EventPresenter"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "e5f6a7b8-c9d0-4e1f-8a2b-3c4d5e6f7081"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "webhook responded with status 503"
+                },
+                "next_attempt_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:02Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "succeeded",
+                        "failed"
+                    ],
+                    "example": "succeeded"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:01Z"
+                },
+                "webhook_id": {
+                    "type": "string",
+                    "example": "d4e5f6a7-b8c9-4d0e-8f1a-2b3c4d5e6f70"
+                }
+            }
+        },
+        "http.WebhookListPresenter": {
+            "description": "Registered webhooks, ordered by creation date",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.WebhookPresenter"
+                    }
+                }
+            }
+        },
+        "http.WebhookPresenter": {
+            "description": "Endpoint of an external system notified of DAG and session events",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dag.created",
+                        "session.completed"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "d4e5f6a7-b8c9-4d0e-8f1a-2b3c4d5e6f70"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "5f2b...e91c"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://cases.example.com/hooks/jurigen"
+                }
+            }
+        },
+        "http.WebhookRequest": {
+            "description": "Webhook endpoint and subscriptions",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dag.created",
+                        "session.completed"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "my-shared-secret-value"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://cases.example.com/hooks/jurigen"
+                }
+            }
+        },
         "xhttp.ErrorResponse": {
             "description": "Standard error response format for API failures",
             "type": "object",
@@ -636,6 +5719,10 @@ const docTemplate = `{
         {
             "description": "Operations for managing and retrieving Legal Case DAGs",
             "name": "DAGs"
+        },
+        {
+            "description": "Build information of the running server",
+            "name": "Version"
         }
     ]
 }`
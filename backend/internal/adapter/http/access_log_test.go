@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_AccessLog(t *testing.T) {
+	var logs bytes.Buffer
+	globalLogger := log.Logger
+	log.Logger = zerolog.New(&logs)
+	t.Cleanup(func() { log.Logger = globalLogger })
+
+	stored := dagtest.ValidSingleRoot()
+	authFn := xhttp.StaticUsersFn([]auth.StaticUser{
+		{Username: "viewer", Password: "secret", Role: user.RoleViewer},
+	})
+	viewerID := uuid.NewSHA1(uuid.NameSpaceOID, []byte("viewer"))
+
+	// accessLog returns the access log line of the last request
+	accessLog := func(t *testing.T) map[string]interface{} {
+		defer logs.Reset()
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			if entry["message"] == "request completed" {
+				return entry
+			}
+		}
+		t.Fatalf("no access log in %q", logs.String())
+		return nil
+	}
+
+	t.Run("logs the request with its user, DAG and request ID", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().Get(gomock.Any(), usecase.CmdGetDAG{DAGId: stored.Id.String()}).Return(stored, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/dags/"+stored.Id.String(), nil)
+		req.SetBasicAuth("viewer", "secret")
+		req.Header.Set(xhttp.RequestIDHeader, "req-access-log")
+		rr := httptest.NewRecorder()
+		New(mockApp, authFn, Config{}).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		entry := accessLog(t)
+		assert.Equal(t, "info", entry["level"])
+		assert.Equal(t, http.MethodGet, entry["method"])
+		assert.Equal(t, "/v1/dags/"+stored.Id.String(), entry["path"])
+		assert.Equal(t, "/v1/dags/{dagId}", entry["route"])
+		assert.Equal(t, float64(http.StatusOK), entry["status"])
+		assert.Contains(t, entry, "latency_ms")
+		assert.Equal(t, viewerID.String(), entry["user_id"])
+		assert.Equal(t, stored.Id.String(), entry["dag_id"])
+		assert.Equal(t, "req-access-log", entry["request_id"])
+	})
+
+	t.Run("logs rejected requests without user as warnings", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/dags", nil)
+		rr := httptest.NewRecorder()
+		New(mocks.NewMockApp(gomock.NewController(t)), authFn, Config{}).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		entry := accessLog(t)
+		assert.Equal(t, "warn", entry["level"])
+		assert.Equal(t, float64(http.StatusUnauthorized), entry["status"])
+		assert.NotContains(t, entry, "user_id")
+		assert.NotContains(t, entry, "dag_id")
+		assert.NotEmpty(t, entry["request_id"])
+	})
+
+	t.Run("replaces a request ID unfit for the logs", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().Get(gomock.Any(), gomock.Any()).Return(stored, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/dags/"+stored.Id.String(), nil)
+		req.SetBasicAuth("viewer", "secret")
+		req.Header.Set(xhttp.RequestIDHeader, strings.Repeat("x", 200))
+		rr := httptest.NewRecorder()
+		New(mockApp, authFn, Config{}).ServeHTTP(rr, req)
+
+		requestID := rr.Header().Get(xhttp.RequestIDHeader)
+		assert.NoError(t, uuid.Validate(requestID))
+		assert.Equal(t, requestID, accessLog(t)["request_id"])
+	})
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DAGSyncer persists the DAGs changed in memory to files, which lag behind when write-through is disabled
+type DAGSyncer interface {
+	SyncDirty(ctx context.Context) (int, error)
+	SyncStatus() model.SyncStatus
+}
+
+// SyncPresenter represents the outcome of a sync of the DAGs to files
+//
+// @Description Outcome of a sync of the DAGs changed in memory to files
+// @Example {"synced": 2, "dirty": 0, "last_sync_at": "2024-01-15T10:30:00Z"}
+type SyncPresenter struct {
+	Synced     int        `json:"synced" example:"2" description:"Number of DAGs written to or removed from files"`
+	Dirty      int        `json:"dirty" example:"0" description:"Number of DAGs still waiting to be persisted"`
+	LastSyncAt *time.Time `json:"last_sync_at,omitempty" example:"2024-01-15T10:30:00Z" description:"End of the last sync"`
+}
+
+type adminHandler struct {
+	syncer DAGSyncer
+}
+
+func NewAdminHandler(syncer DAGSyncer) *adminHandler {
+	return &adminHandler{
+		syncer: syncer,
+	}
+}
+
+// Sync persists the DAGs changed in memory to files
+//
+// @Summary Sync DAGs to files
+// @Description Write the DAGs changed in memory since the last sync to their files, and remove the files of the deleted DAGs.
+// @Description DAGs are persisted on every change with write-through, there is then nothing to sync.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} SyncPresenter "DAGs synced"
+// @Failure 500 {object} xhttp.ErrorResponse "Some DAGs could not be synced"
+// @Failure 503 {object} xhttp.ErrorResponse "Sync unavailable"
+// @Security ApiKeyAuth
+// @Router /admin/sync [post]
+func (h *adminHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.syncer == nil {
+		xhttp.WriteError(ctx, w, http.StatusServiceUnavailable, "sync unavailable", errors.New("no DAG syncer configured"))
+		return
+	}
+
+	synced, err := h.syncer.SyncDirty(ctx)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Int("synced", synced).Msg("failed to sync DAGs")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to sync DAGs", err)
+		return
+	}
+
+	status := h.syncer.SyncStatus()
+	xhttp.WriteObject(ctx, w, http.StatusOK, SyncPresenter{
+		Synced:     synced,
+		Dirty:      status.Dirty,
+		LastSyncAt: status.LastSyncAt,
+	})
+}
+
+// syncMetrics exposes the DAGs waiting to be persisted to files
+type syncMetrics struct {
+	syncer DAGSyncer
+}
+
+// WriteMetrics writes the sync gauges in the Prometheus text format
+func (m syncMetrics) WriteMetrics(w io.Writer) {
+	now := time.Now()
+	status := m.syncer.SyncStatus()
+
+	fmt.Fprintln(w, "# HELP jurigen_dag_sync_dirty DAGs changed in memory and not yet persisted to files.")
+	fmt.Fprintln(w, "# TYPE jurigen_dag_sync_dirty gauge")
+	fmt.Fprintf(w, "jurigen_dag_sync_dirty %d\n", status.Dirty)
+	fmt.Fprintln(w, "# HELP jurigen_dag_sync_lag_seconds Age of the oldest change not yet persisted to files.")
+	fmt.Fprintln(w, "# TYPE jurigen_dag_sync_lag_seconds gauge")
+	fmt.Fprintf(w, "jurigen_dag_sync_lag_seconds %g\n", status.Lag(now).Seconds())
+	if status.LastSyncAt != nil {
+		fmt.Fprintln(w, "# HELP jurigen_dag_sync_last_timestamp_seconds End of the last sync of the DAGs to files.")
+		fmt.Fprintln(w, "# TYPE jurigen_dag_sync_last_timestamp_seconds gauge")
+		fmt.Fprintf(w, "jurigen_dag_sync_last_timestamp_seconds %d\n", status.LastSyncAt.Unix())
+	}
+}
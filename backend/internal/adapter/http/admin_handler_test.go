@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSyncer syncs its dirty DAGs, failing with err when set
+type fakeSyncer struct {
+	status model.SyncStatus
+	err    error
+}
+
+func (s *fakeSyncer) SyncDirty(context.Context) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	synced := s.status.Dirty
+	now := time.Now()
+	s.status = model.SyncStatus{LastSyncAt: &now}
+	return synced, nil
+}
+
+func (s *fakeSyncer) SyncStatus() model.SyncStatus {
+	return s.status
+}
+
+func TestAdminHandler_Sync(t *testing.T) {
+	dirtySince := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name           string
+		syncer         *fakeSyncer
+		expectedStatus int
+		check          func(t *testing.T, body []byte)
+	}{
+		{
+			name:           "syncs the dirty DAGs",
+			syncer:         &fakeSyncer{status: model.SyncStatus{Dirty: 2, OldestDirtyAt: &dirtySince}},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				var presenter SyncPresenter
+				require.NoError(t, json.Unmarshal(body, &presenter))
+				assert.Equal(t, 2, presenter.Synced)
+				assert.Equal(t, 0, presenter.Dirty)
+				assert.NotNil(t, presenter.LastSyncAt)
+			},
+		},
+		{
+			name:           "reports a failed sync",
+			syncer:         &fakeSyncer{status: model.SyncStatus{Dirty: 1, OldestDirtyAt: &dirtySince}, err: errors.New("disk full")},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "is unavailable without syncer",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{}
+			if tt.syncer != nil {
+				config.Sync = tt.syncer
+			}
+
+			rr := httptest.NewRecorder()
+			New(mocks.NewMockApp(gomock.NewController(t)), nil, config).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/admin/sync", nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.check != nil {
+				tt.check(t, rr.Body.Bytes())
+			}
+		})
+	}
+
+	t.Run("is left to admins", func(t *testing.T) {
+		authFn := xhttp.StaticUsersFn([]auth.StaticUser{
+			{Username: "editor", Password: "secret", Role: user.RoleEditor},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/sync", nil)
+		req.SetBasicAuth("editor", "secret")
+
+		rr := httptest.NewRecorder()
+		New(mocks.NewMockApp(gomock.NewController(t)), authFn, Config{Sync: &fakeSyncer{}}).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestRouter_SyncMetrics(t *testing.T) {
+	dirtySince := time.Now().Add(-90 * time.Second)
+	lastSyncAt := time.Unix(1700000000, 0)
+	syncer := &fakeSyncer{status: model.SyncStatus{Dirty: 3, OldestDirtyAt: &dirtySince, LastSyncAt: &lastSyncAt}}
+
+	rr := httptest.NewRecorder()
+	New(mocks.NewMockApp(gomock.NewController(t)), nil, Config{Sync: syncer, ThrottleCounter: xhttp.NewThrottleCounter()}).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, "# TYPE jurigen_http_throttled_requests_total counter")
+	assert.Contains(t, body, "jurigen_dag_sync_dirty 3\n")
+	assert.Regexp(t, `jurigen_dag_sync_lag_seconds 9\d\.\d+\n`, body)
+	assert.Contains(t, body, "jurigen_dag_sync_last_timestamp_seconds 1700000000\n")
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_Authorization(t *testing.T) {
+	stored := dagtest.ValidSingleRoot()
+	body, err := json.Marshal(NewDAGPresenter(stored))
+	require.NoError(t, err)
+
+	authFn := xhttp.StaticUsersFn([]auth.StaticUser{
+		{Username: "viewer", Password: "secret", Role: user.RoleViewer},
+		{Username: "editor", Password: "secret", Role: user.RoleEditor},
+		{Username: "admin", Password: "secret", Role: user.RoleAdmin},
+	})
+
+	tests := []struct {
+		name           string
+		username       string
+		method         string
+		path           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+	}{
+		{
+			name:           "rejects anonymous requests",
+			method:         http.MethodGet,
+			path:           "/v1/dags/" + stored.Id.String(),
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:     "lets viewers read DAGs",
+			username: "viewer",
+			method:   http.MethodGet,
+			path:     "/v1/dags/" + stored.Id.String(),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Get(gomock.Any(), gomock.Any()).Return(stored, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "forbids viewers to change DAGs",
+			username:       "viewer",
+			method:         http.MethodPut,
+			path:           "/v1/dags/" + stored.Id.String(),
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:     "lets editors change DAGs",
+			username: "editor",
+			method:   http.MethodPut,
+			path:     "/v1/dags/" + stored.Id.String(),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Update(gomock.Any(), gomock.Any()).Return(stored, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "forbids editors to delete DAGs",
+			username:       "editor",
+			method:         http.MethodDelete,
+			path:           "/v1/dags/" + stored.Id.String(),
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:     "lets admins delete DAGs",
+			username: "admin",
+			method:   http.MethodDelete,
+			path:     "/v1/dags/" + stored.Id.String(),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "forbids editors to manage webhooks",
+			username:       "editor",
+			method:         http.MethodGet,
+			path:           "/v1/webhooks",
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:     "lets admins manage webhooks",
+			username: "admin",
+			method:   http.MethodGet,
+			path:     "/v1/webhooks",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ListWebhooks(gomock.Any()).Return([]model.Webhook{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			tt.setupMock(mockApp)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader(body))
+			if tt.username != "" {
+				req.SetBasicAuth(tt.username, "secret")
+			}
+
+			rr := httptest.NewRecorder()
+			New(mockApp, authFn, Config{}).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// eventStreamKeepAlive is the interval of the comments keeping idle event streams open through proxies
+const eventStreamKeepAlive = 15 * time.Second
+
+// EventSubscriber subscribes to the application events, the channel is closed when ctx is cancelled
+type EventSubscriber interface {
+	Subscribe(ctx context.Context) <-chan model.Event
+}
+
+// Events streams the events of a DAG as Server-Sent Events
+//
+// @Summary Stream Legal Case DAG events
+// @Description Stream the events of a DAG (updates, validations, deletion, completed sessions) as Server-Sent Events until the client disconnects.
+// @Description Each message carries the event ID, the event type as SSE event name and the event as JSON data. The stream ends after the DAG deletion.
+// @Tags DAGs
+// @Produce text/event-stream
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Success 200 {object} EventPresenter "Stream of DAG events"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Failure 503 {object} xhttp.ErrorResponse "Event stream unavailable"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/events [get]
+func (h *dagHandler) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.events == nil {
+		xhttp.WriteError(ctx, w, http.StatusServiceUnavailable, "event stream unavailable", errors.New("no event subscriber configured"))
+		return
+	}
+
+	dag, err := h.app.Get(ctx, usecase.CmdGetDAG{
+		DAGId: mux.Vars(r)[dagId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to stream DAG events")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get DAG", err)
+		}
+		return
+	}
+
+	events := h.events.Subscribe(ctx)
+
+	// The server write timeout would cut the stream, it is lifted for this response only
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		xlog.Ctx(ctx).Warn().Err(err).Msg("failed to lift the write deadline of the event stream")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("event stream not supported by the response writer")
+		return
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.DAGId != dag.Id {
+				continue
+			}
+			if err := writeServerSentEvent(w, event); err != nil {
+				xlog.Ctx(ctx).Debug().Err(err).Msg("event stream closed")
+				return
+			}
+			if event.Type == model.EventDAGDeleted {
+				_ = rc.Flush()
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeServerSentEvent writes an event as a Server-Sent Events message
+func writeServerSentEvent(w http.ResponseWriter, event model.Event) error {
+	data, err := json.Marshal(EventPresenter(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+	return err
+}
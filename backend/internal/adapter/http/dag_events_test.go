@@ -0,0 +1,93 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/eventbus"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Events(t *testing.T) {
+	dag := model.NewDAG("Dismissal")
+
+	t.Run("streams the DAG events until its deletion", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().Get(gomock.Any(), usecase.CmdGetDAG{DAGId: dag.Id.String()}).Return(dag, nil)
+		bus := eventbus.New()
+		server := httptest.NewServer(New(mockApp, nil, Config{Events: bus}))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/v1/dags/" + dag.Id.String() + "/events")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		// The subscription is registered once the response headers are sent
+		now := time.Now()
+		updated := model.NewEvent(model.EventDAGUpdated, dag.Id, now)
+		require.NoError(t, bus.Publish(context.Background(), model.NewEvent(model.EventDAGUpdated, uuid.New(), now)))
+		require.NoError(t, bus.Publish(context.Background(), updated))
+		require.NoError(t, bus.Publish(context.Background(), model.NewEvent(model.EventDAGDeleted, dag.Id, now)))
+
+		done := make(chan string)
+		go func() {
+			body, _ := io.ReadAll(resp.Body)
+			done <- string(body)
+		}()
+
+		var body string
+		select {
+		case body = <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream not closed after the DAG deletion")
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(body))
+		var names []string
+		for scanner.Scan() {
+			if name, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				names = append(names, name)
+			}
+		}
+		assert.Equal(t, []string{model.EventDAGUpdated, model.EventDAGDeleted}, names, "events of other DAGs are filtered out")
+		assert.Contains(t, body, "id: "+updated.Id.String()+"\n")
+		assert.Contains(t, body, `data: {"id":"`+updated.Id.String()+`","type":"dag.updated"`)
+	})
+
+	t.Run("returns 404 for unknown DAG", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/dags/"+dag.Id.String()+"/events", nil)
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{Events: eventbus.New()}).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "DAG not found")
+	})
+
+	t.Run("returns 503 without event subscriber", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/dags/"+dag.Id.String()+"/events", nil)
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), "event stream unavailable")
+	})
+}
@@ -2,16 +2,22 @@ package http
 
 import (
 	"context"
+	"davidterranova/jurigen/backend/internal/export"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
 	"davidterranova/jurigen/backend/pkg/xhttp"
+	"davidterranova/jurigen/backend/pkg/xlog"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
-	"github.com/rs/zerolog/log"
 )
 
 //go:generate go run github.com/golang/mock/mockgen -source=dag_handler.go -destination=testdata/mocks/app_mock.go -package=mocks
@@ -19,13 +25,74 @@ import (
 type App interface {
 	Get(ctx context.Context, cmd usecase.CmdGetDAG) (*model.DAG, error)
 	List(ctx context.Context, cmd usecase.CmdListDAGs) ([]uuid.UUID, error)
-	ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) ([]*model.DAG, error)
+	ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) (*model.DAGPage, error)
+	Create(ctx context.Context, cmd usecase.CmdCreateDAG) (*model.DAG, error)
 	Update(ctx context.Context, cmd usecase.CmdUpdateDAG) (*model.DAG, error)
+	Delete(ctx context.Context, cmd usecase.CmdDeleteDAG) error
+	PreviewUpdate(ctx context.Context, cmd usecase.CmdUpdateDAG) (*usecase.UpdatePreview, error)
+	ValidateDAG(ctx context.Context, d *model.DAG, rules map[string]usecase.Severity) (usecase.ValidationResult, error)
 	ValidateStoredDAG(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*usecase.ValidationResult, error)
+	ValidateAllDAGs(ctx context.Context, cmd usecase.CmdValidateAllDAGs) (*usecase.BulkValidationReport, error)
+	EnqueueDAGValidation(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*model.Job, error)
+	MergeAnswerMetadata(ctx context.Context, cmd usecase.CmdMergeAnswerMetadata) (*model.Answer, error)
+	InsertNode(ctx context.Context, cmd usecase.CmdInsertNode) (*model.DAG, error)
+	SplitNode(ctx context.Context, cmd usecase.CmdSplitNode) (*model.DAG, error)
+	CheckNodeReferences(ctx context.Context, cmd usecase.CmdCheckNodeReferences) (*usecase.NodeReferences, error)
+	EnumeratePaths(ctx context.Context, cmd usecase.CmdEnumeratePaths) (*usecase.PathList, error)
+	ExportDAG(ctx context.Context, cmd usecase.CmdExportDAG) (*usecase.ExportedDAG, error)
+	ImportDAG(ctx context.Context, cmd usecase.CmdImportDAG) (*usecase.ImportResult, error)
+	SearchDAGs(ctx context.Context, cmd usecase.CmdSearchDAGs) ([]model.SearchHit, error)
+	RecordWalk(ctx context.Context, cmd usecase.CmdRecordWalk) (*usecase.WalkResult, error)
+	GetPathAnalytics(ctx context.Context, cmd usecase.CmdGetPathAnalytics) ([]usecase.RankedPath, error)
+	ListDAGVersions(ctx context.Context, cmd usecase.CmdListDAGVersions) ([]model.DAGVersion, error)
+	GetDAGVersion(ctx context.Context, cmd usecase.CmdGetDAGVersion) (*model.DAGVersion, error)
+	RestoreDAGVersion(ctx context.Context, cmd usecase.CmdRestoreDAGVersion) (*model.DAG, error)
+	DiffDAGs(ctx context.Context, cmd usecase.CmdDiffDAGs) (*usecase.DAGChangeSet, error)
+	UpdateNode(ctx context.Context, cmd usecase.CmdUpdateNode) (*model.Node, error)
+	UpdateAnswer(ctx context.Context, cmd usecase.CmdUpdateAnswer) (*model.Answer, error)
+	ListDeletedDAGs(ctx context.Context) ([]*model.DAG, error)
+	RestoreDeletedDAG(ctx context.Context, cmd usecase.CmdRestoreDeletedDAG) (*model.DAG, error)
+	CloneDAG(ctx context.Context, cmd usecase.CmdCloneDAG) (*model.DAG, error)
+	MergeDAG(ctx context.Context, cmd usecase.CmdMergeDAG) (*usecase.MergeResult, error)
+	ExtractSubtree(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error)
+	MaterializeSubtree(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error)
+	GetMetadataSchema(ctx context.Context, cmd usecase.CmdGetMetadataSchema) (*model.MetadataSchema, error)
+	SetMetadataSchema(ctx context.Context, cmd usecase.CmdSetMetadataSchema) (*model.MetadataSchema, error)
+	DeleteMetadataSchema(ctx context.Context, cmd usecase.CmdDeleteMetadataSchema) error
+	StartSession(ctx context.Context, cmd usecase.CmdStartSession) (*model.CaseSession, error)
+	AnswerSession(ctx context.Context, cmd usecase.CmdAnswerSession) (*model.CaseSession, error)
+	ReviseSessionAnswer(ctx context.Context, cmd usecase.CmdReviseSessionAnswer) (*model.CaseSession, error)
+	CompleteSession(ctx context.Context, cmd usecase.CmdCompleteSession) (*model.CaseSession, error)
+	GetSession(ctx context.Context, cmd usecase.CmdGetSession) (*model.CaseSession, error)
+	GetSessionDocument(ctx context.Context, cmd usecase.CmdGetSessionDocument) (*usecase.SessionDocument, error)
+	BuildPrompt(ctx context.Context, cmd usecase.CmdBuildPrompt) (*usecase.BuiltPrompt, error)
+	AssessSession(ctx context.Context, cmd usecase.CmdAssessSession) (*model.CaseSession, error)
+	ScoreSession(ctx context.Context, cmd usecase.CmdScoreSession) (*model.Score, error)
+	AddAttachment(ctx context.Context, cmd usecase.CmdAddAttachment) (*model.Attachment, error)
+	GetAttachment(ctx context.Context, cmd usecase.CmdGetAttachment) (*usecase.AttachmentContent, error)
+
+	CreateWebhook(ctx context.Context, cmd usecase.CmdCreateWebhook) (*model.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]model.Webhook, error)
+	GetWebhook(ctx context.Context, cmd usecase.CmdGetWebhook) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, cmd usecase.CmdUpdateWebhook) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, cmd usecase.CmdDeleteWebhook) error
+	ListWebhookDeliveries(ctx context.Context, cmd usecase.CmdListWebhookDeliveries) ([]model.WebhookDelivery, error)
+
+	SaveAsTemplate(ctx context.Context, cmd usecase.CmdSaveAsTemplate) (*model.Template, error)
+	ListTemplates(ctx context.Context) ([]model.Template, error)
+	InstantiateTemplate(ctx context.Context, cmd usecase.CmdInstantiateTemplate) (*model.DAG, error)
+
+	GetJob(ctx context.Context, cmd usecase.CmdGetJob) (*model.Job, error)
 }
 
 type dagHandler struct {
 	app App
+	// preserveWhitespace disables the whitespace normalization of submitted questions and statements
+	preserveWhitespace bool
+	// cache serves DAG metadata reads from memory when set
+	cache *ResponseCache
+	// events streams the DAG events when set
+	events EventSubscriber
 }
 
 // ValidateRequest represents the request payload for DAG validation
@@ -33,6 +100,115 @@ type dagHandler struct {
 // @Description DAG validation request containing the DAG structure to validate
 type ValidateRequest struct {
 	DAG DAGPresenter `json:"dag" validate:"required"`
+	// Rules overrides the severity of validation rules for this request only
+	Rules map[string]string `json:"rules,omitempty" example:"DAG_MULTIPLE_ROOTS:warning" description:"Severity of validation rules by code (error, warning or off), overriding the server validation profile"`
+}
+
+// MergeRequest represents the request payload for merging a DAG into another one
+//
+// @Description Source DAG to graft and answer of the target DAG it is grafted beneath
+type MergeRequest struct {
+	SourceDAGId string `json:"source_dag_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"DAG grafted onto the target, left unchanged"`
+	AnswerId    string `json:"answer_id" example:"c4d5e6f7-a8b9-4c0d-9e1f-2a3b4c5d6e7f" description:"Answer of the target leading nowhere yet, which leads to the root of the source after the merge"`
+}
+
+// SubtreeRequest represents the request payload for materializing the subtree of a node
+//
+// @Description Options of the DAG created from a subtree
+type SubtreeRequest struct {
+	Title string `json:"title,omitempty" example:"Notice period" description:"Title of the new DAG, the question of the subtree root when omitted"`
+}
+
+// CloneRequest represents the request payload for cloning a DAG
+//
+// @Description Options of the DAG clone
+type CloneRequest struct {
+	Title string `json:"title,omitempty" example:"Employment Discrimination Case (copy)" description:"Title of the clone, the title of the cloned DAG when omitted"`
+}
+
+// DiffRequest represents the request payload for comparing two DAGs
+//
+// @Description Two DAGs to compare, each given as a DAG payload or as a version of the DAG identified by dag_id
+type DiffRequest struct {
+	DAGId  string             `json:"dag_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" description:"DAG whose versions are compared, required when an operand references a version"`
+	Before DiffOperandRequest `json:"before"`
+	After  DiffOperandRequest `json:"after"`
+}
+
+// DiffOperandRequest is one side of a diff request
+//
+// @Description Either a DAG payload or a version number
+type DiffOperandRequest struct {
+	DAG     *DAGPresenter `json:"dag,omitempty"`
+	Version int           `json:"version,omitempty" example:"2"`
+}
+
+// ImportResultPresenter represents the outcome of a DAG import
+//
+// @Description Detected format, converted DAG and its validation result, with whether the DAG was stored
+type ImportResultPresenter struct {
+	Format     string                    `json:"format" example:"graphml"`
+	Stored     bool                      `json:"stored" example:"true"`
+	DAG        DAGPresenter              `json:"dag"`
+	Validation ValidationResultPresenter `json:"validation"`
+}
+
+// AnswerMetadataRequest represents the request payload for merging answer metadata
+//
+// @Description Metadata keys to merge into an answer, null values remove the key
+type AnswerMetadataRequest struct {
+	Metadata map[string]interface{} `json:"metadata" validate:"required"`
+}
+
+// InsertNodeRequest represents the request payload for inserting a node after an answer
+//
+// @Description New node inserted between an answer and its current target
+type InsertNodeRequest struct {
+	Question string `json:"question" validate:"required" example:"Did you report the incident?"`
+	Answer   string `json:"answer" validate:"required" example:"Continue"`
+}
+
+// UpdateNodeRequest represents the request payload for a partial node edit
+//
+// @Description Node fields to change, omitted fields are left untouched
+type UpdateNodeRequest struct {
+	Question        *string `json:"question,omitempty" example:"Were you dismissed in writing?"`
+	Required        *bool   `json:"required,omitempty" example:"true"`
+	MultiSelect     *bool   `json:"multi_select,omitempty" example:"false"`
+	MultiSelectNext *string `json:"multi_select_next,omitempty" example:"priority" enums:"priority,convergence"`
+}
+
+// UpdateAnswerRequest represents the request payload for a partial answer edit
+//
+// @Description Answer fields to change, omitted fields are left untouched. An empty next_node makes the answer terminal.
+type UpdateAnswerRequest struct {
+	Statement   *string `json:"answer,omitempty" example:"Yes, by email"`
+	NextNode    *string `json:"next_node,omitempty" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655"`
+	UserContext *string `json:"user_context,omitempty" example:"Manager explicitly mentioned my age during termination"`
+	Disabled    *bool   `json:"disabled,omitempty" example:"false"`
+}
+
+// SplitNodeRequest represents the request payload for splitting a node into sub-questions
+//
+// @Description Grouping of all the answers of a node into buckets, each bucket becoming a sub-question
+type SplitNodeRequest struct {
+	Buckets []SplitBucketRequest `json:"buckets" validate:"required"`
+}
+
+// SplitBucketRequest represents a group of answers moved under a new sub-question
+//
+// @Description Answers moved under a new sub-question, reached from the split node through a new answer
+type SplitBucketRequest struct {
+	Answer    string   `json:"answer" validate:"required" example:"Employment matter"`
+	Question  string   `json:"question" validate:"required" example:"Which employment matter?"`
+	AnswerIds []string `json:"answer_ids" validate:"required" example:"fc28c4b6-d185-cf56-a7e4-dead499ff1e8"`
+}
+
+// WalkCompleteRequest represents the request payload for recording a completed walk
+//
+// @Description Answers chosen from the root node to the end of the walk, in order
+type WalkCompleteRequest struct {
+	AnswerIds []string `json:"answer_ids" validate:"required" example:"fc28c4b6-d185-cf56-a7e4-dead499ff1e8"`
 }
 
 // ValidationResultPresenter represents the validation result for API responses
@@ -70,15 +246,76 @@ type ValidationWarningPresenter struct {
 //
 // @Description Statistical information about the DAG structure and validation results
 type ValidationStatisticsPresenter struct {
-	TotalNodes   int      `json:"total_nodes" example:"5"`
-	RootNodes    int      `json:"root_nodes" example:"1"`
-	LeafNodes    int      `json:"leaf_nodes" example:"2"`
-	TotalAnswers int      `json:"total_answers" example:"12"`
-	MaxDepth     int      `json:"max_depth" example:"3"`
-	HasCycles    bool     `json:"has_cycles" example:"false"`
-	RootNodeIDs  []string `json:"root_node_ids,omitempty"`
-	LeafNodeIDs  []string `json:"leaf_node_ids,omitempty"`
-	CyclePaths   []string `json:"cycle_paths,omitempty"`
+	TotalNodes             int                  `json:"total_nodes" example:"5"`
+	RootNodes              int                  `json:"root_nodes" example:"1"`
+	LeafNodes              int                  `json:"leaf_nodes" example:"2"`
+	TotalAnswers           int                  `json:"total_answers" example:"12"`
+	MaxDepth               int                  `json:"max_depth" example:"3"`
+	HasCycles              bool                 `json:"has_cycles" example:"false"`
+	AvgBranchingFactor     float64              `json:"avg_branching_factor" example:"2.5"`
+	TotalEstimatedDamages  float64              `json:"total_estimated_damages,omitempty" example:"125000" description:"Sum of the damages_estimate metadata of every answer"`
+	TotalEstimatedDuration float64              `json:"total_estimated_duration,omitempty" example:"180" description:"Sum of the duration_estimate metadata of every answer"`
+	BranchingHistogram     map[int]int          `json:"branching_histogram,omitempty"`
+	RootNodeIDs            []string             `json:"root_node_ids,omitempty"`
+	LeafNodeIDs            []string             `json:"leaf_node_ids,omitempty"`
+	CyclePaths             []CyclePathPresenter `json:"cycle_paths,omitempty"`
+	TotalPaths             int                  `json:"total_paths,omitempty" example:"8" description:"Number of distinct root to leaf paths, saturating at the largest integer"`
+	MinPathLength          int                  `json:"min_path_length,omitempty" example:"2" description:"Number of answers given along the shortest path"`
+	MaxPathLength          int                  `json:"max_path_length,omitempty" example:"5" description:"Number of answers given along the longest path"`
+	AvgPathLength          float64              `json:"avg_path_length,omitempty" example:"3.5"`
+	LeafPaths              []LeafPathsPresenter `json:"leaf_paths,omitempty"`
+}
+
+// LeafPathsPresenter describes the paths ending on a node
+//
+// @Description Number and depth of the paths from the root node ending on a leaf or on a node holding terminal answers
+type LeafPathsPresenter struct {
+	LeafNodeID string  `json:"leaf_node_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Paths      int     `json:"paths" example:"3"`
+	MinDepth   int     `json:"min_depth" example:"2"`
+	MaxDepth   int     `json:"max_depth" example:"4"`
+	AvgDepth   float64 `json:"avg_depth" example:"3"`
+}
+
+func newLeafPathsPresenters(leafPaths []model.LeafPaths) []LeafPathsPresenter {
+	if len(leafPaths) == 0 {
+		return nil
+	}
+
+	presenters := make([]LeafPathsPresenter, len(leafPaths))
+	for i, paths := range leafPaths {
+		presenters[i] = LeafPathsPresenter{
+			LeafNodeID: paths.LeafNodeID,
+			Paths:      paths.Paths,
+			MinDepth:   paths.MinDepth,
+			MaxDepth:   paths.MaxDepth,
+			AvgDepth:   paths.AvgDepth,
+		}
+	}
+	return presenters
+}
+
+// CyclePathPresenter represents an elementary cycle of a DAG
+//
+// @Description Nodes of a cycle, closed on its first node, and the answer leading back to its first node
+type CyclePathPresenter struct {
+	NodeIDs         []string `json:"node_ids" example:"550e8400-e29b-41d4-a716-446655440000,6ba7b810-9dad-11d1-80b4-00c04fd430c8,550e8400-e29b-41d4-a716-446655440000"`
+	ClosingAnswerID string   `json:"closing_answer_id,omitempty" example:"fc28c4b6-d185-cf56-a7e4-dead499ff1e8"`
+}
+
+func newCyclePathPresenters(paths []model.CyclePath) []CyclePathPresenter {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	presenters := make([]CyclePathPresenter, len(paths))
+	for i, path := range paths {
+		presenters[i] = CyclePathPresenter{
+			NodeIDs:         path.NodeIDs,
+			ClosingAnswerID: path.ClosingAnswerID,
+		}
+	}
+	return presenters
 }
 
 func NewDAGHandler(app App) *dagHandler {
@@ -96,6 +333,7 @@ func NewDAGHandler(app App) *dagHandler {
 // @Produce json
 // @Param dagId path string true "DAG unique identifier (UUID)"
 // @Success 200 {object} DAGMetadataPresenter "Successfully retrieved DAG metadata"
+// @Header 200 {string} ETag "Revision of the DAG, to send back in If-Match on updates"
 // @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format"
 // @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
 // @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
@@ -106,11 +344,25 @@ func (h *dagHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	id := mux.Vars(r)[dagId]
 
+	// Malformed IDs are not cached, the app layer rejects them
+	cacheKey, cacheErr := uuid.Parse(id)
+	useCache := h.cache != nil && cacheErr == nil
+	var epoch uint64
+	if useCache {
+		cached, cacheEpoch, hit := h.cache.get(cacheKey)
+		if hit {
+			w.Header().Set("ETag", cached.etag)
+			writeJSONBody(ctx, w, http.StatusOK, cached.body)
+			return
+		}
+		epoch = cacheEpoch
+	}
+
 	dag, err := h.app.Get(ctx, usecase.CmdGetDAG{
 		DAGId: id,
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get DAG metadata")
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get DAG metadata")
 		switch {
 		case errors.Is(err, usecase.ErrInvalidCommand):
 			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
@@ -124,7 +376,31 @@ func (h *dagHandler) Get(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGMetadataPresenter(dag))
+	w.Header().Set("ETag", revisionETag(dag))
+	if !useCache {
+		xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGMetadataPresenter(dag))
+		return
+	}
+
+	body, err := json.Marshal(NewDAGMetadataPresenter(dag))
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get DAG", err)
+		return
+	}
+	// Match the trailing newline written by xhttp.WriteObject
+	body = append(body, '\n')
+	h.cache.put(cacheKey, epoch, cachedResponse{body: body, etag: revisionETag(dag)})
+
+	writeJSONBody(ctx, w, http.StatusOK, body)
+}
+
+// writeJSONBody writes an already marshaled JSON response
+func writeJSONBody(ctx context.Context, w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to write json body")
+	}
 }
 
 // GetContent retrieves the complete DAG content by its unique identifier
@@ -135,7 +411,9 @@ func (h *dagHandler) Get(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param lang query string false "Language code used to render translated questions and answers, falling back to the default text"
 // @Success 200 {object} DAGContentPresenter "Successfully retrieved DAG content"
+// @Header 200 {string} ETag "Revision of the DAG, to send back in If-Match on updates"
 // @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format"
 // @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
 // @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
@@ -150,7 +428,7 @@ func (h *dagHandler) GetContent(w http.ResponseWriter, r *http.Request) {
 		DAGId: id,
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get DAG content")
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get DAG content")
 		switch {
 		case errors.Is(err, usecase.ErrInvalidCommand):
 			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
@@ -164,190 +442,1781 @@ func (h *dagHandler) GetContent(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		dag = dag.Localize(lang)
+	}
+
+	w.Header().Set("ETag", revisionETag(dag))
 	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGContentPresenter(dag))
 }
 
-// List retrieves all available Legal Case DAGs with summary information
+// GetPaths lists the paths of a DAG from its root node, up to a limit
 //
-// @Summary List Legal Case DAGs
-// @Description Retrieve a list of all available Legal Case DAGs with ID, title, and validation status
+// @Summary List Legal Case DAG paths
+// @Description Enumerate the paths from the root node with the question and chosen answer of each step, for path review.
+// @Description The number of paths grows exponentially with the depth of a DAG, truncated is set when paths were left out by the limit.
 // @Tags DAGs
 // @Accept json
 // @Produce json
-// @Success 200 {object} DAGSummaryListPresenter "Successfully retrieved DAG list with summary information"
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param limit query int false "Maximum number of paths to return, 100 when omitted, at most 1000"
+// @Success 200 {object} PathListPresenter "Successfully enumerated DAG paths"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format, invalid limit or DAG cannot be walked"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
 // @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
-// @Router /dags [get]
-func (h *dagHandler) List(w http.ResponseWriter, r *http.Request) {
+// @Router /dags/{dagId}/paths [get]
+func (h *dagHandler) GetPaths(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	dags, err := h.app.ListDAGs(ctx, usecase.CmdListDAGs{})
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+	}
+
+	list, err := h.app.EnumeratePaths(ctx, usecase.CmdEnumeratePaths{
+		DAGId: mux.Vars(r)[dagId],
+		Limit: limit,
+	})
 	if err != nil {
-		log.Error().Err(err).Msg("failed to list DAGs")
-		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list DAGs", err)
-		return
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to enumerate DAG paths")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to enumerate DAG paths", err)
+			return
+		}
 	}
 
-	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGSummaryListPresenter(dags))
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewPathListPresenter(list))
 }
 
-// Update modifies an existing Legal Case DAG with new content
+// Export renders a DAG for visualization tools
 //
-// @Summary Update Legal Case DAG
-// @Description Update a complete Legal Case DAG structure including questions, answers, and context
+// @Summary Export Legal Case DAG
+// @Description Render a DAG for visualization tools: Graphviz DOT, GraphML (yEd, Gephi), Mermaid flowchart or canonical JSON. Every node is a question and every edge an answer statement.
 // @Tags DAGs
-// @Accept json
+// @Produce plain
+// @Produce xml
 // @Produce json
 // @Param dagId path string true "DAG unique identifier (UUID)"
-// @Param dag body DAGPresenter true "Updated DAG structure"
-// @Success 200 {object} DAGPresenter "Successfully updated DAG"
-// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body or DAG ID format"
+// @Param format query string false "Export format" Enums(dot, graphml, mermaid, json) default(dot)
+// @Param highlight_leaves query bool false "Mark the leaf nodes"
+// @Param highlight_cycles query bool false "Mark the edges of the cycles detected by the validator"
+// @Success 200 {string} string "DAG rendered in the requested format"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format, format or highlight parameter"
 // @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
 // @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
-// @Router /dags/{dagId} [put]
-func (h *dagHandler) Update(w http.ResponseWriter, r *http.Request) {
+// @Router /dags/{dagId}/export [get]
+func (h *dagHandler) Export(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	id := mux.Vars(r)[dagId]
+	cmd := usecase.CmdExportDAG{
+		DAGId:  mux.Vars(r)[dagId],
+		Format: r.URL.Query().Get("format"),
+	}
+	if cmd.Format == "" {
+		cmd.Format = export.FormatDOT
+	}
 
-	// Parse the request body
-	var dagRequest DAGPresenter
-	err := json.NewDecoder(r.Body).Decode(&dagRequest)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to decode request body")
-		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+	var err error
+	if cmd.HighlightLeaves, err = parseBoolQuery(r, "highlight_leaves"); err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid highlight_leaves parameter", err)
+		return
+	}
+	if cmd.HighlightCycles, err = parseBoolQuery(r, "highlight_cycles"); err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid highlight_cycles parameter", err)
 		return
 	}
 
-	// Convert presenter to DAG
-	dagToUpdate := h.presenterToDAG(dagRequest)
-
-	// Execute the update
-	updatedDAG, err := h.app.Update(ctx, usecase.CmdUpdateDAG{
-		DAGId: id,
-		DAG:   dagToUpdate,
-	})
+	exported, err := h.app.ExportDAG(ctx, cmd)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to update DAG")
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to export DAG")
 		switch {
 		case errors.Is(err, usecase.ErrInvalidCommand):
-			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG data", err)
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid export request", err)
 			return
 		case errors.Is(err, usecase.ErrNotFound):
 			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
 			return
 		default:
-			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update DAG", err)
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to export DAG", err)
 			return
 		}
 	}
 
-	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(updatedDAG))
+	w.Header().Set("Content-Type", exported.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(exported.Data); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to write DAG export")
+	}
 }
 
-// ValidateDAG validates a DAG structure without saving it
+// List retrieves the available Legal Case DAGs with summary information, optionally filtered, sorted and paginated
 //
-// @Summary Validate Legal Case DAG
-// @Description Validate a DAG structure to ensure it meets all requirements (single root node, acyclic, valid relationships)
+// @Summary List Legal Case DAGs
+// @Description Retrieve the available Legal Case DAGs with ID, title, and validation status, optionally filtered by title or update time, sorted and paginated. The response carries the total number of matching DAGs.
 // @Tags DAGs
 // @Accept json
 // @Produce json
-// @Param dag body ValidateRequest true "DAG structure to validate"
-// @Success 200 {object} ValidationResultPresenter "DAG validation completed (may contain errors)"
-// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body"
+// @Param sort query string false "Order by title or update time, prefix with - for descending order; updated and -updated are kept as aliases of updated_at and -updated_at" Enums(title, -title, updated_at, -updated_at, updated, -updated)
+// @Param updated_since query string false "Only list DAGs updated at or after this RFC3339 time"
+// @Param title_contains query string false "Only list DAGs whose title contains this text, case insensitive"
+// @Param page query int false "1-based page to return, every DAG is listed when neither page nor page_size is set"
+// @Param page_size query int false "Number of DAGs per page, 20 when only page is set, at most 100"
+// @Param compute_missing query bool false "Compute the validity of DAGs without validation metadata instead of reporting it as unknown"
+// @Success 200 {object} DAGSummaryPagePresenter "Successfully retrieved DAG list with summary information"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid sort, updated_since, page, page_size or compute_missing parameter"
 // @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
-// @Router /dags/validate [post]
-func (h *dagHandler) ValidateDAG(w http.ResponseWriter, r *http.Request) {
+// @Router /dags [get]
+func (h *dagHandler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Parse the request body
-	var validateRequest ValidateRequest
-	err := json.NewDecoder(r.Body).Decode(&validateRequest)
+	cmd := usecase.CmdListDAGs{
+		Sort:          r.URL.Query().Get("sort"),
+		TitleContains: r.URL.Query().Get("title_contains"),
+	}
+	if value := r.URL.Query().Get("updated_since"); value != "" {
+		updatedSince, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid updated_since parameter", err)
+			return
+		}
+		cmd.UpdatedSince = updatedSince
+	}
+	if value := r.URL.Query().Get("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid page parameter", err)
+			return
+		}
+		cmd.Page = page
+	}
+	if value := r.URL.Query().Get("page_size"); value != "" {
+		pageSize, err := strconv.Atoi(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid page_size parameter", err)
+			return
+		}
+		cmd.PageSize = pageSize
+	}
+
+	computeMissing, err := parseBoolQuery(r, "compute_missing")
 	if err != nil {
-		log.Error().Err(err).Msg("failed to decode validation request body")
-		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid compute_missing parameter", err)
 		return
 	}
 
-	// Convert presenter to DAG
-	dagToValidate := h.presenterToDAG(validateRequest.DAG)
-
-	// Validate the DAG using the validator service
-	validator := usecase.NewDAGValidator()
-	validationResult := validator.ValidateDAG(dagToValidate)
+	page, err := h.app.ListDAGs(ctx, cmd)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to list DAGs")
+		if errors.Is(err, usecase.ErrInvalidCommand) {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid list parameters", err)
+			return
+		}
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list DAGs", err)
+		return
+	}
 
-	// Convert validation result to presenter format
-	resultPresenter := h.validationResultToPresenter(validationResult)
+	var validator *usecase.DAGValidator
+	if computeMissing {
+		validator = usecase.NewDAGValidator()
+	}
 
-	// Return validation results (always 200 OK, even if DAG is invalid)
-	xhttp.WriteObject(ctx, w, http.StatusOK, resultPresenter)
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGSummaryPagePresenter(page, validator))
 }
 
-// ValidateStoredDAG validates an existing stored DAG and persists the validation metadata
+// Create stores a new Legal Case DAG
 //
-// @Summary Validate stored Legal Case DAG
-// @Description Validates an existing Legal Case DAG by ID, persisting validation results including status and statistics to the DAG metadata
+// @Summary Create Legal Case DAG
+// @Description Create a Legal Case DAG from its questions and answers. The server assigns an ID when the payload carries none.
 // @Tags DAGs
 // @Accept json
 // @Produce json
-// @Param dagId path string true "DAG unique identifier (UUID)"
-// @Success 200 {object} ValidationResultPresenter "DAG validation completed successfully (may contain validation errors)"
-// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format"
-// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
-// @Failure 500 {object} xhttp.ErrorResponse "Internal server error during validation"
+// @Param strict query bool false "Reject payloads containing unknown fields"
+// @Param dag body DAGPresenter true "DAG structure to create"
+// @Success 201 {object} DAGPresenter "Successfully created DAG"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, invalid DAG or DAG ID already in use"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
-// @Router /dags/{dagId}/validate [post]
-func (h *dagHandler) ValidateStoredDAG(w http.ResponseWriter, r *http.Request) {
+// @Router /dags [post]
+func (h *dagHandler) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	id := mux.Vars(r)[dagId]
+	var dagRequest DAGPresenter
+	err := decodeRequestBody(r, &dagRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
 
-	// Validate and execute the stored DAG validation
-	validationResult, err := h.app.ValidateStoredDAG(ctx, usecase.CmdValidateStoredDAG{
-		DAGId: id,
+	createdDAG, err := h.app.Create(ctx, usecase.CmdCreateDAG{
+		DAG: h.presenterToDAG(dagRequest),
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("failed to validate stored DAG")
-		switch {
-		case errors.Is(err, usecase.ErrInvalidCommand):
-			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to create DAG")
+		if errors.Is(err, usecase.ErrInvalidCommand) {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG data", err)
 			return
-		case errors.Is(err, usecase.ErrNotFound):
-			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		}
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to create DAG", err)
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewDAGPresenter(createdDAG))
+}
+
+// Import converts a DAG authored with another tool and stores it
+//
+// @Summary Import Legal Case DAG
+// @Description Convert a JSON, YAML or GraphML document into a DAG and validate it. The format is detected from the content unless given.
+// @Description Valid DAGs are stored unless dry_run is set, invalid ones are never stored and are returned with their validation errors.
+// @Tags DAGs
+// @Accept json
+// @Accept plain
+// @Accept xml
+// @Produce json
+// @Param format query string false "Format of the document, detected when omitted" Enums(json, yaml, graphml)
+// @Param dry_run query bool false "Validate the imported DAG without storing it"
+// @Param skip_validation_metadata query bool false "Store the DAG without recording its validation in its metadata, e.g. for bulk imports validated afterwards through /dags/validate-all"
+// @Param document body string true "Document to import"
+// @Success 200 {object} ImportResultPresenter "DAG converted and validated, not stored (dry run)"
+// @Success 201 {object} ImportResultPresenter "DAG converted, validated and stored"
+// @Failure 400 {object} xhttp.ErrorResponse "Unreadable or empty body, unknown format, malformed document or DAG ID already in use"
+// @Failure 422 {object} ImportResultPresenter "Imported DAG is invalid, validation errors are returned"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/import [post]
+func (h *dagHandler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dryRun, err := parseBoolQuery(r, "dry_run")
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid dry_run parameter", err)
+		return
+	}
+	skipValidationMetadata, err := parseBoolQuery(r, "skip_validation_metadata")
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid skip_validation_metadata parameter", err)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to read import body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	result, err := h.app.ImportDAG(ctx, usecase.CmdImportDAG{
+		Data:                   data,
+		Format:                 r.URL.Query().Get("format"),
+		DryRun:                 dryRun,
+		NormalizeText:          !h.preserveWhitespace,
+		SkipValidationMetadata: skipValidationMetadata,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to import DAG")
+		if errors.Is(err, usecase.ErrInvalidCommand) {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid import document", err)
 			return
-		default:
-			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to validate stored DAG", err)
+		}
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to import DAG", err)
+		return
+	}
+
+	status := http.StatusOK
+	switch {
+	case result.Stored:
+		status = http.StatusCreated
+	case !result.Validation.IsValid:
+		status = http.StatusUnprocessableEntity
+	}
+
+	xhttp.WriteObject(ctx, w, status, ImportResultPresenter{
+		Format:     result.Format,
+		Stored:     result.Stored,
+		DAG:        NewDAGPresenter(result.DAG),
+		Validation: newValidationResultPresenter(result.Validation),
+	})
+}
+
+// Search runs a full-text search over the questions, answers and user contexts of every DAG
+//
+// @Summary Search Legal Case DAGs
+// @Description Search node questions, answer statements and answer user contexts across all DAGs.
+// @Description Every word of the query must match the start of a word of the text, case insensitive. Hits are ranked by number of matching words.
+// @Tags DAGs
+// @Produce json
+// @Param q query string true "Words to search for"
+// @Param limit query int false "Maximum number of hits to return, 20 when omitted, at most 100"
+// @Success 200 {object} SearchResultPresenter "Matching node texts with a snippet of the match"
+// @Failure 400 {object} xhttp.ErrorResponse "Missing query or invalid limit"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/search [get]
+func (h *dagHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid limit", err)
 			return
 		}
 	}
 
-	// Convert validation result to presenter format
-	resultPresenter := h.validationResultToPresenter(*validationResult)
+	hits, err := h.app.SearchDAGs(ctx, usecase.CmdSearchDAGs{
+		Query: r.URL.Query().Get("q"),
+		Limit: limit,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to search DAGs")
+		if errors.Is(err, usecase.ErrInvalidCommand) {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid search parameters", err)
+			return
+		}
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to search DAGs", err)
+		return
+	}
 
-	// Return validation results (always 200 OK, even if DAG is invalid)
-	xhttp.WriteObject(ctx, w, http.StatusOK, resultPresenter)
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewSearchResultPresenter(hits))
 }
 
-// validationResultToPresenter converts usecase ValidationResult to ValidationResultPresenter
-func (h *dagHandler) validationResultToPresenter(result usecase.ValidationResult) ValidationResultPresenter {
-	presenter := ValidationResultPresenter{
-		IsValid: result.IsValid,
-		Statistics: ValidationStatisticsPresenter{
-			TotalNodes:   result.Statistics.TotalNodes,
-			RootNodes:    result.Statistics.RootNodes,
-			LeafNodes:    result.Statistics.LeafNodes,
-			TotalAnswers: result.Statistics.TotalAnswers,
-			MaxDepth:     result.Statistics.MaxDepth,
-			HasCycles:    result.Statistics.HasCycles,
-		},
-	}
-
-	// Copy statistics directly as they're already strings
-	presenter.Statistics.RootNodeIDs = result.Statistics.RootNodeIDs
-	presenter.Statistics.LeafNodeIDs = result.Statistics.LeafNodeIDs
-	presenter.Statistics.CyclePaths = result.Statistics.CyclePaths
+// Update modifies an existing Legal Case DAG with new content
+//
+// @Summary Update Legal Case DAG
+// @Description Update a complete Legal Case DAG structure including questions, answers, and context.
+// @Description With dry_run the update is validated but not persisted, include_stats then returns the statistics before and after the update.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param dry_run query bool false "Validate the update without persisting it"
+// @Param include_stats query bool false "With dry_run, return the statistics before and after the update"
+// @Param strict query bool false "Reject payloads containing unknown fields"
+// @Param If-Match header string false "ETag of the DAG revision the update applies to"
+// @Param dag body DAGPresenter true "Updated DAG structure"
+// @Success 200 {object} DAGPresenter "Successfully updated DAG"
+// @Header 200 {string} ETag "Revision of the updated DAG"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, DAG ID format or If-Match header"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 412 {object} xhttp.ErrorResponse "DAG was modified since the If-Match revision"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId} [put]
+func (h *dagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id := mux.Vars(r)[dagId]
+
+	dryRun, err := parseBoolQuery(r, "dry_run")
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid dry_run parameter", err)
+		return
+	}
+
+	includeStats, err := parseBoolQuery(r, "include_stats")
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid include_stats parameter", err)
+		return
+	}
+
+	// Parse the request body
+	var dagRequest DAGPresenter
+	err = decodeRequestBody(r, &dagRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	ifRevision, err := parseIfMatch(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid If-Match header", err)
+		return
+	}
+
+	// Convert presenter to DAG
+	dagToUpdate := h.presenterToDAG(dagRequest)
+
+	if dryRun {
+		h.previewUpdate(w, r, id, dagToUpdate, includeStats)
+		return
+	}
+
+	// Execute the update
+	updatedDAG, err := h.app.Update(ctx, usecase.CmdUpdateDAG{
+		DAGId:      id,
+		DAG:        dagToUpdate,
+		IfRevision: ifRevision,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to update DAG")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG data", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+			return
+		case errors.Is(err, usecase.ErrPreconditionFailed):
+			xhttp.WriteError(ctx, w, http.StatusPreconditionFailed, "DAG was modified since it was read", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update DAG", err)
+			return
+		}
+	}
+
+	w.Header().Set("ETag", revisionETag(updatedDAG))
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(updatedDAG))
+}
+
+// Delete moves a Legal Case DAG to the trash
+//
+// @Summary Delete Legal Case DAG
+// @Description Move a Legal Case DAG to the trash, it can be restored until it is purged along with its walk analytics and versions. DAGs with recorded walks are only deleted with force.
+// @Tags DAGs
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param force query bool false "Delete the DAG even when walks were recorded on it"
+// @Success 204 "Successfully moved DAG to the trash"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format or force parameter"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Walks were recorded on the DAG"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId} [delete]
+func (h *dagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	force, err := parseBoolQuery(r, "force")
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid force parameter", err)
+		return
+	}
+
+	err = h.app.Delete(ctx, usecase.CmdDeleteDAG{
+		DAGId: mux.Vars(r)[dagId],
+		Force: force,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to delete DAG")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "DAG has recorded walks", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to delete DAG", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Trash lists the Legal Case DAGs moved to the trash
+//
+// @Summary List deleted Legal Case DAGs
+// @Description Retrieve the DAGs in the trash, the most recently deleted first. They are purged once kept longer than the server retention.
+// @Tags DAGs
+// @Produce json
+// @Success 200 {object} DAGSummaryListPresenter "Successfully retrieved deleted DAGs"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/trash [get]
+func (h *dagHandler) Trash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dags, err := h.app.ListDeletedDAGs(ctx)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to list deleted DAGs")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list deleted DAGs", err)
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGSummaryListPresenter(dags, nil))
+}
+
+// RestoreDeleted takes a Legal Case DAG out of the trash
+//
+// @Summary Restore deleted Legal Case DAG
+// @Description Take a DAG out of the trash along with its walk analytics and versions.
+// @Tags DAGs
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Success 200 {object} DAGPresenter "Successfully restored DAG"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 409 {object} xhttp.ErrorResponse "DAG is not in the trash"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/restore [post]
+func (h *dagHandler) RestoreDeleted(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	restored, err := h.app.RestoreDeletedDAG(ctx, usecase.CmdRestoreDeletedDAG{
+		DAGId: mux.Vars(r)[dagId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to restore deleted DAG")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "DAG is not deleted", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to restore deleted DAG", err)
+		}
+		return
+	}
+
+	w.Header().Set("ETag", revisionETag(restored))
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(restored))
+}
+
+// Clone stores a deep copy of a Legal Case DAG
+//
+// @Summary Clone Legal Case DAG
+// @Description Copy a DAG into a new DAG. The DAG, its nodes and its answers get fresh IDs and the links between nodes are remapped onto the new node IDs. The clone is validated and announced like any created DAG.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param options body CloneRequest false "Options of the clone"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 201 {object} DAGPresenter "Successfully created clone"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format, request body or title, or the clone is invalid"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/clone [post]
+func (h *dagHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// The options are optional, an empty body clones the DAG as is
+	var cloneRequest CloneRequest
+	if err := decodeRequestBody(r, &cloneRequest); err != nil && !errors.Is(err, io.EOF) {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode clone request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	clone, err := h.app.CloneDAG(ctx, usecase.CmdCloneDAG{
+		DAGId: mux.Vars(r)[dagId],
+		Title: cloneRequest.Title,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to clone DAG")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid clone request", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to clone DAG", err)
+		}
+		return
+	}
+
+	w.Header().Set("ETag", revisionETag(clone))
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewDAGPresenter(clone))
+}
+
+// Merge grafts a copy of another Legal Case DAG onto the DAG
+//
+// @Summary Merge Legal Case DAG
+// @Description Attach a copy of the source DAG beneath an answer of the DAG which leads nowhere yet, the answer then leads to the root of the source. Source node and answer IDs already used by the DAG are given fresh IDs, which are returned. The source DAG is left unchanged and the resulting DAG is re-validated before being stored.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "Target DAG unique identifier (UUID)"
+// @Param merge body MergeRequest true "Source DAG and target answer"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} MergeResultPresenter "Successfully merged DAG"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, identifier format, source DAG without a single root or resulting DAG"
+// @Failure 404 {object} xhttp.ErrorResponse "Target DAG, source DAG or answer not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Answer already leads to a node"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/merge [post]
+func (h *dagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var mergeRequest MergeRequest
+	if err := decodeRequestBody(r, &mergeRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode merge request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	result, err := h.app.MergeDAG(ctx, usecase.CmdMergeDAG{
+		TargetId: mux.Vars(r)[dagId],
+		SourceId: mergeRequest.SourceDAGId,
+		AnswerId: mergeRequest.AnswerId,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to merge DAG")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG merge", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG or answer not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "answer already leads to a node", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to merge DAG", err)
+		}
+		return
+	}
+
+	w.Header().Set("ETag", revisionETag(result.DAG))
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewMergeResultPresenter(*result))
+}
+
+// GetSubtree returns the part of a Legal Case DAG reachable from a node
+//
+// @Summary Get subtree of node
+// @Description Extract the nodes reachable from a node as a standalone DAG rooted at the node, for review or reuse. The subtree keeps the node and answer IDs of the DAG, gets a fresh DAG ID and is not stored.
+// @Tags DAGs
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param nodeId path string true "Node unique identifier (UUID)"
+// @Success 200 {object} DAGPresenter "Subtree rooted at the node"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid identifier format"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG or node not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/subtree/{nodeId} [get]
+func (h *dagHandler) GetSubtree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	subtree, err := h.app.ExtractSubtree(ctx, usecase.CmdExtractSubtree{
+		DAGId:  vars[dagId],
+		NodeId: vars[nodeId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to extract subtree")
+		writeSubtreeError(w, r, err, "failed to extract subtree")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(subtree))
+}
+
+// MaterializeSubtree stores the part of a Legal Case DAG reachable from a node as a new DAG
+//
+// @Summary Materialize subtree of node
+// @Description Store the nodes reachable from a node as a new DAG rooted at the node. Its nodes and answers get fresh IDs, it is validated and announced like any created DAG.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param nodeId path string true "Node unique identifier (UUID)"
+// @Param options body SubtreeRequest false "Options of the new DAG"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 201 {object} DAGPresenter "Successfully created DAG"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid identifier format, request body, title or resulting DAG"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG or node not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/subtree/{nodeId} [post]
+func (h *dagHandler) MaterializeSubtree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	// The options are optional, an empty body titles the DAG after its root question
+	var subtreeRequest SubtreeRequest
+	if err := decodeRequestBody(r, &subtreeRequest); err != nil && !errors.Is(err, io.EOF) {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode subtree request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	created, err := h.app.MaterializeSubtree(ctx, usecase.CmdExtractSubtree{
+		DAGId:  vars[dagId],
+		NodeId: vars[nodeId],
+		Title:  subtreeRequest.Title,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to materialize subtree")
+		writeSubtreeError(w, r, err, "failed to materialize subtree")
+		return
+	}
+
+	w.Header().Set("ETag", revisionETag(created))
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewDAGPresenter(created))
+}
+
+// writeSubtreeError maps the errors of the subtree extraction to statuses
+func writeSubtreeError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	ctx := r.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid subtree request", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG or node not found", err)
+	default:
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// previewUpdate validates an update without persisting it, optionally returning the statistics change
+func (h *dagHandler) previewUpdate(w http.ResponseWriter, r *http.Request, id string, dagToUpdate *model.DAG, includeStats bool) {
+	ctx := r.Context()
+
+	preview, err := h.app.PreviewUpdate(ctx, usecase.CmdUpdateDAG{
+		DAGId: id,
+		DAG:   dagToUpdate,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to preview DAG update")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG data", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to preview DAG update", err)
+			return
+		}
+	}
+
+	if includeStats {
+		xhttp.WriteObject(ctx, w, http.StatusOK, NewUpdatePreviewPresenter(*preview))
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(preview.DAG))
+}
+
+// decodeRequestBody decodes the JSON request body into v, with the strict query parameter set
+// fields v does not declare are rejected instead of silently dropped
+func decodeRequestBody(r *http.Request, v any) error {
+	strict, err := parseBoolQuery(r, "strict")
+	if err != nil {
+		return fmt.Errorf("invalid strict parameter: %w", err)
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+
+	return decoder.Decode(v)
+}
+
+// parseBoolQuery parses an optional boolean query parameter, defaulting to false
+func parseBoolQuery(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// revisionETag renders the revision of a DAG as a strong entity tag
+func revisionETag(dag *model.DAG) string {
+	return strconv.Quote(strconv.FormatUint(dag.Revision, 10))
+}
+
+// parseIfMatch returns the DAG revision required by the If-Match header, nil when the header is absent or "*"
+func parseIfMatch(r *http.Request) (*uint64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	tag, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		return nil, fmt.Errorf("If-Match must be a single strong entity tag: %s", value)
+	}
+	revision, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("If-Match is not a DAG revision: %s", value)
+	}
+
+	return &revision, nil
+}
+
+// ValidateDAG validates a DAG structure without saving it
+//
+// @Summary Validate Legal Case DAG
+// @Description Validate a DAG structure to ensure it meets all requirements (single root node, acyclic, valid relationships). The severity of the validation rules can be overridden by code, e.g. to accept several roots in a draft DAG.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param strict query bool false "Reject payloads containing unknown fields"
+// @Param dag body ValidateRequest true "DAG structure to validate"
+// @Success 200 {object} ValidationResultPresenter "DAG validation completed (may contain errors)"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body or rule overrides"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/validate [post]
+func (h *dagHandler) ValidateDAG(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parse the request body
+	var validateRequest ValidateRequest
+	err := decodeRequestBody(r, &validateRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode validation request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	// Convert presenter to DAG
+	dagToValidate := h.presenterToDAG(validateRequest.DAG)
+
+	rules := make(map[string]usecase.Severity, len(validateRequest.Rules))
+	for code, severity := range validateRequest.Rules {
+		rules[code] = usecase.Severity(severity)
+	}
+
+	// Validate the DAG using the configured validation profile
+	validationResult, err := h.app.ValidateDAG(ctx, dagToValidate, rules)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to validate DAG")
+		if errors.Is(err, usecase.ErrInvalidCommand) {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid validation rules", err)
+			return
+		}
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to validate DAG", err)
+		return
+	}
+
+	// Convert validation result to presenter format
+	resultPresenter := newValidationResultPresenter(validationResult)
+
+	// Return validation results (always 200 OK, even if DAG is invalid)
+	xhttp.WriteObject(ctx, w, http.StatusOK, resultPresenter)
+}
+
+// Diff compares the structure of two DAGs
+//
+// @Summary Compare two DAGs
+// @Description Compare two DAGs, each given as a payload or as a version reference, and return the added, removed and modified nodes and answers, changed next node links and title change. Nodes and answers are matched by ID.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param diff body DiffRequest true "DAGs to compare"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} DAGChangeSetPresenter "Successfully compared DAGs"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body or operands"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG version not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/diff [post]
+func (h *dagHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var diffRequest DiffRequest
+	if err := decodeRequestBody(r, &diffRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode diff request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	changes, err := h.app.DiffDAGs(ctx, usecase.CmdDiffDAGs{
+		DAGId:  diffRequest.DAGId,
+		Before: h.diffOperand(diffRequest.Before),
+		After:  h.diffOperand(diffRequest.After),
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to compare DAGs")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid diff request", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG version not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to compare DAGs", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGChangeSetPresenter(*changes))
+}
+
+func (h *dagHandler) diffOperand(operand DiffOperandRequest) usecase.DiffOperand {
+	result := usecase.DiffOperand{Version: operand.Version}
+	if operand.DAG != nil {
+		result.DAG = h.presenterToDAG(*operand.DAG)
+	}
+	return result
+}
+
+// ValidateStoredDAG validates an existing stored DAG and persists the validation metadata
+//
+// @Summary Validate stored Legal Case DAG
+// @Description Validates an existing Legal Case DAG by ID, persisting validation results including status and statistics to the DAG metadata
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param async query bool false "Validate in the background, the job to poll on /jobs/{jobId} is returned right away"
+// @Success 200 {object} ValidationResultPresenter "DAG validation completed successfully (may contain validation errors)"
+// @Success 202 {object} JobPresenter "DAG validation enqueued"
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format or async parameter"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error during validation"
+// @Failure 503 {object} xhttp.ErrorResponse "Job queue full or not configured"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/validate [post]
+func (h *dagHandler) ValidateStoredDAG(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id := mux.Vars(r)[dagId]
+
+	async, err := parseBoolQuery(r, "async")
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid async parameter", err)
+		return
+	}
+	if async {
+		h.enqueueDAGValidation(w, r, id)
+		return
+	}
+
+	// Validate and execute the stored DAG validation
+	validationResult, err := h.app.ValidateStoredDAG(ctx, usecase.CmdValidateStoredDAG{
+		DAGId: id,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to validate stored DAG")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to validate stored DAG", err)
+			return
+		}
+	}
+
+	// Convert validation result to presenter format
+	resultPresenter := newValidationResultPresenter(*validationResult)
+
+	// Return validation results (always 200 OK, even if DAG is invalid)
+	xhttp.WriteObject(ctx, w, http.StatusOK, resultPresenter)
+}
+
+// enqueueDAGValidation queues the validation of a stored DAG and returns the job to poll
+func (h *dagHandler) enqueueDAGValidation(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	job, err := h.app.EnqueueDAGValidation(ctx, usecase.CmdValidateStoredDAG{
+		DAGId: id,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to enqueue DAG validation")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		case errors.Is(err, usecase.ErrUnavailable):
+			xhttp.WriteError(ctx, w, http.StatusServiceUnavailable, "job queue unavailable", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to enqueue DAG validation", err)
+		}
+		return
+	}
+
+	w.Header().Set("Location", "/v1/jobs/"+job.Id.String())
+	xhttp.WriteObject(ctx, w, http.StatusAccepted, NewJobPresenter(job))
+}
+
+// ValidateAllDAGs validates every stored DAG and persists their validation metadata
+//
+// @Summary Validate every stored Legal Case DAG
+// @Description Validates every stored DAG, persisting the validation results to the metadata of each, and reports the number of valid DAGs, the invalid DAGs with their most frequent error codes and the errors counted by code
+// @Tags DAGs
+// @Produce json
+// @Param workers query int false "Number of DAGs validated in parallel, from 1 to 32, one at a time by default"
+// @Success 200 {object} BulkValidationReportPresenter "DAGs validated successfully (some may be invalid)"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid workers parameter"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error during validation"
+// @Security ApiKeyAuth
+// @Router /dags/validate-all [post]
+func (h *dagHandler) ValidateAllDAGs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cmd := usecase.CmdValidateAllDAGs{}
+	if value := r.URL.Query().Get("workers"); value != "" {
+		workers, err := strconv.Atoi(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid workers parameter", err)
+			return
+		}
+		cmd.Workers = workers
+	}
+
+	report, err := h.app.ValidateAllDAGs(ctx, cmd)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to validate DAGs")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid workers parameter", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to validate DAGs", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewBulkValidationReportPresenter(*report))
+}
+
+// MergeAnswerMetadata merges metadata keys into a single answer of a stored DAG
+//
+// @Summary Merge answer metadata
+// @Description Merge the provided metadata keys into an answer without replacing the whole DAG, so concurrent edits of different answers do not conflict. Keys set to null are removed.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param answerId path string true "Answer unique identifier (UUID)"
+// @Param metadata body AnswerMetadataRequest true "Metadata keys to merge"
+// @Success 200 {object} AnswerPresenter "Successfully merged answer metadata"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body or identifier format"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG or answer not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/answers/{answerId}/metadata [post]
+func (h *dagHandler) MergeAnswerMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+
+	// Parse the request body
+	var metadataRequest AnswerMetadataRequest
+	err := json.NewDecoder(r.Body).Decode(&metadataRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode answer metadata request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	answer, err := h.app.MergeAnswerMetadata(ctx, usecase.CmdMergeAnswerMetadata{
+		DAGId:    vars[dagId],
+		AnswerId: vars[answerId],
+		Metadata: metadataRequest.Metadata,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to merge answer metadata")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid answer metadata", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG or answer not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to merge answer metadata", err)
+			return
+		}
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewAnswerPresenter(*answer))
+}
+
+// GetMetadataSchema returns the metadata schema of a stored DAG
+//
+// @Summary Get metadata schema
+// @Description Retrieve the fields, types and allowed values the metadata of the answers of a DAG must match
+// @Tags DAGs
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Success 200 {object} MetadataSchemaPresenter "Successfully retrieved metadata schema"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found or without metadata schema"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/metadata-schema [get]
+func (h *dagHandler) GetMetadataSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	schema, err := h.app.GetMetadataSchema(ctx, usecase.CmdGetMetadataSchema{
+		DAGId: mux.Vars(r)[dagId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get metadata schema")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG or metadata schema not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get metadata schema", err)
+			return
+		}
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, newMetadataSchemaPresenter(schema))
+}
+
+// SetMetadataSchema replaces the metadata schema of a stored DAG
+//
+// @Summary Set metadata schema
+// @Description Declare the fields, types and allowed values of answer metadata. The DAG is re-validated, so the schema is rejected when the metadata of existing answers does not match it.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param If-Match header string false "ETag of the DAG revision the change applies to"
+// @Param schema body MetadataSchemaPresenter true "Metadata schema"
+// @Success 200 {object} MetadataSchemaPresenter "Successfully set metadata schema"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, schema or If-Match header, or answer metadata not matching the schema"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 412 {object} xhttp.ErrorResponse "DAG was modified since the If-Match revision"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/metadata-schema [put]
+func (h *dagHandler) SetMetadataSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var schemaRequest MetadataSchemaPresenter
+	err := decodeRequestBody(r, &schemaRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode metadata schema request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	ifRevision, err := parseIfMatch(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid If-Match header", err)
+		return
+	}
+
+	schema, err := h.app.SetMetadataSchema(ctx, usecase.CmdSetMetadataSchema{
+		DAGId:      mux.Vars(r)[dagId],
+		Schema:     *presenterToMetadataSchema(&schemaRequest),
+		IfRevision: ifRevision,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to set metadata schema")
+		writeMetadataSchemaError(w, r, err, "failed to set metadata schema")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, newMetadataSchemaPresenter(schema))
+}
+
+// DeleteMetadataSchema removes the metadata schema of a stored DAG
+//
+// @Summary Delete metadata schema
+// @Description Remove the metadata schema of a DAG, any answer metadata is then accepted
+// @Tags DAGs
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param If-Match header string false "ETag of the DAG revision the change applies to"
+// @Success 204 "Metadata schema deleted"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format or If-Match header"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 412 {object} xhttp.ErrorResponse "DAG was modified since the If-Match revision"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/metadata-schema [delete]
+func (h *dagHandler) DeleteMetadataSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ifRevision, err := parseIfMatch(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid If-Match header", err)
+		return
+	}
+
+	err = h.app.DeleteMetadataSchema(ctx, usecase.CmdDeleteMetadataSchema{
+		DAGId:      mux.Vars(r)[dagId],
+		IfRevision: ifRevision,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to delete metadata schema")
+		writeMetadataSchemaError(w, r, err, "failed to delete metadata schema")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeMetadataSchemaError maps the errors of the metadata schema changes to statuses
+func writeMetadataSchemaError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	ctx := r.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid metadata schema", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+	case errors.Is(err, usecase.ErrPreconditionFailed):
+		xhttp.WriteError(ctx, w, http.StatusPreconditionFailed, "DAG was modified since it was read", err)
+	default:
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// InsertNode inserts a new node between an answer and its current target
+//
+// @Summary Insert node after answer
+// @Description Create a new information node, repoint the answer to it and give the new node a single answer leading to the original target. The resulting DAG is re-validated before being stored.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param answerId path string true "Answer unique identifier (UUID)"
+// @Param node body InsertNodeRequest true "Node to insert"
+// @Success 200 {object} DAGPresenter "Successfully inserted node"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, identifier format or resulting DAG"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG or answer not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/answers/{answerId}/insert-node [post]
+func (h *dagHandler) InsertNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+
+	// Parse the request body
+	var insertRequest InsertNodeRequest
+	err := json.NewDecoder(r.Body).Decode(&insertRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode insert node request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	updatedDAG, err := h.app.InsertNode(ctx, usecase.CmdInsertNode{
+		DAGId:     vars[dagId],
+		AnswerId:  vars[answerId],
+		Question:  insertRequest.Question,
+		Statement: insertRequest.Answer,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to insert node")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid node insertion", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG or answer not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to insert node", err)
+			return
+		}
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(updatedDAG))
+}
+
+// SplitNode splits the answers of a node into sub-questions
+//
+// @Summary Split node into sub-questions
+// @Description Move the answers of a node to one new intermediate node per bucket and give the node one answer per bucket leading to it. Every answer must belong to exactly one bucket and keeps its identifier and target. The resulting DAG is re-validated before being stored.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param nodeId path string true "Node unique identifier (UUID)"
+// @Param split body SplitNodeRequest true "Grouping of the node answers"
+// @Success 200 {object} DAGPresenter "Successfully split node"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, identifier format, grouping or resulting DAG"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG or node not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/nodes/{nodeId}/split [post]
+func (h *dagHandler) SplitNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+
+	// Parse the request body
+	var splitRequest SplitNodeRequest
+	err := json.NewDecoder(r.Body).Decode(&splitRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode split node request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	buckets := make([]usecase.SplitBucket, len(splitRequest.Buckets))
+	for i, bucket := range splitRequest.Buckets {
+		buckets[i] = usecase.SplitBucket{
+			Statement: bucket.Answer,
+			Question:  bucket.Question,
+			AnswerIds: bucket.AnswerIds,
+		}
+	}
+
+	updatedDAG, err := h.app.SplitNode(ctx, usecase.CmdSplitNode{
+		DAGId:   vars[dagId],
+		NodeId:  vars[nodeId],
+		Buckets: buckets,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to split node")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid node split", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG or node not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to split node", err)
+			return
+		}
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(updatedDAG))
+}
+
+// UpdateNode changes some fields of a single node
+//
+// @Summary Edit node
+// @Description Change the question or flags of a node without replacing the whole DAG, so concurrent edits of different nodes do not conflict. Omitted fields are left untouched and the resulting DAG is re-validated before being stored.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param nodeId path string true "Node unique identifier (UUID)"
+// @Param If-Match header string false "ETag of the DAG revision the change applies to"
+// @Param node body UpdateNodeRequest true "Node fields to change"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} NodePresenter "Successfully updated node"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, identifier format, If-Match header or resulting DAG"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG or node not found"
+// @Failure 412 {object} xhttp.ErrorResponse "DAG was modified since the If-Match revision"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/nodes/{nodeId} [patch]
+func (h *dagHandler) UpdateNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+
+	var nodeRequest UpdateNodeRequest
+	if err := decodeRequestBody(r, &nodeRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode node update request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	ifRevision, err := parseIfMatch(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid If-Match header", err)
+		return
+	}
+
+	node, err := h.app.UpdateNode(ctx, usecase.CmdUpdateNode{
+		DAGId:           vars[dagId],
+		NodeId:          vars[nodeId],
+		Question:        h.normalizeText(nodeRequest.Question),
+		Required:        nodeRequest.Required,
+		MultiSelect:     nodeRequest.MultiSelect,
+		MultiSelectNext: nodeRequest.MultiSelectNext,
+		IfRevision:      ifRevision,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to update node")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid node update", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG or node not found", err)
+		case errors.Is(err, usecase.ErrPreconditionFailed):
+			xhttp.WriteError(ctx, w, http.StatusPreconditionFailed, "DAG was modified since it was read", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update node", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewNodePresenter(*node))
+}
+
+// UpdateAnswer changes some fields of a single answer
+//
+// @Summary Edit answer
+// @Description Change the statement, target, context or disabled flag of an answer without replacing the whole DAG. Omitted fields are left untouched and the resulting DAG is re-validated, rejecting dangling references and cycles.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param nodeId path string true "Node unique identifier (UUID)"
+// @Param answerId path string true "Answer unique identifier (UUID)"
+// @Param If-Match header string false "ETag of the DAG revision the change applies to"
+// @Param answer body UpdateAnswerRequest true "Answer fields to change"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} AnswerPresenter "Successfully updated answer"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, identifier format, If-Match header or resulting DAG"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG, node or answer not found"
+// @Failure 412 {object} xhttp.ErrorResponse "DAG was modified since the If-Match revision"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/nodes/{nodeId}/answers/{answerId} [patch]
+func (h *dagHandler) UpdateAnswer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+
+	var answerRequest UpdateAnswerRequest
+	if err := decodeRequestBody(r, &answerRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode answer update request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	ifRevision, err := parseIfMatch(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid If-Match header", err)
+		return
+	}
+
+	answer, err := h.app.UpdateAnswer(ctx, usecase.CmdUpdateAnswer{
+		DAGId:       vars[dagId],
+		NodeId:      vars[nodeId],
+		AnswerId:    vars[answerId],
+		Statement:   h.normalizeText(answerRequest.Statement),
+		NextNode:    answerRequest.NextNode,
+		UserContext: answerRequest.UserContext,
+		Disabled:    answerRequest.Disabled,
+		IfRevision:  ifRevision,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to update answer")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid answer update", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG, node or answer not found", err)
+		case errors.Is(err, usecase.ErrPreconditionFailed):
+			xhttp.WriteError(ctx, w, http.StatusPreconditionFailed, "DAG was modified since it was read", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update answer", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewAnswerPresenter(*answer))
+}
+
+// normalizeText normalizes the whitespace of a submitted text unless configured to preserve it
+func (h *dagHandler) normalizeText(text *string) *string {
+	if text == nil || h.preserveWhitespace {
+		return text
+	}
+	normalized := model.NormalizeText(*text)
+	return &normalized
+}
+
+// CheckNodeReferences checks the next_node references of a node against a DAG
+//
+// @Summary Check node references
+// @Description Report which next_node references of a node, typically copied from another DAG, exist in the target DAG and which are dangling. The DAG is not modified.
+// @Tags DAGs
+// @Accept json
+// @Produce json
+// @Param dagId path string true "Target DAG unique identifier (UUID)"
+// @Param node body NodePresenter true "Node to check"
+// @Success 200 {object} NodeReferencesPresenter "Successfully checked node references"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body or DAG ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/nodes/check-references [post]
+func (h *dagHandler) CheckNodeReferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var nodeRequest NodePresenter
+	err := json.NewDecoder(r.Body).Decode(&nodeRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode check references request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	node := presenterToNode(nodeRequest)
+	references, err := h.app.CheckNodeReferences(ctx, usecase.CmdCheckNodeReferences{
+		DAGId: mux.Vars(r)[dagId],
+		Node:  &node,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to check node references")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid reference check", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to check node references", err)
+			return
+		}
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewNodeReferencesPresenter(*references))
+}
+
+// WalkComplete records a completed walk of a DAG for path analytics
+//
+// @Summary Record completed walk
+// @Description Record the answers chosen during a complete walk, from the root node to a terminal answer or leaf node, to accumulate path analytics
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param walk body WalkCompleteRequest true "Answers chosen during the walk"
+// @Success 201 {object} WalkCompletePresenter "Successfully recorded walk, with its coverage of the required questions"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, identifier format or incomplete walk"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Failure 503 {object} xhttp.ErrorResponse "Too many concurrent walks on the DAG, retry after the Retry-After delay"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/walk-complete [post]
+func (h *dagHandler) WalkComplete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var walkRequest WalkCompleteRequest
+	err := json.NewDecoder(r.Body).Decode(&walkRequest)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode walk complete request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	result, err := h.app.RecordWalk(ctx, usecase.CmdRecordWalk{
+		DAGId:     mux.Vars(r)[dagId],
+		AnswerIds: walkRequest.AnswerIds,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to record walk")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid walk", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to record walk", err)
+			return
+		}
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewWalkCompletePresenter(*result))
+}
+
+// GetPathAnalytics lists the most common complete paths of a DAG
+//
+// @Summary Get path analytics
+// @Description List the complete paths of a DAG ranked by the number of recorded walks that followed them, most common first
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param limit query int false "Maximum number of paths to return, all paths when omitted"
+// @Success 200 {object} PathAnalyticsPresenter "Successfully retrieved path analytics"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format or limit"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/analytics/paths [get]
+func (h *dagHandler) GetPathAnalytics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+	}
+
+	paths, err := h.app.GetPathAnalytics(ctx, usecase.CmdGetPathAnalytics{
+		DAGId: mux.Vars(r)[dagId],
+		Limit: limit,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get path analytics")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid path analytics request", err)
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+			return
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get path analytics", err)
+			return
+		}
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewPathAnalyticsPresenter(paths))
+}
+
+// ListVersions lists the recorded versions of a DAG
+//
+// @Summary List DAG versions
+// @Description List the versions of a DAG, recorded every time the DAG is replaced, oldest first
+// @Tags Versions
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Success 200 {object} DAGVersionListPresenter "Successfully retrieved DAG versions"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/versions [get]
+func (h *dagHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	versions, err := h.app.ListDAGVersions(ctx, usecase.CmdListDAGVersions{
+		DAGId: mux.Vars(r)[dagId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to list DAG versions")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list DAG versions", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGVersionListPresenter(versions))
+}
+
+// GetVersion retrieves a recorded version of a DAG
+//
+// @Summary Get DAG version
+// @Description Retrieve the snapshot of a DAG at a given version
+// @Tags Versions
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param version path int true "Version number, starting at 1"
+// @Success 200 {object} DAGVersionPresenter "Successfully retrieved DAG version"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format or version number"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG version not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/versions/{version} [get]
+func (h *dagHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	number, err := strconv.Atoi(mux.Vars(r)[versionNumber])
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid version number", err)
+		return
+	}
+
+	version, err := h.app.GetDAGVersion(ctx, usecase.CmdGetDAGVersion{
+		DAGId:  mux.Vars(r)[dagId],
+		Number: number,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get DAG version")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG version request", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG version not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get DAG version", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGVersionPresenter(*version))
+}
+
+// RestoreVersion rolls a DAG back to one of its versions
+//
+// @Summary Restore DAG version
+// @Description Replace a DAG with the snapshot of one of its versions. The history is kept, the restored DAG is recorded as a new version.
+// @Tags Versions
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param version path int true "Version number, starting at 1"
+// @Success 200 {object} DAGPresenter "Successfully restored DAG version"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid version number or version failing the current validation"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG or version not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/versions/{version}/restore [post]
+func (h *dagHandler) RestoreVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	number, err := strconv.Atoi(mux.Vars(r)[versionNumber])
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid version number", err)
+		return
+	}
+
+	restored, err := h.app.RestoreDAGVersion(ctx, usecase.CmdRestoreDAGVersion{
+		DAGId:  mux.Vars(r)[dagId],
+		Number: number,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to restore DAG version")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid DAG version", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG version not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to restore DAG version", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewDAGPresenter(restored))
+}
+
+// validationResultToPresenter converts usecase ValidationResult to ValidationResultPresenter
+func newValidationResultPresenter(result usecase.ValidationResult) ValidationResultPresenter {
+	presenter := ValidationResultPresenter{
+		IsValid:    result.IsValid,
+		Statistics: newValidationStatisticsPresenter(result.Statistics),
+	}
 
 	// Convert errors
 	presenter.Errors = make([]ValidationErrorPresenter, len(result.Errors))
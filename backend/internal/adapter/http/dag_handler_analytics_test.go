@@ -0,0 +1,196 @@
+package http
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_WalkComplete(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+	paths, err := testDAG.EnumeratePaths()
+	require.NoError(t, err)
+
+	answerIds := make([]string, len(paths[0].Steps))
+	for i, step := range paths[0].Steps {
+		answerIds[i] = step.Answer.Id.String()
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "records a complete walk",
+			body: mustJSON(t, WalkCompleteRequest{AnswerIds: answerIds}),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RecordWalk(gomock.Any(), usecase.CmdRecordWalk{DAGId: testDAG.Id.String(), AnswerIds: answerIds}).Return(&usecase.WalkResult{
+					Path:     paths[0],
+					Coverage: model.CoverageReport{Answered: []uuid.UUID{paths[0].Steps[0].NodeId}, MissedRequired: []uuid.UUID{paths[0].LeafNodeId}},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response WalkCompletePresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, paths[0].LeafNodeId, response.LeafNodeId)
+				assert.Len(t, response.Steps, len(answerIds))
+				assert.Equal(t, []uuid.UUID{paths[0].Steps[0].NodeId}, response.Coverage.Answered)
+				assert.Equal(t, []uuid.UUID{paths[0].LeafNodeId}, response.Coverage.MissedRequired)
+			},
+		},
+		{
+			name:           "returns 400 for invalid body",
+			body:           "{",
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid request body")
+			},
+		},
+		{
+			name: "returns 400 for incomplete walk",
+			body: mustJSON(t, WalkCompleteRequest{AnswerIds: answerIds[:1]}),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RecordWalk(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid walk")
+			},
+		},
+		{
+			name: "returns 404 when DAG not found",
+			body: mustJSON(t, WalkCompleteRequest{AnswerIds: answerIds}),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RecordWalk(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			req, err := http.NewRequest("POST", "/v1/dags/"+testDAG.Id.String()+"/walk-complete", bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"dagId": testDAG.Id.String()})
+
+			rr := httptest.NewRecorder()
+			handler.WalkComplete(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
+
+func TestDAGHandler_GetPathAnalytics(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+	paths, err := testDAG.EnumeratePaths()
+	require.NoError(t, err)
+
+	ranked := []usecase.RankedPath{
+		{Path: paths[2], Count: 5},
+		{Path: paths[0], Count: 3},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "returns ranked paths",
+			query: "?limit=2",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetPathAnalytics(gomock.Any(), usecase.CmdGetPathAnalytics{DAGId: testDAG.Id.String(), Limit: 2}).Return(ranked, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response PathAnalyticsPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Equal(t, 2, response.Count)
+				assert.Equal(t, 5, response.Paths[0].Count)
+				assert.Equal(t, paths[2].LeafNodeId, response.Paths[0].Path.LeafNodeId)
+				assert.Equal(t, 3, response.Paths[1].Count)
+			},
+		},
+		{
+			name:           "returns 400 for invalid limit",
+			query:          "?limit=many",
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid limit")
+			},
+		},
+		{
+			name: "returns 404 when DAG not found",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetPathAnalytics(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			req, err := http.NewRequest("GET", "/v1/dags/"+testDAG.Id.String()+"/analytics/paths"+tt.query, nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"dagId": testDAG.Id.String()})
+
+			rr := httptest.NewRecorder()
+			handler.GetPathAnalytics(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_CheckNodeReferences(t *testing.T) {
+	dagUUID := uuid.New()
+	existingId, danglingId := uuid.New(), uuid.New()
+
+	node := NodePresenter{
+		Id:       uuid.New(),
+		Question: "Copied question?",
+		Answers: []AnswerPresenter{
+			{Id: uuid.New(), Statement: "Known", NextNode: &existingId},
+			{Id: uuid.New(), Statement: "Unknown", NextNode: &danglingId},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "reports existing and dangling references",
+			requestBody: mustJSON(t, node),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().CheckNodeReferences(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, cmd usecase.CmdCheckNodeReferences) (*usecase.NodeReferences, error) {
+						assert.Equal(t, dagUUID.String(), cmd.DAGId)
+						require.NotNil(t, cmd.Node)
+						assert.Equal(t, node.Id, cmd.Node.Id)
+						require.Len(t, cmd.Node.Answers, 2)
+						assert.Equal(t, &danglingId, cmd.Node.Answers[1].NextNode)
+						return &usecase.NodeReferences{Existing: []uuid.UUID{existingId}, Dangling: []uuid.UUID{danglingId}}, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response NodeReferencesPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, []uuid.UUID{existingId}, response.Existing)
+				assert.Equal(t, []uuid.UUID{danglingId}, response.Dangling)
+			},
+		},
+		{
+			name:           "returns 400 for invalid JSON",
+			requestBody:    "invalid json",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid request body")
+			},
+		},
+		{
+			name:        "returns 404 when DAG not found",
+			requestBody: mustJSON(t, node),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().CheckNodeReferences(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG not found")
+			},
+		},
+		{
+			name:        "returns 500 for internal server error",
+			requestBody: mustJSON(t, node),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().CheckNodeReferences(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "failed to check node references")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			url := "/v1/dags/" + dagUUID.String() + "/nodes/check-references"
+			req, err := http.NewRequest("POST", url, bytes.NewBufferString(tt.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"dagId": dagUUID.String()})
+
+			rr := httptest.NewRecorder()
+			handler.CheckNodeReferences(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
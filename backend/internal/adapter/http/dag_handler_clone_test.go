@@ -0,0 +1,74 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Clone(t *testing.T) {
+	source := dagtest.ValidSingleRoot()
+	clone := model.CloneDAG(source)
+
+	serve := func(mockApp *mocks.MockApp, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/dags/"+source.Id.String()+"/clone", strings.NewReader(body)))
+		return rr
+	}
+
+	t.Run("creates a clone with the given title", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().CloneDAG(gomock.Any(), usecase.CmdCloneDAG{
+			DAGId: source.Id.String(),
+			Title: "Copy",
+		}).Return(clone, nil)
+
+		rr := serve(mockApp, `{"title":"Copy"}`)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+		var response DAGPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, clone.Id, response.Id)
+		assert.Len(t, response.Nodes, len(source.Nodes))
+	})
+
+	t.Run("clones without options", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().CloneDAG(gomock.Any(), usecase.CmdCloneDAG{DAGId: source.Id.String()}).Return(clone, nil)
+
+		rr := serve(mockApp, "")
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("maps errors to statuses", func(t *testing.T) {
+		for _, tt := range []struct {
+			err            error
+			expectedStatus int
+		}{
+			{fmt.Errorf("%w: DAG validation failed", usecase.ErrInvalidCommand), http.StatusBadRequest},
+			{fmt.Errorf("%w: DAG not found", usecase.ErrNotFound), http.StatusNotFound},
+			{usecase.ErrInternal, http.StatusInternalServerError},
+		} {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			mockApp.EXPECT().CloneDAG(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+
+			rr := serve(mockApp, "{}")
+			assert.Equal(t, tt.expectedStatus, rr.Code, tt.err)
+		}
+
+		rr := serve(mocks.NewMockApp(gomock.NewController(t)), `{`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
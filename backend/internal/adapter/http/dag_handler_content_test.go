@@ -0,0 +1,89 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_GetContent_Localized(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+	root := dagtest.Root(testDAG)
+	root.Translations = map[string]string{"fr": "Question racine ?"}
+	root.Answers[0].Translations = map[string]string{"fr": "Aller au milieu"}
+	testDAG.Nodes[root.Id] = root
+	testDAG.Revision = 2
+
+	tests := []struct {
+		name              string
+		query             string
+		expectedQuestion  string
+		expectedStatement string
+	}{
+		{
+			name:              "renders default text without lang",
+			query:             "",
+			expectedQuestion:  "Root question?",
+			expectedStatement: "Go to middle",
+		},
+		{
+			name:              "renders translated text",
+			query:             "?lang=fr",
+			expectedQuestion:  "Question racine ?",
+			expectedStatement: "Aller au milieu",
+		},
+		{
+			name:              "falls back to default text for untranslated language",
+			query:             "?lang=es",
+			expectedQuestion:  "Root question?",
+			expectedStatement: "Go to middle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			mockApp.EXPECT().Get(gomock.Any(), usecase.CmdGetDAG{DAGId: testDAG.Id.String()}).Return(testDAG, nil)
+
+			handler := NewDAGHandler(mockApp)
+
+			req, err := http.NewRequest("GET", "/v1/dags/"+testDAG.Id.String()+"/content"+tt.query, nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"dagId": testDAG.Id.String()})
+
+			rr := httptest.NewRecorder()
+			handler.GetContent(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+
+			var response DAGContentPresenter
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			var rootPresenter *NodePresenter
+			for i := range response.Nodes {
+				if response.Nodes[i].Id == root.Id {
+					rootPresenter = &response.Nodes[i]
+				}
+			}
+			require.NotNil(t, rootPresenter)
+
+			assert.Equal(t, tt.expectedQuestion, rootPresenter.Question)
+			assert.Equal(t, tt.expectedStatement, rootPresenter.Answers[0].Statement)
+			assert.Equal(t, root.Translations, rootPresenter.Translations)
+			assert.Equal(t, "Go to middle", testDAG.Nodes[root.Id].Answers[0].Statement)
+		})
+	}
+}
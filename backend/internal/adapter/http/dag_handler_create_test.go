@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Create(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+
+	withoutId := func() string {
+		presenter := NewDAGPresenter(testDAG)
+		presenter.Id = uuid.Nil
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(mustJSON(t, presenter)), &payload))
+		delete(payload, "id")
+
+		return mustJSON(t, payload)
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "creates a DAG with a server assigned ID",
+			body: withoutId(),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, cmd usecase.CmdCreateDAG) (*model.DAG, error) {
+						assert.Equal(t, uuid.Nil, cmd.DAG.Id)
+						assert.Len(t, cmd.DAG.Nodes, len(testDAG.Nodes))
+
+						created := *cmd.DAG
+						created.Id = uuid.New()
+						return &created, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.NotEqual(t, uuid.Nil, response.Id)
+				assert.Equal(t, testDAG.Title, response.Title)
+				assert.Len(t, response.Nodes, len(testDAG.Nodes))
+			},
+		},
+		{
+			name: "creates a DAG with its own ID",
+			body: mustJSON(t, NewDAGPresenter(testDAG)),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, cmd usecase.CmdCreateDAG) (*model.DAG, error) {
+						assert.Equal(t, testDAG.Id, cmd.DAG.Id)
+						return cmd.DAG, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, testDAG.Id, response.Id)
+			},
+		},
+		{
+			name:           "returns 400 for invalid JSON",
+			body:           "invalid json",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid request body")
+			},
+		},
+		{
+			name: "returns 400 for invalid DAG data",
+			body: mustJSON(t, NewDAGPresenter(testDAG)),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid DAG data")
+			},
+		},
+		{
+			name: "returns 500 when app layer fails",
+			body: mustJSON(t, NewDAGPresenter(testDAG)),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "failed to create DAG")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			router := New(mockApp, nil, Config{})
+			req := httptest.NewRequest(http.MethodPost, "/v1/dags", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			tt.checkResponse(t, rr)
+		})
+	}
+}
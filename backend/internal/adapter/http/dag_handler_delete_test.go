@@ -0,0 +1,91 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDAGHandler_Delete(t *testing.T) {
+	id := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "deletes the DAG",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Delete(gomock.Any(), usecase.CmdDeleteDAG{DAGId: id}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "forwards the force flag",
+			query: "?force=true",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Delete(gomock.Any(), usecase.CmdDeleteDAG{DAGId: id, Force: true}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "returns 400 for invalid force",
+			query:          "?force=maybe",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid force parameter",
+		},
+		{
+			name: "returns 404 for unknown DAG",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG not found",
+		},
+		{
+			name: "returns 409 when walks were recorded",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(usecase.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "DAG has recorded walks",
+		},
+		{
+			name: "returns 500 when app layer fails",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to delete DAG",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			router := New(mockApp, nil, Config{})
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/v1/dags/"+id+tt.query, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Empty(t, rr.Body.String())
+				return
+			}
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+		})
+	}
+}
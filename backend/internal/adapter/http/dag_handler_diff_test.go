@@ -0,0 +1,138 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Diff(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+	dagPresenter := NewDAGPresenter(d)
+	nodeId := uuid.New()
+	answerId := uuid.New()
+	nextNode := uuid.New()
+	disabled := true
+	changes := &usecase.DAGChangeSet{
+		Title:        &usecase.TextChange{Before: "Before", After: "After"},
+		AddedNodes:   []usecase.NodeRef{{NodeId: nextNode, Question: "New question?"}},
+		RemovedNodes: []usecase.NodeRef{},
+		ModifiedNodes: []usecase.NodeChange{{
+			NodeId: nodeId,
+			ModifiedAnswers: []usecase.AnswerChange{{
+				AnswerId: answerId,
+				NextNode: &usecase.LinkChange{After: &nextNode},
+				Disabled: &disabled,
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "compares two payloads",
+			body: mustJSON(t, DiffRequest{
+				Before: DiffOperandRequest{DAG: &dagPresenter},
+				After:  DiffOperandRequest{DAG: &dagPresenter},
+			}),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().DiffDAGs(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, cmd usecase.CmdDiffDAGs) (*usecase.DAGChangeSet, error) {
+						require.NotNil(t, cmd.Before.DAG)
+						require.NotNil(t, cmd.After.DAG)
+						assert.Equal(t, d.Id, cmd.Before.DAG.Id)
+						assert.Len(t, cmd.After.DAG.Nodes, len(d.Nodes))
+						return changes, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "compares two versions",
+			body: `{"dag_id":"` + d.Id.String() + `","before":{"version":1},"after":{"version":2}}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().DiffDAGs(gomock.Any(), usecase.CmdDiffDAGs{
+					DAGId:  d.Id.String(),
+					Before: usecase.DiffOperand{Version: 1},
+					After:  usecase.DiffOperand{Version: 2},
+				}).Return(changes, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns 400 for invalid body",
+			body:           `{invalid`,
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid request body",
+		},
+		{
+			name: "returns 400 for invalid operands",
+			body: `{"before":{"version":1},"after":{"version":2}}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().DiffDAGs(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid diff request",
+		},
+		{
+			name: "returns 404 for unknown version",
+			body: `{"dag_id":"` + d.Id.String() + `","before":{"version":1},"after":{"version":9}}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().DiffDAGs(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG version not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			router := New(mockApp, nil, Config{})
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/dags/diff", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedBody)
+				return
+			}
+
+			var presenter DAGChangeSetPresenter
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &presenter))
+			assert.True(t, presenter.HasChanges)
+			require.NotNil(t, presenter.Title)
+			assert.Equal(t, "After", presenter.Title.After)
+			require.Len(t, presenter.AddedNodes, 1)
+			assert.Empty(t, presenter.RemovedNodes)
+			require.Len(t, presenter.ModifiedNodes, 1)
+			require.Len(t, presenter.ModifiedNodes[0].ModifiedAnswers, 1)
+			answer := presenter.ModifiedNodes[0].ModifiedAnswers[0]
+			require.NotNil(t, answer.NextNode)
+			assert.Nil(t, answer.NextNode.Before)
+			assert.Equal(t, nextNode, *answer.NextNode.After)
+			require.NotNil(t, answer.Disabled)
+			assert.True(t, *answer.Disabled)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/export"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Export(t *testing.T) {
+	id := uuid.New().String()
+	dot := &usecase.ExportedDAG{Format: export.FormatDOT, ContentType: "text/vnd.graphviz; charset=utf-8", Data: []byte("digraph \"DAG\" {\n}\n")}
+	mermaid := &usecase.ExportedDAG{Format: export.FormatMermaid, ContentType: "text/vnd.mermaid; charset=utf-8", Data: []byte("flowchart TD\n")}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "renders DOT by default",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ExportDAG(gomock.Any(), usecase.CmdExportDAG{DAGId: id, Format: export.FormatDOT}).Return(dot, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Equal(t, "text/vnd.graphviz; charset=utf-8", rr.Header().Get("Content-Type"))
+				assert.Equal(t, string(dot.Data), rr.Body.String())
+			},
+		},
+		{
+			name:  "forwards the format and highlight options",
+			query: "?format=mermaid&highlight_leaves=true&highlight_cycles=1",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ExportDAG(gomock.Any(), usecase.CmdExportDAG{
+					DAGId:           id,
+					Format:          export.FormatMermaid,
+					HighlightLeaves: true,
+					HighlightCycles: true,
+				}).Return(mermaid, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Equal(t, "text/vnd.mermaid; charset=utf-8", rr.Header().Get("Content-Type"))
+				assert.Equal(t, string(mermaid.Data), rr.Body.String())
+			},
+		},
+		{
+			name:           "returns 400 for invalid highlight parameter",
+			query:          "?highlight_leaves=maybe",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid highlight_leaves parameter")
+			},
+		},
+		{
+			name:  "returns 400 for unsupported format",
+			query: "?format=svg",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ExportDAG(gomock.Any(), usecase.CmdExportDAG{DAGId: id, Format: "svg"}).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid export request")
+			},
+		},
+		{
+			name: "returns 404 when DAG not found",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ExportDAG(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/dags/"+id+"/export"+tt.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/importer"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Import(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+	body := "title: Imported\n"
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "returns 201 when the DAG is stored",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ImportDAG(gomock.Any(), usecase.CmdImportDAG{Data: []byte(body), NormalizeText: true}).Return(&usecase.ImportResult{
+					Format:     importer.FormatYAML,
+					DAG:        testDAG,
+					Validation: usecase.ValidationResult{IsValid: true},
+					Stored:     true,
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response ImportResultPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, importer.FormatYAML, response.Format)
+				assert.True(t, response.Stored)
+				assert.True(t, response.Validation.IsValid)
+				assert.Equal(t, testDAG.Id, response.DAG.Id)
+			},
+		},
+		{
+			name:  "returns 200 on dry run",
+			query: "?dry_run=true&format=yaml",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ImportDAG(gomock.Any(), usecase.CmdImportDAG{Data: []byte(body), Format: importer.FormatYAML, DryRun: true, NormalizeText: true}).Return(&usecase.ImportResult{
+					Format:     importer.FormatYAML,
+					DAG:        testDAG,
+					Validation: usecase.ValidationResult{IsValid: true},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response ImportResultPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.False(t, response.Stored)
+			},
+		},
+		{
+			name:  "passes the skip_validation_metadata flag",
+			query: "?skip_validation_metadata=true",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ImportDAG(gomock.Any(), usecase.CmdImportDAG{Data: []byte(body), NormalizeText: true, SkipValidationMetadata: true}).Return(&usecase.ImportResult{
+					Format:     importer.FormatYAML,
+					DAG:        testDAG,
+					Validation: usecase.ValidationResult{IsValid: true},
+					Stored:     true,
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse:  func(*testing.T, *httptest.ResponseRecorder) {},
+		},
+		{
+			name: "returns 422 with the validation errors of an invalid DAG",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ImportDAG(gomock.Any(), gomock.Any()).Return(&usecase.ImportResult{
+					Format: importer.FormatYAML,
+					DAG:    testDAG,
+					Validation: usecase.ValidationResult{
+						Errors: []usecase.ValidationError{{Code: "DAG_HAS_CYCLES", Message: "DAG contains 1 cycle(s)", Severity: "error"}},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response ImportResultPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.False(t, response.Stored)
+				require.Len(t, response.Validation.Errors, 1)
+				assert.Equal(t, "DAG_HAS_CYCLES", response.Validation.Errors[0].Code)
+			},
+		},
+		{
+			name: "returns 400 for a malformed document",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ImportDAG(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid import document")
+			},
+		},
+		{
+			name:           "returns 400 for invalid dry_run parameter",
+			query:          "?dry_run=maybe",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid dry_run parameter")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/dags/import"+tt.query, strings.NewReader(body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_InsertNode(t *testing.T) {
+	dagUUID := uuid.New()
+	answerUUID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "successfully inserts node",
+			requestBody: `{"question": "Did you report it?", "answer": "Continue"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().InsertNode(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, cmd usecase.CmdInsertNode) (*model.DAG, error) {
+						assert.Equal(t, dagUUID.String(), cmd.DAGId)
+						assert.Equal(t, answerUUID.String(), cmd.AnswerId)
+						assert.Equal(t, "Did you report it?", cmd.Question)
+						assert.Equal(t, "Continue", cmd.Statement)
+						return dagtest.LinearChain(3), nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Len(t, response.Nodes, 3)
+			},
+		},
+		{
+			name:           "returns 400 for invalid JSON",
+			requestBody:    "invalid json",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid request body")
+			},
+		},
+		{
+			name:        "returns 400 when the resulting DAG is invalid",
+			requestBody: `{"question": "Did you report it?", "answer": "Continue"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().InsertNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid node insertion")
+			},
+		},
+		{
+			name:        "returns 404 when answer not found",
+			requestBody: `{"question": "Did you report it?", "answer": "Continue"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().InsertNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG or answer not found")
+			},
+		},
+		{
+			name:        "returns 500 for internal server error",
+			requestBody: `{"question": "Did you report it?", "answer": "Continue"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().InsertNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "failed to insert node")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			url := "/v1/dags/" + dagUUID.String() + "/answers/" + answerUUID.String() + "/insert-node"
+			req, err := http.NewRequest("POST", url, bytes.NewBufferString(tt.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"dagId": dagUUID.String(), "answerId": answerUUID.String()})
+
+			rr := httptest.NewRecorder()
+			handler.InsertNode(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
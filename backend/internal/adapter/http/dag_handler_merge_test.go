@@ -0,0 +1,75 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Merge(t *testing.T) {
+	target := dagtest.ValidSingleRoot()
+	sourceId, answerId := uuid.New(), uuid.New()
+	body := fmt.Sprintf(`{"source_dag_id":%q,"answer_id":%q}`, sourceId, answerId)
+
+	serve := func(mockApp *mocks.MockApp, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/dags/"+target.Id.String()+"/merge", strings.NewReader(body)))
+		return rr
+	}
+
+	t.Run("returns the merged DAG and the remapped IDs", func(t *testing.T) {
+		graftedRootId, collidingId := uuid.New(), uuid.New()
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().MergeDAG(gomock.Any(), usecase.CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: sourceId.String(),
+			AnswerId: answerId.String(),
+		}).Return(&usecase.MergeResult{
+			DAG:           target,
+			GraftedRootId: graftedRootId,
+			Remapped:      map[uuid.UUID]uuid.UUID{collidingId: graftedRootId},
+		}, nil)
+
+		rr := serve(mockApp, body)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+		var response MergeResultPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, target.Id, response.DAG.Id)
+		assert.Equal(t, graftedRootId, response.GraftedRootId)
+		assert.Equal(t, map[string]string{collidingId.String(): graftedRootId.String()}, response.RemappedIds)
+	})
+
+	t.Run("maps errors to statuses", func(t *testing.T) {
+		for _, tt := range []struct {
+			err            error
+			expectedStatus int
+		}{
+			{fmt.Errorf("%w: DAG validation failed", usecase.ErrInvalidCommand), http.StatusBadRequest},
+			{fmt.Errorf("%w: answer not found", usecase.ErrNotFound), http.StatusNotFound},
+			{fmt.Errorf("%w: answer already leads to a node", usecase.ErrConflict), http.StatusConflict},
+			{usecase.ErrInternal, http.StatusInternalServerError},
+		} {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			mockApp.EXPECT().MergeDAG(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+
+			rr := serve(mockApp, body)
+			assert.Equal(t, tt.expectedStatus, rr.Code, tt.err)
+		}
+
+		rr := serve(mocks.NewMockApp(gomock.NewController(t)), `{`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_MetadataSchema(t *testing.T) {
+	dagUUID := uuid.New()
+	url := "/v1/dags/" + dagUUID.String() + "/metadata-schema"
+	schema := &model.MetadataSchema{Fields: []model.MetadataField{
+		{Name: "severity", Type: model.MetadataFieldString, Enum: []string{"low", "high"}},
+	}}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		ifMatch        string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "gets the schema",
+			method: http.MethodGet,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetMetadataSchema(gomock.Any(), usecase.CmdGetMetadataSchema{DAGId: dagUUID.String()}).Return(schema, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response MetadataSchemaPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Len(t, response.Fields, 1)
+				assert.Equal(t, "severity", response.Fields[0].Name)
+				assert.Equal(t, []string{"low", "high"}, response.Fields[0].Enum)
+			},
+		},
+		{
+			name:   "returns 404 without schema",
+			method: http.MethodGet,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetMetadataSchema(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: no metadata schema", usecase.ErrNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:    "sets the schema",
+			method:  http.MethodPut,
+			body:    `{"fields": [{"name": "severity", "type": "string", "enum": ["low", "high"]}]}`,
+			ifMatch: `"4"`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SetMetadataSchema(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, cmd usecase.CmdSetMetadataSchema) (*model.MetadataSchema, error) {
+						assert.Equal(t, dagUUID.String(), cmd.DAGId)
+						assert.Equal(t, *schema, cmd.Schema)
+						require.NotNil(t, cmd.IfRevision)
+						assert.Equal(t, uint64(4), *cmd.IfRevision)
+						return &cmd.Schema, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns 400 for invalid JSON",
+			method:         http.MethodPut,
+			body:           "invalid json",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "returns 400 when answer metadata violates the schema",
+			method: http.MethodPut,
+			body:   `{"fields": [{"name": "severity", "type": "string", "required": true}]}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SetMetadataSchema(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: DAG validation failed", usecase.ErrInvalidCommand))
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid metadata schema")
+			},
+		},
+		{
+			name:   "deletes the schema",
+			method: http.MethodDelete,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().DeleteMetadataSchema(gomock.Any(), usecase.CmdDeleteMetadataSchema{DAGId: dagUUID.String()}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:    "returns 412 for an outdated revision",
+			method:  http.MethodDelete,
+			ifMatch: `"1"`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().DeleteMetadataSchema(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: revision mismatch", usecase.ErrPreconditionFailed))
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			req := httptest.NewRequest(tt.method, url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, rr)
+			}
+		})
+	}
+}
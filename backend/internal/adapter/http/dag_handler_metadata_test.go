@@ -0,0 +1,119 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_MergeAnswerMetadata(t *testing.T) {
+	dagUUID := uuid.New()
+	answerUUID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "successfully merges metadata",
+			requestBody: `{"metadata": {"confidence": 0.9}}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().MergeAnswerMetadata(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, cmd usecase.CmdMergeAnswerMetadata) (*model.Answer, error) {
+						assert.Equal(t, dagUUID.String(), cmd.DAGId)
+						assert.Equal(t, answerUUID.String(), cmd.AnswerId)
+						assert.Equal(t, map[string]interface{}{"confidence": 0.9}, cmd.Metadata)
+						return &model.Answer{
+							Id:        answerUUID,
+							Statement: "Yes",
+							Metadata:  map[string]interface{}{"confidence": 0.9, "severity": "high"},
+							MetadataHistory: []model.MetadataSnapshot{
+								{At: time.Now(), Metadata: map[string]interface{}{"confidence": 0.9, "severity": "high"}},
+							},
+						}, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response AnswerPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, answerUUID, response.Id)
+				assert.Equal(t, 0.9, response.Metadata["confidence"])
+				assert.Equal(t, 1, response.MetadataHistoryCount)
+				assert.NotNil(t, response.LatestMetadataChange)
+			},
+		},
+		{
+			name:           "returns 400 for invalid JSON",
+			requestBody:    "invalid json",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid request body")
+			},
+		},
+		{
+			name:        "returns 404 when answer not found",
+			requestBody: `{"metadata": {"confidence": 0.9}}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().MergeAnswerMetadata(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG or answer not found")
+			},
+		},
+		{
+			name:        "returns 500 for internal server error",
+			requestBody: `{"metadata": {"confidence": 0.9}}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().MergeAnswerMetadata(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "failed to merge answer metadata")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			url := "/v1/dags/" + dagUUID.String() + "/answers/" + answerUUID.String() + "/metadata"
+			req, err := http.NewRequest("POST", url, bytes.NewBufferString(tt.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"dagId": dagUUID.String(), "answerId": answerUUID.String()})
+
+			rr := httptest.NewRecorder()
+			handler.MergeAnswerMetadata(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
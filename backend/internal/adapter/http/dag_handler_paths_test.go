@@ -0,0 +1,115 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_GetPaths(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+	paths, err := testDAG.EnumeratePaths()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "returns every path with its questions",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().EnumeratePaths(gomock.Any(), usecase.CmdEnumeratePaths{DAGId: testDAG.Id.String()}).Return(&usecase.PathList{Paths: paths}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response PathListPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Equal(t, len(paths), response.Count)
+				assert.False(t, response.Truncated)
+				for _, path := range response.Paths {
+					for _, step := range path.Steps {
+						assert.Equal(t, testDAG.Nodes[step.NodeId].Question, step.Question)
+					}
+				}
+			},
+		},
+		{
+			name:  "reports the paths left out by the limit",
+			query: "?limit=1",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().EnumeratePaths(gomock.Any(), usecase.CmdEnumeratePaths{DAGId: testDAG.Id.String(), Limit: 1}).
+					Return(&usecase.PathList{Paths: paths[:1], Truncated: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response PathListPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, 1, response.Count)
+				assert.True(t, response.Truncated)
+			},
+		},
+		{
+			name:           "returns 400 for an invalid limit",
+			query:          "?limit=all",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid limit")
+			},
+		},
+		{
+			name: "returns 400 when the DAG cannot be walked",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().EnumeratePaths(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid DAG")
+			},
+		},
+		{
+			name: "returns 404 when DAG not found",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().EnumeratePaths(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG not found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			req, err := http.NewRequest("GET", "/v1/dags/"+testDAG.Id.String()+"/paths"+tt.query, nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"dagId": testDAG.Id.String()})
+
+			rr := httptest.NewRecorder()
+			handler.GetPaths(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Search(t *testing.T) {
+	answerId := uuid.New()
+	hits := []model.SearchHit{
+		{DAGId: uuid.New(), NodeId: uuid.New(), AnswerId: &answerId, Field: model.SearchFieldAnswer, Snippet: "Notice was given, notice was late", Score: 2},
+		{DAGId: uuid.New(), NodeId: uuid.New(), Field: model.SearchFieldQuestion, Snippet: "Did the landlord give notice?", Score: 1},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "returns the hits",
+			query: "?q=notice&limit=5",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SearchDAGs(gomock.Any(), usecase.CmdSearchDAGs{Query: "notice", Limit: 5}).Return(hits, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response SearchResultPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Equal(t, 2, response.Count)
+				assert.Equal(t, hits[0].DAGId, response.Hits[0].DAGId)
+				assert.Equal(t, hits[0].NodeId, response.Hits[0].NodeId)
+				require.NotNil(t, response.Hits[0].AnswerId)
+				assert.Equal(t, answerId, *response.Hits[0].AnswerId)
+				assert.Equal(t, "Notice was given, notice was late", response.Hits[0].Snippet)
+				assert.Nil(t, response.Hits[1].AnswerId)
+				assert.Equal(t, model.SearchFieldQuestion, response.Hits[1].Field)
+			},
+		},
+		{
+			name:           "returns 400 for invalid limit",
+			query:          "?q=notice&limit=many",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid limit")
+			},
+		},
+		{
+			name:  "returns 400 for missing query",
+			query: "",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SearchDAGs(gomock.Any(), usecase.CmdSearchDAGs{}).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid search parameters")
+			},
+		},
+		{
+			name:  "returns 500 when app layer fails",
+			query: "?q=notice",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SearchDAGs(gomock.Any(), usecase.CmdSearchDAGs{Query: "notice"}).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "failed to search DAGs")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/dags/search"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
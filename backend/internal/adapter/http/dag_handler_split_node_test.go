@@ -0,0 +1,128 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_SplitNode(t *testing.T) {
+	dagUUID := uuid.New()
+	nodeUUID := uuid.New()
+	answer1, answer2 := uuid.New(), uuid.New()
+
+	validBody := `{"buckets": [` +
+		`{"answer": "Employment", "question": "Which employment matter?", "answer_ids": ["` + answer1.String() + `"]},` +
+		`{"answer": "Housing", "question": "Which housing matter?", "answer_ids": ["` + answer2.String() + `"]}]}`
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "successfully splits node",
+			requestBody: validBody,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SplitNode(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, cmd usecase.CmdSplitNode) (*model.DAG, error) {
+						assert.Equal(t, dagUUID.String(), cmd.DAGId)
+						assert.Equal(t, nodeUUID.String(), cmd.NodeId)
+						assert.Equal(t, []usecase.SplitBucket{
+							{Statement: "Employment", Question: "Which employment matter?", AnswerIds: []string{answer1.String()}},
+							{Statement: "Housing", Question: "Which housing matter?", AnswerIds: []string{answer2.String()}},
+						}, cmd.Buckets)
+						return dagtest.LinearChain(3), nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Len(t, response.Nodes, 3)
+			},
+		},
+		{
+			name:           "returns 400 for invalid JSON",
+			requestBody:    "invalid json",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid request body")
+			},
+		},
+		{
+			name:        "returns 400 for an invalid grouping",
+			requestBody: validBody,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SplitNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid node split")
+			},
+		},
+		{
+			name:        "returns 404 when node not found",
+			requestBody: validBody,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SplitNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "DAG or node not found")
+			},
+		},
+		{
+			name:        "returns 500 for internal server error",
+			requestBody: validBody,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().SplitNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "failed to split node")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			url := "/v1/dags/" + dagUUID.String() + "/nodes/" + nodeUUID.String() + "/split"
+			req, err := http.NewRequest("POST", url, bytes.NewBufferString(tt.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"dagId": dagUUID.String(), "nodeId": nodeUUID.String()})
+
+			rr := httptest.NewRecorder()
+			handler.SplitNode(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withUnknownField returns the JSON encoding of v with a misspelled title field added
+func withUnknownField(t *testing.T, v any) string {
+	t.Helper()
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(mustJSON(t, v)), &payload))
+	payload["titel"] = "Misspelled title"
+
+	return mustJSON(t, payload)
+}
+
+func TestDAGHandler_StrictDecoding(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "update accepts unknown fields by default",
+			method: http.MethodPut,
+			path:   "/v1/dags/" + testDAG.Id.String(),
+			body:   withUnknownField(t, NewDAGPresenter(testDAG)),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Update(gomock.Any(), gomock.Any()).Return(testDAG, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "update rejects unknown fields in strict mode",
+			method:         http.MethodPut,
+			path:           "/v1/dags/" + testDAG.Id.String() + "?strict=true",
+			body:           withUnknownField(t, NewDAGPresenter(testDAG)),
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `unknown field \"titel\"`,
+		},
+		{
+			name:   "update accepts a clean payload in strict mode",
+			method: http.MethodPut,
+			path:   "/v1/dags/" + testDAG.Id.String() + "?strict=true",
+			body:   mustJSON(t, NewDAGPresenter(testDAG)),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Update(gomock.Any(), gomock.Any()).Return(testDAG, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "update rejects an invalid strict parameter",
+			method:         http.MethodPut,
+			path:           "/v1/dags/" + testDAG.Id.String() + "?strict=maybe",
+			body:           mustJSON(t, NewDAGPresenter(testDAG)),
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid strict parameter",
+		},
+		{
+			name:   "validate accepts unknown fields by default",
+			method: http.MethodPost,
+			path:   "/v1/dags/validate",
+			body:   withUnknownField(t, ValidateRequest{DAG: NewDAGPresenter(testDAG)}),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ValidateDAG(gomock.Any(), gomock.Any(), gomock.Any()).Return(usecase.ValidationResult{IsValid: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "validate rejects unknown fields in strict mode",
+			method:         http.MethodPost,
+			path:           "/v1/dags/validate?strict=true",
+			body:           withUnknownField(t, ValidateRequest{DAG: NewDAGPresenter(testDAG)}),
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `unknown field \"titel\"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler := NewDAGHandler(mockApp)
+			if tt.method == http.MethodPut {
+				req = mux.SetURLVars(req, map[string]string{dagId: testDAG.Id.String()})
+				handler.Update(rr, req)
+			} else {
+				handler.ValidateDAG(rr, req)
+			}
+
+			assert.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Subtree(t *testing.T) {
+	stored := dagtest.LinearChain(3)
+	root := dagtest.Root(stored)
+	subtree, err := stored.Subtree(root.Id)
+	require.NoError(t, err)
+	target := "/v1/dags/" + stored.Id.String() + "/subtree/" + root.Id.String()
+
+	serve := func(mockApp *mocks.MockApp, method, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rr
+	}
+
+	t.Run("returns the subtree of a node", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().ExtractSubtree(gomock.Any(), usecase.CmdExtractSubtree{
+			DAGId:  stored.Id.String(),
+			NodeId: root.Id.String(),
+		}).Return(subtree, nil)
+
+		rr := serve(mockApp, http.MethodGet, "")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response DAGPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, subtree.Id, response.Id)
+		assert.Len(t, response.Nodes, 3)
+	})
+
+	t.Run("materializes the subtree of a node", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().MaterializeSubtree(gomock.Any(), usecase.CmdExtractSubtree{
+			DAGId:  stored.Id.String(),
+			NodeId: root.Id.String(),
+			Title:  "Branch",
+		}).Return(subtree, nil)
+		mockApp.EXPECT().MaterializeSubtree(gomock.Any(), usecase.CmdExtractSubtree{
+			DAGId:  stored.Id.String(),
+			NodeId: root.Id.String(),
+		}).Return(subtree, nil)
+
+		rr := serve(mockApp, http.MethodPost, `{"title":"Branch"}`)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+
+		rr = serve(mockApp, http.MethodPost, "")
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("maps errors to statuses", func(t *testing.T) {
+		for _, tt := range []struct {
+			err            error
+			expectedStatus int
+		}{
+			{fmt.Errorf("%w: invalid UUID format", usecase.ErrInvalidCommand), http.StatusBadRequest},
+			{fmt.Errorf("%w: node not found", usecase.ErrNotFound), http.StatusNotFound},
+			{usecase.ErrInternal, http.StatusInternalServerError},
+		} {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			mockApp.EXPECT().ExtractSubtree(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+			mockApp.EXPECT().MaterializeSubtree(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+
+			assert.Equal(t, tt.expectedStatus, serve(mockApp, http.MethodGet, "").Code, tt.err)
+			assert.Equal(t, tt.expectedStatus, serve(mockApp, http.MethodPost, "{}").Code, tt.err)
+		}
+	})
+}
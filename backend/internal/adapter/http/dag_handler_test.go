@@ -2,12 +2,14 @@ package http
 
 import (
 	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -30,6 +32,7 @@ func TestNewDAGHandler(t *testing.T) {
 func TestDAGHandler_List(t *testing.T) {
 	tests := []struct {
 		name           string
+		query          string
 		setupMock      func(*mocks.MockApp)
 		expectedStatus int
 		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
@@ -57,36 +60,41 @@ func TestDAGHandler_List(t *testing.T) {
 				}
 
 				dags := []*model.DAG{dag1, dag2}
-				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{}).Return(dags, nil)
+				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{}).Return(&model.DAGPage{DAGs: dags, Total: 2}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
-				var response DAGSummaryListPresenter
+				var response DAGSummaryPagePresenter
 				err := json.Unmarshal(rr.Body.Bytes(), &response)
 				require.NoError(t, err)
 
 				assert.Len(t, response.DAGs, 2)
 				assert.Equal(t, 2, response.Count)
+				assert.Equal(t, 2, response.Total)
+				assert.Zero(t, response.Page)
+				assert.Zero(t, response.PageSize)
 
 				// Check first DAG
 				assert.Equal(t, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), response.DAGs[0].Id)
 				assert.Equal(t, "Employment Law Case", response.DAGs[0].Title)
 				assert.True(t, response.DAGs[0].IsValid)
+				assert.Equal(t, ValidationStatusValid, response.DAGs[0].ValidationStatus)
 
 				// Check second DAG
 				assert.Equal(t, uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), response.DAGs[1].Id)
 				assert.Equal(t, "Contract Dispute", response.DAGs[1].Title)
 				assert.False(t, response.DAGs[1].IsValid) // Should be false when metadata is nil
+				assert.Equal(t, ValidationStatusUnknown, response.DAGs[1].ValidationStatus)
 			},
 		},
 		{
 			name: "returns empty list when no DAGs exist",
 			setupMock: func(mockApp *mocks.MockApp) {
-				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{}).Return([]*model.DAG{}, nil)
+				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{}).Return(&model.DAGPage{DAGs: []*model.DAG{}}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
-				var response DAGSummaryListPresenter
+				var response DAGSummaryPagePresenter
 				err := json.Unmarshal(rr.Body.Bytes(), &response)
 				require.NoError(t, err)
 
@@ -94,6 +102,117 @@ func TestDAGHandler_List(t *testing.T) {
 				assert.Equal(t, 0, response.Count)
 			},
 		},
+		{
+			name:  "passes freshness sort and filter to the app layer",
+			query: "?sort=-updated&updated_since=2024-01-15T10:30:00Z",
+			setupMock: func(mockApp *mocks.MockApp) {
+				updatedAt := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{
+					Sort:         usecase.SortUpdatedDesc,
+					UpdatedSince: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+				}).Return(&model.DAGPage{DAGs: []*model.DAG{{Id: uuid.New(), Title: "Recent", UpdatedAt: updatedAt}}, Total: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGSummaryPagePresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Len(t, response.DAGs, 1)
+				require.NotNil(t, response.DAGs[0].UpdatedAt)
+				assert.True(t, time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC).Equal(*response.DAGs[0].UpdatedAt))
+			},
+		},
+		{
+			name:  "computes validity of DAGs without metadata",
+			query: "?compute_missing=true",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{}).Return(&model.DAGPage{DAGs: []*model.DAG{dagtest.ValidSingleRoot(), dagtest.MultipleRoots()}, Total: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGSummaryPagePresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Len(t, response.DAGs, 2)
+				assert.True(t, response.DAGs[0].IsValid)
+				assert.Equal(t, ValidationStatusValid, response.DAGs[0].ValidationStatus)
+				assert.False(t, response.DAGs[1].IsValid)
+				assert.Equal(t, ValidationStatusInvalid, response.DAGs[1].ValidationStatus)
+			},
+		},
+		{
+			name:           "returns 400 for invalid compute_missing",
+			query:          "?compute_missing=maybe",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid compute_missing parameter")
+			},
+		},
+		{
+			name:           "returns 400 for invalid updated_since",
+			query:          "?updated_since=yesterday",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid updated_since parameter")
+			},
+		},
+		{
+			name:  "passes pagination, title sort and filter to the app layer",
+			query: "?sort=title&title_contains=employment&page=2&page_size=1",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{
+					Sort:          model.SortTitleAsc,
+					TitleContains: "employment",
+					Page:          2,
+					PageSize:      1,
+				}).Return(&model.DAGPage{
+					DAGs:     []*model.DAG{{Id: uuid.New(), Title: "Employment Law Case"}},
+					Total:    3,
+					Page:     2,
+					PageSize: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGSummaryPagePresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				require.Len(t, response.DAGs, 1)
+				assert.Equal(t, "Employment Law Case", response.DAGs[0].Title)
+				assert.Equal(t, 1, response.Count)
+				assert.Equal(t, 3, response.Total)
+				assert.Equal(t, 2, response.Page)
+				assert.Equal(t, 1, response.PageSize)
+			},
+		},
+		{
+			name:           "returns 400 for invalid page",
+			query:          "?page=first",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid page parameter")
+			},
+		},
+		{
+			name:           "returns 400 for invalid page_size",
+			query:          "?page_size=all",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid page_size parameter")
+			},
+		},
+		{
+			name:  "returns 400 for invalid sort",
+			query: "?sort=name",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ListDAGs(gomock.Any(), usecase.CmdListDAGs{Sort: "name"}).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid list parameters")
+			},
+		},
 		{
 			name: "returns 500 when app layer fails",
 			setupMock: func(mockApp *mocks.MockApp) {
@@ -116,7 +235,7 @@ func TestDAGHandler_List(t *testing.T) {
 
 			handler := NewDAGHandler(mockApp)
 
-			req, err := http.NewRequest("GET", "/v1/dags", nil)
+			req, err := http.NewRequest("GET", "/v1/dags"+tt.query, nil)
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
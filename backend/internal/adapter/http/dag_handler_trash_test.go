@@ -0,0 +1,109 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Trash(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 16, 8, 0, 0, 0, time.UTC)
+	trashed := model.NewDAG("Trashed")
+	trashed.DeletedAt = &deletedAt
+
+	t.Run("lists the deleted DAGs", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().ListDeletedDAGs(gomock.Any()).Return([]*model.DAG{trashed}, nil)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/dags/trash", nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response DAGSummaryListPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.DAGs, 1)
+		assert.Equal(t, trashed.Id, response.DAGs[0].Id)
+		require.NotNil(t, response.DAGs[0].DeletedAt)
+		assert.True(t, deletedAt.Equal(*response.DAGs[0].DeletedAt))
+	})
+
+	t.Run("returns 500 when app layer fails", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().ListDeletedDAGs(gomock.Any()).Return(nil, usecase.ErrInternal)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/dags/trash", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "failed to list deleted DAGs")
+	})
+}
+
+func TestDAGHandler_RestoreDeleted(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "restores the DAG",
+			setupMock: func(mockApp *mocks.MockApp) {
+				restored := model.NewDAG("Restored")
+				restored.Id = id
+				mockApp.EXPECT().RestoreDeletedDAG(gomock.Any(), usecase.CmdRestoreDeletedDAG{DAGId: id.String()}).Return(restored, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "Restored",
+		},
+		{
+			name: "returns 404 for unknown DAG",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RestoreDeletedDAG(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG not found",
+		},
+		{
+			name: "returns 409 for live DAG",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RestoreDeletedDAG(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "DAG is not deleted",
+		},
+		{
+			name: "returns 500 when app layer fails",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RestoreDeletedDAG(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to restore deleted DAG",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			tt.setupMock(mockApp)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/dags/"+id.String()+"/restore", nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+		})
+	}
+}
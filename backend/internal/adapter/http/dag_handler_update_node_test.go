@@ -0,0 +1,221 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_UpdateNode(t *testing.T) {
+	dagID := uuid.New().String()
+	nodeID := uuid.New()
+	url := "/v1/dags/" + dagID + "/nodes/" + nodeID.String()
+	question := "Were you dismissed in writing?"
+	required := true
+
+	tests := []struct {
+		name           string
+		body           string
+		ifMatch        string
+		config         Config
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "updates the given fields with normalized whitespace",
+			body: `{"question":"  Were you   dismissed in writing? ","required":true}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateNode(gomock.Any(), usecase.CmdUpdateNode{
+					DAGId:    dagID,
+					NodeId:   nodeID.String(),
+					Question: &question,
+					Required: &required,
+				}).Return(&model.Node{Id: nodeID, Question: question, Required: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "preserves whitespace when configured",
+			body:   `{"question":"  Spaced  "}`,
+			config: Config{PreserveWhitespace: true},
+			setupMock: func(mockApp *mocks.MockApp) {
+				spaced := "  Spaced  "
+				mockApp.EXPECT().UpdateNode(gomock.Any(), usecase.CmdUpdateNode{
+					DAGId:    dagID,
+					NodeId:   nodeID.String(),
+					Question: &spaced,
+				}).Return(&model.Node{Id: nodeID, Question: spaced}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "forwards the If-Match revision",
+			body:    `{"required":true}`,
+			ifMatch: `"7"`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				revision := uint64(7)
+				mockApp.EXPECT().UpdateNode(gomock.Any(), usecase.CmdUpdateNode{
+					DAGId:      dagID,
+					NodeId:     nodeID.String(),
+					Required:   &required,
+					IfRevision: &revision,
+				}).Return(&model.Node{Id: nodeID, Required: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "returns 412 when the DAG moved on",
+			body:    `{"required":true}`,
+			ifMatch: `"7"`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrPreconditionFailed)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   "DAG was modified since it was read",
+		},
+		{
+			name:           "returns 400 for invalid body",
+			body:           `{invalid`,
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid request body",
+		},
+		{
+			name: "returns 400 when the resulting DAG is invalid",
+			body: `{"question":"x"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid node update",
+		},
+		{
+			name: "returns 404 for unknown node",
+			body: `{"question":"x"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG or node not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			router := New(mockApp, nil, tt.config)
+			req := httptest.NewRequest(http.MethodPatch, url, strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedBody)
+				return
+			}
+
+			var presenter NodePresenter
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &presenter))
+			assert.Equal(t, nodeID, presenter.Id)
+		})
+	}
+}
+
+func TestDAGHandler_UpdateAnswer(t *testing.T) {
+	dagID := uuid.New().String()
+	nodeID := uuid.New().String()
+	answerID := uuid.New()
+	url := "/v1/dags/" + dagID + "/nodes/" + nodeID + "/answers/" + answerID.String()
+	terminal := ""
+	disabled := true
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "updates the given fields",
+			body: `{"next_node":"","disabled":true}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateAnswer(gomock.Any(), usecase.CmdUpdateAnswer{
+					DAGId:    dagID,
+					NodeId:   nodeID,
+					AnswerId: answerID.String(),
+					NextNode: &terminal,
+					Disabled: &disabled,
+				}).Return(&model.Answer{Id: answerID, Statement: "Yes", Disabled: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "returns 400 for a dangling reference",
+			body: `{"next_node":"` + uuid.NewString() + `"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateAnswer(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid answer update",
+		},
+		{
+			name: "returns 404 for unknown answer",
+			body: `{"answer":"Yes"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateAnswer(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG, node or answer not found",
+		},
+		{
+			name: "returns 500 when app layer fails",
+			body: `{"answer":"Yes"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().UpdateAnswer(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to update answer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			router := New(mockApp, nil, Config{})
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPatch, url, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedBody)
+				return
+			}
+
+			var presenter AnswerPresenter
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &presenter))
+			assert.Equal(t, answerID, presenter.Id)
+			assert.True(t, presenter.Disabled)
+		})
+	}
+}
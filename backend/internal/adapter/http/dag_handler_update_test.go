@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
 	"encoding/json"
@@ -19,7 +20,7 @@ import (
 )
 
 func TestDAGHandler_Update(t *testing.T) {
-	testDAG := createTestDAG()
+	testDAG := dagtest.ValidSingleRoot()
 
 	tests := []struct {
 		name           string
@@ -155,7 +156,7 @@ func TestDAGHandler_Update(t *testing.T) {
 }
 
 func TestDAGHandler_PresenterToDAG(t *testing.T) {
-	testDAG := createTestDAG()
+	testDAG := dagtest.ValidSingleRoot()
 	presenter := NewDAGPresenter(testDAG)
 
 	handler := NewDAGHandler(nil) // App not needed for this test
@@ -233,30 +234,6 @@ func TestDAGHandler_Update_ComplexDAG(t *testing.T) {
 	assert.True(t, hasMetadata, "Metadata should be preserved in complex DAG")
 }
 
-// Helper function to create a test DAG
-func createTestDAG() *model.DAG {
-	dagId := uuid.New()
-	nodeId := uuid.New()
-	answerId := uuid.New()
-
-	return &model.DAG{
-		Id: dagId,
-		Nodes: map[uuid.UUID]model.Node{
-			nodeId: {
-				Id:       nodeId,
-				Question: "Is this a test question?",
-				Answers: []model.Answer{
-					{
-						Id:        answerId,
-						Statement: "Yes, this is a test",
-						NextNode:  nil, // Leaf node
-					},
-				},
-			},
-		},
-	}
-}
-
 // Helper function to create a complex test DAG with metadata
 func createComplexTestDAG() *model.DAG {
 	dagId := uuid.New()
@@ -311,3 +288,173 @@ func createComplexTestDAG() *model.DAG {
 		},
 	}
 }
+
+func TestDAGHandler_Update_DryRun(t *testing.T) {
+	testDAG := dagtest.LinearChain(3)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "returns statistics before and after the update",
+			query: "?dry_run=true&include_stats=true",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().PreviewUpdate(gomock.Any(), gomock.Any()).Return(&usecase.UpdatePreview{
+					DAG:    testDAG,
+					Before: usecase.ValidationStatistics{TotalNodes: 2},
+					After:  usecase.ValidationStatistics{TotalNodes: 3},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response UpdatePreviewPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, 2, response.Before.TotalNodes)
+				assert.Equal(t, 3, response.After.TotalNodes)
+			},
+		},
+		{
+			name:  "returns the validated DAG without statistics",
+			query: "?dry_run=true",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().PreviewUpdate(gomock.Any(), gomock.Any()).Return(&usecase.UpdatePreview{DAG: testDAG}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response DAGPresenter
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Len(t, response.Nodes, 3)
+			},
+		},
+		{
+			name:  "returns 400 for an invalid DAG",
+			query: "?dry_run=true&include_stats=true",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().PreviewUpdate(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid DAG data")
+			},
+		},
+		{
+			name:           "returns 400 for an invalid dry_run value",
+			query:          "?dry_run=maybe",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Contains(t, rr.Body.String(), "invalid dry_run parameter")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Update must never be called on a dry run
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			handler := NewDAGHandler(mockApp)
+
+			requestBody, err := json.Marshal(NewDAGPresenter(testDAG))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("PUT", "/v1/dags/"+testDAG.Id.String()+tt.query, bytes.NewBuffer(requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"dagId": testDAG.Id.String()})
+
+			rr := httptest.NewRecorder()
+			handler.Update(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			tt.checkResponse(t, rr)
+		})
+	}
+}
+
+func TestDAGHandler_Update_IfMatch(t *testing.T) {
+	testDAG := dagtest.ValidSingleRoot()
+	updated := *testDAG
+	updated.Revision = 4
+	revision := uint64(3)
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedETag   string
+		expectedBody   string
+	}{
+		{
+			name:    "forwards the If-Match revision and returns the new ETag",
+			ifMatch: `"3"`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, cmd usecase.CmdUpdateDAG) (*model.DAG, error) {
+						assert.Equal(t, &revision, cmd.IfRevision)
+						return &updated, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:    "any revision matches *",
+			ifMatch: "*",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, cmd usecase.CmdUpdateDAG) (*model.DAG, error) {
+						assert.Nil(t, cmd.IfRevision)
+						return &updated, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:           "returns 400 for a malformed If-Match",
+			ifMatch:        `W/"3"`,
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid If-Match header",
+		},
+		{
+			name:    "returns 412 when the DAG moved on",
+			ifMatch: `"3"`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrPreconditionFailed)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   "DAG was modified since it was read",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			tt.setupMock(mockApp)
+
+			body, err := json.Marshal(NewDAGPresenter(testDAG))
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPut, "/v1/dags/"+testDAG.Id.String(), bytes.NewReader(body))
+			req.Header.Set("If-Match", tt.ifMatch)
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGHandler_Versions(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+	id := d.Id.String()
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	versions := []model.DAGVersion{
+		{Number: 1, DAG: d, CreatedAt: createdAt},
+		{Number: 2, DAG: d, CreatedAt: createdAt.Add(time.Hour), Author: "author"},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+		assertBody     func(t *testing.T, body []byte)
+	}{
+		{
+			name:   "lists versions",
+			method: http.MethodGet,
+			url:    "/v1/dags/" + id + "/versions",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ListDAGVersions(gomock.Any(), usecase.CmdListDAGVersions{DAGId: id}).Return(versions, nil)
+			},
+			expectedStatus: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				var presenter DAGVersionListPresenter
+				require.NoError(t, json.Unmarshal(body, &presenter))
+				require.Equal(t, 2, presenter.Count)
+				assert.Equal(t, 2, presenter.Versions[1].Number)
+				assert.Equal(t, "author", presenter.Versions[1].Author)
+				assert.Equal(t, len(d.Nodes), presenter.Versions[1].NodeCount)
+			},
+		},
+		{
+			name:   "returns 404 when listing versions of unknown DAG",
+			method: http.MethodGet,
+			url:    "/v1/dags/" + id + "/versions",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ListDAGVersions(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG not found",
+		},
+		{
+			name:   "gets a version snapshot",
+			method: http.MethodGet,
+			url:    "/v1/dags/" + id + "/versions/2",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetDAGVersion(gomock.Any(), usecase.CmdGetDAGVersion{DAGId: id, Number: 2}).Return(&versions[1], nil)
+			},
+			expectedStatus: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				var presenter DAGVersionPresenter
+				require.NoError(t, json.Unmarshal(body, &presenter))
+				assert.Equal(t, 2, presenter.Number)
+				assert.Equal(t, d.Id, presenter.DAG.Id)
+				assert.Len(t, presenter.DAG.Nodes, len(d.Nodes))
+			},
+		},
+		{
+			name:           "returns 400 for invalid version number",
+			method:         http.MethodGet,
+			url:            "/v1/dags/" + id + "/versions/latest",
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid version number",
+		},
+		{
+			name:   "returns 404 for unknown version",
+			method: http.MethodGet,
+			url:    "/v1/dags/" + id + "/versions/9",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetDAGVersion(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG version not found",
+		},
+		{
+			name:   "restores a version",
+			method: http.MethodPost,
+			url:    "/v1/dags/" + id + "/versions/1/restore",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RestoreDAGVersion(gomock.Any(), usecase.CmdRestoreDAGVersion{DAGId: id, Number: 1}).Return(d, nil)
+			},
+			expectedStatus: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				var presenter DAGPresenter
+				require.NoError(t, json.Unmarshal(body, &presenter))
+				assert.Equal(t, d.Id, presenter.Id)
+			},
+		},
+		{
+			name:   "returns 400 when the version fails validation",
+			method: http.MethodPost,
+			url:    "/v1/dags/" + id + "/versions/1/restore",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RestoreDAGVersion(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid DAG version",
+		},
+		{
+			name:   "returns 500 when restore fails",
+			method: http.MethodPost,
+			url:    "/v1/dags/" + id + "/versions/1/restore",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().RestoreDAGVersion(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to restore DAG version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			router := New(mockApp, nil, Config{})
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.url, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.assertBody != nil {
+				tt.assertBody(t, rr.Body.Bytes())
+				return
+			}
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package http
 
 import (
 	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,9 +13,36 @@ import (
 // @Description Legal Case DAG with questions, answers, and context
 // @Example {"id": "550e8400-e29b-41d4-a716-446655440000", "title": "Employment Discrimination Case", "nodes": [{"id": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655", "question": "Were you discriminated against?", "answers": [{"id": "fc28c4b6-d185-cf56-a7e4-dead499ff1e8", "answer": "Yes, age discrimination occurred", "user_context": "Manager explicitly mentioned my age", "metadata": {"confidence": 0.9, "tags": ["age_discrimination"]}}]}]}
 type DAGPresenter struct {
-	Id    uuid.UUID       `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" description:"Unique identifier for the Legal Case DAG"`
-	Title string          `json:"title" example:"Employment Discrimination Case" description:"Human-readable title describing the legal case context"`
-	Nodes []NodePresenter `json:"nodes" description:"Array of question nodes that make up the legal case decision tree"`
+	Id             uuid.UUID                `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" description:"Unique identifier for the Legal Case DAG"`
+	Title          string                   `json:"title" example:"Employment Discrimination Case" description:"Human-readable title describing the legal case context"`
+	Nodes          []NodePresenter          `json:"nodes" description:"Array of question nodes that make up the legal case decision tree"`
+	Scoring        *ScoringPresenter        `json:"scoring,omitempty" description:"Dimensions the answers contribute scores to, every contributed dimension is scored when absent"`
+	MetadataSchema *MetadataSchemaPresenter `json:"metadata_schema,omitempty" description:"Fields of answer metadata, any metadata is accepted when absent"`
+}
+
+// ScoringPresenter represents the scoring configuration of a DAG
+//
+// @Description Dimensions the answers of a DAG contribute scores to
+type ScoringPresenter struct {
+	Dimensions []ScoreDimensionPresenter `json:"dimensions" description:"Declared dimensions, in display order"`
+}
+
+// ScoreDimensionPresenter represents a scoring dimension
+//
+// @Description Axis scored along a path, e.g. the strength of a claim
+type ScoreDimensionPresenter struct {
+	Name   string               `json:"name" example:"claim_strength" description:"Name answers refer to in their scores"`
+	Label  string               `json:"label,omitempty" example:"Claim strength" description:"Human-readable label"`
+	Weight float64              `json:"weight,omitempty" example:"2" description:"Weight of the dimension in the total score, 1 when omitted"`
+	Bands  []ScoreBandPresenter `json:"bands,omitempty" description:"Bands qualifying the score, the band with the highest minimum reached applies"`
+}
+
+// ScoreBandPresenter represents a band qualifying a dimension score
+//
+// @Description Label given to the scores reaching a minimum
+type ScoreBandPresenter struct {
+	Min   float64 `json:"min" example:"10" description:"Minimum score of the band"`
+	Label string  `json:"label" example:"strong" description:"Label of the band"`
 }
 
 func NewDAGPresenter(dag *model.DAG) DAGPresenter {
@@ -23,10 +52,106 @@ func NewDAGPresenter(dag *model.DAG) DAGPresenter {
 	}
 
 	return DAGPresenter{
-		Id:    dag.Id,
-		Title: dag.Title,
-		Nodes: nodes,
+		Id:             dag.Id,
+		Title:          dag.Title,
+		Nodes:          nodes,
+		Scoring:        newScoringPresenter(dag.Scoring),
+		MetadataSchema: newMetadataSchemaPresenter(dag.MetadataSchema),
+	}
+}
+
+func newScoringPresenter(scoring *model.Scoring) *ScoringPresenter {
+	if scoring == nil {
+		return nil
+	}
+
+	presenter := &ScoringPresenter{Dimensions: make([]ScoreDimensionPresenter, 0, len(scoring.Dimensions))}
+	for _, dimension := range scoring.Dimensions {
+		dp := ScoreDimensionPresenter{Name: dimension.Name, Label: dimension.Label, Weight: dimension.Weight}
+		for _, band := range dimension.Bands {
+			dp.Bands = append(dp.Bands, ScoreBandPresenter(band))
+		}
+		presenter.Dimensions = append(presenter.Dimensions, dp)
+	}
+	return presenter
+}
+
+func presenterToScoring(presenter *ScoringPresenter) *model.Scoring {
+	if presenter == nil {
+		return nil
+	}
+
+	scoring := &model.Scoring{Dimensions: make([]model.ScoreDimension, 0, len(presenter.Dimensions))}
+	for _, dp := range presenter.Dimensions {
+		dimension := model.ScoreDimension{Name: dp.Name, Label: dp.Label, Weight: dp.Weight}
+		for _, band := range dp.Bands {
+			dimension.Bands = append(dimension.Bands, model.ScoreBand(band))
+		}
+		scoring.Dimensions = append(scoring.Dimensions, dimension)
+	}
+	return scoring
+}
+
+// MetadataSchemaPresenter represents the metadata schema of a DAG
+//
+// @Description Fields the metadata of the answers of a DAG may hold
+// @Example {"fields": [{"name": "confidence", "type": "number", "required": true}, {"name": "severity", "type": "string", "enum": ["low", "medium", "high"]}]}
+type MetadataSchemaPresenter struct {
+	Fields           []MetadataFieldPresenter `json:"fields" description:"Declared metadata fields"`
+	AdditionalFields bool                     `json:"additional_fields,omitempty" example:"false" description:"Accept metadata fields the schema does not declare"`
+}
+
+// MetadataFieldPresenter represents a field of answer metadata
+//
+// @Description Name, type and allowed values of an answer metadata field
+type MetadataFieldPresenter struct {
+	Name        string   `json:"name" example:"severity" description:"Metadata key"`
+	Type        string   `json:"type" enums:"string,number,boolean,string_list" example:"string" description:"Type of the values"`
+	Enum        []string `json:"enum,omitempty" example:"low,medium,high" description:"Allowed values of string and string_list fields, any value when omitted"`
+	Required    bool     `json:"required,omitempty" example:"false" description:"Field must be set on every answer"`
+	Description string   `json:"description,omitempty" example:"Severity of the situation" description:"Human-readable description"`
+}
+
+func newMetadataSchemaPresenter(schema *model.MetadataSchema) *MetadataSchemaPresenter {
+	if schema == nil {
+		return nil
+	}
+
+	presenter := &MetadataSchemaPresenter{
+		Fields:           make([]MetadataFieldPresenter, 0, len(schema.Fields)),
+		AdditionalFields: schema.AdditionalFields,
+	}
+	for _, field := range schema.Fields {
+		presenter.Fields = append(presenter.Fields, MetadataFieldPresenter{
+			Name:        field.Name,
+			Type:        string(field.Type),
+			Enum:        field.Enum,
+			Required:    field.Required,
+			Description: field.Description,
+		})
 	}
+	return presenter
+}
+
+func presenterToMetadataSchema(presenter *MetadataSchemaPresenter) *model.MetadataSchema {
+	if presenter == nil {
+		return nil
+	}
+
+	schema := &model.MetadataSchema{
+		Fields:           make([]model.MetadataField, 0, len(presenter.Fields)),
+		AdditionalFields: presenter.AdditionalFields,
+	}
+	for _, field := range presenter.Fields {
+		schema.Fields = append(schema.Fields, model.MetadataField{
+			Name:        field.Name,
+			Type:        model.MetadataFieldType(field.Type),
+			Enum:        field.Enum,
+			Required:    field.Required,
+			Description: field.Description,
+		})
+	}
+	return schema
 }
 
 // NodePresenter represents a question node in the Legal Case DAG
@@ -34,9 +159,36 @@ func NewDAGPresenter(dag *model.DAG) DAGPresenter {
 // @Description A question node with potential answers for legal case context building
 // @Example {"id": "8b007ce4-b676-5fb3-9f93-f5f6c41cb655", "question": "Were you discriminated against?", "answers": [{"id": "fc28c4b6-d185-cf56-a7e4-dead499ff1e8", "answer": "Yes", "user_context": "Manager made age-related comments"}]}
 type NodePresenter struct {
-	Id       uuid.UUID         `json:"id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"Unique identifier for the question node"`
-	Question string            `json:"question" example:"Were you discriminated against in the workplace?" description:"The legal question being asked"`
-	Answers  []AnswerPresenter `json:"answers" description:"Available answer options for this question"`
+	Id              uuid.UUID         `json:"id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"Unique identifier for the question node"`
+	Question        string            `json:"question" example:"Were you discriminated against in the workplace?" description:"The legal question being asked"`
+	Answers         []AnswerPresenter `json:"answers" description:"Available answer options for this question"`
+	Required        bool              `json:"required,omitempty" example:"true" description:"Whether the question is mandatory for walk coverage"`
+	MultiSelect     bool              `json:"multi_select,omitempty" example:"false" description:"Whether several answers can be selected"`
+	MultiSelectNext string            `json:"multi_select_next,omitempty" example:"priority" enums:"priority,convergence" description:"How the next node of a multi-select question is resolved: priority follows the first selected answer leading somewhere, convergence requires all selected answers to lead to the same node"`
+	Translations    map[string]string `json:"translations,omitempty" description:"Question translations keyed by language code"`
+	Type            string            `json:"type,omitempty" example:"question" enums:"question,information,outcome,input" description:"How the node is presented: question (default), information displayed before advancing through its single answer, outcome ending the walk, or input asking for a value before advancing through its single answer"`
+	Outcome         *OutcomePresenter `json:"outcome,omitempty" description:"Assessment concluding the walk, for outcome nodes"`
+	Input           *InputPresenter   `json:"input,omitempty" description:"Value entered on input nodes and how it is validated"`
+}
+
+// OutcomePresenter represents the assessment of an outcome node
+//
+// @Description Assessment label, recommended actions and statutes an outcome node concludes a walk with
+type OutcomePresenter struct {
+	Assessment         string   `json:"assessment" example:"Likely unfair dismissal" description:"Assessment label"`
+	RecommendedActions []string `json:"recommended_actions,omitempty" example:"File a claim within 12 months" description:"Actions recommended to the user"`
+	Statutes           []string `json:"statutes,omitempty" example:"Code du travail L1235-1" description:"Legal provisions the assessment relies on"`
+}
+
+// InputPresenter represents the value entered on an input node
+//
+// @Description Kind of value entered on an input node, the variable it is stored under and its validation rules
+type InputPresenter struct {
+	Kind     string `json:"kind,omitempty" example:"date" enums:"text,number,date" description:"Kind of value, text when empty"`
+	Variable string `json:"variable" example:"termination_date" description:"Variable the value is stored under, for conditions to refer to it"`
+	Pattern  string `json:"pattern,omitempty" example:"^[0-9]{4}-" description:"Regular expression the value must match"`
+	Min      string `json:"min,omitempty" example:"2000-01-01" description:"Inclusive lower bound of number and date values, dates being written YYYY-MM-DD"`
+	Max      string `json:"max,omitempty" example:"2030-12-31" description:"Inclusive upper bound of number and date values, dates being written YYYY-MM-DD"`
 }
 
 func NewNodePresenter(node model.Node) NodePresenter {
@@ -46,9 +198,30 @@ func NewNodePresenter(node model.Node) NodePresenter {
 	}
 
 	np := NodePresenter{
-		Id:       node.Id,
-		Question: node.Question,
-		Answers:  answers,
+		Id:              node.Id,
+		Question:        node.Question,
+		Answers:         answers,
+		Required:        node.Required,
+		MultiSelect:     node.MultiSelect,
+		Translations:    node.Translations,
+		Type:            string(node.Type),
+		MultiSelectNext: string(node.MultiSelectNext),
+	}
+	if node.Outcome != nil {
+		np.Outcome = &OutcomePresenter{
+			Assessment:         node.Outcome.Assessment,
+			RecommendedActions: node.Outcome.RecommendedActions,
+			Statutes:           node.Outcome.Statutes,
+		}
+	}
+	if node.Input != nil {
+		np.Input = &InputPresenter{
+			Kind:     string(node.Input.Kind),
+			Variable: node.Input.Variable,
+			Pattern:  node.Input.Pattern,
+			Min:      node.Input.Min,
+			Max:      node.Input.Max,
+		}
 	}
 
 	return np
@@ -59,21 +232,68 @@ func NewNodePresenter(node model.Node) NodePresenter {
 // @Description An answer to a legal question with optional user context and structured metadata for evidence tracking
 // @Example {"id": "fc28c4b6-d185-cf56-a7e4-dead499ff1e8", "answer": "Yes, age discrimination occurred", "user_context": "Manager explicitly mentioned my age during termination", "metadata": {"confidence": 0.9, "severity": "high", "tags": ["age_discrimination", "wrongful_termination"], "sources": ["HR_Email.pdf", "Witness_Statement.pdf"], "damages_estimate": 75000}}
 type AnswerPresenter struct {
-	Id          uuid.UUID              `json:"id" example:"fc28c4b6-d185-cf56-a7e4-dead499ff1e8" description:"Unique identifier for the answer"`
-	Statement   string                 `json:"answer" example:"Yes, age discrimination occurred" description:"The answer statement or response"`
-	NextNode    *uuid.UUID             `json:"next_node,omitempty" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"ID of the next node to navigate to (null for leaf nodes)"`
-	UserContext string                 `json:"user_context,omitempty" example:"Manager explicitly mentioned my age during termination" description:"Free-form user notes and context for this answer"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty" description:"Structured metadata for legal assessment: confidence scores, evidence tracking, damages estimates, action items, etc."`
+	Id                   uuid.UUID                  `json:"id" example:"fc28c4b6-d185-cf56-a7e4-dead499ff1e8" description:"Unique identifier for the answer"`
+	Statement            string                     `json:"answer" example:"Yes, age discrimination occurred" description:"The answer statement or response"`
+	NextNode             *uuid.UUID                 `json:"next_node,omitempty" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"ID of the next node to navigate to (null for leaf nodes)"`
+	ParentNodeId         *uuid.UUID                 `json:"parent_node_id,omitempty" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"ID of the node asking the question this answer belongs to, for upward navigation. Ignored on input"`
+	UserContext          string                     `json:"user_context,omitempty" example:"Manager explicitly mentioned my age during termination" description:"Free-form user notes and context for this answer"`
+	Metadata             map[string]interface{}     `json:"metadata,omitempty" description:"Structured metadata for legal assessment: confidence scores, evidence tracking, damages estimates, action items, etc."`
+	LatestMetadataChange *MetadataSnapshotPresenter `json:"latest_metadata_change,omitempty" description:"Most recent recorded change of the answer metadata"`
+	MetadataHistoryCount int                        `json:"metadata_history_count,omitempty" example:"3" description:"Number of recorded metadata revisions"`
+	Translations         map[string]string          `json:"translations,omitempty" description:"Answer statement translations keyed by language code"`
+	Disabled             bool                       `json:"disabled,omitempty" example:"false" description:"Whether the answer is kept in the structure without being offered at runtime"`
+	Conditions           []BranchPresenter          `json:"conditions,omitempty" description:"Conditional next nodes, the first condition holding over the metadata collected along the walk wins over next_node"`
+	Scores               map[string]float64         `json:"scores,omitempty" description:"Contributions of the answer to the score of a path, keyed by dimension name"`
+}
+
+// BranchPresenter represents a conditional next node of an answer
+//
+// @Description Next node selected when the condition holds over the metadata collected along the walk
+type BranchPresenter struct {
+	When     string    `json:"when" example:"confidence > 0.7 && \"urgent\" in tags" description:"Condition over collected metadata: comparisons, membership tests with 'in', '!', '&&', '||' and parentheses"`
+	NextNode uuid.UUID `json:"next_node" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"ID of the node to navigate to when the condition holds"`
+}
+
+// MetadataSnapshotPresenter represents a recorded revision of answer metadata
+//
+// @Description Answer metadata as recorded after a change, with its author and timestamp
+type MetadataSnapshotPresenter struct {
+	At       time.Time              `json:"at" example:"2024-01-15T10:30:00Z" description:"When the metadata was changed"`
+	By       string                 `json:"by,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" description:"Identifier of the user who changed the metadata"`
+	Metadata map[string]interface{} `json:"metadata,omitempty" description:"Metadata after the change"`
 }
 
 func NewAnswerPresenter(answer model.Answer) AnswerPresenter {
-	return AnswerPresenter{
-		Id:          answer.Id,
-		Statement:   answer.Statement,
-		NextNode:    answer.NextNode,
-		UserContext: answer.UserContext,
-		Metadata:    answer.Metadata,
+	ap := AnswerPresenter{
+		Id:                   answer.Id,
+		Statement:            answer.Statement,
+		NextNode:             answer.NextNode,
+		UserContext:          answer.UserContext,
+		Metadata:             answer.Metadata,
+		MetadataHistoryCount: len(answer.MetadataHistory),
+		Translations:         answer.Translations,
+		Disabled:             answer.Disabled,
+		Scores:               answer.Scores,
+	}
+	for _, branch := range answer.Conditions {
+		ap.Conditions = append(ap.Conditions, BranchPresenter{When: branch.When, NextNode: branch.NextNode})
 	}
+
+	if answer.ParentNode != nil {
+		parentNodeId := answer.ParentNode.Id
+		ap.ParentNodeId = &parentNodeId
+	}
+
+	if len(answer.MetadataHistory) > 0 {
+		latest := answer.MetadataHistory[len(answer.MetadataHistory)-1]
+		ap.LatestMetadataChange = &MetadataSnapshotPresenter{
+			At:       latest.At,
+			By:       latest.By,
+			Metadata: latest.Metadata,
+		}
+	}
+
+	return ap
 }
 
 // DAGListPresenter represents a list of Legal Case DAG identifiers for API responses
@@ -95,13 +315,23 @@ func NewDAGListPresenter(dagIds []uuid.UUID) DAGListPresenter {
 // DAGSummaryPresenter represents a DAG summary with essential information for list endpoints
 //
 // @Description Summary information for a DAG including ID, title, and validation status
-// @Example {"id": "550e8400-e29b-41d4-a716-446655440000", "title": "Employment Law Case", "is_valid": true}
+// @Example {"id": "550e8400-e29b-41d4-a716-446655440000", "title": "Employment Law Case", "is_valid": true, "validation_status": "valid"}
 type DAGSummaryPresenter struct {
-	Id      uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" description:"Unique identifier for the Legal Case DAG"`
-	Title   string    `json:"title" example:"Employment Discrimination Case" description:"Human-readable title describing the legal case context"`
-	IsValid bool      `json:"is_valid" example:"true" description:"Whether the DAG has passed validation successfully"`
+	Id               uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000" description:"Unique identifier for the Legal Case DAG"`
+	Title            string     `json:"title" example:"Employment Discrimination Case" description:"Human-readable title describing the legal case context"`
+	IsValid          bool       `json:"is_valid" example:"true" description:"Whether the DAG has passed validation successfully"`
+	ValidationStatus string     `json:"validation_status" example:"valid" enums:"valid,invalid,unknown" description:"Validation status, unknown when the DAG has no validation metadata and validity was not computed"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty" example:"2024-01-15T10:30:00Z" description:"Last time the DAG content changed, omitted when unknown"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty" example:"2024-01-16T08:00:00Z" description:"Time the DAG was moved to the trash, omitted for live DAGs"`
 }
 
+// Validation statuses of a DAG summary
+const (
+	ValidationStatusValid   = "valid"
+	ValidationStatusInvalid = "invalid"
+	ValidationStatusUnknown = "unknown"
+)
+
 // DAGSummaryListPresenter represents a list of DAG summaries for API responses
 //
 // @Description List of DAG summaries with essential information for efficient overview
@@ -111,23 +341,54 @@ type DAGSummaryListPresenter struct {
 	Count int                   `json:"count" description:"Total number of DAGs available"`
 }
 
-func NewDAGSummaryPresenter(dag *model.DAG) DAGSummaryPresenter {
-	isValid := false
-	if dag.Metadata != nil {
-		isValid = dag.Metadata.IsValid
+// DAGSummaryPagePresenter represents a page of DAG summaries for API responses
+//
+// @Description Page of DAG summaries along with the number of DAGs matching the listing
+// @Example {"dags": [{"id": "550e8400-e29b-41d4-a716-446655440000", "title": "Employment Law", "is_valid": true}], "count": 1, "total": 42, "page": 3, "page_size": 20}
+type DAGSummaryPagePresenter struct {
+	DAGs     []DAGSummaryPresenter `json:"dags" description:"Array of DAG summaries in the page"`
+	Count    int                   `json:"count" description:"Number of DAGs in the page"`
+	Total    int                   `json:"total" description:"Number of DAGs matching the listing across all pages"`
+	Page     int                   `json:"page,omitempty" description:"1-based page number, omitted when the listing is not paginated"`
+	PageSize int                   `json:"page_size,omitempty" description:"Maximum number of DAGs per page, omitted when the listing is not paginated"`
+}
+
+// NewDAGSummaryPresenter summarizes a DAG. When the DAG has no metadata its validity is
+// computed with validator, or reported as unknown when validator is nil.
+func NewDAGSummaryPresenter(dag *model.DAG, validator *usecase.DAGValidator) DAGSummaryPresenter {
+	summary := DAGSummaryPresenter{
+		Id:               dag.Id,
+		Title:            dag.Title,
+		ValidationStatus: ValidationStatusUnknown,
 	}
 
-	return DAGSummaryPresenter{
-		Id:      dag.Id,
-		Title:   dag.Title,
-		IsValid: isValid,
+	switch {
+	case dag.Metadata != nil:
+		summary.IsValid = dag.Metadata.IsValid
+		summary.ValidationStatus = validationStatus(summary.IsValid)
+	case validator != nil:
+		summary.IsValid = validator.IsValidDAG(dag)
+		summary.ValidationStatus = validationStatus(summary.IsValid)
 	}
+	if !dag.UpdatedAt.IsZero() {
+		summary.UpdatedAt = &dag.UpdatedAt
+	}
+	summary.DeletedAt = dag.DeletedAt
+
+	return summary
 }
 
-func NewDAGSummaryListPresenter(dags []*model.DAG) DAGSummaryListPresenter {
+func validationStatus(isValid bool) string {
+	if isValid {
+		return ValidationStatusValid
+	}
+	return ValidationStatusInvalid
+}
+
+func NewDAGSummaryListPresenter(dags []*model.DAG, validator *usecase.DAGValidator) DAGSummaryListPresenter {
 	summaries := make([]DAGSummaryPresenter, len(dags))
 	for i, dag := range dags {
-		summaries[i] = NewDAGSummaryPresenter(dag)
+		summaries[i] = NewDAGSummaryPresenter(dag, validator)
 	}
 
 	return DAGSummaryListPresenter{
@@ -136,6 +397,18 @@ func NewDAGSummaryListPresenter(dags []*model.DAG) DAGSummaryListPresenter {
 	}
 }
 
+func NewDAGSummaryPagePresenter(page *model.DAGPage, validator *usecase.DAGValidator) DAGSummaryPagePresenter {
+	list := NewDAGSummaryListPresenter(page.DAGs, validator)
+
+	return DAGSummaryPagePresenter{
+		DAGs:     list.DAGs,
+		Count:    list.Count,
+		Total:    page.Total,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}
+}
+
 // DAGMetadataPresenter represents DAG metadata information without content
 //
 // @Description DAG metadata including ID, title, validation status, and statistics
@@ -190,52 +463,553 @@ func NewDAGContentPresenter(dag *model.DAG) DAGContentPresenter {
 // Helper function to convert model.ValidationStatistics to ValidationStatisticsPresenter
 func convertValidationStatsToPresenter(stats model.ValidationStatistics) ValidationStatisticsPresenter {
 	return ValidationStatisticsPresenter{
-		TotalNodes:   stats.TotalNodes,
-		RootNodes:    stats.RootNodes,
-		LeafNodes:    stats.LeafNodes,
-		TotalAnswers: stats.TotalAnswers,
-		MaxDepth:     stats.MaxDepth,
-		HasCycles:    stats.HasCycles,
-		RootNodeIDs:  stats.RootNodeIDs,
-		LeafNodeIDs:  stats.LeafNodeIDs,
-		CyclePaths:   stats.CyclePaths,
+		TotalNodes:             stats.TotalNodes,
+		RootNodes:              stats.RootNodes,
+		LeafNodes:              stats.LeafNodes,
+		TotalAnswers:           stats.TotalAnswers,
+		MaxDepth:               stats.MaxDepth,
+		HasCycles:              stats.HasCycles,
+		AvgBranchingFactor:     stats.AvgBranchingFactor,
+		TotalEstimatedDamages:  stats.TotalEstimatedDamages,
+		TotalEstimatedDuration: stats.TotalEstimatedDuration,
+		BranchingHistogram:     stats.BranchingHistogram,
+		RootNodeIDs:            stats.RootNodeIDs,
+		LeafNodeIDs:            stats.LeafNodeIDs,
+		CyclePaths:             newCyclePathPresenters(stats.CyclePaths),
+		TotalPaths:             stats.TotalPaths,
+		MinPathLength:          stats.MinPathLength,
+		MaxPathLength:          stats.MaxPathLength,
+		AvgPathLength:          stats.AvgPathLength,
+		LeafPaths:              newLeafPathsPresenters(stats.LeafPaths),
+	}
+}
+
+// WalkStepPresenter represents a single step of a DAG path
+//
+// @Description Question asked at a node and the answer chosen to leave it
+type WalkStepPresenter struct {
+	NodeId   uuid.UUID       `json:"node_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655"`
+	Question string          `json:"question" example:"Were you discriminated against?"`
+	Answer   AnswerPresenter `json:"answer"`
+}
+
+// PathPresenter represents a complete path from the root node
+//
+// @Description Ordered steps from the root node to the node ending the path
+type PathPresenter struct {
+	Steps      []WalkStepPresenter `json:"steps"`
+	LeafNodeId uuid.UUID           `json:"leaf_node_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// PathListPresenter represents the paths of a DAG
+//
+// @Description The first paths of a DAG from its root node
+type PathListPresenter struct {
+	Paths []PathPresenter `json:"paths"`
+	Count int             `json:"count" example:"4"`
+	// Truncated is set when the DAG has more paths than the limit
+	Truncated bool `json:"truncated" example:"false"`
+}
+
+func NewPathListPresenter(list *usecase.PathList) PathListPresenter {
+	presenters := make([]PathPresenter, len(list.Paths))
+	for i, path := range list.Paths {
+		presenters[i] = newPathPresenter(path)
+	}
+
+	return PathListPresenter{
+		Paths:     presenters,
+		Count:     len(presenters),
+		Truncated: list.Truncated,
+	}
+}
+
+func newPathPresenter(path model.PathDetail) PathPresenter {
+	steps := make([]WalkStepPresenter, len(path.Steps))
+	for i, step := range path.Steps {
+		steps[i] = WalkStepPresenter{
+			NodeId:   step.NodeId,
+			Question: step.Question,
+			Answer:   NewAnswerPresenter(step.Answer),
+		}
+	}
+
+	return PathPresenter{Steps: steps, LeafNodeId: path.LeafNodeId}
+}
+
+// NodeReferencesPresenter represents the next_node references of a node checked against a DAG
+//
+// @Description References of a node resolving to nodes of the target DAG and dangling ones
+type NodeReferencesPresenter struct {
+	Existing []uuid.UUID `json:"existing"`
+	Dangling []uuid.UUID `json:"dangling"`
+}
+
+func NewNodeReferencesPresenter(references usecase.NodeReferences) NodeReferencesPresenter {
+	return NodeReferencesPresenter{
+		Existing: references.Existing,
+		Dangling: references.Dangling,
+	}
+}
+
+// CoveragePresenter represents which questions a walk answered and which required ones it missed
+//
+// @Description Nodes answered during a walk and required nodes reachable from them that the walk never reached
+type CoveragePresenter struct {
+	Answered       []uuid.UUID `json:"answered"`
+	MissedRequired []uuid.UUID `json:"missed_required"`
+}
+
+// WalkCompletePresenter represents a recorded walk
+//
+// @Description Complete path followed by a recorded walk along with its coverage of the required questions
+type WalkCompletePresenter struct {
+	PathPresenter
+	Coverage CoveragePresenter `json:"coverage"`
+}
+
+func NewWalkCompletePresenter(result usecase.WalkResult) WalkCompletePresenter {
+	return WalkCompletePresenter{
+		PathPresenter: newPathPresenter(result.Path),
+		Coverage: CoveragePresenter{
+			Answered:       result.Coverage.Answered,
+			MissedRequired: result.Coverage.MissedRequired,
+		},
+	}
+}
+
+// RankedPathPresenter represents a complete path and how many walks followed it
+//
+// @Description Complete path with the number of recorded walks that followed it
+type RankedPathPresenter struct {
+	Path  PathPresenter `json:"path"`
+	Count int           `json:"count" example:"12"`
+}
+
+// PathAnalyticsPresenter represents the most common complete paths of a DAG
+//
+// @Description Complete paths of a DAG ranked by the number of recorded walks, most common first
+type PathAnalyticsPresenter struct {
+	Paths []RankedPathPresenter `json:"paths"`
+	Count int                   `json:"count" example:"3"`
+}
+
+func NewPathAnalyticsPresenter(paths []usecase.RankedPath) PathAnalyticsPresenter {
+	presenters := make([]RankedPathPresenter, len(paths))
+	for i, ranked := range paths {
+		presenters[i] = RankedPathPresenter{
+			Path:  newPathPresenter(ranked.Path),
+			Count: ranked.Count,
+		}
+	}
+
+	return PathAnalyticsPresenter{
+		Paths: presenters,
+		Count: len(presenters),
+	}
+}
+
+// UpdatePreviewPresenter represents the statistics change an update would produce
+//
+// @Description DAG statistics computed on the stored DAG and on the incoming DAG of a dry-run update
+type UpdatePreviewPresenter struct {
+	Before ValidationStatisticsPresenter `json:"before"`
+	After  ValidationStatisticsPresenter `json:"after"`
+}
+
+func NewUpdatePreviewPresenter(preview usecase.UpdatePreview) UpdatePreviewPresenter {
+	return UpdatePreviewPresenter{
+		Before: newValidationStatisticsPresenter(preview.Before),
+		After:  newValidationStatisticsPresenter(preview.After),
+	}
+}
+
+// DAGVersionSummaryPresenter represents a recorded version of a DAG without its content
+//
+// @Description Version of a DAG, recorded every time the DAG is replaced
+type DAGVersionSummaryPresenter struct {
+	Number    int       `json:"number" example:"3" description:"Version number, starting at 1"`
+	Title     string    `json:"title" example:"Employment Discrimination Case" description:"Title of the DAG at this version"`
+	NodeCount int       `json:"node_count" example:"12" description:"Number of nodes of the DAG at this version"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z" description:"When the version was recorded"`
+	Author    string    `json:"author,omitempty" example:"550e8400-e29b-41d4-a716-446655440000" description:"ID of the user whose change produced the version, omitted when unknown"`
+}
+
+// DAGVersionListPresenter represents the history of a DAG
+//
+// @Description Recorded versions of a DAG, oldest first
+type DAGVersionListPresenter struct {
+	Versions []DAGVersionSummaryPresenter `json:"versions"`
+	Count    int                          `json:"count" example:"3"`
+}
+
+// DAGVersionPresenter represents a recorded version of a DAG along with its content
+//
+// @Description Snapshot of a DAG at a given version
+type DAGVersionPresenter struct {
+	DAGVersionSummaryPresenter
+	DAG DAGPresenter `json:"dag"`
+}
+
+func newDAGVersionSummaryPresenter(version model.DAGVersion) DAGVersionSummaryPresenter {
+	return DAGVersionSummaryPresenter{
+		Number:    version.Number,
+		Title:     version.DAG.Title,
+		NodeCount: len(version.DAG.Nodes),
+		CreatedAt: version.CreatedAt,
+		Author:    version.Author,
+	}
+}
+
+func NewDAGVersionListPresenter(versions []model.DAGVersion) DAGVersionListPresenter {
+	presenters := make([]DAGVersionSummaryPresenter, len(versions))
+	for i, version := range versions {
+		presenters[i] = newDAGVersionSummaryPresenter(version)
+	}
+
+	return DAGVersionListPresenter{
+		Versions: presenters,
+		Count:    len(presenters),
+	}
+}
+
+func NewDAGVersionPresenter(version model.DAGVersion) DAGVersionPresenter {
+	return DAGVersionPresenter{
+		DAGVersionSummaryPresenter: newDAGVersionSummaryPresenter(version),
+		DAG:                        NewDAGPresenter(version.DAG),
+	}
+}
+
+// MergeResultPresenter represents a DAG after another one was merged into it
+//
+// @Description Merged DAG along with the IDs given to the grafted nodes and answers
+type MergeResultPresenter struct {
+	DAG           DAGPresenter      `json:"dag"`
+	GraftedRootId uuid.UUID         `json:"grafted_root_id" example:"550e8400-e29b-41d4-a716-446655440000" description:"ID of the root node of the source DAG in the merged DAG"`
+	RemappedIds   map[string]string `json:"remapped_ids" description:"Source node and answer IDs which collided with IDs of the target, mapped to the IDs they were given"`
+}
+
+func NewMergeResultPresenter(result usecase.MergeResult) MergeResultPresenter {
+	remapped := make(map[string]string, len(result.Remapped))
+	for from, to := range result.Remapped {
+		remapped[from.String()] = to.String()
+	}
+
+	return MergeResultPresenter{
+		DAG:           NewDAGPresenter(result.DAG),
+		GraftedRootId: result.GraftedRootId,
+		RemappedIds:   remapped,
+	}
+}
+
+// DAGChangeSetPresenter represents the structural changes between two DAGs
+//
+// @Description Changes turning the before DAG into the after DAG, nodes sorted by ID
+type DAGChangeSetPresenter struct {
+	HasChanges    bool                  `json:"has_changes" example:"true"`
+	Title         *TextChangePresenter  `json:"title,omitempty" description:"Title change, omitted when unchanged"`
+	AddedNodes    []NodeRefPresenter    `json:"added_nodes"`
+	RemovedNodes  []NodeRefPresenter    `json:"removed_nodes"`
+	ModifiedNodes []NodeChangePresenter `json:"modified_nodes"`
+}
+
+// TextChangePresenter represents a text before and after a change
+type TextChangePresenter struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// NodeRefPresenter represents a node added or removed
+type NodeRefPresenter struct {
+	NodeId   uuid.UUID `json:"node_id"`
+	Question string    `json:"question"`
+}
+
+// AnswerRefPresenter represents an answer added or removed
+type AnswerRefPresenter struct {
+	AnswerId  uuid.UUID  `json:"answer_id"`
+	Statement string     `json:"answer"`
+	NextNode  *uuid.UUID `json:"next_node,omitempty"`
+}
+
+// NodeChangePresenter represents the changes of a node present in both DAGs
+type NodeChangePresenter struct {
+	NodeId          uuid.UUID               `json:"node_id"`
+	Question        *TextChangePresenter    `json:"question,omitempty" description:"Question change, omitted when unchanged"`
+	AddedAnswers    []AnswerRefPresenter    `json:"added_answers,omitempty"`
+	RemovedAnswers  []AnswerRefPresenter    `json:"removed_answers,omitempty"`
+	ModifiedAnswers []AnswerChangePresenter `json:"modified_answers,omitempty"`
+}
+
+// AnswerChangePresenter represents the changes of an answer present in both DAGs
+type AnswerChangePresenter struct {
+	AnswerId  uuid.UUID            `json:"answer_id"`
+	Statement *TextChangePresenter `json:"answer,omitempty" description:"Statement change, omitted when unchanged"`
+	NextNode  *LinkChangePresenter `json:"next_node,omitempty" description:"Next node change, omitted when the answer still leads to the same node"`
+	Disabled  *bool                `json:"disabled,omitempty" description:"New disabled flag, omitted when unchanged"`
+}
+
+// LinkChangePresenter represents the node an answer leads to before and after a change, omitted for terminal answers
+type LinkChangePresenter struct {
+	Before *uuid.UUID `json:"before,omitempty"`
+	After  *uuid.UUID `json:"after,omitempty"`
+}
+
+func NewDAGChangeSetPresenter(changes usecase.DAGChangeSet) DAGChangeSetPresenter {
+	presenter := DAGChangeSetPresenter{
+		HasChanges:    !changes.IsEmpty(),
+		Title:         newTextChangePresenter(changes.Title),
+		AddedNodes:    make([]NodeRefPresenter, len(changes.AddedNodes)),
+		RemovedNodes:  make([]NodeRefPresenter, len(changes.RemovedNodes)),
+		ModifiedNodes: make([]NodeChangePresenter, len(changes.ModifiedNodes)),
+	}
+	for i, node := range changes.AddedNodes {
+		presenter.AddedNodes[i] = NodeRefPresenter(node)
+	}
+	for i, node := range changes.RemovedNodes {
+		presenter.RemovedNodes[i] = NodeRefPresenter(node)
+	}
+	for i, node := range changes.ModifiedNodes {
+		presenter.ModifiedNodes[i] = newNodeChangePresenter(node)
+	}
+
+	return presenter
+}
+
+func newNodeChangePresenter(change usecase.NodeChange) NodeChangePresenter {
+	presenter := NodeChangePresenter{
+		NodeId:   change.NodeId,
+		Question: newTextChangePresenter(change.Question),
+	}
+	for _, answer := range change.AddedAnswers {
+		presenter.AddedAnswers = append(presenter.AddedAnswers, AnswerRefPresenter(answer))
+	}
+	for _, answer := range change.RemovedAnswers {
+		presenter.RemovedAnswers = append(presenter.RemovedAnswers, AnswerRefPresenter(answer))
+	}
+	for _, answer := range change.ModifiedAnswers {
+		answerPresenter := AnswerChangePresenter{
+			AnswerId:  answer.AnswerId,
+			Statement: newTextChangePresenter(answer.Statement),
+			Disabled:  answer.Disabled,
+		}
+		if answer.NextNode != nil {
+			answerPresenter.NextNode = &LinkChangePresenter{Before: answer.NextNode.Before, After: answer.NextNode.After}
+		}
+		presenter.ModifiedAnswers = append(presenter.ModifiedAnswers, answerPresenter)
+	}
+
+	return presenter
+}
+
+func newTextChangePresenter(change *usecase.TextChange) *TextChangePresenter {
+	if change == nil {
+		return nil
+	}
+	return &TextChangePresenter{Before: change.Before, After: change.After}
+}
+
+// newValidationStatisticsPresenter converts usecase ValidationStatistics to ValidationStatisticsPresenter
+func newValidationStatisticsPresenter(stats usecase.ValidationStatistics) ValidationStatisticsPresenter {
+	return ValidationStatisticsPresenter{
+		TotalNodes:             stats.TotalNodes,
+		RootNodes:              stats.RootNodes,
+		LeafNodes:              stats.LeafNodes,
+		TotalAnswers:           stats.TotalAnswers,
+		MaxDepth:               stats.MaxDepth,
+		HasCycles:              stats.HasCycles,
+		AvgBranchingFactor:     stats.AvgBranchingFactor,
+		TotalEstimatedDamages:  stats.TotalEstimatedDamages,
+		TotalEstimatedDuration: stats.TotalEstimatedDuration,
+		BranchingHistogram:     stats.BranchingHistogram,
+		RootNodeIDs:            stats.RootNodeIDs,
+		LeafNodeIDs:            stats.LeafNodeIDs,
+		CyclePaths:             newCyclePathPresenters(stats.CyclePaths),
+		TotalPaths:             stats.TotalPaths,
+		MinPathLength:          stats.MinPathLength,
+		MaxPathLength:          stats.MaxPathLength,
+		AvgPathLength:          stats.AvgPathLength,
+		LeafPaths:              newLeafPathsPresenters(stats.LeafPaths),
 	}
 }
 
-// presenterToDAG converts a DAGPresenter to a DAG struct
+// presenterToDAG converts a DAGPresenter to a DAG struct, normalizing the whitespace of
+// questions and statements unless the handler preserves it
 func (h *dagHandler) presenterToDAG(presenter DAGPresenter) *model.DAG {
 	nodes := make(map[uuid.UUID]model.Node)
 
 	for _, nodePresenter := range presenter.Nodes {
-		answers := make([]model.Answer, len(nodePresenter.Answers))
-
-		for i, answerPresenter := range nodePresenter.Answers {
-			answers[i] = model.Answer{
-				Id:          answerPresenter.Id,
-				Statement:   answerPresenter.Statement,
-				NextNode:    answerPresenter.NextNode,
-				UserContext: answerPresenter.UserContext,
-				Metadata:    answerPresenter.Metadata,
-			}
+		node := presenterToNode(nodePresenter)
+		nodes[node.Id] = node
+	}
+
+	d := &model.DAG{
+		Id:             presenter.Id,
+		Title:          presenter.Title,
+		Nodes:          nodes,
+		Scoring:        presenterToScoring(presenter.Scoring),
+		MetadataSchema: presenterToMetadataSchema(presenter.MetadataSchema),
+	}
+	if !h.preserveWhitespace {
+		d.NormalizeText()
+	}
+
+	return d
+}
+
+func presenterToNode(nodePresenter NodePresenter) model.Node {
+	answers := make([]model.Answer, len(nodePresenter.Answers))
+
+	for i, answerPresenter := range nodePresenter.Answers {
+		answers[i] = model.Answer{
+			Id:           answerPresenter.Id,
+			Statement:    answerPresenter.Statement,
+			NextNode:     answerPresenter.NextNode,
+			UserContext:  answerPresenter.UserContext,
+			Metadata:     answerPresenter.Metadata,
+			Translations: answerPresenter.Translations,
+			Disabled:     answerPresenter.Disabled,
+			Scores:       answerPresenter.Scores,
+		}
+		for _, branch := range answerPresenter.Conditions {
+			answers[i].Conditions = append(answers[i].Conditions, model.Branch{When: branch.When, NextNode: branch.NextNode})
 		}
+	}
 
-		node := model.Node{
-			Id:       nodePresenter.Id,
-			Question: nodePresenter.Question,
-			Answers:  answers,
+	node := model.Node{
+		Id:              nodePresenter.Id,
+		Question:        nodePresenter.Question,
+		Answers:         answers,
+		Required:        nodePresenter.Required,
+		MultiSelect:     nodePresenter.MultiSelect,
+		Translations:    nodePresenter.Translations,
+		Type:            model.NodeType(nodePresenter.Type),
+		MultiSelectNext: model.MultiSelectNext(nodePresenter.MultiSelectNext),
+	}
+	if nodePresenter.Outcome != nil {
+		node.Outcome = &model.Outcome{
+			Assessment:         nodePresenter.Outcome.Assessment,
+			RecommendedActions: nodePresenter.Outcome.RecommendedActions,
+			Statutes:           nodePresenter.Outcome.Statutes,
 		}
+	}
+	if nodePresenter.Input != nil {
+		node.Input = &model.Input{
+			Kind:     model.InputKind(nodePresenter.Input.Kind),
+			Variable: nodePresenter.Input.Variable,
+			Pattern:  nodePresenter.Input.Pattern,
+			Min:      nodePresenter.Input.Min,
+			Max:      nodePresenter.Input.Max,
+		}
+	}
 
-		// Set parent pointers for answers
-		for i := range node.Answers {
-			node.Answers[i].ParentNode = &node
+	// Set parent pointers for answers
+	for i := range node.Answers {
+		node.Answers[i].ParentNode = &node
+	}
+
+	return node
+}
+
+// SearchHitPresenter represents a node text matching a full-text search
+//
+// @Description Node text matching a search, the answer ID is set for answer statements and user contexts
+type SearchHitPresenter struct {
+	DAGId    uuid.UUID  `json:"dag_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	NodeId   uuid.UUID  `json:"node_id" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	AnswerId *uuid.UUID `json:"answer_id,omitempty" example:"6ba7b811-9dad-11d1-80b4-00c04fd430c8"`
+	Field    string     `json:"field" example:"question" enums:"question,answer,user_context"`
+	Snippet  string     `json:"snippet" example:"Were you dismissed by your employer?"`
+	Score    int        `json:"score" example:"1"`
+}
+
+// SearchResultPresenter represents the hits of a full-text search
+//
+// @Description Node texts matching a search, best matches first
+type SearchResultPresenter struct {
+	Hits  []SearchHitPresenter `json:"hits"`
+	Count int                  `json:"count" example:"1"`
+}
+
+func NewSearchResultPresenter(hits []model.SearchHit) SearchResultPresenter {
+	presenters := make([]SearchHitPresenter, len(hits))
+	for i, hit := range hits {
+		presenters[i] = SearchHitPresenter{
+			DAGId:    hit.DAGId,
+			NodeId:   hit.NodeId,
+			AnswerId: hit.AnswerId,
+			Field:    hit.Field,
+			Snippet:  hit.Snippet,
+			Score:    hit.Score,
 		}
+	}
 
-		nodes[node.Id] = node
+	return SearchResultPresenter{
+		Hits:  presenters,
+		Count: len(presenters),
 	}
+}
 
-	return &model.DAG{
-		Id:    presenter.Id,
-		Title: presenter.Title,
-		Nodes: nodes,
+// BulkValidationReportPresenter represents the validation of every stored DAG
+//
+// @Description Number of valid DAGs, invalid DAGs with their most frequent error codes and errors counted by code
+// @Example {"total": 3, "valid": 2, "invalid": [{"dag_id": "550e8400-e29b-41d4-a716-446655440000", "title": "Employment Law", "errors": 2, "warnings": 1, "top_error_codes": ["DAG_HAS_CYCLES"]}], "error_codes": [{"code": "DAG_HAS_CYCLES", "count": 2}]}
+type BulkValidationReportPresenter struct {
+	Total      int                            `json:"total" example:"3"`
+	Valid      int                            `json:"valid" example:"2"`
+	Invalid    []InvalidDAGPresenter          `json:"invalid"`
+	Failed     []FailedDAGValidationPresenter `json:"failed,omitempty"`
+	ErrorCodes []ErrorCodeCountPresenter      `json:"error_codes,omitempty"`
+}
+
+// InvalidDAGPresenter represents an invalid DAG of a bulk validation
+//
+// @Description Invalid DAG with its number of errors and warnings and its most frequent error codes
+type InvalidDAGPresenter struct {
+	DAGId         uuid.UUID `json:"dag_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title         string    `json:"title" example:"Employment Law"`
+	Errors        int       `json:"errors" example:"2"`
+	Warnings      int       `json:"warnings" example:"1"`
+	TopErrorCodes []string  `json:"top_error_codes" example:"DAG_HAS_CYCLES"`
+}
+
+// FailedDAGValidationPresenter represents a DAG a bulk validation could not validate
+//
+// @Description DAG whose validation failed, e.g. because its metadata could not be persisted
+type FailedDAGValidationPresenter struct {
+	DAGId uuid.UUID `json:"dag_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Error string    `json:"error" example:"failed to update DAG"`
+}
+
+// ErrorCodeCountPresenter represents the number of validation errors of a code
+//
+// @Description Number of errors of a code across the invalid DAGs
+type ErrorCodeCountPresenter struct {
+	Code  string `json:"code" example:"DAG_HAS_CYCLES"`
+	Count int    `json:"count" example:"2"`
+}
+
+func NewBulkValidationReportPresenter(report usecase.BulkValidationReport) BulkValidationReportPresenter {
+	presenter := BulkValidationReportPresenter{
+		Total:   report.Total,
+		Valid:   report.Valid,
+		Invalid: make([]InvalidDAGPresenter, len(report.Invalid)),
+	}
+	for i, invalid := range report.Invalid {
+		presenter.Invalid[i] = InvalidDAGPresenter{
+			DAGId:         invalid.DAGId,
+			Title:         invalid.Title,
+			Errors:        invalid.Errors,
+			Warnings:      invalid.Warnings,
+			TopErrorCodes: invalid.TopErrorCodes,
+		}
 	}
+	for _, failed := range report.Failed {
+		presenter.Failed = append(presenter.Failed, FailedDAGValidationPresenter{DAGId: failed.DAGId, Error: failed.Error})
+	}
+	for _, count := range report.ErrorCodes {
+		presenter.ErrorCodes = append(presenter.ErrorCodes, ErrorCodeCountPresenter{Code: count.Code, Count: count.Count})
+	}
+
+	return presenter
 }
@@ -0,0 +1,291 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDAGPresenter_ParentNodeId(t *testing.T) {
+	t.Parallel()
+
+	assertParentNodeIds := func(t *testing.T, presenter DAGPresenter) {
+		t.Helper()
+
+		for _, node := range presenter.Nodes {
+			for _, answer := range node.Answers {
+				require.NotNil(t, answer.ParentNodeId, "answer %s", answer.Id)
+				assert.Equal(t, node.Id, *answer.ParentNodeId)
+			}
+		}
+	}
+
+	t.Run("after presenterToDAG", func(t *testing.T) {
+		t.Parallel()
+
+		h := &dagHandler{}
+		d := h.presenterToDAG(NewDAGPresenter(dagtest.ValidSingleRoot()))
+
+		assertParentNodeIds(t, NewDAGPresenter(d))
+	})
+
+	t.Run("after UnmarshalJSON", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(dagtest.ValidSingleRoot())
+		require.NoError(t, err)
+
+		var d model.DAG
+		require.NoError(t, json.Unmarshal(data, &d))
+
+		assertParentNodeIds(t, NewDAGPresenter(&d))
+	})
+
+	t.Run("parent node id is serialized", func(t *testing.T) {
+		t.Parallel()
+
+		h := &dagHandler{}
+		d := h.presenterToDAG(NewDAGPresenter(dagtest.ValidSingleRoot()))
+		root := dagtest.Root(d)
+
+		data, err := json.Marshal(NewNodePresenter(root))
+		require.NoError(t, err)
+
+		var raw struct {
+			Answers []map[string]any `json:"answers"`
+		}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		require.NotEmpty(t, raw.Answers)
+		for _, answer := range raw.Answers {
+			assert.Equal(t, root.Id.String(), answer["parent_node_id"])
+		}
+	})
+
+	t.Run("no parent node", func(t *testing.T) {
+		t.Parallel()
+
+		presenter := NewAnswerPresenter(model.Answer{Id: uuid.New(), Statement: "orphan"})
+		assert.Nil(t, presenter.ParentNodeId)
+
+		data, err := json.Marshal(presenter)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "parent_node_id")
+	})
+}
+
+func TestNewAnswerPresenter_Conditions(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	root := dagtest.Root(d)
+	target := *root.Answers[1].NextNode
+	root.Answers[0].Conditions = []model.Branch{{When: `"urgent" in tags`, NextNode: target}}
+	d.Nodes[root.Id] = root
+
+	presenter := NewAnswerPresenter(root.Answers[0])
+	require.Len(t, presenter.Conditions, 1)
+	assert.Equal(t, BranchPresenter{When: `"urgent" in tags`, NextNode: target}, presenter.Conditions[0])
+
+	h := &dagHandler{}
+	roundTrip := h.presenterToDAG(NewDAGPresenter(d))
+	assert.Equal(t, root.Answers[0].Conditions, roundTrip.Nodes[root.Id].Answers[0].Conditions)
+}
+
+func TestNewNodePresenter_NodeTypes(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	var leaf model.Node
+	for _, node := range d.Nodes {
+		if len(node.Answers) == 0 {
+			leaf = node
+		}
+	}
+	leaf.Type = model.NodeTypeOutcome
+	leaf.Outcome = &model.Outcome{Assessment: "Likely unfair dismissal", RecommendedActions: []string{"File a claim"}}
+	d.Nodes[leaf.Id] = leaf
+
+	presenter := NewNodePresenter(leaf)
+	assert.Equal(t, "outcome", presenter.Type)
+	require.NotNil(t, presenter.Outcome)
+	assert.Equal(t, "Likely unfair dismissal", presenter.Outcome.Assessment)
+
+	h := &dagHandler{}
+	roundTrip := h.presenterToDAG(NewDAGPresenter(d))
+	assert.Equal(t, model.NodeTypeOutcome, roundTrip.Nodes[leaf.Id].Type)
+	assert.Equal(t, leaf.Outcome, roundTrip.Nodes[leaf.Id].Outcome)
+	assert.Empty(t, roundTrip.Nodes[dagtest.Root(d).Id].Type)
+}
+
+func TestNewNodePresenter_MultiSelectNext(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	root := dagtest.Root(d)
+	root.MultiSelect = true
+	root.MultiSelectNext = model.MultiSelectNextConvergence
+	d.Nodes[root.Id] = root
+
+	assert.Equal(t, "convergence", NewNodePresenter(root).MultiSelectNext)
+
+	h := &dagHandler{}
+	roundTrip := h.presenterToDAG(NewDAGPresenter(d))
+	assert.True(t, roundTrip.Nodes[root.Id].MultiSelect)
+	assert.Equal(t, model.MultiSelectNextConvergence, roundTrip.Nodes[root.Id].MultiSelectNext)
+}
+
+func TestNewNodePresenter_Input(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	root := dagtest.Root(d)
+	root.Type = model.NodeTypeInput
+	root.Input = &model.Input{Kind: model.InputKindNumber, Variable: "salary", Min: "0"}
+	d.Nodes[root.Id] = root
+
+	presenter := NewNodePresenter(root)
+	assert.Equal(t, "input", presenter.Type)
+	require.NotNil(t, presenter.Input)
+	assert.Equal(t, "salary", presenter.Input.Variable)
+
+	h := &dagHandler{}
+	roundTrip := h.presenterToDAG(NewDAGPresenter(d))
+	assert.Equal(t, root.Input, roundTrip.Nodes[root.Id].Input)
+}
+
+func TestNewDAGPresenter_Scoring(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	d.Scoring = &model.Scoring{Dimensions: []model.ScoreDimension{
+		{Name: "claim_strength", Label: "Claim strength", Weight: 2, Bands: []model.ScoreBand{{Min: 5, Label: "strong"}}},
+	}}
+	root := dagtest.Root(d)
+	root.Answers[0].Scores = map[string]float64{"claim_strength": 3}
+	d.Nodes[root.Id] = root
+
+	presenter := NewDAGPresenter(d)
+	require.NotNil(t, presenter.Scoring)
+	assert.Equal(t, "claim_strength", presenter.Scoring.Dimensions[0].Name)
+
+	h := &dagHandler{}
+	roundTrip := h.presenterToDAG(presenter)
+	assert.Equal(t, d.Scoring, roundTrip.Scoring)
+	assert.Equal(t, map[string]float64{"claim_strength": 3}, roundTrip.Nodes[root.Id].Answers[0].Scores)
+}
+
+func TestNewDAGPresenter_MetadataSchema(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	d.MetadataSchema = &model.MetadataSchema{Fields: []model.MetadataField{
+		{Name: "severity", Type: model.MetadataFieldString, Enum: []string{"low", "high"}, Required: true, Description: "Severity"},
+	}}
+
+	presenter := NewDAGPresenter(d)
+	require.NotNil(t, presenter.MetadataSchema)
+	assert.Equal(t, "string", presenter.MetadataSchema.Fields[0].Type)
+
+	h := &dagHandler{}
+	roundTrip := h.presenterToDAG(presenter)
+	assert.Equal(t, d.MetadataSchema, roundTrip.MetadataSchema)
+}
+
+func TestNewDAGSummaryPresenter_ValidationStatus(t *testing.T) {
+	t.Parallel()
+
+	withMetadata := func(d *model.DAG, isValid bool) *model.DAG {
+		d.Metadata = &model.DAGMetadata{IsValid: isValid}
+		return d
+	}
+
+	tests := []struct {
+		name           string
+		dag            *model.DAG
+		validator      *usecase.DAGValidator
+		expectedValid  bool
+		expectedStatus string
+	}{
+		{
+			name:           "valid metadata",
+			dag:            withMetadata(dagtest.ValidSingleRoot(), true),
+			expectedValid:  true,
+			expectedStatus: ValidationStatusValid,
+		},
+		{
+			name:           "invalid metadata",
+			dag:            withMetadata(dagtest.ValidSingleRoot(), false),
+			validator:      usecase.NewDAGValidator(),
+			expectedValid:  false,
+			expectedStatus: ValidationStatusInvalid,
+		},
+		{
+			name:           "missing metadata",
+			dag:            dagtest.ValidSingleRoot(),
+			expectedValid:  false,
+			expectedStatus: ValidationStatusUnknown,
+		},
+		{
+			name:           "missing metadata computed as valid",
+			dag:            dagtest.ValidSingleRoot(),
+			validator:      usecase.NewDAGValidator(),
+			expectedValid:  true,
+			expectedStatus: ValidationStatusValid,
+		},
+		{
+			name:           "missing metadata computed as invalid",
+			dag:            dagtest.Cyclic(),
+			validator:      usecase.NewDAGValidator(),
+			expectedValid:  false,
+			expectedStatus: ValidationStatusInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			summary := NewDAGSummaryPresenter(tt.dag, tt.validator)
+			assert.Equal(t, tt.expectedValid, summary.IsValid)
+			assert.Equal(t, tt.expectedStatus, summary.ValidationStatus)
+		})
+	}
+}
+
+func TestPresenterToDAG_NormalizesWhitespace(t *testing.T) {
+	t.Parallel()
+
+	source := dagtest.ValidSingleRoot()
+	rootId := dagtest.Root(source).Id
+	presenter := NewDAGPresenter(source)
+	for i, node := range presenter.Nodes {
+		if node.Id == rootId {
+			presenter.Nodes[i].Question = "  Were   you dismissed? "
+			presenter.Nodes[i].Answers[0].Statement = "  Yes   sir  "
+		}
+	}
+
+	t.Run("normalized by default", func(t *testing.T) {
+		t.Parallel()
+
+		d := (&dagHandler{}).presenterToDAG(presenter)
+		root := d.Nodes[rootId]
+		assert.Equal(t, "Were you dismissed?", root.Question)
+		assert.Equal(t, "Yes sir", root.Answers[0].Statement)
+	})
+
+	t.Run("preserved when configured", func(t *testing.T) {
+		t.Parallel()
+
+		d := (&dagHandler{preserveWhitespace: true}).presenterToDAG(presenter)
+		root := d.Nodes[rootId]
+		assert.Equal(t, "  Were   you dismissed? ", root.Question)
+		assert.Equal(t, "  Yes   sir  ", root.Answers[0].Statement)
+	})
+}
@@ -3,11 +3,16 @@ package http
 import (
 	"bytes"
 	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,7 +94,7 @@ func TestDAGHandler_ValidateDAG(t *testing.T) {
 			rr := httptest.NewRecorder()
 
 			// Create handler
-			handler := NewDAGHandler(nil) // No app needed for validation
+			handler := NewDAGHandler(newValidatingApp(t))
 
 			// Execute request
 			handler.ValidateDAG(rr, req)
@@ -141,7 +146,7 @@ func TestDAGHandler_ValidateDAG_Integration(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 
 	rr := httptest.NewRecorder()
-	handler := NewDAGHandler(nil)
+	handler := NewDAGHandler(newValidatingApp(t))
 
 	handler.ValidateDAG(rr, req)
 
@@ -157,6 +162,138 @@ func TestDAGHandler_ValidateDAG_Integration(t *testing.T) {
 	assert.GreaterOrEqual(t, response.Statistics.MaxDepth, 0)
 }
 
+func TestDAGHandler_ValidateDAG_Rules(t *testing.T) {
+	t.Parallel()
+
+	validate := func(rules map[string]string) *httptest.ResponseRecorder {
+		request := createMultipleRootDAGRequest()
+		request.Rules = rules
+		requestBody, err := json.Marshal(request)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		NewDAGHandler(newValidatingApp(t)).ValidateDAG(rr, httptest.NewRequest(http.MethodPost, "/v1/dags/validate", bytes.NewBuffer(requestBody)))
+		return rr
+	}
+
+	t.Run("downgrades errors to warnings", func(t *testing.T) {
+		t.Parallel()
+
+		rr := validate(map[string]string{"DAG_MULTIPLE_ROOTS": "warning"})
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response ValidationResultPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.True(t, response.IsValid)
+		assert.Empty(t, response.Errors)
+		require.NotEmpty(t, response.Warnings)
+		assert.Equal(t, "DAG_MULTIPLE_ROOTS", response.Warnings[0].Code)
+	})
+
+	t.Run("rejects unknown rules", func(t *testing.T) {
+		t.Parallel()
+
+		rr := validate(map[string]string{"DAG_TOO_COLORFUL": "off"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "invalid validation rules")
+	})
+}
+
+func TestDAGHandler_ValidateAllDAGs(t *testing.T) {
+	t.Parallel()
+
+	invalidId := uuid.New()
+	report := &usecase.BulkValidationReport{
+		Total: 3,
+		Valid: 2,
+		Invalid: []usecase.InvalidDAGReport{
+			{DAGId: invalidId, Title: "Cyclic", Errors: 2, Warnings: 1, TopErrorCodes: []string{"DAG_HAS_CYCLES"}},
+		},
+		ErrorCodes: []usecase.ErrorCodeCount{{Code: "DAG_HAS_CYCLES", Count: 2}},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+	}{
+		{
+			name:  "reports the invalid DAGs",
+			query: "?workers=4",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ValidateAllDAGs(gomock.Any(), usecase.CmdValidateAllDAGs{Workers: 4}).Return(report, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects a non numeric workers parameter",
+			query:          "?workers=many",
+			setupMock:      func(*mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "rejects too many workers",
+			query: "?workers=1000",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ValidateAllDAGs(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "reports repository failures",
+			query: "",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ValidateAllDAGs(gomock.Any(), usecase.CmdValidateAllDAGs{}).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/dags/validate-all"+tt.query, nil))
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response BulkValidationReportPresenter
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, 3, response.Total)
+			assert.Equal(t, 2, response.Valid)
+			require.Len(t, response.Invalid, 1)
+			assert.Equal(t, invalidId, response.Invalid[0].DAGId)
+			assert.Equal(t, []string{"DAG_HAS_CYCLES"}, response.Invalid[0].TopErrorCodes)
+			assert.Equal(t, []ErrorCodeCountPresenter{{Code: "DAG_HAS_CYCLES", Count: 2}}, response.ErrorCodes)
+		})
+	}
+}
+
+// newValidatingApp returns an app mock validating DAGs with the default validator
+func newValidatingApp(t *testing.T) *mocks.MockApp {
+	ctrl := gomock.NewController(t)
+	mockApp := mocks.NewMockApp(ctrl)
+	mockApp.EXPECT().ValidateDAG(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, d *model.DAG, rules map[string]usecase.Severity) (usecase.ValidationResult, error) {
+			profile := usecase.DefaultValidationProfile().WithRules(rules)
+			if err := profile.Check(); err != nil {
+				return usecase.ValidationResult{}, fmt.Errorf("%w: %s", usecase.ErrInvalidCommand, err)
+			}
+			return usecase.NewDAGValidatorFromProfile(profile).ValidateDAG(d), nil
+		},
+	).AnyTimes()
+
+	return mockApp
+}
+
 // Helper functions for creating test DAGs
 
 func createValidDAGRequest() ValidateRequest {
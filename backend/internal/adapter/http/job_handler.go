@@ -0,0 +1,97 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// JobPresenter represents a background job
+//
+// @Description Long-running task run in the background, such as the validation of a large DAG, with its result once done
+type JobPresenter struct {
+	Id         uuid.UUID   `json:"id" example:"b7c8d9e0-f1a2-4b3c-8d4e-5f6071829304" description:"Job unique identifier"`
+	Type       string      `json:"type" example:"dag.validate" enums:"dag.validate" description:"Job type"`
+	Status     string      `json:"status" example:"succeeded" enums:"pending,running,succeeded,failed" description:"Progress of the job"`
+	DAGId      uuid.UUID   `json:"dag_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"DAG the job works on"`
+	Result     interface{} `json:"result,omitempty" swaggertype:"object" description:"Result of the succeeded job, a ValidationResultPresenter for dag.validate jobs"`
+	Error      string      `json:"error,omitempty" example:"failed to retrieve DAG for validation" description:"Error of the failed job"`
+	CreatedAt  time.Time   `json:"created_at" example:"2024-01-15T10:30:00Z" description:"When the job was enqueued"`
+	StartedAt  *time.Time  `json:"started_at,omitempty" example:"2024-01-15T10:30:01Z" description:"When a worker started the job"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty" example:"2024-01-15T10:30:05Z" description:"When the job succeeded or failed"`
+}
+
+func NewJobPresenter(job *model.Job) JobPresenter {
+	presenter := JobPresenter{
+		Id:         job.Id,
+		Type:       job.Type,
+		Status:     string(job.Status),
+		DAGId:      job.DAGId,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+
+	switch result := job.Result.(type) {
+	case nil:
+	case *usecase.ValidationResult:
+		presenter.Result = newValidationResultPresenter(*result)
+	default:
+		presenter.Result = result
+	}
+
+	return presenter
+}
+
+type jobHandler struct {
+	app App
+}
+
+func NewJobHandler(app App) *jobHandler {
+	return &jobHandler{
+		app: app,
+	}
+}
+
+// Get returns a background job
+//
+// @Summary Get job
+// @Description Get the status of a background job, along with its result once it succeeded or its error once it failed. Jobs are kept in memory, they are lost on restart.
+// @Tags Jobs
+// @Produce json
+// @Param jobId path string true "Job unique identifier (UUID)"
+// @Success 200 {object} JobPresenter "Job"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid job ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Job not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /jobs/{jobId} [get]
+func (h *jobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	job, err := h.app.GetJob(ctx, usecase.CmdGetJob{
+		JobId: mux.Vars(r)[jobId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get job")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid job ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "job not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get job", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewJobPresenter(job))
+}
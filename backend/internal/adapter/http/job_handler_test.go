@@ -0,0 +1,98 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobHandler(t *testing.T) {
+	dagId := uuid.New()
+	pending := model.NewJob(model.JobTypeValidateDAG, dagId, time.Now())
+
+	serve := func(mockApp *mocks.MockApp, method, target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+		return rr
+	}
+
+	t.Run("enqueues the validation of a DAG", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().EnqueueDAGValidation(gomock.Any(), usecase.CmdValidateStoredDAG{DAGId: dagId.String()}).Return(&pending, nil)
+
+		rr := serve(mockApp, http.MethodPost, "/v1/dags/"+dagId.String()+"/validate?async=true")
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Equal(t, "/v1/jobs/"+pending.Id.String(), rr.Header().Get("Location"))
+
+		var response JobPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, pending.Id, response.Id)
+		assert.Equal(t, string(model.JobStatusPending), response.Status)
+	})
+
+	t.Run("reports an unavailable job queue", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().EnqueueDAGValidation(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrUnavailable)
+
+		rr := serve(mockApp, http.MethodPost, "/v1/dags/"+dagId.String()+"/validate?async=true")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+
+	t.Run("rejects an invalid async parameter", func(t *testing.T) {
+		rr := serve(mocks.NewMockApp(gomock.NewController(t)), http.MethodPost, "/v1/dags/"+dagId.String()+"/validate?async=soon")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("returns the validation result of a succeeded job", func(t *testing.T) {
+		succeeded := pending
+		succeeded.Start(time.Now())
+		succeeded.Finish(&usecase.ValidationResult{
+			IsValid:  false,
+			Errors:   []usecase.ValidationError{{Code: "DAG_HAS_CYCLES", Message: "DAG contains 1 cycle(s)", Severity: "error"}},
+			Warnings: []usecase.ValidationWarning{},
+		}, nil, time.Now())
+
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().GetJob(gomock.Any(), usecase.CmdGetJob{JobId: pending.Id.String()}).Return(&succeeded, nil)
+
+		rr := serve(mockApp, http.MethodGet, "/v1/jobs/"+pending.Id.String())
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			JobPresenter
+			Result ValidationResultPresenter `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, string(model.JobStatusSucceeded), response.Status)
+		assert.NotNil(t, response.FinishedAt)
+		assert.False(t, response.Result.IsValid)
+		require.Len(t, response.Result.Errors, 1)
+		assert.Equal(t, "DAG_HAS_CYCLES", response.Result.Errors[0].Code)
+	})
+
+	t.Run("returns not found for unknown job", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().GetJob(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+
+		rr := serve(mockApp, http.MethodGet, "/v1/jobs/"+uuid.NewString())
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("rejects invalid job identifiers", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().GetJob(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+
+		rr := serve(mockApp, http.MethodGet, "/v1/jobs/invalid")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
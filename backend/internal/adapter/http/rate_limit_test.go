@@ -0,0 +1,96 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_RateLimit(t *testing.T) {
+	mockApp := mocks.NewMockApp(gomock.NewController(t))
+	mockApp.EXPECT().ListWebhooks(gomock.Any()).Return([]model.Webhook{}, nil).AnyTimes()
+
+	counter := xhttp.NewThrottleCounter()
+	router := New(mockApp, nil, Config{
+		// A single request per hour and client, the bucket never refills during the test
+		RateLimits:      map[string]xhttp.RateLimit{RouteGroupWebhooks: {Rate: 1.0 / 3600, Burst: 2}},
+		ThrottleCounter: counter,
+	})
+
+	list := func(remoteAddr, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+		req.RemoteAddr = remoteAddr
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// The burst is served, then the client is throttled
+	assert.Equal(t, http.StatusOK, list("10.0.0.1:1234", "").Code)
+	assert.Equal(t, http.StatusOK, list("10.0.0.1:5678", "").Code)
+	throttled := list("10.0.0.1:1234", "")
+	require.Equal(t, http.StatusTooManyRequests, throttled.Code)
+	retryAfter, err := strconv.Atoi(throttled.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, 3600, retryAfter, 1)
+
+	// Other IPs have their own bucket, unauthenticated credentials do not escape the bucket of the IP
+	assert.Equal(t, http.StatusOK, list("10.0.0.2:1234", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, list("10.0.0.1:1234", "random-key").Code)
+
+	// Route groups without limit are not throttled
+	mockApp.EXPECT().ListDAGs(gomock.Any(), gomock.Any()).Return(&model.DAGPage{DAGs: []*model.DAG{}}, nil).Times(3)
+	for range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/v1/dags", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+
+	assert.Equal(t, uint64(2), counter.Count(RouteGroupWebhooks))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `jurigen_http_throttled_requests_total{group="webhooks"} 2`)
+}
+
+func TestRouter_RateLimit_AuthenticatedUsers(t *testing.T) {
+	mockApp := mocks.NewMockApp(gomock.NewController(t))
+	mockApp.EXPECT().ListWebhooks(gomock.Any()).Return([]model.Webhook{}, nil).AnyTimes()
+
+	router := New(mockApp, xhttp.GrantAnyFn(), Config{
+		RateLimits: map[string]xhttp.RateLimit{RouteGroupWebhooks: {Rate: 1.0 / 3600, Burst: 1}},
+	})
+
+	list := func(remoteAddr, username string) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+		req.RemoteAddr = remoteAddr
+		req.SetBasicAuth(username, "secret")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// Users are throttled whatever the IP they send their requests from
+	assert.Equal(t, http.StatusOK, list("10.0.0.1:1234", "alice"))
+	assert.Equal(t, http.StatusTooManyRequests, list("10.0.0.2:1234", "alice"))
+	assert.Equal(t, http.StatusOK, list("10.0.0.1:1234", "bob"))
+
+	// Requests failing the authentication are rejected before reaching the buckets
+	req := httptest.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
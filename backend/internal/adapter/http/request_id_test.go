@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	pkg "davidterranova/jurigen/backend/internal"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestID_PropagatesAcrossLayers drives updates through the router, the use cases and the hybrid
+// repository and checks every layer logs the request ID
+func TestRequestID_PropagatesAcrossLayers(t *testing.T) {
+	var logs bytes.Buffer
+	logger := zerolog.New(&logs)
+
+	// Handlers and use cases log through the global logger
+	globalLogger := log.Logger
+	log.Logger = logger
+	t.Cleanup(func() { log.Logger = globalLogger })
+
+	repo := port.NewHybridDAGRepository(port.HybridDAGRepositoryConfig{
+		FilePath: t.TempDir(),
+		Logger:   &logger,
+	})
+	stored := dagtest.ValidSingleRoot()
+	require.NoError(t, repo.Create(context.Background(), stored))
+
+	app := pkg.New(repo, repo, port.NewFileWalkAnalyticsRepository(t.TempDir()), port.NewFileDAGVersionRepository(t.TempDir()), port.NewFileSessionRepository(t.TempDir()), nil, port.NewFileAttachmentStorage(t.TempDir()), usecase.DefaultAttachmentPolicy(), port.NewFileWebhookRepository(t.TempDir()), port.NewInMemoryWebhookDeliveryRepository(0), port.NewFileTemplateRepository(t.TempDir()), nil, nil, nil, usecase.DefaultValidationProfile())
+	router := New(app, nil, Config{})
+
+	put := func(requestID string, dagID uuid.UUID) *httptest.ResponseRecorder {
+		body, err := json.Marshal(NewDAGPresenter(stored))
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/dags/"+dagID.String(), bytes.NewReader(body))
+		if requestID != "" {
+			req.Header.Set(xhttp.RequestIDHeader, requestID)
+		}
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	requestIDsByMessage := func() map[string][]string {
+		byMessage := make(map[string][]string)
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+
+			requestID, _ := entry["request_id"].(string)
+			message, _ := entry["message"].(string)
+			byMessage[message] = append(byMessage[message], requestID)
+		}
+		logs.Reset()
+
+		return byMessage
+	}
+
+	t.Run("use case and repository log the client request ID", func(t *testing.T) {
+		rr := put("req-update", stored.Id)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "req-update", rr.Header().Get(xhttp.RequestIDHeader))
+
+		byMessage := requestIDsByMessage()
+		assert.Equal(t, []string{"req-update"}, byMessage["DAG updated in memory (write-through disabled)"])
+		assert.Equal(t, []string{"req-update"}, byMessage["DAG updated"])
+	})
+
+	t.Run("handler logs the request ID of a failed request", func(t *testing.T) {
+		rr := put("req-unknown", uuid.New())
+		require.Equal(t, http.StatusNotFound, rr.Code)
+
+		byMessage := requestIDsByMessage()
+		assert.Equal(t, []string{"req-unknown"}, byMessage["failed to update DAG"])
+	})
+
+	t.Run("generates a request ID when the client sends none", func(t *testing.T) {
+		rr := put("", stored.Id)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		requestID := rr.Header().Get(xhttp.RequestIDHeader)
+		require.NotEmpty(t, requestID)
+
+		byMessage := requestIDsByMessage()
+		assert.Equal(t, []string{requestID}, byMessage["DAG updated"])
+	})
+}
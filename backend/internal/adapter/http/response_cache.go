@@ -0,0 +1,97 @@
+package http
+
+import (
+	"container/list"
+	"davidterranova/jurigen/backend/internal/model"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ResponseCache keeps the marshaled DAG read responses in memory, keyed by DAG ID.
+// Once full the least recently used response is evicted. Entries are invalidated
+// by the repository changes, see OnChange.
+type ResponseCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[uuid.UUID]*list.Element
+	lru     *list.List
+	// epoch is incremented on every invalidation, so that responses computed
+	// from a DAG read before an invalidation are not stored
+	epoch uint64
+}
+
+// cachedResponse is a marshaled DAG read response along with its entity tag
+type cachedResponse struct {
+	body []byte
+	etag string
+}
+
+type cacheEntry struct {
+	dagId    uuid.UUID
+	response cachedResponse
+}
+
+// NewResponseCache creates a response cache holding at most size responses
+func NewResponseCache(size int) *ResponseCache {
+	return &ResponseCache{
+		size:    size,
+		entries: make(map[uuid.UUID]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// OnChange invalidates the cached response of a changed DAG. It is meant to be registered as a repository
+// observer, which unlike a watcher never misses a change
+func (c *ResponseCache) OnChange(event model.ChangeEvent) {
+	c.Invalidate(event.DAGId)
+}
+
+// Invalidate drops the cached response of a DAG
+func (c *ResponseCache) Invalidate(dagId uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	if element, ok := c.entries[dagId]; ok {
+		c.lru.Remove(element)
+		delete(c.entries, dagId)
+	}
+}
+
+// get returns the cached response of a DAG, along with the epoch to store a fresh response with on a miss
+func (c *ResponseCache) get(dagId uuid.UUID) (cachedResponse, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[dagId]
+	if !ok {
+		return cachedResponse{}, c.epoch, false
+	}
+	c.lru.MoveToFront(element)
+
+	return element.Value.(*cacheEntry).response, c.epoch, true
+}
+
+// put stores the response of a DAG unless an invalidation happened since epoch was read
+func (c *ResponseCache) put(dagId uuid.UUID, epoch uint64, response cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if epoch != c.epoch || c.size <= 0 {
+		return
+	}
+
+	if element, ok := c.entries[dagId]; ok {
+		element.Value.(*cacheEntry).response = response
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[dagId] = c.lru.PushFront(&cacheEntry{dagId: dagId, response: response})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).dagId)
+	}
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("evicts the least recently used response", func(t *testing.T) {
+		t.Parallel()
+
+		cache := NewResponseCache(2)
+		first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+		_, epoch, _ := cache.get(first)
+		cache.put(first, epoch, cachedResponse{body: []byte("first")})
+		cache.put(second, epoch, cachedResponse{body: []byte("second")})
+		_, _, hit := cache.get(first)
+		require.True(t, hit)
+		cache.put(third, epoch, cachedResponse{body: []byte("third")})
+
+		_, _, hit = cache.get(second)
+		assert.False(t, hit)
+		cached, _, hit := cache.get(first)
+		assert.True(t, hit)
+		assert.Equal(t, "first", string(cached.body))
+		_, _, hit = cache.get(third)
+		assert.True(t, hit)
+	})
+
+	t.Run("does not store responses read before an invalidation", func(t *testing.T) {
+		t.Parallel()
+
+		cache := NewResponseCache(2)
+		id := uuid.New()
+
+		_, epoch, _ := cache.get(id)
+		cache.Invalidate(id)
+		cache.put(id, epoch, cachedResponse{body: []byte("stale")})
+
+		_, _, hit := cache.get(id)
+		assert.False(t, hit)
+	})
+
+	t.Run("observing the repository misses no change when watchers overflow", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		repo := port.NewInMemoryDAGRepository()
+		// A watcher never read from fills its buffer and then misses the changes
+		_, err := repo.Watch(ctx)
+		require.NoError(t, err)
+
+		const dagCount = 200
+		cache := NewResponseCache(dagCount)
+		stop := repo.Observe(cache.OnChange)
+		defer stop()
+
+		ids := make([]uuid.UUID, 0, dagCount)
+		for i := 0; i < dagCount; i++ {
+			dag := dagtest.ValidSingleRoot()
+			dag.Id = uuid.New()
+			require.NoError(t, repo.Create(ctx, dag))
+			ids = append(ids, dag.Id)
+		}
+		for _, id := range ids {
+			_, epoch, _ := cache.get(id)
+			cache.put(id, epoch, cachedResponse{body: []byte("stale")})
+		}
+
+		for _, id := range ids {
+			require.NoError(t, repo.Update(ctx, id, func(dag model.DAG) (model.DAG, error) {
+				dag.Title = "updated"
+				return dag, nil
+			}))
+		}
+
+		for _, id := range ids {
+			_, _, hit := cache.get(id)
+			assert.False(t, hit, "response of DAG %s should have been invalidated", id)
+		}
+	})
+}
+
+func TestDAGHandler_Get_ResponseCache(t *testing.T) {
+	t.Parallel()
+
+	testDAG := dagtest.ValidSingleRoot()
+	testDAG.Metadata = &model.DAGMetadata{IsValid: true}
+	testDAG.Revision = 5
+
+	get := func(t *testing.T, router http.Handler, id string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/dags/"+id, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		var response DAGMetadataPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, testDAG.Id, response.Id)
+
+		return rr
+	}
+
+	t.Run("second read is served from cache", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+		mockApp.EXPECT().Get(gomock.Any(), usecase.CmdGetDAG{DAGId: testDAG.Id.String()}).Return(testDAG, nil).Times(1)
+
+		router := New(mockApp, nil, Config{ResponseCache: NewResponseCache(10)})
+
+		first := get(t, router, testDAG.Id.String())
+		second := get(t, router, testDAG.Id.String())
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, `"5"`, first.Header().Get("ETag"))
+		assert.Equal(t, `"5"`, second.Header().Get("ETag"))
+	})
+
+	t.Run("change events invalidate the cached response", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+		mockApp.EXPECT().Get(gomock.Any(), usecase.CmdGetDAG{DAGId: testDAG.Id.String()}).Return(testDAG, nil).Times(2)
+
+		cache := NewResponseCache(10)
+		router := New(mockApp, nil, Config{ResponseCache: cache})
+
+		get(t, router, testDAG.Id.String())
+		cache.OnChange(model.ChangeEvent{Type: model.ChangeTypeUpdated, DAGId: testDAG.Id})
+		get(t, router, testDAG.Id.String())
+	})
+
+	t.Run("malformed IDs are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+		mockApp.EXPECT().Get(gomock.Any(), usecase.CmdGetDAG{DAGId: "not-a-uuid"}).Return(nil, usecase.ErrInvalidCommand).Times(2)
+
+		router := New(mockApp, nil, Config{ResponseCache: NewResponseCache(10)})
+		for i := 0; i < 2; i++ {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/dags/not-a-uuid", nil))
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), "invalid DAG ID format")
+		}
+	})
+}
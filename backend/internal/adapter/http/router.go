@@ -1,8 +1,11 @@
 package http
 
 import (
+	"davidterranova/jurigen/backend/pkg/buildinfo"
+	"davidterranova/jurigen/backend/pkg/user"
 	"davidterranova/jurigen/backend/pkg/xhttp"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -11,30 +14,267 @@ import (
 	_ "davidterranova/jurigen/backend/docs/swagger"
 )
 
-const dagId = "dagId"
+// walkRetryAfter is suggested to clients throttled by the concurrent walks limit
+const walkRetryAfter = time.Second
 
-func New(app App, authFn xhttp.AuthFn) *mux.Router {
+const (
+	dagId         = "dagId"
+	answerId      = "answerId"
+	nodeId        = "nodeId"
+	sessionId     = "sessionId"
+	attachmentId  = "attachmentId"
+	webhookId     = "webhookId"
+	templateId    = "templateId"
+	jobId         = "jobId"
+	versionNumber = "version"
+)
+
+// Route groups sharing a rate limit
+const (
+	RouteGroupDAGs     = "dags"
+	RouteGroupSessions = "sessions"
+	RouteGroupWebhooks = "webhooks"
+)
+
+// Config holds the options of the API router
+type Config struct {
+	// MaxConcurrentWalks limits the walks computed at the same time on a DAG, unlimited when 0
+	MaxConcurrentWalks int
+	// PreserveWhitespace stores submitted questions and statements as is instead of normalizing their whitespace
+	PreserveWhitespace bool
+	// ResponseCache serves DAG metadata reads from memory, disabled when nil
+	ResponseCache *ResponseCache
+	// Events feeds the DAG event streams, which are unavailable when nil
+	Events EventSubscriber
+	// RateLimits throttles the clients of each route group, groups without limit are not throttled
+	RateLimits map[string]xhttp.RateLimit
+	// ThrottleCounter counts the throttled requests and serves them on /metrics when set
+	ThrottleCounter *xhttp.ThrottleCounter
+	// MaxAttachmentSize limits the files uploaded as session attachments, in bytes, larger files get 413, unlimited
+	// when 0
+	MaxAttachmentSize int64
+	// Sync persists the DAGs to files on demand and serves its lag on /metrics, the sync is unavailable when nil
+	Sync DAGSyncer
+}
+
+// New creates the API router
+func New(app App, authFn xhttp.AuthFn, config Config) *mux.Router {
 	root := mux.NewRouter()
-	mountV1DAG(root, authFn, app)
+	root.Use(xhttp.RequestIDMiddleware())
+	root.Use(xhttp.AccessLogMiddleware(accessLogFields))
+	mountV1DAG(root, authFn, app, config)
+	mountV1Session(root, authFn, app, config)
+	mountV1Template(root, authFn, app, config)
+	mountV1Webhook(root, authFn, app, config)
+	mountV1Job(root, authFn, app, config)
+	mountV1Admin(root, authFn, config)
+	mountV1Version(root)
+	mountMetrics(root, config)
 	mountSwaggerUI(root)
 
 	return root
 }
 
-func mountV1DAG(router *mux.Router, authFn xhttp.AuthFn, app App) {
+func mountV1DAG(router *mux.Router, authFn xhttp.AuthFn, app App, config Config) {
 	dagHandler := NewDAGHandler(app)
+	dagHandler.preserveWhitespace = config.PreserveWhitespace
+	dagHandler.cache = config.ResponseCache
+	dagHandler.events = config.Events
+	walkLimit := xhttp.ConcurrencyLimitMiddleware(config.MaxConcurrentWalks, walkRetryAfter, func(r *http.Request) string {
+		return mux.Vars(r)[dagId]
+	})
 	v1 := router.PathPrefix("/v1/dags").Subrouter()
 
 	if authFn != nil {
 		v1.Use(xhttp.AuthMiddleware(authFn))
 	}
+	v1.Use(rateLimit(RouteGroupDAGs, config))
+
+	v1.Handle("", allow(user.RoleViewer, dagHandler.List)).Methods(http.MethodGet)
+	v1.Handle("", allow(user.RoleEditor, dagHandler.Create)).Methods(http.MethodPost)
+	v1.Handle("/validate", allow(user.RoleViewer, dagHandler.ValidateDAG)).Methods(http.MethodPost)
+	v1.Handle("/validate-all", allow(user.RoleEditor, dagHandler.ValidateAllDAGs)).Methods(http.MethodPost)
+	v1.Handle("/diff", allow(user.RoleViewer, dagHandler.Diff)).Methods(http.MethodPost)
+	v1.Handle("/import", allow(user.RoleEditor, dagHandler.Import)).Methods(http.MethodPost)
+	v1.Handle("/search", allow(user.RoleViewer, dagHandler.Search)).Methods(http.MethodGet)
+	v1.Handle("/trash", allow(user.RoleAdmin, dagHandler.Trash)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}", allow(user.RoleViewer, dagHandler.Get)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/content", allow(user.RoleViewer, dagHandler.GetContent)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/paths", allow(user.RoleViewer, dagHandler.GetPaths)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/export", allow(user.RoleViewer, dagHandler.Export)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/events", allow(user.RoleViewer, dagHandler.Events)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/validate", allow(user.RoleEditor, dagHandler.ValidateStoredDAG)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}", allow(user.RoleEditor, dagHandler.Update)).Methods(http.MethodPut)
+	v1.Handle("/{"+dagId+"}", allow(user.RoleAdmin, dagHandler.Delete)).Methods(http.MethodDelete)
+	v1.Handle("/{"+dagId+"}/restore", allow(user.RoleAdmin, dagHandler.RestoreDeleted)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/clone", allow(user.RoleEditor, dagHandler.Clone)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/merge", allow(user.RoleEditor, dagHandler.Merge)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/subtree/{"+nodeId+"}", allow(user.RoleViewer, dagHandler.GetSubtree)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/subtree/{"+nodeId+"}", allow(user.RoleEditor, dagHandler.MaterializeSubtree)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/answers/{"+answerId+"}/metadata", allow(user.RoleEditor, dagHandler.MergeAnswerMetadata)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/metadata-schema", allow(user.RoleViewer, dagHandler.GetMetadataSchema)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/metadata-schema", allow(user.RoleEditor, dagHandler.SetMetadataSchema)).Methods(http.MethodPut)
+	v1.Handle("/{"+dagId+"}/metadata-schema", allow(user.RoleEditor, dagHandler.DeleteMetadataSchema)).Methods(http.MethodDelete)
+	v1.Handle("/{"+dagId+"}/answers/{"+answerId+"}/insert-node", allow(user.RoleEditor, dagHandler.InsertNode)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/nodes/check-references", allow(user.RoleViewer, dagHandler.CheckNodeReferences)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/nodes/{"+nodeId+"}", allow(user.RoleEditor, dagHandler.UpdateNode)).Methods(http.MethodPatch)
+	v1.Handle("/{"+dagId+"}/nodes/{"+nodeId+"}/answers/{"+answerId+"}", allow(user.RoleEditor, dagHandler.UpdateAnswer)).Methods(http.MethodPatch)
+	v1.Handle("/{"+dagId+"}/nodes/{"+nodeId+"}/split", allow(user.RoleEditor, dagHandler.SplitNode)).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/walk-complete", walkLimit(allow(user.RoleViewer, dagHandler.WalkComplete))).Methods(http.MethodPost)
+	v1.Handle("/{"+dagId+"}/analytics/paths", allow(user.RoleViewer, dagHandler.GetPathAnalytics)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/versions", allow(user.RoleViewer, dagHandler.ListVersions)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/versions/{"+versionNumber+"}", allow(user.RoleViewer, dagHandler.GetVersion)).Methods(http.MethodGet)
+	v1.Handle("/{"+dagId+"}/versions/{"+versionNumber+"}/restore", allow(user.RoleEditor, dagHandler.RestoreVersion)).Methods(http.MethodPost)
+}
+
+// mountV1Session mounts the case session endpoints, sessions are started from their DAG
+func mountV1Session(router *mux.Router, authFn xhttp.AuthFn, app App, config Config) {
+	sessionHandler := NewSessionHandler(app)
+	sessionHandler.maxAttachmentSize = config.MaxAttachmentSize
+
+	dags := router.PathPrefix("/v1/dags/{" + dagId + "}/sessions").Subrouter()
+	v1 := router.PathPrefix("/v1/sessions").Subrouter()
+
+	if authFn != nil {
+		dags.Use(xhttp.AuthMiddleware(authFn))
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+
+	// Both prefixes share the buckets of the session clients
+	limit := rateLimit(RouteGroupSessions, config)
+	dags.Use(limit)
+	v1.Use(limit)
+
+	dags.Handle("", allow(user.RoleViewer, sessionHandler.Start)).Methods(http.MethodPost)
+	v1.Handle("/{"+sessionId+"}", allow(user.RoleViewer, sessionHandler.Get)).Methods(http.MethodGet)
+	v1.Handle("/{"+sessionId+"}/answers", allow(user.RoleViewer, sessionHandler.Answer)).Methods(http.MethodPut)
+	v1.Handle("/{"+sessionId+"}/answers/{"+nodeId+"}", allow(user.RoleViewer, sessionHandler.ReviseAnswer)).Methods(http.MethodDelete)
+	v1.Handle("/{"+sessionId+"}/complete", allow(user.RoleViewer, sessionHandler.Complete)).Methods(http.MethodPost)
+	v1.Handle("/{"+sessionId+"}/document", allow(user.RoleViewer, sessionHandler.Document)).Methods(http.MethodGet)
+	v1.Handle("/{"+sessionId+"}/prompt", allow(user.RoleViewer, sessionHandler.Prompt)).Methods(http.MethodPost)
+	v1.Handle("/{"+sessionId+"}/assess", allow(user.RoleViewer, sessionHandler.Assess)).Methods(http.MethodPost)
+	v1.Handle("/{"+sessionId+"}/score", allow(user.RoleViewer, sessionHandler.Score)).Methods(http.MethodGet)
+	v1.Handle("/{"+sessionId+"}/answers/{"+answerId+"}/attachments", allow(user.RoleViewer, sessionHandler.UploadAttachment)).Methods(http.MethodPost)
+	v1.Handle("/{"+sessionId+"}/attachments/{"+attachmentId+"}", allow(user.RoleViewer, sessionHandler.DownloadAttachment)).Methods(http.MethodGet)
+}
+
+// mountV1Template mounts the template library endpoints, templates are saved from their DAG
+func mountV1Template(router *mux.Router, authFn xhttp.AuthFn, app App, config Config) {
+	templateHandler := NewTemplateHandler(app)
+
+	dags := router.PathPrefix("/v1/dags/{" + dagId + "}/save-as-template").Subrouter()
+	v1 := router.PathPrefix("/v1/templates").Subrouter()
+
+	if authFn != nil {
+		dags.Use(xhttp.AuthMiddleware(authFn))
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+
+	// Templates are DAGs in the making, they share the buckets of the DAG clients
+	limit := rateLimit(RouteGroupDAGs, config)
+	dags.Use(limit)
+	v1.Use(limit)
+
+	dags.Handle("", allow(user.RoleEditor, templateHandler.SaveAsTemplate)).Methods(http.MethodPost)
+	v1.Handle("", allow(user.RoleViewer, templateHandler.List)).Methods(http.MethodGet)
+	v1.Handle("/{"+templateId+"}/instantiate", allow(user.RoleEditor, templateHandler.Instantiate)).Methods(http.MethodPost)
+}
+
+// mountV1Webhook mounts the webhook registry endpoints
+func mountV1Webhook(router *mux.Router, authFn xhttp.AuthFn, app App, config Config) {
+	webhookHandler := NewWebhookHandler(app)
+	v1 := router.PathPrefix("/v1/webhooks").Subrouter()
+
+	if authFn != nil {
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+	v1.Use(rateLimit(RouteGroupWebhooks, config))
 
-	v1.HandleFunc("", dagHandler.List).Methods(http.MethodGet)
-	v1.HandleFunc("/validate", dagHandler.ValidateDAG).Methods(http.MethodPost)
-	v1.HandleFunc("/{"+dagId+"}", dagHandler.Get).Methods(http.MethodGet)
-	v1.HandleFunc("/{"+dagId+"}/content", dagHandler.GetContent).Methods(http.MethodGet)
-	v1.HandleFunc("/{"+dagId+"}/validate", dagHandler.ValidateStoredDAG).Methods(http.MethodPost)
-	v1.HandleFunc("/{"+dagId+"}", dagHandler.Update).Methods(http.MethodPut)
+	v1.Handle("", allow(user.RoleAdmin, webhookHandler.List)).Methods(http.MethodGet)
+	v1.Handle("", allow(user.RoleAdmin, webhookHandler.Create)).Methods(http.MethodPost)
+	v1.Handle("/{"+webhookId+"}", allow(user.RoleAdmin, webhookHandler.Get)).Methods(http.MethodGet)
+	v1.Handle("/{"+webhookId+"}", allow(user.RoleAdmin, webhookHandler.Update)).Methods(http.MethodPut)
+	v1.Handle("/{"+webhookId+"}", allow(user.RoleAdmin, webhookHandler.Delete)).Methods(http.MethodDelete)
+	v1.Handle("/{"+webhookId+"}/deliveries", allow(user.RoleAdmin, webhookHandler.ListDeliveries)).Methods(http.MethodGet)
+}
+
+// mountV1Job mounts the background job endpoints
+func mountV1Job(router *mux.Router, authFn xhttp.AuthFn, app App, config Config) {
+	jobHandler := NewJobHandler(app)
+	v1 := router.PathPrefix("/v1/jobs").Subrouter()
+
+	if authFn != nil {
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+	v1.Use(rateLimit(RouteGroupDAGs, config))
+
+	v1.Handle("/{"+jobId+"}", allow(user.RoleViewer, jobHandler.Get)).Methods(http.MethodGet)
+}
+
+// mountV1Admin mounts the operation endpoints, left to admins
+func mountV1Admin(router *mux.Router, authFn xhttp.AuthFn, config Config) {
+	adminHandler := NewAdminHandler(config.Sync)
+	v1 := router.PathPrefix("/v1/admin").Subrouter()
+
+	if authFn != nil {
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+
+	v1.Handle("/sync", allow(user.RoleAdmin, adminHandler.Sync)).Methods(http.MethodPost)
+}
+
+// allow restricts a handler to the users granted the role. Reads are open to viewers, changes require editors
+// while deletions and the webhooks are left to admins. Case sessions only record answers to a DAG, viewers run them.
+func allow(role user.Role, handler http.HandlerFunc) http.Handler {
+	return xhttp.RequireRole(role)(handler)
+}
+
+// routeTemplate names the route matched by the request, so that the access logs of the same endpoint share it
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return r.URL.Path
+}
+
+// accessLogFields names the route and the DAG of the request in its access log
+func accessLogFields(r *http.Request) map[string]string {
+	return map[string]string{
+		"route":  routeTemplate(r),
+		"dag_id": mux.Vars(r)[dagId],
+	}
+}
+
+// rateLimit throttles the clients of the route group, per authenticated user once they are authenticated
+func rateLimit(group string, config Config) mux.MiddlewareFunc {
+	var onThrottle func(*http.Request)
+	if config.ThrottleCounter != nil {
+		onThrottle = func(*http.Request) { config.ThrottleCounter.Inc(group) }
+	}
+	return xhttp.RateLimitMiddleware(config.RateLimits[group], xhttp.ClientKey, onThrottle)
+}
+
+// mountV1Version mounts the unauthenticated build information endpoint
+func mountV1Version(router *mux.Router) {
+	versionHandler := NewVersionHandler(buildinfo.Get())
+	router.HandleFunc("/v1/version", versionHandler.Get).Methods(http.MethodGet)
+}
+
+// mountMetrics mounts the unauthenticated Prometheus endpoint when throttled requests are counted or DAGs synced
+func mountMetrics(router *mux.Router, config Config) {
+	var writers []xhttp.MetricsWriter
+	if config.ThrottleCounter != nil {
+		writers = append(writers, config.ThrottleCounter)
+	}
+	if config.Sync != nil {
+		writers = append(writers, syncMetrics{syncer: config.Sync})
+	}
+	if len(writers) == 0 {
+		return
+	}
+	router.Handle("/metrics", xhttp.MetricsHandler(writers...)).Methods(http.MethodGet)
 }
 
 // mountSwaggerUI mounts the Swagger UI documentation endpoint
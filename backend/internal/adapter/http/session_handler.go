@@ -0,0 +1,741 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/document"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// SessionAnswerPresenter represents an answer given during a case session
+//
+// @Description Answer given during a case session, along with the context provided by the user
+type SessionAnswerPresenter struct {
+	NodeId      uuid.UUID              `json:"node_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"Question the answer was given to"`
+	AnswerId    uuid.UUID              `json:"answer_id" example:"9c118df5-c787-6gc4-a0a4-g6g7d52dc766" description:"Selected answer"`
+	Value       string                 `json:"value,omitempty" example:"2024-03-03" description:"Value entered on an input question"`
+	UserContext string                 `json:"user_context,omitempty" example:"Dismissed by email on March 3rd" description:"Context provided by the user"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty" description:"Additional data provided along with the answer"`
+	AnsweredAt  time.Time              `json:"answered_at" example:"2024-01-15T10:30:00Z" description:"When the answer was given"`
+}
+
+// AttachmentPresenter represents a piece of evidence uploaded for an answer of a case session
+//
+// @Description Attachment of an answer, its content being downloaded from its link
+type AttachmentPresenter struct {
+	Id          uuid.UUID `json:"id" example:"c3d4e5f6-a7b8-9012-cdef-123456789012" description:"Attachment unique identifier"`
+	AnswerId    uuid.UUID `json:"answer_id" example:"9c118df5-c787-6gc4-a0a4-g6g7d52dc766" description:"Answer the attachment supports"`
+	FileName    string    `json:"file_name" example:"dismissal-letter.pdf" description:"Name of the uploaded file"`
+	ContentType string    `json:"content_type" example:"application/pdf" description:"Content type detected from the content"`
+	Size        int64     `json:"size" example:"48213" description:"Size of the content, in bytes"`
+	Checksum    string    `json:"checksum" example:"sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" description:"Digest of the content, prefixed with its algorithm"`
+	Link        string    `json:"link" example:"/v1/sessions/a1b2c3d4-e5f6-7890-abcd-ef1234567890/attachments/c3d4e5f6-a7b8-9012-cdef-123456789012" description:"Path downloading the content"`
+	UploadedAt  time.Time `json:"uploaded_at" example:"2024-01-15T10:32:00Z" description:"When the attachment was uploaded"`
+}
+
+func NewAttachmentPresenter(sessionId uuid.UUID, attachment model.Attachment) AttachmentPresenter {
+	return AttachmentPresenter{
+		Id:          attachment.Id,
+		AnswerId:    attachment.AnswerId,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		Link:        usecase.AttachmentLink(sessionId, attachment.Id),
+		UploadedAt:  attachment.UploadedAt,
+	}
+}
+
+// ScorePresenter represents the aggregate scores of a case session
+//
+// @Description Scores of the answers given during a session, per dimension and in total
+type ScorePresenter struct {
+	Dimensions []DimensionScorePresenter `json:"dimensions" description:"Score of each dimension, in declared order"`
+	Total      float64                   `json:"total" example:"12.5" description:"Sum of the dimension scores, each multiplied by the weight of its dimension"`
+}
+
+// DimensionScorePresenter represents the score of a session along a dimension
+//
+// @Description Score of a session along a scoring dimension, with the band it reaches
+type DimensionScorePresenter struct {
+	Name  string  `json:"name" example:"claim_strength" description:"Dimension name"`
+	Label string  `json:"label,omitempty" example:"Claim strength" description:"Dimension label"`
+	Value float64 `json:"value" example:"8" description:"Sum of the contributions of the answers to the dimension"`
+	Band  string  `json:"band,omitempty" example:"strong" description:"Label of the band the value reaches"`
+}
+
+func NewScorePresenter(score model.Score) ScorePresenter {
+	dimensions := make([]DimensionScorePresenter, 0, len(score.Dimensions))
+	for _, dimension := range score.Dimensions {
+		dimensions = append(dimensions, DimensionScorePresenter(dimension))
+	}
+
+	return ScorePresenter{Dimensions: dimensions, Total: score.Total}
+}
+
+// AssessmentPresenter represents the legal assessment of a case session
+//
+// @Description Legal assessment of a completed case session written by a language model
+type AssessmentPresenter struct {
+	Provider  string    `json:"provider" example:"openai" enums:"openai,anthropic,local" description:"Provider of the language model"`
+	Model     string    `json:"model" example:"gpt-4o" description:"Language model which wrote the assessment"`
+	Content   string    `json:"content" example:"The dismissal without notice during the probation period is lawful unless..." description:"Assessment of the case"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:40:00Z" description:"When the assessment was written"`
+}
+
+// CaseSessionPresenter represents a walkthrough of a DAG stored server side
+//
+// @Description Case session holding the answers given so far and the next question to answer
+type CaseSessionPresenter struct {
+	Id            uuid.UUID                `json:"id" example:"a1b2c3d4-e5f6-7890-abcd-ef1234567890" description:"Session unique identifier"`
+	DAGId         uuid.UUID                `json:"dag_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"DAG the session walks through"`
+	Status        string                   `json:"status" example:"in_progress" enums:"in_progress,completed" description:"Progress of the session"`
+	CurrentNodeId *uuid.UUID               `json:"current_node_id,omitempty" description:"Next question to answer, absent once the walk reached its end"`
+	Answers       []SessionAnswerPresenter `json:"answers" description:"Answers given so far, in order"`
+	Attachments   []AttachmentPresenter    `json:"attachments,omitempty" description:"Evidence uploaded for the answers, in upload order"`
+	Assessment    *AssessmentPresenter     `json:"assessment,omitempty" description:"Latest legal assessment of the session, absent until one is requested"`
+	CreatedAt     time.Time                `json:"created_at" example:"2024-01-15T10:30:00Z" description:"When the session was started"`
+	UpdatedAt     time.Time                `json:"updated_at" example:"2024-01-15T10:35:00Z" description:"When the session was last changed"`
+}
+
+func NewCaseSessionPresenter(session *model.CaseSession) CaseSessionPresenter {
+	answers := make([]SessionAnswerPresenter, 0, len(session.Answers))
+	for _, answer := range session.Answers {
+		answers = append(answers, SessionAnswerPresenter(answer))
+	}
+
+	var attachments []AttachmentPresenter
+	for _, attachment := range session.Attachments {
+		attachments = append(attachments, NewAttachmentPresenter(session.Id, attachment))
+	}
+
+	var assessment *AssessmentPresenter
+	if session.Assessment != nil {
+		presented := AssessmentPresenter(*session.Assessment)
+		assessment = &presented
+	}
+
+	return CaseSessionPresenter{
+		Id:            session.Id,
+		DAGId:         session.DAGId,
+		Status:        string(session.Status),
+		CurrentNodeId: session.CurrentNodeId,
+		Answers:       answers,
+		Attachments:   attachments,
+		Assessment:    assessment,
+		CreatedAt:     session.CreatedAt,
+		UpdatedAt:     session.UpdatedAt,
+	}
+}
+
+// SessionAnswerRequest represents the request payload for answering the current question of a session
+//
+// @Description Answer to the current question of a case session
+type SessionAnswerRequest struct {
+	AnswerId    string                 `json:"answer_id,omitempty" example:"9c118df5-c787-6gc4-a0a4-g6g7d52dc766" description:"Selected answer of the current question"`
+	AnswerIds   []string               `json:"answer_ids,omitempty" example:"9c118df5-c787-6gc4-a0a4-g6g7d52dc766" description:"Selected answers of a multi-select question, along with answer_id when it is set"`
+	Value       *string                `json:"value,omitempty" example:"2024-03-03" description:"Value entered on an input question, given instead of answers"`
+	UserContext string                 `json:"user_context,omitempty" example:"Dismissed by email on March 3rd" description:"Context provided by the user"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty" description:"Additional data provided along with the answer"`
+}
+
+// PromptMessagePresenter represents a chat message of a prompt
+//
+// @Description Chat message to send to a language model
+type PromptMessagePresenter struct {
+	Role    string `json:"role" example:"system" enums:"system,user" description:"Author of the message"`
+	Content string `json:"content" example:"You are a legal assistant analysing a client's case." description:"Text of the message"`
+}
+
+// PromptPresenter represents the prompt built from a completed case session
+//
+// @Description Structured prompt summarizing the path answered in a case session
+type PromptPresenter struct {
+	SessionId uuid.UUID                `json:"session_id" example:"a1b2c3d4-e5f6-7890-abcd-ef1234567890" description:"Session the prompt was built from"`
+	DAGId     uuid.UUID                `json:"dag_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"DAG walked by the session"`
+	Messages  []PromptMessagePresenter `json:"messages" description:"Messages of the prompt, system message first"`
+}
+
+func NewPromptPresenter(prompt *usecase.BuiltPrompt) PromptPresenter {
+	messages := make([]PromptMessagePresenter, 0, len(prompt.Messages))
+	for _, message := range prompt.Messages {
+		messages = append(messages, PromptMessagePresenter(message))
+	}
+
+	return PromptPresenter{
+		SessionId: prompt.SessionId,
+		DAGId:     prompt.DAGId,
+		Messages:  messages,
+	}
+}
+
+// BuildPromptRequest represents the request payload for building the prompt of a session
+//
+// @Description Templates and task of the prompt, every field is optional
+type BuildPromptRequest struct {
+	SystemTemplate string `json:"system_template,omitempty" example:"You are a lawyer reviewing the {{.Title}} case." description:"Go text/template source of the system message, replacing the default one"`
+	UserTemplate   string `json:"user_template,omitempty" example:"{{range .Entries}}{{.Question}} {{.Answer}}\n{{end}}" description:"Go text/template source of the user message, replacing the default one"`
+	Task           string `json:"task,omitempty" example:"Draft a demand letter to the employer." description:"What the model is asked to do with the case context"`
+}
+
+// AssessSessionRequest represents the request payload for assessing a session
+//
+// @Description Instructions of the assessment, every field is optional
+type AssessSessionRequest struct {
+	Task string `json:"task,omitempty" example:"Assess the chances of success of an unfair dismissal claim." description:"What the language model is asked to do with the case context, a legal assessment when omitted"`
+}
+
+// attachmentFormField is the multipart form field holding an uploaded attachment
+const attachmentFormField = "file"
+
+type sessionHandler struct {
+	app App
+	// maxAttachmentSize limits the uploaded attachments, in bytes, unlimited when 0
+	maxAttachmentSize int64
+}
+
+func NewSessionHandler(app App) *sessionHandler {
+	return &sessionHandler{
+		app: app,
+	}
+}
+
+// Start starts a case session on a DAG
+//
+// @Summary Start case session
+// @Description Start a walkthrough of a DAG stored server side, positioned on its root question
+// @Tags Sessions
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Success 201 {object} CaseSessionPresenter "Successfully started session"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format or DAG without a single root"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/sessions [post]
+func (h *sessionHandler) Start(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session, err := h.app.StartSession(ctx, usecase.CmdStartSession{
+		DAGId: mux.Vars(r)[dagId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to start session")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid session request", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to start session", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewCaseSessionPresenter(session))
+}
+
+// Answer answers the current question of a case session
+//
+// @Summary Answer session question
+// @Description Record the answer to the current question of a session, with the context provided by the user, and move to the question it leads to. Multi-select questions accept several answers through answer_ids, input questions take the entered value instead of an answer.
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Param answer body SessionAnswerRequest true "Answer to the current question"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} CaseSessionPresenter "Successfully answered question"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body or answer not valid for the current question"
+// @Failure 404 {object} xhttp.ErrorResponse "Session or DAG not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Session has no question left to answer"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/answers [put]
+func (h *sessionHandler) Answer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var answerRequest SessionAnswerRequest
+	if err := decodeRequestBody(r, &answerRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode session answer request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	session, err := h.app.AnswerSession(ctx, usecase.CmdAnswerSession{
+		SessionId:   mux.Vars(r)[sessionId],
+		AnswerId:    answerRequest.AnswerId,
+		AnswerIds:   answerRequest.AnswerIds,
+		Value:       answerRequest.Value,
+		UserContext: answerRequest.UserContext,
+		Metadata:    answerRequest.Metadata,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to answer session")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid session answer", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "session has no question left to answer", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to answer session", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewCaseSessionPresenter(session))
+}
+
+// ReviseAnswer discards an answer of a case session along with the answers given after it
+//
+// @Summary Revise case session answer
+// @Description Discard the answer given to a question of a session along with every answer given after it, so that the question is asked again and the walk may take another branch. A completed session is reopened and its assessment discarded.
+// @Tags Sessions
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Param nodeId path string true "Question to answer again (UUID)"
+// @Success 200 {object} CaseSessionPresenter "Successfully discarded the answers"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid session or node ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Session not found or question not answered during the session"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/answers/{nodeId} [delete]
+func (h *sessionHandler) ReviseAnswer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session, err := h.app.ReviseSessionAnswer(ctx, usecase.CmdReviseSessionAnswer{
+		SessionId: mux.Vars(r)[sessionId],
+		NodeId:    mux.Vars(r)[nodeId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to revise session answer")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid session or node ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session answer not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to revise session answer", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewCaseSessionPresenter(session))
+}
+
+// Complete closes a case session whose walk reached its end
+//
+// @Summary Complete case session
+// @Description Mark a session as completed once all its questions were answered
+// @Tags Sessions
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Success 200 {object} CaseSessionPresenter "Successfully completed session"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid session ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Session not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Session still has questions to answer or is already completed"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/complete [post]
+func (h *sessionHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session, err := h.app.CompleteSession(ctx, usecase.CmdCompleteSession{
+		SessionId: mux.Vars(r)[sessionId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to complete session")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid session ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "session cannot be completed", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to complete session", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewCaseSessionPresenter(session))
+}
+
+// Get retrieves a case session
+//
+// @Summary Get case session
+// @Description Retrieve a session with the answers given so far and the next question to answer
+// @Tags Sessions
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Success 200 {object} CaseSessionPresenter "Successfully retrieved session"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid session ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Session not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId} [get]
+func (h *sessionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session, err := h.app.GetSession(ctx, usecase.CmdGetSession{
+		SessionId: mux.Vars(r)[sessionId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get session")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid session ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get session", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewCaseSessionPresenter(session))
+}
+
+// Document renders a completed case session as a shareable context document
+//
+// @Summary Get case session document
+// @Description Render the questions answered during a completed session, with the user context, confidence, tags and evidence sources of each answer, as a Markdown or PDF document
+// @Tags Sessions
+// @Produce text/markdown
+// @Produce application/pdf
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Param format query string false "Document format, md when omitted" Enums(md, pdf)
+// @Success 200 {string} string "Context document, sent as an attachment"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid session ID format or unsupported format"
+// @Failure 404 {object} xhttp.ErrorResponse "Session or its DAG not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Session is not completed or no longer matches its DAG"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/document [get]
+func (h *sessionHandler) Document(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = document.FormatMarkdown
+	}
+
+	doc, err := h.app.GetSessionDocument(ctx, usecase.CmdGetSessionDocument{
+		SessionId: mux.Vars(r)[sessionId],
+		Format:    format,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get session document")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid document parameters", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "session document unavailable", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get session document", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", doc.FileName))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(doc.Data); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to write session document")
+	}
+}
+
+// Prompt builds a language model prompt from a completed case session
+//
+// @Summary Build case session prompt
+// @Description Convert the path answered during a completed session into system and user messages for a language model. Templates are Go text/template sources rendered with the Title, DAGId, SessionId, GeneratedAt and Entries (Question, Answer, UserContext, Confidence, Tags, Sources) of the case context, and the Task. The request body is optional.
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Param prompt body BuildPromptRequest false "Templates and task of the prompt"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} PromptPresenter "Successfully built prompt"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid session ID format, request body or template"
+// @Failure 404 {object} xhttp.ErrorResponse "Session or its DAG not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Session is not completed or no longer matches its DAG"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/prompt [post]
+func (h *sessionHandler) Prompt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var promptRequest BuildPromptRequest
+	// An empty body builds the default prompt
+	if err := decodeRequestBody(r, &promptRequest); err != nil && !errors.Is(err, io.EOF) {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode prompt request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	prompt, err := h.app.BuildPrompt(ctx, usecase.CmdBuildPrompt{
+		SessionId:      mux.Vars(r)[sessionId],
+		SystemTemplate: promptRequest.SystemTemplate,
+		UserTemplate:   promptRequest.UserTemplate,
+		Task:           promptRequest.Task,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to build session prompt")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid prompt parameters", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "session prompt unavailable", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to build session prompt", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewPromptPresenter(prompt))
+}
+
+// Assess asks the configured language model to assess a completed case session
+//
+// @Summary Assess case session
+// @Description Send the prompt built from a completed session to the configured LLM provider and store the returned legal assessment on the session, replacing the previous one. The request body is optional.
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Param assessment body AssessSessionRequest false "Instructions of the assessment"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} CaseSessionPresenter "Session along with its assessment"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid session ID format or request body"
+// @Failure 404 {object} xhttp.ErrorResponse "Session or its DAG not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Session is not completed or no longer matches its DAG"
+// @Failure 503 {object} xhttp.ErrorResponse "No LLM provider is configured or the provider failed"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/assess [post]
+func (h *sessionHandler) Assess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var assessRequest AssessSessionRequest
+	// An empty body asks for the default assessment
+	if err := decodeRequestBody(r, &assessRequest); err != nil && !errors.Is(err, io.EOF) {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode assess request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	session, err := h.app.AssessSession(ctx, usecase.CmdAssessSession{
+		SessionId: mux.Vars(r)[sessionId],
+		Task:      assessRequest.Task,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to assess session")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid assessment parameters", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "session cannot be assessed", err)
+		case errors.Is(err, usecase.ErrUnavailable):
+			xhttp.WriteError(ctx, w, http.StatusServiceUnavailable, "assessment provider unavailable", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to assess session", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewCaseSessionPresenter(session))
+}
+
+// Score computes the aggregate scores of a completed case session
+//
+// @Summary Score case session
+// @Description Sum the score contributions of the answers given during a completed session along the scoring dimensions of its DAG. Without scoring configuration, every dimension an answer contributes to is scored with a weight of 1.
+// @Tags Sessions
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Success 200 {object} ScorePresenter "Scores of the session"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid session ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Session or its DAG not found"
+// @Failure 409 {object} xhttp.ErrorResponse "Session is not completed or no longer matches its DAG"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/score [get]
+func (h *sessionHandler) Score(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	score, err := h.app.ScoreSession(ctx, usecase.CmdScoreSession{
+		SessionId: mux.Vars(r)[sessionId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to score session")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid session ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session not found", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "session cannot be scored", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to score session", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewScorePresenter(*score))
+}
+
+// UploadAttachment stores a piece of evidence for an answer given during a case session
+//
+// @Summary Upload session attachment
+// @Description Upload a file supporting an answer given during the session, as the file field of a multipart form. The content type is detected from the content and must be accepted by the server, along with the size. The checksum of the content is recorded and checked on download.
+// @Tags Sessions
+// @Accept multipart/form-data
+// @Produce json
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Param answerId path string true "Answer unique identifier (UUID)"
+// @Param file formData file true "Evidence supporting the answer"
+// @Success 201 {object} AttachmentPresenter "Successfully uploaded attachment"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid ID format, missing file or content type not accepted"
+// @Failure 404 {object} xhttp.ErrorResponse "Session not found or answer not given during the session"
+// @Failure 413 {object} xhttp.ErrorResponse "File too large"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Failure 503 {object} xhttp.ErrorResponse "Attachment storage unavailable"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/answers/{answerId}/attachments [post]
+func (h *sessionHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fileName, data, err := h.readAttachment(r)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to read attachment upload")
+		if errors.Is(err, errAttachmentTooLarge) {
+			xhttp.WriteError(ctx, w, http.StatusRequestEntityTooLarge, "attachment too large", err)
+			return
+		}
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid attachment upload", err)
+		return
+	}
+
+	vars := mux.Vars(r)
+	attachment, err := h.app.AddAttachment(ctx, usecase.CmdAddAttachment{
+		SessionId: vars[sessionId],
+		AnswerId:  vars[answerId],
+		FileName:  fileName,
+		Data:      data,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to add attachment")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid attachment", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "session or answer not found", err)
+		case errors.Is(err, usecase.ErrUnavailable):
+			xhttp.WriteError(ctx, w, http.StatusServiceUnavailable, "attachment storage unavailable", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to add attachment", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewAttachmentPresenter(uuid.MustParse(vars[sessionId]), *attachment))
+}
+
+// DownloadAttachment sends the content of an attachment of a case session
+//
+// @Summary Download session attachment
+// @Description Download the content of an attachment, checked against the checksum recorded on upload
+// @Tags Sessions
+// @Produce application/octet-stream
+// @Param sessionId path string true "Session unique identifier (UUID)"
+// @Param attachmentId path string true "Attachment unique identifier (UUID)"
+// @Success 200 {file} file "Attachment content, sent with its detected content type"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Session or attachment not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error or corrupted attachment"
+// @Failure 503 {object} xhttp.ErrorResponse "Attachment storage unavailable"
+// @Security ApiKeyAuth
+// @Router /sessions/{sessionId}/attachments/{attachmentId} [get]
+func (h *sessionHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	content, err := h.app.GetAttachment(ctx, usecase.CmdGetAttachment{
+		SessionId:    vars[sessionId],
+		AttachmentId: vars[attachmentId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get attachment")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid attachment ID format", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "attachment not found", err)
+		case errors.Is(err, usecase.ErrUnavailable):
+			xhttp.WriteError(ctx, w, http.StatusServiceUnavailable, "attachment storage unavailable", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get attachment", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", content.Attachment.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", content.Attachment.FileName))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(content.Data); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to write attachment")
+	}
+}
+
+var errAttachmentTooLarge = errors.New("attachment too large")
+
+// readAttachment reads the file field of a multipart upload, without buffering more than the size limit
+func (h *sessionHandler) readAttachment(r *http.Request) (string, []byte, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return "", nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return "", nil, fmt.Errorf("missing %s field", attachmentFormField)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if part.FormName() != attachmentFormField {
+			continue
+		}
+
+		var body io.Reader = part
+		if h.maxAttachmentSize > 0 {
+			body = io.LimitReader(part, h.maxAttachmentSize+1)
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return "", nil, err
+		}
+		if h.maxAttachmentSize > 0 && int64(len(data)) > h.maxAttachmentSize {
+			return "", nil, fmt.Errorf("%w: the limit is %d bytes", errAttachmentTooLarge, h.maxAttachmentSize)
+		}
+		return part.FileName(), data, nil
+	}
+}
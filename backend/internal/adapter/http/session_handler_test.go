@@ -0,0 +1,561 @@
+package http
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionHandler(t *testing.T) {
+	dagID := uuid.New()
+	nodeID := uuid.New()
+	answerID := uuid.New()
+	session := &model.CaseSession{
+		Id:            uuid.New(),
+		DAGId:         dagID,
+		Status:        model.SessionStatusInProgress,
+		CurrentNodeId: &nodeID,
+		Answers: []model.SessionAnswer{
+			{NodeId: uuid.New(), AnswerId: uuid.New(), UserContext: "Dismissed by email"},
+		},
+	}
+	sessionURL := "/v1/sessions/" + session.Id.String()
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		setupMock      func(*mocks.MockApp)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "starts a session",
+			method: http.MethodPost,
+			url:    "/v1/dags/" + dagID.String() + "/sessions",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().StartSession(gomock.Any(), usecase.CmdStartSession{DAGId: dagID.String()}).Return(session, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "returns 404 when starting on an unknown DAG",
+			method: http.MethodPost,
+			url:    "/v1/dags/" + dagID.String() + "/sessions",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().StartSession(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "DAG not found",
+		},
+		{
+			name:   "answers the current question",
+			method: http.MethodPut,
+			url:    sessionURL + "/answers",
+			body:   `{"answer_id":"` + answerID.String() + `","user_context":"On March 3rd","metadata":{"channel":"email"}}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().AnswerSession(gomock.Any(), usecase.CmdAnswerSession{
+					SessionId:   session.Id.String(),
+					AnswerId:    answerID.String(),
+					UserContext: "On March 3rd",
+					Metadata:    map[string]interface{}{"channel": "email"},
+				}).Return(session, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "answers a multi-select question",
+			method: http.MethodPut,
+			url:    sessionURL + "/answers",
+			body:   `{"answer_ids":["` + answerID.String() + `"]}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().AnswerSession(gomock.Any(), usecase.CmdAnswerSession{
+					SessionId: session.Id.String(),
+					AnswerIds: []string{answerID.String()},
+				}).Return(session, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "answers an input question",
+			method: http.MethodPut,
+			url:    sessionURL + "/answers",
+			body:   `{"value":"2024-03-03"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				value := "2024-03-03"
+				mockApp.EXPECT().AnswerSession(gomock.Any(), usecase.CmdAnswerSession{
+					SessionId: session.Id.String(),
+					Value:     &value,
+				}).Return(session, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns 400 for invalid answer body",
+			method:         http.MethodPut,
+			url:            sessionURL + "/answers",
+			body:           `{invalid`,
+			setupMock:      func(mockApp *mocks.MockApp) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid request body",
+		},
+		{
+			name:   "returns 400 for an answer of another question",
+			method: http.MethodPut,
+			url:    sessionURL + "/answers",
+			body:   `{"answer_id":"` + answerID.String() + `"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().AnswerSession(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid session answer",
+		},
+		{
+			name:   "returns 409 when no question is left",
+			method: http.MethodPut,
+			url:    sessionURL + "/answers",
+			body:   `{"answer_id":"` + answerID.String() + `"}`,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().AnswerSession(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "session has no question left to answer",
+		},
+		{
+			name:   "gets a session",
+			method: http.MethodGet,
+			url:    sessionURL,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetSession(gomock.Any(), usecase.CmdGetSession{SessionId: session.Id.String()}).Return(session, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "returns 404 for unknown session",
+			method: http.MethodGet,
+			url:    sessionURL,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetSession(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "session not found",
+		},
+		{
+			name:   "completes a session",
+			method: http.MethodPost,
+			url:    sessionURL + "/complete",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().CompleteSession(gomock.Any(), usecase.CmdCompleteSession{SessionId: session.Id.String()}).Return(session, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "returns 409 when completing with questions left",
+			method: http.MethodPost,
+			url:    sessionURL + "/complete",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().CompleteSession(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "session cannot be completed",
+		},
+		{
+			name:   "returns 409 for the document of a session in progress",
+			method: http.MethodGet,
+			url:    sessionURL + "/document",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetSessionDocument(gomock.Any(), usecase.CmdGetSessionDocument{SessionId: session.Id.String(), Format: "md"}).Return(nil, usecase.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "session document unavailable",
+		},
+		{
+			name:   "returns 400 for an unsupported document format",
+			method: http.MethodGet,
+			url:    sessionURL + "/document?format=docx",
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetSessionDocument(gomock.Any(), usecase.CmdGetSessionDocument{SessionId: session.Id.String(), Format: "docx"}).Return(nil, usecase.ErrInvalidCommand)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid document parameters",
+		},
+		{
+			name:   "revises an answer",
+			method: http.MethodDelete,
+			url:    sessionURL + "/answers/" + nodeID.String(),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ReviseSessionAnswer(gomock.Any(), usecase.CmdReviseSessionAnswer{SessionId: session.Id.String(), NodeId: nodeID.String()}).Return(session, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "returns 404 when revising a question which was not answered",
+			method: http.MethodDelete,
+			url:    sessionURL + "/answers/" + nodeID.String(),
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().ReviseSessionAnswer(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "session answer not found",
+		},
+		{
+			name:   "returns 500 when app layer fails",
+			method: http.MethodGet,
+			url:    sessionURL,
+			setupMock: func(mockApp *mocks.MockApp) {
+				mockApp.EXPECT().GetSession(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInternal)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to get session",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			tt.setupMock(mockApp)
+
+			router := New(mockApp, nil, Config{})
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedBody)
+				return
+			}
+
+			var presenter CaseSessionPresenter
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &presenter))
+			assert.Equal(t, session.Id, presenter.Id)
+			assert.Equal(t, dagID, presenter.DAGId)
+			assert.Equal(t, "in_progress", presenter.Status)
+			assert.Equal(t, &nodeID, presenter.CurrentNodeId)
+			require.Len(t, presenter.Answers, 1)
+			assert.Equal(t, "Dismissed by email", presenter.Answers[0].UserContext)
+		})
+	}
+}
+
+func TestSessionHandler_Document(t *testing.T) {
+	sessionId := uuid.New()
+	ctrl := gomock.NewController(t)
+	mockApp := mocks.NewMockApp(ctrl)
+	mockApp.EXPECT().GetSessionDocument(gomock.Any(), usecase.CmdGetSessionDocument{SessionId: sessionId.String(), Format: "pdf"}).Return(&usecase.SessionDocument{
+		Format:      "pdf",
+		ContentType: "application/pdf",
+		FileName:    "case-context-" + sessionId.String() + ".pdf",
+		Data:        []byte("%PDF-1.4"),
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/sessions/"+sessionId.String()+"/document?format=pdf", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="case-context-`+sessionId.String()+`.pdf"`, rr.Header().Get("Content-Disposition"))
+	assert.Equal(t, "%PDF-1.4", rr.Body.String())
+}
+
+func TestSessionHandler_Prompt(t *testing.T) {
+	sessionId := uuid.New()
+	dagId := uuid.New()
+	prompt := &usecase.BuiltPrompt{
+		SessionId: sessionId,
+		DAGId:     dagId,
+		Messages: []llmprompt.Message{
+			{Role: llmprompt.RoleSystem, Content: "You are a legal assistant."},
+			{Role: llmprompt.RoleUser, Content: "Case context"},
+		},
+	}
+
+	t.Run("builds the prompt with the given templates and task", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+		mockApp.EXPECT().BuildPrompt(gomock.Any(), usecase.CmdBuildPrompt{
+			SessionId:    sessionId.String(),
+			UserTemplate: "{{.Title}}",
+			Task:         "Draft a letter.",
+		}).Return(prompt, nil)
+
+		rr := httptest.NewRecorder()
+		body := `{"user_template":"{{.Title}}","task":"Draft a letter."}`
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/sessions/"+sessionId.String()+"/prompt", strings.NewReader(body)))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response PromptPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, sessionId, response.SessionId)
+		assert.Equal(t, dagId, response.DAGId)
+		assert.Equal(t, []PromptMessagePresenter{
+			{Role: "system", Content: "You are a legal assistant."},
+			{Role: "user", Content: "Case context"},
+		}, response.Messages)
+	})
+
+	t.Run("builds the default prompt without body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+		mockApp.EXPECT().BuildPrompt(gomock.Any(), usecase.CmdBuildPrompt{SessionId: sessionId.String()}).Return(prompt, nil)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/sessions/"+sessionId.String()+"/prompt", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("maps errors to status codes", func(t *testing.T) {
+		for _, tc := range []struct {
+			err    error
+			status int
+		}{
+			{usecase.ErrInvalidCommand, http.StatusBadRequest},
+			{usecase.ErrNotFound, http.StatusNotFound},
+			{usecase.ErrConflict, http.StatusConflict},
+			{usecase.ErrInternal, http.StatusInternalServerError},
+		} {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			mockApp.EXPECT().BuildPrompt(gomock.Any(), gomock.Any()).Return(nil, tc.err)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/sessions/"+sessionId.String()+"/prompt", nil))
+
+			assert.Equal(t, tc.status, rr.Code, tc.err.Error())
+		}
+	})
+
+	t.Run("rejects a malformed body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/sessions/"+sessionId.String()+"/prompt", strings.NewReader("{")))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSessionHandler_Assess(t *testing.T) {
+	sessionId := uuid.New()
+	assessedAt := time.Date(2024, 1, 15, 10, 40, 0, 0, time.UTC)
+	assessed := &model.CaseSession{
+		Id:      sessionId,
+		DAGId:   uuid.New(),
+		Status:  model.SessionStatusCompleted,
+		Answers: []model.SessionAnswer{},
+		Assessment: &model.Assessment{
+			Provider:  "openai",
+			Model:     "gpt-4o",
+			Content:   "The dismissal was unfair.",
+			CreatedAt: assessedAt,
+		},
+	}
+
+	t.Run("returns the session with its assessment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+		mockApp.EXPECT().AssessSession(gomock.Any(), usecase.CmdAssessSession{
+			SessionId: sessionId.String(),
+			Task:      "Assess the claims.",
+		}).Return(assessed, nil)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/sessions/"+sessionId.String()+"/assess", strings.NewReader(`{"task":"Assess the claims."}`)))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response CaseSessionPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.NotNil(t, response.Assessment)
+		assert.Equal(t, AssessmentPresenter{
+			Provider:  "openai",
+			Model:     "gpt-4o",
+			Content:   "The dismissal was unfair.",
+			CreatedAt: assessedAt,
+		}, *response.Assessment)
+	})
+
+	t.Run("maps errors to status codes", func(t *testing.T) {
+		for _, tc := range []struct {
+			err    error
+			status int
+		}{
+			{usecase.ErrInvalidCommand, http.StatusBadRequest},
+			{usecase.ErrNotFound, http.StatusNotFound},
+			{usecase.ErrConflict, http.StatusConflict},
+			{usecase.ErrUnavailable, http.StatusServiceUnavailable},
+			{usecase.ErrInternal, http.StatusInternalServerError},
+		} {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			mockApp.EXPECT().AssessSession(gomock.Any(), usecase.CmdAssessSession{SessionId: sessionId.String()}).Return(nil, tc.err)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/sessions/"+sessionId.String()+"/assess", nil))
+
+			assert.Equal(t, tc.status, rr.Code, tc.err.Error())
+		}
+	})
+}
+
+func TestSessionHandler_Score(t *testing.T) {
+	sessionId := uuid.New()
+
+	t.Run("returns the scores of the session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockApp := mocks.NewMockApp(ctrl)
+		mockApp.EXPECT().ScoreSession(gomock.Any(), usecase.CmdScoreSession{SessionId: sessionId.String()}).Return(&model.Score{
+			Dimensions: []model.DimensionScore{{Name: "claim_strength", Label: "Claim strength", Value: 6, Band: "strong"}},
+			Total:      12,
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/sessions/"+sessionId.String()+"/score", nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response ScorePresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, ScorePresenter{
+			Dimensions: []DimensionScorePresenter{{Name: "claim_strength", Label: "Claim strength", Value: 6, Band: "strong"}},
+			Total:      12,
+		}, response)
+	})
+
+	t.Run("maps errors to status codes", func(t *testing.T) {
+		for _, tc := range []struct {
+			err    error
+			status int
+		}{
+			{usecase.ErrInvalidCommand, http.StatusBadRequest},
+			{usecase.ErrNotFound, http.StatusNotFound},
+			{usecase.ErrConflict, http.StatusConflict},
+			{usecase.ErrInternal, http.StatusInternalServerError},
+		} {
+			ctrl := gomock.NewController(t)
+			mockApp := mocks.NewMockApp(ctrl)
+			mockApp.EXPECT().ScoreSession(gomock.Any(), gomock.Any()).Return(nil, tc.err)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/sessions/"+sessionId.String()+"/score", nil))
+
+			assert.Equal(t, tc.status, rr.Code, tc.err.Error())
+		}
+	})
+}
+
+func TestSessionHandler_UploadAttachment(t *testing.T) {
+	sessionId, answerId := uuid.New(), uuid.New()
+	url := "/v1/sessions/" + sessionId.String() + "/answers/" + answerId.String() + "/attachments"
+	pdf := []byte("%PDF-1.4\n%dismissal letter")
+	upload := func(field string, data []byte) *http.Request {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile(field, "dismissal.pdf")
+		require.NoError(t, err)
+		_, err = part.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(http.MethodPost, url, &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return req
+	}
+
+	t.Run("uploads an attachment", func(t *testing.T) {
+		attachment := &model.Attachment{Id: uuid.New(), AnswerId: answerId, FileName: "dismissal.pdf", ContentType: "application/pdf", Size: int64(len(pdf))}
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().AddAttachment(gomock.Any(), usecase.CmdAddAttachment{
+			SessionId: sessionId.String(),
+			AnswerId:  answerId.String(),
+			FileName:  "dismissal.pdf",
+			Data:      pdf,
+		}).Return(attachment, nil)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{MaxAttachmentSize: 1024}).ServeHTTP(rr, upload("file", pdf))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var presenter AttachmentPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &presenter))
+		assert.Equal(t, attachment.Id, presenter.Id)
+		assert.Equal(t, "/v1/sessions/"+sessionId.String()+"/attachments/"+attachment.Id.String(), presenter.Link)
+	})
+
+	t.Run("rejects files larger than the limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(mocks.NewMockApp(gomock.NewController(t)), nil, Config{MaxAttachmentSize: 8}).ServeHTTP(rr, upload("file", pdf))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("rejects uploads without file", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		New(mocks.NewMockApp(gomock.NewController(t)), nil, Config{}).ServeHTTP(rr, upload("document", pdf))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "invalid attachment upload")
+	})
+
+	t.Run("maps the use case errors", func(t *testing.T) {
+		for err, status := range map[error]int{
+			usecase.ErrInvalidCommand: http.StatusBadRequest,
+			usecase.ErrNotFound:       http.StatusNotFound,
+			usecase.ErrUnavailable:    http.StatusServiceUnavailable,
+		} {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			mockApp.EXPECT().AddAttachment(gomock.Any(), gomock.Any()).Return(nil, err)
+
+			rr := httptest.NewRecorder()
+			New(mockApp, nil, Config{}).ServeHTTP(rr, upload("file", pdf))
+
+			assert.Equal(t, status, rr.Code, err)
+		}
+	})
+}
+
+func TestSessionHandler_DownloadAttachment(t *testing.T) {
+	sessionId, attachmentId := uuid.New(), uuid.New()
+	url := "/v1/sessions/" + sessionId.String() + "/attachments/" + attachmentId.String()
+
+	t.Run("sends the content", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().GetAttachment(gomock.Any(), usecase.CmdGetAttachment{SessionId: sessionId.String(), AttachmentId: attachmentId.String()}).Return(&usecase.AttachmentContent{
+			Attachment: model.Attachment{Id: attachmentId, FileName: "dismissal.pdf", ContentType: "application/pdf"},
+			Data:       []byte("%PDF-1.4"),
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="dismissal.pdf"`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "%PDF-1.4", rr.Body.String())
+	})
+
+	t.Run("returns 404 for an unknown attachment", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().GetAttachment(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrNotFound)
+
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// TemplatePresenter represents a DAG template without its content
+//
+// @Description Reusable snapshot of a DAG, instantiated into new DAGs
+type TemplatePresenter struct {
+	Id          uuid.UUID `json:"id" example:"c3d4e5f6-a7b8-4c9d-8e0f-1a2b3c4d5e6f" description:"Template unique identifier"`
+	Name        string    `json:"name" example:"Employment dismissal" description:"Template name"`
+	Description string    `json:"description,omitempty" example:"Intake questions for unfair dismissal claims" description:"Template description"`
+	SourceDAGId uuid.UUID `json:"source_dag_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"DAG the template was saved from"`
+	Title       string    `json:"title" example:"Employment Discrimination Case" description:"Title of the template DAG, given to the instances by default"`
+	NodeCount   int       `json:"node_count" example:"12" description:"Number of nodes of the template DAG"`
+	CreatedAt   time.Time `json:"created_at" example:"2024-01-15T10:30:00Z" description:"When the template was saved"`
+}
+
+func NewTemplatePresenter(template *model.Template) TemplatePresenter {
+	return TemplatePresenter{
+		Id:          template.Id,
+		Name:        template.Name,
+		Description: template.Description,
+		SourceDAGId: template.SourceDAGId,
+		Title:       template.DAG.Title,
+		NodeCount:   len(template.DAG.Nodes),
+		CreatedAt:   template.CreatedAt,
+	}
+}
+
+// TemplateListPresenter represents the template library
+//
+// @Description DAG templates, ordered by name
+type TemplateListPresenter struct {
+	Templates []TemplatePresenter `json:"templates" description:"DAG templates"`
+	Count     int                 `json:"count" example:"1" description:"Number of templates"`
+}
+
+// SaveAsTemplateRequest represents the request payload for saving a DAG as a template
+//
+// @Description Name and description of the template
+type SaveAsTemplateRequest struct {
+	Name        string `json:"name" example:"Employment dismissal" description:"Template name, at most 200 characters"`
+	Description string `json:"description,omitempty" example:"Intake questions for unfair dismissal claims" description:"Template description, at most 2000 characters"`
+}
+
+// InstantiateTemplateRequest represents the request payload for creating a DAG from a template
+//
+// @Description Options of the DAG created from a template
+type InstantiateTemplateRequest struct {
+	Title string `json:"title,omitempty" example:"Smith v. Acme Corp" description:"Title of the new DAG, the title of the template DAG when omitted"`
+}
+
+type templateHandler struct {
+	app App
+}
+
+func NewTemplateHandler(app App) *templateHandler {
+	return &templateHandler{
+		app: app,
+	}
+}
+
+// SaveAsTemplate snapshots a DAG into the template library
+//
+// @Summary Save DAG as template
+// @Description Snapshot the current structure of a DAG into a new template. Later changes to the DAG are not reflected in the template.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param dagId path string true "DAG unique identifier (UUID)"
+// @Param template body SaveAsTemplateRequest true "Template name and description"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 201 {object} TemplatePresenter "Successfully saved template"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid DAG ID format, request body or template name"
+// @Failure 404 {object} xhttp.ErrorResponse "DAG not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /dags/{dagId}/save-as-template [post]
+func (h *templateHandler) SaveAsTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var templateRequest SaveAsTemplateRequest
+	if err := decodeRequestBody(r, &templateRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode template request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	template, err := h.app.SaveAsTemplate(ctx, usecase.CmdSaveAsTemplate{
+		DAGId:       mux.Vars(r)[dagId],
+		Name:        templateRequest.Name,
+		Description: templateRequest.Description,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to save DAG as template")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid template request", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "DAG not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to save DAG as template", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewTemplatePresenter(template))
+}
+
+// List lists the template library
+//
+// @Summary List templates
+// @Description List the DAG templates, ordered by name. The template DAGs are summarized by their title and node count.
+// @Tags Templates
+// @Produce json
+// @Success 200 {object} TemplateListPresenter "DAG templates"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /templates [get]
+func (h *templateHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templates, err := h.app.ListTemplates(ctx)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to list templates")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list templates", err)
+		return
+	}
+
+	presenters := make([]TemplatePresenter, 0, len(templates))
+	for i := range templates {
+		presenters = append(presenters, NewTemplatePresenter(&templates[i]))
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, TemplateListPresenter{Templates: presenters, Count: len(presenters)})
+}
+
+// Instantiate creates a DAG from a template
+//
+// @Summary Instantiate template
+// @Description Create a new DAG with the structure of a template. The DAG, its nodes and its answers get fresh IDs while the links between nodes are preserved. The DAG is validated and announced like any created DAG.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template unique identifier (UUID)"
+// @Param options body InstantiateTemplateRequest false "Options of the new DAG"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 201 {object} DAGPresenter "Successfully created DAG"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid template ID format, request body or title, or the template DAG is invalid"
+// @Failure 404 {object} xhttp.ErrorResponse "Template not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /templates/{templateId}/instantiate [post]
+func (h *templateHandler) Instantiate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// The options are optional, an empty body instantiates the template as is
+	var instantiateRequest InstantiateTemplateRequest
+	if err := decodeRequestBody(r, &instantiateRequest); err != nil && !errors.Is(err, io.EOF) {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode instantiate request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	dag, err := h.app.InstantiateTemplate(ctx, usecase.CmdInstantiateTemplate{
+		TemplateId: mux.Vars(r)[templateId],
+		Title:      instantiateRequest.Title,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to instantiate template")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid instantiate request", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "template not found", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to instantiate template", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, NewDAGPresenter(dag))
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateHandler(t *testing.T) {
+	source := dagtest.ValidSingleRoot()
+	template := model.NewTemplate("Employment", "Employment dispute intake", source, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+
+	serve := func(mockApp *mocks.MockApp, method, target, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rr
+	}
+
+	t.Run("saves a DAG as template", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().SaveAsTemplate(gomock.Any(), usecase.CmdSaveAsTemplate{
+			DAGId:       source.Id.String(),
+			Name:        "Employment",
+			Description: "Employment dispute intake",
+		}).Return(&template, nil)
+
+		rr := serve(mockApp, http.MethodPost, "/v1/dags/"+source.Id.String()+"/save-as-template", `{"name":"Employment","description":"Employment dispute intake"}`)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var response TemplatePresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, template.Id, response.Id)
+		assert.Equal(t, source.Id, response.SourceDAGId)
+		assert.Equal(t, len(source.Nodes), response.NodeCount)
+	})
+
+	t.Run("maps the save errors to statuses", func(t *testing.T) {
+		for _, tt := range []struct {
+			err            error
+			expectedStatus int
+		}{
+			{usecase.ErrInvalidCommand, http.StatusBadRequest},
+			{usecase.ErrNotFound, http.StatusNotFound},
+			{usecase.ErrInternal, http.StatusInternalServerError},
+		} {
+			mockApp := mocks.NewMockApp(gomock.NewController(t))
+			mockApp.EXPECT().SaveAsTemplate(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+
+			rr := serve(mockApp, http.MethodPost, "/v1/dags/"+source.Id.String()+"/save-as-template", `{"name":"Employment"}`)
+			assert.Equal(t, tt.expectedStatus, rr.Code, tt.err)
+		}
+
+		rr := serve(mocks.NewMockApp(gomock.NewController(t)), http.MethodPost, "/v1/dags/"+source.Id.String()+"/save-as-template", `{`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("lists templates without their DAG", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().ListTemplates(gomock.Any()).Return([]model.Template{template}, nil)
+
+		rr := serve(mockApp, http.MethodGet, "/v1/templates", "")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list TemplateListPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		assert.Equal(t, 1, list.Count)
+		assert.Equal(t, "Employment", list.Templates[0].Name)
+		assert.NotContains(t, rr.Body.String(), "nodes")
+	})
+
+	t.Run("instantiates a template", func(t *testing.T) {
+		instance := template.Instantiate("Smith v. Acme")
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().InstantiateTemplate(gomock.Any(), usecase.CmdInstantiateTemplate{
+			TemplateId: template.Id.String(),
+			Title:      "Smith v. Acme",
+		}).Return(instance, nil)
+		mockApp.EXPECT().InstantiateTemplate(gomock.Any(), usecase.CmdInstantiateTemplate{
+			TemplateId: template.Id.String(),
+		}).Return(instance, nil)
+
+		rr := serve(mockApp, http.MethodPost, "/v1/templates/"+template.Id.String()+"/instantiate", `{"title":"Smith v. Acme"}`)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var response DAGPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, instance.Id, response.Id)
+
+		// The options are optional
+		rr = serve(mockApp, http.MethodPost, "/v1/templates/"+template.Id.String()+"/instantiate", "")
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("reports missing templates", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().InstantiateTemplate(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: template not found", usecase.ErrNotFound))
+
+		rr := serve(mockApp, http.MethodPost, "/v1/templates/"+template.Id.String()+"/instantiate", "{}")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("lets viewers browse the library but not change it", func(t *testing.T) {
+		authFn := xhttp.StaticUsersFn([]auth.StaticUser{{Username: "viewer", Password: "secret", Role: user.RoleViewer}})
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().ListTemplates(gomock.Any()).Return([]model.Template{}, nil)
+
+		for target, expectedStatus := range map[string]int{
+			"GET /v1/templates": http.StatusOK,
+			"POST /v1/templates/" + template.Id.String() + "/instantiate": http.StatusForbidden,
+			"POST /v1/dags/" + source.Id.String() + "/save-as-template":   http.StatusForbidden,
+		} {
+			method, path, _ := strings.Cut(target, " ")
+			req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+			req.SetBasicAuth("viewer", "secret")
+			rr := httptest.NewRecorder()
+			New(mockApp, authFn, Config{}).ServeHTTP(rr, req)
+			assert.Equal(t, expectedStatus, rr.Code, target)
+		}
+	})
+}
@@ -37,6 +37,258 @@ func (m *MockApp) EXPECT() *MockAppMockRecorder {
 	return m.recorder
 }
 
+// AddAttachment mocks base method.
+func (m *MockApp) AddAttachment(ctx context.Context, cmd usecase.CmdAddAttachment) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", ctx, cmd)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAppMockRecorder) AddAttachment(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockApp)(nil).AddAttachment), ctx, cmd)
+}
+
+// AnswerSession mocks base method.
+func (m *MockApp) AnswerSession(ctx context.Context, cmd usecase.CmdAnswerSession) (*model.CaseSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerSession", ctx, cmd)
+	ret0, _ := ret[0].(*model.CaseSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnswerSession indicates an expected call of AnswerSession.
+func (mr *MockAppMockRecorder) AnswerSession(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerSession", reflect.TypeOf((*MockApp)(nil).AnswerSession), ctx, cmd)
+}
+
+// AssessSession mocks base method.
+func (m *MockApp) AssessSession(ctx context.Context, cmd usecase.CmdAssessSession) (*model.CaseSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssessSession", ctx, cmd)
+	ret0, _ := ret[0].(*model.CaseSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssessSession indicates an expected call of AssessSession.
+func (mr *MockAppMockRecorder) AssessSession(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssessSession", reflect.TypeOf((*MockApp)(nil).AssessSession), ctx, cmd)
+}
+
+// BuildPrompt mocks base method.
+func (m *MockApp) BuildPrompt(ctx context.Context, cmd usecase.CmdBuildPrompt) (*usecase.BuiltPrompt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildPrompt", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.BuiltPrompt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildPrompt indicates an expected call of BuildPrompt.
+func (mr *MockAppMockRecorder) BuildPrompt(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildPrompt", reflect.TypeOf((*MockApp)(nil).BuildPrompt), ctx, cmd)
+}
+
+// CheckNodeReferences mocks base method.
+func (m *MockApp) CheckNodeReferences(ctx context.Context, cmd usecase.CmdCheckNodeReferences) (*usecase.NodeReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckNodeReferences", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.NodeReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckNodeReferences indicates an expected call of CheckNodeReferences.
+func (mr *MockAppMockRecorder) CheckNodeReferences(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckNodeReferences", reflect.TypeOf((*MockApp)(nil).CheckNodeReferences), ctx, cmd)
+}
+
+// CloneDAG mocks base method.
+func (m *MockApp) CloneDAG(ctx context.Context, cmd usecase.CmdCloneDAG) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneDAG", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneDAG indicates an expected call of CloneDAG.
+func (mr *MockAppMockRecorder) CloneDAG(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneDAG", reflect.TypeOf((*MockApp)(nil).CloneDAG), ctx, cmd)
+}
+
+// CompleteSession mocks base method.
+func (m *MockApp) CompleteSession(ctx context.Context, cmd usecase.CmdCompleteSession) (*model.CaseSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSession", ctx, cmd)
+	ret0, _ := ret[0].(*model.CaseSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteSession indicates an expected call of CompleteSession.
+func (mr *MockAppMockRecorder) CompleteSession(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSession", reflect.TypeOf((*MockApp)(nil).CompleteSession), ctx, cmd)
+}
+
+// Create mocks base method.
+func (m *MockApp) Create(ctx context.Context, cmd usecase.CmdCreateDAG) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAppMockRecorder) Create(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApp)(nil).Create), ctx, cmd)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockApp) CreateWebhook(ctx context.Context, cmd usecase.CmdCreateWebhook) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, cmd)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockAppMockRecorder) CreateWebhook(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockApp)(nil).CreateWebhook), ctx, cmd)
+}
+
+// Delete mocks base method.
+func (m *MockApp) Delete(ctx context.Context, cmd usecase.CmdDeleteDAG) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAppMockRecorder) Delete(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockApp)(nil).Delete), ctx, cmd)
+}
+
+// DeleteMetadataSchema mocks base method.
+func (m *MockApp) DeleteMetadataSchema(ctx context.Context, cmd usecase.CmdDeleteMetadataSchema) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMetadataSchema", ctx, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMetadataSchema indicates an expected call of DeleteMetadataSchema.
+func (mr *MockAppMockRecorder) DeleteMetadataSchema(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetadataSchema", reflect.TypeOf((*MockApp)(nil).DeleteMetadataSchema), ctx, cmd)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockApp) DeleteWebhook(ctx context.Context, cmd usecase.CmdDeleteWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockAppMockRecorder) DeleteWebhook(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockApp)(nil).DeleteWebhook), ctx, cmd)
+}
+
+// DiffDAGs mocks base method.
+func (m *MockApp) DiffDAGs(ctx context.Context, cmd usecase.CmdDiffDAGs) (*usecase.DAGChangeSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffDAGs", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.DAGChangeSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffDAGs indicates an expected call of DiffDAGs.
+func (mr *MockAppMockRecorder) DiffDAGs(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffDAGs", reflect.TypeOf((*MockApp)(nil).DiffDAGs), ctx, cmd)
+}
+
+// EnqueueDAGValidation mocks base method.
+func (m *MockApp) EnqueueDAGValidation(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueDAGValidation", ctx, cmd)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueDAGValidation indicates an expected call of EnqueueDAGValidation.
+func (mr *MockAppMockRecorder) EnqueueDAGValidation(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueDAGValidation", reflect.TypeOf((*MockApp)(nil).EnqueueDAGValidation), ctx, cmd)
+}
+
+// EnumeratePaths mocks base method.
+func (m *MockApp) EnumeratePaths(ctx context.Context, cmd usecase.CmdEnumeratePaths) (*usecase.PathList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnumeratePaths", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.PathList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnumeratePaths indicates an expected call of EnumeratePaths.
+func (mr *MockAppMockRecorder) EnumeratePaths(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnumeratePaths", reflect.TypeOf((*MockApp)(nil).EnumeratePaths), ctx, cmd)
+}
+
+// ExportDAG mocks base method.
+func (m *MockApp) ExportDAG(ctx context.Context, cmd usecase.CmdExportDAG) (*usecase.ExportedDAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDAG", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.ExportedDAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportDAG indicates an expected call of ExportDAG.
+func (mr *MockAppMockRecorder) ExportDAG(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDAG", reflect.TypeOf((*MockApp)(nil).ExportDAG), ctx, cmd)
+}
+
+// ExtractSubtree mocks base method.
+func (m *MockApp) ExtractSubtree(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtractSubtree", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtractSubtree indicates an expected call of ExtractSubtree.
+func (mr *MockAppMockRecorder) ExtractSubtree(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractSubtree", reflect.TypeOf((*MockApp)(nil).ExtractSubtree), ctx, cmd)
+}
+
 // Get mocks base method.
 func (m *MockApp) Get(ctx context.Context, cmd usecase.CmdGetDAG) (*model.DAG, error) {
 	m.ctrl.T.Helper()
@@ -52,6 +304,171 @@ func (mr *MockAppMockRecorder) Get(ctx, cmd interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockApp)(nil).Get), ctx, cmd)
 }
 
+// GetAttachment mocks base method.
+func (m *MockApp) GetAttachment(ctx context.Context, cmd usecase.CmdGetAttachment) (*usecase.AttachmentContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.AttachmentContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAppMockRecorder) GetAttachment(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockApp)(nil).GetAttachment), ctx, cmd)
+}
+
+// GetDAGVersion mocks base method.
+func (m *MockApp) GetDAGVersion(ctx context.Context, cmd usecase.CmdGetDAGVersion) (*model.DAGVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDAGVersion", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAGVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDAGVersion indicates an expected call of GetDAGVersion.
+func (mr *MockAppMockRecorder) GetDAGVersion(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDAGVersion", reflect.TypeOf((*MockApp)(nil).GetDAGVersion), ctx, cmd)
+}
+
+// GetJob mocks base method.
+func (m *MockApp) GetJob(ctx context.Context, cmd usecase.CmdGetJob) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, cmd)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockAppMockRecorder) GetJob(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockApp)(nil).GetJob), ctx, cmd)
+}
+
+// GetMetadataSchema mocks base method.
+func (m *MockApp) GetMetadataSchema(ctx context.Context, cmd usecase.CmdGetMetadataSchema) (*model.MetadataSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadataSchema", ctx, cmd)
+	ret0, _ := ret[0].(*model.MetadataSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadataSchema indicates an expected call of GetMetadataSchema.
+func (mr *MockAppMockRecorder) GetMetadataSchema(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadataSchema", reflect.TypeOf((*MockApp)(nil).GetMetadataSchema), ctx, cmd)
+}
+
+// GetPathAnalytics mocks base method.
+func (m *MockApp) GetPathAnalytics(ctx context.Context, cmd usecase.CmdGetPathAnalytics) ([]usecase.RankedPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPathAnalytics", ctx, cmd)
+	ret0, _ := ret[0].([]usecase.RankedPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPathAnalytics indicates an expected call of GetPathAnalytics.
+func (mr *MockAppMockRecorder) GetPathAnalytics(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPathAnalytics", reflect.TypeOf((*MockApp)(nil).GetPathAnalytics), ctx, cmd)
+}
+
+// GetSession mocks base method.
+func (m *MockApp) GetSession(ctx context.Context, cmd usecase.CmdGetSession) (*model.CaseSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, cmd)
+	ret0, _ := ret[0].(*model.CaseSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockAppMockRecorder) GetSession(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockApp)(nil).GetSession), ctx, cmd)
+}
+
+// GetSessionDocument mocks base method.
+func (m *MockApp) GetSessionDocument(ctx context.Context, cmd usecase.CmdGetSessionDocument) (*usecase.SessionDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionDocument", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.SessionDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionDocument indicates an expected call of GetSessionDocument.
+func (mr *MockAppMockRecorder) GetSessionDocument(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionDocument", reflect.TypeOf((*MockApp)(nil).GetSessionDocument), ctx, cmd)
+}
+
+// GetWebhook mocks base method.
+func (m *MockApp) GetWebhook(ctx context.Context, cmd usecase.CmdGetWebhook) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, cmd)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockAppMockRecorder) GetWebhook(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockApp)(nil).GetWebhook), ctx, cmd)
+}
+
+// ImportDAG mocks base method.
+func (m *MockApp) ImportDAG(ctx context.Context, cmd usecase.CmdImportDAG) (*usecase.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportDAG", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportDAG indicates an expected call of ImportDAG.
+func (mr *MockAppMockRecorder) ImportDAG(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportDAG", reflect.TypeOf((*MockApp)(nil).ImportDAG), ctx, cmd)
+}
+
+// InsertNode mocks base method.
+func (m *MockApp) InsertNode(ctx context.Context, cmd usecase.CmdInsertNode) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNode", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNode indicates an expected call of InsertNode.
+func (mr *MockAppMockRecorder) InsertNode(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNode", reflect.TypeOf((*MockApp)(nil).InsertNode), ctx, cmd)
+}
+
+// InstantiateTemplate mocks base method.
+func (m *MockApp) InstantiateTemplate(ctx context.Context, cmd usecase.CmdInstantiateTemplate) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstantiateTemplate", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstantiateTemplate indicates an expected call of InstantiateTemplate.
+func (mr *MockAppMockRecorder) InstantiateTemplate(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantiateTemplate", reflect.TypeOf((*MockApp)(nil).InstantiateTemplate), ctx, cmd)
+}
+
 // List mocks base method.
 func (m *MockApp) List(ctx context.Context, cmd usecase.CmdListDAGs) ([]uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -67,11 +484,26 @@ func (mr *MockAppMockRecorder) List(ctx, cmd interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApp)(nil).List), ctx, cmd)
 }
 
+// ListDAGVersions mocks base method.
+func (m *MockApp) ListDAGVersions(ctx context.Context, cmd usecase.CmdListDAGVersions) ([]model.DAGVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDAGVersions", ctx, cmd)
+	ret0, _ := ret[0].([]model.DAGVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDAGVersions indicates an expected call of ListDAGVersions.
+func (mr *MockAppMockRecorder) ListDAGVersions(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDAGVersions", reflect.TypeOf((*MockApp)(nil).ListDAGVersions), ctx, cmd)
+}
+
 // ListDAGs mocks base method.
-func (m *MockApp) ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) ([]*model.DAG, error) {
+func (m *MockApp) ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) (*model.DAGPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListDAGs", ctx, cmd)
-	ret0, _ := ret[0].([]*model.DAG)
+	ret0, _ := ret[0].(*model.DAGPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -82,6 +514,276 @@ func (mr *MockAppMockRecorder) ListDAGs(ctx, cmd interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDAGs", reflect.TypeOf((*MockApp)(nil).ListDAGs), ctx, cmd)
 }
 
+// ListDeletedDAGs mocks base method.
+func (m *MockApp) ListDeletedDAGs(ctx context.Context) ([]*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedDAGs", ctx)
+	ret0, _ := ret[0].([]*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedDAGs indicates an expected call of ListDeletedDAGs.
+func (mr *MockAppMockRecorder) ListDeletedDAGs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedDAGs", reflect.TypeOf((*MockApp)(nil).ListDeletedDAGs), ctx)
+}
+
+// ListTemplates mocks base method.
+func (m *MockApp) ListTemplates(ctx context.Context) ([]model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx)
+	ret0, _ := ret[0].([]model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockAppMockRecorder) ListTemplates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockApp)(nil).ListTemplates), ctx)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockApp) ListWebhookDeliveries(ctx context.Context, cmd usecase.CmdListWebhookDeliveries) ([]model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, cmd)
+	ret0, _ := ret[0].([]model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockAppMockRecorder) ListWebhookDeliveries(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockApp)(nil).ListWebhookDeliveries), ctx, cmd)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockApp) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockAppMockRecorder) ListWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockApp)(nil).ListWebhooks), ctx)
+}
+
+// MaterializeSubtree mocks base method.
+func (m *MockApp) MaterializeSubtree(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaterializeSubtree", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaterializeSubtree indicates an expected call of MaterializeSubtree.
+func (mr *MockAppMockRecorder) MaterializeSubtree(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeSubtree", reflect.TypeOf((*MockApp)(nil).MaterializeSubtree), ctx, cmd)
+}
+
+// MergeAnswerMetadata mocks base method.
+func (m *MockApp) MergeAnswerMetadata(ctx context.Context, cmd usecase.CmdMergeAnswerMetadata) (*model.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeAnswerMetadata", ctx, cmd)
+	ret0, _ := ret[0].(*model.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeAnswerMetadata indicates an expected call of MergeAnswerMetadata.
+func (mr *MockAppMockRecorder) MergeAnswerMetadata(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeAnswerMetadata", reflect.TypeOf((*MockApp)(nil).MergeAnswerMetadata), ctx, cmd)
+}
+
+// MergeDAG mocks base method.
+func (m *MockApp) MergeDAG(ctx context.Context, cmd usecase.CmdMergeDAG) (*usecase.MergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeDAG", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.MergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeDAG indicates an expected call of MergeDAG.
+func (mr *MockAppMockRecorder) MergeDAG(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeDAG", reflect.TypeOf((*MockApp)(nil).MergeDAG), ctx, cmd)
+}
+
+// PreviewUpdate mocks base method.
+func (m *MockApp) PreviewUpdate(ctx context.Context, cmd usecase.CmdUpdateDAG) (*usecase.UpdatePreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewUpdate", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.UpdatePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewUpdate indicates an expected call of PreviewUpdate.
+func (mr *MockAppMockRecorder) PreviewUpdate(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewUpdate", reflect.TypeOf((*MockApp)(nil).PreviewUpdate), ctx, cmd)
+}
+
+// RecordWalk mocks base method.
+func (m *MockApp) RecordWalk(ctx context.Context, cmd usecase.CmdRecordWalk) (*usecase.WalkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWalk", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.WalkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordWalk indicates an expected call of RecordWalk.
+func (mr *MockAppMockRecorder) RecordWalk(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWalk", reflect.TypeOf((*MockApp)(nil).RecordWalk), ctx, cmd)
+}
+
+// RestoreDAGVersion mocks base method.
+func (m *MockApp) RestoreDAGVersion(ctx context.Context, cmd usecase.CmdRestoreDAGVersion) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDAGVersion", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreDAGVersion indicates an expected call of RestoreDAGVersion.
+func (mr *MockAppMockRecorder) RestoreDAGVersion(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDAGVersion", reflect.TypeOf((*MockApp)(nil).RestoreDAGVersion), ctx, cmd)
+}
+
+// RestoreDeletedDAG mocks base method.
+func (m *MockApp) RestoreDeletedDAG(ctx context.Context, cmd usecase.CmdRestoreDeletedDAG) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDeletedDAG", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreDeletedDAG indicates an expected call of RestoreDeletedDAG.
+func (mr *MockAppMockRecorder) RestoreDeletedDAG(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeletedDAG", reflect.TypeOf((*MockApp)(nil).RestoreDeletedDAG), ctx, cmd)
+}
+
+// ReviseSessionAnswer mocks base method.
+func (m *MockApp) ReviseSessionAnswer(ctx context.Context, cmd usecase.CmdReviseSessionAnswer) (*model.CaseSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviseSessionAnswer", ctx, cmd)
+	ret0, _ := ret[0].(*model.CaseSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviseSessionAnswer indicates an expected call of ReviseSessionAnswer.
+func (mr *MockAppMockRecorder) ReviseSessionAnswer(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviseSessionAnswer", reflect.TypeOf((*MockApp)(nil).ReviseSessionAnswer), ctx, cmd)
+}
+
+// SaveAsTemplate mocks base method.
+func (m *MockApp) SaveAsTemplate(ctx context.Context, cmd usecase.CmdSaveAsTemplate) (*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAsTemplate", ctx, cmd)
+	ret0, _ := ret[0].(*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAsTemplate indicates an expected call of SaveAsTemplate.
+func (mr *MockAppMockRecorder) SaveAsTemplate(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAsTemplate", reflect.TypeOf((*MockApp)(nil).SaveAsTemplate), ctx, cmd)
+}
+
+// ScoreSession mocks base method.
+func (m *MockApp) ScoreSession(ctx context.Context, cmd usecase.CmdScoreSession) (*model.Score, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScoreSession", ctx, cmd)
+	ret0, _ := ret[0].(*model.Score)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScoreSession indicates an expected call of ScoreSession.
+func (mr *MockAppMockRecorder) ScoreSession(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreSession", reflect.TypeOf((*MockApp)(nil).ScoreSession), ctx, cmd)
+}
+
+// SearchDAGs mocks base method.
+func (m *MockApp) SearchDAGs(ctx context.Context, cmd usecase.CmdSearchDAGs) ([]model.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchDAGs", ctx, cmd)
+	ret0, _ := ret[0].([]model.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchDAGs indicates an expected call of SearchDAGs.
+func (mr *MockAppMockRecorder) SearchDAGs(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDAGs", reflect.TypeOf((*MockApp)(nil).SearchDAGs), ctx, cmd)
+}
+
+// SetMetadataSchema mocks base method.
+func (m *MockApp) SetMetadataSchema(ctx context.Context, cmd usecase.CmdSetMetadataSchema) (*model.MetadataSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMetadataSchema", ctx, cmd)
+	ret0, _ := ret[0].(*model.MetadataSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMetadataSchema indicates an expected call of SetMetadataSchema.
+func (mr *MockAppMockRecorder) SetMetadataSchema(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadataSchema", reflect.TypeOf((*MockApp)(nil).SetMetadataSchema), ctx, cmd)
+}
+
+// SplitNode mocks base method.
+func (m *MockApp) SplitNode(ctx context.Context, cmd usecase.CmdSplitNode) (*model.DAG, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitNode", ctx, cmd)
+	ret0, _ := ret[0].(*model.DAG)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitNode indicates an expected call of SplitNode.
+func (mr *MockAppMockRecorder) SplitNode(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitNode", reflect.TypeOf((*MockApp)(nil).SplitNode), ctx, cmd)
+}
+
+// StartSession mocks base method.
+func (m *MockApp) StartSession(ctx context.Context, cmd usecase.CmdStartSession) (*model.CaseSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", ctx, cmd)
+	ret0, _ := ret[0].(*model.CaseSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MockAppMockRecorder) StartSession(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MockApp)(nil).StartSession), ctx, cmd)
+}
+
 // Update mocks base method.
 func (m *MockApp) Update(ctx context.Context, cmd usecase.CmdUpdateDAG) (*model.DAG, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +799,81 @@ func (mr *MockAppMockRecorder) Update(ctx, cmd interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApp)(nil).Update), ctx, cmd)
 }
 
+// UpdateAnswer mocks base method.
+func (m *MockApp) UpdateAnswer(ctx context.Context, cmd usecase.CmdUpdateAnswer) (*model.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnswer", ctx, cmd)
+	ret0, _ := ret[0].(*model.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnswer indicates an expected call of UpdateAnswer.
+func (mr *MockAppMockRecorder) UpdateAnswer(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnswer", reflect.TypeOf((*MockApp)(nil).UpdateAnswer), ctx, cmd)
+}
+
+// UpdateNode mocks base method.
+func (m *MockApp) UpdateNode(ctx context.Context, cmd usecase.CmdUpdateNode) (*model.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNode", ctx, cmd)
+	ret0, _ := ret[0].(*model.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNode indicates an expected call of UpdateNode.
+func (mr *MockAppMockRecorder) UpdateNode(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNode", reflect.TypeOf((*MockApp)(nil).UpdateNode), ctx, cmd)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockApp) UpdateWebhook(ctx context.Context, cmd usecase.CmdUpdateWebhook) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, cmd)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockAppMockRecorder) UpdateWebhook(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockApp)(nil).UpdateWebhook), ctx, cmd)
+}
+
+// ValidateAllDAGs mocks base method.
+func (m *MockApp) ValidateAllDAGs(ctx context.Context, cmd usecase.CmdValidateAllDAGs) (*usecase.BulkValidationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAllDAGs", ctx, cmd)
+	ret0, _ := ret[0].(*usecase.BulkValidationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAllDAGs indicates an expected call of ValidateAllDAGs.
+func (mr *MockAppMockRecorder) ValidateAllDAGs(ctx, cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAllDAGs", reflect.TypeOf((*MockApp)(nil).ValidateAllDAGs), ctx, cmd)
+}
+
+// ValidateDAG mocks base method.
+func (m *MockApp) ValidateDAG(ctx context.Context, d *model.DAG, rules map[string]usecase.Severity) (usecase.ValidationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDAG", ctx, d, rules)
+	ret0, _ := ret[0].(usecase.ValidationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDAG indicates an expected call of ValidateDAG.
+func (mr *MockAppMockRecorder) ValidateDAG(ctx, d, rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDAG", reflect.TypeOf((*MockApp)(nil).ValidateDAG), ctx, d, rules)
+}
+
 // ValidateStoredDAG mocks base method.
 func (m *MockApp) ValidateStoredDAG(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*usecase.ValidationResult, error) {
 	m.ctrl.T.Helper()
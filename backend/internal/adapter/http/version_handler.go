@@ -0,0 +1,45 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/pkg/buildinfo"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"net/http"
+)
+
+// VersionPresenter represents the build information of the running server
+//
+// @Description Version and build information of the running server
+// @Example {"version": "1.2.0", "commit": "a1b2c3d", "build_date": "2024-01-15T10:30:00Z", "go_version": "go1.24.0"}
+type VersionPresenter struct {
+	Version   string `json:"version" example:"1.2.0" description:"Release version"`
+	Commit    string `json:"commit" example:"a1b2c3d" description:"Source commit the binary was built from"`
+	BuildDate string `json:"build_date" example:"2024-01-15T10:30:00Z" description:"Date the binary was built"`
+	GoVersion string `json:"go_version" example:"go1.24.0" description:"Go toolchain version"`
+}
+
+type versionHandler struct {
+	info buildinfo.Info
+}
+
+func NewVersionHandler(info buildinfo.Info) *versionHandler {
+	return &versionHandler{
+		info: info,
+	}
+}
+
+// Get returns the build information of the running server
+//
+// @Summary Get server version
+// @Description Retrieve the version, commit and build date of the running server
+// @Tags Version
+// @Produce json
+// @Success 200 {object} VersionPresenter "Successfully retrieved version information"
+// @Router /version [get]
+func (h *versionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	xhttp.WriteObject(r.Context(), w, http.StatusOK, VersionPresenter{
+		Version:   h.info.Version,
+		Commit:    h.info.Commit,
+		BuildDate: h.info.BuildDate,
+		GoVersion: h.info.GoVersion,
+	})
+}
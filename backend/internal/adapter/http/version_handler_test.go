@@ -0,0 +1,59 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/pkg/buildinfo"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionHandler_Get(t *testing.T) {
+	handler := NewVersionHandler(buildinfo.Info{
+		Version:   "1.2.0",
+		Commit:    "a1b2c3d",
+		BuildDate: "2024-01-15T10:30:00Z",
+		GoVersion: "go1.24.0",
+	})
+
+	req, err := http.NewRequest("GET", "/v1/version", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.Get(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response VersionPresenter
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, VersionPresenter{
+		Version:   "1.2.0",
+		Commit:    "a1b2c3d",
+		BuildDate: "2024-01-15T10:30:00Z",
+		GoVersion: "go1.24.0",
+	}, response)
+}
+
+func TestRouter_VersionEndpoint(t *testing.T) {
+	router := New(nil, nil, Config{})
+
+	req, err := http.NewRequest("GET", "/v1/version", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response VersionPresenter
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, buildinfo.Get(), buildinfo.Info{
+		Version:   response.Version,
+		Commit:    response.Commit,
+		BuildDate: response.BuildDate,
+		GoVersion: response.GoVersion,
+	})
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_WalkConcurrencyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hotDAG := dagtest.ValidSingleRoot()
+	paths, err := hotDAG.EnumeratePaths()
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	mockApp := mocks.NewMockApp(ctrl)
+	mockApp.EXPECT().RecordWalk(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, cmd usecase.CmdRecordWalk) (*usecase.WalkResult, error) {
+			if cmd.DAGId == hotDAG.Id.String() {
+				started <- struct{}{}
+				<-release
+			}
+			return &usecase.WalkResult{Path: paths[0]}, nil
+		},
+	).Times(3)
+
+	router := New(mockApp, nil, Config{MaxConcurrentWalks: 1})
+	walk := func(dagID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/dags/"+dagID+"/walk-complete", strings.NewReader(`{"answer_ids": []}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Saturate the limit of the hot DAG with a walk blocked in the application
+	var wg sync.WaitGroup
+	var first *httptest.ResponseRecorder
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		first = walk(hotDAG.Id.String())
+	}()
+	<-started
+
+	throttled := walk(hotDAG.Id.String())
+	assert.Equal(t, http.StatusServiceUnavailable, throttled.Code)
+	assert.Equal(t, "1", throttled.Header().Get("Retry-After"))
+	assert.Contains(t, throttled.Body.String(), "too many concurrent requests")
+
+	// Other DAGs are not throttled
+	assert.Equal(t, http.StatusCreated, walk(uuid.New().String()).Code)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	// The slot is released once the walk completes
+	go func() { <-started }()
+	assert.Equal(t, http.StatusCreated, walk(hotDAG.Id.String()).Code)
+}
@@ -0,0 +1,357 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xhttp"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// WebhookPresenter represents a registered webhook
+//
+// @Description Endpoint of an external system notified of DAG and session events
+type WebhookPresenter struct {
+	Id        uuid.UUID `json:"id" example:"d4e5f6a7-b8c9-4d0e-8f1a-2b3c4d5e6f70" description:"Webhook unique identifier"`
+	URL       string    `json:"url" example:"https://cases.example.com/hooks/jurigen" description:"Endpoint the events are posted to"`
+	Events    []string  `json:"events" example:"dag.created,session.completed" description:"Subscribed event types, every event type when empty"`
+	Secret    string    `json:"secret,omitempty" example:"5f2b...e91c" description:"Secret signing the payloads, only returned when the webhook is created or its secret rotated"`
+	Active    bool      `json:"active" example:"true" description:"Whether events are delivered to the webhook"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z" description:"When the webhook was registered"`
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z" description:"When the webhook was last changed"`
+}
+
+func NewWebhookPresenter(webhook *model.Webhook) WebhookPresenter {
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+
+	return WebhookPresenter{
+		Id:        webhook.Id,
+		URL:       webhook.URL,
+		Events:    events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// WebhookListPresenter represents the registered webhooks
+//
+// @Description Registered webhooks, ordered by creation date
+type WebhookListPresenter struct {
+	Webhooks []WebhookPresenter `json:"webhooks" description:"Registered webhooks"`
+	Count    int                `json:"count" example:"1" description:"Number of webhooks"`
+}
+
+// WebhookDeliveryPresenter represents the delivery of an event to a webhook
+//
+// @Description Attempts to deliver an event to a webhook
+type WebhookDeliveryPresenter struct {
+	Id            uuid.UUID      `json:"id" example:"e5f6a7b8-c9d0-4e1f-8a2b-3c4d5e6f7081" description:"Delivery unique identifier, sent in the X-Jurigen-Delivery header"`
+	WebhookId     uuid.UUID      `json:"webhook_id" example:"d4e5f6a7-b8c9-4d0e-8f1a-2b3c4d5e6f70" description:"Webhook the event is delivered to"`
+	Event         EventPresenter `json:"event" description:"Delivered event, as posted to the webhook"`
+	Status        string         `json:"status" example:"succeeded" enums:"pending,succeeded,failed" description:"Progress of the delivery"`
+	Attempts      int            `json:"attempts" example:"1" description:"Number of attempts made"`
+	StatusCode    int            `json:"status_code,omitempty" example:"200" description:"HTTP status returned by the last attempt"`
+	LastError     string         `json:"last_error,omitempty" example:"webhook responded with status 503" description:"Error of the last failed attempt"`
+	NextAttemptAt *time.Time     `json:"next_attempt_at,omitempty" example:"2024-01-15T10:30:02Z" description:"When the next attempt is made, while a failed attempt waits to be retried"`
+	CreatedAt     time.Time      `json:"created_at" example:"2024-01-15T10:30:00Z" description:"When the event was published"`
+	UpdatedAt     time.Time      `json:"updated_at" example:"2024-01-15T10:30:01Z" description:"When the delivery last changed"`
+}
+
+// EventPresenter represents an event notified to webhooks and DAG event streams
+//
+// @Description Event posted to webhooks, signed in the X-Jurigen-Signature header, and streamed to the DAG event subscribers
+type EventPresenter struct {
+	Id         uuid.UUID              `json:"id" example:"f6a7b8c9-d0e1-4f2a-8b3c-4d5e6f708192" description:"Event unique identifier"`
+	Type       string                 `json:"type" example:"session.completed" enums:"dag.created,dag.updated,dag.deleted,dag.restored,dag.validated,session.completed" description:"Event type"`
+	OccurredAt time.Time              `json:"occurred_at" example:"2024-01-15T10:30:00Z" description:"When the event occurred"`
+	DAGId      uuid.UUID              `json:"dag_id" example:"8b007ce4-b676-5fb3-9f93-f5f6c41cb655" description:"DAG the event is about"`
+	SessionId  *uuid.UUID             `json:"session_id,omitempty" example:"a1b2c3d4-e5f6-7890-abcd-ef1234567890" description:"Case session the event is about, on session events"`
+	Data       map[string]interface{} `json:"data,omitempty" description:"Details specific to the event type"`
+}
+
+func NewWebhookDeliveryPresenter(delivery model.WebhookDelivery) WebhookDeliveryPresenter {
+	return WebhookDeliveryPresenter{
+		Id:            delivery.Id,
+		WebhookId:     delivery.WebhookId,
+		Event:         EventPresenter(delivery.Event),
+		Status:        string(delivery.Status),
+		Attempts:      delivery.Attempts,
+		StatusCode:    delivery.StatusCode,
+		LastError:     delivery.LastError,
+		NextAttemptAt: delivery.NextAttemptAt,
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedAt:     delivery.UpdatedAt,
+	}
+}
+
+// WebhookDeliveryListPresenter represents the latest deliveries of a webhook
+//
+// @Description Latest deliveries of a webhook, most recent first
+type WebhookDeliveryListPresenter struct {
+	Deliveries []WebhookDeliveryPresenter `json:"deliveries" description:"Latest deliveries"`
+	Count      int                        `json:"count" example:"1" description:"Number of deliveries returned"`
+}
+
+// WebhookRequest represents the request payload for registering or updating a webhook
+//
+// @Description Webhook endpoint and subscriptions
+type WebhookRequest struct {
+	URL    string   `json:"url" example:"https://cases.example.com/hooks/jurigen" description:"Absolute http or https endpoint the events are posted to"`
+	Events []string `json:"events,omitempty" example:"dag.created,session.completed" description:"Event types to subscribe to among dag.created, dag.updated, dag.deleted, dag.restored, dag.validated and session.completed, every event type when omitted"`
+	Secret string   `json:"secret,omitempty" example:"my-shared-secret-value" description:"Secret signing the payloads, at least 16 characters. Generated on registration and kept on update when omitted"`
+	Active *bool    `json:"active,omitempty" example:"true" description:"Whether events are delivered, true on registration and kept on update when omitted"`
+}
+
+type webhookHandler struct {
+	app App
+}
+
+func NewWebhookHandler(app App) *webhookHandler {
+	return &webhookHandler{
+		app: app,
+	}
+}
+
+// Create registers a webhook
+//
+// @Summary Register webhook
+// @Description Register an endpoint notified of DAG and session events. Events are posted as JSON with the X-Jurigen-Event, X-Jurigen-Delivery and X-Jurigen-Timestamp headers, and X-Jurigen-Signature holding "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret. The secret is only returned in this response.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body WebhookRequest true "Webhook endpoint and subscriptions"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 201 {object} WebhookPresenter "Successfully registered webhook, along with its secret"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid request body, URL, event type or secret"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /webhooks [post]
+func (h *webhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var webhookRequest WebhookRequest
+	if err := decodeRequestBody(r, &webhookRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode webhook request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	webhook, err := h.app.CreateWebhook(ctx, usecase.CmdCreateWebhook{
+		URL:    webhookRequest.URL,
+		Events: webhookRequest.Events,
+		Secret: webhookRequest.Secret,
+		Active: webhookRequest.Active,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to create webhook")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid webhook", err)
+		default:
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to create webhook", err)
+		}
+		return
+	}
+
+	presenter := NewWebhookPresenter(webhook)
+	presenter.Secret = webhook.Secret
+	xhttp.WriteObject(ctx, w, http.StatusCreated, presenter)
+}
+
+// List lists the registered webhooks
+//
+// @Summary List webhooks
+// @Description List the registered webhooks, ordered by creation date. Secrets are not returned.
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {object} WebhookListPresenter "Registered webhooks"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /webhooks [get]
+func (h *webhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhooks, err := h.app.ListWebhooks(ctx)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to list webhooks")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list webhooks", err)
+		return
+	}
+
+	presenters := make([]WebhookPresenter, 0, len(webhooks))
+	for i := range webhooks {
+		presenters = append(presenters, NewWebhookPresenter(&webhooks[i]))
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, WebhookListPresenter{Webhooks: presenters, Count: len(presenters)})
+}
+
+// Get returns a webhook
+//
+// @Summary Get webhook
+// @Description Get a registered webhook. Its secret is not returned.
+// @Tags Webhooks
+// @Produce json
+// @Param webhookId path string true "Webhook unique identifier (UUID)"
+// @Success 200 {object} WebhookPresenter "Webhook"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid webhook ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Webhook not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /webhooks/{webhookId} [get]
+func (h *webhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhook, err := h.app.GetWebhook(ctx, usecase.CmdGetWebhook{
+		WebhookId: mux.Vars(r)[webhookId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to get webhook")
+		writeWebhookError(w, r, err, "failed to get webhook")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, NewWebhookPresenter(webhook))
+}
+
+// Update replaces the endpoint and subscriptions of a webhook
+//
+// @Summary Update webhook
+// @Description Replace the URL and subscribed events of a webhook. The secret is rotated when given, and returned in the response only then. Pending retries use the updated webhook.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param webhookId path string true "Webhook unique identifier (UUID)"
+// @Param webhook body WebhookRequest true "Webhook endpoint and subscriptions"
+// @Param strict query bool false "Reject request bodies containing unknown fields"
+// @Success 200 {object} WebhookPresenter "Successfully updated webhook"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid webhook ID format, request body, URL, event type or secret"
+// @Failure 404 {object} xhttp.ErrorResponse "Webhook not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /webhooks/{webhookId} [put]
+func (h *webhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var webhookRequest WebhookRequest
+	if err := decodeRequestBody(r, &webhookRequest); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to decode webhook request body")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	webhook, err := h.app.UpdateWebhook(ctx, usecase.CmdUpdateWebhook{
+		WebhookId: mux.Vars(r)[webhookId],
+		URL:       webhookRequest.URL,
+		Events:    webhookRequest.Events,
+		Secret:    webhookRequest.Secret,
+		Active:    webhookRequest.Active,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to update webhook")
+		writeWebhookError(w, r, err, "failed to update webhook")
+		return
+	}
+
+	presenter := NewWebhookPresenter(webhook)
+	if webhookRequest.Secret != "" {
+		presenter.Secret = webhook.Secret
+	}
+	xhttp.WriteObject(ctx, w, http.StatusOK, presenter)
+}
+
+// Delete unregisters a webhook
+//
+// @Summary Delete webhook
+// @Description Unregister a webhook along with its delivery history, pending retries are abandoned
+// @Tags Webhooks
+// @Param webhookId path string true "Webhook unique identifier (UUID)"
+// @Success 204 "Successfully deleted webhook"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid webhook ID format"
+// @Failure 404 {object} xhttp.ErrorResponse "Webhook not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /webhooks/{webhookId} [delete]
+func (h *webhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := h.app.DeleteWebhook(ctx, usecase.CmdDeleteWebhook{
+		WebhookId: mux.Vars(r)[webhookId],
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to delete webhook")
+		writeWebhookError(w, r, err, "failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries lists the latest deliveries of a webhook
+//
+// @Summary List webhook deliveries
+// @Description List the latest deliveries of a webhook with their status, attempts and last error, most recent first. The delivery history is kept in memory and lost on restart.
+// @Tags Webhooks
+// @Produce json
+// @Param webhookId path string true "Webhook unique identifier (UUID)"
+// @Param limit query int false "Maximum number of deliveries to return, 20 when omitted, at most 100"
+// @Success 200 {object} WebhookDeliveryListPresenter "Latest deliveries"
+// @Failure 400 {object} xhttp.ErrorResponse "Invalid webhook ID format or limit"
+// @Failure 404 {object} xhttp.ErrorResponse "Webhook not found"
+// @Failure 500 {object} xhttp.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /webhooks/{webhookId}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+	}
+
+	deliveries, err := h.app.ListWebhookDeliveries(ctx, usecase.CmdListWebhookDeliveries{
+		WebhookId: mux.Vars(r)[webhookId],
+		Limit:     limit,
+	})
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to list webhook deliveries")
+		writeWebhookError(w, r, err, "failed to list webhook deliveries")
+		return
+	}
+
+	presenters := make([]WebhookDeliveryPresenter, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		presenters = append(presenters, NewWebhookDeliveryPresenter(delivery))
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, WebhookDeliveryListPresenter{Deliveries: presenters, Count: len(presenters)})
+}
+
+// writeWebhookError maps the errors of the use cases addressing a webhook to statuses
+func writeWebhookError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	ctx := r.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid webhook request", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "webhook not found", err)
+	default:
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, message, err)
+	}
+}
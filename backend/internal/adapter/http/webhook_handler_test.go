@@ -0,0 +1,142 @@
+package http
+
+import (
+	"davidterranova/jurigen/backend/internal/adapter/http/testdata/mocks"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	webhook := &model.Webhook{
+		Id:        uuid.New(),
+		URL:       "https://cases.example.com/hooks",
+		Events:    []string{model.EventSessionCompleted},
+		Secret:    "0123456789abcdef",
+		Active:    true,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+
+	serve := func(mockApp *mocks.MockApp, method, target, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		New(mockApp, nil, Config{}).ServeHTTP(rr, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rr
+	}
+
+	t.Run("registers a webhook and returns its secret", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().CreateWebhook(gomock.Any(), usecase.CmdCreateWebhook{
+			URL:    webhook.URL,
+			Events: []string{model.EventSessionCompleted},
+		}).Return(webhook, nil)
+
+		rr := serve(mockApp, http.MethodPost, "/v1/webhooks", `{"url":"https://cases.example.com/hooks","events":["session.completed"]}`)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var response WebhookPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, webhook.Id, response.Id)
+		assert.Equal(t, webhook.Secret, response.Secret)
+		assert.Equal(t, []string{model.EventSessionCompleted}, response.Events)
+	})
+
+	t.Run("rejects invalid webhooks", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidCommand)
+
+		rr := serve(mockApp, http.MethodPost, "/v1/webhooks", `{"url":"ftp://example.com"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(mockApp, http.MethodPost, "/v1/webhooks", `{`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("lists and gets webhooks without their secret", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().ListWebhooks(gomock.Any()).Return([]model.Webhook{*webhook, {Id: uuid.New()}}, nil)
+		mockApp.EXPECT().GetWebhook(gomock.Any(), usecase.CmdGetWebhook{WebhookId: webhook.Id.String()}).Return(webhook, nil)
+
+		rr := serve(mockApp, http.MethodGet, "/v1/webhooks", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), webhook.Secret)
+		var list WebhookListPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		assert.Equal(t, 2, list.Count)
+		assert.Equal(t, []string{}, list.Webhooks[1].Events)
+
+		rr = serve(mockApp, http.MethodGet, "/v1/webhooks/"+webhook.Id.String(), "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), webhook.Secret)
+	})
+
+	t.Run("returns the secret of an update only when rotated", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().UpdateWebhook(gomock.Any(), usecase.CmdUpdateWebhook{
+			WebhookId: webhook.Id.String(),
+			URL:       webhook.URL,
+		}).Return(webhook, nil)
+		mockApp.EXPECT().UpdateWebhook(gomock.Any(), usecase.CmdUpdateWebhook{
+			WebhookId: webhook.Id.String(),
+			URL:       webhook.URL,
+			Secret:    webhook.Secret,
+		}).Return(webhook, nil)
+
+		rr := serve(mockApp, http.MethodPut, "/v1/webhooks/"+webhook.Id.String(), `{"url":"https://cases.example.com/hooks"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), webhook.Secret)
+
+		rr = serve(mockApp, http.MethodPut, "/v1/webhooks/"+webhook.Id.String(), `{"url":"https://cases.example.com/hooks","secret":"0123456789abcdef"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), webhook.Secret)
+	})
+
+	t.Run("deletes webhooks", func(t *testing.T) {
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().DeleteWebhook(gomock.Any(), usecase.CmdDeleteWebhook{WebhookId: webhook.Id.String()}).Return(nil)
+		mockApp.EXPECT().DeleteWebhook(gomock.Any(), gomock.Any()).Return(usecase.ErrNotFound)
+
+		rr := serve(mockApp, http.MethodDelete, "/v1/webhooks/"+webhook.Id.String(), "")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = serve(mockApp, http.MethodDelete, "/v1/webhooks/"+uuid.NewString(), "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("lists the deliveries of a webhook", func(t *testing.T) {
+		delivery := model.NewWebhookDelivery(webhook.Id, model.NewEvent(model.EventDAGCreated, uuid.New(), createdAt), createdAt)
+		delivery.Status = model.DeliveryStatusFailed
+		delivery.Attempts = 5
+		delivery.StatusCode = http.StatusServiceUnavailable
+
+		mockApp := mocks.NewMockApp(gomock.NewController(t))
+		mockApp.EXPECT().ListWebhookDeliveries(gomock.Any(), usecase.CmdListWebhookDeliveries{
+			WebhookId: webhook.Id.String(),
+			Limit:     5,
+		}).Return([]model.WebhookDelivery{delivery}, nil)
+
+		rr := serve(mockApp, http.MethodGet, "/v1/webhooks/"+webhook.Id.String()+"/deliveries?limit=5", "")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response WebhookDeliveryListPresenter
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Equal(t, 1, response.Count)
+		assert.Equal(t, "failed", response.Deliveries[0].Status)
+		assert.Equal(t, 5, response.Deliveries[0].Attempts)
+		assert.Equal(t, model.EventDAGCreated, response.Deliveries[0].Event.Type)
+
+		rr = serve(mockApp, http.MethodGet, "/v1/webhooks/"+webhook.Id.String()+"/deliveries?limit=many", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package llm
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	anthropicBaseURL = "https://api.anthropic.com"
+	anthropicVersion = "2023-06-01"
+	// anthropicMaxTokens bounds the length of assessments, the messages API requires a bound
+	anthropicMaxTokens = 4096
+)
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+// anthropic talks to the Anthropic messages API
+type anthropic struct {
+	client  *http.Client
+	baseURL string
+	model   string
+	apiKey  string
+}
+
+func newAnthropic(client *http.Client, baseURL, model, apiKey string) *anthropic {
+	return &anthropic{
+		client:  client,
+		baseURL: baseURL,
+		model:   model,
+		apiKey:  apiKey,
+	}
+}
+
+func (p *anthropic) Name() string  { return ProviderAnthropic }
+func (p *anthropic) Model() string { return p.model }
+
+func (p *anthropic) Complete(ctx context.Context, messages []llmprompt.Message) (string, error) {
+	// The messages API takes the system prompt apart from the conversation
+	request := anthropicRequest{Model: p.model, MaxTokens: anthropicMaxTokens}
+	var system []string
+	for _, message := range messages {
+		if message.Role == llmprompt.RoleSystem {
+			system = append(system, message.Content)
+			continue
+		}
+		request.Messages = append(request.Messages, anthropicMessage(message))
+	}
+	request.System = strings.Join(system, "\n\n")
+
+	var response anthropicResponse
+	err := postJSON(ctx, p.client, p.baseURL+"/v1/messages", map[string]string{
+		"x-api-key":         p.apiKey,
+		"anthropic-version": anthropicVersion,
+	}, request, &response)
+	if err != nil {
+		return "", err
+	}
+
+	var text []string
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			text = append(text, block.Text)
+		}
+	}
+	if len(text) == 0 {
+		return "", errors.New("message has no text content")
+	}
+	return strings.Join(text, ""), nil
+}
@@ -0,0 +1,131 @@
+// Package llm sends prompts to language model providers over their HTTP APIs.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// ProviderOpenAI is any API compatible with the OpenAI chat completions API
+	ProviderOpenAI = "openai"
+	// ProviderAnthropic is the Anthropic messages API
+	ProviderAnthropic = "anthropic"
+	// ProviderLocal is a model served locally through the Ollama chat API
+	ProviderLocal = "local"
+)
+
+const (
+	defaultTimeout = 2 * time.Minute
+	// errorBodyLimit is the number of bytes of an error response kept in errors
+	errorBodyLimit = 512
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown LLM provider")
+	ErrMissingConfig   = errors.New("missing LLM provider configuration")
+)
+
+// Provider completes prompts with a language model
+type Provider interface {
+	// Name is the provider kind, one of the Provider constants
+	Name() string
+	// Model is the language model the prompts are sent to
+	Model() string
+	// Complete returns the answer of the model to the messages
+	Complete(ctx context.Context, messages []llmprompt.Message) (string, error)
+}
+
+// Config selects and configures a provider
+type Config struct {
+	Provider string
+	Model    string
+	APIKey   string
+	// BaseURL overrides the default API endpoint of the provider, e.g. for a proxy or a compatible API
+	BaseURL string
+	// Timeout bounds each completion, defaults to two minutes
+	Timeout time.Duration
+}
+
+// Providers lists the supported provider kinds
+func Providers() []string {
+	return []string{ProviderOpenAI, ProviderAnthropic, ProviderLocal}
+}
+
+// New returns the provider configured by cfg
+func New(cfg Config) (Provider, error) {
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("%w: model is required", ErrMissingConfig)
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	switch cfg.Provider {
+	case ProviderOpenAI:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("%w: API key is required by the %s provider", ErrMissingConfig, cfg.Provider)
+		}
+		return newOpenAI(client, baseURL(cfg.BaseURL, openAIBaseURL), cfg.Model, cfg.APIKey), nil
+	case ProviderAnthropic:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("%w: API key is required by the %s provider", ErrMissingConfig, cfg.Provider)
+		}
+		return newAnthropic(client, baseURL(cfg.BaseURL, anthropicBaseURL), cfg.Model, cfg.APIKey), nil
+	case ProviderLocal:
+		return newLocal(client, baseURL(cfg.BaseURL, localBaseURL), cfg.Model), nil
+	default:
+		return nil, fmt.Errorf("%w: %q, expected one of %s", ErrUnknownProvider, cfg.Provider, strings.Join(Providers(), ", "))
+	}
+}
+
+func baseURL(configured, fallback string) string {
+	if configured == "" {
+		return fallback
+	}
+	return strings.TrimSuffix(configured, "/")
+}
+
+// postJSON sends request as JSON and decodes the JSON response into response, non 2xx statuses are errors
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, request, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+		return fmt.Errorf("request to %s failed with status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package llm
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var messages = []llmprompt.Message{
+	{Role: llmprompt.RoleSystem, Content: "You are a legal assistant."},
+	{Role: llmprompt.RoleUser, Content: "Was the dismissal fair?"},
+}
+
+// fakeServer answers requests to path with response, recording the request body and headers
+func fakeServer(t *testing.T, path string, response any) (*httptest.Server, *map[string]any, *http.Header) {
+	t.Helper()
+
+	var body map[string]any
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, path, r.URL.Path)
+		headers = r.Header.Clone()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &body, &headers
+}
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config Config
+		err    error
+	}{
+		{"openai", Config{Provider: ProviderOpenAI, Model: "gpt-4o", APIKey: "key"}, nil},
+		{"anthropic", Config{Provider: ProviderAnthropic, Model: "claude", APIKey: "key"}, nil},
+		{"local without key", Config{Provider: ProviderLocal, Model: "llama3"}, nil},
+		{"missing model", Config{Provider: ProviderLocal}, ErrMissingConfig},
+		{"missing key", Config{Provider: ProviderOpenAI, Model: "gpt-4o"}, ErrMissingConfig},
+		{"unknown provider", Config{Provider: "other", Model: "model"}, ErrUnknownProvider},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := New(tc.config)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.config.Provider, provider.Name())
+			assert.Equal(t, tc.config.Model, provider.Model())
+		})
+	}
+}
+
+func TestOpenAI_Complete(t *testing.T) {
+	server, body, headers := fakeServer(t, "/v1/chat/completions", map[string]any{
+		"choices": []map[string]any{{"message": map[string]any{"role": "assistant", "content": "The dismissal was unfair."}}},
+	})
+
+	provider, err := New(Config{Provider: ProviderOpenAI, Model: "gpt-4o", APIKey: "secret", BaseURL: server.URL + "/v1/"})
+	require.NoError(t, err)
+
+	answer, err := provider.Complete(context.Background(), messages)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The dismissal was unfair.", answer)
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+	assert.Equal(t, "gpt-4o", (*body)["model"])
+	assert.Equal(t, []any{
+		map[string]any{"role": "system", "content": "You are a legal assistant."},
+		map[string]any{"role": "user", "content": "Was the dismissal fair?"},
+	}, (*body)["messages"])
+}
+
+func TestAnthropic_Complete(t *testing.T) {
+	server, body, headers := fakeServer(t, "/v1/messages", map[string]any{
+		"content": []map[string]any{{"type": "text", "text": "The dismissal "}, {"type": "text", "text": "was unfair."}},
+	})
+
+	provider, err := New(Config{Provider: ProviderAnthropic, Model: "claude", APIKey: "secret", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	answer, err := provider.Complete(context.Background(), messages)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The dismissal was unfair.", answer)
+	assert.Equal(t, "secret", headers.Get("x-api-key"))
+	assert.Equal(t, anthropicVersion, headers.Get("anthropic-version"))
+	assert.Equal(t, "You are a legal assistant.", (*body)["system"])
+	assert.Equal(t, []any{map[string]any{"role": "user", "content": "Was the dismissal fair?"}}, (*body)["messages"])
+}
+
+func TestLocal_Complete(t *testing.T) {
+	server, body, _ := fakeServer(t, "/api/chat", map[string]any{
+		"message": map[string]any{"role": "assistant", "content": "The dismissal was unfair."},
+	})
+
+	provider, err := New(Config{Provider: ProviderLocal, Model: "llama3", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	answer, err := provider.Complete(context.Background(), messages)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The dismissal was unfair.", answer)
+	assert.Equal(t, false, (*body)["stream"])
+}
+
+func TestComplete_Errors(t *testing.T) {
+	t.Run("reports error statuses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		provider, err := New(Config{Provider: ProviderOpenAI, Model: "gpt-4o", APIKey: "wrong", BaseURL: server.URL})
+		require.NoError(t, err)
+
+		_, err = provider.Complete(context.Background(), messages)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 401")
+		assert.Contains(t, err.Error(), "invalid api key")
+	})
+
+	t.Run("rejects completions without content", func(t *testing.T) {
+		server, _, _ := fakeServer(t, "/chat/completions", map[string]any{"choices": []any{}})
+
+		provider, err := New(Config{Provider: ProviderOpenAI, Model: "gpt-4o", APIKey: "key", BaseURL: server.URL})
+		require.NoError(t, err)
+
+		_, err = provider.Complete(context.Background(), messages)
+		assert.Error(t, err)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		provider, err := New(Config{Provider: ProviderLocal, Model: "llama3", BaseURL: "http://127.0.0.1:0"})
+		require.NoError(t, err)
+
+		_, err = provider.Complete(ctx, messages)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,55 @@
+package llm
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"net/http"
+)
+
+const localBaseURL = "http://localhost:11434"
+
+type localMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type localRequest struct {
+	Model    string         `json:"model"`
+	Messages []localMessage `json:"messages"`
+	Stream   bool           `json:"stream"`
+}
+
+type localResponse struct {
+	Message localMessage `json:"message"`
+}
+
+// local talks to the chat API of an Ollama server, which needs no API key
+type local struct {
+	client  *http.Client
+	baseURL string
+	model   string
+}
+
+func newLocal(client *http.Client, baseURL, model string) *local {
+	return &local{
+		client:  client,
+		baseURL: baseURL,
+		model:   model,
+	}
+}
+
+func (p *local) Name() string  { return ProviderLocal }
+func (p *local) Model() string { return p.model }
+
+func (p *local) Complete(ctx context.Context, messages []llmprompt.Message) (string, error) {
+	request := localRequest{Model: p.model, Messages: make([]localMessage, 0, len(messages))}
+	for _, message := range messages {
+		request.Messages = append(request.Messages, localMessage(message))
+	}
+
+	var response localResponse
+	if err := postJSON(ctx, p.client, p.baseURL+"/api/chat", nil, request, &response); err != nil {
+		return "", err
+	}
+	return response.Message.Content, nil
+}
@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"errors"
+	"net/http"
+)
+
+const openAIBaseURL = "https://api.openai.com/v1"
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// openAI talks to the chat completions API of OpenAI, or of any compatible server
+type openAI struct {
+	client  *http.Client
+	baseURL string
+	model   string
+	apiKey  string
+}
+
+func newOpenAI(client *http.Client, baseURL, model, apiKey string) *openAI {
+	return &openAI{
+		client:  client,
+		baseURL: baseURL,
+		model:   model,
+		apiKey:  apiKey,
+	}
+}
+
+func (p *openAI) Name() string  { return ProviderOpenAI }
+func (p *openAI) Model() string { return p.model }
+
+func (p *openAI) Complete(ctx context.Context, messages []llmprompt.Message) (string, error) {
+	request := openAIRequest{Model: p.model, Messages: make([]openAIMessage, 0, len(messages))}
+	for _, message := range messages {
+		request.Messages = append(request.Messages, openAIMessage(message))
+	}
+
+	var response openAIResponse
+	err := postJSON(ctx, p.client, p.baseURL+"/chat/completions", map[string]string{
+		"Authorization": "Bearer " + p.apiKey,
+	}, request, &response)
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Choices) == 0 {
+		return "", errors.New("completion has no choice")
+	}
+	return response.Choices[0].Message.Content, nil
+}
@@ -2,21 +2,87 @@ package pkg
 
 import (
 	"context"
+	"davidterranova/jurigen/backend/internal/export"
+	"davidterranova/jurigen/backend/internal/importer"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
+	"fmt"
 
 	"github.com/google/uuid"
 )
 
 type App struct {
-	dagUseCase *dagUseCase
+	dagUseCase      *dagUseCase
+	sessionUseCase  *sessionUseCase
+	webhookUseCase  *webhookUseCase
+	templateUseCase *templateUseCase
+	jobUseCase      *jobUseCase
+	dagValidator    *usecase.DAGValidator
 }
 
 type dagUseCase struct {
 	GetDAGUseCase
 	ListDAGsUseCase
+	CreateDAGUseCase
 	UpdateDAGUseCase
+	DeleteDAGUseCase
 	ValidateStoredDAGUseCase
+	ValidateAllDAGsUseCase
+	EnqueueDAGValidationUseCase
+	MergeAnswerMetadataUseCase
+	InsertNodeUseCase
+	SplitNodeUseCase
+	CheckNodeReferencesUseCase
+	EnumeratePathsUseCase
+	ExportDAGUseCase
+	ImportDAGUseCase
+	SearchDAGsUseCase
+	RecordWalkUseCase
+	GetPathAnalyticsUseCase
+	ListDAGVersionsUseCase
+	GetDAGVersionUseCase
+	RestoreDAGVersionUseCase
+	DiffDAGsUseCase
+	UpdateNodeUseCase
+	ListDeletedDAGsUseCase
+	RestoreDeletedDAGUseCase
+	CloneDAGUseCase
+	MergeDAGUseCase
+	ExtractSubtreeUseCase
+	MetadataSchemaUseCase
+}
+
+type sessionUseCase struct {
+	StartSessionUseCase
+	AnswerSessionUseCase
+	ReviseSessionAnswerUseCase
+	CompleteSessionUseCase
+	GetSessionUseCase
+	GetSessionDocumentUseCase
+	BuildPromptUseCase
+	AssessSessionUseCase
+	ScoreSessionUseCase
+	AddAttachmentUseCase
+	GetAttachmentUseCase
+}
+
+type webhookUseCase struct {
+	CreateWebhookUseCase
+	ListWebhooksUseCase
+	GetWebhookUseCase
+	UpdateWebhookUseCase
+	DeleteWebhookUseCase
+	ListWebhookDeliveriesUseCase
+}
+
+type templateUseCase struct {
+	SaveAsTemplateUseCase
+	ListTemplatesUseCase
+	InstantiateTemplateUseCase
+}
+
+type jobUseCase struct {
+	GetJobUseCase
 }
 
 type GetDAGUseCase interface {
@@ -25,25 +91,277 @@ type GetDAGUseCase interface {
 
 type ListDAGsUseCase interface {
 	List(ctx context.Context, cmd usecase.CmdListDAGs) ([]uuid.UUID, error)
-	ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) ([]*model.DAG, error)
+	ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) (*model.DAGPage, error)
+}
+
+type CreateDAGUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdCreateDAG) (*model.DAG, error)
 }
 
 type UpdateDAGUseCase interface {
 	Execute(ctx context.Context, cmd usecase.CmdUpdateDAG) (*model.DAG, error)
+	Preview(ctx context.Context, cmd usecase.CmdUpdateDAG) (*usecase.UpdatePreview, error)
+}
+
+type DeleteDAGUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdDeleteDAG) error
 }
 
 type ValidateStoredDAGUseCase interface {
 	Execute(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*usecase.ValidationResult, error)
 }
 
-func New(dagRepository usecase.DAGRepository) *App {
+type ValidateAllDAGsUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdValidateAllDAGs) (*usecase.BulkValidationReport, error)
+}
+
+type EnqueueDAGValidationUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*model.Job, error)
+}
+
+type MergeAnswerMetadataUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdMergeAnswerMetadata) (*model.Answer, error)
+}
+
+type EnumeratePathsUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdEnumeratePaths) (*usecase.PathList, error)
+}
+
+type ExportDAGUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdExportDAG) (*usecase.ExportedDAG, error)
+}
+
+type ImportDAGUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdImportDAG) (*usecase.ImportResult, error)
+}
+
+type SearchDAGsUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdSearchDAGs) ([]model.SearchHit, error)
+}
+
+type RecordWalkUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdRecordWalk) (*usecase.WalkResult, error)
+}
+
+type GetPathAnalyticsUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdGetPathAnalytics) ([]usecase.RankedPath, error)
+}
+
+type InsertNodeUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdInsertNode) (*model.DAG, error)
+}
+
+type SplitNodeUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdSplitNode) (*model.DAG, error)
+}
+
+type CheckNodeReferencesUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdCheckNodeReferences) (*usecase.NodeReferences, error)
+}
+
+type ListDAGVersionsUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdListDAGVersions) ([]model.DAGVersion, error)
+}
+
+type GetDAGVersionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdGetDAGVersion) (*model.DAGVersion, error)
+}
+
+type RestoreDAGVersionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdRestoreDAGVersion) (*model.DAG, error)
+}
+
+type UpdateNodeUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdUpdateNode) (*model.Node, error)
+	UpdateAnswer(ctx context.Context, cmd usecase.CmdUpdateAnswer) (*model.Answer, error)
+}
+
+type DiffDAGsUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdDiffDAGs) (*usecase.DAGChangeSet, error)
+}
+
+type ListDeletedDAGsUseCase interface {
+	Execute(ctx context.Context) ([]*model.DAG, error)
+}
+
+type RestoreDeletedDAGUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdRestoreDeletedDAG) (*model.DAG, error)
+}
+
+type CloneDAGUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdCloneDAG) (*model.DAG, error)
+}
+
+type MergeDAGUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdMergeDAG) (*usecase.MergeResult, error)
+}
+
+type ExtractSubtreeUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error)
+	Materialize(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error)
+}
+
+type MetadataSchemaUseCase interface {
+	GetSchema(ctx context.Context, cmd usecase.CmdGetMetadataSchema) (*model.MetadataSchema, error)
+	SetSchema(ctx context.Context, cmd usecase.CmdSetMetadataSchema) (*model.MetadataSchema, error)
+	DeleteSchema(ctx context.Context, cmd usecase.CmdDeleteMetadataSchema) error
+}
+
+type StartSessionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdStartSession) (*model.CaseSession, error)
+}
+
+type AnswerSessionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdAnswerSession) (*model.CaseSession, error)
+}
+
+type ReviseSessionAnswerUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdReviseSessionAnswer) (*model.CaseSession, error)
+}
+
+type CompleteSessionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdCompleteSession) (*model.CaseSession, error)
+}
+
+type GetSessionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdGetSession) (*model.CaseSession, error)
+}
+
+type GetSessionDocumentUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdGetSessionDocument) (*usecase.SessionDocument, error)
+}
+
+type BuildPromptUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdBuildPrompt) (*usecase.BuiltPrompt, error)
+}
+
+type AssessSessionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdAssessSession) (*model.CaseSession, error)
+}
+
+type ScoreSessionUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdScoreSession) (*model.Score, error)
+}
+
+type AddAttachmentUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdAddAttachment) (*model.Attachment, error)
+}
+
+type GetAttachmentUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdGetAttachment) (*usecase.AttachmentContent, error)
+}
+
+type CreateWebhookUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdCreateWebhook) (*model.Webhook, error)
+}
+
+type ListWebhooksUseCase interface {
+	Execute(ctx context.Context) ([]model.Webhook, error)
+}
+
+type GetWebhookUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdGetWebhook) (*model.Webhook, error)
+}
+
+type UpdateWebhookUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdUpdateWebhook) (*model.Webhook, error)
+}
+
+type DeleteWebhookUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdDeleteWebhook) error
+}
+
+type ListWebhookDeliveriesUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdListWebhookDeliveries) ([]model.WebhookDelivery, error)
+}
+
+type SaveAsTemplateUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdSaveAsTemplate) (*model.Template, error)
+}
+
+type ListTemplatesUseCase interface {
+	Execute(ctx context.Context) ([]model.Template, error)
+}
+
+type InstantiateTemplateUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdInstantiateTemplate) (*model.DAG, error)
+}
+
+type GetJobUseCase interface {
+	Execute(ctx context.Context, cmd usecase.CmdGetJob) (*model.Job, error)
+}
+
+func New(dagRepository usecase.DAGRepository, searchIndex usecase.DAGSearchIndex, analyticsRepository usecase.WalkAnalyticsRepository, versionRepository usecase.DAGVersionRepository, sessionRepository usecase.SessionRepository, assessmentProvider usecase.AssessmentProvider, attachmentStorage usecase.AttachmentStorage, attachmentPolicy usecase.AttachmentPolicy, webhookRepository usecase.WebhookRepository, deliveryRepository usecase.WebhookDeliveryRepository, templateRepository usecase.TemplateRepository, jobQueue usecase.JobQueue, jobRepository usecase.JobRepository, eventPublisher usecase.EventPublisher, validationProfile usecase.ValidationProfile) *App {
+	dagValidator := usecase.NewDAGValidatorFromProfile(validationProfile)
+	// Soft-deleted DAGs are only reachable through the trash, creations still see them so their IDs are not reused
+	liveRepository := usecase.NewLiveDAGRepository(dagRepository)
+	validateStoredDAG := usecase.NewValidateStoredDAGUseCase(liveRepository, dagValidator, eventPublisher)
+	if jobQueue != nil {
+		jobQueue.Handle(model.JobTypeValidateDAG, validateStoredDAG.RunJob)
+	}
+
 	return &App{
 		dagUseCase: &dagUseCase{
-			usecase.NewGetDAGUseCase(dagRepository),
-			usecase.NewListDAGsUseCase(dagRepository),
-			usecase.NewUpdateDAGUseCase(dagRepository),
-			usecase.NewValidateStoredDAGUseCase(dagRepository),
+			usecase.NewGetDAGUseCase(liveRepository),
+			usecase.NewListDAGsUseCase(liveRepository),
+			usecase.NewCreateDAGUseCase(dagRepository, dagValidator, eventPublisher),
+			usecase.NewUpdateDAGUseCase(liveRepository, versionRepository, dagValidator, eventPublisher),
+			usecase.NewDeleteDAGUseCase(liveRepository, analyticsRepository, eventPublisher),
+			validateStoredDAG,
+			usecase.NewValidateAllDAGsUseCase(liveRepository, validateStoredDAG),
+			usecase.NewEnqueueDAGValidationUseCase(liveRepository, jobQueue),
+			usecase.NewMergeAnswerMetadataUseCase(liveRepository, eventPublisher),
+			usecase.NewInsertNodeUseCase(liveRepository, dagValidator, eventPublisher),
+			usecase.NewSplitNodeUseCase(liveRepository, dagValidator, eventPublisher),
+			usecase.NewCheckNodeReferencesUseCase(liveRepository),
+			usecase.NewEnumeratePathsUseCase(liveRepository),
+			usecase.NewExportDAGUseCase(liveRepository, dagValidator, export.DefaultRegistry()),
+			usecase.NewImportDAGUseCase(dagRepository, dagValidator, importer.DefaultRegistry(), eventPublisher),
+			usecase.NewSearchDAGsUseCase(searchIndex),
+			usecase.NewRecordWalkUseCase(liveRepository, analyticsRepository),
+			usecase.NewGetPathAnalyticsUseCase(liveRepository, analyticsRepository),
+			usecase.NewListDAGVersionsUseCase(liveRepository, versionRepository),
+			usecase.NewGetDAGVersionUseCase(versionRepository),
+			usecase.NewRestoreDAGVersionUseCase(liveRepository, versionRepository, dagValidator, eventPublisher),
+			usecase.NewDiffDAGsUseCase(versionRepository),
+			usecase.NewUpdateNodeUseCase(liveRepository, dagValidator, eventPublisher),
+			usecase.NewListDeletedDAGsUseCase(dagRepository),
+			usecase.NewRestoreDeletedDAGUseCase(dagRepository, eventPublisher),
+			usecase.NewCloneDAGUseCase(liveRepository, dagRepository, dagValidator, eventPublisher),
+			usecase.NewMergeDAGUseCase(liveRepository, dagValidator, eventPublisher),
+			usecase.NewExtractSubtreeUseCase(liveRepository, dagRepository, dagValidator, eventPublisher),
+			usecase.NewMetadataSchemaUseCase(liveRepository, dagValidator, eventPublisher),
+		},
+		sessionUseCase: &sessionUseCase{
+			usecase.NewStartSessionUseCase(liveRepository, sessionRepository),
+			usecase.NewAnswerSessionUseCase(liveRepository, sessionRepository),
+			usecase.NewReviseSessionAnswerUseCase(sessionRepository),
+			usecase.NewCompleteSessionUseCase(sessionRepository, eventPublisher),
+			usecase.NewGetSessionUseCase(sessionRepository),
+			usecase.NewGetSessionDocumentUseCase(liveRepository, sessionRepository),
+			usecase.NewBuildPromptUseCase(liveRepository, sessionRepository),
+			usecase.NewAssessSessionUseCase(liveRepository, sessionRepository, assessmentProvider),
+			usecase.NewScoreSessionUseCase(liveRepository, sessionRepository),
+			usecase.NewAddAttachmentUseCase(sessionRepository, attachmentStorage, attachmentPolicy),
+			usecase.NewGetAttachmentUseCase(sessionRepository, attachmentStorage),
+		},
+		webhookUseCase: &webhookUseCase{
+			usecase.NewCreateWebhookUseCase(webhookRepository),
+			usecase.NewListWebhooksUseCase(webhookRepository),
+			usecase.NewGetWebhookUseCase(webhookRepository),
+			usecase.NewUpdateWebhookUseCase(webhookRepository),
+			usecase.NewDeleteWebhookUseCase(webhookRepository, deliveryRepository),
+			usecase.NewListWebhookDeliveriesUseCase(webhookRepository, deliveryRepository),
 		},
+		templateUseCase: &templateUseCase{
+			usecase.NewSaveAsTemplateUseCase(liveRepository, templateRepository),
+			usecase.NewListTemplatesUseCase(templateRepository),
+			usecase.NewInstantiateTemplateUseCase(templateRepository, dagRepository, dagValidator, eventPublisher),
+		},
+		jobUseCase: &jobUseCase{
+			usecase.NewGetJobUseCase(jobRepository),
+		},
+		dagValidator: dagValidator,
 	}
 }
 
@@ -55,14 +373,232 @@ func (a *App) List(ctx context.Context, cmd usecase.CmdListDAGs) ([]uuid.UUID, e
 	return a.dagUseCase.List(ctx, cmd)
 }
 
+func (a *App) Create(ctx context.Context, cmd usecase.CmdCreateDAG) (*model.DAG, error) {
+	return a.dagUseCase.CreateDAGUseCase.Execute(ctx, cmd)
+}
+
 func (a *App) Update(ctx context.Context, cmd usecase.CmdUpdateDAG) (*model.DAG, error) {
 	return a.dagUseCase.UpdateDAGUseCase.Execute(ctx, cmd)
 }
 
-func (a *App) ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) ([]*model.DAG, error) {
+func (a *App) Delete(ctx context.Context, cmd usecase.CmdDeleteDAG) error {
+	return a.dagUseCase.DeleteDAGUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) PreviewUpdate(ctx context.Context, cmd usecase.CmdUpdateDAG) (*usecase.UpdatePreview, error) {
+	return a.dagUseCase.UpdateDAGUseCase.Preview(ctx, cmd)
+}
+
+func (a *App) ListDAGs(ctx context.Context, cmd usecase.CmdListDAGs) (*model.DAGPage, error) {
 	return a.dagUseCase.ListDAGs(ctx, cmd)
 }
 
 func (a *App) ValidateStoredDAG(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*usecase.ValidationResult, error) {
 	return a.dagUseCase.ValidateStoredDAGUseCase.Execute(ctx, cmd)
 }
+
+func (a *App) ValidateAllDAGs(ctx context.Context, cmd usecase.CmdValidateAllDAGs) (*usecase.BulkValidationReport, error) {
+	return a.dagUseCase.ValidateAllDAGsUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) EnqueueDAGValidation(ctx context.Context, cmd usecase.CmdValidateStoredDAG) (*model.Job, error) {
+	return a.dagUseCase.EnqueueDAGValidationUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) MergeAnswerMetadata(ctx context.Context, cmd usecase.CmdMergeAnswerMetadata) (*model.Answer, error) {
+	return a.dagUseCase.MergeAnswerMetadataUseCase.Execute(ctx, cmd)
+}
+
+// ValidateDAG validates a DAG which is not stored using the configured validation profile, the severity of its
+// rules being overridden by rules
+func (a *App) ValidateDAG(ctx context.Context, d *model.DAG, rules map[string]usecase.Severity) (usecase.ValidationResult, error) {
+	if len(rules) == 0 {
+		return a.dagValidator.ValidateDAG(d), nil
+	}
+
+	profile := a.dagValidator.Profile().WithRules(rules)
+	if err := profile.Check(); err != nil {
+		return usecase.ValidationResult{}, fmt.Errorf("%w: %s", usecase.ErrInvalidCommand, err)
+	}
+	return usecase.NewDAGValidatorFromProfile(profile).ValidateDAG(d), nil
+}
+
+func (a *App) InsertNode(ctx context.Context, cmd usecase.CmdInsertNode) (*model.DAG, error) {
+	return a.dagUseCase.InsertNodeUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) SplitNode(ctx context.Context, cmd usecase.CmdSplitNode) (*model.DAG, error) {
+	return a.dagUseCase.SplitNodeUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) CheckNodeReferences(ctx context.Context, cmd usecase.CmdCheckNodeReferences) (*usecase.NodeReferences, error) {
+	return a.dagUseCase.CheckNodeReferencesUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) EnumeratePaths(ctx context.Context, cmd usecase.CmdEnumeratePaths) (*usecase.PathList, error) {
+	return a.dagUseCase.EnumeratePathsUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ExportDAG(ctx context.Context, cmd usecase.CmdExportDAG) (*usecase.ExportedDAG, error) {
+	return a.dagUseCase.ExportDAGUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ImportDAG(ctx context.Context, cmd usecase.CmdImportDAG) (*usecase.ImportResult, error) {
+	return a.dagUseCase.ImportDAGUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) SearchDAGs(ctx context.Context, cmd usecase.CmdSearchDAGs) ([]model.SearchHit, error) {
+	return a.dagUseCase.SearchDAGsUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) RecordWalk(ctx context.Context, cmd usecase.CmdRecordWalk) (*usecase.WalkResult, error) {
+	return a.dagUseCase.RecordWalkUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) GetPathAnalytics(ctx context.Context, cmd usecase.CmdGetPathAnalytics) ([]usecase.RankedPath, error) {
+	return a.dagUseCase.GetPathAnalyticsUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ListDAGVersions(ctx context.Context, cmd usecase.CmdListDAGVersions) ([]model.DAGVersion, error) {
+	return a.dagUseCase.ListDAGVersionsUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) GetDAGVersion(ctx context.Context, cmd usecase.CmdGetDAGVersion) (*model.DAGVersion, error) {
+	return a.dagUseCase.GetDAGVersionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) RestoreDAGVersion(ctx context.Context, cmd usecase.CmdRestoreDAGVersion) (*model.DAG, error) {
+	return a.dagUseCase.RestoreDAGVersionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) UpdateNode(ctx context.Context, cmd usecase.CmdUpdateNode) (*model.Node, error) {
+	return a.dagUseCase.UpdateNodeUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) UpdateAnswer(ctx context.Context, cmd usecase.CmdUpdateAnswer) (*model.Answer, error) {
+	return a.dagUseCase.UpdateNodeUseCase.UpdateAnswer(ctx, cmd)
+}
+
+func (a *App) DiffDAGs(ctx context.Context, cmd usecase.CmdDiffDAGs) (*usecase.DAGChangeSet, error) {
+	return a.dagUseCase.DiffDAGsUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ListDeletedDAGs(ctx context.Context) ([]*model.DAG, error) {
+	return a.dagUseCase.ListDeletedDAGsUseCase.Execute(ctx)
+}
+
+func (a *App) RestoreDeletedDAG(ctx context.Context, cmd usecase.CmdRestoreDeletedDAG) (*model.DAG, error) {
+	return a.dagUseCase.RestoreDeletedDAGUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) CloneDAG(ctx context.Context, cmd usecase.CmdCloneDAG) (*model.DAG, error) {
+	return a.dagUseCase.CloneDAGUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) MergeDAG(ctx context.Context, cmd usecase.CmdMergeDAG) (*usecase.MergeResult, error) {
+	return a.dagUseCase.MergeDAGUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ExtractSubtree(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error) {
+	return a.dagUseCase.ExtractSubtreeUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) MaterializeSubtree(ctx context.Context, cmd usecase.CmdExtractSubtree) (*model.DAG, error) {
+	return a.dagUseCase.ExtractSubtreeUseCase.Materialize(ctx, cmd)
+}
+
+func (a *App) GetMetadataSchema(ctx context.Context, cmd usecase.CmdGetMetadataSchema) (*model.MetadataSchema, error) {
+	return a.dagUseCase.MetadataSchemaUseCase.GetSchema(ctx, cmd)
+}
+
+func (a *App) SetMetadataSchema(ctx context.Context, cmd usecase.CmdSetMetadataSchema) (*model.MetadataSchema, error) {
+	return a.dagUseCase.MetadataSchemaUseCase.SetSchema(ctx, cmd)
+}
+
+func (a *App) DeleteMetadataSchema(ctx context.Context, cmd usecase.CmdDeleteMetadataSchema) error {
+	return a.dagUseCase.MetadataSchemaUseCase.DeleteSchema(ctx, cmd)
+}
+
+func (a *App) StartSession(ctx context.Context, cmd usecase.CmdStartSession) (*model.CaseSession, error) {
+	return a.sessionUseCase.StartSessionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) AnswerSession(ctx context.Context, cmd usecase.CmdAnswerSession) (*model.CaseSession, error) {
+	return a.sessionUseCase.AnswerSessionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ReviseSessionAnswer(ctx context.Context, cmd usecase.CmdReviseSessionAnswer) (*model.CaseSession, error) {
+	return a.sessionUseCase.ReviseSessionAnswerUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) CompleteSession(ctx context.Context, cmd usecase.CmdCompleteSession) (*model.CaseSession, error) {
+	return a.sessionUseCase.CompleteSessionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) GetSession(ctx context.Context, cmd usecase.CmdGetSession) (*model.CaseSession, error) {
+	return a.sessionUseCase.GetSessionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) GetSessionDocument(ctx context.Context, cmd usecase.CmdGetSessionDocument) (*usecase.SessionDocument, error) {
+	return a.sessionUseCase.GetSessionDocumentUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) BuildPrompt(ctx context.Context, cmd usecase.CmdBuildPrompt) (*usecase.BuiltPrompt, error) {
+	return a.sessionUseCase.BuildPromptUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) AssessSession(ctx context.Context, cmd usecase.CmdAssessSession) (*model.CaseSession, error) {
+	return a.sessionUseCase.AssessSessionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ScoreSession(ctx context.Context, cmd usecase.CmdScoreSession) (*model.Score, error) {
+	return a.sessionUseCase.ScoreSessionUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) AddAttachment(ctx context.Context, cmd usecase.CmdAddAttachment) (*model.Attachment, error) {
+	return a.sessionUseCase.AddAttachmentUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) GetAttachment(ctx context.Context, cmd usecase.CmdGetAttachment) (*usecase.AttachmentContent, error) {
+	return a.sessionUseCase.GetAttachmentUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) CreateWebhook(ctx context.Context, cmd usecase.CmdCreateWebhook) (*model.Webhook, error) {
+	return a.webhookUseCase.CreateWebhookUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	return a.webhookUseCase.ListWebhooksUseCase.Execute(ctx)
+}
+
+func (a *App) GetWebhook(ctx context.Context, cmd usecase.CmdGetWebhook) (*model.Webhook, error) {
+	return a.webhookUseCase.GetWebhookUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) UpdateWebhook(ctx context.Context, cmd usecase.CmdUpdateWebhook) (*model.Webhook, error) {
+	return a.webhookUseCase.UpdateWebhookUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) DeleteWebhook(ctx context.Context, cmd usecase.CmdDeleteWebhook) error {
+	return a.webhookUseCase.DeleteWebhookUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ListWebhookDeliveries(ctx context.Context, cmd usecase.CmdListWebhookDeliveries) ([]model.WebhookDelivery, error) {
+	return a.webhookUseCase.ListWebhookDeliveriesUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) SaveAsTemplate(ctx context.Context, cmd usecase.CmdSaveAsTemplate) (*model.Template, error) {
+	return a.templateUseCase.SaveAsTemplateUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) ListTemplates(ctx context.Context) ([]model.Template, error) {
+	return a.templateUseCase.ListTemplatesUseCase.Execute(ctx)
+}
+
+func (a *App) InstantiateTemplate(ctx context.Context, cmd usecase.CmdInstantiateTemplate) (*model.DAG, error) {
+	return a.templateUseCase.InstantiateTemplateUseCase.Execute(ctx, cmd)
+}
+
+func (a *App) GetJob(ctx context.Context, cmd usecase.CmdGetJob) (*model.Job, error) {
+	return a.jobUseCase.GetJobUseCase.Execute(ctx, cmd)
+}
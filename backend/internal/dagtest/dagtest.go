@@ -0,0 +1,179 @@
+// Package dagtest provides DAG fixtures shared across test suites.
+// Every fixture returns a freshly generated DAG with answers wired to their parent node.
+package dagtest
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ValidSingleRoot returns a valid three nodes DAG: root -> middle -> leaf, with a shortcut root -> leaf
+// and a terminal answer on the middle node
+func ValidSingleRoot() *model.DAG {
+	rootID := uuid.New()
+	middleID := uuid.New()
+	leafID := uuid.New()
+
+	return Wire(&model.DAG{
+		Id:    uuid.New(),
+		Title: "Valid Single Root DAG",
+		Nodes: map[uuid.UUID]model.Node{
+			rootID: {
+				Id:       rootID,
+				Question: "Root question?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Go to middle", NextNode: &middleID},
+					{Id: uuid.New(), Statement: "Go to leaf", NextNode: &leafID},
+				},
+			},
+			middleID: {
+				Id:       middleID,
+				Question: "Middle question?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Go to leaf", NextNode: &leafID},
+					{Id: uuid.New(), Statement: "Stay here"},
+				},
+			},
+			leafID: {
+				Id:       leafID,
+				Question: "Leaf question?",
+				Answers:  []model.Answer{},
+			},
+		},
+	})
+}
+
+// MultipleRoots returns a DAG where two root nodes lead to the same leaf
+func MultipleRoots() *model.DAG {
+	root1ID := uuid.New()
+	root2ID := uuid.New()
+	leafID := uuid.New()
+
+	return Wire(&model.DAG{
+		Id:    uuid.New(),
+		Title: "Multiple Root DAG",
+		Nodes: map[uuid.UUID]model.Node{
+			root1ID: {
+				Id:       root1ID,
+				Question: "Root 1 question?",
+				Answers:  []model.Answer{{Id: uuid.New(), Statement: "Go to leaf", NextNode: &leafID}},
+			},
+			root2ID: {
+				Id:       root2ID,
+				Question: "Root 2 question?",
+				Answers:  []model.Answer{{Id: uuid.New(), Statement: "Go to leaf", NextNode: &leafID}},
+			},
+			leafID: {
+				Id:       leafID,
+				Question: "Leaf question?",
+				Answers:  []model.Answer{},
+			},
+		},
+	})
+}
+
+// Cyclic returns a DAG of three nodes forming a single cycle, hence without root node
+func Cyclic() *model.DAG {
+	node1ID := uuid.New()
+	node2ID := uuid.New()
+	node3ID := uuid.New()
+
+	return Wire(&model.DAG{
+		Id:    uuid.New(),
+		Title: "Cyclic DAG",
+		Nodes: map[uuid.UUID]model.Node{
+			node1ID: {
+				Id:       node1ID,
+				Question: "Node 1 question?",
+				Answers:  []model.Answer{{Id: uuid.New(), Statement: "Go to node 2", NextNode: &node2ID}},
+			},
+			node2ID: {
+				Id:       node2ID,
+				Question: "Node 2 question?",
+				Answers:  []model.Answer{{Id: uuid.New(), Statement: "Go to node 3", NextNode: &node3ID}},
+			},
+			node3ID: {
+				Id:       node3ID,
+				Question: "Node 3 question?",
+				Answers:  []model.Answer{{Id: uuid.New(), Statement: "Go back to node 1", NextNode: &node1ID}},
+			},
+		},
+	})
+}
+
+// SingleNode returns a DAG made of a single node without answers
+func SingleNode() *model.DAG {
+	nodeID := uuid.New()
+
+	return Wire(&model.DAG{
+		Id:    uuid.New(),
+		Title: "Single Node DAG",
+		Nodes: map[uuid.UUID]model.Node{
+			nodeID: {
+				Id:       nodeID,
+				Question: "Only question?",
+				Answers:  []model.Answer{},
+			},
+		},
+	})
+}
+
+// LinearChain returns a DAG of length nodes where each node has a single answer leading to the next one.
+// A length lower than one returns a single node DAG.
+func LinearChain(length int) *model.DAG {
+	if length <= 0 {
+		return SingleNode()
+	}
+
+	nodeIDs := make([]uuid.UUID, length)
+	for i := range nodeIDs {
+		nodeIDs[i] = uuid.New()
+	}
+
+	nodes := make(map[uuid.UUID]model.Node, length)
+	for i, nodeID := range nodeIDs {
+		answers := []model.Answer{}
+		if i < length-1 {
+			answers = append(answers, model.Answer{
+				Id:        uuid.New(),
+				Statement: fmt.Sprintf("Go to node %d", i+2),
+				NextNode:  &nodeIDs[i+1],
+			})
+		}
+
+		nodes[nodeID] = model.Node{
+			Id:       nodeID,
+			Question: fmt.Sprintf("Question %d?", i+1),
+			Answers:  answers,
+		}
+	}
+
+	return Wire(&model.DAG{
+		Id:    uuid.New(),
+		Title: fmt.Sprintf("Linear Chain DAG (length %d)", length),
+		Nodes: nodes,
+	})
+}
+
+// Root returns the root node of a fixture, panicking when the fixture has none or several
+func Root(d *model.DAG) model.Node {
+	root, err := d.GetRootNode()
+	if err != nil {
+		panic(fmt.Sprintf("dagtest: %s", err))
+	}
+	return root
+}
+
+// Wire sets the parent pointer of every answer, the same way unmarshalling a DAG does
+func Wire(d *model.DAG) *model.DAG {
+	for id, node := range d.Nodes {
+		nodeCopy := node
+		for i := range nodeCopy.Answers {
+			nodeCopy.Answers[i].ParentNode = &nodeCopy
+		}
+		d.Nodes[id] = nodeCopy
+	}
+	return d
+}
@@ -0,0 +1,60 @@
+package dagtest_test
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		dag         *model.DAG
+		expectValid bool
+		expectCycle bool
+		totalNodes  int
+	}{
+		{name: "valid single root", dag: dagtest.ValidSingleRoot(), expectValid: true, totalNodes: 3},
+		{name: "multiple roots", dag: dagtest.MultipleRoots(), expectValid: false, totalNodes: 3},
+		{name: "cyclic", dag: dagtest.Cyclic(), expectValid: false, expectCycle: true, totalNodes: 3},
+		{name: "single node", dag: dagtest.SingleNode(), expectValid: true, totalNodes: 1},
+		{name: "linear chain", dag: dagtest.LinearChain(5), expectValid: true, totalNodes: 5},
+		{name: "empty linear chain", dag: dagtest.LinearChain(0), expectValid: true, totalNodes: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := usecase.NewDAGValidator().ValidateDAG(tt.dag)
+			assert.Equal(t, tt.expectValid, result.IsValid, "errors: %v", result.Errors)
+			assert.Equal(t, tt.expectCycle, result.Statistics.HasCycles)
+			assert.Equal(t, tt.totalNodes, result.Statistics.TotalNodes)
+
+			for nodeId, node := range tt.dag.Nodes {
+				for _, answer := range node.Answers {
+					require.NotNil(t, answer.ParentNode)
+					assert.Equal(t, nodeId, answer.ParentNode.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestLinearChain(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.LinearChain(4)
+
+	path, err := d.Walk(dagtest.Root(d).Id, func(node model.Node) (model.Answer, error) {
+		return node.Answers[0], nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, path, 3)
+}
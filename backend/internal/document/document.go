@@ -0,0 +1,57 @@
+// Package document renders case context documents as shareable files.
+package document
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatPDF      = "pdf"
+)
+
+var ErrUnknownFormat = errors.New("unknown document format")
+
+// Rendered is a context document rendered in a file format
+type Rendered struct {
+	Format      string
+	ContentType string
+	Data        []byte
+}
+
+// Formats lists the supported formats
+func Formats() []string {
+	return []string{FormatMarkdown, FormatPDF}
+}
+
+// Render renders a context document in the given format
+func Render(doc *model.ContextDocument, format string) (*Rendered, error) {
+	switch format {
+	case FormatMarkdown:
+		return &Rendered{Format: format, ContentType: "text/markdown; charset=utf-8", Data: Markdown(doc)}, nil
+	case FormatPDF:
+		return &Rendered{Format: format, ContentType: "application/pdf", Data: PDF(doc)}, nil
+	}
+	return nil, fmt.Errorf("%w: %q, supported formats: %v", ErrUnknownFormat, format, Formats())
+}
+
+// FileName is the name under which a document is saved, derived from the DAG or session it summarizes
+func FileName(doc *model.ContextDocument, format string) string {
+	id := doc.DAGId
+	if doc.SessionId != nil {
+		id = *doc.SessionId
+	}
+	return "case-context-" + id.String() + "." + format
+}
+
+func formatConfidence(confidence float64) string {
+	return strconv.FormatFloat(confidence, 'f', 2, 64)
+}
+
+// attachmentDetails describes the content of an evidence file, e.g. "(application/pdf, 2048 bytes, sha256:9f86d0...)"
+func attachmentDetails(attachment model.ContextDocumentAttachment) string {
+	return fmt.Sprintf("(%s, %d bytes, %s)", attachment.ContentType, attachment.Size, attachment.Checksum)
+}
@@ -0,0 +1,69 @@
+package document
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureDocument() *model.ContextDocument {
+	confidence := 0.8
+	sessionId := uuid.MustParse("20000000-0000-0000-0000-000000000000")
+
+	return &model.ContextDocument{
+		Title:       "Employment",
+		DAGId:       uuid.MustParse("10000000-0000-0000-0000-000000000000"),
+		SessionId:   &sessionId,
+		GeneratedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Entries: []model.ContextDocumentEntry{
+			{
+				Question:    "Were you dismissed?",
+				Answer:      "Yes",
+				UserContext: "Dismissed by email on March 3rd",
+				Confidence:  &confidence,
+				Tags:        []string{"dismissal", "urgent"},
+				Sources:     []string{"Email_HR_2024-03-03.pdf", "Witness statement"},
+				Attachments: []model.ContextDocumentAttachment{{
+					FileName:    "dismissal.pdf",
+					ContentType: "application/pdf",
+					Size:        2048,
+					Checksum:    "sha256:9f86d081",
+					Link:        "/v1/sessions/20000000-0000-0000-0000-000000000000/attachments/30000000-0000-0000-0000-000000000000",
+				}},
+			},
+			{
+				Question: "Did you receive a notice?",
+				Answer:   "No",
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	doc := fixtureDocument()
+
+	rendered, err := Render(doc, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, "text/markdown; charset=utf-8", rendered.ContentType)
+	assert.Equal(t, Markdown(doc), rendered.Data)
+
+	rendered, err = Render(doc, FormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", rendered.ContentType)
+	assert.Equal(t, PDF(doc), rendered.Data)
+
+	_, err = Render(doc, "docx")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestFileName(t *testing.T) {
+	doc := fixtureDocument()
+	assert.Equal(t, "case-context-20000000-0000-0000-0000-000000000000.pdf", FileName(doc, FormatPDF))
+
+	doc.SessionId = nil
+	assert.Equal(t, "case-context-10000000-0000-0000-0000-000000000000.md", FileName(doc, FormatMarkdown))
+}
@@ -0,0 +1,51 @@
+package document
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Markdown renders a context document as Markdown, one section per answered question
+func Markdown(doc *model.ContextDocument) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# Case context: %s\n\n", doc.Title)
+	fmt.Fprintf(&b, "- DAG: `%s`\n", doc.DAGId)
+	if doc.SessionId != nil {
+		fmt.Fprintf(&b, "- Session: `%s`\n", *doc.SessionId)
+	}
+	fmt.Fprintf(&b, "- Generated: %s\n", doc.GeneratedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Questions answered: %d\n", len(doc.Entries))
+
+	for i, entry := range doc.Entries {
+		fmt.Fprintf(&b, "\n## %d. %s\n\n", i+1, entry.Question)
+		fmt.Fprintf(&b, "**Answer:** %s\n", entry.Answer)
+
+		if entry.UserContext != "" {
+			fmt.Fprintf(&b, "\n**Context:** %s\n", entry.UserContext)
+		}
+		if entry.Confidence != nil {
+			fmt.Fprintf(&b, "\n**Confidence:** %s\n", formatConfidence(*entry.Confidence))
+		}
+		if len(entry.Tags) > 0 {
+			fmt.Fprintf(&b, "\n**Tags:** %s\n", strings.Join(entry.Tags, ", "))
+		}
+		if len(entry.Sources) > 0 {
+			b.WriteString("\n**Evidence sources:**\n\n")
+			for _, source := range entry.Sources {
+				fmt.Fprintf(&b, "- %s\n", source)
+			}
+		}
+		if len(entry.Attachments) > 0 {
+			b.WriteString("\n**Attachments:**\n\n")
+			for _, attachment := range entry.Attachments {
+				fmt.Fprintf(&b, "- [%s](%s) %s\n", attachment.FileName, attachment.Link, attachmentDetails(attachment))
+			}
+		}
+	}
+
+	return b.Bytes()
+}
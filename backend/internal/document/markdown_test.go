@@ -0,0 +1,41 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	expected := "# Case context: Employment\n" +
+		"\n" +
+		"- DAG: `10000000-0000-0000-0000-000000000000`\n" +
+		"- Session: `20000000-0000-0000-0000-000000000000`\n" +
+		"- Generated: 2024-06-01T12:00:00Z\n" +
+		"- Questions answered: 2\n" +
+		"\n" +
+		"## 1. Were you dismissed?\n" +
+		"\n" +
+		"**Answer:** Yes\n" +
+		"\n" +
+		"**Context:** Dismissed by email on March 3rd\n" +
+		"\n" +
+		"**Confidence:** 0.80\n" +
+		"\n" +
+		"**Tags:** dismissal, urgent\n" +
+		"\n" +
+		"**Evidence sources:**\n" +
+		"\n" +
+		"- Email_HR_2024-03-03.pdf\n" +
+		"- Witness statement\n" +
+		"\n" +
+		"**Attachments:**\n" +
+		"\n" +
+		"- [dismissal.pdf](/v1/sessions/20000000-0000-0000-0000-000000000000/attachments/30000000-0000-0000-0000-000000000000) (application/pdf, 2048 bytes, sha256:9f86d081)\n" +
+		"\n" +
+		"## 2. Did you receive a notice?\n" +
+		"\n" +
+		"**Answer:** No\n"
+
+	assert.Equal(t, expected, string(Markdown(fixtureDocument())))
+}
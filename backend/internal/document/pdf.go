@@ -0,0 +1,239 @@
+package document
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 page layout, in points
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	pageMargin = 50.0
+	// averageCharWidth is the width of an average Helvetica character relative to the font size,
+	// used to wrap lines without font metrics
+	averageCharWidth = 0.55
+)
+
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// pdfLine is a line of text laid out on a page
+type pdfLine struct {
+	text   string
+	font   string
+	size   float64
+	indent float64
+}
+
+// PDF renders a context document as a PDF file using the standard Helvetica fonts, so that no font
+// has to be embedded. Characters outside the Windows-1252 character set are replaced by question marks.
+func PDF(doc *model.ContextDocument) []byte {
+	pages := paginate(layout(doc))
+
+	w := &pdfWriter{}
+	w.header()
+
+	// Objects 1 to 5 are fixed, each page then takes a page object and a content stream object
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	w.object(fmt.Sprintf("<< /Title %s /Producer (jurigen) /CreationDate (D:%s) >>",
+		pdfString("Case context: "+doc.Title), doc.GeneratedAt.UTC().Format("20060102150405Z")))
+
+	for i, lines := range pages {
+		w.object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 7+2*i,
+		))
+		w.stream(pageContent(lines, i+1, len(pages)))
+	}
+
+	w.trailer()
+	return w.buf.Bytes()
+}
+
+// layout turns a document into wrapped lines, empty lines separating the sections
+func layout(doc *model.ContextDocument) []pdfLine {
+	var lines []pdfLine
+	add := func(text, font string, size, indent float64) {
+		for _, wrapped := range wrap(text, size, indent) {
+			lines = append(lines, pdfLine{text: wrapped, font: font, size: size, indent: indent})
+		}
+	}
+	blank := func() { lines = append(lines, pdfLine{size: 10}) }
+
+	add("Case context: "+doc.Title, fontBold, 16, 0)
+	blank()
+	add("DAG: "+doc.DAGId.String(), fontRegular, 10, 0)
+	if doc.SessionId != nil {
+		add("Session: "+doc.SessionId.String(), fontRegular, 10, 0)
+	}
+	add("Generated: "+doc.GeneratedAt.UTC().Format(time.RFC3339), fontRegular, 10, 0)
+	add(fmt.Sprintf("Questions answered: %d", len(doc.Entries)), fontRegular, 10, 0)
+
+	for i, entry := range doc.Entries {
+		blank()
+		add(fmt.Sprintf("%d. %s", i+1, entry.Question), fontBold, 11, 0)
+		add("Answer: "+entry.Answer, fontRegular, 10, 15)
+		if entry.UserContext != "" {
+			add("Context: "+entry.UserContext, fontRegular, 10, 15)
+		}
+		if entry.Confidence != nil {
+			add("Confidence: "+formatConfidence(*entry.Confidence), fontRegular, 10, 15)
+		}
+		if len(entry.Tags) > 0 {
+			add("Tags: "+strings.Join(entry.Tags, ", "), fontRegular, 10, 15)
+		}
+		if len(entry.Sources) > 0 {
+			add("Evidence sources:", fontRegular, 10, 15)
+			for _, source := range entry.Sources {
+				add("- "+source, fontRegular, 10, 25)
+			}
+		}
+		if len(entry.Attachments) > 0 {
+			add("Attachments:", fontRegular, 10, 15)
+			for _, attachment := range entry.Attachments {
+				add("- "+attachment.FileName+" "+attachmentDetails(attachment), fontRegular, 10, 25)
+				add(attachment.Link, fontRegular, 10, 35)
+			}
+		}
+	}
+
+	return lines
+}
+
+// wrap splits text on words so that each line fits the page width, words too long for a line are cut
+func wrap(text string, size, indent float64) []string {
+	width := int((pageWidth - 2*pageMargin - indent) / (size * averageCharWidth))
+
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
+func leading(line pdfLine) float64 {
+	return line.size * 1.4
+}
+
+// paginate distributes lines over pages, keeping room for the page footer
+func paginate(lines []pdfLine) [][]pdfLine {
+	var pages [][]pdfLine
+	var page []pdfLine
+	available := pageHeight - 2*pageMargin - 20
+
+	used := 0.0
+	for _, line := range lines {
+		if used+leading(line) > available && len(page) > 0 {
+			pages = append(pages, page)
+			page, used = nil, 0
+		}
+		// Blank lines are dropped at the top of a page
+		if len(page) == 0 && line.text == "" {
+			continue
+		}
+		page = append(page, line)
+		used += leading(line)
+	}
+
+	return append(pages, page)
+}
+
+func pageContent(lines []pdfLine, number, count int) string {
+	var b strings.Builder
+	y := pageHeight - pageMargin
+	for _, line := range lines {
+		y -= leading(line)
+		if line.text == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "BT /%s %.0f Tf %.2f %.2f Td %s Tj ET\n", line.font, line.size, pageMargin+line.indent, y, pdfString(line.text))
+	}
+	fmt.Fprintf(&b, "BT /%s 8 Tf %.2f %.2f Td %s Tj ET\n", fontRegular, pageMargin, pageMargin/2, pdfString(fmt.Sprintf("Page %d / %d", number, count)))
+	return b.String()
+}
+
+// pdfString encodes text as a PDF literal string in the Windows-1252 encoding of the fonts
+func pdfString(text string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range text {
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			c = '?'
+		}
+		if c == '\\' || c == '(' || c == ')' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// pdfWriter writes numbered objects and keeps their offsets for the cross-reference table
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *pdfWriter) header() {
+	// The binary comment tells transfer tools the file is not plain text
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+}
+
+func (w *pdfWriter) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+func (w *pdfWriter) stream(content string) {
+	w.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+}
+
+func (w *pdfWriter) trailer() {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xref)
+}
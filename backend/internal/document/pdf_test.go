@@ -0,0 +1,74 @@
+package document
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDF(t *testing.T) {
+	data := string(PDF(fixtureDocument()))
+
+	assert.True(t, strings.HasPrefix(data, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(data, "%%EOF\n"))
+	assert.Contains(t, data, "(1. Were you dismissed?) Tj")
+	assert.Contains(t, data, "(Context: Dismissed by email on March 3rd) Tj")
+	assert.Contains(t, data, "(Confidence: 0.80) Tj")
+	assert.Contains(t, data, "(- Witness statement) Tj")
+	assert.Contains(t, data, "(- dismissal.pdf \\(application/pdf, 2048 bytes, sha256:9f86d081\\)) Tj")
+	assert.Contains(t, data, "/Count 1 >>")
+
+	// Every cross-reference entry points at the object it numbers
+	xref := strings.Index(data, "xref\n")
+	require.Positive(t, xref)
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(data[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(data[offset:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+	assert.Contains(t, data, fmt.Sprintf("startxref\n%d\n", xref))
+}
+
+func TestPDF_Pages(t *testing.T) {
+	doc := fixtureDocument()
+	for i := 0; i < 60; i++ {
+		doc.Entries = append(doc.Entries, model.ContextDocumentEntry{
+			Question: fmt.Sprintf("Question %d?", i),
+			Answer:   strings.Repeat("A long answer statement ", 10),
+		})
+	}
+
+	data := string(PDF(doc))
+
+	count := regexp.MustCompile(`/Count (\d+) >>`).FindStringSubmatch(data)
+	require.Len(t, count, 2)
+	pages, err := strconv.Atoi(count[1])
+	require.NoError(t, err)
+	assert.Greater(t, pages, 1)
+	assert.Equal(t, pages, strings.Count(data, "/Type /Page /Parent"))
+	assert.Contains(t, data, fmt.Sprintf("(Page %d / %d) Tj", pages, pages))
+}
+
+func TestPDFString(t *testing.T) {
+	assert.Equal(t, `(Art. 1 \(a\) \\ b)`, pdfString(`Art. 1 (a) \ b`))
+	assert.Equal(t, "(Cr\xe9dit ?)", pdfString("Crédit 😀"))
+}
+
+func TestWrap(t *testing.T) {
+	lines := wrap(strings.Repeat("word ", 100), 10, 0)
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 90)
+	}
+
+	assert.Equal(t, []string{""}, wrap("", 10, 0))
+	assert.Len(t, wrap(strings.Repeat("x", 200), 10, 0), 3)
+}
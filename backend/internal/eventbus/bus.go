@@ -0,0 +1,96 @@
+package eventbus
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"errors"
+	"sync"
+)
+
+// subscriberBufferSize is the number of events buffered per subscriber before new events are dropped
+const subscriberBufferSize = 64
+
+// Publisher is notified synchronously of every event published on the bus
+type Publisher interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+// Bus is an in-process pub/sub of the application events
+type Bus struct {
+	publishers []Publisher
+
+	mu          sync.Mutex
+	subscribers map[chan model.Event]struct{}
+	closed      bool
+}
+
+// New returns a bus forwarding its events to publishers, e.g. the webhook dispatcher, before fanning them out to subscribers
+func New(publishers ...Publisher) *Bus {
+	return &Bus{
+		publishers:  publishers,
+		subscribers: make(map[chan model.Event]struct{}),
+	}
+}
+
+// Publish forwards an event to the publishers then sends it to every subscriber without blocking; slow subscribers miss events
+func (b *Bus) Publish(ctx context.Context, event model.Event) error {
+	var errs []error
+	for _, publisher := range b.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Subscribe registers a new subscriber, the returned channel is closed when ctx is cancelled or the bus is closed
+func (b *Bus) Subscribe(ctx context.Context) <-chan model.Event {
+	ch := make(chan model.Event, subscriberBufferSize)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(ch)
+		return ch
+	}
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}()
+
+	return ch
+}
+
+// Close closes the channels of the subscribers, ending the event streams so that the server can shut down.
+// Events are still forwarded to the publishers.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
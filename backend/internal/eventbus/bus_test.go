@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type publisherFunc func(ctx context.Context, event model.Event) error
+
+func (f publisherFunc) Publish(ctx context.Context, event model.Event) error {
+	return f(ctx, event)
+}
+
+func TestBus(t *testing.T) {
+	t.Run("fans events out to subscribers", func(t *testing.T) {
+		bus := New()
+		ctx, cancel := context.WithCancel(context.Background())
+		first := bus.Subscribe(ctx)
+		second := bus.Subscribe(ctx)
+
+		event := model.NewEvent(model.EventDAGUpdated, uuid.New(), time.Now())
+		require.NoError(t, bus.Publish(context.Background(), event))
+
+		assert.Equal(t, event, <-first)
+		assert.Equal(t, event, <-second)
+
+		cancel()
+		_, open := <-first
+		assert.False(t, open, "channel closed on cancellation")
+	})
+
+	t.Run("drops events of slow subscribers", func(t *testing.T) {
+		bus := New()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := bus.Subscribe(ctx)
+
+		for i := 0; i < subscriberBufferSize+10; i++ {
+			require.NoError(t, bus.Publish(context.Background(), model.NewEvent(model.EventDAGUpdated, uuid.New(), time.Now())))
+		}
+		assert.Len(t, events, subscriberBufferSize)
+	})
+
+	t.Run("forwards events to publishers", func(t *testing.T) {
+		var forwarded []model.Event
+		failing := errors.New("unreachable")
+		bus := New(
+			publisherFunc(func(_ context.Context, event model.Event) error {
+				forwarded = append(forwarded, event)
+				return nil
+			}),
+			publisherFunc(func(context.Context, model.Event) error { return failing }),
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := bus.Subscribe(ctx)
+
+		event := model.NewEvent(model.EventDAGDeleted, uuid.New(), time.Now())
+		err := bus.Publish(context.Background(), event)
+		assert.ErrorIs(t, err, failing)
+		assert.Equal(t, []model.Event{event}, forwarded)
+		assert.Equal(t, event, <-events, "subscribers notified despite a failing publisher")
+	})
+
+	t.Run("closes the subscriptions", func(t *testing.T) {
+		bus := New()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := bus.Subscribe(ctx)
+		bus.Close()
+
+		_, open := <-events
+		assert.False(t, open, "channel closed with the bus")
+		_, open = <-bus.Subscribe(ctx)
+		assert.False(t, open, "no subscription once closed")
+		assert.NoError(t, bus.Publish(context.Background(), model.NewEvent(model.EventDAGUpdated, uuid.New(), time.Now())))
+	})
+}
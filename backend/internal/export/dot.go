@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"strings"
+)
+
+// DOT renders a DAG as a Graphviz digraph: every node is a question and every answer an edge labeled with its statement.
+// Terminal answers point to an unlabeled end point
+type DOT struct{}
+
+func (DOT) Format() string {
+	return FormatDOT
+}
+
+func (DOT) ContentType() string {
+	return "text/vnd.graphviz; charset=utf-8"
+}
+
+func (DOT) Export(d *model.DAG, options Options) ([]byte, error) {
+	cycleEdges := options.cycleEdges()
+	nodes := sortedNodes(d)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "digraph %s {\n", dotQuote(d.Title))
+	buf.WriteString("  rankdir=TB;\n")
+	buf.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, node := range nodes {
+		attributes := "label=" + dotQuote(node.Question)
+		if options.HighlightLeaves && isLeaf(node) {
+			attributes += `, style="rounded,filled", fillcolor=palegreen`
+		}
+		fmt.Fprintf(&buf, "  %s [%s];\n", dotQuote(node.Id.String()), attributes)
+	}
+
+	for _, node := range nodes {
+		for _, answer := range sortedAnswers(node) {
+			attributes := "label=" + dotQuote(answer.Statement)
+			if answer.Disabled {
+				attributes += ", style=dashed"
+			}
+
+			// Conditional next nodes are drawn as dotted edges labelled with their condition
+			for _, branch := range answer.Conditions {
+				fmt.Fprintf(&buf, "  %s -> %s [label=%s, style=dotted];\n", dotQuote(node.Id.String()), dotQuote(branch.NextNode.String()), dotQuote(answer.Statement+" when "+branch.When))
+			}
+
+			if answer.NextNode == nil {
+				fmt.Fprintf(&buf, "  %s [shape=point, label=\"\"];\n", dotQuote(answer.Id.String()))
+				fmt.Fprintf(&buf, "  %s -> %s [%s];\n", dotQuote(node.Id.String()), dotQuote(answer.Id.String()), attributes)
+				continue
+			}
+
+			if cycleEdges[edge{from: node.Id, to: *answer.NextNode}] {
+				attributes += ", color=red, penwidth=2"
+			}
+			fmt.Fprintf(&buf, "  %s -> %s [%s];\n", dotQuote(node.Id.String()), dotQuote(answer.NextNode.String()), attributes)
+		}
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.Bytes(), nil
+}
+
+// dotQuote renders a string as a DOT quoted identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s) + `"`
+}
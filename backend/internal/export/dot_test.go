@@ -0,0 +1,57 @@
+package export
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDOT_Export(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renders questions as nodes and answers as edges", func(t *testing.T) {
+		t.Parallel()
+
+		expected := `digraph "Dismissal \"fast track\"" {
+  rankdir=TB;
+  node [shape=box, style=rounded];
+  "00000000-0000-0000-0000-000000000001" [label="Were you dismissed?"];
+  "00000000-0000-0000-0000-000000000002" [label="In writing?\nPlease check"];
+  "00000000-0000-0000-0000-000000000001" -> "00000000-0000-0000-0000-000000000002" [label="Yes"];
+  "00000000-0000-0000-0000-000000000012" [shape=point, label=""];
+  "00000000-0000-0000-0000-000000000001" -> "00000000-0000-0000-0000-000000000012" [label="No", style=dashed];
+}
+`
+		data, err := DOT{}.Export(fixtureDAG(), Options{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("highlights leaf nodes", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := DOT{}.Export(fixtureDAG(), Options{HighlightLeaves: true})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"00000000-0000-0000-0000-000000000002" [label="In writing?\nPlease check", style="rounded,filled", fillcolor=palegreen];`)
+		assert.Contains(t, string(data), `"00000000-0000-0000-0000-000000000001" [label="Were you dismissed?"];`)
+	})
+
+	t.Run("highlights cycle edges", func(t *testing.T) {
+		t.Parallel()
+
+		d, cycles := cyclicDAG()
+		data, err := DOT{}.Export(d, Options{Cycles: cycles})
+		require.NoError(t, err)
+
+		for _, node := range d.Nodes {
+			edge := fmt.Sprintf("%q -> %q [label=%q, color=red, penwidth=2];", node.Id.String(), node.Answers[0].NextNode.String(), node.Answers[0].Statement)
+			assert.Contains(t, string(data), edge)
+		}
+
+		data, err = DOT{}.Export(d, Options{})
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "color=red")
+	})
+}
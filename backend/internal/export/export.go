@@ -0,0 +1,119 @@
+// Package export renders DAGs in formats consumed by visualization and documentation tools.
+// Every format is an Exporter registered by name, so that the CLI and the HTTP API expose the same formats.
+package export
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FormatDOT     = "dot"
+	FormatGraphML = "graphml"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Options controls what an export emphasizes, formats without styling record it as data
+type Options struct {
+	// HighlightLeaves marks the nodes without answers leading to another node
+	HighlightLeaves bool
+	// Cycles lists the node IDs of the cycles to highlight, each cycle closed on its first node
+	Cycles [][]uuid.UUID
+}
+
+// Exporter renders a DAG in a single format
+type Exporter interface {
+	// Format is the name the exporter is registered under
+	Format() string
+	ContentType() string
+	Export(d *model.DAG, options Options) ([]byte, error)
+}
+
+// Registry holds the available exporters by format
+type Registry struct {
+	exporters map[string]Exporter
+}
+
+func NewRegistry(exporters ...Exporter) *Registry {
+	r := &Registry{exporters: make(map[string]Exporter, len(exporters))}
+	for _, exporter := range exporters {
+		r.Register(exporter)
+	}
+	return r
+}
+
+// DefaultRegistry returns a registry holding every format of this package
+func DefaultRegistry() *Registry {
+	return NewRegistry(DOT{}, GraphML{}, Mermaid{}, JSON{})
+}
+
+// Register adds an exporter, replacing the one registered under the same format
+func (r *Registry) Register(exporter Exporter) {
+	r.exporters[exporter.Format()] = exporter
+}
+
+func (r *Registry) Get(format string) (Exporter, bool) {
+	exporter, ok := r.exporters[format]
+	return exporter, ok
+}
+
+// Formats returns the registered formats in alphabetical order
+func (r *Registry) Formats() []string {
+	formats := make([]string, 0, len(r.exporters))
+	for format := range r.exporters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// sortedNodes returns the nodes of the DAG sorted by ID, so that the same DAG always exports the same
+func sortedNodes(d *model.DAG) []model.Node {
+	nodes := make([]model.Node, 0, len(d.Nodes))
+	for _, node := range d.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Id.String() < nodes[j].Id.String()
+	})
+	return nodes
+}
+
+// sortedAnswers returns a copy of the answers of the node sorted by ID
+func sortedAnswers(node model.Node) []model.Answer {
+	answers := make([]model.Answer, len(node.Answers))
+	copy(answers, node.Answers)
+	sort.SliceStable(answers, func(i, j int) bool {
+		return answers[i].Id.String() < answers[j].Id.String()
+	})
+	return answers
+}
+
+// isLeaf reports whether a node has no answers leading to another node
+func isLeaf(node model.Node) bool {
+	for _, answer := range node.Answers {
+		if len(answer.Targets()) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type edge struct {
+	from uuid.UUID
+	to   uuid.UUID
+}
+
+// cycleEdges indexes the edges belonging to the cycles of the options
+func (o Options) cycleEdges() map[edge]bool {
+	edges := map[edge]bool{}
+	for _, cycle := range o.Cycles {
+		for i := 1; i < len(cycle); i++ {
+			edges[edge{from: cycle[i-1], to: cycle[i]}] = true
+		}
+	}
+	return edges
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	rootID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	leafID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+)
+
+// fixtureDAG returns a two nodes DAG with a terminal disabled answer on the root node
+func fixtureDAG() *model.DAG {
+	return &model.DAG{
+		Id:    uuid.MustParse("00000000-0000-0000-0000-0000000000aa"),
+		Title: `Dismissal "fast track"`,
+		Nodes: map[uuid.UUID]model.Node{
+			rootID: {
+				Id:       rootID,
+				Question: "Were you dismissed?",
+				Answers: []model.Answer{
+					{Id: uuid.MustParse("00000000-0000-0000-0000-000000000012"), Statement: "No", Disabled: true},
+					{Id: uuid.MustParse("00000000-0000-0000-0000-000000000011"), Statement: "Yes", NextNode: &leafID},
+				},
+			},
+			leafID: {Id: leafID, Question: "In writing?\nPlease check", Answers: []model.Answer{}},
+		},
+	}
+}
+
+// cyclicDAG returns the cyclic fixture along with its single cycle
+func cyclicDAG() (*model.DAG, [][]uuid.UUID) {
+	d := dagtest.Cyclic()
+
+	var start uuid.UUID
+	for id := range d.Nodes {
+		start = id
+		break
+	}
+
+	cycle := []uuid.UUID{start}
+	for next := *d.Nodes[start].Answers[0].NextNode; next != start; next = *d.Nodes[next].Answers[0].NextNode {
+		cycle = append(cycle, next)
+	}
+
+	return d, [][]uuid.UUID{append(cycle, start)}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default registry holds every format", func(t *testing.T) {
+		t.Parallel()
+
+		registry := DefaultRegistry()
+		assert.Equal(t, []string{FormatDOT, FormatGraphML, FormatJSON, FormatMermaid}, registry.Formats())
+
+		for _, format := range registry.Formats() {
+			exporter, ok := registry.Get(format)
+			require.True(t, ok)
+			assert.Equal(t, format, exporter.Format())
+			assert.NotEmpty(t, exporter.ContentType())
+		}
+	})
+
+	t.Run("unknown format is not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := DefaultRegistry().Get("svg")
+		assert.False(t, ok)
+	})
+
+	t.Run("register replaces the exporter of the same format", func(t *testing.T) {
+		t.Parallel()
+
+		registry := NewRegistry(DOT{})
+		registry.Register(JSON{})
+		registry.Register(JSON{})
+
+		assert.Equal(t, []string{FormatDOT, FormatJSON}, registry.Formats())
+	})
+}
+
+func TestJSON_Export(t *testing.T) {
+	t.Parallel()
+
+	d := fixtureDAG()
+	expected, err := d.MarshalCanonicalJSON()
+	require.NoError(t, err)
+
+	data, err := JSON{}.Export(d, Options{HighlightLeaves: true})
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(data))
+}
@@ -0,0 +1,144 @@
+package export
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// GraphML renders a DAG as a GraphML document for graph tools such as yEd or Gephi.
+// Questions and terminal answer ends are nodes told apart by their kind, answers are edges.
+// Highlights are recorded as boolean data for the tool to style
+type GraphML struct{}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	Id          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Data        []graphMLData `xml:"data"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Id     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (GraphML) Format() string {
+	return FormatGraphML
+}
+
+func (GraphML) ContentType() string {
+	return "application/graphml+xml; charset=utf-8"
+}
+
+func (GraphML) Export(d *model.DAG, options Options) ([]byte, error) {
+	cycleEdges := options.cycleEdges()
+
+	doc := graphMLDocument{
+		Xmlns: graphMLNamespace,
+		Keys: []graphMLKey{
+			{Id: "title", For: "graph", AttrName: "title", AttrType: "string"},
+			{Id: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{Id: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+			{Id: "leaf", For: "node", AttrName: "leaf", AttrType: "boolean"},
+			{Id: "statement", For: "edge", AttrName: "statement", AttrType: "string"},
+			{Id: "condition", For: "edge", AttrName: "condition", AttrType: "string"},
+			{Id: "disabled", For: "edge", AttrName: "disabled", AttrType: "boolean"},
+			{Id: "cycle", For: "edge", AttrName: "cycle", AttrType: "boolean"},
+		},
+		Graph: graphMLGraph{
+			Id:          d.Id.String(),
+			EdgeDefault: "directed",
+			Data:        []graphMLData{{Key: "title", Value: d.Title}},
+		},
+	}
+
+	nodes := sortedNodes(d)
+	for _, node := range nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			Id: node.Id.String(),
+			Data: []graphMLData{
+				{Key: "label", Value: node.Question},
+				{Key: "kind", Value: "question"},
+				{Key: "leaf", Value: strconv.FormatBool(options.HighlightLeaves && isLeaf(node))},
+			},
+		})
+	}
+
+	for _, node := range nodes {
+		for _, answer := range sortedAnswers(node) {
+			target := answer.Id.String()
+			if answer.NextNode == nil {
+				doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+					Id:   target,
+					Data: []graphMLData{{Key: "kind", Value: "end"}},
+				})
+			} else {
+				target = answer.NextNode.String()
+			}
+
+			cycle := answer.NextNode != nil && cycleEdges[edge{from: node.Id, to: *answer.NextNode}]
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+				Id:     answer.Id.String(),
+				Source: node.Id.String(),
+				Target: target,
+				Data: []graphMLData{
+					{Key: "statement", Value: answer.Statement},
+					{Key: "disabled", Value: strconv.FormatBool(answer.Disabled)},
+					{Key: "cycle", Value: strconv.FormatBool(cycle)},
+				},
+			})
+
+			for i, branch := range answer.Conditions {
+				doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+					Id:     fmt.Sprintf("%s-%d", answer.Id, i),
+					Source: node.Id.String(),
+					Target: branch.NextNode.String(),
+					Data: []graphMLData{
+						{Key: "statement", Value: answer.Statement},
+						{Key: "condition", Value: branch.When},
+						{Key: "disabled", Value: strconv.FormatBool(answer.Disabled)},
+						{Key: "cycle", Value: strconv.FormatBool(cycleEdges[edge{from: node.Id, to: branch.NextNode}])},
+					},
+				})
+			}
+		}
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal GraphML document: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphML_Export(t *testing.T) {
+	t.Parallel()
+
+	dataOf := func(data []graphMLData) map[string]string {
+		values := map[string]string{}
+		for _, d := range data {
+			values[d.Key] = d.Value
+		}
+		return values
+	}
+
+	t.Run("renders a well-formed GraphML document", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := GraphML{}.Export(fixtureDAG(), Options{HighlightLeaves: true})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+
+		var doc graphMLDocument
+		require.NoError(t, xml.Unmarshal(data, &doc))
+
+		assert.Equal(t, "directed", doc.Graph.EdgeDefault)
+		assert.Equal(t, `Dismissal "fast track"`, dataOf(doc.Graph.Data)["title"])
+
+		require.Len(t, doc.Graph.Nodes, 3)
+		assert.Equal(t, map[string]string{"label": "Were you dismissed?", "kind": "question", "leaf": "false"}, dataOf(doc.Graph.Nodes[0].Data))
+		assert.Equal(t, map[string]string{"label": "In writing?\nPlease check", "kind": "question", "leaf": "true"}, dataOf(doc.Graph.Nodes[1].Data))
+		assert.Equal(t, map[string]string{"kind": "end"}, dataOf(doc.Graph.Nodes[2].Data))
+
+		require.Len(t, doc.Graph.Edges, 2)
+		assert.Equal(t, leafID.String(), doc.Graph.Edges[0].Target)
+		assert.Equal(t, map[string]string{"statement": "No", "disabled": "true", "cycle": "false"}, dataOf(doc.Graph.Edges[1].Data))
+		assert.Equal(t, doc.Graph.Nodes[2].Id, doc.Graph.Edges[1].Target)
+	})
+
+	t.Run("marks cycle edges", func(t *testing.T) {
+		t.Parallel()
+
+		d, cycles := cyclicDAG()
+		data, err := GraphML{}.Export(d, Options{Cycles: cycles})
+		require.NoError(t, err)
+
+		var doc graphMLDocument
+		require.NoError(t, xml.Unmarshal(data, &doc))
+		require.Len(t, doc.Graph.Edges, 3)
+		for _, e := range doc.Graph.Edges {
+			assert.Equal(t, "true", dataOf(e.Data)["cycle"])
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+)
+
+// JSON renders a DAG as its canonical JSON document, options do not apply
+type JSON struct{}
+
+func (JSON) Format() string {
+	return FormatJSON
+}
+
+func (JSON) ContentType() string {
+	return "application/json"
+}
+
+func (JSON) Export(d *model.DAG, _ Options) ([]byte, error) {
+	data, err := d.MarshalCanonicalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal DAG: %w", err)
+	}
+
+	return data, nil
+}
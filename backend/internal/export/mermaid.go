@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Mermaid renders a DAG as a Mermaid flowchart, ready to embed in Markdown documentation.
+// Disabled answers are dotted links and terminal answers point to a small end circle
+type Mermaid struct{}
+
+func (Mermaid) Format() string {
+	return FormatMermaid
+}
+
+func (Mermaid) ContentType() string {
+	return "text/vnd.mermaid; charset=utf-8"
+}
+
+func (Mermaid) Export(d *model.DAG, options Options) ([]byte, error) {
+	cycleEdges := options.cycleEdges()
+	nodes := sortedNodes(d)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "---\ntitle: %s\n---\n", mermaidQuote(d.Title))
+	buf.WriteString("flowchart TD\n")
+
+	var leaves []string
+	for _, node := range nodes {
+		fmt.Fprintf(&buf, "  %s[%s]\n", mermaidId("n", node.Id), mermaidQuote(node.Question))
+		if options.HighlightLeaves && isLeaf(node) {
+			leaves = append(leaves, mermaidId("n", node.Id))
+		}
+	}
+
+	// Links are styled by their declaration index
+	var cycleLinks []string
+	link := 0
+	for _, node := range nodes {
+		for _, answer := range sortedAnswers(node) {
+			arrow := "-->"
+			if answer.Disabled {
+				arrow = "-.->"
+			}
+
+			target := ""
+			if answer.NextNode == nil {
+				target = mermaidId("a", answer.Id) + `((" "))`
+			} else {
+				target = mermaidId("n", *answer.NextNode)
+				if cycleEdges[edge{from: node.Id, to: *answer.NextNode}] {
+					cycleLinks = append(cycleLinks, fmt.Sprint(link))
+				}
+			}
+
+			fmt.Fprintf(&buf, "  %s %s|%s| %s\n", mermaidId("n", node.Id), arrow, mermaidQuote(answer.Statement), target)
+			link++
+
+			// Conditional next nodes are drawn as dotted links labelled with their condition
+			for _, branch := range answer.Conditions {
+				fmt.Fprintf(&buf, "  %s -.->|%s| %s\n", mermaidId("n", node.Id), mermaidQuote(answer.Statement+" when "+branch.When), mermaidId("n", branch.NextNode))
+				link++
+			}
+		}
+	}
+
+	if len(leaves) > 0 {
+		buf.WriteString("  classDef leaf fill:#98fb98\n")
+		fmt.Fprintf(&buf, "  class %s leaf\n", strings.Join(leaves, ","))
+	}
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(&buf, "  linkStyle %s stroke:red,stroke-width:2px\n", strings.Join(cycleLinks, ","))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mermaidId derives a Mermaid node ID from a UUID, Mermaid IDs cannot contain dashes
+func mermaidId(prefix string, id uuid.UUID) string {
+	return prefix + strings.ReplaceAll(id.String(), "-", "")
+}
+
+// mermaidQuote renders a string as a Mermaid quoted label
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\r", "", "\n", "<br/>").Replace(s) + `"`
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMermaid_Export(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renders a flowchart", func(t *testing.T) {
+		t.Parallel()
+
+		expected := `---
+title: "Dismissal #quot;fast track#quot;"
+---
+flowchart TD
+  n00000000000000000000000000000001["Were you dismissed?"]
+  n00000000000000000000000000000002["In writing?<br/>Please check"]
+  n00000000000000000000000000000001 -->|"Yes"| n00000000000000000000000000000002
+  n00000000000000000000000000000001 -.->|"No"| a00000000000000000000000000000012((" "))
+  classDef leaf fill:#98fb98
+  class n00000000000000000000000000000002 leaf
+`
+		data, err := Mermaid{}.Export(fixtureDAG(), Options{HighlightLeaves: true})
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("styles the links of cycles", func(t *testing.T) {
+		t.Parallel()
+
+		d, cycles := cyclicDAG()
+		data, err := Mermaid{}.Export(d, Options{Cycles: cycles})
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(string(data), "  linkStyle 0,1,2 stroke:red,stroke-width:2px\n"))
+		assert.NotContains(t, string(data), "classDef leaf")
+	})
+}
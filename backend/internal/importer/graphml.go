@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const defaultGraphMLTitle = "Imported DAG"
+
+// GraphML reads GraphML documents such as the ones exported by jurigen, yEd or Gephi.
+// Data is matched by the attr.name of its key: nodes carry their question as label, edges their statement as statement or label.
+// Nodes of kind end make their incoming edges terminal answers, edges carrying a condition are conditional next nodes of
+// the answer edge whose ID prefixes theirs. Non UUID identifiers are replaced by fresh UUIDs
+type GraphML struct{}
+
+type graphMLDocument struct {
+	Keys  []graphMLKey `xml:"key"`
+	Graph struct {
+		Id    string        `xml:"id,attr"`
+		Data  []graphMLData `xml:"data"`
+		Nodes []struct {
+			Id   string        `xml:"id,attr"`
+			Data []graphMLData `xml:"data"`
+		} `xml:"node"`
+		Edges []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+type graphMLEdge struct {
+	Id     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	AttrName string `xml:"attr.name,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (GraphML) Format() string {
+	return FormatGraphML
+}
+
+func (GraphML) Detect(data []byte) bool {
+	return bytes.Contains(data, []byte("<graphml"))
+}
+
+func (GraphML) Import(data []byte) (*model.DAG, error) {
+	var doc graphMLDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	attrNames := make(map[string]string, len(doc.Keys))
+	for _, key := range doc.Keys {
+		attrNames[key.Id] = key.AttrName
+	}
+	values := func(data []graphMLData) map[string]string {
+		byName := make(map[string]string, len(data))
+		for _, d := range data {
+			name, ok := attrNames[d.Key]
+			if !ok {
+				name = d.Key
+			}
+			byName[name] = d.Value
+		}
+		return byName
+	}
+
+	ids := map[string]uuid.UUID{}
+	idFor := func(id string) uuid.UUID {
+		if parsed, ok := ids[id]; ok {
+			return parsed
+		}
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			parsed = uuid.New()
+		}
+		ids[id] = parsed
+		return parsed
+	}
+
+	graph := values(doc.Graph.Data)
+	d := &model.DAG{
+		Id:    idFor(doc.Graph.Id),
+		Title: graph["title"],
+		Nodes: map[uuid.UUID]model.Node{},
+	}
+	if d.Title == "" {
+		d.Title = defaultGraphMLTitle
+	}
+
+	ends := map[string]bool{}
+	for _, node := range doc.Graph.Nodes {
+		nodeData := values(node.Data)
+		if nodeData["kind"] == "end" {
+			ends[node.Id] = true
+			continue
+		}
+
+		id := idFor(node.Id)
+		if _, exists := d.Nodes[id]; exists {
+			return nil, fmt.Errorf("duplicate node %q", node.Id)
+		}
+		d.Nodes[id] = model.Node{Id: id, Question: firstValue(nodeData, "label", "question"), Answers: []model.Answer{}}
+	}
+
+	// Conditional edges are attached to the answer their ID derives from once every answer is read
+	var conditional []graphMLEdge
+	for _, edge := range doc.Graph.Edges {
+		source, ok := d.Nodes[idFor(edge.Source)]
+		if !ok {
+			return nil, fmt.Errorf("edge %q leaves unknown node %q", edge.Id, edge.Source)
+		}
+
+		edgeData := values(edge.Data)
+		if edgeData["condition"] != "" {
+			conditional = append(conditional, edge)
+			continue
+		}
+
+		answer := model.Answer{
+			Id:        uuid.New(),
+			Statement: firstValue(edgeData, "statement", "label"),
+		}
+		if edge.Id != "" {
+			answer.Id = idFor(edge.Id)
+		}
+		if disabled, err := strconv.ParseBool(edgeData["disabled"]); err == nil {
+			answer.Disabled = disabled
+		}
+
+		if !ends[edge.Target] {
+			target := idFor(edge.Target)
+			if _, ok := d.Nodes[target]; !ok {
+				return nil, fmt.Errorf("edge %q enters unknown node %q", edge.Id, edge.Target)
+			}
+			answer.NextNode = &target
+		}
+
+		source.Answers = append(source.Answers, answer)
+		d.Nodes[source.Id] = source
+	}
+
+	for _, edge := range conditional {
+		// The ID of a conditional edge is the ID of its answer edge suffixed by the condition index
+		answerEdgeId := edge.Id
+		if i := strings.LastIndex(edge.Id, "-"); i > 0 {
+			answerEdgeId = edge.Id[:i]
+		}
+
+		target := idFor(edge.Target)
+		if _, ok := d.Nodes[target]; !ok {
+			return nil, fmt.Errorf("edge %q enters unknown node %q", edge.Id, edge.Target)
+		}
+
+		source := d.Nodes[idFor(edge.Source)]
+		attached := false
+		for i, answer := range source.Answers {
+			if answer.Id == idFor(answerEdgeId) {
+				source.Answers[i].Conditions = append(answer.Conditions, model.Branch{When: values(edge.Data)["condition"], NextNode: target})
+				attached = true
+				break
+			}
+		}
+		if !attached {
+			return nil, fmt.Errorf("conditional edge %q has no answer edge %q", edge.Id, answerEdgeId)
+		}
+	}
+
+	// Wire the answers to their parent node, as the JSON document does
+	for id, node := range d.Nodes {
+		nodeCopy := node
+		for i := range nodeCopy.Answers {
+			nodeCopy.Answers[i].ParentNode = &nodeCopy
+		}
+		d.Nodes[id] = nodeCopy
+	}
+
+	return d, nil
+}
+
+// firstValue returns the first non empty value among the names
+func firstValue(values map[string]string, names ...string) string {
+	for _, name := range names {
+		if value := values[name]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
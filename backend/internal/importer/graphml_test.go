@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/export"
+	"davidterranova/jurigen/backend/internal/model"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphML_Import(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads back a jurigen GraphML export", func(t *testing.T) {
+		t.Parallel()
+
+		d := dagtest.ValidSingleRoot()
+		data, err := export.GraphML{}.Export(d, export.Options{})
+		require.NoError(t, err)
+
+		imported, err := GraphML{}.Import(data)
+		require.NoError(t, err)
+
+		assert.Equal(t, d.Id, imported.Id)
+		assert.Equal(t, d.Title, imported.Title)
+		require.Len(t, imported.Nodes, len(d.Nodes))
+		for id, node := range d.Nodes {
+			importedNode := imported.Nodes[id]
+			assert.Equal(t, node.Question, importedNode.Question)
+			require.Len(t, importedNode.Answers, len(node.Answers))
+			for i, answer := range sortedById(node.Answers) {
+				importedAnswer := importedNode.Answers[i]
+				assert.Equal(t, answer.Id, importedAnswer.Id)
+				assert.Equal(t, answer.Statement, importedAnswer.Statement)
+				assert.Equal(t, answer.NextNode, importedAnswer.NextNode)
+				require.NotNil(t, importedAnswer.ParentNode)
+				assert.Equal(t, id, importedAnswer.ParentNode.Id)
+			}
+		}
+	})
+
+	t.Run("reads back conditional next nodes", func(t *testing.T) {
+		t.Parallel()
+
+		d := dagtest.ValidSingleRoot()
+		root := dagtest.Root(d)
+		root.Answers[0].Conditions = []model.Branch{{When: "confidence > 0.7", NextNode: *root.Answers[1].NextNode}}
+		d.Nodes[root.Id] = root
+
+		data, err := export.GraphML{}.Export(d, export.Options{})
+		require.NoError(t, err)
+
+		imported, err := GraphML{}.Import(data)
+		require.NoError(t, err)
+
+		importedRoot := dagtest.Root(imported)
+		require.Len(t, importedRoot.Answers, len(root.Answers))
+		for _, answer := range importedRoot.Answers {
+			if answer.Id == root.Answers[0].Id {
+				assert.Equal(t, root.Answers[0].Conditions, answer.Conditions)
+			} else {
+				assert.Empty(t, answer.Conditions)
+			}
+		}
+	})
+
+	t.Run("reads a foreign GraphML document", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="label" attr.type="string"/>
+  <key id="d1" for="edge" attr.name="label" attr.type="string"/>
+  <graph id="G" edgedefault="directed">
+    <node id="n0"><data key="d0">Were you dismissed?</data></node>
+    <node id="n1"><data key="d0">In writing?</data></node>
+    <edge id="e0" source="n0" target="n1"><data key="d1">Yes</data></edge>
+  </graph>
+</graphml>`)
+
+		imported, err := GraphML{}.Import(data)
+		require.NoError(t, err)
+
+		assert.Equal(t, defaultGraphMLTitle, imported.Title)
+		require.Len(t, imported.Nodes, 2)
+
+		root, err := imported.GetRootNode()
+		require.NoError(t, err)
+		assert.Equal(t, "Were you dismissed?", root.Question)
+		require.Len(t, root.Answers, 1)
+		assert.Equal(t, "Yes", root.Answers[0].Statement)
+		assert.Equal(t, "In writing?", imported.Nodes[*root.Answers[0].NextNode].Question)
+	})
+
+	t.Run("rejects edges to unknown nodes", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := GraphML{}.Import([]byte(`<graphml><graph><node id="n0"/><edge source="n0" target="n9"/></graph></graphml>`))
+		assert.ErrorContains(t, err, "unknown node")
+	})
+}
+
+// sortedById returns the answers in the order of the GraphML export
+func sortedById(answers []model.Answer) []model.Answer {
+	sorted := append([]model.Answer(nil), answers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Id.String() < sorted[j].Id.String()
+	})
+	return sorted
+}
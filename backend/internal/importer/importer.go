@@ -0,0 +1,115 @@
+// Package importer converts DAGs authored with other tools into the internal model.
+// Every format is an Importer registered by name, the registry detects the format of a document when none is given.
+package importer
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatYAML    = "yaml"
+	FormatGraphML = "graphml"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown import format")
+	ErrMalformed     = errors.New("malformed document")
+)
+
+// Importer converts a document of a single format into a DAG
+type Importer interface {
+	// Format is the name the importer is registered under
+	Format() string
+	// Detect reports whether the document looks like this format
+	Detect(data []byte) bool
+	Import(data []byte) (*model.DAG, error)
+}
+
+// Registry holds the available importers, in detection order
+type Registry struct {
+	importers []Importer
+}
+
+func NewRegistry(importers ...Importer) *Registry {
+	r := &Registry{}
+	for _, importer := range importers {
+		r.Register(importer)
+	}
+	return r
+}
+
+// DefaultRegistry returns a registry holding every format of this package.
+// YAML comes last as most documents parse as YAML
+func DefaultRegistry() *Registry {
+	return NewRegistry(JSON{}, GraphML{}, YAML{})
+}
+
+// Register adds an importer, replacing the one registered under the same format
+func (r *Registry) Register(importer Importer) {
+	for i, existing := range r.importers {
+		if existing.Format() == importer.Format() {
+			r.importers[i] = importer
+			return
+		}
+	}
+	r.importers = append(r.importers, importer)
+}
+
+func (r *Registry) Get(format string) (Importer, bool) {
+	for _, importer := range r.importers {
+		if importer.Format() == format {
+			return importer, true
+		}
+	}
+	return nil, false
+}
+
+// Detect returns the first importer recognizing the document
+func (r *Registry) Detect(data []byte) (Importer, bool) {
+	for _, importer := range r.importers {
+		if importer.Detect(data) {
+			return importer, true
+		}
+	}
+	return nil, false
+}
+
+// Formats returns the registered formats in alphabetical order
+func (r *Registry) Formats() []string {
+	formats := make([]string, 0, len(r.importers))
+	for _, importer := range r.importers {
+		formats = append(formats, importer.Format())
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Import converts a document with the importer of the format, detecting the format when empty
+func (r *Registry) Import(format string, data []byte) (*model.DAG, string, error) {
+	var (
+		importer Importer
+		ok       bool
+	)
+	if format == "" {
+		importer, ok = r.Detect(data)
+		if !ok {
+			return nil, "", fmt.Errorf("%w: cannot detect the format of the document, supported formats: %v", ErrUnknownFormat, r.Formats())
+		}
+	} else {
+		importer, ok = r.Get(format)
+		if !ok {
+			return nil, "", fmt.Errorf("%w: %q, supported formats: %v", ErrUnknownFormat, format, r.Formats())
+		}
+	}
+
+	d, err := importer.Import(data)
+	if err != nil {
+		return nil, importer.Format(), fmt.Errorf("%w: %s", ErrMalformed, err)
+	}
+
+	return d, importer.Format(), nil
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/export"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlDocument = `
+id: 00000000-0000-0000-0000-0000000000aa
+title: Dismissal
+nodes:
+  - id: 00000000-0000-0000-0000-000000000001
+    question: Were you dismissed?
+    answers:
+      - id: 00000000-0000-0000-0000-000000000011
+        answer: "Yes"
+        next_node: 00000000-0000-0000-0000-000000000002
+      - id: 00000000-0000-0000-0000-000000000012
+        answer: "No"
+  - id: 00000000-0000-0000-0000-000000000002
+    question: In writing?
+    answers: []
+`
+
+func TestRegistry_Import(t *testing.T) {
+	t.Parallel()
+
+	d := dagtest.ValidSingleRoot()
+	jsonData, err := d.MarshalJSON()
+	require.NoError(t, err)
+	graphMLData, err := export.GraphML{}.Export(d, export.Options{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		format         string
+		data           []byte
+		expectedFormat string
+		errorType      error
+	}{
+		{name: "detects JSON", data: jsonData, expectedFormat: FormatJSON},
+		{name: "detects GraphML", data: graphMLData, expectedFormat: FormatGraphML},
+		{name: "detects YAML", data: []byte(yamlDocument), expectedFormat: FormatYAML},
+		{name: "uses the given format", format: FormatYAML, data: jsonData, expectedFormat: FormatYAML},
+		{name: "rejects unknown format", format: "csv", data: jsonData, errorType: ErrUnknownFormat},
+		{name: "rejects undetectable document", data: []byte("just some text"), errorType: ErrUnknownFormat},
+		{name: "rejects malformed document", format: FormatJSON, data: []byte("{"), errorType: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			imported, format, err := DefaultRegistry().Import(tt.format, tt.data)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFormat, format)
+			assert.NotEmpty(t, imported.Nodes)
+		})
+	}
+}
+
+func TestRegistry_Formats(t *testing.T) {
+	t.Parallel()
+
+	registry := DefaultRegistry()
+	assert.Equal(t, []string{FormatGraphML, FormatJSON, FormatYAML}, registry.Formats())
+
+	registry.Register(JSON{})
+	assert.Equal(t, []string{FormatGraphML, FormatJSON, FormatYAML}, registry.Formats())
+}
+
+func TestYAML_Import(t *testing.T) {
+	t.Parallel()
+
+	d, err := YAML{}.Import([]byte(yamlDocument))
+	require.NoError(t, err)
+
+	assert.Equal(t, "00000000-0000-0000-0000-0000000000aa", d.Id.String())
+	assert.Equal(t, "Dismissal", d.Title)
+	require.Len(t, d.Nodes, 2)
+
+	root, err := d.GetRootNode()
+	require.NoError(t, err)
+	assert.Equal(t, "Were you dismissed?", root.Question)
+	require.Len(t, root.Answers, 2)
+	assert.Equal(t, "Yes", root.Answers[0].Statement)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", root.Answers[0].NextNode.String())
+	assert.Nil(t, root.Answers[1].NextNode)
+}
@@ -0,0 +1,26 @@
+package importer
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
+)
+
+// JSON reads the DAG JSON document served and stored by jurigen
+type JSON struct{}
+
+func (JSON) Format() string {
+	return FormatJSON
+}
+
+func (JSON) Detect(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+func (JSON) Import(data []byte) (*model.DAG, error) {
+	var d model.DAG
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,28 @@
+package importer
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML reads the DAG document in YAML, with the same fields as the JSON document
+type YAML struct{}
+
+func (YAML) Format() string {
+	return FormatYAML
+}
+
+// Detect accepts any document holding a YAML mapping
+func (YAML) Detect(data []byte) bool {
+	var document map[string]interface{}
+	return yaml.Unmarshal(data, &document) == nil && len(document) > 0
+}
+
+func (YAML) Import(data []byte) (*model.DAG, error) {
+	var d model.DAG
+	if err := yaml.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
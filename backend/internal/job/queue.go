@@ -0,0 +1,144 @@
+// Package job runs long-running tasks, such as the validation of large DAGs, in the background.
+// Jobs are queued and run by a pool of workers with the handler registered for their type, their progress
+// and result are recorded in a job repository for the clients to poll.
+package job
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Config tunes the queue, zero values select the defaults
+type Config struct {
+	// Workers is the number of jobs run at the same time, 2 by default
+	Workers int
+	// QueueSize is the number of jobs waiting for a worker, 64 by default
+	QueueSize int
+}
+
+func (c Config) withDefaults() Config {
+	if c.Workers <= 0 {
+		c.Workers = 2
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 64
+	}
+	return c
+}
+
+// Handler runs a job and returns its result
+type Handler func(ctx context.Context, job model.Job) (interface{}, error)
+
+// Queue runs the enqueued jobs in the background
+type Queue struct {
+	jobs   usecase.JobRepository
+	config Config
+	queue  chan model.Job
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewQueue(jobs usecase.JobRepository, config Config) *Queue {
+	config = config.withDefaults()
+
+	return &Queue{
+		jobs:     jobs,
+		config:   config,
+		queue:    make(chan model.Job, config.QueueSize),
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle registers the handler of a job type, replacing any previous one
+func (q *Queue) Handle(jobType string, handler func(ctx context.Context, job model.Job) (interface{}, error)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.handlers[jobType] = handler
+}
+
+// Enqueue records the pending job and queues it. Jobs of an unknown type are rejected, jobs enqueued while
+// the queue is full are recorded as failed and rejected.
+func (q *Queue) Enqueue(ctx context.Context, job model.Job) error {
+	if q.handler(job.Type) == nil {
+		return fmt.Errorf("%w: no handler for jobs of type %q", usecase.ErrInvalidCommand, job.Type)
+	}
+
+	if err := q.jobs.Save(ctx, job); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+
+	select {
+	case q.queue <- job:
+		return nil
+	default:
+	}
+
+	job.Finish(nil, fmt.Errorf("job queue is full"), time.Now())
+	if err := q.jobs.Save(ctx, job); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Str("job_id", job.Id.String()).Msg("failed to save job")
+	}
+	return fmt.Errorf("%w: job queue is full", usecase.ErrUnavailable)
+}
+
+// Run runs the queued jobs until ctx is cancelled, the jobs in progress are then cancelled and fail.
+// The jobs still queued are left pending.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < q.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-q.queue:
+					q.run(ctx, job)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// run runs a job with the handler of its type and records its outcome
+func (q *Queue) run(ctx context.Context, job model.Job) {
+	logger := xlog.Ctx(ctx).With().
+		Str("job_id", job.Id.String()).
+		Str("job_type", job.Type).
+		Logger()
+
+	job.Start(time.Now())
+	q.save(ctx, job)
+
+	result, err := q.handler(job.Type)(ctx, job)
+	job.Finish(result, err, time.Now())
+	q.save(ctx, job)
+
+	if err != nil {
+		logger.Warn().Err(err).Msg("job failed")
+		return
+	}
+	logger.Debug().Dur("duration", job.FinishedAt.Sub(*job.StartedAt)).Msg("job succeeded")
+}
+
+// save records the progress of a job, even when ctx is cancelled so that interrupted jobs are marked as failed
+func (q *Queue) save(ctx context.Context, job model.Job) {
+	if err := q.jobs.Save(context.WithoutCancel(ctx), job); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Str("job_id", job.Id.String()).Msg("failed to save job")
+	}
+}
+
+func (q *Queue) handler(jobType string) Handler {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.handlers[jobType]
+}
@@ -0,0 +1,139 @@
+package job
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/port"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJobType = "test.echo"
+
+// waitJob polls the repository until the job is done
+func waitJob(t *testing.T, jobs *port.InMemoryJobRepository, id uuid.UUID) model.Job {
+	t.Helper()
+
+	var job *model.Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = jobs.Get(context.Background(), id)
+		return err == nil && job.Done()
+	}, time.Second, 5*time.Millisecond)
+	return *job
+}
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs the jobs and records their result", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := port.NewInMemoryJobRepository(0)
+		queue := NewQueue(jobs, Config{})
+		queue.Handle(testJobType, func(ctx context.Context, job model.Job) (interface{}, error) {
+			return job.DAGId.String(), nil
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go queue.Run(ctx)
+
+		job := model.NewJob(testJobType, uuid.New(), time.Now())
+		require.NoError(t, queue.Enqueue(context.Background(), job))
+
+		done := waitJob(t, jobs, job.Id)
+		assert.Equal(t, model.JobStatusSucceeded, done.Status)
+		assert.Equal(t, job.DAGId.String(), done.Result)
+		require.NotNil(t, done.StartedAt)
+		require.NotNil(t, done.FinishedAt)
+		assert.False(t, done.FinishedAt.Before(*done.StartedAt))
+	})
+
+	t.Run("records the error of failed jobs", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := port.NewInMemoryJobRepository(0)
+		queue := NewQueue(jobs, Config{})
+		queue.Handle(testJobType, func(ctx context.Context, job model.Job) (interface{}, error) {
+			return nil, errors.New("DAG is gone")
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go queue.Run(ctx)
+
+		job := model.NewJob(testJobType, uuid.New(), time.Now())
+		require.NoError(t, queue.Enqueue(context.Background(), job))
+
+		done := waitJob(t, jobs, job.Id)
+		assert.Equal(t, model.JobStatusFailed, done.Status)
+		assert.Equal(t, "DAG is gone", done.Error)
+		assert.Nil(t, done.Result)
+	})
+
+	t.Run("rejects jobs of an unknown type", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := port.NewInMemoryJobRepository(0)
+		job := model.NewJob("unknown", uuid.New(), time.Now())
+
+		err := NewQueue(jobs, Config{}).Enqueue(context.Background(), job)
+		assert.ErrorIs(t, err, usecase.ErrInvalidCommand)
+		_, err = jobs.Get(context.Background(), job.Id)
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+	})
+
+	t.Run("rejects jobs once the queue is full", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := port.NewInMemoryJobRepository(0)
+		queue := NewQueue(jobs, Config{QueueSize: 1})
+		queue.Handle(testJobType, func(ctx context.Context, job model.Job) (interface{}, error) { return nil, nil })
+
+		queued := model.NewJob(testJobType, uuid.New(), time.Now())
+		require.NoError(t, queue.Enqueue(context.Background(), queued))
+		rejected := model.NewJob(testJobType, uuid.New(), time.Now())
+		assert.ErrorIs(t, queue.Enqueue(context.Background(), rejected), usecase.ErrUnavailable)
+
+		stored, err := jobs.Get(context.Background(), queued.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.JobStatusPending, stored.Status)
+		stored, err = jobs.Get(context.Background(), rejected.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.JobStatusFailed, stored.Status)
+	})
+
+	t.Run("fails the jobs in progress when stopped", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := port.NewInMemoryJobRepository(0)
+		queue := NewQueue(jobs, Config{})
+		started := make(chan struct{})
+		queue.Handle(testJobType, func(ctx context.Context, job model.Job) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		go func() {
+			queue.Run(ctx)
+			close(stopped)
+		}()
+
+		job := model.NewJob(testJobType, uuid.New(), time.Now())
+		require.NoError(t, queue.Enqueue(context.Background(), job))
+		<-started
+		cancel()
+		<-stopped
+
+		done := waitJob(t, jobs, job.Id)
+		assert.Equal(t, model.JobStatusFailed, done.Status)
+		assert.Equal(t, context.Canceled.Error(), done.Error)
+	})
+}
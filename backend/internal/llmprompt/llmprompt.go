@@ -0,0 +1,136 @@
+// Package llmprompt turns case context documents into chat messages for large language models.
+// Messages are rendered from text/template sources, so that deployments can adapt the wording
+// without reimplementing the formatting of the case context.
+package llmprompt
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	RoleSystem = "system"
+	RoleUser   = "user"
+)
+
+var ErrInvalidTemplate = errors.New("invalid prompt template")
+
+// Message is a chat message sent to a language model
+type Message struct {
+	Role    string
+	Content string
+}
+
+// Template holds the text/template sources of the system and user messages.
+// Both templates receive a Data value.
+type Template struct {
+	System string
+	User   string
+}
+
+// Data is what prompt templates are rendered with: the fields of the context document
+// (Title, DAGId, SessionId, GeneratedAt, Entries) and the task given to the model
+type Data struct {
+	*model.ContextDocument
+	// Task is what the model is asked to do with the case context, empty to leave it to the template
+	Task string
+}
+
+const defaultSystemTemplate = `You are a legal assistant analysing a client's case.
+The case context below was gathered by walking the "{{.Title}}" questionnaire: each question was answered by the client, sometimes with additional context, a confidence level between 0 and 1, tags and evidence sources.
+Base your analysis on this context only, state the assumptions you make and point out the information that is missing.`
+
+const defaultUserTemplate = `Case context ({{len .Entries}} questions answered):
+{{range $i, $entry := .Entries}}
+{{inc $i}}. Question: {{$entry.Question}}
+   Answer: {{$entry.Answer}}
+{{- if $entry.UserContext}}
+   Context: {{$entry.UserContext}}
+{{- end}}
+{{- if $entry.Confidence}}
+   Confidence: {{confidence $entry.Confidence}}
+{{- end}}
+{{- if $entry.Tags}}
+   Tags: {{join $entry.Tags ", "}}
+{{- end}}
+{{- if $entry.Sources}}
+   Evidence sources: {{join $entry.Sources ", "}}
+{{- end}}
+{{end}}
+{{- if .Task}}
+Task: {{.Task}}
+{{- else}}
+Task: Summarize the legal situation of the client, the claims they may have and the next steps to take.
+{{- end}}`
+
+// DefaultTemplate returns the templates used when none is configured
+func DefaultTemplate() Template {
+	return Template{
+		System: defaultSystemTemplate,
+		User:   defaultUserTemplate,
+	}
+}
+
+// WithOverrides returns the template with the non-empty sources of overrides replacing its own
+func (t Template) WithOverrides(overrides Template) Template {
+	if overrides.System != "" {
+		t.System = overrides.System
+	}
+	if overrides.User != "" {
+		t.User = overrides.User
+	}
+	return t
+}
+
+var funcs = template.FuncMap{
+	"inc":  func(i int) int { return i + 1 },
+	"join": strings.Join,
+	"confidence": func(confidence *float64) string {
+		if confidence == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*confidence, 'f', 2, 64)
+	},
+}
+
+// Build renders the system and user messages of a prompt, skipping a message whose template renders blank
+func Build(t Template, data Data) ([]Message, error) {
+	messages := make([]Message, 0, 2)
+	for _, part := range []struct {
+		role   string
+		source string
+	}{
+		{RoleSystem, t.System},
+		{RoleUser, t.User},
+	} {
+		content, err := render(part.role, part.source, data)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		messages = append(messages, Message{Role: part.role, Content: content})
+	}
+
+	return messages, nil
+}
+
+func render(name string, source string, data Data) (string, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s template: %s", ErrInvalidTemplate, name, err)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("%w: %s template: %s", ErrInvalidTemplate, name, err)
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,78 @@
+package llmprompt
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contextDocument() *model.ContextDocument {
+	confidence := 0.8
+	sessionId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	return &model.ContextDocument{
+		Title:       "Employment",
+		DAGId:       uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		SessionId:   &sessionId,
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		Entries: []model.ContextDocumentEntry{
+			{
+				Question:    "Were you dismissed?",
+				Answer:      "Yes",
+				UserContext: "Without notice",
+				Confidence:  &confidence,
+				Tags:        []string{"dismissal", "notice"},
+				Sources:     []string{"letter.pdf"},
+			},
+			{Question: "Did you sign a contract?", Answer: "No"},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("renders the default templates", func(t *testing.T) {
+		messages, err := Build(DefaultTemplate(), Data{ContextDocument: contextDocument()})
+		require.NoError(t, err)
+
+		require.Len(t, messages, 2)
+		assert.Equal(t, RoleSystem, messages[0].Role)
+		assert.Contains(t, messages[0].Content, `"Employment" questionnaire`)
+
+		assert.Equal(t, RoleUser, messages[1].Role)
+		user := messages[1].Content
+		assert.Contains(t, user, "Case context (2 questions answered):")
+		assert.Contains(t, user, "1. Question: Were you dismissed?\n   Answer: Yes\n   Context: Without notice\n   Confidence: 0.80\n   Tags: dismissal, notice\n   Evidence sources: letter.pdf")
+		assert.Contains(t, user, "2. Question: Did you sign a contract?\n   Answer: No\n")
+		assert.Contains(t, user, "Task: Summarize the legal situation")
+	})
+
+	t.Run("uses the given task", func(t *testing.T) {
+		messages, err := Build(DefaultTemplate(), Data{ContextDocument: contextDocument(), Task: "Draft a demand letter."})
+		require.NoError(t, err)
+
+		assert.Contains(t, messages[1].Content, "Task: Draft a demand letter.")
+		assert.NotContains(t, messages[1].Content, "Summarize")
+	})
+
+	t.Run("overrides templates and skips blank messages", func(t *testing.T) {
+		tmpl := DefaultTemplate().WithOverrides(Template{System: " ", User: "{{.Title}}: {{range .Entries}}{{.Answer}};{{end}}"})
+		tmpl.System = "{{if .Task}}{{.Task}}{{end}}"
+
+		messages, err := Build(tmpl, Data{ContextDocument: contextDocument()})
+		require.NoError(t, err)
+
+		require.Len(t, messages, 1)
+		assert.Equal(t, Message{Role: RoleUser, Content: "Employment: Yes;No;"}, messages[0])
+	})
+
+	t.Run("rejects invalid templates", func(t *testing.T) {
+		_, err := Build(Template{User: "{{.Title"}, Data{ContextDocument: contextDocument()})
+		assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+		_, err = Build(Template{User: "{{.Unknown}}"}, Data{ContextDocument: contextDocument()})
+		assert.ErrorIs(t, err, ErrInvalidTemplate)
+	})
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChecksumAlgorithm prefixes the checksums of the attachments
+const ChecksumAlgorithm = "sha256"
+
+// Attachment is a piece of evidence uploaded for an answer of a case session, its content being kept in the
+// attachment storage
+type Attachment struct {
+	Id       uuid.UUID `json:"id"`
+	AnswerId uuid.UUID `json:"answer_id"`
+	FileName string    `json:"file_name"`
+	// ContentType is detected from the content, not taken from the upload
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Checksum is the digest of the content, e.g. "sha256:9f86d0..."
+	Checksum   string    `json:"checksum"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// Checksum returns the digest of attachment content, prefixed with its algorithm
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return ChecksumAlgorithm + ":" + hex.EncodeToString(sum[:])
+}
+
+// StorageKey locates the content of an attachment in the attachment storage
+func (a Attachment) StorageKey(sessionId uuid.UUID) string {
+	return fmt.Sprintf("%s/%s", sessionId, a.Id)
+}
+
+// Attach records an attachment on an answer given during the session
+func (s *CaseSession) Attach(attachment Attachment, now time.Time) error {
+	if !s.HasAnswer(attachment.AnswerId) {
+		return fmt.Errorf("answer %s was not given during session %s", attachment.AnswerId, s.Id)
+	}
+
+	s.Attachments = append(s.Attachments, attachment)
+	s.UpdatedAt = now
+	return nil
+}
+
+// HasAnswer tells whether an answer was given during the session
+func (s CaseSession) HasAnswer(answerId uuid.UUID) bool {
+	for _, answer := range s.Answers {
+		if answer.AnswerId == answerId {
+			return true
+		}
+	}
+	return false
+}
+
+// Attachment returns the attachment of the given ID
+func (s CaseSession) Attachment(id uuid.UUID) (Attachment, bool) {
+	for _, attachment := range s.Attachments {
+		if attachment.Id == id {
+			return attachment, true
+		}
+	}
+	return Attachment{}, false
+}
+
+// AttachmentsOf returns the attachments of an answer, in upload order
+func (s CaseSession) AttachmentsOf(answerId uuid.UUID) []Attachment {
+	var attachments []Attachment
+	for _, attachment := range s.Attachments {
+		if attachment.AnswerId == answerId {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments
+}
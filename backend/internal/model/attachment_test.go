@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", Checksum([]byte("test")))
+}
+
+func TestCaseSession_Attach(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	d, rootId, _ := newSessionDAG()
+	session, err := NewCaseSession(d, now)
+	require.NoError(t, err)
+	answerId := d.Nodes[rootId].Answers[0].Id
+	require.NoError(t, session.Answer(d, answerId, "", nil, now))
+
+	first := Attachment{Id: uuid.New(), AnswerId: answerId, FileName: "letter.pdf"}
+	second := Attachment{Id: uuid.New(), AnswerId: answerId, FileName: "email.png"}
+	later := now.Add(time.Minute)
+	require.NoError(t, session.Attach(first, now))
+	require.NoError(t, session.Attach(second, later))
+	assert.Equal(t, later, session.UpdatedAt)
+
+	assert.Equal(t, []Attachment{first, second}, session.AttachmentsOf(answerId))
+	assert.Empty(t, session.AttachmentsOf(uuid.New()))
+	got, ok := session.Attachment(second.Id)
+	assert.True(t, ok)
+	assert.Equal(t, second, got)
+	_, ok = session.Attachment(uuid.New())
+	assert.False(t, ok)
+	assert.Equal(t, session.Id.String()+"/"+first.Id.String(), first.StorageKey(session.Id))
+
+	err = session.Attach(Attachment{Id: uuid.New(), AnswerId: uuid.New()}, now)
+	assert.Error(t, err)
+	assert.Len(t, session.Attachments, 2)
+}
@@ -0,0 +1,278 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionStatus is the progress of a case session
+type SessionStatus string
+
+const (
+	SessionStatusInProgress SessionStatus = "in_progress"
+	SessionStatusCompleted  SessionStatus = "completed"
+)
+
+// SessionAnswer is an answer given during a case session, with the context the user attached to it
+type SessionAnswer struct {
+	NodeId   uuid.UUID `json:"node_id"`
+	AnswerId uuid.UUID `json:"answer_id"`
+	// Value is the value entered on an input node
+	Value       string                 `json:"value,omitempty"`
+	UserContext string                 `json:"user_context,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	AnsweredAt  time.Time              `json:"answered_at"`
+}
+
+// Assessment is the legal assessment of a completed case session, as returned by a language model
+type Assessment struct {
+	// Provider and Model identify the language model which wrote the assessment
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CaseSession is a walkthrough of a DAG by a user, answered one question at a time
+type CaseSession struct {
+	Id      uuid.UUID       `json:"id"`
+	DAGId   uuid.UUID       `json:"dag_id"`
+	Answers []SessionAnswer `json:"answers"`
+	Status  SessionStatus   `json:"status"`
+	// CurrentNodeId is the question to answer next, nil once the walk reached its end
+	CurrentNodeId *uuid.UUID `json:"current_node_id,omitempty"`
+	// Assessment is the latest legal assessment of the session, nil until one is requested
+	Assessment *Assessment `json:"assessment,omitempty"`
+	// Attachments are the evidence uploaded for the answers, in upload order
+	Attachments []Attachment `json:"attachments,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// NewCaseSession starts a session on the root node of a DAG
+func NewCaseSession(d *DAG, now time.Time) (*CaseSession, error) {
+	root, err := d.GetRootNode()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &CaseSession{
+		Id:        uuid.New(),
+		DAGId:     d.Id,
+		Answers:   []SessionAnswer{},
+		Status:    SessionStatusInProgress,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	session.moveTo(d, &root.Id)
+
+	return session, nil
+}
+
+// Answer records the answer given to the current question and moves to the question it leads to,
+// the conditions of the answer being evaluated against the metadata collected during the session
+func (s *CaseSession) Answer(d *DAG, answerId uuid.UUID, userContext string, metadata map[string]interface{}, now time.Time) error {
+	return s.AnswerMany(d, []uuid.UUID{answerId}, userContext, metadata, now)
+}
+
+// AnswerMany records the answers selected on the current question, several of them being allowed on
+// multi-select questions, and moves to the question resolved by the next rule of the node. The user
+// context and metadata are recorded along with each answer.
+func (s *CaseSession) AnswerMany(d *DAG, answerIds []uuid.UUID, userContext string, metadata map[string]interface{}, now time.Time) error {
+	if s.Status != SessionStatusInProgress {
+		return fmt.Errorf("session %s is %s", s.Id, s.Status)
+	}
+	if s.CurrentNodeId == nil {
+		return fmt.Errorf("session %s has no question left to answer", s.Id)
+	}
+
+	node, err := d.GetNode(*s.CurrentNodeId)
+	if err != nil {
+		return fmt.Errorf("error getting node %s: %w", *s.CurrentNodeId, err)
+	}
+
+	if node.Kind() == NodeTypeInput {
+		return fmt.Errorf("node %s expects an input value", node.Id)
+	}
+
+	selected := make([]Answer, 0, len(answerIds))
+	for _, answerId := range answerIds {
+		answer, ok := findAnswer(node, answerId)
+		if !ok {
+			return fmt.Errorf("answer %s is not valid for node %s", answerId, node.Id)
+		}
+		selected = append(selected, answer)
+	}
+	if err := node.checkSelection(selected); err != nil {
+		return err
+	}
+
+	answered := len(s.Answers)
+	for _, answerId := range answerIds {
+		s.Answers = append(s.Answers, SessionAnswer{
+			NodeId:      node.Id,
+			AnswerId:    answerId,
+			UserContext: userContext,
+			Metadata:    metadata,
+			AnsweredAt:  now,
+		})
+	}
+
+	next, err := node.ResolveNext(selected, s.variables(d))
+	if err != nil {
+		s.Answers = s.Answers[:answered]
+		return err
+	}
+	s.moveTo(d, next)
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// AnswerInput records the value entered on the current question, an input node, and moves to the question
+// its answer leads to, the value being available to conditions under the variable of the node
+func (s *CaseSession) AnswerInput(d *DAG, value string, userContext string, metadata map[string]interface{}, now time.Time) error {
+	if s.Status != SessionStatusInProgress {
+		return fmt.Errorf("session %s is %s", s.Id, s.Status)
+	}
+	if s.CurrentNodeId == nil {
+		return fmt.Errorf("session %s has no question left to answer", s.Id)
+	}
+
+	node, err := d.GetNode(*s.CurrentNodeId)
+	if err != nil {
+		return fmt.Errorf("error getting node %s: %w", *s.CurrentNodeId, err)
+	}
+	answer, err := node.InputAnswer(value)
+	if err != nil {
+		return err
+	}
+
+	s.Answers = append(s.Answers, SessionAnswer{
+		NodeId:      node.Id,
+		AnswerId:    answer.Id,
+		Value:       answer.Statement,
+		UserContext: userContext,
+		Metadata:    metadata,
+		AnsweredAt:  now,
+	})
+	s.moveTo(d, answer.Next(s.variables(d)))
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// Complete closes a session whose walk reached its end
+func (s *CaseSession) Complete(now time.Time) error {
+	if s.Status != SessionStatusInProgress {
+		return fmt.Errorf("session %s is %s", s.Id, s.Status)
+	}
+	if s.CurrentNodeId != nil {
+		return fmt.Errorf("session %s still has node %s to answer", s.Id, *s.CurrentNodeId)
+	}
+
+	s.Status = SessionStatusCompleted
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// Revise discards the answer given to a node along with every answer given after it, the walk resuming from that
+// node. A completed session is reopened and its assessment discarded, the answers it was written from having changed.
+func (s *CaseSession) Revise(nodeId uuid.UUID, now time.Time) error {
+	index := -1
+	for i, answer := range s.Answers {
+		if answer.NodeId == nodeId {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("node %s was not answered during session %s", nodeId, s.Id)
+	}
+
+	s.Answers = s.Answers[:index]
+	s.CurrentNodeId = &nodeId
+	s.Status = SessionStatusInProgress
+	s.Assessment = nil
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// Assess stores the assessment of a completed session, replacing the previous one
+func (s *CaseSession) Assess(assessment Assessment) error {
+	if s.Status != SessionStatusCompleted {
+		return fmt.Errorf("session %s is %s, only completed sessions can be assessed", s.Id, s.Status)
+	}
+
+	s.Assessment = &assessment
+	s.UpdatedAt = assessment.CreatedAt
+
+	return nil
+}
+
+// Steps returns the questions answered during the session along with the selected answers, in order.
+// The user context and metadata given during the session replace the ones stored on the answers, the
+// values entered on input nodes are set on their answers.
+func (s *CaseSession) Steps(d *DAG) ([]WalkStep, error) {
+	steps := make([]WalkStep, 0, len(s.Answers))
+	for _, given := range s.Answers {
+		node, err := d.GetNode(given.NodeId)
+		if err != nil {
+			return nil, fmt.Errorf("error getting node %s: %w", given.NodeId, err)
+		}
+
+		answer, ok := findAnswer(node, given.AnswerId)
+		if !ok {
+			return nil, fmt.Errorf("answer %s is not an answer of node %s", given.AnswerId, node.Id)
+		}
+		if given.UserContext != "" {
+			answer.UserContext = given.UserContext
+		}
+		if len(given.Metadata) > 0 {
+			answer.Metadata = given.Metadata
+		}
+		if given.Value != "" {
+			if answer, err = node.withInput(answer, given.Value); err != nil {
+				return nil, err
+			}
+		}
+
+		steps = append(steps, WalkStep{NodeId: node.Id, Question: node.Question, Answer: answer})
+	}
+
+	return steps, nil
+}
+
+// variables collects the variables the conditions of the answers are evaluated against, from the answers
+// given so far along with the metadata given with them
+func (s *CaseSession) variables(d *DAG) map[string]interface{} {
+	steps, err := s.Steps(d)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+
+	path := make([]Answer, 0, len(steps))
+	for _, step := range steps {
+		path = append(path, step.Answer)
+	}
+	return CollectVariables(path)
+}
+
+// moveTo makes nodeId the current question, a node without answers ends the walk
+func (s *CaseSession) moveTo(d *DAG, nodeId *uuid.UUID) {
+	if nodeId == nil {
+		s.CurrentNodeId = nil
+		return
+	}
+	if node, err := d.GetNode(*nodeId); err == nil && len(node.Answers) == 0 {
+		s.CurrentNodeId = nil
+		return
+	}
+
+	next := *nodeId
+	s.CurrentNodeId = &next
+}
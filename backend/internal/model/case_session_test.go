@@ -0,0 +1,274 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSessionDAG returns a DAG root -> follow-up -> leaf, where the root also has a terminal answer
+func newSessionDAG() (d *DAG, rootId, followUpId uuid.UUID) {
+	d = NewDAG("Dismissal")
+	rootId = uuid.New()
+	followUpId = uuid.New()
+	leafId := uuid.New()
+
+	d.Nodes[rootId] = Node{
+		Id:       rootId,
+		Question: "Were you dismissed?",
+		Answers: []Answer{
+			{Id: uuid.New(), Statement: "Yes", NextNode: &followUpId},
+			{Id: uuid.New(), Statement: "No"},
+		},
+	}
+	d.Nodes[followUpId] = Node{
+		Id:       followUpId,
+		Question: "Were you notified in writing?",
+		Answers:  []Answer{{Id: uuid.New(), Statement: "Yes", NextNode: &leafId}},
+	}
+	d.Nodes[leafId] = Node{Id: leafId, Question: "Conclusion"}
+
+	return d, rootId, followUpId
+}
+
+func TestCaseSession(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("starts on the root node", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newSessionDAG()
+
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		assert.Equal(t, d.Id, session.DAGId)
+		assert.Equal(t, SessionStatusInProgress, session.Status)
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, rootId, *session.CurrentNodeId)
+		assert.Empty(t, session.Answers)
+	})
+
+	t.Run("fails on a DAG without root", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCaseSession(NewDAG("empty"), now)
+		assert.Error(t, err)
+	})
+
+	t.Run("records answers until the walk ends", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, followUpId := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		metadata := map[string]interface{}{"date": "2024-01-10"}
+		require.NoError(t, session.Answer(d, d.Nodes[rootId].Answers[0].Id, "By email", metadata, now.Add(time.Minute)))
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, followUpId, *session.CurrentNodeId)
+
+		// The follow-up answer leads to a node without answers, which ends the walk
+		require.NoError(t, session.Answer(d, d.Nodes[followUpId].Answers[0].Id, "", nil, now.Add(2*time.Minute)))
+		assert.Nil(t, session.CurrentNodeId)
+
+		require.Len(t, session.Answers, 2)
+		assert.Equal(t, rootId, session.Answers[0].NodeId)
+		assert.Equal(t, "By email", session.Answers[0].UserContext)
+		assert.Equal(t, metadata, session.Answers[0].Metadata)
+		assert.Equal(t, followUpId, session.Answers[1].NodeId)
+		assert.Equal(t, now.Add(2*time.Minute), session.UpdatedAt)
+
+		assert.Error(t, session.Answer(d, d.Nodes[followUpId].Answers[0].Id, "", nil, now), "no question left")
+
+		assert.Error(t, session.Assess(Assessment{Content: "Too early"}), "not completed")
+
+		require.NoError(t, session.Complete(now.Add(3*time.Minute)))
+		assert.Equal(t, SessionStatusCompleted, session.Status)
+		assert.Error(t, session.Complete(now), "already completed")
+
+		assessment := Assessment{Provider: "openai", Model: "gpt-4o", Content: "Unfair dismissal", CreatedAt: now.Add(4 * time.Minute)}
+		require.NoError(t, session.Assess(assessment))
+		require.NotNil(t, session.Assessment)
+		assert.Equal(t, assessment, *session.Assessment)
+		assert.Equal(t, now.Add(4*time.Minute), session.UpdatedAt)
+	})
+
+	t.Run("terminal answer ends the walk", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		require.NoError(t, session.Answer(d, d.Nodes[rootId].Answers[1].Id, "", nil, now))
+		assert.Nil(t, session.CurrentNodeId)
+	})
+
+	t.Run("rejects an answer of another node", func(t *testing.T) {
+		t.Parallel()
+
+		d, _, followUpId := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		assert.Error(t, session.Answer(d, d.Nodes[followUpId].Answers[0].Id, "", nil, now))
+		assert.Empty(t, session.Answers)
+	})
+
+	t.Run("cannot complete before the walk ends", func(t *testing.T) {
+		t.Parallel()
+
+		d, _, _ := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		assert.Error(t, session.Complete(now))
+		assert.Equal(t, SessionStatusInProgress, session.Status)
+	})
+}
+
+func TestCaseSession_Steps(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	d, rootId, followUpId := newSessionDAG()
+	root := d.Nodes[rootId]
+	root.Answers[0].UserContext = "Stored context"
+	root.Answers[0].Metadata = map[string]interface{}{"confidence": 0.5}
+	d.Nodes[rootId] = root
+
+	session, err := NewCaseSession(d, now)
+	require.NoError(t, err)
+	require.NoError(t, session.Answer(d, root.Answers[0].Id, "", nil, now))
+	require.NoError(t, session.Answer(d, d.Nodes[followUpId].Answers[0].Id, "Letter dated March 3rd", map[string]interface{}{"tags": []string{"notice"}}, now))
+
+	steps, err := session.Steps(d)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+
+	// Answers without session context keep the stored one
+	assert.Equal(t, "Were you dismissed?", steps[0].Question)
+	assert.Equal(t, "Stored context", steps[0].Answer.UserContext)
+	assert.Equal(t, 0.5, steps[0].Answer.Metadata["confidence"])
+
+	assert.Equal(t, followUpId, steps[1].NodeId)
+	assert.Equal(t, "Letter dated March 3rd", steps[1].Answer.UserContext)
+	assert.Equal(t, []string{"notice"}, steps[1].Answer.Metadata["tags"])
+
+	// Answers removed from the DAG since the session are reported
+	delete(d.Nodes, followUpId)
+	_, err = session.Steps(d)
+	assert.Error(t, err)
+}
+
+func TestCaseSession_AnswerMany(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("records every selected answer and moves to the resolved node", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, followUpId := newConvergingDAG()
+		root := d.Nodes[rootId]
+
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		require.NoError(t, session.AnswerMany(d, []uuid.UUID{root.Answers[1].Id, root.Answers[0].Id}, "", nil, now))
+
+		require.Len(t, session.Answers, 2)
+		assert.Equal(t, root.Answers[1].Id, session.Answers[0].AnswerId)
+		assert.Equal(t, root.Answers[0].Id, session.Answers[1].AnswerId)
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, followUpId, *session.CurrentNodeId)
+	})
+
+	t.Run("leaves the session untouched on a divergent selection", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newMultiSelectDAG()
+		root := d.Nodes[rootId]
+		root.MultiSelectNext = MultiSelectNextConvergence
+		d.Nodes[rootId] = root
+
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		err = session.AnswerMany(d, []uuid.UUID{root.Answers[0].Id, root.Answers[1].Id}, "", nil, now)
+		assert.ErrorContains(t, err, "lead to different nodes")
+		assert.Empty(t, session.Answers)
+		assert.Equal(t, rootId, *session.CurrentNodeId)
+	})
+
+	t.Run("rejects several answers on a single-select question", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newSessionDAG()
+		root := d.Nodes[rootId]
+
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		err = session.AnswerMany(d, []uuid.UUID{root.Answers[0].Id, root.Answers[1].Id}, "", nil, now)
+		assert.ErrorContains(t, err, "single-select")
+		assert.Empty(t, session.Answers)
+	})
+}
+
+func TestCaseSession_Revise(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("discards the answers from the revised node on", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, followUpId := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		require.NoError(t, session.Answer(d, d.Nodes[rootId].Answers[0].Id, "", nil, now))
+		require.NoError(t, session.Answer(d, d.Nodes[followUpId].Answers[0].Id, "", nil, now))
+		require.NoError(t, session.Complete(now))
+		require.NoError(t, session.Assess(Assessment{Content: "Unfair dismissal", CreatedAt: now}))
+
+		require.NoError(t, session.Revise(rootId, now.Add(time.Minute)))
+		assert.Empty(t, session.Answers)
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, rootId, *session.CurrentNodeId)
+		assert.Equal(t, SessionStatusInProgress, session.Status)
+		assert.Nil(t, session.Assessment)
+		assert.Equal(t, now.Add(time.Minute), session.UpdatedAt)
+
+		// The walk can take another branch from the revised node
+		require.NoError(t, session.Answer(d, d.Nodes[rootId].Answers[1].Id, "", nil, now))
+		assert.Nil(t, session.CurrentNodeId)
+	})
+
+	t.Run("keeps the answers before the revised node", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, followUpId := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		require.NoError(t, session.Answer(d, d.Nodes[rootId].Answers[0].Id, "", nil, now))
+		require.NoError(t, session.Answer(d, d.Nodes[followUpId].Answers[0].Id, "", nil, now))
+
+		require.NoError(t, session.Revise(followUpId, now))
+		require.Len(t, session.Answers, 1)
+		assert.Equal(t, rootId, session.Answers[0].NodeId)
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, followUpId, *session.CurrentNodeId)
+	})
+
+	t.Run("rejects a node which was not answered", func(t *testing.T) {
+		t.Parallel()
+
+		d, _, followUpId := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		assert.Error(t, session.Revise(followUpId, now))
+		assert.Equal(t, now, session.UpdatedAt)
+	})
+}
@@ -0,0 +1,18 @@
+package model
+
+import "github.com/google/uuid"
+
+// ChangeType identifies the kind of change applied to a stored DAG
+type ChangeType string
+
+const (
+	ChangeTypeCreated ChangeType = "created"
+	ChangeTypeUpdated ChangeType = "updated"
+	ChangeTypeDeleted ChangeType = "deleted"
+)
+
+// ChangeEvent notifies watchers that a DAG has been changed in a repository
+type ChangeEvent struct {
+	Type  ChangeType `json:"type"`
+	DAGId uuid.UUID  `json:"dag_id"`
+}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/google/uuid"
+)
+
+// CloneDAG returns a deep copy of the DAG in which the DAG, its nodes and its answers get fresh IDs, the
+// links between nodes are remapped onto the new node IDs
+func CloneDAG(d *DAG) *DAG {
+	ids := make(map[uuid.UUID]uuid.UUID)
+	return d.Reidentify(func(id uuid.UUID) uuid.UUID {
+		if newId, ok := ids[id]; ok {
+			return newId
+		}
+		ids[id] = uuid.New()
+		return ids[id]
+	})
+}
+
+// Reidentify returns a deep copy of the DAG in which the DAG, node and answer IDs are replaced by newId.
+// Links between nodes are rewritten with the same function, so newId must map an ID to the same new ID
+// every time it is called to preserve the topology. The copy is a new DAG: its revision, trash and
+// validation state are reset and the metadata history of its answers is not kept.
+func (d DAG) Reidentify(newId func(id uuid.UUID) uuid.UUID) *DAG {
+	copied := &DAG{
+		Id:             newId(d.Id),
+		Title:          d.Title,
+		Nodes:          make(map[uuid.UUID]Node, len(d.Nodes)),
+		Metadata:       NewDAGMetadata(),
+		Scoring:        d.Scoring.clone(),
+		MetadataSchema: d.MetadataSchema.clone(),
+	}
+
+	for _, node := range d.Nodes {
+		nodeCopy := Node{
+			Id:              newId(node.Id),
+			Question:        node.Question,
+			Required:        node.Required,
+			MultiSelect:     node.MultiSelect,
+			MultiSelectNext: node.MultiSelectNext,
+			Translations:    maps.Clone(node.Translations),
+			Type:            node.Type,
+			Outcome:         node.Outcome.clone(),
+			Input:           node.Input.clone(),
+			Answers:         make([]Answer, 0, len(node.Answers)),
+		}
+
+		for _, answer := range node.Answers {
+			answerCopy := Answer{
+				Id:           newId(answer.Id),
+				Statement:    answer.Statement,
+				UserContext:  answer.UserContext,
+				Metadata:     copyMetadata(answer.Metadata),
+				Translations: maps.Clone(answer.Translations),
+				Disabled:     answer.Disabled,
+				Scores:       maps.Clone(answer.Scores),
+			}
+			if answer.NextNode != nil {
+				nextNode := newId(*answer.NextNode)
+				answerCopy.NextNode = &nextNode
+			}
+			for _, branch := range answer.Conditions {
+				answerCopy.Conditions = append(answerCopy.Conditions, Branch{When: branch.When, NextNode: newId(branch.NextNode)})
+			}
+			nodeCopy.Answers = append(nodeCopy.Answers, answerCopy)
+		}
+
+		// Parent pointers are set once the answers are all appended, so that they point to the stored copy
+		for i := range nodeCopy.Answers {
+			nodeCopy.Answers[i].ParentNode = &nodeCopy
+		}
+		copied.Nodes[nodeCopy.Id] = nodeCopy
+	}
+
+	return copied
+}
+
+// Subtree returns a deep copy of the part of the DAG reachable from a node, as a standalone DAG rooted at
+// the node. Node and answer IDs are kept, the subtree gets a fresh ID and is titled after its root question.
+func (d DAG) Subtree(nodeId uuid.UUID) (*DAG, error) {
+	if _, err := d.GetNode(nodeId); err != nil {
+		return nil, fmt.Errorf("node %s: %w", nodeId, err)
+	}
+
+	reachable := map[uuid.UUID]bool{nodeId: true}
+	queue := []uuid.UUID{nodeId}
+	for len(queue) > 0 {
+		node := d.Nodes[queue[0]]
+		queue = queue[1:]
+		for _, next := range d.targets(node.Id) {
+			if reachable[next] {
+				continue
+			}
+			if _, ok := d.Nodes[next]; !ok {
+				continue
+			}
+			reachable[next] = true
+			queue = append(queue, next)
+		}
+	}
+
+	subtree := d.Reidentify(func(id uuid.UUID) uuid.UUID { return id })
+	for id := range subtree.Nodes {
+		if !reachable[id] {
+			delete(subtree.Nodes, id)
+		}
+	}
+	subtree.Id = uuid.New()
+	subtree.Title = d.Nodes[nodeId].Question
+
+	return subtree, nil
+}
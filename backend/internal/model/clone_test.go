@@ -0,0 +1,144 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneDAG(t *testing.T) {
+	t.Parallel()
+
+	t.Run("regenerates every ID and remaps the links", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		clone := CloneDAG(source)
+
+		assert.NotEqual(t, source.Id, clone.Id)
+		assert.Equal(t, source.Title, clone.Title)
+		require.Len(t, clone.Nodes, len(source.Nodes))
+
+		questions := make(map[uuid.UUID]string, len(source.Nodes))
+		for id, node := range source.Nodes {
+			questions[id] = node.Question
+			assert.NotContains(t, clone.Nodes, id)
+		}
+
+		for _, sourceNode := range source.Nodes {
+			for _, sourceAnswer := range sourceNode.Answers {
+				answer := answerByQuestion(t, clone, sourceNode.Question, sourceAnswer.Statement)
+				assert.NotEqual(t, sourceAnswer.Id, answer.Id)
+				if sourceAnswer.NextNode == nil {
+					assert.Nil(t, answer.NextNode)
+					continue
+				}
+				require.NotNil(t, answer.NextNode)
+				require.Contains(t, clone.Nodes, *answer.NextNode)
+				assert.Equal(t, questions[*sourceAnswer.NextNode], clone.Nodes[*answer.NextNode].Question)
+			}
+		}
+	})
+
+	t.Run("shares no state with the source", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		for id, node := range source.Nodes {
+			node.Translations = map[string]string{"fr": "Question"}
+			node.Answers[0].Metadata = map[string]interface{}{"estimated_damages": 1000.0}
+			source.Nodes[id] = node
+		}
+
+		clone := CloneDAG(source)
+		for _, node := range clone.Nodes {
+			node.Translations["fr"] = "Changed"
+			node.Answers[0].Metadata["estimated_damages"] = 0.0
+		}
+
+		for _, node := range source.Nodes {
+			assert.Equal(t, "Question", node.Translations["fr"])
+			assert.Equal(t, 1000.0, node.Answers[0].Metadata["estimated_damages"])
+		}
+	})
+
+	t.Run("clones twice into distinct DAGs", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		first, second := CloneDAG(source), CloneDAG(source)
+
+		assert.NotEqual(t, first.Id, second.Id)
+		for id := range first.Nodes {
+			assert.NotContains(t, second.Nodes, id)
+		}
+	})
+}
+
+func TestDAG_Subtree(t *testing.T) {
+	t.Parallel()
+
+	nodeByQuestion := func(t *testing.T, d *DAG, question string) Node {
+		t.Helper()
+		for _, node := range d.Nodes {
+			if node.Question == question {
+				return node
+			}
+		}
+		t.Fatalf("question %q not found", question)
+		return Node{}
+	}
+
+	t.Run("keeps the nodes reachable from the node", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		notice := nodeByQuestion(t, source, "Did you receive notice?")
+
+		subtree, err := source.Subtree(notice.Id)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, source.Id, subtree.Id)
+		assert.Equal(t, "Did you receive notice?", subtree.Title)
+		require.Len(t, subtree.Nodes, 2)
+		assert.Contains(t, subtree.Nodes, notice.Id)
+		assert.Contains(t, subtree.Nodes, nodeByQuestion(t, source, "Any other claim?").Id)
+
+		root, err := subtree.GetRootNode()
+		require.NoError(t, err)
+		assert.Equal(t, notice.Id, root.Id)
+		assert.Equal(t, notice.Answers[0].Id, root.Answers[0].Id)
+	})
+
+	t.Run("extracts the whole DAG from its root", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		subtree, err := source.Subtree(nodeByQuestion(t, source, "Were you dismissed?").Id)
+		require.NoError(t, err)
+
+		_, err = source.StructuralMapping(*subtree)
+		assert.NoError(t, err)
+	})
+
+	t.Run("shares no state with the DAG", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		claim := nodeByQuestion(t, source, "Any other claim?")
+		subtree, err := source.Subtree(claim.Id)
+		require.NoError(t, err)
+
+		subtree.Nodes[claim.Id].Answers[0].Statement = "Changed"
+		assert.Equal(t, "None", source.Nodes[claim.Id].Answers[0].Statement)
+	})
+
+	t.Run("fails for an unknown node", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newCaseDAG().Subtree(uuid.New())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,549 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Branch is a conditional next node of an answer, selected when its condition holds over the variables
+// collected along the walk
+type Branch struct {
+	// When is the condition, e.g. `confidence > 0.7 && "urgent" in tags`
+	When     string    `json:"when"`
+	NextNode uuid.UUID `json:"next_node"`
+}
+
+// Targets returns the distinct nodes an answer may lead to: its default next node first, then the nodes
+// of its conditions in order
+func (a Answer) Targets() []uuid.UUID {
+	targets := make([]uuid.UUID, 0, len(a.Conditions)+1)
+	seen := make(map[uuid.UUID]bool, len(a.Conditions)+1)
+	if a.NextNode != nil {
+		targets = append(targets, *a.NextNode)
+		seen[*a.NextNode] = true
+	}
+	for _, branch := range a.Conditions {
+		if !seen[branch.NextNode] {
+			targets = append(targets, branch.NextNode)
+			seen[branch.NextNode] = true
+		}
+	}
+	return targets
+}
+
+// Next selects the node the answer leads to given the collected variables: the node of the first
+// condition holding, or the default next node when none does. Conditions which cannot be parsed never hold.
+func (a Answer) Next(vars map[string]interface{}) *uuid.UUID {
+	for _, branch := range a.Conditions {
+		condition, err := ParseCondition(branch.When)
+		if err != nil {
+			continue
+		}
+		if condition.Eval(vars) {
+			next := branch.NextNode
+			return &next
+		}
+	}
+	return a.NextNode
+}
+
+// CollectVariables merges the metadata of the answers of a walk into the variables conditions are
+// evaluated against, later answers overriding earlier ones
+func CollectVariables(path []Answer) map[string]interface{} {
+	vars := make(map[string]interface{})
+	for _, answer := range path {
+		for key, value := range answer.Metadata {
+			vars[key] = value
+		}
+	}
+	return vars
+}
+
+// Condition is a parsed branch condition. It supports comparisons (`> >= < <= == !=`) between variables,
+// numbers, quoted strings and booleans, membership tests (`"urgent" in tags`), bare variables tested for
+// truthiness, `!`, `&&`, `||` and parentheses. Comparisons involving a missing variable or values of
+// different types do not hold.
+type Condition struct {
+	expr string
+	root conditionExpr
+}
+
+// ParseCondition parses a branch condition
+func ParseCondition(expr string) (Condition, error) {
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return Condition{}, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return Condition{}, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return Condition{}, fmt.Errorf("invalid condition %q: unexpected %q", expr, tok.text)
+	}
+
+	return Condition{expr: expr, root: root}, nil
+}
+
+// Eval evaluates the condition against the given variables
+func (c Condition) Eval(vars map[string]interface{}) bool {
+	if c.root == nil {
+		return false
+	}
+	return c.root.eval(vars)
+}
+
+// Variables returns the sorted names of the variables the condition refers to
+func (c Condition) Variables() []string {
+	if c.root == nil {
+		return []string{}
+	}
+
+	set := make(map[string]bool)
+	c.root.variables(set)
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c Condition) String() string {
+	return c.expr
+}
+
+type conditionExpr interface {
+	eval(vars map[string]interface{}) bool
+	variables(set map[string]bool)
+}
+
+type orExpr struct{ left, right conditionExpr }
+
+func (e orExpr) eval(vars map[string]interface{}) bool {
+	return e.left.eval(vars) || e.right.eval(vars)
+}
+
+func (e orExpr) variables(set map[string]bool) {
+	e.left.variables(set)
+	e.right.variables(set)
+}
+
+type andExpr struct{ left, right conditionExpr }
+
+func (e andExpr) eval(vars map[string]interface{}) bool {
+	return e.left.eval(vars) && e.right.eval(vars)
+}
+
+func (e andExpr) variables(set map[string]bool) {
+	e.left.variables(set)
+	e.right.variables(set)
+}
+
+type notExpr struct{ inner conditionExpr }
+
+func (e notExpr) eval(vars map[string]interface{}) bool {
+	return !e.inner.eval(vars)
+}
+
+func (e notExpr) variables(set map[string]bool) {
+	e.inner.variables(set)
+}
+
+// operand is either a variable or a literal value
+type operand struct {
+	variable string
+	value    interface{}
+}
+
+func (o operand) resolve(vars map[string]interface{}) (interface{}, bool) {
+	if o.variable == "" {
+		return o.value, true
+	}
+	value, ok := vars[o.variable]
+	return value, ok && value != nil
+}
+
+func (o operand) addVariable(set map[string]bool) {
+	if o.variable != "" {
+		set[o.variable] = true
+	}
+}
+
+type truthyExpr struct{ operand operand }
+
+func (e truthyExpr) eval(vars map[string]interface{}) bool {
+	value, ok := e.operand.resolve(vars)
+	return ok && truthy(value)
+}
+
+func (e truthyExpr) variables(set map[string]bool) {
+	e.operand.addVariable(set)
+}
+
+type compareExpr struct {
+	op          string
+	left, right operand
+}
+
+func (e compareExpr) eval(vars map[string]interface{}) bool {
+	left, ok := e.left.resolve(vars)
+	if !ok {
+		return false
+	}
+	right, ok := e.right.resolve(vars)
+	if !ok {
+		return false
+	}
+
+	if l, ok := toFloat(left); ok {
+		r, ok := toFloat(right)
+		if !ok {
+			return false
+		}
+		return compareOrdered(e.op, l, r)
+	}
+	if l, ok := left.(string); ok {
+		r, ok := right.(string)
+		if !ok {
+			return false
+		}
+		return compareOrdered(e.op, l, r)
+	}
+	if l, ok := left.(bool); ok {
+		r, ok := right.(bool)
+		if !ok {
+			return false
+		}
+		switch e.op {
+		case "==":
+			return l == r
+		case "!=":
+			return l != r
+		}
+	}
+	return false
+}
+
+func (e compareExpr) variables(set map[string]bool) {
+	e.left.addVariable(set)
+	e.right.addVariable(set)
+}
+
+func compareOrdered[T float64 | string](op string, l, r T) bool {
+	switch op {
+	case "==":
+		return l == r
+	case "!=":
+		return l != r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	}
+	return false
+}
+
+// inExpr tests whether a value is an element of a list variable or a key of a map variable
+type inExpr struct {
+	needle   operand
+	haystack string
+}
+
+func (e inExpr) eval(vars map[string]interface{}) bool {
+	needle, ok := e.needle.resolve(vars)
+	if !ok {
+		return false
+	}
+	haystack, ok := vars[e.haystack]
+	if !ok || haystack == nil {
+		return false
+	}
+
+	v := reflect.ValueOf(haystack)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if sameValue(needle, v.Index(i).Interface()) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if sameValue(needle, key.Interface()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e inExpr) variables(set map[string]bool) {
+	e.needle.addVariable(set)
+	set[e.haystack] = true
+}
+
+func sameValue(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return a == b
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func truthy(value interface{}) bool {
+	if f, ok := toFloat(value); ok {
+		return f != 0
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() > 0
+	}
+	return value != nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+)
+
+type conditionToken struct {
+	kind tokenKind
+	text string
+}
+
+func tokenizeCondition(expr string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenString, text: string(runes[i+1 : end])})
+			i = end + 1
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenNumber, text: string(runes[i:end])})
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_' || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenIdent, text: string(runes[i:end])})
+			i = end
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", ">=", "<=", "==", "!=", ">", "<", "!", "(", ")"} {
+				if strings.HasPrefix(string(runes[i:]), candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenOperator, text: op})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, conditionToken{kind: tokenEOF}), nil
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() conditionToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *conditionParser) acceptOperator(op string) bool {
+	if tok := p.peek(); tok.kind == tokenOperator && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *conditionParser) parseOr() (conditionExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (conditionExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (conditionExpr, error) {
+	if p.acceptOperator("!") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner: inner}, nil
+	}
+
+	if p.acceptOperator("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptOperator(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return inner, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	switch {
+	case tok.kind == tokenIdent && tok.text == "in":
+		p.next()
+		haystack := p.next()
+		if haystack.kind != tokenIdent || isConditionKeyword(haystack.text) {
+			return nil, fmt.Errorf("expected a variable after 'in', got %q", haystack.text)
+		}
+		return inExpr{needle: left, haystack: haystack.text}, nil
+	case tok.kind == tokenOperator && isComparison(tok.text):
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareExpr{op: tok.text, left: left, right: right}, nil
+	}
+
+	return truthyExpr{operand: left}, nil
+}
+
+func (p *conditionParser) parseOperand() (operand, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return operand{}, fmt.Errorf("invalid number %q", tok.text)
+		}
+		return operand{value: value}, nil
+	case tokenString:
+		return operand{value: tok.text}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return operand{value: true}, nil
+		case "false":
+			return operand{value: false}, nil
+		case "in":
+			return operand{}, fmt.Errorf("unexpected 'in'")
+		}
+		return operand{variable: tok.text}, nil
+	case tokenEOF:
+		return operand{}, fmt.Errorf("unexpected end of condition")
+	}
+	return operand{}, fmt.Errorf("unexpected %q", tok.text)
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", ">", ">=", "<", "<=":
+		return true
+	}
+	return false
+}
+
+func isConditionKeyword(text string) bool {
+	return text == "in" || text == "true" || text == "false"
+}
@@ -0,0 +1,201 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCondition(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]interface{}{
+		"confidence":   0.8,
+		"claims":       3,
+		"jurisdiction": "FR",
+		"written":      true,
+		"tags":         []interface{}{"urgent", "employment"},
+		"notes":        "",
+	}
+
+	testCases := []struct {
+		name     string
+		expr     string
+		expected bool
+	}{
+		{name: "greater than", expr: "confidence > 0.7", expected: true},
+		{name: "less or equal", expr: "claims <= 2", expected: false},
+		{name: "string equality", expr: `jurisdiction == "FR"`, expected: true},
+		{name: "single quoted string", expr: "jurisdiction != 'FR'", expected: false},
+		{name: "boolean equality", expr: "written == true", expected: true},
+		{name: "tag present", expr: `"urgent" in tags`, expected: true},
+		{name: "tag absent", expr: `"criminal" in tags`, expected: false},
+		{name: "truthy variable", expr: "written", expected: true},
+		{name: "empty string is falsy", expr: "notes", expected: false},
+		{name: "negation", expr: "!written", expected: false},
+		{name: "conjunction", expr: `confidence > 0.7 && "urgent" in tags`, expected: true},
+		{name: "disjunction", expr: "claims > 5 || written", expected: true},
+		{name: "parentheses", expr: "!(claims > 5 || !written)", expected: true},
+		{name: "negative number", expr: "claims > -1", expected: true},
+		{name: "missing variable", expr: "damages > 1000", expected: false},
+		{name: "missing variable in inequality", expr: "damages != 1000", expected: false},
+		{name: "type mismatch", expr: `confidence > "high"`, expected: false},
+		{name: "missing list", expr: `"urgent" in labels`, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition, err := ParseCondition(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, condition.Eval(vars))
+		})
+	}
+
+	t.Run("lists the referenced variables", func(t *testing.T) {
+		t.Parallel()
+
+		condition, err := ParseCondition(`confidence > 0.7 && ("urgent" in tags || confidence > level)`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"confidence", "level", "tags"}, condition.Variables())
+	})
+
+	t.Run("rejects invalid conditions", func(t *testing.T) {
+		t.Parallel()
+
+		for _, expr := range []string{"", "confidence >", "(written", "written)", `"urgent" in`, "a = b", `"unterminated`, "a && || b"} {
+			_, err := ParseCondition(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+}
+
+// newConditionalDAG returns a DAG whose root answer leads to the urgent node when confidence is high,
+// to the default node otherwise
+func newConditionalDAG() (d *DAG, rootId, urgentId, defaultId uuid.UUID) {
+	d = NewDAG("Triage")
+	rootId = uuid.New()
+	urgentId = uuid.New()
+	defaultId = uuid.New()
+
+	d.Nodes[rootId] = Node{
+		Id:       rootId,
+		Question: "Describe the case",
+		Answers: []Answer{{
+			Id:         uuid.New(),
+			Statement:  "Described",
+			NextNode:   &defaultId,
+			Conditions: []Branch{{When: "confidence > 0.7", NextNode: urgentId}},
+		}},
+	}
+	d.Nodes[urgentId] = Node{Id: urgentId, Question: "Urgent follow-up"}
+	d.Nodes[defaultId] = Node{Id: defaultId, Question: "Standard follow-up"}
+
+	return d, rootId, urgentId, defaultId
+}
+
+func TestAnswer_Next(t *testing.T) {
+	t.Parallel()
+
+	d, rootId, urgentId, defaultId := newConditionalDAG()
+	answer := d.Nodes[rootId].Answers[0]
+
+	assert.Equal(t, []uuid.UUID{defaultId, urgentId}, answer.Targets())
+	assert.Equal(t, urgentId, *answer.Next(map[string]interface{}{"confidence": 0.9}))
+	assert.Equal(t, defaultId, *answer.Next(map[string]interface{}{"confidence": 0.5}))
+	assert.Equal(t, defaultId, *answer.Next(nil))
+}
+
+func TestDAG_Walk_Conditions(t *testing.T) {
+	t.Parallel()
+
+	d, rootId, urgentId, defaultId := newConditionalDAG()
+
+	root, err := d.GetRootNode()
+	require.NoError(t, err)
+	assert.Equal(t, rootId, root.Id)
+
+	walkWith := func(metadata map[string]interface{}) []Answer {
+		path, err := d.Walk(rootId, func(node Node) (Answer, error) {
+			answer := node.Answers[0]
+			answer.Metadata = metadata
+			return answer, nil
+		})
+		require.NoError(t, err)
+		return path
+	}
+
+	// The walk ends on the node selected by the metadata collected along the walk
+	path := walkWith(map[string]interface{}{"confidence": 0.9})
+	require.Len(t, path, 1)
+	assert.Equal(t, urgentId, *path[0].Next(CollectVariables(path)))
+
+	path = walkWith(nil)
+	require.Len(t, path, 1)
+	assert.Equal(t, defaultId, *path[0].Next(CollectVariables(path)))
+
+	t.Run("enumerates a path per possible next node", func(t *testing.T) {
+		t.Parallel()
+
+		paths, err := d.EnumeratePaths()
+		require.NoError(t, err)
+		require.Len(t, paths, 2)
+		assert.Equal(t, defaultId, paths[0].LeafNodeId)
+		assert.Equal(t, urgentId, paths[1].LeafNodeId)
+	})
+}
+
+func TestCaseSession_Conditions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	d, rootId, urgentId, defaultId := newConditionalDAG()
+	// Follow-up nodes need answers, otherwise reaching them ends the walk
+	for _, id := range []uuid.UUID{urgentId, defaultId} {
+		node := d.Nodes[id]
+		node.Answers = []Answer{{Id: uuid.New(), Statement: "Continue"}}
+		d.Nodes[id] = node
+	}
+	answerId := d.Nodes[rootId].Answers[0].Id
+
+	t.Run("follows the condition holding on the given metadata", func(t *testing.T) {
+		t.Parallel()
+
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		require.NoError(t, session.Answer(d, answerId, "", map[string]interface{}{"confidence": 0.95}, now))
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, urgentId, *session.CurrentNodeId)
+	})
+
+	t.Run("falls back to the next node", func(t *testing.T) {
+		t.Parallel()
+
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+		require.NoError(t, session.Answer(d, answerId, "", nil, now))
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, defaultId, *session.CurrentNodeId)
+	})
+}
+
+func TestDAG_Reidentify_Conditions(t *testing.T) {
+	t.Parallel()
+
+	d, rootId, urgentId, _ := newConditionalDAG()
+
+	clone := CloneDAG(d)
+	root, err := clone.GetRootNode()
+	require.NoError(t, err)
+	require.Len(t, root.Answers[0].Conditions, 1)
+
+	branch := root.Answers[0].Conditions[0]
+	assert.Equal(t, "confidence > 0.7", branch.When)
+	assert.NotEqual(t, urgentId, branch.NextNode)
+	assert.Equal(t, "Urgent follow-up", clone.Nodes[branch.NextNode].Question)
+	assert.NotEqual(t, rootId, root.Id)
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContextDocumentEntry is a question answered on a path, with the context gathered on the answer
+type ContextDocumentEntry struct {
+	Question    string
+	Answer      string
+	UserContext string
+	// Confidence is the "confidence" metadata of the answer, nil when absent
+	Confidence *float64
+	// Tags is the "tags" metadata of the answer
+	Tags []string
+	// Sources is the "sources" metadata of the answer, the evidence backing it
+	Sources []string
+	// Attachments are the evidence files uploaded for the answer during a case session
+	Attachments []ContextDocumentAttachment
+}
+
+// ContextDocumentAttachment is an evidence file of a context document entry
+type ContextDocumentAttachment struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	// Link is where the file can be downloaded from
+	Link string
+}
+
+// ContextDocument is the shareable summary of a case built by walking a DAG
+type ContextDocument struct {
+	Title string
+	DAGId uuid.UUID
+	// SessionId is set when the document summarizes a case session
+	SessionId   *uuid.UUID
+	GeneratedAt time.Time
+	Entries     []ContextDocumentEntry
+}
+
+// NewContextDocument summarizes the answers of a path through a DAG, in walk order
+func NewContextDocument(d *DAG, steps []WalkStep, generatedAt time.Time) *ContextDocument {
+	entries := make([]ContextDocumentEntry, 0, len(steps))
+	for _, step := range steps {
+		entry := ContextDocumentEntry{
+			Question:    step.Question,
+			Answer:      step.Answer.Statement,
+			UserContext: step.Answer.UserContext,
+			Tags:        MetadataStrings(step.Answer.Metadata, "tags"),
+			Sources:     MetadataStrings(step.Answer.Metadata, "sources"),
+		}
+		if confidence, ok := metadataFloat(step.Answer.Metadata, "confidence"); ok {
+			entry.Confidence = &confidence
+		}
+		entries = append(entries, entry)
+	}
+
+	return &ContextDocument{
+		Title:       d.Title,
+		DAGId:       d.Id,
+		GeneratedAt: generatedAt,
+		Entries:     entries,
+	}
+}
+
+// MetadataStrings extracts a list of strings from answer metadata, whether decoded from JSON or set in code.
+// Values which are not strings are skipped.
+func MetadataStrings(metadata map[string]interface{}, key string) []string {
+	var values []string
+	switch list := metadata[key].(type) {
+	case []string:
+		values = list
+	case []interface{}:
+		for _, item := range list {
+			if value, ok := item.(string); ok {
+				values = append(values, value)
+			}
+		}
+	case string:
+		values = []string{list}
+	}
+
+	return values
+}
+
+func metadataFloat(metadata map[string]interface{}, key string) (float64, bool) {
+	switch value := metadata[key].(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	}
+	return 0, false
+}
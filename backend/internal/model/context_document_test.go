@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContextDocument(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	d := NewDAG("Employment")
+	steps := []WalkStep{
+		{
+			NodeId:   uuid.New(),
+			Question: "Were you dismissed?",
+			Answer: Answer{
+				Statement:   "Yes",
+				UserContext: "Dismissed by email",
+				Metadata: map[string]interface{}{
+					"confidence": 0.8,
+					"tags":       []interface{}{"dismissal", 42, "urgent"},
+					"sources":    []string{"Email_HR.pdf"},
+				},
+			},
+		},
+		{NodeId: uuid.New(), Question: "Did you receive a notice?", Answer: Answer{Statement: "No"}},
+	}
+
+	doc := NewContextDocument(d, steps, now)
+
+	assert.Equal(t, "Employment", doc.Title)
+	assert.Equal(t, d.Id, doc.DAGId)
+	assert.Nil(t, doc.SessionId)
+	assert.Equal(t, now, doc.GeneratedAt)
+	require.Len(t, doc.Entries, 2)
+
+	first := doc.Entries[0]
+	assert.Equal(t, "Were you dismissed?", first.Question)
+	assert.Equal(t, "Yes", first.Answer)
+	assert.Equal(t, "Dismissed by email", first.UserContext)
+	require.NotNil(t, first.Confidence)
+	assert.Equal(t, 0.8, *first.Confidence)
+	assert.Equal(t, []string{"dismissal", "urgent"}, first.Tags)
+	assert.Equal(t, []string{"Email_HR.pdf"}, first.Sources)
+
+	second := doc.Entries[1]
+	assert.Nil(t, second.Confidence)
+	assert.Empty(t, second.Tags)
+	assert.Empty(t, second.Sources)
+}
@@ -0,0 +1,47 @@
+package model
+
+import "fmt"
+
+// CopyContext copies the user context and metadata of the answers of a DAG onto the matching answers,
+// by statement, of a structurally equal DAG. It returns the number of answers updated.
+func CopyContext(from, to *DAG) (int, error) {
+	mapping, err := from.StructuralMapping(*to)
+	if err != nil {
+		return 0, fmt.Errorf("DAGs are not structurally equal: %w", err)
+	}
+
+	copied := 0
+	for nodeId, node := range from.Nodes {
+		target := to.Nodes[mapping[nodeId]]
+		for _, answer := range node.Answers {
+			if answer.UserContext == "" && len(answer.Metadata) == 0 {
+				continue
+			}
+
+			// Answers share their backing array with the node stored in the map
+			for i := range target.Answers {
+				if target.Answers[i].Statement != answer.Statement {
+					continue
+				}
+
+				target.Answers[i].UserContext = answer.UserContext
+				target.Answers[i].Metadata = copyMetadata(answer.Metadata)
+				copied++
+			}
+		}
+	}
+
+	return copied, nil
+}
+
+func copyMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+
+	copied := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCaseDAG builds the same three questions case tree with fresh IDs on every call
+func newCaseDAG() *DAG {
+	rootId, noticeId, claimId := uuid.New(), uuid.New(), uuid.New()
+
+	return &DAG{
+		Id:    uuid.New(),
+		Title: "Dismissal case",
+		Nodes: map[uuid.UUID]Node{
+			rootId: {Id: rootId, Question: "Were you dismissed?", Answers: []Answer{
+				{Id: uuid.New(), Statement: "Yes", NextNode: &noticeId},
+				{Id: uuid.New(), Statement: "No", NextNode: &claimId},
+			}},
+			noticeId: {Id: noticeId, Question: "Did you receive notice?", Answers: []Answer{
+				{Id: uuid.New(), Statement: "Yes", NextNode: &claimId},
+				{Id: uuid.New(), Statement: "No"},
+			}},
+			claimId: {Id: claimId, Question: "Any other claim?", Answers: []Answer{
+				{Id: uuid.New(), Statement: "None"},
+			}},
+		},
+	}
+}
+
+func answerByQuestion(t *testing.T, d *DAG, question string, statement string) Answer {
+	t.Helper()
+
+	for _, node := range d.Nodes {
+		if node.Question != question {
+			continue
+		}
+		answer, err := answerByStatement(node, statement)
+		require.NoError(t, err)
+		return answer
+	}
+
+	t.Fatalf("question %q not found", question)
+	return Answer{}
+}
+
+func TestCopyContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("copies context onto a structurally equal DAG", func(t *testing.T) {
+		t.Parallel()
+
+		from := newCaseDAG()
+		to := newCaseDAG()
+
+		for _, node := range from.Nodes {
+			for i := range node.Answers {
+				if node.Question == "Were you dismissed?" && node.Answers[i].Statement == "Yes" {
+					node.Answers[i].UserContext = "Dismissed on March 3rd"
+					node.Answers[i].Metadata = map[string]interface{}{"confidence": 0.9}
+				}
+				if node.Question == "Did you receive notice?" && node.Answers[i].Statement == "No" {
+					node.Answers[i].UserContext = "No letter received"
+				}
+			}
+		}
+
+		copied, err := CopyContext(from, to)
+		require.NoError(t, err)
+		assert.Equal(t, 2, copied)
+
+		dismissed := answerByQuestion(t, to, "Were you dismissed?", "Yes")
+		assert.Equal(t, "Dismissed on March 3rd", dismissed.UserContext)
+		assert.Equal(t, map[string]interface{}{"confidence": 0.9}, dismissed.Metadata)
+		assert.Equal(t, "No letter received", answerByQuestion(t, to, "Did you receive notice?", "No").UserContext)
+		assert.Empty(t, answerByQuestion(t, to, "Were you dismissed?", "No").UserContext)
+
+		// Target metadata is not shared with the source
+		dismissed.Metadata["confidence"] = 0.1
+		assert.Equal(t, 0.9, answerByQuestion(t, from, "Were you dismissed?", "Yes").Metadata["confidence"])
+	})
+
+	t.Run("rejects DAGs with a different question", func(t *testing.T) {
+		t.Parallel()
+
+		from := newCaseDAG()
+		to := newCaseDAG()
+		for id, node := range to.Nodes {
+			if node.Question == "Any other claim?" {
+				node.Question = "Anything else?"
+				to.Nodes[id] = node
+			}
+		}
+
+		_, err := CopyContext(from, to)
+		assert.ErrorContains(t, err, "not structurally equal")
+	})
+
+	t.Run("rejects DAGs with a different answer", func(t *testing.T) {
+		t.Parallel()
+
+		from := newCaseDAG()
+		to := newCaseDAG()
+		for _, node := range to.Nodes {
+			if node.Question == "Did you receive notice?" {
+				node.Answers[1].Statement = "Not sure"
+			}
+		}
+
+		_, err := CopyContext(from, to)
+		assert.ErrorContains(t, err, `has no answer "No"`)
+	})
+
+	t.Run("rejects DAGs where an answer leads elsewhere", func(t *testing.T) {
+		t.Parallel()
+
+		from := newCaseDAG()
+		to := newCaseDAG()
+		for _, node := range to.Nodes {
+			if node.Question == "Did you receive notice?" {
+				node.Answers[0].NextNode, node.Answers[1].NextNode = nil, node.Answers[0].NextNode
+			}
+		}
+
+		_, err := CopyContext(from, to)
+		assert.ErrorContains(t, err, "ends the walk in only one DAG")
+	})
+}
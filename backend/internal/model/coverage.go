@@ -0,0 +1,74 @@
+package model
+
+import "github.com/google/uuid"
+
+// CoverageReport lists the nodes answered during a walk and the required nodes the walk missed
+type CoverageReport struct {
+	Answered       []uuid.UUID `json:"answered"`
+	MissedRequired []uuid.UUID `json:"missed_required"`
+}
+
+// Coverage compares the answers chosen during a walk against the required nodes reachable from the nodes
+// the walk went through: a required node is missed when the walk never reached it. An empty walk is
+// compared against the required nodes reachable from the root node.
+// Answered nodes are listed in walk order, missed ones in breadth-first order from the walked nodes.
+func (d DAG) Coverage(path []Answer) CoverageReport {
+	owners := make(map[uuid.UUID]uuid.UUID)
+	for _, node := range d.Nodes {
+		for _, answer := range node.Answers {
+			owners[answer.Id] = node.Id
+		}
+	}
+
+	report := CoverageReport{Answered: []uuid.UUID{}, MissedRequired: []uuid.UUID{}}
+	reached := make(map[uuid.UUID]bool)
+	for _, answer := range path {
+		nodeId, ok := owners[answer.Id]
+		if !ok || reached[nodeId] {
+			continue
+		}
+		reached[nodeId] = true
+		report.Answered = append(report.Answered, nodeId)
+	}
+
+	queue := append([]uuid.UUID(nil), report.Answered...)
+	if len(path) > 0 {
+		if next := path[len(path)-1].Next(CollectVariables(path)); next != nil {
+			// The walk ended on a node without answers
+			reached[*next] = true
+		}
+	}
+	if len(queue) == 0 {
+		root, err := d.GetRootNode()
+		if err != nil {
+			return report
+		}
+		queue = append(queue, root.Id)
+	}
+
+	visited := make(map[uuid.UUID]bool, len(d.Nodes))
+	for _, id := range queue {
+		visited[id] = true
+	}
+	for len(queue) > 0 {
+		node, ok := d.Nodes[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+
+		if node.Required && !reached[node.Id] {
+			report.MissedRequired = append(report.MissedRequired, node.Id)
+		}
+
+		for _, next := range d.targets(node.Id) {
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDAG_Coverage(t *testing.T) {
+	t.Parallel()
+
+	// root (required) -> A -> employment (required) -> Done
+	//                 -> B -> other -> Continue -> conclusion (required, no answers)
+	//                                -> Stop
+	rootId, employmentId, otherId, conclusionId := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	answerA, answerB := Answer{Id: uuid.New(), Statement: "A", NextNode: &employmentId}, Answer{Id: uuid.New(), Statement: "B", NextNode: &otherId}
+	done := Answer{Id: uuid.New(), Statement: "Done"}
+	proceed, stop := Answer{Id: uuid.New(), Statement: "Continue", NextNode: &conclusionId}, Answer{Id: uuid.New(), Statement: "Stop"}
+
+	d := DAG{
+		Id: uuid.New(),
+		Nodes: map[uuid.UUID]Node{
+			rootId:       {Id: rootId, Question: "Root?", Required: true, Answers: []Answer{answerA, answerB}},
+			employmentId: {Id: employmentId, Question: "Employment?", Required: true, Answers: []Answer{done}},
+			otherId:      {Id: otherId, Question: "Other?", Answers: []Answer{proceed, stop}},
+			conclusionId: {Id: conclusionId, Question: "Conclusion", Required: true, Answers: []Answer{}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		path             []Answer
+		expectedAnswered []uuid.UUID
+		expectedMissed   []uuid.UUID
+	}{
+		{
+			name:             "reports the required node of the branch not taken",
+			path:             []Answer{answerA, done},
+			expectedAnswered: []uuid.UUID{rootId, employmentId},
+			expectedMissed:   []uuid.UUID{conclusionId},
+		},
+		{
+			name:             "counts the node without answers ending the walk as reached",
+			path:             []Answer{answerB, proceed},
+			expectedAnswered: []uuid.UUID{rootId, otherId},
+			expectedMissed:   []uuid.UUID{employmentId},
+		},
+		{
+			name:             "reports every required node reachable when stopping early",
+			path:             []Answer{answerB, stop},
+			expectedAnswered: []uuid.UUID{rootId, otherId},
+			expectedMissed:   []uuid.UUID{employmentId, conclusionId},
+		},
+		{
+			name:             "reports every required node for an empty walk",
+			path:             nil,
+			expectedAnswered: []uuid.UUID{},
+			expectedMissed:   []uuid.UUID{rootId, employmentId, conclusionId},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := d.Coverage(tt.path)
+			assert.Equal(t, tt.expectedAnswered, report.Answered)
+			assert.Equal(t, tt.expectedMissed, report.MissedRequired)
+		})
+	}
+}
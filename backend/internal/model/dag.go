@@ -3,6 +3,10 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,12 +18,37 @@ type DAG struct {
 	Title    string    `json:"title"`
 	Nodes    map[uuid.UUID]Node
 	Metadata *DAGMetadata `json:"metadata,omitempty"`
+	// UpdatedAt is the last time the DAG content changed, zero when unknown
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	// Revision is incremented on every content change, zero for DAGs stored before revisions were tracked
+	Revision uint64 `json:"revision,omitempty"`
+	// DeletedAt is set while the DAG is in the trash, soft-deleted DAGs are purged after a retention period
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Scoring declares the dimensions the answers contribute scores to, nil to score every contributed dimension
+	Scoring *Scoring `json:"scoring,omitempty"`
+	// MetadataSchema declares the fields of answer metadata, nil to accept any metadata
+	MetadataSchema *MetadataSchema `json:"metadata_schema,omitempty"`
 }
 
 type Node struct {
 	Id       uuid.UUID `json:"id"`
 	Question string    `json:"question"`
 	Answers  []Answer  `json:"answers"`
+	// Required marks a mandatory question, walks not reaching it are reported by Coverage
+	Required bool `json:"required,omitempty"`
+	// MultiSelect allows selecting several answers, WalkMulti then follows all of them while WalkSelect and
+	// sessions move to the single node resolved by MultiSelectNext
+	MultiSelect bool `json:"multi_select,omitempty"`
+	// MultiSelectNext is the rule resolving the next node from the selected answers, priority when empty
+	MultiSelectNext MultiSelectNext `json:"multi_select_next,omitempty"`
+	// Translations holds the question in other languages, keyed by language code
+	Translations map[string]string `json:"translations,omitempty"`
+	// Type tells how the node is presented, nodes without a type are questions
+	Type NodeType `json:"type,omitempty"`
+	// Outcome holds the assessment of an outcome node
+	Outcome *Outcome `json:"outcome,omitempty"`
+	// Input describes the value entered on an input node
+	Input *Input `json:"input,omitempty"`
 }
 
 type Answer struct {
@@ -29,6 +58,17 @@ type Answer struct {
 	ParentNode  *Node                  `json:"-"` // Excluded from JSON to avoid circular references
 	UserContext string                 `json:"user_context,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	// MetadataHistory keeps the most recent metadata revisions, oldest first
+	MetadataHistory []MetadataSnapshot `json:"metadata_history,omitempty"`
+	// Translations holds the statement in other languages, keyed by language code
+	Translations map[string]string `json:"translations,omitempty"`
+	// Disabled keeps the answer in the structure without offering it at runtime
+	Disabled bool `json:"disabled,omitempty"`
+	// Conditions select another next node than NextNode from the variables collected along the walk,
+	// the first condition holding wins and NextNode is the fallback
+	Conditions []Branch `json:"conditions,omitempty"`
+	// Scores are the contributions of the answer to the score of a path, keyed by dimension name
+	Scores map[string]float64 `json:"scores,omitempty"`
 }
 
 // DAGMetadata combines a DAG with its validation metadata
@@ -40,15 +80,64 @@ type DAGMetadata struct {
 
 // ValidationStatistics provides DAG structure statistics
 type ValidationStatistics struct {
-	TotalNodes   int      `json:"total_nodes"`
-	RootNodes    int      `json:"root_nodes"`
-	LeafNodes    int      `json:"leaf_nodes"`
-	TotalAnswers int      `json:"total_answers"`
-	MaxDepth     int      `json:"max_depth"`
-	HasCycles    bool     `json:"has_cycles"`
-	RootNodeIDs  []string `json:"root_node_ids,omitempty"`
-	LeafNodeIDs  []string `json:"leaf_node_ids,omitempty"`
-	CyclePaths   []string `json:"cycle_paths,omitempty"`
+	TotalNodes         int     `json:"total_nodes"`
+	RootNodes          int     `json:"root_nodes"`
+	LeafNodes          int     `json:"leaf_nodes"`
+	TotalAnswers       int     `json:"total_answers"`
+	MaxDepth           int     `json:"max_depth"`
+	HasCycles          bool    `json:"has_cycles"`
+	AvgBranchingFactor float64 `json:"avg_branching_factor"`
+	// TotalEstimatedDamages and TotalEstimatedDuration sum the estimates of every answer of the DAG
+	TotalEstimatedDamages  float64     `json:"total_estimated_damages,omitempty"`
+	TotalEstimatedDuration float64     `json:"total_estimated_duration,omitempty"`
+	BranchingHistogram     map[int]int `json:"branching_histogram,omitempty"`
+	RootNodeIDs            []string    `json:"root_node_ids,omitempty"`
+	LeafNodeIDs            []string    `json:"leaf_node_ids,omitempty"`
+	CyclePaths             []CyclePath `json:"cycle_paths,omitempty"`
+	// TotalPaths, MinPathLength, MaxPathLength and AvgPathLength describe the root to leaf paths,
+	// their length being the number of answers given
+	TotalPaths    int     `json:"total_paths,omitempty"`
+	MinPathLength int     `json:"min_path_length,omitempty"`
+	MaxPathLength int     `json:"max_path_length,omitempty"`
+	AvgPathLength float64 `json:"avg_path_length,omitempty"`
+	// LeafPaths describes the paths ending on each node, sorted by node ID
+	LeafPaths []LeafPaths `json:"leaf_paths,omitempty"`
+}
+
+// LeafPaths describes the paths from the root node ending on a node, either a leaf or a node holding
+// terminal answers
+type LeafPaths struct {
+	LeafNodeID string  `json:"leaf_node_id"`
+	Paths      int     `json:"paths"`
+	MinDepth   int     `json:"min_depth"`
+	MaxDepth   int     `json:"max_depth"`
+	AvgDepth   float64 `json:"avg_depth"`
+}
+
+// CyclePath is an elementary cycle of a DAG
+type CyclePath struct {
+	// NodeIDs lists the nodes of the cycle, closed on its first node
+	NodeIDs []string `json:"node_ids"`
+	// ClosingAnswerID is the answer leading from the last node of the cycle back to its first node
+	ClosingAnswerID string `json:"closing_answer_id,omitempty"`
+}
+
+// UnmarshalJSON also reads the cycle paths of the metadata stored before they were structured,
+// a stringified list of node IDs such as "[id1 id2 id1]"
+func (c *CyclePath) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		*c = CyclePath{NodeIDs: strings.Fields(strings.Trim(legacy, "[]"))}
+		return nil
+	}
+
+	type cyclePath CyclePath
+	var path cyclePath
+	if err := json.Unmarshal(data, &path); err != nil {
+		return err
+	}
+	*c = CyclePath(path)
+	return nil
 }
 
 // NewDAGMetadata creates a new DAGMetadata with default values
@@ -85,8 +174,8 @@ func (d DAG) GetRootNode() (Node, error) {
 
 	for _, node := range d.Nodes {
 		for _, answer := range node.Answers {
-			if answer.NextNode != nil {
-				referencedNodes[*answer.NextNode] = true
+			for _, target := range answer.Targets() {
+				referencedNodes[target] = true
 			}
 		}
 	}
@@ -110,12 +199,33 @@ func (d DAG) GetRootNode() (Node, error) {
 	return rootNodes[0], nil
 }
 
+// Questions returns every distinct node question of the DAG in sorted order
+func (d DAG) Questions() []string {
+	seen := make(map[string]bool, len(d.Nodes))
+	questions := make([]string, 0, len(d.Nodes))
+	for _, node := range d.Nodes {
+		if seen[node.Question] {
+			continue
+		}
+		seen[node.Question] = true
+		questions = append(questions, node.Question)
+	}
+
+	sort.Strings(questions)
+	return questions
+}
+
 // dagJSON represents the JSON structure for marshaling/unmarshaling a DAG
 type dagJSON struct {
-	Id       uuid.UUID    `json:"id"`
-	Title    string       `json:"title"`
-	Nodes    []Node       `json:"nodes"`
-	Metadata *DAGMetadata `json:"metadata,omitempty"`
+	Id             uuid.UUID       `json:"id"`
+	Title          string          `json:"title"`
+	Nodes          []Node          `json:"nodes"`
+	Metadata       *DAGMetadata    `json:"metadata,omitempty"`
+	UpdatedAt      time.Time       `json:"updated_at,omitzero"`
+	Revision       uint64          `json:"revision,omitempty"`
+	DeletedAt      *time.Time      `json:"deleted_at,omitempty"`
+	Scoring        *Scoring        `json:"scoring,omitempty"`
+	MetadataSchema *MetadataSchema `json:"metadata_schema,omitempty"`
 }
 
 func (d DAG) MarshalJSON() ([]byte, error) {
@@ -126,15 +236,49 @@ func (d DAG) MarshalJSON() ([]byte, error) {
 
 	// Create a dagJSON struct to marshal id, title, nodes, and metadata
 	dag := dagJSON{
-		Id:       d.Id,
-		Title:    d.Title,
-		Nodes:    nodes,
-		Metadata: d.Metadata,
+		Id:             d.Id,
+		Title:          d.Title,
+		Nodes:          nodes,
+		Metadata:       d.Metadata,
+		UpdatedAt:      d.UpdatedAt,
+		Revision:       d.Revision,
+		DeletedAt:      d.DeletedAt,
+		Scoring:        d.Scoring,
+		MetadataSchema: d.MetadataSchema,
 	}
 
 	return json.Marshal(dag)
 }
 
+// MarshalCanonicalJSON renders the DAG as indented JSON with nodes sorted by ID,
+// so that the same DAG always produces the same bytes regardless of who wrote it
+func (d DAG) MarshalCanonicalJSON() ([]byte, error) {
+	nodes := make([]Node, 0, len(d.Nodes))
+	for _, node := range d.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Id.String() < nodes[j].Id.String()
+	})
+
+	data, err := json.MarshalIndent(dagJSON{
+		Id:             d.Id,
+		Title:          d.Title,
+		Nodes:          nodes,
+		Metadata:       d.Metadata,
+		UpdatedAt:      d.UpdatedAt,
+		Revision:       d.Revision,
+		DeletedAt:      d.DeletedAt,
+		Scoring:        d.Scoring,
+		MetadataSchema: d.MetadataSchema,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
 func (d *DAG) UnmarshalJSON(data []byte) error {
 	var dag dagJSON
 
@@ -147,6 +291,11 @@ func (d *DAG) UnmarshalJSON(data []byte) error {
 	d.Id = dag.Id
 	d.Title = dag.Title
 	d.Metadata = dag.Metadata
+	d.UpdatedAt = dag.UpdatedAt
+	d.Revision = dag.Revision
+	d.DeletedAt = dag.DeletedAt
+	d.Scoring = dag.Scoring
+	d.MetadataSchema = dag.MetadataSchema
 
 	// Initialize the Nodes map if it's nil
 	if d.Nodes == nil {
@@ -169,7 +318,11 @@ func (d *DAG) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// String renders the DAG one question at a time, previewing the next question of each answer.
+// Answers leading back into a cycle are annotated with [CYCLE].
 func (d DAG) String() string {
+	components := d.stronglyConnectedComponents()
+
 	var sb strings.Builder
 	for _, node := range d.Nodes {
 		sb.WriteString("Question: " + node.Question + "\n")
@@ -181,11 +334,23 @@ func (d DAG) String() string {
 					sb.WriteString(" -> [ERROR: " + err.Error() + "]")
 				} else {
 					sb.WriteString(" -> " + nextNode.Question)
+					if components[node.Id] == components[nextNode.Id] {
+						sb.WriteString(" [CYCLE]")
+					}
 				}
 			} else {
 				sb.WriteString(" -> [LEAF]")
 			}
 			sb.WriteString("\n")
+			for _, branch := range answer.Conditions {
+				sb.WriteString("\t\tWhen " + branch.When)
+				if nextNode, err := d.GetNode(branch.NextNode); err != nil {
+					sb.WriteString(" -> [ERROR: " + err.Error() + "]")
+				} else {
+					sb.WriteString(" -> " + nextNode.Question)
+				}
+				sb.WriteString("\n")
+			}
 		}
 		sb.WriteString("\n")
 	}
@@ -193,10 +358,84 @@ func (d DAG) String() string {
 	return sb.String()
 }
 
+// targets returns the distinct nodes the answers of a node may lead to, in answer order
+func (d DAG) targets(nodeId uuid.UUID) []uuid.UUID {
+	var targets []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, answer := range d.Nodes[nodeId].Answers {
+		for _, target := range answer.Targets() {
+			if !seen[target] {
+				seen[target] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets
+}
+
+// stronglyConnectedComponents assigns a component index to every node using Tarjan's algorithm,
+// an answer is part of a cycle when its node and next node share the same component
+func (d DAG) stronglyConnectedComponents() map[uuid.UUID]int {
+	index := 0
+	indices := make(map[uuid.UUID]int, len(d.Nodes))
+	lowLinks := make(map[uuid.UUID]int, len(d.Nodes))
+	onStack := make(map[uuid.UUID]bool, len(d.Nodes))
+	stack := []uuid.UUID{}
+	components := make(map[uuid.UUID]int, len(d.Nodes))
+	component := 0
+
+	var strongConnect func(uuid.UUID)
+	strongConnect = func(nodeId uuid.UUID) {
+		indices[nodeId] = index
+		lowLinks[nodeId] = index
+		index++
+		stack = append(stack, nodeId)
+		onStack[nodeId] = true
+
+		for _, next := range d.targets(nodeId) {
+			if _, exists := d.Nodes[next]; !exists {
+				continue
+			}
+
+			if _, visited := indices[next]; !visited {
+				strongConnect(next)
+				lowLinks[nodeId] = min(lowLinks[nodeId], lowLinks[next])
+			} else if onStack[next] {
+				lowLinks[nodeId] = min(lowLinks[nodeId], indices[next])
+			}
+		}
+
+		if lowLinks[nodeId] == indices[nodeId] {
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				components[top] = component
+				if top == nodeId {
+					break
+				}
+			}
+			component++
+		}
+	}
+
+	for nodeId := range d.Nodes {
+		if _, visited := indices[nodeId]; !visited {
+			strongConnect(nodeId)
+		}
+	}
+
+	return components
+}
+
 // Walk traverses the DAG starting from the given node ID, using fnAnswer to determine
 // which answer to follow at each step until reaching a leaf node.
 func (d DAG) Walk(nodeId uuid.UUID, fnAnswer func(Node) (Answer, error)) ([]Answer, error) {
-	var path []Answer
+	return d.walk(nodeId, nil, fnAnswer)
+}
+
+// walk continues a walk on the given node, path holding the answers given before reaching it
+func (d DAG) walk(nodeId uuid.UUID, path []Answer, fnAnswer func(Node) (Answer, error)) ([]Answer, error) {
 	currentNodeId := nodeId
 
 	for {
@@ -229,95 +468,155 @@ func (d DAG) Walk(nodeId uuid.UUID, fnAnswer func(Node) (Answer, error)) ([]Answ
 		if !isValid {
 			return path, fmt.Errorf("selected answer %s is not valid for node %s", selectedAnswer.Id, currentNodeId)
 		}
+		if err := currentNode.checkInput(selectedAnswer); err != nil {
+			return path, err
+		}
 
 		// Add the enhanced answer to the path (preserving any additional context)
 		path = append(path, selectedAnswer)
 
 		// If this answer has no next node, we've reached a leaf
-		if selectedAnswer.NextNode == nil {
+		next := selectedAnswer.Next(CollectVariables(path))
+		if next == nil {
 			break
 		}
 
 		// Move to the next node
-		currentNodeId = *selectedAnswer.NextNode
+		currentNodeId = *next
 	}
 
 	return path, nil
 }
 
-func CLIFnAnswer(node Node) (Answer, error) {
-	fmt.Printf("\n%s\n", node.Question)
-	fmt.Println(strings.Repeat("-", len(node.Question)))
+// CLIFnAnswer returns an answer provider prompting the user on the terminal using the given prompt configuration.
+// Information nodes are displayed and advanced through without prompting, input nodes read the value typed in.
+func CLIFnAnswer(config PromptConfig) func(Node) (Answer, error) {
+	return func(node Node) (Answer, error) {
+		if answer, ok := node.AutoAnswer(); ok {
+			config.RenderInformation(os.Stdout, node)
+			return answer, nil
+		}
+		if node.Kind() == NodeTypeInput {
+			return promptInput(config, node)
+		}
 
-	// Display numbered options
-	for i, answer := range node.Answers {
-		fmt.Printf("%d. %s\n", i+1, answer.Statement)
-	}
+		selectedAnswer, err := promptAnswer(config, node)
+		if err != nil {
+			return Answer{}, err
+		}
 
-	// Prompt for user input
-	fmt.Print("\nSelect your answer (enter the number): ")
+		fmt.Printf(config.SelectedFormat, selectedAnswer.Statement)
 
-	var choice int
-	_, err := fmt.Scanf("%d", &choice)
-	if err != nil {
-		return Answer{}, fmt.Errorf("invalid input: %w", err)
+		return selectedAnswer, nil
 	}
+}
 
-	// Validate choice
-	if choice < 1 || choice > len(node.Answers) {
-		return Answer{}, fmt.Errorf("invalid choice: must be between 1 and %d", len(node.Answers))
-	}
+// CLIFnAnswerWithContext is an enhanced version that collects additional user context, information nodes
+// are displayed and advanced through without prompting nor collecting context, as are input nodes once read
+func CLIFnAnswerWithContext(config PromptConfig) func(Node) (Answer, error) {
+	return func(node Node) (Answer, error) {
+		if answer, ok := node.AutoAnswer(); ok {
+			config.RenderInformation(os.Stdout, node)
+			return answer, nil
+		}
+		if node.Kind() == NodeTypeInput {
+			return promptInput(config, node)
+		}
 
-	// Get the selected answer
-	selectedAnswer := node.Answers[choice-1]
-	fmt.Printf("You selected: %s\n", selectedAnswer.Statement)
+		selectedAnswer, err := promptAnswer(config, node)
+		if err != nil {
+			return Answer{}, err
+		}
 
-	return selectedAnswer, nil
+		return collectAnswerContext(config, selectedAnswer)
+	}
 }
 
-// CLIFnAnswerWithContext is an enhanced version that collects additional user context
-func CLIFnAnswerWithContext(node Node) (Answer, error) {
-	fmt.Printf("\n%s\n", node.Question)
-	fmt.Println(strings.Repeat("-", len(node.Question)))
+// CLIFnAnswers returns a multi-answer provider prompting the user on the terminal, for walks through WalkSelect.
+// Several comma-separated options can be selected on multi-select nodes, information nodes are advanced through
+// and input nodes read the value typed in.
+func CLIFnAnswers(config PromptConfig) func(Node) ([]Answer, error) {
+	return func(node Node) ([]Answer, error) {
+		if answer, ok := node.AutoAnswer(); ok {
+			config.RenderInformation(os.Stdout, node)
+			return []Answer{answer}, nil
+		}
+		if node.Kind() == NodeTypeInput {
+			answer, err := promptInput(config, node)
+			if err != nil {
+				return nil, err
+			}
+			return []Answer{answer}, nil
+		}
 
-	// Display numbered options
-	for i, answer := range node.Answers {
-		fmt.Printf("%d. %s\n", i+1, answer.Statement)
-	}
+		selectedAnswers, err := promptAnswers(config, node)
+		if err != nil {
+			return nil, err
+		}
 
-	// Prompt for user input
-	fmt.Print("\nSelect your answer (enter the number): ")
+		for _, answer := range selectedAnswers {
+			fmt.Printf(config.SelectedFormat, answer.Statement)
+		}
 
-	var choice int
-	_, err := fmt.Scanf("%d", &choice)
-	if err != nil {
-		return Answer{}, fmt.Errorf("invalid input: %w", err)
+		return selectedAnswers, nil
 	}
+}
 
-	// Validate choice
-	if choice < 1 || choice > len(node.Answers) {
-		return Answer{}, fmt.Errorf("invalid choice: must be between 1 and %d", len(node.Answers))
+// CLIFnAnswersWithContext is the multi-answer version of CLIFnAnswerWithContext, context is collected for each selected answer
+func CLIFnAnswersWithContext(config PromptConfig) func(Node) ([]Answer, error) {
+	return func(node Node) ([]Answer, error) {
+		if answer, ok := node.AutoAnswer(); ok {
+			config.RenderInformation(os.Stdout, node)
+			return []Answer{answer}, nil
+		}
+		if node.Kind() == NodeTypeInput {
+			answer, err := promptInput(config, node)
+			if err != nil {
+				return nil, err
+			}
+			return []Answer{answer}, nil
+		}
+
+		selectedAnswers, err := promptAnswers(config, node)
+		if err != nil {
+			return nil, err
+		}
+
+		enhancedAnswers := make([]Answer, 0, len(selectedAnswers))
+		for _, answer := range selectedAnswers {
+			enhancedAnswer, err := collectAnswerContext(config, answer)
+			if err != nil {
+				return nil, err
+			}
+			enhancedAnswers = append(enhancedAnswers, enhancedAnswer)
+		}
+
+		return enhancedAnswers, nil
 	}
+}
 
-	// Get the selected answer and create a copy for enhancement
-	selectedAnswer := node.Answers[choice-1]
+// collectAnswerContext prompts for the notes, confidence and tags of the selected answer
+func collectAnswerContext(config PromptConfig, selectedAnswer Answer) (Answer, error) {
+	// Create a copy of the selected answer for enhancement
 	enhancedAnswer := Answer{
 		Id:         selectedAnswer.Id,
 		Statement:  selectedAnswer.Statement,
 		NextNode:   selectedAnswer.NextNode,
+		Conditions: selectedAnswer.Conditions,
 		ParentNode: selectedAnswer.ParentNode,
+		Scores:     selectedAnswer.Scores,
 		Metadata:   make(map[string]interface{}),
 	}
 
-	fmt.Printf("You selected: %s\n", selectedAnswer.Statement)
+	fmt.Printf(config.SelectedFormat, selectedAnswer.Statement)
 
 	// Collect additional context (optional)
-	fmt.Print("\n--- Additional Context (Optional) ---")
-	fmt.Print("\nAdd notes or explanation (press Enter to skip): ")
+	fmt.Print(config.ContextHeader)
+	fmt.Print(config.NotesPrompt)
 
 	// Clear the input buffer
 	var dummy string
-	_, err = fmt.Scanln(&dummy) // consume the newline from previous input
+	_, err := fmt.Scanln(&dummy) // consume the newline from previous input
 	if err != nil {
 		return Answer{}, fmt.Errorf("invalid input: %w", err)
 	}
@@ -333,7 +632,7 @@ func CLIFnAnswerWithContext(node Node) (Answer, error) {
 	}
 
 	// Collect confidence level
-	fmt.Print("Confidence level 1-10 (press Enter to skip): ")
+	fmt.Print(config.ConfidencePrompt)
 	var confidenceStr string
 	_, err = fmt.Scanln(&confidenceStr)
 	if err != nil {
@@ -349,7 +648,7 @@ func CLIFnAnswerWithContext(node Node) (Answer, error) {
 	}
 
 	// Collect tags
-	fmt.Print("Tags (comma-separated, press Enter to skip): ")
+	fmt.Print(config.TagsPrompt)
 	var tagsStr string
 	_, err = fmt.Scanln(&tagsStr)
 	if err != nil {
@@ -366,3 +665,226 @@ func CLIFnAnswerWithContext(node Node) (Answer, error) {
 
 	return enhancedAnswer, nil
 }
+
+// promptAnswers renders the node prompt and reads the user's numbered choices, comma-separated on multi-select nodes
+func promptAnswers(config PromptConfig, node Node) ([]Answer, error) {
+	if !node.MultiSelect {
+		answer, err := promptAnswer(config, node)
+		if err != nil {
+			return nil, err
+		}
+		return []Answer{answer}, nil
+	}
+
+	config.RenderQuestion(os.Stdout, node)
+
+	var input string
+	_, err := fmt.Scanf("%s", &input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	if navigation, ok := scanWalkNavigation(input); ok {
+		return nil, navigation
+	}
+
+	return parseSelection(node, input)
+}
+
+// parseSelection reads comma-separated option numbers into the answers of the node they designate
+func parseSelection(node Node, input string) ([]Answer, error) {
+	var selected []Answer
+	seen := make(map[int]bool)
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		choice, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid choice %q: %w", field, err)
+		}
+		if choice < 1 || choice > len(node.Answers) {
+			return nil, fmt.Errorf("invalid choice: must be between 1 and %d", len(node.Answers))
+		}
+		if seen[choice] {
+			continue
+		}
+		seen[choice] = true
+		selected = append(selected, node.Answers[choice-1])
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("invalid choice: select at least one answer")
+	}
+	return selected, nil
+}
+
+// promptAnswer renders the node prompt and reads the user's numbered choice, or a navigation command
+func promptAnswer(config PromptConfig, node Node) (Answer, error) {
+	config.RenderQuestion(os.Stdout, node)
+
+	var input string
+	_, err := fmt.Scanf("%s", &input)
+	if err != nil {
+		return Answer{}, fmt.Errorf("invalid input: %w", err)
+	}
+	if navigation, ok := scanWalkNavigation(input); ok {
+		return Answer{}, navigation
+	}
+
+	choice, err := strconv.Atoi(input)
+	if err != nil {
+		return Answer{}, fmt.Errorf("invalid input: %w", err)
+	}
+
+	// Validate choice
+	if choice < 1 || choice > len(node.Answers) {
+		return Answer{}, fmt.Errorf("invalid choice: must be between 1 and %d", len(node.Answers))
+	}
+
+	return node.Answers[choice-1], nil
+}
+
+// promptInput renders the input prompt of the node and reads the value typed in on a line. Blank lines, such
+// as the end of the line of a previous choice, are skipped.
+func promptInput(config PromptConfig, node Node) (Answer, error) {
+	config.RenderInput(os.Stdout, node)
+
+	for {
+		line, err := readLine(os.Stdin)
+		if err != nil {
+			return Answer{}, fmt.Errorf("invalid input: %w", err)
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if navigation, ok := ParseWalkNavigation(line); ok {
+			return Answer{}, navigation
+		}
+
+		answer, err := node.InputAnswer(line)
+		if err != nil {
+			return Answer{}, err
+		}
+		fmt.Printf(config.SelectedFormat, answer.Statement)
+		return answer, nil
+	}
+}
+
+// scanWalkNavigation reads a navigation command typed instead of a choice, input holding the first word read.
+// The step of a goto command is read from the rest of the line.
+func scanWalkNavigation(input string) (WalkNavigation, bool) {
+	if WalkCommand(strings.ToLower(input)) == WalkCommandGoto {
+		var step int
+		if _, err := fmt.Scanf("%d", &step); err != nil {
+			return WalkNavigation{}, false
+		}
+		input = fmt.Sprintf("%s %d", input, step)
+	}
+	return ParseWalkNavigation(input)
+}
+
+// readLine reads a line one byte at a time, so that no input is buffered away from the fmt scanning functions
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return strings.TrimSuffix(string(line), "\r"), nil
+			}
+			line = append(line, buf[0])
+		}
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				return string(line), nil
+			}
+			return "", err
+		}
+	}
+}
+
+// ScriptedFnAnswer returns an answer provider that selects answers from a predefined
+// script mapping node IDs to answer IDs, allowing non-interactive walks. Information nodes missing
+// from the script are advanced through.
+func ScriptedFnAnswer(answers map[uuid.UUID]uuid.UUID) func(Node) (Answer, error) {
+	return func(node Node) (Answer, error) {
+		answerId, ok := answers[node.Id]
+		if !ok {
+			if answer, auto := node.AutoAnswer(); auto {
+				return answer, nil
+			}
+			return Answer{}, fmt.Errorf("no scripted answer for node %s", node.Id)
+		}
+
+		for _, answer := range node.Answers {
+			if answer.Id == answerId {
+				return answer, nil
+			}
+		}
+
+		return Answer{}, fmt.Errorf("scripted answer %s not found in node %s", answerId, node.Id)
+	}
+}
+
+// ScriptedFnAnswerByStatement returns an answer provider that selects answers from a predefined
+// script mapping node IDs to answer statements. Statements are matched ignoring surrounding
+// whitespace and case, and the match must be unique within the node. Information nodes missing from
+// the script are advanced through and the statement of input nodes is the value entered.
+func ScriptedFnAnswerByStatement(answers map[uuid.UUID]string) func(Node) (Answer, error) {
+	return func(node Node) (Answer, error) {
+		statement, ok := answers[node.Id]
+		if !ok {
+			if answer, auto := node.AutoAnswer(); auto {
+				return answer, nil
+			}
+			return Answer{}, fmt.Errorf("no scripted answer for node %s", node.Id)
+		}
+		if node.Kind() == NodeTypeInput {
+			return node.InputAnswer(statement)
+		}
+
+		want := strings.TrimSpace(statement)
+		var matches []Answer
+		for _, answer := range node.Answers {
+			if strings.EqualFold(strings.TrimSpace(answer.Statement), want) {
+				matches = append(matches, answer)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return Answer{}, fmt.Errorf("no answer matching %q in node %s", statement, node.Id)
+		case 1:
+			return matches[0], nil
+		default:
+			return Answer{}, fmt.Errorf("ambiguous answer %q in node %s: %d answers match", statement, node.Id, len(matches))
+		}
+	}
+}
+
+// CheckReferences splits the distinct next_node references of the node, in answer order, between those
+// resolving to a node of the DAG or to the node itself and the dangling ones
+func (d DAG) CheckReferences(node Node) (existing []uuid.UUID, dangling []uuid.UUID) {
+	existing, dangling = []uuid.UUID{}, []uuid.UUID{}
+	seen := make(map[uuid.UUID]bool)
+
+	for _, answer := range node.Answers {
+		for _, target := range answer.Targets() {
+			if seen[target] {
+				continue
+			}
+			seen[target] = true
+
+			if _, ok := d.Nodes[target]; ok || target == node.Id {
+				existing = append(existing, target)
+			} else {
+				dangling = append(dangling, target)
+			}
+		}
+	}
+
+	return existing, dangling
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// SortTitleAsc orders DAGs alphabetically by title, case insensitive
+	SortTitleAsc = "title"
+	// SortTitleDesc orders DAGs reverse alphabetically by title, case insensitive
+	SortTitleDesc = "-title"
+	// SortUpdatedAtAsc orders DAGs from the least to the most recently updated
+	SortUpdatedAtAsc = "updated_at"
+	// SortUpdatedAtDesc orders DAGs from the most to the least recently updated
+	SortUpdatedAtDesc = "-updated_at"
+)
+
+// DAGQuery selects, orders and paginates the stored DAGs
+type DAGQuery struct {
+	// TitleContains keeps only the DAGs whose title contains the text, case insensitive
+	TitleContains string
+	// UpdatedSince keeps only the DAGs updated at or after the given time, zero keeps every DAG
+	UpdatedSince time.Time
+	// Sort orders the DAGs, empty orders them by ID
+	Sort string
+	// Page is the 1-based page to return, ignored when PageSize is zero
+	Page int
+	// PageSize caps the number of DAGs returned, zero returns every matching DAG
+	PageSize int
+	// Deleted selects the soft-deleted DAGs instead of the live ones
+	Deleted bool
+}
+
+// DAGPage holds the DAGs of a page along with the number of DAGs matching the query
+type DAGPage struct {
+	DAGs     []*DAG
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// Matches reports whether a DAG passes the query filters
+func (q DAGQuery) Matches(dag *DAG) bool {
+	if dag.IsDeleted() != q.Deleted {
+		return false
+	}
+	if !q.UpdatedSince.IsZero() && dag.UpdatedAt.Before(q.UpdatedSince) {
+		return false
+	}
+	if q.TitleContains != "" && !strings.Contains(strings.ToLower(dag.Title), strings.ToLower(q.TitleContains)) {
+		return false
+	}
+	return true
+}
+
+// Apply filters, orders and paginates DAGs, repositories without native query support run it
+// over their whole content. DAGs never updated sort as the oldest, ties are broken by ID.
+func (q DAGQuery) Apply(dags []*DAG) *DAGPage {
+	matching := make([]*DAG, 0, len(dags))
+	for _, dag := range dags {
+		if q.Matches(dag) {
+			matching = append(matching, dag)
+		}
+	}
+
+	less := q.less()
+	sort.SliceStable(matching, func(i, j int) bool {
+		if less != nil {
+			if less(matching[i], matching[j]) {
+				return true
+			}
+			if less(matching[j], matching[i]) {
+				return false
+			}
+		}
+		return matching[i].Id.String() < matching[j].Id.String()
+	})
+
+	page := &DAGPage{
+		DAGs:  matching,
+		Total: len(matching),
+	}
+	if q.PageSize <= 0 {
+		return page
+	}
+
+	page.Page = max(q.Page, 1)
+	page.PageSize = q.PageSize
+	start := min((page.Page-1)*q.PageSize, len(matching))
+	end := min(start+q.PageSize, len(matching))
+	page.DAGs = matching[start:end]
+
+	return page
+}
+
+// less returns the ordering requested by the query, nil when DAGs are only ordered by ID
+func (q DAGQuery) less() func(a, b *DAG) bool {
+	switch q.Sort {
+	case SortTitleAsc:
+		return func(a, b *DAG) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	case SortTitleDesc:
+		return func(a, b *DAG) bool { return strings.ToLower(a.Title) > strings.ToLower(b.Title) }
+	case SortUpdatedAtAsc:
+		return func(a, b *DAG) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case SortUpdatedAtDesc:
+		return func(a, b *DAG) bool { return a.UpdatedAt.After(b.UpdatedAt) }
+	}
+	return nil
+}
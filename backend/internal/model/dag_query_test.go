@@ -0,0 +1,120 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDAGQuery_Apply(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	never := &DAG{Id: uuid.MustParse("00000000-0000-0000-0000-000000000004"), Title: "Never updated"}
+	old := &DAG{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Title: "old lease", UpdatedAt: now.Add(-48 * time.Hour)}
+	recent := &DAG{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Title: "Recent employment", UpdatedAt: now.Add(-1 * time.Hour)}
+	latest := &DAG{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Title: "latest employment", UpdatedAt: now}
+	deletedAt := now.Add(-2 * time.Hour)
+	trashed := &DAG{Id: uuid.MustParse("00000000-0000-0000-0000-000000000005"), Title: "Trashed employment", UpdatedAt: now, DeletedAt: &deletedAt}
+	stored := []*DAG{recent, never, latest, old, trashed}
+
+	tests := []struct {
+		name             string
+		query            DAGQuery
+		expectedTitles   []string
+		expectedTotal    int
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{
+			name:           "orders by ID by default",
+			query:          DAGQuery{},
+			expectedTitles: []string{"Recent employment", "old lease", "latest employment", "Never updated"},
+			expectedTotal:  4,
+		},
+		{
+			name:           "sorts least recently updated first",
+			query:          DAGQuery{Sort: SortUpdatedAtAsc},
+			expectedTitles: []string{"Never updated", "old lease", "Recent employment", "latest employment"},
+			expectedTotal:  4,
+		},
+		{
+			name:           "sorts most recently updated first",
+			query:          DAGQuery{Sort: SortUpdatedAtDesc},
+			expectedTitles: []string{"latest employment", "Recent employment", "old lease", "Never updated"},
+			expectedTotal:  4,
+		},
+		{
+			name:           "sorts by title ignoring case",
+			query:          DAGQuery{Sort: SortTitleAsc},
+			expectedTitles: []string{"latest employment", "Never updated", "old lease", "Recent employment"},
+			expectedTotal:  4,
+		},
+		{
+			name:           "sorts by title in reverse",
+			query:          DAGQuery{Sort: SortTitleDesc},
+			expectedTitles: []string{"Recent employment", "old lease", "Never updated", "latest employment"},
+			expectedTotal:  4,
+		},
+		{
+			name:           "filters DAGs updated since a time",
+			query:          DAGQuery{Sort: SortUpdatedAtDesc, UpdatedSince: now.Add(-1 * time.Hour)},
+			expectedTitles: []string{"latest employment", "Recent employment"},
+			expectedTotal:  2,
+		},
+		{
+			name:           "selects the soft-deleted DAGs",
+			query:          DAGQuery{Deleted: true},
+			expectedTitles: []string{"Trashed employment"},
+			expectedTotal:  1,
+		},
+		{
+			name:           "filters DAGs by title ignoring case",
+			query:          DAGQuery{Sort: SortTitleAsc, TitleContains: "EMPLOY"},
+			expectedTitles: []string{"latest employment", "Recent employment"},
+			expectedTotal:  2,
+		},
+		{
+			name:             "returns the requested page with the total",
+			query:            DAGQuery{Sort: SortTitleAsc, Page: 2, PageSize: 3},
+			expectedTitles:   []string{"Recent employment"},
+			expectedTotal:    4,
+			expectedPage:     2,
+			expectedPageSize: 3,
+		},
+		{
+			name:             "returns the first page when no page is set",
+			query:            DAGQuery{Sort: SortTitleAsc, PageSize: 2},
+			expectedTitles:   []string{"latest employment", "Never updated"},
+			expectedTotal:    4,
+			expectedPage:     1,
+			expectedPageSize: 2,
+		},
+		{
+			name:             "returns an empty page past the last one",
+			query:            DAGQuery{Page: 5, PageSize: 2},
+			expectedTitles:   []string{},
+			expectedTotal:    4,
+			expectedPage:     5,
+			expectedPageSize: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := tt.query.Apply(stored)
+
+			titles := make([]string, len(page.DAGs))
+			for i, d := range page.DAGs {
+				titles[i] = d.Title
+			}
+			assert.Equal(t, tt.expectedTitles, titles)
+			assert.Equal(t, tt.expectedTotal, page.Total)
+			assert.Equal(t, tt.expectedPage, page.Page)
+			assert.Equal(t, tt.expectedPageSize, page.PageSize)
+		})
+	}
+
+	// Applying a query must not reorder the given DAGs
+	assert.Equal(t, []*DAG{recent, never, latest, old, trashed}, stored)
+}
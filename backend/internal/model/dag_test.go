@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/uuid"
@@ -462,6 +463,54 @@ func TestDAG_String(t *testing.T) {
 				assert.Contains(t, result, "Answer: Answer 1")
 				assert.Contains(t, result, "Answer: Answer 2")
 				assert.Contains(t, result, "[LEAF]")
+				assert.NotContains(t, result, "[CYCLE]")
+			},
+		},
+		{
+			name: "annotates answers participating in a cycle",
+			setup: func() *DAG {
+				d := NewDAG("Cyclic DAG")
+				rootId := uuid.New()
+				loopAId := uuid.New()
+				loopBId := uuid.New()
+
+				d.Nodes[rootId] = Node{
+					Id:       rootId,
+					Question: "Root question?",
+					Answers:  []Answer{{Id: uuid.New(), Statement: "Enter loop", NextNode: &loopAId}},
+				}
+				d.Nodes[loopAId] = Node{
+					Id:       loopAId,
+					Question: "Loop A?",
+					Answers:  []Answer{{Id: uuid.New(), Statement: "To B", NextNode: &loopBId}},
+				}
+				d.Nodes[loopBId] = Node{
+					Id:       loopBId,
+					Question: "Loop B?",
+					Answers:  []Answer{{Id: uuid.New(), Statement: "Back to A", NextNode: &loopAId}},
+				}
+				return d
+			},
+			verify: func(t *testing.T, result string) {
+				assert.Contains(t, result, "Answer: Enter loop -> Loop A?\n")
+				assert.Contains(t, result, "Answer: To B -> Loop B? [CYCLE]")
+				assert.Contains(t, result, "Answer: Back to A -> Loop A? [CYCLE]")
+			},
+		},
+		{
+			name: "annotates self-referencing answers",
+			setup: func() *DAG {
+				d := NewDAG("Self Loop DAG")
+				nodeId := uuid.New()
+				d.Nodes[nodeId] = Node{
+					Id:       nodeId,
+					Question: "Again?",
+					Answers:  []Answer{{Id: uuid.New(), Statement: "Repeat", NextNode: &nodeId}},
+				}
+				return d
+			},
+			verify: func(t *testing.T, result string) {
+				assert.Contains(t, result, "Answer: Repeat -> Again? [CYCLE]")
 			},
 		},
 	}
@@ -744,6 +793,7 @@ func TestDAG_JSONRoundTrip(t *testing.T) {
 		}
 
 		original.Nodes[nodeId] = node
+		original.Revision = 7
 
 		// Marshal
 		data, err := original.MarshalJSON()
@@ -756,6 +806,7 @@ func TestDAG_JSONRoundTrip(t *testing.T) {
 
 		// Verify structure is preserved
 		assert.Equal(t, original.Id, roundtrip.Id)
+		assert.Equal(t, uint64(7), roundtrip.Revision)
 		assert.Equal(t, len(original.Nodes), len(roundtrip.Nodes))
 
 		roundtripNode, exists := roundtrip.Nodes[nodeId]
@@ -981,3 +1032,209 @@ func TestDAG_ContextMarshalling(t *testing.T) {
 		assert.Nil(t, basicRetrieved.Metadata)
 	})
 }
+
+func TestScriptedFnAnswer(t *testing.T) {
+	t.Parallel()
+
+	nodeId := uuid.New()
+	answerId := uuid.New()
+	node := Node{
+		Id:       nodeId,
+		Question: "Were you dismissed?",
+		Answers: []Answer{
+			{Id: answerId, Statement: "Yes"},
+			{Id: uuid.New(), Statement: "No"},
+		},
+	}
+
+	t.Run("selects scripted answer", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ScriptedFnAnswer(map[uuid.UUID]uuid.UUID{nodeId: answerId})(node)
+		require.NoError(t, err)
+		assert.Equal(t, answerId, got.Id)
+	})
+
+	t.Run("returns error when node is not scripted", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ScriptedFnAnswer(map[uuid.UUID]uuid.UUID{})(node)
+		assert.ErrorContains(t, err, "no scripted answer")
+	})
+
+	t.Run("returns error when answer does not belong to node", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ScriptedFnAnswer(map[uuid.UUID]uuid.UUID{nodeId: uuid.New()})(node)
+		assert.ErrorContains(t, err, "not found in node")
+	})
+}
+
+func TestScriptedFnAnswerByStatement(t *testing.T) {
+	t.Parallel()
+
+	nodeId := uuid.New()
+	yesId := uuid.New()
+	node := Node{
+		Id:       nodeId,
+		Question: "Were you dismissed?",
+		Answers: []Answer{
+			{Id: yesId, Statement: "Yes"},
+			{Id: uuid.New(), Statement: "No"},
+			{Id: uuid.New(), Statement: "Not sure"},
+			{Id: uuid.New(), Statement: " not SURE "},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statement string
+		wantId    uuid.UUID
+		errMsg    string
+	}{
+		{
+			name:      "matches exact statement",
+			statement: "Yes",
+			wantId:    yesId,
+		},
+		{
+			name:      "matches trimmed and case-folded statement",
+			statement: "  yES ",
+			wantId:    yesId,
+		},
+		{
+			name:      "returns error on ambiguous match",
+			statement: "not sure",
+			errMsg:    "ambiguous answer",
+		},
+		{
+			name:      "returns error when nothing matches",
+			statement: "Maybe",
+			errMsg:    "no answer matching",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ScriptedFnAnswerByStatement(map[uuid.UUID]string{nodeId: tt.statement})(node)
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantId, got.Id)
+		})
+	}
+
+	t.Run("walks a DAG using statements", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDAG("Scripted DAG")
+		rootId := uuid.New()
+		childId := uuid.New()
+		d.Nodes[rootId] = Node{
+			Id:       rootId,
+			Question: "Root?",
+			Answers:  []Answer{{Id: uuid.New(), Statement: "Continue", NextNode: &childId}},
+		}
+		d.Nodes[childId] = Node{
+			Id:       childId,
+			Question: "Child?",
+			Answers:  []Answer{{Id: uuid.New(), Statement: "Done"}},
+		}
+
+		path, err := d.Walk(rootId, ScriptedFnAnswerByStatement(map[uuid.UUID]string{
+			rootId:  "continue",
+			childId: "done",
+		}))
+		require.NoError(t, err)
+		assert.Len(t, path, 2)
+	})
+}
+
+func TestDAG_Questions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns sorted deduplicated questions", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDAG("Question Bank")
+		for _, question := range []string{"Were you dismissed?", "Do you have a contract?", "Were you dismissed?", "Are you employed?"} {
+			id := uuid.New()
+			d.Nodes[id] = Node{Id: id, Question: question}
+		}
+
+		assert.Equal(t, []string{"Are you employed?", "Do you have a contract?", "Were you dismissed?"}, d.Questions())
+	})
+
+	t.Run("returns empty list for empty DAG", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, NewDAG("Empty").Questions())
+	})
+}
+
+func TestDAG_MarshalCanonicalJSON(t *testing.T) {
+	d := NewDAG("Canonical DAG")
+	for i := 0; i < 10; i++ {
+		id := uuid.New()
+		d.Nodes[id] = Node{Id: id, Question: "Question?", Answers: []Answer{}}
+	}
+
+	first, err := d.MarshalCanonicalJSON()
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		again, err := d.MarshalCanonicalJSON()
+		require.NoError(t, err)
+		assert.Equal(t, first, again)
+	}
+
+	var decoded DAG
+	require.NoError(t, decoded.UnmarshalJSON(first))
+	assert.Equal(t, len(d.Nodes), len(decoded.Nodes))
+}
+
+func TestCyclePath_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		expected CyclePath
+	}{
+		{
+			name: "structured",
+			data: `{"node_ids":["a","b","a"],"closing_answer_id":"c"}`,
+			expected: CyclePath{
+				NodeIDs:         []string{"a", "b", "a"},
+				ClosingAnswerID: "c",
+			},
+		},
+		{
+			name:     "stored before cycle paths were structured",
+			data:     `"[a b a]"`,
+			expected: CyclePath{NodeIDs: []string{"a", "b", "a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var path CyclePath
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &path))
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+
+	t.Run("rejects other values", func(t *testing.T) {
+		t.Parallel()
+
+		var path CyclePath
+		assert.Error(t, json.Unmarshal([]byte(`42`), &path))
+	})
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsDeleted reports whether the DAG is in the trash
+func (d DAG) IsDeleted() bool {
+	return d.DeletedAt != nil
+}
+
+// SoftDelete moves the DAG to the trash, it stays restorable until purged
+func (d *DAG) SoftDelete(now time.Time) error {
+	if d.IsDeleted() {
+		return fmt.Errorf("DAG %s is already deleted", d.Id)
+	}
+
+	d.DeletedAt = &now
+	return nil
+}
+
+// Undelete takes the DAG out of the trash
+func (d *DAG) Undelete() error {
+	if !d.IsDeleted() {
+		return fmt.Errorf("DAG %s is not deleted", d.Id)
+	}
+
+	d.DeletedAt = nil
+	return nil
+}
+
+// PurgeableAt reports whether a soft-deleted DAG was kept in the trash for longer than retention at now
+func (d DAG) PurgeableAt(now time.Time, retention time.Duration) bool {
+	return d.IsDeleted() && !d.DeletedAt.Add(retention).After(now)
+}
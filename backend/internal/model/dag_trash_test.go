@@ -0,0 +1,39 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAG_Trash(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	d := NewDAG("Dismissal")
+	assert.False(t, d.IsDeleted())
+	assert.False(t, d.PurgeableAt(now.Add(365*24*time.Hour), retention), "live DAGs are never purged")
+	assert.Error(t, d.Undelete(), "not deleted")
+
+	require.NoError(t, d.SoftDelete(now))
+	assert.True(t, d.IsDeleted())
+	assert.Error(t, d.SoftDelete(now), "already deleted")
+	assert.False(t, d.PurgeableAt(now.Add(retention-time.Second), retention))
+	assert.True(t, d.PurgeableAt(now.Add(retention), retention))
+
+	// The deletion survives storage
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	var stored DAG
+	require.NoError(t, json.Unmarshal(data, &stored))
+	require.NotNil(t, stored.DeletedAt)
+	assert.True(t, now.Equal(*stored.DeletedAt))
+
+	require.NoError(t, stored.Undelete())
+	assert.False(t, stored.IsDeleted())
+}
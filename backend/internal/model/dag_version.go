@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+// DAGVersion is an immutable snapshot of a DAG, recorded every time the DAG is replaced
+type DAGVersion struct {
+	// Number orders the versions of a DAG, starting at 1
+	Number    int       `json:"number"`
+	DAG       *DAG      `json:"dag"`
+	CreatedAt time.Time `json:"created_at"`
+	// Author is the ID of the user whose change produced the version, empty when unknown
+	Author string `json:"author,omitempty"`
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML renders the DAG with the fields of its JSON document, nodes sorted by ID.
+// Multi-line questions and statements are written as literal blocks
+func (d DAG) MarshalYAML() (interface{}, error) {
+	data, err := d.MarshalCanonicalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, decoding it into a node keeps the field order
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("error converting DAG to YAML: %w", err)
+	}
+	resetYAMLStyle(&document)
+
+	return document.Content[0], nil
+}
+
+// MarshalCanonicalYAML renders the DAG as a YAML document indented by two spaces, see MarshalYAML
+func (d DAG) MarshalCanonicalYAML() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(d); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalYAML reads a DAG from the YAML form of its JSON document
+func (d *DAG) UnmarshalYAML(value *yaml.Node) error {
+	var document map[string]interface{}
+	if err := value.Decode(&document); err != nil {
+		return fmt.Errorf("error unmarshalling DAG data: %w", err)
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("error unmarshalling DAG data: %w", err)
+	}
+
+	return d.UnmarshalJSON(data)
+}
+
+// resetYAMLStyle drops the flow and quoting styles inherited from JSON, letting the encoder pick block styles
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDAG_YAML(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	leafID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	answerID := uuid.MustParse("00000000-0000-0000-0000-000000000011")
+
+	d := DAG{
+		Id:    uuid.MustParse("00000000-0000-0000-0000-0000000000aa"),
+		Title: "Dismissal",
+		Nodes: map[uuid.UUID]Node{
+			leafID: {Id: leafID, Question: "In writing?", Answers: []Answer{}},
+			rootID: {
+				Id:       rootID,
+				Question: "Were you dismissed?\nAnswer for your last position",
+				Answers: []Answer{
+					{Id: answerID, Statement: "Yes", NextNode: &leafID, Metadata: map[string]interface{}{"evidence": "letter"}},
+				},
+			},
+		},
+	}
+
+	t.Run("renders the JSON fields in canonical order", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := d.MarshalCanonicalYAML()
+		require.NoError(t, err)
+
+		expected := `id: 00000000-0000-0000-0000-0000000000aa
+title: Dismissal
+nodes:
+  - id: 00000000-0000-0000-0000-000000000001
+    question: |-
+      Were you dismissed?
+      Answer for your last position
+    answers:
+      - id: 00000000-0000-0000-0000-000000000011
+        answer: Yes
+        next_node: 00000000-0000-0000-0000-000000000002
+        metadata:
+          evidence: letter
+  - id: 00000000-0000-0000-0000-000000000002
+    question: In writing?
+    answers: []
+`
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("round trips through YAML", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := yaml.Marshal(d)
+		require.NoError(t, err)
+
+		var got DAG
+		require.NoError(t, yaml.Unmarshal(data, &got))
+
+		assert.Equal(t, d.Id, got.Id)
+		assert.Equal(t, d.Title, got.Title)
+		require.Len(t, got.Nodes, 2)
+		root := got.Nodes[rootID]
+		assert.Equal(t, d.Nodes[rootID].Question, root.Question)
+		require.Len(t, root.Answers, 1)
+		assert.Equal(t, leafID, *root.Answers[0].NextNode)
+		assert.Equal(t, "letter", root.Answers[0].Metadata["evidence"])
+		require.NotNil(t, root.Answers[0].ParentNode)
+		assert.Equal(t, rootID, root.Answers[0].ParentNode.Id)
+	})
+
+	t.Run("reads hand written YAML with comments", func(t *testing.T) {
+		t.Parallel()
+
+		var got DAG
+		err := yaml.Unmarshal([]byte(`# Employment matters
+id: 00000000-0000-0000-0000-0000000000aa
+title: Dismissal
+nodes:
+  - id: 00000000-0000-0000-0000-000000000001
+    # Keep the question short
+    question: >
+      Were you dismissed
+      last year?
+    answers: []
+`), &got)
+		require.NoError(t, err)
+		assert.Equal(t, "Were you dismissed last year?\n", got.Nodes[rootID].Question)
+	})
+}
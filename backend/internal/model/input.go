@@ -0,0 +1,217 @@
+package model
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InputKind is the kind of value the user enters on an input node
+type InputKind string
+
+const (
+	InputKindText   InputKind = "text"
+	InputKindNumber InputKind = "number"
+	// InputKindDate values are entered and stored in the InputDateLayout layout
+	InputKindDate InputKind = "date"
+)
+
+// InputDateLayout is the layout of date inputs and of their bounds
+const InputDateLayout = "2006-01-02"
+
+// Input describes the value entered on an input node and how it is validated
+type Input struct {
+	// Kind of the value, text when empty
+	Kind InputKind `json:"kind,omitempty"`
+	// Variable names the metadata entry the value is stored under, for conditions to refer to it
+	Variable string `json:"variable"`
+	// Pattern is a regular expression the entered value must match
+	Pattern string `json:"pattern,omitempty"`
+	// Min and Max bound number and date values inclusively, dates being written in the InputDateLayout layout
+	Min string `json:"min,omitempty"`
+	Max string `json:"max,omitempty"`
+}
+
+// ValueKind returns the kind of the value, text when none is set
+func (in Input) ValueKind() InputKind {
+	if in.Kind == "" {
+		return InputKindText
+	}
+	return in.Kind
+}
+
+// Check reports the first inconsistency of the input definition: unknown kind, variable unusable in
+// conditions, invalid pattern or invalid bounds
+func (in Input) Check() error {
+	if in.Variable == "" {
+		return fmt.Errorf("no output variable declared")
+	}
+	if condition, err := ParseCondition(in.Variable); err != nil || !slices.Equal(condition.Variables(), []string{in.Variable}) {
+		return fmt.Errorf("variable %q is not a valid variable name", in.Variable)
+	}
+	if in.Pattern != "" {
+		if _, err := regexp.Compile(in.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", in.Pattern, err)
+		}
+	}
+
+	switch in.ValueKind() {
+	case InputKindText:
+		if in.Min != "" || in.Max != "" {
+			return fmt.Errorf("text inputs cannot be bounded")
+		}
+		return nil
+	case InputKindNumber, InputKindDate:
+		min, max, err := in.bounds()
+		if err != nil {
+			return err
+		}
+		if min != nil && max != nil && *min > *max {
+			return fmt.Errorf("min %s is greater than max %s", in.Min, in.Max)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown input kind %q", in.Kind)
+	}
+}
+
+// Parse validates an entered value against the input definition and returns the value to store: a string
+// for texts and dates, a float64 for numbers
+func (in Input) Parse(raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("a value is required")
+	}
+	if in.Pattern != "" {
+		pattern, err := regexp.Compile(in.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", in.Pattern, err)
+		}
+		if !pattern.MatchString(raw) {
+			return nil, fmt.Errorf("value %q does not match pattern %q", raw, in.Pattern)
+		}
+	}
+
+	var value interface{}
+	var scalar float64
+	switch in.ValueKind() {
+	case InputKindText:
+		return raw, nil
+	case InputKindNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not a number", raw)
+		}
+		value, scalar = number, number
+	case InputKindDate:
+		date, err := time.Parse(InputDateLayout, raw)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not a date in the %s layout", raw, InputDateLayout)
+		}
+		value, scalar = date.Format(InputDateLayout), float64(date.Unix())
+	default:
+		return nil, fmt.Errorf("unknown input kind %q", in.Kind)
+	}
+
+	min, max, err := in.bounds()
+	if err != nil {
+		return nil, err
+	}
+	if min != nil && scalar < *min {
+		return nil, fmt.Errorf("value %s is lower than %s", raw, in.Min)
+	}
+	if max != nil && scalar > *max {
+		return nil, fmt.Errorf("value %s is greater than %s", raw, in.Max)
+	}
+
+	return value, nil
+}
+
+// bounds parses the bounds of number and date inputs, dates being compared as Unix times
+func (in Input) bounds() (min, max *float64, err error) {
+	parse := func(bound string) (*float64, error) {
+		if bound == "" {
+			return nil, nil
+		}
+		if in.ValueKind() == InputKindDate {
+			date, err := time.Parse(InputDateLayout, bound)
+			if err != nil {
+				return nil, fmt.Errorf("bound %q is not a date in the %s layout", bound, InputDateLayout)
+			}
+			unix := float64(date.Unix())
+			return &unix, nil
+		}
+		number, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bound %q is not a number", bound)
+		}
+		return &number, nil
+	}
+
+	if min, err = parse(in.Min); err != nil {
+		return nil, nil, err
+	}
+	if max, err = parse(in.Max); err != nil {
+		return nil, nil, err
+	}
+	return min, max, nil
+}
+
+func (in *Input) clone() *Input {
+	if in == nil {
+		return nil
+	}
+	copied := *in
+	return &copied
+}
+
+// InputAnswer returns the answer of an input node carrying the entered value: the value is the statement
+// of the answer and is stored in its metadata under the variable of the node
+func (n Node) InputAnswer(raw string) (Answer, error) {
+	if n.Kind() != NodeTypeInput || len(n.Answers) != 1 {
+		return Answer{}, fmt.Errorf("node %s is not an input node with a single answer", n.Id)
+	}
+	return n.withInput(n.Answers[0], raw)
+}
+
+// withInput sets the entered value on an answer of the input node
+func (n Node) withInput(answer Answer, raw string) (Answer, error) {
+	if n.Input == nil {
+		return Answer{}, fmt.Errorf("input node %s declares no input", n.Id)
+	}
+	value, err := n.Input.Parse(raw)
+	if err != nil {
+		return Answer{}, fmt.Errorf("invalid value for node %s: %w", n.Id, err)
+	}
+
+	answer.Statement = strings.TrimSpace(raw)
+	answer.Metadata = maps.Clone(answer.Metadata)
+	if answer.Metadata == nil {
+		answer.Metadata = make(map[string]interface{})
+	}
+	answer.Metadata[n.Input.Variable] = value
+
+	return answer, nil
+}
+
+// checkInput ensures an answer given on an input node carries a valid value under the variable of the node,
+// answers of other nodes are not checked
+func (n Node) checkInput(answer Answer) error {
+	if n.Kind() != NodeTypeInput {
+		return nil
+	}
+	if n.Input == nil {
+		return fmt.Errorf("input node %s declares no input", n.Id)
+	}
+	if _, ok := answer.Metadata[n.Input.Variable]; !ok {
+		return fmt.Errorf("no value entered for input node %s", n.Id)
+	}
+	if _, err := n.Input.Parse(answer.Statement); err != nil {
+		return fmt.Errorf("invalid value for node %s: %w", n.Id, err)
+	}
+	return nil
+}
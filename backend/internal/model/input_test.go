@@ -0,0 +1,273 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput_Parse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    Input
+		raw      string
+		expected interface{}
+		wantErr  bool
+	}{
+		{name: "text", input: Input{Variable: "employer"}, raw: " ACME Corp ", expected: "ACME Corp"},
+		{name: "text matching pattern", input: Input{Variable: "siret", Pattern: `^\d{14}$`}, raw: "12345678901234", expected: "12345678901234"},
+		{name: "text not matching pattern", input: Input{Variable: "siret", Pattern: `^\d{14}$`}, raw: "1234", wantErr: true},
+		{name: "empty value", input: Input{Variable: "employer"}, raw: "  ", wantErr: true},
+		{name: "number", input: Input{Kind: InputKindNumber, Variable: "salary"}, raw: "2500.50", expected: 2500.5},
+		{name: "number within range", input: Input{Kind: InputKindNumber, Variable: "seniority", Min: "0", Max: "50"}, raw: "50", expected: 50.0},
+		{name: "number below range", input: Input{Kind: InputKindNumber, Variable: "seniority", Min: "0"}, raw: "-1", wantErr: true},
+		{name: "number above range", input: Input{Kind: InputKindNumber, Variable: "seniority", Max: "50"}, raw: "51", wantErr: true},
+		{name: "not a number", input: Input{Kind: InputKindNumber, Variable: "salary"}, raw: "a lot", wantErr: true},
+		{name: "date", input: Input{Kind: InputKindDate, Variable: "termination_date"}, raw: "2024-03-03", expected: "2024-03-03"},
+		{name: "date within range", input: Input{Kind: InputKindDate, Variable: "termination_date", Min: "2024-01-01", Max: "2024-12-31"}, raw: "2024-12-31", expected: "2024-12-31"},
+		{name: "date out of range", input: Input{Kind: InputKindDate, Variable: "termination_date", Max: "2024-12-31"}, raw: "2025-01-01", wantErr: true},
+		{name: "not a date", input: Input{Kind: InputKindDate, Variable: "termination_date"}, raw: "03/03/2024", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := tc.input.Parse(tc.raw)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestInput_Check(t *testing.T) {
+	t.Parallel()
+
+	valid := []Input{
+		{Variable: "employer"},
+		{Kind: InputKindNumber, Variable: "salary", Min: "0", Max: "100000"},
+		{Kind: InputKindDate, Variable: "termination_date", Min: "2000-01-01"},
+		{Variable: "case.reference", Pattern: `^[A-Z]{2}-\d+$`},
+	}
+	for _, input := range valid {
+		assert.NoError(t, input.Check(), input)
+	}
+
+	invalid := []Input{
+		{},
+		{Variable: "termination date"},
+		{Variable: "true"},
+		{Kind: "duration", Variable: "notice"},
+		{Variable: "reference", Pattern: "(unclosed"},
+		{Variable: "employer", Min: "1"},
+		{Kind: InputKindNumber, Variable: "salary", Min: "low"},
+		{Kind: InputKindNumber, Variable: "salary", Min: "10", Max: "1"},
+		{Kind: InputKindDate, Variable: "termination_date", Max: "tomorrow"},
+	}
+	for _, input := range invalid {
+		assert.Error(t, input.Check(), input)
+	}
+}
+
+// newInputDAG returns a DAG asking for the seniority of the employee, leading to the long tenure node
+// when it exceeds two years and to the short tenure node otherwise
+func newInputDAG() (d *DAG, inputId, longId, shortId uuid.UUID) {
+	d = NewDAG("Seniority")
+	inputId = uuid.New()
+	longId = uuid.New()
+	shortId = uuid.New()
+
+	d.Nodes[inputId] = Node{
+		Id:       inputId,
+		Question: "How many years did you work there?",
+		Type:     NodeTypeInput,
+		Input:    &Input{Kind: InputKindNumber, Variable: "seniority", Min: "0", Max: "60"},
+		Answers: []Answer{{
+			Id:         uuid.New(),
+			Statement:  "Seniority",
+			NextNode:   &shortId,
+			Conditions: []Branch{{When: "seniority > 2", NextNode: longId}},
+		}},
+	}
+	d.Nodes[longId] = Node{Id: longId, Question: "Long tenure", Answers: []Answer{{Id: uuid.New(), Statement: "Continue"}}}
+	d.Nodes[shortId] = Node{Id: shortId, Question: "Short tenure", Answers: []Answer{{Id: uuid.New(), Statement: "Continue"}}}
+
+	return d, inputId, longId, shortId
+}
+
+func TestNode_InputAnswer(t *testing.T) {
+	t.Parallel()
+
+	d, inputId, _, _ := newInputDAG()
+	node := d.Nodes[inputId]
+
+	answer, err := node.InputAnswer(" 5 ")
+	require.NoError(t, err)
+	assert.Equal(t, node.Answers[0].Id, answer.Id)
+	assert.Equal(t, "5", answer.Statement)
+	assert.Equal(t, 5.0, answer.Metadata["seniority"])
+	assert.Nil(t, node.Answers[0].Metadata, "the answer of the node is left untouched")
+
+	_, err = node.InputAnswer("61")
+	assert.Error(t, err)
+
+	_, err = d.Nodes[d.Nodes[inputId].Answers[0].Conditions[0].NextNode].InputAnswer("5")
+	assert.ErrorContains(t, err, "not an input node")
+}
+
+func TestDAG_Walk_Input(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores the entered value on the path for conditions to use", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, longId, _ := newInputDAG()
+		path, err := d.Walk(inputId, ScriptedFnAnswerByStatement(map[uuid.UUID]string{
+			inputId: "5",
+			longId:  "Continue",
+		}))
+		require.NoError(t, err)
+
+		require.Len(t, path, 2)
+		assert.Equal(t, "5", path[0].Statement)
+		assert.Equal(t, 5.0, CollectVariables(path)["seniority"])
+		assert.Equal(t, "Continue", path[1].Statement)
+	})
+
+	t.Run("rejects an answer without value", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, _, _ := newInputDAG()
+		_, err := d.Walk(inputId, func(node Node) (Answer, error) {
+			return node.Answers[0], nil
+		})
+		assert.ErrorContains(t, err, "no value entered")
+	})
+
+	t.Run("rejects an invalid value", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, _, _ := newInputDAG()
+		_, err := d.Walk(inputId, ScriptedFnAnswerByStatement(map[uuid.UUID]string{inputId: "many"}))
+		assert.ErrorContains(t, err, "is not a number")
+	})
+
+	t.Run("checks the value in select walks", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, _, _ := newInputDAG()
+		_, err := d.WalkSelect(inputId, func(node Node) ([]Answer, error) {
+			return node.Answers, nil
+		})
+		assert.ErrorContains(t, err, "no value entered")
+	})
+}
+
+func TestCaseSession_AnswerInput(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("records the value and follows the conditions on it", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, longId, _ := newInputDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		require.NoError(t, session.AnswerInput(d, "5", "Hired in 2019", nil, now))
+		require.Len(t, session.Answers, 1)
+		assert.Equal(t, "5", session.Answers[0].Value)
+		assert.Equal(t, d.Nodes[inputId].Answers[0].Id, session.Answers[0].AnswerId)
+		require.NotNil(t, session.CurrentNodeId)
+		assert.Equal(t, longId, *session.CurrentNodeId)
+
+		steps, err := session.Steps(d)
+		require.NoError(t, err)
+		require.Len(t, steps, 1)
+		assert.Equal(t, "5", steps[0].Answer.Statement)
+		assert.Equal(t, 5.0, steps[0].Answer.Metadata["seniority"])
+		assert.Equal(t, "Hired in 2019", steps[0].Answer.UserContext)
+	})
+
+	t.Run("rejects an invalid value", func(t *testing.T) {
+		t.Parallel()
+
+		d, _, _, _ := newInputDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		assert.Error(t, session.AnswerInput(d, "-3", "", nil, now))
+		assert.Empty(t, session.Answers)
+	})
+
+	t.Run("requires a value on input nodes", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, _, _ := newInputDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		err = session.Answer(d, d.Nodes[inputId].Answers[0].Id, "", nil, now)
+		assert.ErrorContains(t, err, "expects an input value")
+	})
+
+	t.Run("rejects values on other nodes", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newSessionDAG()
+		session, err := NewCaseSession(d, now)
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, session.AnswerInput(d, "5", "", nil, now), "not an input node")
+		assert.Equal(t, rootId, *session.CurrentNodeId)
+	})
+}
+
+func TestPromptConfig_RenderInput(t *testing.T) {
+	t.Parallel()
+
+	node := Node{
+		Id:       uuid.New(),
+		Question: "When were you dismissed?",
+		Type:     NodeTypeInput,
+		Input:    &Input{Kind: InputKindDate, Variable: "termination_date"},
+	}
+
+	var buf bytes.Buffer
+	DefaultPromptConfig().RenderInput(&buf, node)
+
+	assert.Equal(t, "\nWhen were you dismissed?\n------------------------\n\nEnter your answer (YYYY-MM-DD): ", buf.String())
+}
+
+func TestReadLine(t *testing.T) {
+	t.Parallel()
+
+	r := strings.NewReader("ACME Corp\r\n\nlast")
+
+	line, err := readLine(r)
+	require.NoError(t, err)
+	assert.Equal(t, "ACME Corp", line)
+
+	line, err = readLine(r)
+	require.NoError(t, err)
+	assert.Empty(t, line)
+
+	line, err = readLine(r)
+	require.NoError(t, err)
+	assert.Equal(t, "last", line)
+
+	_, err = readLine(r)
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// StructuralMapping maps every node of the DAG to its counterpart in a structurally equal DAG.
+// Two DAGs are structurally equal when walking both from their root pairs nodes asking the same
+// question, whose answers have the same statements and lead to paired nodes. IDs may differ.
+func (d DAG) StructuralMapping(other DAG) (map[uuid.UUID]uuid.UUID, error) {
+	if len(d.Nodes) != len(other.Nodes) {
+		return nil, fmt.Errorf("DAGs have %d and %d nodes", len(d.Nodes), len(other.Nodes))
+	}
+
+	root, err := d.GetRootNode()
+	if err != nil {
+		return nil, err
+	}
+	otherRoot, err := other.GetRootNode()
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := map[uuid.UUID]uuid.UUID{root.Id: otherRoot.Id}
+	mapped := map[uuid.UUID]bool{otherRoot.Id: true}
+	queue := [][2]Node{{root, otherRoot}}
+
+	for len(queue) > 0 {
+		node, otherNode := queue[0][0], queue[0][1]
+		queue = queue[1:]
+
+		if node.Question != otherNode.Question {
+			return nil, fmt.Errorf("node %s asks %q where node %s asks %q", node.Id, node.Question, otherNode.Id, otherNode.Question)
+		}
+		if len(node.Answers) != len(otherNode.Answers) {
+			return nil, fmt.Errorf("node %s has %d answers where node %s has %d", node.Id, len(node.Answers), otherNode.Id, len(otherNode.Answers))
+		}
+
+		for _, answer := range node.Answers {
+			otherAnswer, err := answerByStatement(otherNode, answer.Statement)
+			if err != nil {
+				return nil, err
+			}
+
+			if (answer.NextNode == nil) != (otherAnswer.NextNode == nil) {
+				return nil, fmt.Errorf("answer %q of node %s ends the walk in only one DAG", answer.Statement, node.Id)
+			}
+			if answer.NextNode == nil {
+				continue
+			}
+
+			if target, ok := mapping[*answer.NextNode]; ok {
+				if target != *otherAnswer.NextNode {
+					return nil, fmt.Errorf("answer %q of node %s leads to different nodes", answer.Statement, node.Id)
+				}
+				continue
+			}
+			if mapped[*otherAnswer.NextNode] {
+				return nil, fmt.Errorf("answer %q of node %s leads to different nodes", answer.Statement, node.Id)
+			}
+
+			next, err := d.GetNode(*answer.NextNode)
+			if err != nil {
+				return nil, fmt.Errorf("error getting node %s: %w", *answer.NextNode, err)
+			}
+			otherNext, err := other.GetNode(*otherAnswer.NextNode)
+			if err != nil {
+				return nil, fmt.Errorf("error getting node %s: %w", *otherAnswer.NextNode, err)
+			}
+
+			mapping[next.Id] = otherNext.Id
+			mapped[otherNext.Id] = true
+			queue = append(queue, [2]Node{next, otherNext})
+		}
+	}
+
+	return mapping, nil
+}
+
+// StructuralHash returns a hash of the DAG structure ignoring IDs, titles and answer order:
+// structurally equal DAGs share the same hash. Each node is hashed from its question and the
+// statements of its answers with the hashes of the nodes they lead to.
+func (d DAG) StructuralHash() (string, error) {
+	hashes := make(map[uuid.UUID]string, len(d.Nodes))
+	visiting := make(map[uuid.UUID]bool)
+
+	var hashNode func(id uuid.UUID) (string, error)
+	hashNode = func(id uuid.UUID) (string, error) {
+		if hash, ok := hashes[id]; ok {
+			return hash, nil
+		}
+		if visiting[id] {
+			return "", fmt.Errorf("cycle detected at node %s", id)
+		}
+		visiting[id] = true
+
+		node, err := d.GetNode(id)
+		if err != nil {
+			return "", fmt.Errorf("error getting node %s: %w", id, err)
+		}
+
+		answers := make([]string, 0, len(node.Answers))
+		for _, answer := range node.Answers {
+			next := ""
+			if answer.NextNode != nil {
+				next, err = hashNode(*answer.NextNode)
+				if err != nil {
+					return "", err
+				}
+			}
+			answers = append(answers, fmt.Sprintf("%q->%s", answer.Statement, next))
+		}
+		sort.Strings(answers)
+
+		hashes[id] = structuralDigest(fmt.Sprintf("%q", node.Question), answers)
+		visiting[id] = false
+		return hashes[id], nil
+	}
+
+	referenced := make(map[uuid.UUID]bool, len(d.Nodes))
+	for _, node := range d.Nodes {
+		for _, answer := range node.Answers {
+			for _, target := range answer.Targets() {
+				referenced[target] = true
+			}
+		}
+	}
+
+	var roots []string
+	for id := range d.Nodes {
+		if referenced[id] {
+			continue
+		}
+		hash, err := hashNode(id)
+		if err != nil {
+			return "", err
+		}
+		roots = append(roots, hash)
+	}
+	if len(roots) == 0 && len(d.Nodes) > 0 {
+		return "", fmt.Errorf("no root node found")
+	}
+	sort.Strings(roots)
+
+	return structuralDigest("dag", roots), nil
+}
+
+func structuralDigest(head string, parts []string) string {
+	sum := sha256.Sum256([]byte(head + "[" + strings.Join(parts, ",") + "]"))
+	return hex.EncodeToString(sum[:])
+}
+
+// answerByStatement returns the single answer of the node with the given statement
+func answerByStatement(node Node, statement string) (Answer, error) {
+	var found []Answer
+	for _, answer := range node.Answers {
+		if answer.Statement == statement {
+			found = append(found, answer)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Answer{}, fmt.Errorf("node %s has no answer %q", node.Id, statement)
+	case 1:
+		return found[0], nil
+	default:
+		return Answer{}, fmt.Errorf("node %s has %d answers %q", node.Id, len(found), statement)
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAG_StructuralHash(t *testing.T) {
+	t.Parallel()
+
+	hash := func(t *testing.T, d *DAG) string {
+		t.Helper()
+		h, err := d.StructuralHash()
+		require.NoError(t, err)
+		return h
+	}
+
+	t.Run("ignores IDs, title and answer order", func(t *testing.T) {
+		t.Parallel()
+
+		clone := newCaseDAG()
+		clone.Title = "Another title"
+		for _, node := range clone.Nodes {
+			for i, j := 0, len(node.Answers)-1; i < j; i, j = i+1, j-1 {
+				node.Answers[i], node.Answers[j] = node.Answers[j], node.Answers[i]
+			}
+		}
+
+		assert.Equal(t, hash(t, newCaseDAG()), hash(t, clone))
+	})
+
+	t.Run("differs when a question changes", func(t *testing.T) {
+		t.Parallel()
+
+		changed := newCaseDAG()
+		for id, node := range changed.Nodes {
+			if node.Question == "Any other claim?" {
+				node.Question = "Anything else?"
+				changed.Nodes[id] = node
+			}
+		}
+
+		assert.NotEqual(t, hash(t, newCaseDAG()), hash(t, changed))
+	})
+
+	t.Run("differs when an answer leads elsewhere", func(t *testing.T) {
+		t.Parallel()
+
+		changed := newCaseDAG()
+		for _, node := range changed.Nodes {
+			if node.Question == "Did you receive notice?" {
+				node.Answers[0].NextNode = nil
+			}
+		}
+
+		assert.NotEqual(t, hash(t, newCaseDAG()), hash(t, changed))
+	})
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		t.Parallel()
+
+		rootId, loopId := uuid.New(), uuid.New()
+		cyclic := &DAG{Id: uuid.New(), Nodes: map[uuid.UUID]Node{
+			rootId: {Id: rootId, Question: "Root?", Answers: []Answer{{Id: uuid.New(), Statement: "Loop", NextNode: &loopId}}},
+			loopId: {Id: loopId, Question: "Loop?", Answers: []Answer{{Id: uuid.New(), Statement: "Again", NextNode: &loopId}}},
+		}}
+
+		_, err := cyclic.StructuralHash()
+		assert.ErrorContains(t, err, "cycle detected")
+	})
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job types run in the background
+const (
+	JobTypeValidateDAG = "dag.validate"
+)
+
+// JobStatus is the progress of a background job
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// Job is a long-running task run in the background, its result is kept once it is done
+type Job struct {
+	Id     uuid.UUID `json:"id"`
+	Type   string    `json:"type"`
+	Status JobStatus `json:"status"`
+	// DAGId is the DAG the job works on
+	DAGId uuid.UUID `json:"dag_id"`
+	// Result is set once the job succeeded, its type depends on the job type
+	Result interface{} `json:"result,omitempty"`
+	// Error is set once the job failed
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// NewJob returns a pending job of a DAG
+func NewJob(jobType string, dagId uuid.UUID, now time.Time) Job {
+	return Job{
+		Id:        uuid.New(),
+		Type:      jobType,
+		Status:    JobStatusPending,
+		DAGId:     dagId,
+		CreatedAt: now,
+	}
+}
+
+// Start marks the job as running
+func (j *Job) Start(now time.Time) {
+	j.Status = JobStatusRunning
+	j.StartedAt = &now
+}
+
+// Finish records the outcome of the job, failed when err is not nil
+func (j *Job) Finish(result interface{}, err error, now time.Time) {
+	j.FinishedAt = &now
+	if err != nil {
+		j.Status = JobStatusFailed
+		j.Error = err.Error()
+		return
+	}
+
+	j.Status = JobStatusSucceeded
+	j.Result = result
+}
+
+// Done tells whether the job succeeded or failed
+func (j Job) Done() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
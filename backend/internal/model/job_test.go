@@ -0,0 +1,41 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_Lifecycle(t *testing.T) {
+	now := time.Now()
+
+	t.Run("records the result of a succeeded job", func(t *testing.T) {
+		job := NewJob(JobTypeValidateDAG, uuid.New(), now)
+		assert.Equal(t, JobStatusPending, job.Status)
+		assert.False(t, job.Done())
+
+		job.Start(now.Add(time.Second))
+		assert.Equal(t, JobStatusRunning, job.Status)
+		require.NotNil(t, job.StartedAt)
+		assert.False(t, job.Done())
+
+		job.Finish("valid", nil, now.Add(2*time.Second))
+		assert.Equal(t, JobStatusSucceeded, job.Status)
+		assert.Equal(t, "valid", job.Result)
+		require.NotNil(t, job.FinishedAt)
+		assert.True(t, job.Done())
+	})
+
+	t.Run("records the error of a failed job", func(t *testing.T) {
+		job := NewJob(JobTypeValidateDAG, uuid.New(), now)
+		job.Finish("ignored", errors.New("DAG not found"), now)
+		assert.Equal(t, JobStatusFailed, job.Status)
+		assert.Equal(t, "DAG not found", job.Error)
+		assert.Nil(t, job.Result)
+		assert.True(t, job.Done())
+	})
+}
@@ -0,0 +1,37 @@
+package model
+
+import "github.com/google/uuid"
+
+// LocalizedQuestion returns the question translated to lang, falling back to the default question
+func (n Node) LocalizedQuestion(lang string) string {
+	if translated, ok := n.Translations[lang]; ok && translated != "" {
+		return translated
+	}
+	return n.Question
+}
+
+// LocalizedStatement returns the statement translated to lang, falling back to the default statement
+func (a Answer) LocalizedStatement(lang string) string {
+	if translated, ok := a.Translations[lang]; ok && translated != "" {
+		return translated
+	}
+	return a.Statement
+}
+
+// Localize returns a copy of the DAG whose questions and statements are rendered in lang where a translation exists
+func (d DAG) Localize(lang string) *DAG {
+	nodes := make(map[uuid.UUID]Node, len(d.Nodes))
+	for id, node := range d.Nodes {
+		localized := node
+		localized.Question = node.LocalizedQuestion(lang)
+		localized.Answers = make([]Answer, len(node.Answers))
+		for i, answer := range node.Answers {
+			localized.Answers[i] = answer
+			localized.Answers[i].Statement = answer.LocalizedStatement(lang)
+		}
+		nodes[id] = localized
+	}
+
+	d.Nodes = nodes
+	return &d
+}
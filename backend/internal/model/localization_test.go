@@ -0,0 +1,88 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAG_Localize(t *testing.T) {
+	t.Parallel()
+
+	nodeID := uuid.New()
+	translatedID := uuid.New()
+	untranslatedID := uuid.New()
+
+	d := DAG{
+		Id:    uuid.New(),
+		Title: "Localized DAG",
+		Nodes: map[uuid.UUID]Node{
+			nodeID: {
+				Id:           nodeID,
+				Question:     "Were you dismissed?",
+				Translations: map[string]string{"fr": "Avez-vous été licencié ?"},
+				Answers: []Answer{
+					{Id: translatedID, Statement: "Yes", Translations: map[string]string{"fr": "Oui"}},
+					{Id: untranslatedID, Statement: "No"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		lang              string
+		expectedQuestion  string
+		expectedStatement string
+	}{
+		{
+			name:              "renders available translations",
+			lang:              "fr",
+			expectedQuestion:  "Avez-vous été licencié ?",
+			expectedStatement: "Oui",
+		},
+		{
+			name:              "falls back to default text for missing language",
+			lang:              "de",
+			expectedQuestion:  "Were you dismissed?",
+			expectedStatement: "Yes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			localized := d.Localize(tt.lang)
+			node := localized.Nodes[nodeID]
+
+			assert.Equal(t, tt.expectedQuestion, node.Question)
+			assert.Equal(t, tt.expectedStatement, node.Answers[0].Statement)
+			assert.Equal(t, "No", node.Answers[1].Statement)
+		})
+	}
+
+	t.Run("does not modify the original DAG", func(t *testing.T) {
+		t.Parallel()
+
+		_ = d.Localize("fr")
+		assert.Equal(t, "Were you dismissed?", d.Nodes[nodeID].Question)
+		assert.Equal(t, "Yes", d.Nodes[nodeID].Answers[0].Statement)
+	})
+
+	t.Run("preserves translations through JSON", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(d)
+		require.NoError(t, err)
+
+		var decoded DAG
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, d.Nodes[nodeID].Translations, decoded.Nodes[nodeID].Translations)
+		assert.Equal(t, d.Nodes[nodeID].Answers[0].Translations, decoded.Nodes[nodeID].Answers[0].Translations)
+		assert.Nil(t, decoded.Nodes[nodeID].Answers[1].Translations)
+	})
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxMetadataHistory caps the number of metadata snapshots kept per answer
+const MaxMetadataHistory = 20
+
+// MetadataSnapshot records the metadata of an answer after a change
+type MetadataSnapshot struct {
+	At       time.Time              `json:"at"`
+	By       string                 `json:"by,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// RecordMetadataChange appends a snapshot of the current metadata when it differs from
+// the previous one, dropping the oldest snapshots beyond MaxMetadataHistory
+func (a *Answer) RecordMetadataChange(previous map[string]interface{}, at time.Time, by string) bool {
+	if metadataEqual(previous, a.Metadata) {
+		return false
+	}
+
+	a.MetadataHistory = append(a.MetadataHistory, MetadataSnapshot{
+		At:       at,
+		By:       by,
+		Metadata: a.Metadata,
+	})
+	if len(a.MetadataHistory) > MaxMetadataHistory {
+		a.MetadataHistory = a.MetadataHistory[len(a.MetadataHistory)-MaxMetadataHistory:]
+	}
+
+	return true
+}
+
+// CarryMetadataHistory copies the metadata history of the previous version of the DAG
+// onto matching answers and records a snapshot for every answer whose metadata changed
+func (d *DAG) CarryMetadataHistory(previous DAG, at time.Time, by string) {
+	previousAnswers := make(map[uuid.UUID]Answer)
+	for _, node := range previous.Nodes {
+		for _, answer := range node.Answers {
+			previousAnswers[answer.Id] = answer
+		}
+	}
+
+	for _, node := range d.Nodes {
+		// Answers share their backing array with the node stored in the map
+		for i := range node.Answers {
+			answer := &node.Answers[i]
+			previousAnswer := previousAnswers[answer.Id]
+			answer.MetadataHistory = append([]MetadataSnapshot(nil), previousAnswer.MetadataHistory...)
+			answer.RecordMetadataChange(previousAnswer.Metadata, at, by)
+		}
+	}
+}
+
+// metadataEqual compares metadata maps, treating nil and empty maps as equal
+func metadataEqual(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnswer_RecordMetadataChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ignores unchanged metadata", func(t *testing.T) {
+		t.Parallel()
+
+		answer := Answer{Id: uuid.New(), Metadata: map[string]interface{}{"confidence": 0.5}}
+
+		changed := answer.RecordMetadataChange(map[string]interface{}{"confidence": 0.5}, time.Now(), "")
+		assert.False(t, changed)
+		assert.Empty(t, answer.MetadataHistory)
+
+		changed = (&Answer{}).RecordMetadataChange(map[string]interface{}{}, time.Now(), "")
+		assert.False(t, changed, "nil and empty metadata are equivalent")
+	})
+
+	t.Run("records changed metadata", func(t *testing.T) {
+		t.Parallel()
+
+		at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		answer := Answer{Id: uuid.New(), Metadata: map[string]interface{}{"confidence": 0.9}}
+
+		changed := answer.RecordMetadataChange(map[string]interface{}{"confidence": 0.5}, at, "alice")
+		require.True(t, changed)
+		require.Len(t, answer.MetadataHistory, 1)
+		assert.Equal(t, at, answer.MetadataHistory[0].At)
+		assert.Equal(t, "alice", answer.MetadataHistory[0].By)
+		assert.Equal(t, 0.9, answer.MetadataHistory[0].Metadata["confidence"])
+	})
+
+	t.Run("caps history length", func(t *testing.T) {
+		t.Parallel()
+
+		answer := Answer{Id: uuid.New()}
+		for i := 0; i < MaxMetadataHistory+5; i++ {
+			previous := answer.Metadata
+			answer.Metadata = map[string]interface{}{"revision": i}
+			answer.RecordMetadataChange(previous, time.Now(), "")
+		}
+
+		require.Len(t, answer.MetadataHistory, MaxMetadataHistory)
+		assert.Equal(t, 5, answer.MetadataHistory[0].Metadata["revision"])
+		assert.Equal(t, MaxMetadataHistory+4, answer.MetadataHistory[MaxMetadataHistory-1].Metadata["revision"])
+	})
+}
+
+func TestDAG_CarryMetadataHistory(t *testing.T) {
+	t.Parallel()
+
+	nodeId := uuid.New()
+	answerId := uuid.New()
+	newDAG := func(metadata map[string]interface{}) DAG {
+		return DAG{
+			Id: uuid.New(),
+			Nodes: map[uuid.UUID]Node{
+				nodeId: {
+					Id:       nodeId,
+					Question: "Were you dismissed?",
+					Answers:  []Answer{{Id: answerId, Statement: "Yes", Metadata: metadata}},
+				},
+			},
+		}
+	}
+
+	current := newDAG(map[string]interface{}{"confidence": 0.5})
+	for _, confidence := range []float64{0.6, 0.6, 0.8} {
+		next := newDAG(map[string]interface{}{"confidence": confidence})
+		next.CarryMetadataHistory(current, time.Now(), "bob")
+		current = next
+	}
+
+	history := current.Nodes[nodeId].Answers[0].MetadataHistory
+	require.Len(t, history, 2, "only actual metadata edits are recorded")
+	assert.Equal(t, 0.6, history[0].Metadata["confidence"])
+	assert.Equal(t, 0.8, history[1].Metadata["confidence"])
+	assert.Equal(t, "bob", history[1].By)
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// MetadataFieldType is the type of the values of an answer metadata field
+type MetadataFieldType string
+
+const (
+	MetadataFieldString     MetadataFieldType = "string"
+	MetadataFieldNumber     MetadataFieldType = "number"
+	MetadataFieldBoolean    MetadataFieldType = "boolean"
+	MetadataFieldStringList MetadataFieldType = "string_list"
+)
+
+// MetadataSchema declares the fields the metadata of the answers of a DAG may hold
+type MetadataSchema struct {
+	Fields []MetadataField `json:"fields"`
+	// AdditionalFields accepts metadata fields the schema does not declare
+	AdditionalFields bool `json:"additional_fields,omitempty"`
+}
+
+// MetadataField declares a field of answer metadata
+type MetadataField struct {
+	Name string            `json:"name"`
+	Type MetadataFieldType `json:"type"`
+	// Enum restricts the values of string and string list fields, any value is accepted when empty
+	Enum []string `json:"enum,omitempty"`
+	// Required fields must be set on the metadata of every answer
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (s *MetadataSchema) clone() *MetadataSchema {
+	if s == nil {
+		return nil
+	}
+	copied := &MetadataSchema{Fields: make([]MetadataField, 0, len(s.Fields)), AdditionalFields: s.AdditionalFields}
+	for _, field := range s.Fields {
+		field.Enum = slices.Clone(field.Enum)
+		copied.Fields = append(copied.Fields, field)
+	}
+	return copied
+}
+
+// Field returns the declared field of the given name
+func (s *MetadataSchema) Field(name string) (MetadataField, bool) {
+	if s == nil {
+		return MetadataField{}, false
+	}
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return MetadataField{}, false
+}
+
+// Check checks the fields of the schema are named once with a known type, enums being only allowed on
+// string and string list fields
+func (s MetadataSchema) Check() error {
+	declared := make(map[string]bool, len(s.Fields))
+	for i, field := range s.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("metadata field %d must have a name", i)
+		}
+		if declared[field.Name] {
+			return fmt.Errorf("metadata field %q is declared more than once", field.Name)
+		}
+		declared[field.Name] = true
+
+		switch field.Type {
+		case MetadataFieldString, MetadataFieldStringList:
+		case MetadataFieldNumber, MetadataFieldBoolean:
+			if len(field.Enum) > 0 {
+				return fmt.Errorf("metadata field %q of type %s cannot have an enum", field.Name, field.Type)
+			}
+		default:
+			return fmt.Errorf("metadata field %q has unknown type %q", field.Name, field.Type)
+		}
+	}
+	return nil
+}
+
+// Validate checks metadata against the schema, reporting every missing required field, undeclared field
+// and value of the wrong type or outside its enum. A nil schema accepts any metadata.
+func (s *MetadataSchema) Validate(metadata map[string]interface{}) error {
+	if s == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, field := range s.Fields {
+		value, ok := metadata[field.Name]
+		if !ok {
+			if field.Required {
+				errs = append(errs, fmt.Errorf("metadata field %q is required", field.Name))
+			}
+			continue
+		}
+		if err := field.check(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !s.AdditionalFields {
+		names := make([]string, 0, len(metadata))
+		for name := range metadata {
+			if _, ok := s.Field(name); !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			errs = append(errs, fmt.Errorf("metadata field %q is not declared by the schema", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// check checks a value has the type of the field and belongs to its enum
+func (f MetadataField) check(value interface{}) error {
+	switch f.Type {
+	case MetadataFieldString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("metadata field %q must be a string", f.Name)
+		}
+		return f.checkEnum(s)
+	case MetadataFieldNumber:
+		if _, ok := toFloat(value); !ok {
+			return fmt.Errorf("metadata field %q must be a number", f.Name)
+		}
+	case MetadataFieldBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("metadata field %q must be a boolean", f.Name)
+		}
+	case MetadataFieldStringList:
+		values, ok := stringList(value)
+		if !ok {
+			return fmt.Errorf("metadata field %q must be a list of strings", f.Name)
+		}
+		for _, s := range values {
+			if err := f.checkEnum(s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f MetadataField) checkEnum(value string) error {
+	if len(f.Enum) > 0 && !slices.Contains(f.Enum, value) {
+		return fmt.Errorf("metadata field %q does not accept %q, expected one of %v", f.Name, value, f.Enum)
+	}
+	return nil
+}
+
+// stringList returns the strings of a list decoded from JSON or set from Go code
+func stringList(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return values, true
+	}
+	return nil, false
+}
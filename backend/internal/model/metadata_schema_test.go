@@ -0,0 +1,156 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataSchema_Check(t *testing.T) {
+	t.Parallel()
+
+	valid := []MetadataSchema{
+		{},
+		{Fields: []MetadataField{
+			{Name: "confidence", Type: MetadataFieldNumber, Required: true},
+			{Name: "severity", Type: MetadataFieldString, Enum: []string{"low", "high"}},
+			{Name: "tags", Type: MetadataFieldStringList, Enum: []string{"urgent"}},
+			{Name: "reviewed", Type: MetadataFieldBoolean},
+		}},
+	}
+	for _, schema := range valid {
+		assert.NoError(t, schema.Check(), schema)
+	}
+
+	invalid := []MetadataSchema{
+		{Fields: []MetadataField{{Type: MetadataFieldString}}},
+		{Fields: []MetadataField{{Name: "severity", Type: MetadataFieldString}, {Name: "severity", Type: MetadataFieldNumber}}},
+		{Fields: []MetadataField{{Name: "severity", Type: "enum"}}},
+		{Fields: []MetadataField{{Name: "confidence", Type: MetadataFieldNumber, Enum: []string{"1"}}}},
+	}
+	for _, schema := range invalid {
+		assert.Error(t, schema.Check(), schema)
+	}
+}
+
+func TestMetadataSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	schema := &MetadataSchema{Fields: []MetadataField{
+		{Name: "confidence", Type: MetadataFieldNumber, Required: true},
+		{Name: "severity", Type: MetadataFieldString, Enum: []string{"low", "high"}},
+		{Name: "tags", Type: MetadataFieldStringList, Enum: []string{"urgent", "discrimination"}},
+		{Name: "reviewed", Type: MetadataFieldBoolean},
+	}}
+
+	testCases := []struct {
+		name     string
+		schema   *MetadataSchema
+		metadata map[string]interface{}
+		errors   []string
+	}{
+		{
+			name:     "without schema",
+			metadata: map[string]interface{}{"anything": []int{1}},
+		},
+		{
+			name:     "matching metadata",
+			schema:   schema,
+			metadata: map[string]interface{}{"confidence": 1, "severity": "high", "tags": []interface{}{"urgent"}, "reviewed": true},
+		},
+		{
+			name:     "string list set from Go code",
+			schema:   schema,
+			metadata: map[string]interface{}{"confidence": 0.5, "tags": []string{"discrimination"}},
+		},
+		{
+			name:     "missing required field",
+			schema:   schema,
+			metadata: map[string]interface{}{"severity": "low"},
+			errors:   []string{`metadata field "confidence" is required`},
+		},
+		{
+			name:     "values of the wrong type",
+			schema:   schema,
+			metadata: map[string]interface{}{"confidence": "0.9", "reviewed": "yes", "tags": []interface{}{"urgent", 2}},
+			errors: []string{
+				`metadata field "confidence" must be a number`,
+				`metadata field "tags" must be a list of strings`,
+				`metadata field "reviewed" must be a boolean`,
+			},
+		},
+		{
+			name:     "values outside the enum",
+			schema:   schema,
+			metadata: map[string]interface{}{"confidence": 0.9, "severity": "critical", "tags": []interface{}{"other"}},
+			errors: []string{
+				`metadata field "severity" does not accept "critical"`,
+				`metadata field "tags" does not accept "other"`,
+			},
+		},
+		{
+			name:     "undeclared fields",
+			schema:   schema,
+			metadata: map[string]interface{}{"confidence": 0.9, "source": "email", "channel": "phone"},
+			errors: []string{
+				`metadata field "channel" is not declared by the schema`,
+				`metadata field "source" is not declared by the schema`,
+			},
+		},
+		{
+			name:     "additional fields accepted",
+			schema:   &MetadataSchema{Fields: schema.Fields, AdditionalFields: true},
+			metadata: map[string]interface{}{"confidence": 0.9, "source": "email"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.schema.Validate(tc.metadata)
+			if len(tc.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, message := range tc.errors {
+				assert.ErrorContains(t, err, message)
+			}
+		})
+	}
+}
+
+func TestDAG_MetadataSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := &MetadataSchema{Fields: []MetadataField{{Name: "severity", Type: MetadataFieldString, Enum: []string{"low", "high"}}}}
+
+	t.Run("copies the schema with the DAG", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDAG("Dismissal")
+		d.MetadataSchema = schema.clone()
+
+		clone := CloneDAG(d)
+		require.NotNil(t, clone.MetadataSchema)
+		assert.Equal(t, d.MetadataSchema, clone.MetadataSchema)
+		clone.MetadataSchema.Fields[0].Enum[0] = "changed"
+		assert.Equal(t, "low", d.MetadataSchema.Fields[0].Enum[0])
+	})
+
+	t.Run("keeps the schema through JSON", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDAG("Dismissal")
+		d.MetadataSchema = schema
+
+		data, err := json.Marshal(d)
+		require.NoError(t, err)
+		var decoded DAG
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, d.MetadataSchema, decoded.MetadataSchema)
+	})
+}
@@ -0,0 +1,86 @@
+package model
+
+import "slices"
+
+// NodeType tells how a node is presented during a walk
+type NodeType string
+
+const (
+	// NodeTypeQuestion asks a question, the user picks one of its answers
+	NodeTypeQuestion NodeType = "question"
+	// NodeTypeInformation displays its text and advances through its single answer without asking anything
+	NodeTypeInformation NodeType = "information"
+	// NodeTypeOutcome ends a walk with an assessment of the case
+	NodeTypeOutcome NodeType = "outcome"
+	// NodeTypeInput asks the user to enter a value, stored along its single answer as described by Input
+	NodeTypeInput NodeType = "input"
+)
+
+// Outcome is the assessment an outcome node concludes a walk with
+type Outcome struct {
+	// Assessment labels the outcome, e.g. "Likely unfair dismissal"
+	Assessment         string   `json:"assessment"`
+	RecommendedActions []string `json:"recommended_actions,omitempty"`
+	// Statutes lists the legal provisions the assessment relies on
+	Statutes []string `json:"statutes,omitempty"`
+}
+
+func (o *Outcome) clone() *Outcome {
+	if o == nil {
+		return nil
+	}
+	return &Outcome{
+		Assessment:         o.Assessment,
+		RecommendedActions: slices.Clone(o.RecommendedActions),
+		Statutes:           slices.Clone(o.Statutes),
+	}
+}
+
+// Kind returns the type of the node, nodes without a type being questions
+func (n Node) Kind() NodeType {
+	if n.Type == "" {
+		return NodeTypeQuestion
+	}
+	return n.Type
+}
+
+// AutoAnswer returns the answer an information node advances through, false for other nodes and
+// for information nodes without a single answer
+func (n Node) AutoAnswer() (Answer, bool) {
+	if n.Kind() != NodeTypeInformation || len(n.Answers) != 1 {
+		return Answer{}, false
+	}
+	return n.Answers[0], true
+}
+
+// ReachedOutcome returns the outcome node a walk ended on, false when the walk ended elsewhere
+func (d DAG) ReachedOutcome(path []Answer) (Node, bool) {
+	if len(path) == 0 {
+		return Node{}, false
+	}
+
+	// The last node may have been answered with several answers, the next node is resolved from all of them
+	last := path[len(path)-1]
+	next := last.Next(CollectVariables(path))
+	if last.ParentNode != nil {
+		if node, ok := d.Nodes[last.ParentNode.Id]; ok {
+			first := len(path) - 1
+			for first > 0 && path[first-1].ParentNode != nil && path[first-1].ParentNode.Id == node.Id {
+				first--
+			}
+			var err error
+			if next, err = node.ResolveNext(path[first:], CollectVariables(path)); err != nil {
+				return Node{}, false
+			}
+		}
+	}
+	if next == nil {
+		return Node{}, false
+	}
+
+	node, ok := d.Nodes[*next]
+	if !ok || node.Kind() != NodeTypeOutcome {
+		return Node{}, false
+	}
+	return node, true
+}
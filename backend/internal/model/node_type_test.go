@@ -0,0 +1,108 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTypedDAG returns a DAG question -> information -> outcome, the question also having a terminal answer
+func newTypedDAG() (d *DAG, questionId, informationId, outcomeId uuid.UUID) {
+	d = NewDAG("Dismissal")
+	questionId = uuid.New()
+	informationId = uuid.New()
+	outcomeId = uuid.New()
+
+	d.Nodes[questionId] = Node{
+		Id:       questionId,
+		Question: "Were you dismissed?",
+		Answers: []Answer{
+			{Id: uuid.New(), Statement: "Yes", NextNode: &informationId},
+			{Id: uuid.New(), Statement: "No"},
+		},
+	}
+	d.Nodes[informationId] = Node{
+		Id:       informationId,
+		Question: "Dismissals must be notified in writing.",
+		Type:     NodeTypeInformation,
+		Answers:  []Answer{{Id: uuid.New(), Statement: "Continue", NextNode: &outcomeId}},
+	}
+	d.Nodes[outcomeId] = Node{
+		Id:       outcomeId,
+		Question: "Conclusion",
+		Type:     NodeTypeOutcome,
+		Outcome:  &Outcome{Assessment: "Likely unfair dismissal", Statutes: []string{"L1235-1"}},
+	}
+
+	return d, questionId, informationId, outcomeId
+}
+
+func TestNode_Kind(t *testing.T) {
+	t.Parallel()
+
+	d, questionId, informationId, outcomeId := newTypedDAG()
+
+	assert.Equal(t, NodeTypeQuestion, d.Nodes[questionId].Kind())
+	assert.Equal(t, NodeTypeInformation, d.Nodes[informationId].Kind())
+	assert.Equal(t, NodeTypeOutcome, d.Nodes[outcomeId].Kind())
+
+	_, ok := d.Nodes[questionId].AutoAnswer()
+	assert.False(t, ok)
+	answer, ok := d.Nodes[informationId].AutoAnswer()
+	require.True(t, ok)
+	assert.Equal(t, "Continue", answer.Statement)
+}
+
+func TestDAG_Walk_NodeTypes(t *testing.T) {
+	t.Parallel()
+
+	d, questionId, informationId, outcomeId := newTypedDAG()
+	yes := d.Nodes[questionId].Answers[0]
+	no := d.Nodes[questionId].Answers[1]
+
+	t.Run("advances through information nodes and ends on the outcome", func(t *testing.T) {
+		t.Parallel()
+
+		path, err := d.Walk(questionId, ScriptedFnAnswer(map[uuid.UUID]uuid.UUID{questionId: yes.Id}))
+		require.NoError(t, err)
+		require.Len(t, path, 2)
+		assert.Equal(t, d.Nodes[informationId].Answers[0].Id, path[1].Id)
+
+		outcome, ok := d.ReachedOutcome(path)
+		require.True(t, ok)
+		assert.Equal(t, outcomeId, outcome.Id)
+		assert.Equal(t, "Likely unfair dismissal", outcome.Outcome.Assessment)
+	})
+
+	t.Run("walks ending on a terminal answer have no outcome", func(t *testing.T) {
+		t.Parallel()
+
+		path, err := d.Walk(questionId, ScriptedFnAnswerByStatement(map[uuid.UUID]string{questionId: no.Statement}))
+		require.NoError(t, err)
+
+		_, ok := d.ReachedOutcome(path)
+		assert.False(t, ok)
+	})
+}
+
+func TestCloneDAG_NodeTypes(t *testing.T) {
+	t.Parallel()
+
+	d, _, _, outcomeId := newTypedDAG()
+
+	clone := CloneDAG(d)
+	var outcome Node
+	for _, node := range clone.Nodes {
+		if node.Kind() == NodeTypeOutcome {
+			outcome = node
+		}
+	}
+	require.NotNil(t, outcome.Outcome)
+	assert.NotEqual(t, outcomeId, outcome.Id)
+	assert.Equal(t, *d.Nodes[outcomeId].Outcome, *outcome.Outcome)
+
+	outcome.Outcome.Statutes[0] = "changed"
+	assert.Equal(t, "L1235-1", d.Nodes[outcomeId].Outcome.Statutes[0])
+}
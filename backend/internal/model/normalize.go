@@ -0,0 +1,24 @@
+package model
+
+import "strings"
+
+// NormalizeText trims s and collapses every run of internal whitespace into a single space
+func NormalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// NormalizeText normalizes the questions and answer statements of every node of the DAG,
+// see NormalizeText. Answers with a parent pointer are re-pointed to the normalized node.
+func (d *DAG) NormalizeText() {
+	for id, node := range d.Nodes {
+		nodeCopy := node
+		nodeCopy.Question = NormalizeText(nodeCopy.Question)
+		for i := range nodeCopy.Answers {
+			nodeCopy.Answers[i].Statement = NormalizeText(nodeCopy.Answers[i].Statement)
+			if nodeCopy.Answers[i].ParentNode != nil {
+				nodeCopy.Answers[i].ParentNode = &nodeCopy
+			}
+		}
+		d.Nodes[id] = nodeCopy
+	}
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "  Yes   sir  ", expected: "Yes sir"},
+		{input: "No\t\tway\n", expected: "No way"},
+		{input: "Already clean", expected: "Already clean"},
+		{input: "   ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, NormalizeText(tt.input), "input %q", tt.input)
+	}
+}
+
+func TestDAG_NormalizeText(t *testing.T) {
+	t.Parallel()
+
+	nodeId := uuid.New()
+	d := NewDAG("Normalization")
+	d.Nodes[nodeId] = Node{
+		Id:       nodeId,
+		Question: "  Were   you dismissed? ",
+		Answers: []Answer{
+			{Id: uuid.New(), Statement: "  Yes   sir  "},
+			{Id: uuid.New(), Statement: "No"},
+		},
+	}
+
+	d.NormalizeText()
+
+	node := d.Nodes[nodeId]
+	assert.Equal(t, "Were you dismissed?", node.Question)
+	assert.Equal(t, "Yes sir", node.Answers[0].Statement)
+	assert.Equal(t, "No", node.Answers[1].Statement)
+}
+
+func TestDAG_NormalizeText_ParentPointers(t *testing.T) {
+	t.Parallel()
+
+	nodeId := uuid.New()
+	data := `{"id":"` + uuid.NewString() + `","title":"Normalization","nodes":[{"id":"` + nodeId.String() + `","question":" Dismissed? ","answers":[{"id":"` + uuid.NewString() + `","answer":"  Yes   sir  "}]}]}`
+
+	var d DAG
+	require.NoError(t, json.Unmarshal([]byte(data), &d))
+
+	d.NormalizeText()
+
+	answer := d.Nodes[nodeId].Answers[0]
+	assert.Equal(t, "Yes sir", answer.Statement)
+	require.NotNil(t, answer.ParentNode)
+	assert.Equal(t, "Dismissed?", answer.ParentNode.Question)
+}
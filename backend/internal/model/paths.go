@@ -0,0 +1,163 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// WalkStep is a single step of a path: the question asked and the answer chosen
+type WalkStep struct {
+	NodeId   uuid.UUID `json:"node_id"`
+	Question string    `json:"question"`
+	Answer   Answer    `json:"answer"`
+}
+
+// PathDetail describes a complete path from the root node to the node where the walk ends
+type PathDetail struct {
+	Steps      []WalkStep `json:"steps"`
+	LeafNodeId uuid.UUID  `json:"leaf_node_id"`
+}
+
+// EnumeratePaths lists every path from the root node, in answer order. A path ends on a terminal
+// answer, in which case its leaf is the node holding that answer, or on a node without answers.
+// Each node a conditional answer may lead to yields its own paths.
+func (d DAG) EnumeratePaths() ([]PathDetail, error) {
+	paths, _, err := d.EnumeratePathsUpTo(0)
+	return paths, err
+}
+
+// EnumeratePathsUpTo lists the first limit paths from the root node as EnumeratePaths does, every path when limit
+// is not positive, and reports whether paths were left out. The number of paths grows exponentially with the
+// depth of a DAG, the walk stops as soon as the limit is exceeded.
+func (d DAG) EnumeratePathsUpTo(limit int) ([]PathDetail, bool, error) {
+	root, err := d.GetRootNode()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The walk is depth first with an explicit stack, so that deep DAGs do not grow the call stack. Each step
+	// either visits a node, emits a path or leaves a node once the paths below it are listed.
+	type step struct {
+		nodeId uuid.UUID
+		steps  []WalkStep
+		emit   bool
+		leave  bool
+	}
+
+	var paths []PathDetail
+	add := func(path PathDetail) bool {
+		if limit > 0 && len(paths) == limit {
+			return false
+		}
+		paths = append(paths, path)
+		return true
+	}
+
+	onPath := make(map[uuid.UUID]bool)
+	stack := []step{{nodeId: root.Id, steps: []WalkStep{}}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		switch {
+		case current.leave:
+			delete(onPath, current.nodeId)
+			continue
+		case current.emit:
+			if !add(PathDetail{Steps: current.steps, LeafNodeId: current.nodeId}) {
+				return paths, true, nil
+			}
+			continue
+		}
+
+		node, err := d.GetNode(current.nodeId)
+		if err != nil {
+			return nil, false, fmt.Errorf("error getting node %s: %w", current.nodeId, err)
+		}
+		if onPath[node.Id] {
+			return nil, false, fmt.Errorf("cycle detected at node %s", node.Id)
+		}
+
+		if len(node.Answers) == 0 {
+			if !add(PathDetail{Steps: current.steps, LeafNodeId: node.Id}) {
+				return paths, true, nil
+			}
+			continue
+		}
+
+		onPath[node.Id] = true
+		next := []step{}
+		for _, answer := range node.Answers {
+			nextSteps := append(append([]WalkStep(nil), current.steps...), WalkStep{
+				NodeId:   node.Id,
+				Question: node.Question,
+				Answer:   answer,
+			})
+
+			// Without a default next node, the walk ends on the answer when none of its conditions holds
+			if answer.NextNode == nil {
+				next = append(next, step{nodeId: node.Id, steps: nextSteps, emit: true})
+			}
+			for _, target := range answer.Targets() {
+				next = append(next, step{nodeId: target, steps: nextSteps})
+			}
+		}
+		next = append(next, step{nodeId: node.Id, leave: true})
+
+		// Pushed in reverse, the steps are taken in answer order
+		for i := len(next) - 1; i >= 0; i-- {
+			stack = append(stack, next[i])
+		}
+	}
+
+	return paths, false, nil
+}
+
+// ResolvePath follows the given answers from the root node and returns the complete path they describe.
+// Every answer must belong to the node reached so far and the last one must end the walk.
+func (d DAG) ResolvePath(answerIds []uuid.UUID) (PathDetail, error) {
+	node, err := d.GetRootNode()
+	if err != nil {
+		return PathDetail{}, err
+	}
+
+	steps := make([]WalkStep, 0, len(answerIds))
+	path := make([]Answer, 0, len(answerIds))
+	for i, answerId := range answerIds {
+		answer, ok := findAnswer(node, answerId)
+		if !ok {
+			return PathDetail{}, fmt.Errorf("answer %s is not an answer of node %s", answerId, node.Id)
+		}
+		steps = append(steps, WalkStep{NodeId: node.Id, Question: node.Question, Answer: answer})
+		path = append(path, answer)
+
+		next := answer.Next(CollectVariables(path))
+		if next == nil {
+			if i != len(answerIds)-1 {
+				return PathDetail{}, fmt.Errorf("answer %s ends the walk before the last answer", answerId)
+			}
+			return PathDetail{Steps: steps, LeafNodeId: node.Id}, nil
+		}
+
+		node, err = d.GetNode(*next)
+		if err != nil {
+			return PathDetail{}, fmt.Errorf("error getting node %s: %w", *next, err)
+		}
+	}
+
+	if len(node.Answers) > 0 {
+		return PathDetail{}, fmt.Errorf("walk is incomplete, node %s still has answers", node.Id)
+	}
+
+	return PathDetail{Steps: steps, LeafNodeId: node.Id}, nil
+}
+
+func findAnswer(node Node, answerId uuid.UUID) (Answer, bool) {
+	for _, answer := range node.Answers {
+		if answer.Id == answerId {
+			return answer, true
+		}
+	}
+	return Answer{}, false
+}
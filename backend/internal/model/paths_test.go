@@ -0,0 +1,178 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAG_EnumeratePaths(t *testing.T) {
+	t.Parallel()
+
+	rootId := uuid.New()
+	middleId := uuid.New()
+	leafId := uuid.New()
+
+	d := DAG{
+		Id:    uuid.New(),
+		Title: "Paths",
+		Nodes: map[uuid.UUID]Node{
+			rootId: {
+				Id:       rootId,
+				Question: "Root question?",
+				Answers: []Answer{
+					{Id: uuid.New(), Statement: "To middle", NextNode: &middleId},
+					{Id: uuid.New(), Statement: "To leaf", NextNode: &leafId},
+				},
+			},
+			middleId: {
+				Id:       middleId,
+				Question: "Middle question?",
+				Answers: []Answer{
+					{Id: uuid.New(), Statement: "To leaf", NextNode: &leafId},
+					{Id: uuid.New(), Statement: "Stop here"},
+				},
+			},
+			leafId: {Id: leafId, Question: "Leaf question?", Answers: []Answer{}},
+		},
+	}
+
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+
+	expected := []struct {
+		questions  []string
+		statements []string
+		leaf       uuid.UUID
+	}{
+		{[]string{"Root question?", "Middle question?"}, []string{"To middle", "To leaf"}, leafId},
+		{[]string{"Root question?", "Middle question?"}, []string{"To middle", "Stop here"}, middleId},
+		{[]string{"Root question?"}, []string{"To leaf"}, leafId},
+	}
+
+	for i, path := range paths {
+		assert.Equal(t, expected[i].leaf, path.LeafNodeId)
+		require.Len(t, path.Steps, len(expected[i].questions))
+		for j, step := range path.Steps {
+			// Each step question is the question of the node the answer belongs to
+			assert.Equal(t, d.Nodes[step.NodeId].Question, step.Question)
+			assert.Equal(t, expected[i].questions[j], step.Question)
+			assert.Equal(t, expected[i].statements[j], step.Answer.Statement)
+		}
+	}
+
+	t.Run("single node", func(t *testing.T) {
+		t.Parallel()
+
+		nodeId := uuid.New()
+		single := DAG{Nodes: map[uuid.UUID]Node{nodeId: {Id: nodeId, Question: "Only?"}}}
+
+		paths, err := single.EnumeratePaths()
+		require.NoError(t, err)
+		require.Len(t, paths, 1)
+		assert.Empty(t, paths[0].Steps)
+		assert.Equal(t, nodeId, paths[0].LeafNodeId)
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		t.Parallel()
+
+		limited, truncated, err := d.EnumeratePathsUpTo(2)
+		require.NoError(t, err)
+		assert.True(t, truncated)
+		require.Len(t, limited, 2)
+		assert.Equal(t, paths[:2], limited)
+
+		all, truncated, err := d.EnumeratePathsUpTo(len(paths))
+		require.NoError(t, err)
+		assert.False(t, truncated)
+		assert.Equal(t, paths, all)
+	})
+
+	t.Run("cycle below the root", func(t *testing.T) {
+		t.Parallel()
+
+		aId, bId, cId := uuid.New(), uuid.New(), uuid.New()
+		cyclic := DAG{Nodes: map[uuid.UUID]Node{
+			aId: {Id: aId, Question: "A?", Answers: []Answer{{Id: uuid.New(), Statement: "B", NextNode: &bId}}},
+			bId: {Id: bId, Question: "B?", Answers: []Answer{{Id: uuid.New(), Statement: "C", NextNode: &cId}}},
+			cId: {Id: cId, Question: "C?", Answers: []Answer{{Id: uuid.New(), Statement: "B", NextNode: &bId}}},
+		}}
+
+		_, err := cyclic.EnumeratePaths()
+		assert.ErrorContains(t, err, "cycle detected")
+	})
+}
+
+func TestDAG_ResolvePath(t *testing.T) {
+	t.Parallel()
+
+	rootId := uuid.New()
+	leafId := uuid.New()
+	toLeaf := Answer{Id: uuid.New(), Statement: "To leaf", NextNode: &leafId}
+	stop := Answer{Id: uuid.New(), Statement: "Stop here"}
+	leafAnswer := Answer{Id: uuid.New(), Statement: "Done"}
+
+	d := DAG{
+		Id: uuid.New(),
+		Nodes: map[uuid.UUID]Node{
+			rootId: {Id: rootId, Question: "Root?", Answers: []Answer{toLeaf, stop}},
+			leafId: {Id: leafId, Question: "Leaf?", Answers: []Answer{leafAnswer}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		answerIds     []uuid.UUID
+		expectedLeaf  uuid.UUID
+		expectedSteps int
+		expectedError string
+	}{
+		{
+			name:          "ends on terminal answer",
+			answerIds:     []uuid.UUID{toLeaf.Id, leafAnswer.Id},
+			expectedLeaf:  leafId,
+			expectedSteps: 2,
+		},
+		{
+			name:          "ends on terminal answer of the root",
+			answerIds:     []uuid.UUID{stop.Id},
+			expectedLeaf:  rootId,
+			expectedSteps: 1,
+		},
+		{
+			name:          "rejects incomplete walk",
+			answerIds:     []uuid.UUID{toLeaf.Id},
+			expectedError: "walk is incomplete",
+		},
+		{
+			name:          "rejects answer of another node",
+			answerIds:     []uuid.UUID{leafAnswer.Id},
+			expectedError: "is not an answer of node",
+		},
+		{
+			name:          "rejects answers after the walk ended",
+			answerIds:     []uuid.UUID{stop.Id, leafAnswer.Id},
+			expectedError: "ends the walk before the last answer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path, err := d.ResolvePath(tt.answerIds)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLeaf, path.LeafNodeId)
+			assert.Len(t, path.Steps, tt.expectedSteps)
+		})
+	}
+}
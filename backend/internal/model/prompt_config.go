@@ -0,0 +1,128 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PromptConfig holds the wording used by the CLI answer providers, allowing deployments
+// to customize or localize the interactive prompts
+type PromptConfig struct {
+	// QuestionHeader formats the node question (receives the question as %s)
+	QuestionHeader string
+	// QuestionUnderline is repeated under the question header, empty to disable
+	QuestionUnderline string
+	// OptionFormat formats each answer option (receives the option number as %d and the statement as %s)
+	OptionFormat string
+	// SelectionPrompt asks the user to pick an option
+	SelectionPrompt string
+	// MultiSelectionPrompt asks the user to pick one or more options of a multi-select node
+	MultiSelectionPrompt string
+	// InputPrompt asks the user to type in the value of an input node (receives the expected format as %s)
+	InputPrompt string
+	// SelectedFormat confirms the selected answer (receives the statement as %s)
+	SelectedFormat string
+	// ContextHeader introduces the optional context collection
+	ContextHeader string
+	// NotesPrompt asks for free-form notes
+	NotesPrompt string
+	// ConfidencePrompt asks for a confidence level
+	ConfidencePrompt string
+	// TagsPrompt asks for comma-separated tags
+	TagsPrompt string
+	// AssessmentFormat formats the assessment of an outcome node (receives the assessment as %s)
+	AssessmentFormat string
+	// ActionsHeader and StatutesHeader introduce the recommended actions and statutes of an outcome node
+	ActionsHeader  string
+	StatutesHeader string
+	// ListItemFormat formats each recommended action or statute (receives the item as %s)
+	ListItemFormat string
+}
+
+// DefaultPromptConfig returns the default English prompt wording
+func DefaultPromptConfig() PromptConfig {
+	return PromptConfig{
+		QuestionHeader:       "\n%s\n",
+		QuestionUnderline:    "-",
+		OptionFormat:         "%d. %s\n",
+		SelectionPrompt:      "\nSelect your answer (enter the number): ",
+		MultiSelectionPrompt: "\nSelect all answers that apply (comma-separated numbers): ",
+		InputPrompt:          "\nEnter your answer (%s): ",
+		SelectedFormat:       "You selected: %s\n",
+		ContextHeader:        "\n--- Additional Context (Optional) ---",
+		NotesPrompt:          "\nAdd notes or explanation (press Enter to skip): ",
+		ConfidencePrompt:     "Confidence level 1-10 (press Enter to skip): ",
+		TagsPrompt:           "Tags (comma-separated, press Enter to skip): ",
+		AssessmentFormat:     "Assessment: %s\n",
+		ActionsHeader:        "Recommended actions:\n",
+		StatutesHeader:       "Statutes:\n",
+		ListItemFormat:       "  - %s\n",
+	}
+}
+
+// RenderQuestion writes the question, its numbered answer options and the selection prompt matching the node
+func (c PromptConfig) RenderQuestion(w io.Writer, node Node) {
+	fmt.Fprintf(w, c.QuestionHeader, node.Question)
+	if c.QuestionUnderline != "" {
+		fmt.Fprintln(w, strings.Repeat(c.QuestionUnderline, len(node.Question)))
+	}
+
+	for i, answer := range node.Answers {
+		fmt.Fprintf(w, c.OptionFormat, i+1, answer.Statement)
+	}
+
+	if node.MultiSelect {
+		fmt.Fprint(w, c.MultiSelectionPrompt)
+		return
+	}
+	fmt.Fprint(w, c.SelectionPrompt)
+}
+
+// RenderInput writes the question of an input node and the prompt describing the expected value
+func (c PromptConfig) RenderInput(w io.Writer, node Node) {
+	c.RenderInformation(w, node)
+
+	format := "text"
+	if node.Input != nil {
+		switch node.Input.ValueKind() {
+		case InputKindNumber:
+			format = "number"
+		case InputKindDate:
+			format = "YYYY-MM-DD"
+		}
+	}
+	fmt.Fprintf(w, c.InputPrompt, format)
+}
+
+// RenderInformation writes the text of an information node
+func (c PromptConfig) RenderInformation(w io.Writer, node Node) {
+	fmt.Fprintf(w, c.QuestionHeader, node.Question)
+	if c.QuestionUnderline != "" {
+		fmt.Fprintln(w, strings.Repeat(c.QuestionUnderline, len(node.Question)))
+	}
+}
+
+// RenderOutcome writes the text of an outcome node along with its assessment, recommended actions and statutes
+func (c PromptConfig) RenderOutcome(w io.Writer, node Node) {
+	c.RenderInformation(w, node)
+	if node.Outcome == nil {
+		return
+	}
+
+	if node.Outcome.Assessment != "" {
+		fmt.Fprintf(w, c.AssessmentFormat, node.Outcome.Assessment)
+	}
+	if len(node.Outcome.RecommendedActions) > 0 {
+		fmt.Fprint(w, c.ActionsHeader)
+		for _, action := range node.Outcome.RecommendedActions {
+			fmt.Fprintf(w, c.ListItemFormat, action)
+		}
+	}
+	if len(node.Outcome.Statutes) > 0 {
+		fmt.Fprint(w, c.StatutesHeader)
+		for _, statute := range node.Outcome.Statutes {
+			fmt.Fprintf(w, c.ListItemFormat, statute)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptConfig_RenderQuestion(t *testing.T) {
+	t.Parallel()
+
+	node := Node{
+		Id:       uuid.New(),
+		Question: "Were you dismissed?",
+		Answers: []Answer{
+			{Id: uuid.New(), Statement: "Yes"},
+			{Id: uuid.New(), Statement: "No"},
+		},
+	}
+
+	t.Run("renders default prompt", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		DefaultPromptConfig().RenderQuestion(&buf, node)
+
+		assert.Equal(t,
+			"\nWere you dismissed?\n-------------------\n1. Yes\n2. No\n\nSelect your answer (enter the number): ",
+			buf.String(),
+		)
+	})
+
+	t.Run("renders custom template", func(t *testing.T) {
+		t.Parallel()
+
+		config := DefaultPromptConfig()
+		config.QuestionHeader = "## %s\n"
+		config.QuestionUnderline = ""
+		config.OptionFormat = "[%d] %s\n"
+		config.SelectionPrompt = "Votre choix : "
+
+		var buf bytes.Buffer
+		config.RenderQuestion(&buf, node)
+
+		assert.Equal(t, "## Were you dismissed?\n[1] Yes\n[2] No\nVotre choix : ", buf.String())
+	})
+
+	t.Run("asks for several answers on multi-select nodes", func(t *testing.T) {
+		t.Parallel()
+
+		multi := node
+		multi.MultiSelect = true
+
+		var buf bytes.Buffer
+		DefaultPromptConfig().RenderQuestion(&buf, multi)
+
+		assert.Contains(t, buf.String(), "\nSelect all answers that apply (comma-separated numbers): ")
+	})
+}
+
+func TestPromptConfig_RenderOutcome(t *testing.T) {
+	t.Parallel()
+
+	node := Node{
+		Id:       uuid.New(),
+		Question: "Conclusion",
+		Type:     NodeTypeOutcome,
+		Outcome: &Outcome{
+			Assessment:         "Likely unfair dismissal",
+			RecommendedActions: []string{"File a claim"},
+			Statutes:           []string{"L1235-1"},
+		},
+	}
+
+	var buf bytes.Buffer
+	DefaultPromptConfig().RenderOutcome(&buf, node)
+
+	assert.Equal(t,
+		"\nConclusion\n----------\nAssessment: Likely unfair dismissal\nRecommended actions:\n  - File a claim\nStatutes:\n  - L1235-1\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"slices"
+	"sort"
+)
+
+// Scoring declares the dimensions the answers of a DAG contribute scores to, e.g. the strength of a claim
+type Scoring struct {
+	Dimensions []ScoreDimension `json:"dimensions"`
+}
+
+// ScoreDimension is an axis scored along a path
+type ScoreDimension struct {
+	// Name identifies the dimension in the scores of the answers
+	Name  string `json:"name"`
+	Label string `json:"label,omitempty"`
+	// Weight of the dimension in the total score, 1 when zero
+	Weight float64 `json:"weight,omitempty"`
+	// Bands qualify the score of the dimension, the band with the highest minimum reached applies
+	Bands []ScoreBand `json:"bands,omitempty"`
+}
+
+// ScoreBand qualifies the scores reaching its minimum, e.g. "strong" from 10
+type ScoreBand struct {
+	Min   float64 `json:"min"`
+	Label string  `json:"label"`
+}
+
+// Score is the aggregate score of a path
+type Score struct {
+	Dimensions []DimensionScore `json:"dimensions"`
+	// Total sums the scores of the dimensions, each multiplied by the weight of its dimension
+	Total float64 `json:"total"`
+}
+
+// DimensionScore is the score of a path along a dimension
+type DimensionScore struct {
+	Name  string  `json:"name"`
+	Label string  `json:"label,omitempty"`
+	Value float64 `json:"value"`
+	// Band is the label of the band the value reaches, empty when none does
+	Band string `json:"band,omitempty"`
+}
+
+func (s *Scoring) clone() *Scoring {
+	if s == nil {
+		return nil
+	}
+	copied := &Scoring{Dimensions: make([]ScoreDimension, 0, len(s.Dimensions))}
+	for _, dimension := range s.Dimensions {
+		dimension.Bands = slices.Clone(dimension.Bands)
+		copied.Dimensions = append(copied.Dimensions, dimension)
+	}
+	return copied
+}
+
+// Dimension returns the declared dimension of the given name
+func (s *Scoring) Dimension(name string) (ScoreDimension, bool) {
+	if s == nil {
+		return ScoreDimension{}, false
+	}
+	for _, dimension := range s.Dimensions {
+		if dimension.Name == name {
+			return dimension, true
+		}
+	}
+	return ScoreDimension{}, false
+}
+
+// Score sums the score contributions of the answers of a path along the dimensions of the DAG, in their
+// declared order. Without scoring configuration, every dimension an answer contributes to is scored with
+// a weight of 1, in name order.
+func (d DAG) Score(path []Answer) Score {
+	dimensions := d.scoreDimensions(path)
+
+	score := Score{Dimensions: make([]DimensionScore, 0, len(dimensions))}
+	for _, dimension := range dimensions {
+		var value float64
+		for _, answer := range path {
+			value += answer.Scores[dimension.Name]
+		}
+
+		weight := dimension.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		score.Total += value * weight
+		score.Dimensions = append(score.Dimensions, DimensionScore{
+			Name:  dimension.Name,
+			Label: dimension.Label,
+			Value: value,
+			Band:  dimension.band(value),
+		})
+	}
+
+	return score
+}
+
+func (d DAG) scoreDimensions(path []Answer) []ScoreDimension {
+	if d.Scoring != nil {
+		return d.Scoring.Dimensions
+	}
+
+	seen := make(map[string]bool)
+	var dimensions []ScoreDimension
+	for _, answer := range path {
+		for name := range answer.Scores {
+			if !seen[name] {
+				seen[name] = true
+				dimensions = append(dimensions, ScoreDimension{Name: name})
+			}
+		}
+	}
+	sort.Slice(dimensions, func(i, j int) bool {
+		return dimensions[i].Name < dimensions[j].Name
+	})
+	return dimensions
+}
+
+// band returns the label of the band with the highest minimum the value reaches
+func (dimension ScoreDimension) band(value float64) string {
+	var reached *ScoreBand
+	for i, band := range dimension.Bands {
+		if value >= band.Min && (reached == nil || band.Min > reached.Min) {
+			reached = &dimension.Bands[i]
+		}
+	}
+	if reached == nil {
+		return ""
+	}
+	return reached.Label
+}
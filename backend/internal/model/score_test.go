@@ -0,0 +1,82 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAG_Score(t *testing.T) {
+	t.Parallel()
+
+	path := []Answer{
+		{Statement: "Dismissed by email", Scores: map[string]float64{"claim_strength": 4, "evidence": 1}},
+		{Statement: "Kept the email", Scores: map[string]float64{"evidence": 3}},
+		{Statement: "No witness"},
+	}
+
+	t.Run("scores the declared dimensions", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDAG("Dismissal")
+		d.Scoring = &Scoring{Dimensions: []ScoreDimension{
+			{Name: "evidence", Label: "Evidence", Weight: 2, Bands: []ScoreBand{{Min: 0, Label: "weak"}, {Min: 5, Label: "solid"}, {Min: 3, Label: "fair"}}},
+			{Name: "claim_strength", Label: "Claim strength", Bands: []ScoreBand{{Min: 5, Label: "strong"}}},
+			{Name: "damages"},
+		}}
+
+		score := d.Score(path)
+		assert.Equal(t, []DimensionScore{
+			{Name: "evidence", Label: "Evidence", Value: 4, Band: "fair"},
+			{Name: "claim_strength", Label: "Claim strength", Value: 4},
+			{Name: "damages", Value: 0},
+		}, score.Dimensions)
+		assert.Equal(t, 12.0, score.Total)
+	})
+
+	t.Run("scores every contributed dimension without configuration", func(t *testing.T) {
+		t.Parallel()
+
+		score := NewDAG("Dismissal").Score(path)
+		assert.Equal(t, []DimensionScore{
+			{Name: "claim_strength", Value: 4},
+			{Name: "evidence", Value: 4},
+		}, score.Dimensions)
+		assert.Equal(t, 8.0, score.Total)
+	})
+
+	t.Run("copies the scoring with the DAG", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _, _ := newConditionalDAG()
+		d.Scoring = &Scoring{Dimensions: []ScoreDimension{{Name: "evidence", Bands: []ScoreBand{{Min: 1, Label: "some"}}}}}
+		root := d.Nodes[rootId]
+		root.Answers[0].Scores = map[string]float64{"evidence": 1}
+		d.Nodes[rootId] = root
+
+		clone := CloneDAG(d)
+		require.NotNil(t, clone.Scoring)
+		assert.Equal(t, d.Scoring, clone.Scoring)
+		clone.Scoring.Dimensions[0].Bands[0].Label = "changed"
+		assert.Equal(t, "some", d.Scoring.Dimensions[0].Bands[0].Label)
+
+		cloneRoot, err := clone.GetRootNode()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{"evidence": 1}, cloneRoot.Answers[0].Scores)
+	})
+
+	t.Run("keeps the scoring through JSON", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDAG("Dismissal")
+		d.Scoring = &Scoring{Dimensions: []ScoreDimension{{Name: "evidence", Weight: 2}}}
+
+		data, err := json.Marshal(d)
+		require.NoError(t, err)
+		var decoded DAG
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, d.Scoring, decoded.Scoring)
+	})
+}
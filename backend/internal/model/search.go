@@ -0,0 +1,23 @@
+package model
+
+import "github.com/google/uuid"
+
+const (
+	SearchFieldQuestion    = "question"
+	SearchFieldAnswer      = "answer"
+	SearchFieldUserContext = "user_context"
+)
+
+// SearchHit is a node text matching a full-text search
+type SearchHit struct {
+	DAGId  uuid.UUID
+	NodeId uuid.UUID
+	// AnswerId is set when the match is in an answer statement or its user context
+	AnswerId *uuid.UUID
+	// Field is the matched text, one of the SearchField constants
+	Field string
+	// Snippet is the matched text around the first occurrence of the query
+	Snippet string
+	// Score counts the occurrences of the query terms in the matched text
+	Score int
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// SyncStatus describes the DAGs changed in memory which are not yet persisted to files
+type SyncStatus struct {
+	// Dirty is the number of DAGs changed since they were last persisted
+	Dirty int
+	// OldestDirtyAt is when the DAG waiting the longest was changed, nil when no DAG is dirty
+	OldestDirtyAt *time.Time
+	// LastSyncAt is the end of the last sync, nil before the first one
+	LastSyncAt *time.Time
+}
+
+// Lag is how long the oldest change has been waiting to be persisted, 0 when every DAG is persisted
+func (s SyncStatus) Lag(now time.Time) time.Duration {
+	if s.OldestDirtyAt == nil {
+		return 0
+	}
+	return now.Sub(*s.OldestDirtyAt)
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Template is a reusable snapshot of a DAG, from which new DAGs of the same structure are instantiated
+type Template struct {
+	Id          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	// SourceDAGId is the DAG the template was saved from, the template does not follow its later changes
+	SourceDAGId uuid.UUID `json:"source_dag_id"`
+	DAG         *DAG      `json:"dag"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewTemplate snapshots a DAG as a template. The snapshot drops the revision, trash and validation state
+// of the DAG, which belong to the DAG rather than to its structure.
+func NewTemplate(name, description string, d *DAG, now time.Time) Template {
+	snapshot := d.Reidentify(func(id uuid.UUID) uuid.UUID { return id })
+	return Template{
+		Id:          uuid.New(),
+		Name:        name,
+		Description: description,
+		SourceDAGId: d.Id,
+		DAG:         snapshot,
+		CreatedAt:   now,
+	}
+}
+
+// Instantiate returns a new DAG with the structure of the template, the DAG, its nodes and its answers
+// getting fresh IDs. The DAG is titled after the template DAG when title is empty.
+func (t Template) Instantiate(title string) *DAG {
+	d := CloneDAG(t.DAG)
+	if title != "" {
+		d.Title = title
+	}
+	return d
+}
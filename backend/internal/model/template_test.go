@@ -0,0 +1,100 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("snapshots a DAG without its state", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		deletedAt := now
+		source.Revision = 4
+		source.DeletedAt = &deletedAt
+		source.Metadata = &DAGMetadata{IsValid: true}
+
+		template := NewTemplate("Dismissal", "Employment dismissal intake", source, now)
+
+		assert.NotEqual(t, uuid.Nil, template.Id)
+		assert.Equal(t, source.Id, template.SourceDAGId)
+		assert.Equal(t, source.Id, template.DAG.Id)
+		assert.Equal(t, now, template.CreatedAt)
+		assert.Zero(t, template.DAG.Revision)
+		assert.Nil(t, template.DAG.DeletedAt)
+		assert.False(t, template.DAG.Metadata.IsValid)
+		for id := range source.Nodes {
+			assert.Contains(t, template.DAG.Nodes, id)
+		}
+	})
+
+	t.Run("instantiates with fresh IDs and the same topology", func(t *testing.T) {
+		t.Parallel()
+
+		source := newCaseDAG()
+		for _, node := range source.Nodes {
+			node.Answers[0].Metadata = map[string]interface{}{"estimated_damages": 1000.0}
+		}
+		template := NewTemplate("Dismissal", "", source, now)
+
+		instance := template.Instantiate("")
+
+		assert.NotEqual(t, source.Id, instance.Id)
+		assert.Equal(t, source.Title, instance.Title)
+		require.Len(t, instance.Nodes, len(source.Nodes))
+
+		sourceIds := make(map[uuid.UUID]bool)
+		for _, node := range source.Nodes {
+			sourceIds[node.Id] = true
+			for _, answer := range node.Answers {
+				sourceIds[answer.Id] = true
+			}
+		}
+		for id, node := range instance.Nodes {
+			assert.Equal(t, id, node.Id)
+			assert.False(t, sourceIds[node.Id])
+			for _, answer := range node.Answers {
+				assert.False(t, sourceIds[answer.Id])
+				assert.Equal(t, node.Id, answer.ParentNode.Id)
+				if answer.NextNode != nil {
+					assert.Contains(t, instance.Nodes, *answer.NextNode)
+				}
+			}
+		}
+
+		_, err := source.StructuralMapping(*instance)
+		require.NoError(t, err)
+		assert.Equal(t, 1000.0, answerByQuestion(t, instance, "Any other claim?", "None").Metadata["estimated_damages"])
+	})
+
+	t.Run("instances do not share state with the template", func(t *testing.T) {
+		t.Parallel()
+
+		template := NewTemplate("Dismissal", "", newCaseDAG(), now)
+		first := template.Instantiate("First case")
+		second := template.Instantiate("")
+
+		assert.Equal(t, "First case", first.Title)
+		assert.NotEqual(t, first.Id, second.Id)
+		for id := range first.Nodes {
+			assert.NotContains(t, second.Nodes, id)
+			assert.NotContains(t, template.DAG.Nodes, id)
+		}
+
+		for _, node := range first.Nodes {
+			node.Answers[0].Metadata = map[string]interface{}{"changed": true}
+		}
+		for _, node := range template.DAG.Nodes {
+			assert.NotContains(t, node.Answers[0].Metadata, "changed")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// WalkAnalytics accumulates the answers chosen during the completed walks of a DAG
+type WalkAnalytics struct {
+	DAGId uuid.UUID `json:"dag_id"`
+	// EdgeCounts counts the completed walks that went through each answer
+	EdgeCounts map[uuid.UUID]int `json:"edge_counts"`
+	// Paths counts the completed walks per distinct path, in order of first recording
+	Paths []PathCount `json:"paths"`
+}
+
+// PathCount is a complete path, as the ordered answers chosen, and how many walks followed it
+type PathCount struct {
+	AnswerIds []uuid.UUID `json:"answer_ids"`
+	Count     int         `json:"count"`
+}
+
+func NewWalkAnalytics(dagId uuid.UUID) *WalkAnalytics {
+	return &WalkAnalytics{
+		DAGId:      dagId,
+		EdgeCounts: make(map[uuid.UUID]int),
+		Paths:      []PathCount{},
+	}
+}
+
+// Record accounts for a completed walk through the given answers
+func (a *WalkAnalytics) Record(answerIds []uuid.UUID) {
+	if a.EdgeCounts == nil {
+		a.EdgeCounts = make(map[uuid.UUID]int)
+	}
+	for _, answerId := range answerIds {
+		a.EdgeCounts[answerId]++
+	}
+
+	for i := range a.Paths {
+		if samePath(a.Paths[i].AnswerIds, answerIds) {
+			a.Paths[i].Count++
+			return
+		}
+	}
+
+	a.Paths = append(a.Paths, PathCount{
+		AnswerIds: append([]uuid.UUID(nil), answerIds...),
+		Count:     1,
+	})
+}
+
+// TotalWalks returns the number of completed walks recorded
+func (a WalkAnalytics) TotalWalks() int {
+	total := 0
+	for _, path := range a.Paths {
+		total += path.Count
+	}
+	return total
+}
+
+// TopPaths returns the most followed paths, most common first, ties kept in order of first recording.
+// A limit of zero returns every path.
+func (a WalkAnalytics) TopPaths(limit int) []PathCount {
+	paths := append([]PathCount(nil), a.Paths...)
+	sort.SliceStable(paths, func(i, j int) bool {
+		return paths[i].Count > paths[j].Count
+	})
+
+	if limit > 0 && len(paths) > limit {
+		paths = paths[:limit]
+	}
+	return paths
+}
+
+func samePath(a []uuid.UUID, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkAnalytics_Record(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	common := []uuid.UUID{a, b}
+	rare := []uuid.UUID{a, c}
+	other := []uuid.UUID{c}
+
+	analytics := NewWalkAnalytics(uuid.New())
+	analytics.Record(rare)
+	analytics.Record(common)
+	analytics.Record(other)
+	analytics.Record(common)
+	analytics.Record(common)
+	analytics.Record(other)
+
+	assert.Equal(t, map[uuid.UUID]int{a: 4, b: 3, c: 3}, analytics.EdgeCounts)
+
+	top := analytics.TopPaths(0)
+	require.Len(t, top, 3)
+	assert.Equal(t, PathCount{AnswerIds: common, Count: 3}, top[0])
+	assert.Equal(t, PathCount{AnswerIds: other, Count: 2}, top[1])
+	assert.Equal(t, PathCount{AnswerIds: rare, Count: 1}, top[2])
+
+	t.Run("limits the number of paths", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, top[:2], analytics.TopPaths(2))
+	})
+
+	t.Run("keeps first recorded path first on ties", func(t *testing.T) {
+		t.Parallel()
+
+		tied := NewWalkAnalytics(uuid.New())
+		tied.Record(rare)
+		tied.Record(common)
+
+		assert.Equal(t, rare, tied.TopPaths(0)[0].AnswerIds)
+	})
+}
@@ -0,0 +1,210 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// WalkBranch is an answer selected during a multi-select walk, along with the branches walked from its next node
+type WalkBranch struct {
+	Answer Answer
+	Next   []WalkBranch
+}
+
+// WalkMulti traverses the DAG starting from the given node ID like Walk, using fnMultiAnswer to
+// select the answers of each node. Every answer selected on a multi-select node is followed, so the
+// walk produces a tree of sub-paths. Single-select nodes must be answered with exactly one answer.
+func (d DAG) WalkMulti(nodeId uuid.UUID, fnMultiAnswer func(Node) ([]Answer, error)) ([]WalkBranch, error) {
+	return d.walkMulti(nodeId, fnMultiAnswer, nil)
+}
+
+// walkMulti walks a node given the answers selected on the way to it, whose metadata conditions are evaluated against
+func (d DAG) walkMulti(nodeId uuid.UUID, fnMultiAnswer func(Node) ([]Answer, error), path []Answer) ([]WalkBranch, error) {
+	node, err := d.GetNode(nodeId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node %s: %w", nodeId, err)
+	}
+
+	if len(node.Answers) == 0 {
+		return nil, nil
+	}
+
+	selectedAnswers, err := fnMultiAnswer(node)
+	if err != nil {
+		return nil, fmt.Errorf("error getting answers for node %s: %w", nodeId, err)
+	}
+
+	if err := node.checkSelection(selectedAnswers); err != nil {
+		return nil, err
+	}
+
+	branches := make([]WalkBranch, 0, len(selectedAnswers))
+	for _, selectedAnswer := range selectedAnswers {
+		branch := WalkBranch{Answer: selectedAnswer}
+		branchPath := append(append([]Answer(nil), path...), selectedAnswer)
+		if next := selectedAnswer.Next(CollectVariables(branchPath)); next != nil {
+			branch.Next, err = d.walkMulti(*next, fnMultiAnswer, branchPath)
+			if err != nil {
+				return branches, err
+			}
+		}
+		branches = append(branches, branch)
+	}
+
+	return branches, nil
+}
+
+// FlattenWalkBranches flattens the sub-paths of a multi-select walk into linear paths, one per branch end
+func FlattenWalkBranches(branches []WalkBranch) [][]Answer {
+	var paths [][]Answer
+	for _, branch := range branches {
+		if len(branch.Next) == 0 {
+			paths = append(paths, []Answer{branch.Answer})
+			continue
+		}
+		for _, subPath := range FlattenWalkBranches(branch.Next) {
+			paths = append(paths, append([]Answer{branch.Answer}, subPath...))
+		}
+	}
+
+	return paths
+}
+
+// MultiSelectNext is the rule resolving the next node of a multi-select node from the answers selected on it
+type MultiSelectNext string
+
+const (
+	// MultiSelectNextPriority moves to the next node of the first selected answer, in answer order, leading somewhere
+	MultiSelectNextPriority MultiSelectNext = "priority"
+	// MultiSelectNextConvergence requires every selected answer to lead to the same node
+	MultiSelectNextConvergence MultiSelectNext = "convergence"
+)
+
+// NextRule returns the rule resolving the next node of the node, priority when none is set
+func (n Node) NextRule() MultiSelectNext {
+	if n.MultiSelectNext == "" {
+		return MultiSelectNextPriority
+	}
+	return n.MultiSelectNext
+}
+
+// ResolveNext resolves the node a walk moves to once the given answers were selected on the node, their
+// conditions being evaluated against vars. A nil node ends the walk.
+func (n Node) ResolveNext(selected []Answer, vars map[string]interface{}) (*uuid.UUID, error) {
+	isSelected := make(map[uuid.UUID]bool, len(selected))
+	for _, answer := range selected {
+		isSelected[answer.Id] = true
+	}
+
+	switch n.NextRule() {
+	case MultiSelectNextPriority:
+		for _, answer := range n.Answers {
+			if !isSelected[answer.Id] {
+				continue
+			}
+			if next := answer.Next(vars); next != nil {
+				return next, nil
+			}
+		}
+		return nil, nil
+	case MultiSelectNextConvergence:
+		var resolved *uuid.UUID
+		for i, answer := range selected {
+			next := answer.Next(vars)
+			if i > 0 && !sameNode(resolved, next) {
+				return nil, fmt.Errorf("selected answers of node %s lead to different nodes", n.Id)
+			}
+			resolved = next
+		}
+		return resolved, nil
+	default:
+		return nil, fmt.Errorf("node %s has unknown multi-select next rule %q", n.Id, n.MultiSelectNext)
+	}
+}
+
+// WalkSelect traverses the DAG starting from the given node ID like Walk, using fnAnswers to select the
+// answers of each node. Several answers may be selected on multi-select nodes, they are all part of the
+// path and the walk moves to the single node resolved by the next rule of the node.
+func (d DAG) WalkSelect(nodeId uuid.UUID, fnAnswers func(Node) ([]Answer, error)) ([]Answer, error) {
+	return d.walkSelect(nodeId, nil, fnAnswers)
+}
+
+// walkSelect continues a walk on the given node, path holding the answers given before reaching it
+func (d DAG) walkSelect(nodeId uuid.UUID, path []Answer, fnAnswers func(Node) ([]Answer, error)) ([]Answer, error) {
+	currentNodeId := nodeId
+
+	for {
+		node, err := d.GetNode(currentNodeId)
+		if err != nil {
+			return path, fmt.Errorf("error getting node %s: %w", currentNodeId, err)
+		}
+
+		if len(node.Answers) == 0 {
+			break
+		}
+
+		selectedAnswers, err := fnAnswers(node)
+		if err != nil {
+			return path, fmt.Errorf("error getting answers for node %s: %w", currentNodeId, err)
+		}
+		if err := node.checkSelection(selectedAnswers); err != nil {
+			return path, err
+		}
+
+		path = append(path, selectedAnswers...)
+
+		next, err := node.ResolveNext(selectedAnswers, CollectVariables(path))
+		if err != nil {
+			return path, err
+		}
+		if next == nil {
+			break
+		}
+		currentNodeId = *next
+	}
+
+	return path, nil
+}
+
+// checkSelection ensures the selected answers belong to the node, are selected once, carry the value
+// entered on input nodes and that several answers are only selected on multi-select nodes
+func (n Node) checkSelection(selected []Answer) error {
+	if len(selected) == 0 {
+		return fmt.Errorf("no answer selected for node %s", n.Id)
+	}
+	if !n.MultiSelect && len(selected) > 1 {
+		return fmt.Errorf("%d answers selected for single-select node %s", len(selected), n.Id)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(selected))
+	for _, answer := range selected {
+		if !n.hasAnswer(answer.Id) {
+			return fmt.Errorf("selected answer %s is not valid for node %s", answer.Id, n.Id)
+		}
+		if seen[answer.Id] {
+			return fmt.Errorf("answer %s selected twice for node %s", answer.Id, n.Id)
+		}
+		seen[answer.Id] = true
+		if err := n.checkInput(answer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sameNode(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (n Node) hasAnswer(answerId uuid.UUID) bool {
+	for _, answer := range n.Answers {
+		if answer.Id == answerId {
+			return true
+		}
+	}
+	return false
+}
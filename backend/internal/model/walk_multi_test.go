@@ -0,0 +1,246 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMultiSelectDAG returns a DAG whose multi-select root leads to a question per selected
+// ground, each of them ending on a terminal answer
+func newMultiSelectDAG() (d *DAG, rootId uuid.UUID, grounds map[string]uuid.UUID) {
+	d = NewDAG("Dismissal grounds")
+	rootId = uuid.New()
+	grounds = map[string]uuid.UUID{}
+
+	var answers []Answer
+	for _, ground := range []string{"Harassment", "Discrimination", "Unpaid wages"} {
+		nodeId := uuid.New()
+		grounds[ground] = nodeId
+		d.Nodes[nodeId] = Node{
+			Id:       nodeId,
+			Question: "Do you have evidence of " + ground + "?",
+			Answers:  []Answer{{Id: uuid.New(), Statement: "Yes"}, {Id: uuid.New(), Statement: "No"}},
+		}
+		answers = append(answers, Answer{Id: uuid.New(), Statement: ground, NextNode: &nodeId})
+	}
+	d.Nodes[rootId] = Node{Id: rootId, Question: "Which of these apply?", Answers: answers, MultiSelect: true}
+
+	return d, rootId, grounds
+}
+
+func TestDAG_WalkMulti(t *testing.T) {
+	t.Parallel()
+
+	t.Run("follows every selected answer of a multi-select node", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, grounds := newMultiSelectDAG()
+		branches, err := d.WalkMulti(rootId, func(node Node) ([]Answer, error) {
+			if node.MultiSelect {
+				return []Answer{node.Answers[0], node.Answers[2]}, nil
+			}
+			return []Answer{node.Answers[0]}, nil
+		})
+		require.NoError(t, err)
+
+		require.Len(t, branches, 2)
+		assert.Equal(t, "Harassment", branches[0].Answer.Statement)
+		assert.Equal(t, "Unpaid wages", branches[1].Answer.Statement)
+		for _, branch := range branches {
+			require.Len(t, branch.Next, 1)
+			assert.Equal(t, "Yes", branch.Next[0].Answer.Statement)
+			assert.Empty(t, branch.Next[0].Next)
+			assert.Contains(t, d.Nodes[grounds[branch.Answer.Statement]].Answers, branch.Next[0].Answer)
+		}
+
+		paths := FlattenWalkBranches(branches)
+		require.Len(t, paths, 2)
+		assert.Equal(t, []string{"Harassment", "Yes"}, []string{paths[0][0].Statement, paths[0][1].Statement})
+		assert.Equal(t, []string{"Unpaid wages", "Yes"}, []string{paths[1][0].Statement, paths[1][1].Statement})
+	})
+
+	t.Run("rejects several answers on a single-select node", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newMultiSelectDAG()
+		_, err := d.WalkMulti(rootId, func(node Node) ([]Answer, error) {
+			return node.Answers, nil
+		})
+		assert.ErrorContains(t, err, "single-select")
+	})
+
+	t.Run("rejects answers of another node", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newMultiSelectDAG()
+		_, err := d.WalkMulti(rootId, func(node Node) ([]Answer, error) {
+			return []Answer{{Id: uuid.New(), Statement: "Unknown"}}, nil
+		})
+		assert.ErrorContains(t, err, "is not valid for node")
+	})
+
+	t.Run("rejects an empty selection", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newMultiSelectDAG()
+		_, err := d.WalkMulti(rootId, func(node Node) ([]Answer, error) {
+			return nil, nil
+		})
+		assert.ErrorContains(t, err, "no answer selected")
+	})
+
+	t.Run("rejects an answer selected twice", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newMultiSelectDAG()
+		_, err := d.WalkMulti(rootId, func(node Node) ([]Answer, error) {
+			if node.MultiSelect {
+				return []Answer{node.Answers[0], node.Answers[0]}, nil
+			}
+			return []Answer{node.Answers[0]}, nil
+		})
+		assert.ErrorContains(t, err, "selected twice")
+	})
+}
+
+// newConvergingDAG returns a DAG whose multi-select root answers all lead to the same follow-up node
+func newConvergingDAG() (d *DAG, rootId, followUpId uuid.UUID) {
+	d = NewDAG("Damages")
+	rootId = uuid.New()
+	followUpId = uuid.New()
+
+	d.Nodes[followUpId] = Node{Id: followUpId, Question: "When did it happen?", Answers: []Answer{{Id: uuid.New(), Statement: "Last year"}}}
+	d.Nodes[rootId] = Node{
+		Id:              rootId,
+		Question:        "Which damages did you suffer?",
+		MultiSelect:     true,
+		MultiSelectNext: MultiSelectNextConvergence,
+		Answers: []Answer{
+			{Id: uuid.New(), Statement: "Financial", NextNode: &followUpId},
+			{Id: uuid.New(), Statement: "Moral", NextNode: &followUpId},
+		},
+	}
+
+	return d, rootId, followUpId
+}
+
+func TestNode_ResolveNext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("priority follows the first selected answer in node order", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, grounds := newMultiSelectDAG()
+		root := d.Nodes[rootId]
+
+		next, err := root.ResolveNext([]Answer{root.Answers[2], root.Answers[1]}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, grounds["Discrimination"], *next)
+	})
+
+	t.Run("priority skips terminal answers", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, grounds := newMultiSelectDAG()
+		root := d.Nodes[rootId]
+		root.Answers[0].NextNode = nil
+
+		next, err := root.ResolveNext([]Answer{root.Answers[0], root.Answers[2]}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, grounds["Unpaid wages"], *next)
+
+		next, err = root.ResolveNext([]Answer{root.Answers[0]}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+	})
+
+	t.Run("convergence moves to the node shared by the selected answers", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, followUpId := newConvergingDAG()
+		root := d.Nodes[rootId]
+
+		next, err := root.ResolveNext(root.Answers, nil)
+		require.NoError(t, err)
+		assert.Equal(t, followUpId, *next)
+	})
+
+	t.Run("convergence rejects answers leading to different nodes", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newMultiSelectDAG()
+		root := d.Nodes[rootId]
+		root.MultiSelectNext = MultiSelectNextConvergence
+
+		_, err := root.ResolveNext([]Answer{root.Answers[0], root.Answers[1]}, nil)
+		assert.ErrorContains(t, err, "lead to different nodes")
+	})
+
+	t.Run("rejects an unknown rule", func(t *testing.T) {
+		t.Parallel()
+
+		root := Node{Id: uuid.New(), MultiSelectNext: "random"}
+		_, err := root.ResolveNext(nil, nil)
+		assert.ErrorContains(t, err, "unknown multi-select next rule")
+	})
+}
+
+func TestDAG_WalkSelect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps every selected answer in the path and moves to a single node", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newConvergingDAG()
+		path, err := d.WalkSelect(rootId, func(node Node) ([]Answer, error) {
+			return node.Answers, nil
+		})
+		require.NoError(t, err)
+
+		require.Len(t, path, 3)
+		assert.Equal(t, []string{"Financial", "Moral", "Last year"}, []string{path[0].Statement, path[1].Statement, path[2].Statement})
+	})
+
+	t.Run("stops on a divergent selection", func(t *testing.T) {
+		t.Parallel()
+
+		d, rootId, _ := newMultiSelectDAG()
+		root := d.Nodes[rootId]
+		root.MultiSelectNext = MultiSelectNextConvergence
+		d.Nodes[rootId] = root
+
+		_, err := d.WalkSelect(rootId, func(node Node) ([]Answer, error) {
+			return node.Answers[:2], nil
+		})
+		assert.ErrorContains(t, err, "lead to different nodes")
+	})
+
+	t.Run("rejects several answers on a single-select node", func(t *testing.T) {
+		t.Parallel()
+
+		d, _, grounds := newMultiSelectDAG()
+		_, err := d.WalkSelect(grounds["Harassment"], func(node Node) ([]Answer, error) {
+			return node.Answers, nil
+		})
+		assert.ErrorContains(t, err, "single-select")
+	})
+}
+
+func TestParseSelection(t *testing.T) {
+	t.Parallel()
+
+	d, rootId, _ := newMultiSelectDAG()
+	root := d.Nodes[rootId]
+
+	selected, err := parseSelection(root, " 3, 1,3 ")
+	require.NoError(t, err)
+	assert.Equal(t, []Answer{root.Answers[2], root.Answers[0]}, selected)
+
+	for _, input := range []string{"", ",", "4", "0", "a,1"} {
+		_, err := parseSelection(root, input)
+		assert.Error(t, err, input)
+	}
+}
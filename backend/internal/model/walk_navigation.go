@@ -0,0 +1,159 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WalkCommand is typed instead of an answer to revise the answers given earlier in a walk
+type WalkCommand string
+
+const (
+	// WalkCommandBack asks the previous question again
+	WalkCommandBack WalkCommand = "back"
+	// WalkCommandRestart asks every question again from the first one
+	WalkCommandRestart WalkCommand = "restart"
+	// WalkCommandGoto asks the questions again from the given one
+	WalkCommandGoto WalkCommand = "goto"
+)
+
+// WalkNavigation is returned as an error by answer providers to move a revisable walk back to an earlier question,
+// the answers given from that question on being discarded
+type WalkNavigation struct {
+	Command WalkCommand
+	// Step is the question to go back to with the goto command, numbered from 1
+	Step int
+}
+
+func (n WalkNavigation) Error() string {
+	if n.Command == WalkCommandGoto {
+		return fmt.Sprintf("walk navigation: %s %d", n.Command, n.Step)
+	}
+	return fmt.Sprintf("walk navigation: %s", n.Command)
+}
+
+// ParseWalkNavigation reads a navigation command typed instead of an answer: "back", "restart" or "goto <n>"
+func ParseWalkNavigation(input string) (WalkNavigation, bool) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return WalkNavigation{}, false
+	}
+
+	switch command := WalkCommand(fields[0]); command {
+	case WalkCommandBack, WalkCommandRestart:
+		if len(fields) != 1 {
+			return WalkNavigation{}, false
+		}
+		return WalkNavigation{Command: command}, true
+	case WalkCommandGoto:
+		if len(fields) != 2 {
+			return WalkNavigation{}, false
+		}
+		step, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return WalkNavigation{}, false
+		}
+		return WalkNavigation{Command: command, Step: step}, true
+	default:
+		return WalkNavigation{}, false
+	}
+}
+
+// Steps returns the number of questions answered, the answers selected together on a multi-select node
+// counting as a single question
+func (s WalkState) Steps() int {
+	steps := 0
+	for i, answer := range s.Answers {
+		if i == 0 || s.Answers[i-1].NodeId != answer.NodeId {
+			steps++
+		}
+	}
+	return steps
+}
+
+// Navigate discards the answers given from the question the navigation moves back to on, so that the walk
+// resumes from that question. Going back skips the information nodes of the DAG, which are advanced through
+// without asking, going back from the first question restarts the walk.
+func (s *WalkState) Navigate(d *DAG, navigation WalkNavigation) error {
+	steps := s.Steps()
+
+	var keep int
+	switch navigation.Command {
+	case WalkCommandBack:
+		keep = steps
+		for keep > 0 {
+			keep--
+			if _, auto := d.Nodes[s.Answers[s.answersOf(keep)].NodeId].AutoAnswer(); !auto {
+				break
+			}
+		}
+	case WalkCommandRestart:
+		keep = 0
+	case WalkCommandGoto:
+		// The current question, one past the answered ones, may be gone to as well
+		if navigation.Step < 1 || navigation.Step > steps+1 {
+			return fmt.Errorf("cannot go to question %d, questions 1 to %d were reached", navigation.Step, steps+1)
+		}
+		keep = navigation.Step - 1
+	default:
+		return fmt.Errorf("unknown walk command %q", navigation.Command)
+	}
+
+	s.Answers = s.Answers[:s.answersOf(keep)]
+	return nil
+}
+
+// answersOf returns the number of answers given on the first steps questions
+func (s WalkState) answersOf(steps int) int {
+	step := 0
+	for i, answer := range s.Answers {
+		if i == 0 || s.Answers[i-1].NodeId != answer.NodeId {
+			step++
+		}
+		if step > steps {
+			return i
+		}
+	}
+	return len(s.Answers)
+}
+
+// WalkSelectRevisable resumes a walk from its state like WalkSelectFrom, recording the answers of fnAnswers in
+// the state. When fnAnswers returns a WalkNavigation, the answers given from the question it moves back to on are
+// discarded, along with the branches they led to, and the walk resumes from that question. onChange, when not nil,
+// is called with the state after each answer and navigation, e.g. to save it.
+func (d DAG) WalkSelectRevisable(state *WalkState, fnAnswers func(Node) ([]Answer, error), onChange func(WalkState) error) ([]Answer, error) {
+	recording := func(node Node) ([]Answer, error) {
+		answers, err := fnAnswers(node)
+		if err != nil {
+			return nil, err
+		}
+
+		state.Record(node, answers)
+		if onChange != nil {
+			if err := onChange(*state); err != nil {
+				return nil, err
+			}
+		}
+		return answers, nil
+	}
+
+	for {
+		path, err := d.WalkSelectFrom(*state, recording)
+
+		var navigation WalkNavigation
+		if !errors.As(err, &navigation) {
+			return path, err
+		}
+
+		if err := state.Navigate(&d, navigation); err != nil {
+			return path, err
+		}
+		if onChange != nil {
+			if err := onChange(*state); err != nil {
+				return path, err
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNavigationDAG returns a DAG dismissed -> notice -> written, where the notice is an information node
+// and the dismissed question also has a terminal answer
+func newNavigationDAG() (d *DAG, dismissedId, noticeId, writtenId uuid.UUID) {
+	d = NewDAG("Dismissal")
+	dismissedId, noticeId, writtenId = uuid.New(), uuid.New(), uuid.New()
+
+	d.Nodes[dismissedId] = Node{
+		Id:       dismissedId,
+		Question: "Were you dismissed?",
+		Answers: []Answer{
+			{Id: uuid.New(), Statement: "Yes", NextNode: &noticeId},
+			{Id: uuid.New(), Statement: "No"},
+		},
+	}
+	d.Nodes[noticeId] = Node{
+		Id:       noticeId,
+		Type:     NodeTypeInformation,
+		Question: "A dismissal must be notified",
+		Answers:  []Answer{{Id: uuid.New(), Statement: "Continue", NextNode: &writtenId}},
+	}
+	d.Nodes[writtenId] = Node{
+		Id:       writtenId,
+		Question: "Were you notified in writing?",
+		Answers:  []Answer{{Id: uuid.New(), Statement: "Yes"}, {Id: uuid.New(), Statement: "No"}},
+	}
+
+	return d, dismissedId, noticeId, writtenId
+}
+
+// scriptedFnAnswers answers each node with the next reply scripted for it, a navigation being returned as is
+func scriptedFnAnswers(t *testing.T, script map[uuid.UUID][]interface{}) func(Node) ([]Answer, error) {
+	return func(node Node) ([]Answer, error) {
+		if answer, ok := node.AutoAnswer(); ok {
+			return []Answer{answer}, nil
+		}
+		require.NotEmpty(t, script[node.Id], "unexpected question %q", node.Question)
+
+		reply := script[node.Id][0]
+		script[node.Id] = script[node.Id][1:]
+		switch reply := reply.(type) {
+		case WalkNavigation:
+			return nil, reply
+		case string:
+			for _, answer := range node.Answers {
+				if answer.Statement == reply {
+					return []Answer{answer}, nil
+				}
+			}
+		}
+		return nil, errors.New("unknown reply")
+	}
+}
+
+func TestParseWalkNavigation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected WalkNavigation
+		ok       bool
+	}{
+		{input: "back", expected: WalkNavigation{Command: WalkCommandBack}, ok: true},
+		{input: " Restart ", expected: WalkNavigation{Command: WalkCommandRestart}, ok: true},
+		{input: "goto 3", expected: WalkNavigation{Command: WalkCommandGoto, Step: 3}, ok: true},
+		{input: "goto"},
+		{input: "goto three"},
+		{input: "back 2"},
+		{input: "2"},
+		{input: ""},
+	}
+
+	for _, tt := range tests {
+		navigation, ok := ParseWalkNavigation(tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+		assert.Equal(t, tt.expected, navigation, tt.input)
+	}
+}
+
+func TestWalkState_Navigate(t *testing.T) {
+	t.Parallel()
+
+	d, dismissedId, noticeId, writtenId := newNavigationDAG()
+	answered := func() WalkState {
+		state := NewWalkState(d, dismissedId)
+		state.Record(d.Nodes[dismissedId], d.Nodes[dismissedId].Answers[:1])
+		state.Record(d.Nodes[noticeId], d.Nodes[noticeId].Answers)
+		state.Record(d.Nodes[writtenId], d.Nodes[writtenId].Answers[:1])
+		return state
+	}
+
+	tests := []struct {
+		name       string
+		navigation WalkNavigation
+		expected   []uuid.UUID
+		err        string
+	}{
+		{name: "back drops the last answer", navigation: WalkNavigation{Command: WalkCommandBack}, expected: []uuid.UUID{dismissedId, noticeId}},
+		{name: "restart drops every answer", navigation: WalkNavigation{Command: WalkCommandRestart}, expected: []uuid.UUID{}},
+		{name: "goto keeps the answers before the question", navigation: WalkNavigation{Command: WalkCommandGoto, Step: 2}, expected: []uuid.UUID{dismissedId}},
+		{name: "goto the current question keeps every answer", navigation: WalkNavigation{Command: WalkCommandGoto, Step: 4}, expected: []uuid.UUID{dismissedId, noticeId, writtenId}},
+		{name: "goto rejects an unreached question", navigation: WalkNavigation{Command: WalkCommandGoto, Step: 5}, err: "questions 1 to 4 were reached"},
+		{name: "goto rejects question zero", navigation: WalkNavigation{Command: WalkCommandGoto}, err: "cannot go to question 0"},
+		{name: "rejects unknown commands", navigation: WalkNavigation{Command: "skip"}, err: "unknown walk command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := answered()
+			err := state.Navigate(d, tt.navigation)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				assert.Len(t, state.Answers, 3)
+				return
+			}
+			require.NoError(t, err)
+
+			nodeIds := []uuid.UUID{}
+			for _, answer := range state.Answers {
+				nodeIds = append(nodeIds, answer.NodeId)
+			}
+			assert.Equal(t, tt.expected, nodeIds)
+		})
+	}
+
+	t.Run("back skips information nodes", func(t *testing.T) {
+		t.Parallel()
+
+		state := answered()
+		state.Answers = state.Answers[:2]
+		require.NoError(t, state.Navigate(d, WalkNavigation{Command: WalkCommandBack}))
+		assert.Empty(t, state.Answers)
+	})
+
+	t.Run("counts the answers of a multi-select node as one step", func(t *testing.T) {
+		t.Parallel()
+
+		state := answered()
+		state.Record(d.Nodes[writtenId], d.Nodes[writtenId].Answers[1:])
+		assert.Equal(t, 3, state.Steps())
+
+		require.NoError(t, state.Navigate(d, WalkNavigation{Command: WalkCommandBack}))
+		assert.Len(t, state.Answers, 2)
+	})
+}
+
+func TestDAG_WalkSelectRevisable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("takes another branch once an earlier answer is revised", func(t *testing.T) {
+		t.Parallel()
+
+		d, dismissedId, _, writtenId := newNavigationDAG()
+		fnAnswers := scriptedFnAnswers(t, map[uuid.UUID][]interface{}{
+			dismissedId: {"Yes", "No"},
+			writtenId:   {WalkNavigation{Command: WalkCommandBack}},
+		})
+
+		var changes []WalkState
+		state := NewWalkState(d, dismissedId)
+		path, err := d.WalkSelectRevisable(&state, fnAnswers, func(s WalkState) error {
+			changes = append(changes, s)
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.Len(t, path, 1)
+		assert.Equal(t, "No", path[0].Statement)
+		require.Len(t, state.Answers, 1)
+		assert.Equal(t, path[0].Id, state.Answers[0].AnswerId)
+
+		// Two answers, the navigation back to the first question, then the revised answer
+		require.Len(t, changes, 4)
+		assert.Empty(t, changes[2].Answers)
+	})
+
+	t.Run("goes back to a numbered question of a resumed walk", func(t *testing.T) {
+		t.Parallel()
+
+		d, dismissedId, _, writtenId := newNavigationDAG()
+		fnAnswers := scriptedFnAnswers(t, map[uuid.UUID][]interface{}{
+			dismissedId: {"No"},
+			writtenId:   {WalkNavigation{Command: WalkCommandGoto, Step: 1}},
+		})
+
+		state := NewWalkState(d, dismissedId)
+		state.Record(d.Nodes[dismissedId], d.Nodes[dismissedId].Answers[:1])
+		path, err := d.WalkSelectRevisable(&state, fnAnswers, nil)
+		require.NoError(t, err)
+
+		require.Len(t, path, 1)
+		assert.Equal(t, "No", path[0].Statement)
+	})
+
+	t.Run("stops on an invalid navigation", func(t *testing.T) {
+		t.Parallel()
+
+		d, dismissedId, _, _ := newNavigationDAG()
+		state := NewWalkState(d, dismissedId)
+		_, err := d.WalkSelectRevisable(&state, func(Node) ([]Answer, error) {
+			return nil, WalkNavigation{Command: WalkCommandGoto, Step: 3}
+		}, nil)
+		assert.ErrorContains(t, err, "cannot go to question 3")
+	})
+
+	t.Run("stops when the state cannot be saved", func(t *testing.T) {
+		t.Parallel()
+
+		d, dismissedId, _, _ := newNavigationDAG()
+		state := NewWalkState(d, dismissedId)
+		_, err := d.WalkSelectRevisable(&state, scriptedFnAnswers(t, map[uuid.UUID][]interface{}{dismissedId: {"No"}}), func(WalkState) error {
+			return errors.New("disk full")
+		})
+		assert.ErrorContains(t, err, "disk full")
+	})
+}
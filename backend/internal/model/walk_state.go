@@ -0,0 +1,139 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// WalkState is a partial walk of a DAG, recorded answer after answer so that the walk can be saved
+// and resumed later with WalkFrom or WalkSelectFrom
+type WalkState struct {
+	DAGId uuid.UUID `json:"dag_id"`
+	// StartNodeId is the node the walk started from
+	StartNodeId uuid.UUID `json:"start_node_id"`
+	// Answers are the answers given so far, in order, several answers of a multi-select node following each other
+	Answers []WalkStateAnswer `json:"answers"`
+}
+
+// WalkStateAnswer is an answer given during a walk, with what the user entered or attached to it
+type WalkStateAnswer struct {
+	NodeId   uuid.UUID `json:"node_id"`
+	AnswerId uuid.UUID `json:"answer_id"`
+	// Value is the value entered on an input node
+	Value       string                 `json:"value,omitempty"`
+	UserContext string                 `json:"user_context,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// NewWalkState returns the state of a walk starting from the given node, before any answer is given
+func NewWalkState(d *DAG, startNodeId uuid.UUID) WalkState {
+	return WalkState{
+		DAGId:       d.Id,
+		StartNodeId: startNodeId,
+		Answers:     []WalkStateAnswer{},
+	}
+}
+
+// Record appends the answers selected on a node to the state
+func (s *WalkState) Record(node Node, answers []Answer) {
+	for _, answer := range answers {
+		recorded := WalkStateAnswer{
+			NodeId:      node.Id,
+			AnswerId:    answer.Id,
+			UserContext: answer.UserContext,
+			Metadata:    answer.Metadata,
+		}
+		if node.Kind() == NodeTypeInput {
+			recorded.Value = answer.Statement
+		}
+		s.Answers = append(s.Answers, recorded)
+	}
+}
+
+// WalkFrom resumes a walk from its recorded state like Walk, using fnAnswer to determine which answer to follow
+// from the node the recorded answers lead to. The returned path holds the recorded answers followed by the new ones.
+func (d DAG) WalkFrom(state WalkState, fnAnswer func(Node) (Answer, error)) ([]Answer, error) {
+	path, next, err := d.replay(state)
+	if err != nil || next == nil {
+		return path, err
+	}
+
+	return d.walk(*next, path, fnAnswer)
+}
+
+// WalkSelectFrom resumes a walk from its recorded state like WalkSelect, several answers being selected
+// on multi-select nodes
+func (d DAG) WalkSelectFrom(state WalkState, fnAnswers func(Node) ([]Answer, error)) ([]Answer, error) {
+	path, next, err := d.replay(state)
+	if err != nil || next == nil {
+		return path, err
+	}
+
+	return d.walkSelect(*next, path, fnAnswers)
+}
+
+// replay follows the recorded answers of a walk and returns the path they describe along with the node to
+// answer next, nil once the walk reached its end
+func (d DAG) replay(state WalkState) ([]Answer, *uuid.UUID, error) {
+	if state.DAGId != d.Id {
+		return nil, nil, fmt.Errorf("walk state of DAG %s cannot be resumed on DAG %s", state.DAGId, d.Id)
+	}
+
+	path := []Answer{}
+	currentNodeId := state.StartNodeId
+	for i := 0; i < len(state.Answers); {
+		node, err := d.GetNode(currentNodeId)
+		if err != nil {
+			return path, nil, fmt.Errorf("error getting node %s: %w", currentNodeId, err)
+		}
+
+		var selected []Answer
+		for ; i < len(state.Answers) && state.Answers[i].NodeId == node.Id; i++ {
+			answer, err := node.recordedAnswer(state.Answers[i])
+			if err != nil {
+				return path, nil, err
+			}
+			selected = append(selected, answer)
+		}
+		if len(selected) == 0 {
+			return path, nil, fmt.Errorf("recorded answer %s was given on node %s, the walk is on node %s", state.Answers[i].AnswerId, state.Answers[i].NodeId, node.Id)
+		}
+		if err := node.checkSelection(selected); err != nil {
+			return path, nil, err
+		}
+		path = append(path, selected...)
+
+		next, err := node.ResolveNext(selected, CollectVariables(path))
+		if err != nil {
+			return path, nil, err
+		}
+		if next == nil {
+			if i < len(state.Answers) {
+				return path, nil, fmt.Errorf("%d answer(s) recorded after the end of the walk", len(state.Answers)-i)
+			}
+			return path, nil, nil
+		}
+		currentNodeId = *next
+	}
+
+	return path, &currentNodeId, nil
+}
+
+// recordedAnswer returns the answer of the node a recorded answer refers to, with what the user entered
+// or attached to it
+func (n Node) recordedAnswer(recorded WalkStateAnswer) (Answer, error) {
+	answer, ok := findAnswer(n, recorded.AnswerId)
+	if !ok {
+		return Answer{}, fmt.Errorf("recorded answer %s is not valid for node %s", recorded.AnswerId, n.Id)
+	}
+
+	answer.UserContext = recorded.UserContext
+	if recorded.Metadata != nil {
+		answer.Metadata = recorded.Metadata
+	}
+	if n.Kind() == NodeTypeInput {
+		return n.withInput(answer, recorded.Value)
+	}
+	return answer, nil
+}
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFnAnswer records the answers of fnAnswer in state, failing once stopAt is reached
+func recordingFnAnswer(state *WalkState, stopAt uuid.UUID, fnAnswer func(Node) (Answer, error)) func(Node) (Answer, error) {
+	return func(node Node) (Answer, error) {
+		if node.Id == stopAt {
+			return Answer{}, errors.New("interrupted")
+		}
+		answer, err := fnAnswer(node)
+		if err != nil {
+			return Answer{}, err
+		}
+		state.Record(node, []Answer{answer})
+		return answer, nil
+	}
+}
+
+func TestDAG_WalkFrom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resumes a saved walk where it stopped", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, longId, _ := newInputDAG()
+		fnAnswer := ScriptedFnAnswerByStatement(map[uuid.UUID]string{
+			inputId: "5",
+			longId:  "Continue",
+		})
+
+		state := NewWalkState(d, inputId)
+		_, err := d.Walk(inputId, recordingFnAnswer(&state, longId, fnAnswer))
+		require.ErrorContains(t, err, "interrupted")
+		require.Len(t, state.Answers, 1)
+		assert.Equal(t, "5", state.Answers[0].Value)
+
+		data, err := json.Marshal(state)
+		require.NoError(t, err)
+		var saved WalkState
+		require.NoError(t, json.Unmarshal(data, &saved))
+
+		path, err := d.WalkFrom(saved, func(node Node) (Answer, error) {
+			assert.Equal(t, longId, node.Id, "recorded questions are not asked again")
+			return fnAnswer(node)
+		})
+		require.NoError(t, err)
+
+		require.Len(t, path, 2)
+		assert.Equal(t, "5", path[0].Statement)
+		assert.Equal(t, 5.0, CollectVariables(path)["seniority"])
+		assert.Equal(t, "Continue", path[1].Statement)
+	})
+
+	t.Run("returns the path of a completed walk without asking", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, longId, _ := newInputDAG()
+		state := NewWalkState(d, inputId)
+		_, err := d.Walk(inputId, recordingFnAnswer(&state, uuid.Nil, ScriptedFnAnswerByStatement(map[uuid.UUID]string{
+			inputId: "5",
+			longId:  "Continue",
+		})))
+		require.NoError(t, err)
+
+		path, err := d.WalkFrom(state, func(node Node) (Answer, error) {
+			return Answer{}, errors.New("no question left")
+		})
+		require.NoError(t, err)
+		assert.Len(t, path, 2)
+	})
+
+	t.Run("keeps the context attached to the recorded answers", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, longId, _ := newInputDAG()
+		state := NewWalkState(d, inputId)
+		state.Answers = append(state.Answers,
+			WalkStateAnswer{NodeId: inputId, AnswerId: d.Nodes[inputId].Answers[0].Id, Value: "10", UserContext: "Since 2014"},
+			WalkStateAnswer{NodeId: longId, AnswerId: d.Nodes[longId].Answers[0].Id, Metadata: map[string]interface{}{"confidence": 0.5}},
+		)
+
+		path, err := d.WalkFrom(state, nil)
+		require.NoError(t, err)
+		require.Len(t, path, 2)
+		assert.Equal(t, "Since 2014", path[0].UserContext)
+		assert.Equal(t, 10.0, path[0].Metadata["seniority"])
+		assert.Equal(t, 0.5, path[1].Metadata["confidence"])
+	})
+
+	t.Run("rejects invalid states", func(t *testing.T) {
+		t.Parallel()
+
+		d, inputId, longId, shortId := newInputDAG()
+		inputAnswer := WalkStateAnswer{NodeId: inputId, AnswerId: d.Nodes[inputId].Answers[0].Id, Value: "1"}
+
+		testCases := []struct {
+			name    string
+			state   WalkState
+			wantErr string
+		}{
+			{
+				name:    "other DAG",
+				state:   WalkState{DAGId: uuid.New(), StartNodeId: inputId},
+				wantErr: "cannot be resumed",
+			},
+			{
+				name:    "unknown answer",
+				state:   WalkState{DAGId: d.Id, StartNodeId: inputId, Answers: []WalkStateAnswer{{NodeId: inputId, AnswerId: uuid.New(), Value: "1"}}},
+				wantErr: "is not valid for node",
+			},
+			{
+				name:    "invalid value",
+				state:   WalkState{DAGId: d.Id, StartNodeId: inputId, Answers: []WalkStateAnswer{{NodeId: inputId, AnswerId: inputAnswer.AnswerId, Value: "many"}}},
+				wantErr: "is not a number",
+			},
+			{
+				name: "answer given on another node",
+				state: WalkState{DAGId: d.Id, StartNodeId: inputId, Answers: []WalkStateAnswer{
+					inputAnswer,
+					{NodeId: longId, AnswerId: d.Nodes[longId].Answers[0].Id},
+				}},
+				wantErr: "the walk is on node " + shortId.String(),
+			},
+			{
+				name: "answers after the end",
+				state: WalkState{DAGId: d.Id, StartNodeId: inputId, Answers: []WalkStateAnswer{
+					inputAnswer,
+					{NodeId: shortId, AnswerId: d.Nodes[shortId].Answers[0].Id},
+					inputAnswer,
+				}},
+				wantErr: "after the end of the walk",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := d.WalkFrom(tc.state, nil)
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
+
+func TestDAG_WalkSelectFrom(t *testing.T) {
+	t.Parallel()
+
+	d, rootId, followUpId := newConvergingDAG()
+	state := NewWalkState(d, rootId)
+	state.Record(d.Nodes[rootId], d.Nodes[rootId].Answers)
+
+	path, err := d.WalkSelectFrom(state, func(node Node) ([]Answer, error) {
+		assert.Equal(t, followUpId, node.Id)
+		return node.Answers, nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, path, 3)
+	assert.Equal(t, []string{"Financial", "Moral", "Last year"}, []string{path[0].Statement, path[1].Statement, path[2].Statement})
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types notified to webhooks
+const (
+	EventDAGCreated       = "dag.created"
+	EventDAGUpdated       = "dag.updated"
+	EventDAGDeleted       = "dag.deleted"
+	EventDAGRestored      = "dag.restored"
+	EventDAGValidated     = "dag.validated"
+	EventSessionCompleted = "session.completed"
+)
+
+// EventTypes lists the event types webhooks can subscribe to
+func EventTypes() []string {
+	return []string{EventDAGCreated, EventDAGUpdated, EventDAGDeleted, EventDAGRestored, EventDAGValidated, EventSessionCompleted}
+}
+
+// Event is something which happened to a DAG or a case session, notified to the subscribed webhooks
+type Event struct {
+	Id         uuid.UUID `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	DAGId      uuid.UUID `json:"dag_id"`
+	// SessionId is set on session events
+	SessionId *uuid.UUID `json:"session_id,omitempty"`
+	// Data holds details specific to the event type
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// NewEvent returns an event of a DAG which occurred at now
+func NewEvent(eventType string, dagId uuid.UUID, now time.Time) Event {
+	return Event{
+		Id:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: now,
+		DAGId:      dagId,
+	}
+}
+
+// Webhook is an endpoint of an external system notified of events
+type Webhook struct {
+	Id  uuid.UUID `json:"id"`
+	URL string    `json:"url"`
+	// Events are the event types the webhook subscribes to, every event type when empty
+	Events []string `json:"events,omitempty"`
+	// Secret signs the payloads delivered to the webhook
+	Secret    string    `json:"secret"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribes tells whether events of eventType are delivered to the webhook
+func (w Webhook) Subscribes(eventType string) bool {
+	return w.Active && (len(w.Events) == 0 || slices.Contains(w.Events, eventType))
+}
+
+// DeliveryStatus is the progress of the delivery of an event to a webhook
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// WebhookDelivery tracks the attempts to deliver an event to a webhook
+type WebhookDelivery struct {
+	Id        uuid.UUID      `json:"id"`
+	WebhookId uuid.UUID      `json:"webhook_id"`
+	Event     Event          `json:"event"`
+	Status    DeliveryStatus `json:"status"`
+	Attempts  int            `json:"attempts"`
+	// StatusCode is the HTTP status returned by the last attempt, 0 when no response was received
+	StatusCode int    `json:"status_code,omitempty"`
+	LastError  string `json:"last_error,omitempty"`
+	// NextAttemptAt is set while a failed attempt is waiting to be retried
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NewWebhookDelivery returns a pending delivery of event to webhookId
+func NewWebhookDelivery(webhookId uuid.UUID, event Event, now time.Time) WebhookDelivery {
+	return WebhookDelivery{
+		Id:        uuid.New(),
+		WebhookId: webhookId,
+		Event:     event,
+		Status:    DeliveryStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
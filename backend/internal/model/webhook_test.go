@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Subscribes(t *testing.T) {
+	t.Parallel()
+
+	all := Webhook{Active: true}
+	for _, eventType := range EventTypes() {
+		assert.True(t, all.Subscribes(eventType), eventType)
+	}
+
+	sessions := Webhook{Active: true, Events: []string{EventSessionCompleted}}
+	assert.True(t, sessions.Subscribes(EventSessionCompleted))
+	assert.False(t, sessions.Subscribes(EventDAGCreated))
+
+	inactive := Webhook{Events: []string{EventSessionCompleted}}
+	assert.False(t, inactive.Subscribes(EventSessionCompleted))
+}
+
+func TestNewWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	event := NewEvent(EventDAGCreated, uuid.New(), now)
+	webhookId := uuid.New()
+
+	delivery := NewWebhookDelivery(webhookId, event, now)
+
+	assert.Equal(t, webhookId, delivery.WebhookId)
+	assert.Equal(t, event, delivery.Event)
+	assert.Equal(t, DeliveryStatusPending, delivery.Status)
+	assert.Zero(t, delivery.Attempts)
+	assert.Equal(t, now, delivery.CreatedAt)
+}
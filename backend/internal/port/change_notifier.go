@@ -0,0 +1,86 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// watchBufferSize is the number of events buffered per watcher before new events are dropped
+const watchBufferSize = 64
+
+// changeNotifier fans out repository change events to registered watchers and observers
+// The zero value is ready to use
+type changeNotifier struct {
+	mu       sync.Mutex
+	watchers map[chan model.ChangeEvent]struct{}
+	// observers are called synchronously on every event, unlike watchers they never miss one
+	observers map[*observer]struct{}
+}
+
+type observer struct {
+	fn func(model.ChangeEvent)
+}
+
+// Watch registers a new watcher, the returned channel is closed when ctx is cancelled
+func (n *changeNotifier) Watch(ctx context.Context) (<-chan model.ChangeEvent, error) {
+	ch := make(chan model.ChangeEvent, watchBufferSize)
+
+	n.mu.Lock()
+	if n.watchers == nil {
+		n.watchers = make(map[chan model.ChangeEvent]struct{})
+	}
+	n.watchers[ch] = struct{}{}
+	n.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.watchers, ch)
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// Observe registers fn to be called on every change before the write returns, until the returned stop
+// function is called. fn must be fast and must not call the repository
+func (n *changeNotifier) Observe(fn func(model.ChangeEvent)) (stop func()) {
+	o := &observer{fn: fn}
+
+	n.mu.Lock()
+	if n.observers == nil {
+		n.observers = make(map[*observer]struct{})
+	}
+	n.observers[o] = struct{}{}
+	n.mu.Unlock()
+
+	return func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.observers, o)
+	}
+}
+
+// notify calls every observer, then sends the event to every watcher without blocking; slow watchers miss events
+func (n *changeNotifier) notify(changeType model.ChangeType, id uuid.UUID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	event := model.ChangeEvent{Type: changeType, DAGId: id}
+	for o := range n.observers {
+		o.fn(event)
+	}
+	for ch := range n.watchers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
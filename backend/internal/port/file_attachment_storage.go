@@ -0,0 +1,75 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileAttachmentStorage keeps each attachment in its own file, the key being its path under the directory
+type FileAttachmentStorage struct {
+	dir string
+}
+
+func NewFileAttachmentStorage(dir string) *FileAttachmentStorage {
+	return &FileAttachmentStorage{
+		dir: dir,
+	}
+}
+
+// Put writes the content of an attachment, replacing any previous content under the same key
+func (s *FileAttachmentStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating attachment directory: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing attachment %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *FileAttachmentStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: attachment %s", usecase.ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading attachment %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// Delete removes the content of an attachment, deleting a missing attachment succeeds
+func (s *FileAttachmentStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting attachment %s: %w", key, err)
+	}
+	return nil
+}
+
+// path resolves a key under the directory, rejecting the keys which would escape it
+func (s *FileAttachmentStorage) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(key) || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("%w: invalid attachment key %q", usecase.ErrInvalidCommand, key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
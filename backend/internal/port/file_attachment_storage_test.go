@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileAttachmentStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage := NewFileAttachmentStorage(dir)
+
+	require.NoError(t, storage.Put(ctx, "session/attachment", []byte("%PDF-1.4"), "application/pdf"))
+	data, err := os.ReadFile(filepath.Join(dir, "session", "attachment"))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	data, err = storage.Get(ctx, "session/attachment")
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	require.NoError(t, storage.Delete(ctx, "session/attachment"))
+	_, err = storage.Get(ctx, "session/attachment")
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	assert.NoError(t, storage.Delete(ctx, "session/attachment"), "deleting a missing attachment succeeds")
+
+	for _, key := range []string{"", "../outside", "/etc/passwd", `session\attachment`} {
+		assert.ErrorIs(t, storage.Put(ctx, key, []byte("data"), "text/plain"), usecase.ErrInvalidCommand, key)
+	}
+}
@@ -4,18 +4,34 @@ import (
 	"context"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
 )
 
 const dagFileExtension = ".json"
 
+// dagFileExtensions lists the extensions of DAG files in lookup order, new DAGs are written as JSON
+var dagFileExtensions = []string{dagFileExtension, ".yaml", ".yml"}
+
+// quarantineDir is the subdirectory where corrupt DAG files are moved, out of the DAG files
+const quarantineDir = "quarantine"
+
+// errCorruptFile marks the DAG files which can be read but not decoded, e.g. truncated by a crash
+var errCorruptFile = errors.New("corrupt DAG file")
+
 type FileDAGRepository struct {
 	filePath string
+	// locking makes the writers of a DAG, in this process or another one, wait for each other
+	locking bool
+	changeNotifier
 }
 
 func NewFileDAGRepository(filePath string) *FileDAGRepository {
@@ -24,8 +40,15 @@ func NewFileDAGRepository(filePath string) *FileDAGRepository {
 	}
 }
 
+// WithLocking takes an advisory lock on a DAG while its file is written, so that the processes sharing the
+// directory, such as a server and a CLI run, do not interleave their changes
+func (r *FileDAGRepository) WithLocking() *FileDAGRepository {
+	r.locking = true
+	return r
+}
+
 func (r *FileDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG, error) {
-	dagFile := filepath.Join(r.filePath, id.String()+dagFileExtension)
+	dagFile, _ := r.dagFile(id)
 	data, err := os.ReadFile(dagFile)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -36,11 +59,16 @@ func (r *FileDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG,
 	}
 
 	var dag = model.NewDAG("Untitled DAG")
-	err = dag.UnmarshalJSON(data)
+	if isYAMLFile(dagFile) {
+		err = yaml.Unmarshal(data, dag)
+	} else {
+		err = dag.UnmarshalJSON(data)
+	}
 	if err != nil {
 		return nil, fmt.Errorf(
-			"%w: %s",
+			"%w: %w: %s",
 			usecase.ErrInternal,
+			errCorruptFile,
 			fmt.Errorf("error unmarshalling file '%s': %w", dagFile, err),
 		)
 	}
@@ -48,7 +76,7 @@ func (r *FileDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG,
 	return dag, nil
 }
 
-// List returns all DAG IDs found in the file directory
+// List returns all DAG IDs found in the file directory, whatever the format of their file
 func (r *FileDAGRepository) List(ctx context.Context) ([]uuid.UUID, error) {
 	entries, err := os.ReadDir(r.filePath)
 	if err != nil {
@@ -57,40 +85,105 @@ func (r *FileDAGRepository) List(ctx context.Context) ([]uuid.UUID, error) {
 
 	//nolint:prealloc // This is a valid use of range
 	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
 
 		filename := entry.Name()
-		if !strings.HasSuffix(filename, dagFileExtension) {
+		extension := filepath.Ext(filename)
+		if !slices.Contains(dagFileExtensions, extension) {
 			continue
 		}
 
 		// Extract UUID from filename
-		idStr := strings.TrimSuffix(filename, dagFileExtension)
+		idStr := strings.TrimSuffix(filename, extension)
 		id, err := uuid.Parse(idStr)
 		if err != nil {
 			// Skip invalid UUID filenames
 			continue
 		}
 
+		// A DAG stored in several formats is listed once, Get reads the first file in lookup order
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
 		ids = append(ids, id)
 	}
 
 	return ids, nil
 }
 
+// Query reads every DAG file and runs the query over them. Files that cannot be read are
+// skipped so that a single broken file does not prevent listing the others.
+func (r *FileDAGRepository) Query(ctx context.Context, query model.DAGQuery) (*model.DAGPage, error) {
+	ids, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dags := make([]*model.DAG, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		dagObj, err := r.Get(ctx, id)
+		if err != nil {
+			continue
+		}
+		if query.Matches(dagObj) {
+			dags = append(dags, dagObj)
+		}
+	}
+
+	return query.Apply(dags), nil
+}
+
+// Each reads the DAG files one at a time, stopping on the first error returned by fn,
+// so that only a single DAG is held in memory at once
+func (r *FileDAGRepository) Each(ctx context.Context, fn func(dag *model.DAG) error) error {
+	ids, err := r.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		dagObj, err := r.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fn(dagObj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Create stores a new DAG to a file
 func (r *FileDAGRepository) Create(ctx context.Context, dagObj *model.DAG) error {
 	if dagObj == nil {
 		return fmt.Errorf("%w: DAG cannot be nil", usecase.ErrInvalidCommand)
 	}
 
-	dagFile := filepath.Join(r.filePath, dagObj.Id.String()+dagFileExtension)
+	unlock, err := r.lockDAG(ctx, dagObj.Id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	dagFile, exists := r.dagFile(dagObj.Id)
 
 	// Check if file already exists
-	if _, err := os.Stat(dagFile); err == nil {
+	if exists {
 		return fmt.Errorf("%w: DAG with id %s already exists", usecase.ErrInvalidCommand, dagObj.Id.String())
 	}
 
@@ -105,17 +198,25 @@ func (r *FileDAGRepository) Create(ctx context.Context, dagObj *model.DAG) error
 		return fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, r.filePath, err)
 	}
 
-	// Write to file
-	err = os.WriteFile(dagFile, data, 0644)
+	// Write to file, atomically so that a crash cannot leave a truncated file
+	err = writeFileAtomic(dagFile, data, 0644)
 	if err != nil {
 		return fmt.Errorf("%w: error writing file '%s': %w", usecase.ErrInternal, dagFile, err)
 	}
 
+	r.notify(model.ChangeTypeCreated, dagObj.Id)
 	return nil
 }
 
 // Update modifies an existing DAG file using the provided function
 func (r *FileDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(dag model.DAG) (model.DAG, error)) error {
+	// The DAG is locked from its read to its write, so that concurrent changes are not lost
+	unlock, err := r.lockDAG(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// First, get the existing DAG
 	existingDAG, err := r.Get(ctx, id)
 	if err != nil {
@@ -138,28 +239,40 @@ func (r *FileDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate f
 		)
 	}
 
-	// Marshal updated DAG to JSON
-	data, err := updatedDAG.MarshalJSON()
+	// Marshal updated DAG in the format of its file
+	dagFile, _ := r.dagFile(id)
+	var data []byte
+	if isYAMLFile(dagFile) {
+		data, err = updatedDAG.MarshalCanonicalYAML()
+	} else {
+		data, err = updatedDAG.MarshalJSON()
+	}
 	if err != nil {
 		return fmt.Errorf("%w: error marshalling updated DAG: %w", usecase.ErrInternal, err)
 	}
 
-	// Write back to file
-	dagFile := filepath.Join(r.filePath, id.String()+dagFileExtension)
-	err = os.WriteFile(dagFile, data, 0644)
+	// Write back to file, atomically so that a crash cannot leave a truncated file
+	err = writeFileAtomic(dagFile, data, 0644)
 	if err != nil {
 		return fmt.Errorf("%w: error writing updated file '%s': %w", usecase.ErrInternal, dagFile, err)
 	}
 
+	r.notify(model.ChangeTypeUpdated, id)
 	return nil
 }
 
 // Delete removes a DAG file from the file system
 func (r *FileDAGRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	dagFile := filepath.Join(r.filePath, id.String()+dagFileExtension)
+	unlock, err := r.lockDAG(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	dagFile, exists := r.dagFile(id)
 
 	// Check if file exists
-	if _, err := os.Stat(dagFile); os.IsNotExist(err) {
+	if !exists {
 		return fmt.Errorf(
 			"%w: DAG with id %s not found in file system",
 			usecase.ErrNotFound,
@@ -168,10 +281,85 @@ func (r *FileDAGRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	// Remove the file
-	err := os.Remove(dagFile)
+	err = os.Remove(dagFile)
 	if err != nil {
 		return fmt.Errorf("%w: error deleting file '%s': %w", usecase.ErrInternal, dagFile, err)
 	}
 
+	r.notify(model.ChangeTypeDeleted, id)
 	return nil
 }
+
+// Quarantine moves the file of a DAG to the quarantine directory, so that it is no longer loaded but can be
+// inspected and repaired. It returns the path of the quarantined file.
+func (r *FileDAGRepository) Quarantine(id uuid.UUID) (string, error) {
+	dagFile, exists := r.dagFile(id)
+	if !exists {
+		return "", fmt.Errorf("%w: DAG with id %s not found in file system", usecase.ErrNotFound, id.String())
+	}
+
+	dir := filepath.Join(r.filePath, quarantineDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, dir, err)
+	}
+
+	// The time suffix keeps the earlier copies of a file quarantined several times
+	quarantined := filepath.Join(dir, fmt.Sprintf("%s.%s", filepath.Base(dagFile), time.Now().UTC().Format("20060102T150405.000000000Z")))
+	if err := os.Rename(dagFile, quarantined); err != nil {
+		return "", fmt.Errorf("%w: error moving file '%s' to quarantine: %w", usecase.ErrInternal, dagFile, err)
+	}
+
+	return quarantined, nil
+}
+
+// Location returns the path of the file storing the DAG
+func (r *FileDAGRepository) Location(id uuid.UUID) string {
+	dagFile, _ := r.dagFile(id)
+	return dagFile
+}
+
+// RemoveTempFiles removes the temporary files left by writes interrupted by a crash, the DAG files they were
+// replacing are intact. It must not run while DAGs are written, it returns the removed files.
+func (r *FileDAGRepository) RemoveTempFiles() ([]string, error) {
+	entries, err := os.ReadDir(r.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory '%s': %w", r.filePath, err)
+	}
+
+	var removed []string
+	for _, entry := range entries {
+		if entry.IsDir() || !isTempFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(r.filePath, entry.Name())
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("%w: error removing temporary file '%s': %w", usecase.ErrInternal, path, err)
+		}
+		removed = append(removed, path)
+	}
+
+	return removed, nil
+}
+
+// dagFile returns the path of the file storing the DAG and whether it exists.
+// The JSON path is returned when no file exists, so that new DAGs are written as JSON
+func (r *FileDAGRepository) dagFile(id uuid.UUID) (string, bool) {
+	for _, extension := range dagFileExtensions {
+		path := filepath.Join(r.filePath, id.String()+extension)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+
+	return filepath.Join(r.filePath, id.String()+dagFileExtension), false
+}
+
+func isYAMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -667,3 +668,241 @@ func TestFileDAGRepository_InterfaceCompliance(t *testing.T) {
 
 	var _ usecase.DAGRepository = NewFileDAGRepository(tempDir)
 }
+
+func TestFileDAGRepository_Each(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFileDAGRepository(t.TempDir())
+
+	stored := map[uuid.UUID]bool{}
+	for _, title := range []string{"First", "Second", "Third"} {
+		d := model.NewDAG(title)
+		require.NoError(t, repo.Create(ctx, d))
+		stored[d.Id] = true
+	}
+
+	t.Run("yields every DAG once", func(t *testing.T) {
+		seen := map[uuid.UUID]int{}
+		err := repo.Each(ctx, func(d *model.DAG) error {
+			seen[d.Id]++
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, seen, len(stored))
+		for id := range stored {
+			assert.Equal(t, 1, seen[id])
+		}
+	})
+
+	t.Run("stops on the first error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := repo.Each(ctx, func(d *model.DAG) error {
+			calls++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestFileDAGRepository_YAMLFiles(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, extension string) (*FileDAGRepository, *model.DAG, string) {
+		dir := t.TempDir()
+
+		rootID := uuid.New()
+		leafID := uuid.New()
+		d := &model.DAG{
+			Id:    uuid.New(),
+			Title: "Authored in YAML",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {Id: rootID, Question: "Were you dismissed?\nAnswer for your last position", Answers: []model.Answer{{Id: uuid.New(), Statement: "Yes", NextNode: &leafID}}},
+				leafID: {Id: leafID, Question: "In writing?", Answers: []model.Answer{}},
+			},
+		}
+
+		data, err := d.MarshalCanonicalYAML()
+		require.NoError(t, err)
+		path := filepath.Join(dir, d.Id.String()+extension)
+		require.NoError(t, os.WriteFile(path, append([]byte("# Reviewed by the employment team\n"), data...), 0644))
+
+		return NewFileDAGRepository(dir), d, path
+	}
+
+	for _, extension := range []string{".yaml", ".yml"} {
+		t.Run("reads and lists "+extension+" files", func(t *testing.T) {
+			repo, d, _ := setup(t, extension)
+
+			got, err := repo.Get(ctx, d.Id)
+			require.NoError(t, err)
+			assert.Equal(t, d.Title, got.Title)
+			require.Len(t, got.Nodes, 2)
+			for id, node := range d.Nodes {
+				assert.Equal(t, node.Question, got.Nodes[id].Question)
+			}
+
+			ids, err := repo.List(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{d.Id}, ids)
+		})
+	}
+
+	t.Run("update keeps the YAML format", func(t *testing.T) {
+		repo, d, path := setup(t, ".yaml")
+
+		err := repo.Update(ctx, d.Id, func(dag model.DAG) (model.DAG, error) {
+			dag.Title = "Updated in YAML"
+			return dag, nil
+		})
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "title: Updated in YAML\n")
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(path), d.Id.String()+dagFileExtension))
+	})
+
+	t.Run("create rejects an ID stored in YAML", func(t *testing.T) {
+		repo, d, _ := setup(t, ".yml")
+
+		err := repo.Create(ctx, d)
+		assert.ErrorIs(t, err, usecase.ErrInvalidCommand)
+	})
+
+	t.Run("delete removes the YAML file", func(t *testing.T) {
+		repo, d, path := setup(t, ".yaml")
+
+		require.NoError(t, repo.Delete(ctx, d.Id))
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("lists a DAG stored in several formats once", func(t *testing.T) {
+		repo, d, _ := setup(t, ".yaml")
+		data, err := d.MarshalJSON()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(repo.filePath, d.Id.String()+dagFileExtension), data, 0644))
+
+		ids, err := repo.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{d.Id}, ids)
+	})
+}
+
+func TestFileDAGRepository_Query(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo := NewFileDAGRepository(dir)
+
+	for _, title := range []string{"Employment dismissal", "Lease termination", "Employment overtime"} {
+		require.NoError(t, repo.Create(ctx, model.NewDAG(title)))
+	}
+	// Unreadable DAG files are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, uuid.New().String()+".json"), []byte("{not json"), 0600))
+
+	t.Run("filters, sorts and paginates", func(t *testing.T) {
+		page, err := repo.Query(ctx, model.DAGQuery{Sort: model.SortTitleAsc, Page: 2, PageSize: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, page.Total)
+		assert.Equal(t, 2, page.Page)
+		require.Len(t, page.DAGs, 1)
+		assert.Equal(t, "Lease termination", page.DAGs[0].Title)
+	})
+
+	t.Run("filters by title", func(t *testing.T) {
+		page, err := repo.Query(ctx, model.DAGQuery{TitleContains: "EMPLOYMENT", Sort: model.SortTitleAsc})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, page.Total)
+		require.Len(t, page.DAGs, 2)
+		assert.Equal(t, "Employment dismissal", page.DAGs[0].Title)
+		assert.Equal(t, "Employment overtime", page.DAGs[1].Title)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := repo.Query(cancelled, model.DAGQuery{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestFileDAGRepository_AtomicWrites(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := NewFileDAGRepository(tempDir)
+	ctx := context.Background()
+
+	testDAG := createTestDAG(t)
+	require.NoError(t, repo.Create(ctx, testDAG))
+	require.NoError(t, repo.Update(ctx, testDAG.Id, func(dag model.DAG) (model.DAG, error) {
+		dag.Title = "Updated title"
+		return dag, nil
+	}))
+
+	// Only the DAG file is left, with the permissions of the previous writes
+	entries, err := os.ReadDir(tempDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, testDAG.Id.String()+".json", entries[0].Name())
+	info, err := entries[0].Info()
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	stored, err := repo.Get(ctx, testDAG.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated title", stored.Title)
+}
+
+func TestFileDAGRepository_RemoveTempFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := NewFileDAGRepository(tempDir)
+	ctx := context.Background()
+
+	testDAG := createTestDAG(t)
+	require.NoError(t, repo.Create(ctx, testDAG))
+
+	// A write interrupted by a crash leaves its temporary file, which is not listed
+	leftover := filepath.Join(tempDir, "."+testDAG.Id.String()+".json"+tempFileMarker+"123456")
+	require.NoError(t, os.WriteFile(leftover, []byte(`{"id":`), 0644))
+
+	ids, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{testDAG.Id}, ids)
+
+	removed, err := repo.RemoveTempFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{leftover}, removed)
+	assert.NoFileExists(t, leftover)
+
+	_, err = repo.Get(ctx, testDAG.Id)
+	assert.NoError(t, err, "the DAG file is intact")
+}
+
+func TestFileDAGRepository_Quarantine(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := NewFileDAGRepository(tempDir)
+	ctx := context.Background()
+
+	id := uuid.New()
+	dagFile := filepath.Join(tempDir, id.String()+".json")
+	require.NoError(t, os.WriteFile(dagFile, []byte(`{"id": "`+id.String()+`", "nod`), 0644))
+
+	_, err := repo.Get(ctx, id)
+	assert.ErrorIs(t, err, errCorruptFile)
+
+	quarantined, err := repo.Quarantine(id)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tempDir, quarantineDir), filepath.Dir(quarantined))
+	assert.FileExists(t, quarantined)
+	assert.NoFileExists(t, dagFile)
+
+	ids, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	_, err = repo.Quarantine(id)
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
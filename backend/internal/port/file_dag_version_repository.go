@@ -0,0 +1,175 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const dagVersionsDirExtension = ".versions"
+
+// FileDAGVersionRepository stores the versions of each DAG next to its file, in a <id>.versions
+// directory holding one <number>.json file per version. Appends are serialized so that version
+// numbers are never given twice.
+type FileDAGVersionRepository struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+func NewFileDAGVersionRepository(filePath string) *FileDAGVersionRepository {
+	return &FileDAGVersionRepository{
+		filePath: filePath,
+	}
+}
+
+// Append writes a snapshot of the DAG as the version following the latest one
+func (r *FileDAGVersionRepository) Append(ctx context.Context, d *model.DAG, createdAt time.Time, author string) (*model.DAGVersion, error) {
+	if d == nil {
+		return nil, fmt.Errorf("%w: DAG cannot be nil", usecase.ErrInvalidCommand)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	numbers, err := r.numbers(d.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	version := model.DAGVersion{
+		Number:    1,
+		DAG:       d,
+		CreatedAt: createdAt,
+		Author:    author,
+	}
+	if len(numbers) > 0 {
+		version.Number = numbers[len(numbers)-1] + 1
+	}
+
+	data, err := json.MarshalIndent(version, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%w: error marshalling DAG version: %w", usecase.ErrInternal, err)
+	}
+
+	dir := r.dir(d.Id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, dir, err)
+	}
+
+	versionFile := r.file(d.Id, version.Number)
+	if err := os.WriteFile(versionFile, data, 0644); err != nil {
+		return nil, fmt.Errorf("%w: error writing file '%s': %w", usecase.ErrInternal, versionFile, err)
+	}
+
+	return &version, nil
+}
+
+// List reads every version of a DAG, oldest first
+func (r *FileDAGVersionRepository) List(ctx context.Context, dagId uuid.UUID) ([]model.DAGVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	numbers, err := r.numbers(dagId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]model.DAGVersion, 0, len(numbers))
+	for _, number := range numbers {
+		version, err := r.read(dagId, number)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *version)
+	}
+
+	return versions, nil
+}
+
+// Get reads a single version of a DAG
+func (r *FileDAGVersionRepository) Get(ctx context.Context, dagId uuid.UUID, number int) (*model.DAGVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read(dagId, number)
+}
+
+// Delete removes the versions directory of a DAG
+func (r *FileDAGVersionRepository) Delete(ctx context.Context, dagId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dir := r.dir(dagId)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("%w: error removing directory '%s': %w", usecase.ErrInternal, dir, err)
+	}
+
+	return nil
+}
+
+// numbers returns the recorded version numbers of a DAG in ascending order
+func (r *FileDAGVersionRepository) numbers(dagId uuid.UUID) ([]int, error) {
+	dir := r.dir(dagId)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading directory '%s': %w", usecase.ErrInternal, dir, err)
+	}
+
+	var numbers []int
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), dagFileExtension) {
+			continue
+		}
+
+		number, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), dagFileExtension))
+		if err != nil {
+			// Skip files which are not versions
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	return numbers, nil
+}
+
+func (r *FileDAGVersionRepository) read(dagId uuid.UUID, number int) (*model.DAGVersion, error) {
+	versionFile := r.file(dagId, number)
+	data, err := os.ReadFile(versionFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: version %d of DAG %s not found", usecase.ErrNotFound, number, dagId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading file '%s': %w", usecase.ErrInternal, versionFile, err)
+	}
+
+	var version model.DAGVersion
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("%w: error unmarshalling file '%s': %w", usecase.ErrInternal, versionFile, err)
+	}
+
+	return &version, nil
+}
+
+func (r *FileDAGVersionRepository) dir(dagId uuid.UUID) string {
+	return filepath.Join(r.filePath, dagId.String()+dagVersionsDirExtension)
+}
+
+func (r *FileDAGVersionRepository) file(dagId uuid.UUID, number int) string {
+	return filepath.Join(r.dir(dagId), strconv.Itoa(number)+dagFileExtension)
+}
@@ -0,0 +1,104 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileDAGVersionRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	t.Run("stores versions next to the DAG file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		d := dagtest.ValidSingleRoot()
+		repo := NewFileDAGVersionRepository(dir)
+
+		first, err := repo.Append(ctx, d, createdAt, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, first.Number)
+
+		d.Title = "Renamed"
+		second, err := repo.Append(ctx, d, createdAt.Add(time.Hour), "author")
+		require.NoError(t, err)
+		assert.Equal(t, 2, second.Number)
+
+		require.FileExists(t, filepath.Join(dir, d.Id.String()+".versions", "1.json"))
+		require.FileExists(t, filepath.Join(dir, d.Id.String()+".versions", "2.json"))
+
+		// A new repository reads the versions back from disk, oldest first
+		versions, err := NewFileDAGVersionRepository(dir).List(ctx, d.Id)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, "Valid Single Root DAG", versions[0].DAG.Title)
+		assert.Equal(t, "Renamed", versions[1].DAG.Title)
+		assert.Equal(t, "author", versions[1].Author)
+		assert.True(t, createdAt.Add(time.Hour).Equal(versions[1].CreatedAt))
+		assert.Len(t, versions[1].DAG.Nodes, len(d.Nodes))
+
+		version, err := repo.Get(ctx, d.Id, 1)
+		require.NoError(t, err)
+		assert.Equal(t, d.Id, version.DAG.Id)
+	})
+
+	t.Run("does not interfere with the DAG files", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		d := dagtest.ValidSingleRoot()
+		require.NoError(t, NewFileDAGRepository(dir).Create(ctx, d))
+
+		_, err := NewFileDAGVersionRepository(dir).Append(ctx, d, createdAt, "")
+		require.NoError(t, err)
+
+		ids, err := NewFileDAGRepository(dir).List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{d.Id}, ids)
+	})
+
+	t.Run("lists no versions for a DAG never versioned", func(t *testing.T) {
+		t.Parallel()
+
+		versions, err := NewFileDAGVersionRepository(t.TempDir()).List(ctx, uuid.New())
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+
+	t.Run("returns not found for unknown version", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFileDAGVersionRepository(t.TempDir()).Get(ctx, uuid.New(), 1)
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+	})
+
+	t.Run("deletes the versions", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		d := dagtest.ValidSingleRoot()
+		repo := NewFileDAGVersionRepository(dir)
+
+		_, err := repo.Append(ctx, d, createdAt, "")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, d.Id))
+		_, err = os.Stat(filepath.Join(dir, d.Id.String()+".versions"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		// Deleting versions never recorded is not an error
+		require.NoError(t, repo.Delete(ctx, d.Id))
+	})
+}
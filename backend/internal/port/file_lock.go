@@ -0,0 +1,159 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// dagLocksDir holds the lock file of each DAG, taken while its file is written
+	dagLocksDir = ".locks"
+	// directoryLockFile is held by the server owning a DAG directory for as long as it runs
+	directoryLockFile = ".jurigen.lock"
+	// lockRetryInterval is the wait between two attempts to take a lock held by another process
+	lockRetryInterval = 10 * time.Millisecond
+)
+
+// ErrDirectoryLocked is returned when another process owns the DAG directory
+var ErrDirectoryLocked = errors.New("DAG directory is locked by another process")
+
+// errLockHeld is returned by tryLock when the lock is held, possibly by another process
+var errLockHeld = errors.New("lock is held")
+
+// LockHolder describes the process owning a DAG directory, it is written in the directory lock file
+type LockHolder struct {
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+func (h LockHolder) String() string {
+	return fmt.Sprintf("process %d on %s since %s", h.PID, h.Host, h.StartedAt.Format(time.RFC3339))
+}
+
+// DirectoryLock is the ownership of a DAG directory by the running process
+type DirectoryLock struct {
+	file *os.File
+	// Stale describes the previous owner when it stopped without releasing the lock, e.g. after a crash
+	Stale *LockHolder
+}
+
+// LockDirectory takes the ownership of a DAG directory, so that two servers do not serve and write the same
+// DAGs. The lock is advisory and released by the system when the process exits, a lock file still naming a
+// holder then reveals a stale lock, which is taken over.
+func LockDirectory(dir string) (*DirectoryLock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, dir, err)
+	}
+
+	path := filepath.Join(dir, directoryLockFile)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error opening lock file '%s': %w", usecase.ErrInternal, path, err)
+	}
+
+	previous := readLockHolder(file)
+	if err := tryLock(file); err != nil {
+		_ = file.Close()
+		if errors.Is(err, errLockHeld) && previous != nil {
+			return nil, fmt.Errorf("%w: held by %s", ErrDirectoryLocked, previous)
+		}
+		if errors.Is(err, errLockHeld) {
+			return nil, ErrDirectoryLocked
+		}
+		return nil, fmt.Errorf("%w: error locking '%s': %w", usecase.ErrInternal, path, err)
+	}
+
+	host, _ := os.Hostname()
+	data, err := json.Marshal(LockHolder{PID: os.Getpid(), Host: host, StartedAt: time.Now().UTC()})
+	if err == nil {
+		err = writeLockHolder(file, data)
+	}
+	if err != nil {
+		_ = unlock(file)
+		_ = file.Close()
+		return nil, fmt.Errorf("%w: error writing lock file '%s': %w", usecase.ErrInternal, path, err)
+	}
+
+	return &DirectoryLock{file: file, Stale: previous}, nil
+}
+
+// Release gives up the ownership of the directory, clearing the lock file so that it is not seen as stale
+func (l *DirectoryLock) Release() error {
+	truncateErr := l.file.Truncate(0)
+	unlockErr := unlock(l.file)
+	closeErr := l.file.Close()
+	return errors.Join(truncateErr, unlockErr, closeErr)
+}
+
+func readLockHolder(file *os.File) *LockHolder {
+	data, err := io.ReadAll(io.NewSectionReader(file, 0, 1<<16))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+
+	var holder LockHolder
+	if err := json.Unmarshal(data, &holder); err != nil {
+		return nil
+	}
+	return &holder
+}
+
+func writeLockHolder(file *os.File, data []byte) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := file.WriteAt(data, 0); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// lockDAG takes the lock of a DAG, waiting for the other writers until ctx is done.
+// It returns the function releasing the lock.
+func (r *FileDAGRepository) lockDAG(ctx context.Context, id uuid.UUID) (func(), error) {
+	if !r.locking {
+		return func() {}, nil
+	}
+
+	dir := filepath.Join(r.filePath, dagLocksDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, dir, err)
+	}
+
+	path := filepath.Join(dir, id.String()+".lock")
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error opening lock file '%s': %w", usecase.ErrInternal, path, err)
+	}
+
+	for {
+		err := tryLock(file)
+		if err == nil {
+			return func() {
+				_ = unlock(file)
+				_ = file.Close()
+			}, nil
+		}
+		if !errors.Is(err, errLockHeld) {
+			_ = file.Close()
+			return nil, fmt.Errorf("%w: error locking '%s': %w", usecase.ErrInternal, path, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = file.Close()
+			return nil, fmt.Errorf("%w: DAG %s is locked by another writer: %w", usecase.ErrUnavailable, id, ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package port
+
+import "os"
+
+// tryLock does not lock on platforms without flock, the lock files still record the directory owner
+func tryLock(file *os.File) error {
+	return nil
+}
+
+func unlock(file *os.File) error {
+	return nil
+}
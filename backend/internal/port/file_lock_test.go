@@ -0,0 +1,116 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockDirectory(t *testing.T) {
+	t.Run("lets a single owner lock the directory", func(t *testing.T) {
+		dir := t.TempDir()
+
+		lock, err := LockDirectory(dir)
+		require.NoError(t, err)
+		assert.Nil(t, lock.Stale)
+
+		_, err = LockDirectory(dir)
+		assert.ErrorIs(t, err, ErrDirectoryLocked)
+		assert.ErrorContains(t, err, "process", "the error names the owner")
+
+		require.NoError(t, lock.Release())
+		lock, err = LockDirectory(dir)
+		require.NoError(t, err)
+		assert.Nil(t, lock.Stale, "a released lock is not stale")
+		require.NoError(t, lock.Release())
+	})
+
+	t.Run("takes over a stale lock", func(t *testing.T) {
+		dir := t.TempDir()
+		holder := LockHolder{PID: 4242, Host: "crashed-host", StartedAt: time.Now().Add(-time.Hour).UTC().Truncate(time.Second)}
+		data, err := json.Marshal(holder)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, directoryLockFile), data, 0644))
+
+		lock, err := LockDirectory(dir)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, lock.Release()) }()
+
+		require.NotNil(t, lock.Stale)
+		assert.Equal(t, holder, *lock.Stale)
+	})
+}
+
+func TestFileDAGRepository_Locking(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("does not lose concurrent updates", func(t *testing.T) {
+		dir := t.TempDir()
+		testDAG := createTestDAG(t)
+		require.NoError(t, NewFileDAGRepository(dir).Create(ctx, testDAG))
+
+		// Each repository stands for a process sharing the directory
+		repos := []*FileDAGRepository{NewFileDAGRepository(dir).WithLocking(), NewFileDAGRepository(dir).WithLocking()}
+		var wg sync.WaitGroup
+		for _, repo := range repos {
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, repo.Update(ctx, testDAG.Id, func(dag model.DAG) (model.DAG, error) {
+						dag.Revision++
+						return dag, nil
+					}))
+				}()
+			}
+		}
+		wg.Wait()
+
+		stored, err := repos[0].Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		assert.Equal(t, testDAG.Revision+20, stored.Revision)
+	})
+
+	t.Run("gives up once the context is done", func(t *testing.T) {
+		dir := t.TempDir()
+		testDAG := createTestDAG(t)
+		holder := NewFileDAGRepository(dir).WithLocking()
+		require.NoError(t, holder.Create(ctx, testDAG))
+
+		unlock, err := holder.lockDAG(ctx, testDAG.Id)
+		require.NoError(t, err)
+		defer unlock()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		err = NewFileDAGRepository(dir).WithLocking().Delete(timeoutCtx, testDAG.Id)
+		assert.ErrorIs(t, err, usecase.ErrUnavailable)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		_, err = holder.Get(ctx, testDAG.Id)
+		assert.NoError(t, err, "the DAG is not deleted")
+	})
+
+	t.Run("leaves the lock files out of the DAGs", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := NewFileDAGRepository(dir).WithLocking()
+		testDAG := createTestDAG(t)
+		require.NoError(t, repo.Create(ctx, testDAG))
+		lock, err := LockDirectory(dir)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, lock.Release()) }()
+
+		ids, err := repo.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, ids, 1)
+	})
+}
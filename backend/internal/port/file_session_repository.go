@@ -0,0 +1,115 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const sessionFileExtension = ".session.json"
+
+// FileSessionRepository persists each case session in its own file.
+// Updates are serialized so that concurrent answers never overwrite each other.
+type FileSessionRepository struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+func NewFileSessionRepository(filePath string) *FileSessionRepository {
+	return &FileSessionRepository{
+		filePath: filePath,
+	}
+}
+
+// Get reads a session from its file
+func (r *FileSessionRepository) Get(ctx context.Context, id uuid.UUID) (*model.CaseSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read(id)
+}
+
+// Create writes a new session to its file
+func (r *FileSessionRepository) Create(ctx context.Context, session *model.CaseSession) error {
+	if session == nil {
+		return fmt.Errorf("%w: session cannot be nil", usecase.ErrInvalidCommand)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := os.Stat(r.file(session.Id)); err == nil {
+		return fmt.Errorf("%w: session with id %s already exists", usecase.ErrInvalidCommand, session.Id)
+	}
+
+	return r.write(*session)
+}
+
+// Update applies fnUpdate to a session and writes the result back to its file
+func (r *FileSessionRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(session model.CaseSession) (model.CaseSession, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, err := r.read(id)
+	if err != nil {
+		return err
+	}
+
+	updated, err := fnUpdate(*session)
+	if err != nil {
+		return fmt.Errorf("update function failed: %w", err)
+	}
+	if updated.Id != id {
+		return fmt.Errorf("%w: update function cannot change session ID from %s to %s", usecase.ErrInvalidCommand, id, updated.Id)
+	}
+
+	return r.write(updated)
+}
+
+func (r *FileSessionRepository) read(id uuid.UUID) (*model.CaseSession, error) {
+	sessionFile := r.file(id)
+	data, err := os.ReadFile(sessionFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: session with id %s not found", usecase.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading file '%s': %w", usecase.ErrInternal, sessionFile, err)
+	}
+
+	var session model.CaseSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("%w: error unmarshalling file '%s': %w", usecase.ErrInternal, sessionFile, err)
+	}
+
+	return &session, nil
+}
+
+func (r *FileSessionRepository) write(session model.CaseSession) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: error marshalling session: %w", usecase.ErrInternal, err)
+	}
+
+	if err := os.MkdirAll(r.filePath, 0755); err != nil {
+		return fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	sessionFile := r.file(session.Id)
+	if err := os.WriteFile(sessionFile, data, 0644); err != nil {
+		return fmt.Errorf("%w: error writing file '%s': %w", usecase.ErrInternal, sessionFile, err)
+	}
+
+	return nil
+}
+
+func (r *FileSessionRepository) file(id uuid.UUID) string {
+	return filepath.Join(r.filePath, id.String()+sessionFileExtension)
+}
@@ -0,0 +1,125 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSessionRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newSession := func() *model.CaseSession {
+		now := time.Now().UTC().Truncate(time.Second)
+		nodeId := uuid.New()
+		return &model.CaseSession{
+			Id:            uuid.New(),
+			DAGId:         uuid.New(),
+			Answers:       []model.SessionAnswer{},
+			Status:        model.SessionStatusInProgress,
+			CurrentNodeId: &nodeId,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+	}
+
+	t.Run("persists sessions in their own file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "sessions")
+		session := newSession()
+
+		require.NoError(t, NewFileSessionRepository(dir).Create(ctx, session))
+		require.FileExists(t, filepath.Join(dir, session.Id.String()+sessionFileExtension))
+
+		// A new repository reads the session back from disk
+		stored, err := NewFileSessionRepository(dir).Get(ctx, session.Id)
+		require.NoError(t, err)
+		assert.Equal(t, session, stored)
+	})
+
+	t.Run("returns not found for unknown session", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewFileSessionRepository(t.TempDir())
+
+		_, err := repo.Get(ctx, uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+
+		err = repo.Update(ctx, uuid.New(), func(session model.CaseSession) (model.CaseSession, error) {
+			return session, nil
+		})
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+	})
+
+	t.Run("rejects duplicated session", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewFileSessionRepository(t.TempDir())
+		session := newSession()
+
+		require.NoError(t, repo.Create(ctx, session))
+		assert.ErrorIs(t, repo.Create(ctx, session), usecase.ErrInvalidCommand)
+	})
+
+	t.Run("keeps the session when the update fails", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewFileSessionRepository(t.TempDir())
+		session := newSession()
+		require.NoError(t, repo.Create(ctx, session))
+
+		updateErr := errors.New("boom")
+		err := repo.Update(ctx, session.Id, func(s model.CaseSession) (model.CaseSession, error) {
+			s.Status = model.SessionStatusCompleted
+			return s, updateErr
+		})
+		assert.ErrorIs(t, err, updateErr)
+
+		err = repo.Update(ctx, session.Id, func(s model.CaseSession) (model.CaseSession, error) {
+			s.Id = uuid.New()
+			return s, nil
+		})
+		assert.ErrorIs(t, err, usecase.ErrInvalidCommand)
+
+		stored, err := repo.Get(ctx, session.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.SessionStatusInProgress, stored.Status)
+	})
+
+	t.Run("serializes concurrent updates", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewFileSessionRepository(t.TempDir())
+		session := newSession()
+		require.NoError(t, repo.Create(ctx, session))
+
+		const updates = 20
+		var wg sync.WaitGroup
+		for i := 0; i < updates; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, repo.Update(ctx, session.Id, func(s model.CaseSession) (model.CaseSession, error) {
+					s.Answers = append(s.Answers, model.SessionAnswer{NodeId: uuid.New(), AnswerId: uuid.New()})
+					return s, nil
+				}))
+			}()
+		}
+		wg.Wait()
+
+		stored, err := repo.Get(ctx, session.Id)
+		require.NoError(t, err)
+		assert.Len(t, stored.Answers, updates)
+	})
+}
@@ -0,0 +1,132 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const templateFileExtension = ".template.json"
+
+// FileTemplateRepository persists each template, along with its DAG snapshot, in its own file
+type FileTemplateRepository struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+func NewFileTemplateRepository(filePath string) *FileTemplateRepository {
+	return &FileTemplateRepository{
+		filePath: filePath,
+	}
+}
+
+// List reads every template file, ordered by name
+func (r *FileTemplateRepository) List(ctx context.Context) ([]model.Template, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := os.ReadDir(r.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []model.Template{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	templates := make([]model.Template, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), templateFileExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		id, err := uuid.Parse(name)
+		if err != nil {
+			continue
+		}
+
+		template, err := r.read(id)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *template)
+	}
+
+	sort.SliceStable(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].Id.String() < templates[j].Id.String()
+	})
+
+	return templates, nil
+}
+
+// Get reads a template from its file
+func (r *FileTemplateRepository) Get(ctx context.Context, id uuid.UUID) (*model.Template, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read(id)
+}
+
+// Create writes a new template to its file
+func (r *FileTemplateRepository) Create(ctx context.Context, template *model.Template) error {
+	if template == nil || template.DAG == nil {
+		return fmt.Errorf("%w: template and its DAG cannot be nil", usecase.ErrInvalidCommand)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	templateFile := r.file(template.Id)
+	if _, err := os.Stat(templateFile); err == nil {
+		return fmt.Errorf("%w: template with id %s already exists", usecase.ErrInvalidCommand, template.Id)
+	}
+
+	data, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: error marshalling template: %w", usecase.ErrInternal, err)
+	}
+
+	if err := os.MkdirAll(r.filePath, 0755); err != nil {
+		return fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	if err := writeFileAtomic(templateFile, data, 0644); err != nil {
+		return fmt.Errorf("%w: error writing file '%s': %w", usecase.ErrInternal, templateFile, err)
+	}
+
+	return nil
+}
+
+func (r *FileTemplateRepository) read(id uuid.UUID) (*model.Template, error) {
+	templateFile := r.file(id)
+	data, err := os.ReadFile(templateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: template with id %s not found", usecase.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading file '%s': %w", usecase.ErrInternal, templateFile, err)
+	}
+
+	var template model.Template
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("%w: error unmarshalling file '%s': %w", usecase.ErrInternal, templateFile, err)
+	}
+
+	return &template, nil
+}
+
+func (r *FileTemplateRepository) file(id uuid.UUID) string {
+	return filepath.Join(r.filePath, id.String()+templateFileExtension)
+}
@@ -0,0 +1,75 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTemplateRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("persists templates with their DAG", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "templates")
+		template := model.NewTemplate("Tenancy", "Tenancy deposit claims", dagtest.ValidSingleRoot(), now)
+
+		require.NoError(t, NewFileTemplateRepository(dir).Create(ctx, &template))
+
+		// A new repository reads the template back from disk
+		stored, err := NewFileTemplateRepository(dir).Get(ctx, template.Id)
+		require.NoError(t, err)
+		assert.Equal(t, template.Name, stored.Name)
+		assert.Equal(t, template.Description, stored.Description)
+		assert.Equal(t, template.SourceDAGId, stored.SourceDAGId)
+		assert.True(t, template.CreatedAt.Equal(stored.CreatedAt))
+		_, err = template.DAG.StructuralMapping(*stored.DAG)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, NewFileTemplateRepository(dir).Create(ctx, &template), usecase.ErrInvalidCommand)
+	})
+
+	t.Run("lists templates by name", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewFileTemplateRepository(t.TempDir())
+		for _, name := range []string{"Tenancy", "Contract", "Employment"} {
+			template := model.NewTemplate(name, "", dagtest.ValidSingleRoot(), now)
+			require.NoError(t, repo.Create(ctx, &template))
+		}
+
+		templates, err := repo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, templates, 3)
+		assert.Equal(t, "Contract", templates[0].Name)
+		assert.Equal(t, "Employment", templates[1].Name)
+		assert.Equal(t, "Tenancy", templates[2].Name)
+	})
+
+	t.Run("lists no template before the first is saved", func(t *testing.T) {
+		t.Parallel()
+
+		templates, err := NewFileTemplateRepository(filepath.Join(t.TempDir(), "templates")).List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, templates)
+	})
+
+	t.Run("reports missing templates", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFileTemplateRepository(t.TempDir()).Get(ctx, uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+	})
+}
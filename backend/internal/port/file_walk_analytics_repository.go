@@ -0,0 +1,107 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const walkAnalyticsFileExtension = ".analytics.json"
+
+// FileWalkAnalyticsRepository persists the walk analytics of each DAG in a sidecar file.
+// Updates are serialized so that concurrent walks never lose a count.
+type FileWalkAnalyticsRepository struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+func NewFileWalkAnalyticsRepository(filePath string) *FileWalkAnalyticsRepository {
+	return &FileWalkAnalyticsRepository{
+		filePath: filePath,
+	}
+}
+
+// Get returns the analytics of a DAG, empty analytics when no walk was recorded yet
+func (r *FileWalkAnalyticsRepository) Get(ctx context.Context, dagId uuid.UUID) (*model.WalkAnalytics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read(dagId)
+}
+
+// Update applies fnUpdate to the analytics of a DAG and writes the result back to its sidecar file
+func (r *FileWalkAnalyticsRepository) Update(ctx context.Context, dagId uuid.UUID, fnUpdate func(analytics model.WalkAnalytics) (model.WalkAnalytics, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	analytics, err := r.read(dagId)
+	if err != nil {
+		return err
+	}
+
+	updated, err := fnUpdate(*analytics)
+	if err != nil {
+		return fmt.Errorf("update function failed: %w", err)
+	}
+	updated.DAGId = dagId
+
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: error marshalling walk analytics: %w", usecase.ErrInternal, err)
+	}
+
+	if err := os.MkdirAll(r.filePath, 0755); err != nil {
+		return fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	// Write atomically so that a crash cannot leave a truncated sidecar, failing every later walk of the DAG
+	analyticsFile := r.file(dagId)
+	if err := writeFileAtomic(analyticsFile, data, 0644); err != nil {
+		return fmt.Errorf("%w: error writing file '%s': %w", usecase.ErrInternal, analyticsFile, err)
+	}
+
+	return nil
+}
+
+// Delete removes the sidecar file of a DAG, if any
+func (r *FileWalkAnalyticsRepository) Delete(ctx context.Context, dagId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	analyticsFile := r.file(dagId)
+	if err := os.Remove(analyticsFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: error removing file '%s': %w", usecase.ErrInternal, analyticsFile, err)
+	}
+
+	return nil
+}
+
+func (r *FileWalkAnalyticsRepository) read(dagId uuid.UUID) (*model.WalkAnalytics, error) {
+	analyticsFile := r.file(dagId)
+	data, err := os.ReadFile(analyticsFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return model.NewWalkAnalytics(dagId), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading file '%s': %w", usecase.ErrInternal, analyticsFile, err)
+	}
+
+	analytics := model.NewWalkAnalytics(dagId)
+	if err := json.Unmarshal(data, analytics); err != nil {
+		return nil, fmt.Errorf("%w: error unmarshalling file '%s': %w", usecase.ErrInternal, analyticsFile, err)
+	}
+
+	return analytics, nil
+}
+
+func (r *FileWalkAnalyticsRepository) file(dagId uuid.UUID) string {
+	return filepath.Join(r.filePath, dagId.String()+walkAnalyticsFileExtension)
+}
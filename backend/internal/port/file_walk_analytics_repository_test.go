@@ -0,0 +1,122 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWalkAnalyticsRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("returns empty analytics before any walk", func(t *testing.T) {
+		t.Parallel()
+
+		dagId := uuid.New()
+		repo := NewFileWalkAnalyticsRepository(filepath.Join(t.TempDir(), "analytics"))
+
+		analytics, err := repo.Get(ctx, dagId)
+		require.NoError(t, err)
+		assert.Equal(t, dagId, analytics.DAGId)
+		assert.Empty(t, analytics.Paths)
+		assert.Empty(t, analytics.EdgeCounts)
+	})
+
+	t.Run("deletes the sidecar file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "analytics")
+		dagId := uuid.New()
+		repo := NewFileWalkAnalyticsRepository(dir)
+
+		require.NoError(t, repo.Update(ctx, dagId, func(analytics model.WalkAnalytics) (model.WalkAnalytics, error) {
+			analytics.Record([]uuid.UUID{uuid.New()})
+			return analytics, nil
+		}))
+		require.FileExists(t, filepath.Join(dir, dagId.String()+walkAnalyticsFileExtension))
+
+		require.NoError(t, repo.Delete(ctx, dagId))
+		assert.NoFileExists(t, filepath.Join(dir, dagId.String()+walkAnalyticsFileExtension))
+
+		// Deleting analytics never recorded is not an error
+		require.NoError(t, repo.Delete(ctx, dagId))
+	})
+
+	t.Run("persists analytics in a sidecar file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "analytics")
+		dagId := uuid.New()
+		path := []uuid.UUID{uuid.New(), uuid.New()}
+
+		repo := NewFileWalkAnalyticsRepository(dir)
+		err := repo.Update(ctx, dagId, func(analytics model.WalkAnalytics) (model.WalkAnalytics, error) {
+			analytics.Record(path)
+			return analytics, nil
+		})
+		require.NoError(t, err)
+
+		_, err = os.Stat(filepath.Join(dir, dagId.String()+walkAnalyticsFileExtension))
+		require.NoError(t, err)
+
+		// A new repository reads back the persisted counters
+		analytics, err := NewFileWalkAnalyticsRepository(dir).Get(ctx, dagId)
+		require.NoError(t, err)
+		assert.Equal(t, []model.PathCount{{AnswerIds: path, Count: 1}}, analytics.Paths)
+		assert.Equal(t, map[uuid.UUID]int{path[0]: 1, path[1]: 1}, analytics.EdgeCounts)
+	})
+
+	t.Run("does not lose concurrent updates", func(t *testing.T) {
+		t.Parallel()
+
+		dagId := uuid.New()
+		paths := [][]uuid.UUID{{uuid.New()}, {uuid.New()}}
+		repo := NewFileWalkAnalyticsRepository(t.TempDir())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(path []uuid.UUID) {
+				defer wg.Done()
+				err := repo.Update(ctx, dagId, func(analytics model.WalkAnalytics) (model.WalkAnalytics, error) {
+					analytics.Record(path)
+					return analytics, nil
+				})
+				assert.NoError(t, err)
+			}(paths[i%2])
+		}
+		wg.Wait()
+
+		analytics, err := repo.Get(ctx, dagId)
+		require.NoError(t, err)
+		require.Len(t, analytics.Paths, 2)
+		assert.Equal(t, 10, analytics.Paths[0].Count)
+		assert.Equal(t, 10, analytics.Paths[1].Count)
+	})
+
+	t.Run("keeps stored analytics when the update fails", func(t *testing.T) {
+		t.Parallel()
+
+		dagId := uuid.New()
+		repo := NewFileWalkAnalyticsRepository(t.TempDir())
+
+		err := repo.Update(ctx, dagId, func(analytics model.WalkAnalytics) (model.WalkAnalytics, error) {
+			return analytics, fmt.Errorf("boom")
+		})
+		assert.ErrorContains(t, err, "boom")
+
+		analytics, err := repo.Get(ctx, dagId)
+		require.NoError(t, err)
+		assert.Empty(t, analytics.Paths)
+	})
+}
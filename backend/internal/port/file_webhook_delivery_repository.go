@@ -0,0 +1,175 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const deliveriesFileExtension = ".deliveries.json"
+
+// FileWebhookDeliveryRepository persists the latest deliveries of each webhook in its own file, so that the
+// pending deliveries are resumed after a restart
+type FileWebhookDeliveryRepository struct {
+	filePath    string
+	historySize int
+	mu          sync.Mutex
+}
+
+// NewFileWebhookDeliveryRepository keeps historySize deliveries per webhook, DefaultDeliveryHistorySize when not positive
+func NewFileWebhookDeliveryRepository(filePath string, historySize int) *FileWebhookDeliveryRepository {
+	if historySize <= 0 {
+		historySize = DefaultDeliveryHistorySize
+	}
+
+	return &FileWebhookDeliveryRepository{
+		filePath:    filePath,
+		historySize: historySize,
+	}
+}
+
+// Save replaces a known delivery, or appends it dropping the oldest delivery once the history is full
+func (r *FileWebhookDeliveryRepository) Save(ctx context.Context, delivery model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries, err := r.read(delivery.WebhookId)
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		if deliveries[i].Id == delivery.Id {
+			deliveries[i] = delivery
+			return r.write(delivery.WebhookId, deliveries)
+		}
+	}
+
+	deliveries = append(deliveries, delivery)
+	if len(deliveries) > r.historySize {
+		deliveries = deliveries[len(deliveries)-r.historySize:]
+	}
+	return r.write(delivery.WebhookId, deliveries)
+}
+
+// List returns the latest deliveries of a webhook, most recent first
+func (r *FileWebhookDeliveryRepository) List(ctx context.Context, webhookId uuid.UUID, limit int) ([]model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries, err := r.read(webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	count := len(deliveries)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+
+	latest := make([]model.WebhookDelivery, 0, count)
+	for i := len(deliveries) - 1; i >= len(deliveries)-count; i-- {
+		latest = append(latest, deliveries[i])
+	}
+
+	return latest, nil
+}
+
+// DeleteAll removes the file of a webhook deliveries
+func (r *FileWebhookDeliveryRepository) DeleteAll(ctx context.Context, webhookId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveriesFile := r.file(webhookId)
+	if err := os.Remove(deliveriesFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: error removing file '%s': %w", usecase.ErrInternal, deliveriesFile, err)
+	}
+
+	return nil
+}
+
+// Pending reads the pending deliveries of every webhook, oldest first
+func (r *FileWebhookDeliveryRepository) Pending(ctx context.Context) ([]model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := os.ReadDir(r.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []model.WebhookDelivery{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	pending := []model.WebhookDelivery{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), deliveriesFileExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		webhookId, err := uuid.Parse(name)
+		if err != nil {
+			continue
+		}
+
+		deliveries, err := r.read(webhookId)
+		if err != nil {
+			return nil, err
+		}
+		pending = appendPending(pending, deliveries)
+	}
+	sortDeliveries(pending)
+
+	return pending, nil
+}
+
+// read returns the deliveries of a webhook, oldest first
+func (r *FileWebhookDeliveryRepository) read(webhookId uuid.UUID) ([]model.WebhookDelivery, error) {
+	deliveriesFile := r.file(webhookId)
+	data, err := os.ReadFile(deliveriesFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading file '%s': %w", usecase.ErrInternal, deliveriesFile, err)
+	}
+
+	var deliveries []model.WebhookDelivery
+	if err := json.Unmarshal(data, &deliveries); err != nil {
+		return nil, fmt.Errorf("%w: error unmarshalling file '%s': %w", usecase.ErrInternal, deliveriesFile, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *FileWebhookDeliveryRepository) write(webhookId uuid.UUID, deliveries []model.WebhookDelivery) error {
+	data, err := json.MarshalIndent(deliveries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: error marshalling deliveries: %w", usecase.ErrInternal, err)
+	}
+
+	if err := os.MkdirAll(r.filePath, 0700); err != nil {
+		return fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	// Write atomically so that a crash cannot leave a truncated file, losing the pending deliveries
+	deliveriesFile := r.file(webhookId)
+	if err := writeFileAtomic(deliveriesFile, data, 0600); err != nil {
+		return fmt.Errorf("%w: error writing file '%s': %w", usecase.ErrInternal, deliveriesFile, err)
+	}
+
+	return nil
+}
+
+func (r *FileWebhookDeliveryRepository) file(webhookId uuid.UUID) string {
+	return filepath.Join(r.filePath, webhookId.String()+deliveriesFileExtension)
+}
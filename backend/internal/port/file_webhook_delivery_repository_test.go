@@ -0,0 +1,63 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWebhookDeliveryRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	dir := t.TempDir()
+	webhookId, otherId := uuid.New(), uuid.New()
+	event := model.NewEvent(model.EventDAGCreated, uuid.New(), now)
+
+	repo := NewFileWebhookDeliveryRepository(dir, 3)
+	var deliveries []model.WebhookDelivery
+	for i := 0; i < 4; i++ {
+		delivery := model.NewWebhookDelivery(webhookId, event, now.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, repo.Save(ctx, delivery))
+		deliveries = append(deliveries, delivery)
+	}
+	other := model.NewWebhookDelivery(otherId, event, now.Add(90*time.Second))
+	require.NoError(t, repo.Save(ctx, other))
+
+	// Saving a known delivery replaces it
+	deliveries[3].Status = model.DeliveryStatusSucceeded
+	require.NoError(t, repo.Save(ctx, deliveries[3]))
+
+	// The deliveries are read back after a restart
+	reopened := NewFileWebhookDeliveryRepository(dir, 3)
+	latest, err := reopened.List(ctx, webhookId, 0)
+	require.NoError(t, err)
+	require.Len(t, latest, 3, "the oldest delivery is dropped")
+	assert.Equal(t, deliveries[3].Id, latest[0].Id)
+	assert.Equal(t, model.DeliveryStatusSucceeded, latest[0].Status)
+	assert.Equal(t, deliveries[1].Id, latest[2].Id)
+
+	limited, err := reopened.List(ctx, webhookId, 2)
+	require.NoError(t, err)
+	assert.Len(t, limited, 2)
+
+	pending, err := reopened.Pending(ctx)
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, 0, len(pending))
+	for _, delivery := range pending {
+		ids = append(ids, delivery.Id)
+	}
+	assert.Equal(t, []uuid.UUID{deliveries[1].Id, other.Id, deliveries[2].Id}, ids)
+
+	require.NoError(t, reopened.DeleteAll(ctx, webhookId))
+	latest, err = reopened.List(ctx, webhookId, 0)
+	require.NoError(t, err)
+	assert.Empty(t, latest)
+	assert.NoError(t, reopened.DeleteAll(ctx, webhookId), "deleting twice is a no-op")
+}
@@ -0,0 +1,175 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const webhookFileExtension = ".webhook.json"
+
+// FileWebhookRepository persists each webhook in its own file.
+// Webhook files hold the secrets signing the payloads and are only readable by their owner.
+type FileWebhookRepository struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+func NewFileWebhookRepository(filePath string) *FileWebhookRepository {
+	return &FileWebhookRepository{
+		filePath: filePath,
+	}
+}
+
+// List reads every webhook file, ordered by creation date
+func (r *FileWebhookRepository) List(ctx context.Context) ([]model.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := os.ReadDir(r.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []model.Webhook{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	webhooks := make([]model.Webhook, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), webhookFileExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		id, err := uuid.Parse(name)
+		if err != nil {
+			continue
+		}
+
+		webhook, err := r.read(id)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].Id.String() < webhooks[j].Id.String()
+	})
+
+	return webhooks, nil
+}
+
+// Get reads a webhook from its file
+func (r *FileWebhookRepository) Get(ctx context.Context, id uuid.UUID) (*model.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read(id)
+}
+
+// Create writes a new webhook to its file
+func (r *FileWebhookRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	if webhook == nil {
+		return fmt.Errorf("%w: webhook cannot be nil", usecase.ErrInvalidCommand)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := os.Stat(r.file(webhook.Id)); err == nil {
+		return fmt.Errorf("%w: webhook with id %s already exists", usecase.ErrInvalidCommand, webhook.Id)
+	}
+
+	return r.write(*webhook)
+}
+
+// Update applies fnUpdate to a webhook and writes the result back to its file
+func (r *FileWebhookRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(webhook model.Webhook) (model.Webhook, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook, err := r.read(id)
+	if err != nil {
+		return err
+	}
+
+	updated, err := fnUpdate(*webhook)
+	if err != nil {
+		return fmt.Errorf("update function failed: %w", err)
+	}
+	if updated.Id != id {
+		return fmt.Errorf("%w: update function cannot change webhook ID from %s to %s", usecase.ErrInvalidCommand, id, updated.Id)
+	}
+
+	return r.write(updated)
+}
+
+// Delete removes the file of a webhook
+func (r *FileWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhookFile := r.file(id)
+	err := os.Remove(webhookFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: webhook with id %s not found", usecase.ErrNotFound, id)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: error removing file '%s': %w", usecase.ErrInternal, webhookFile, err)
+	}
+
+	return nil
+}
+
+func (r *FileWebhookRepository) read(id uuid.UUID) (*model.Webhook, error) {
+	webhookFile := r.file(id)
+	data, err := os.ReadFile(webhookFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: webhook with id %s not found", usecase.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading file '%s': %w", usecase.ErrInternal, webhookFile, err)
+	}
+
+	var webhook model.Webhook
+	if err := json.Unmarshal(data, &webhook); err != nil {
+		return nil, fmt.Errorf("%w: error unmarshalling file '%s': %w", usecase.ErrInternal, webhookFile, err)
+	}
+
+	return &webhook, nil
+}
+
+func (r *FileWebhookRepository) write(webhook model.Webhook) error {
+	data, err := json.MarshalIndent(webhook, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: error marshalling webhook: %w", usecase.ErrInternal, err)
+	}
+
+	if err := os.MkdirAll(r.filePath, 0700); err != nil {
+		return fmt.Errorf("%w: error creating directory '%s': %w", usecase.ErrInternal, r.filePath, err)
+	}
+
+	webhookFile := r.file(webhook.Id)
+	if err := os.WriteFile(webhookFile, data, 0600); err != nil {
+		return fmt.Errorf("%w: error writing file '%s': %w", usecase.ErrInternal, webhookFile, err)
+	}
+
+	return nil
+}
+
+func (r *FileWebhookRepository) file(id uuid.UUID) string {
+	return filepath.Join(r.filePath, id.String()+webhookFileExtension)
+}
@@ -0,0 +1,99 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWebhookRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newWebhook := func(createdAt time.Time) *model.Webhook {
+		return &model.Webhook{
+			Id:        uuid.New(),
+			URL:       "https://example.com/hooks",
+			Events:    []string{model.EventDAGCreated},
+			Secret:    "0123456789abcdef",
+			Active:    true,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("persists webhooks in owner only files", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "webhooks")
+		webhook := newWebhook(now)
+
+		require.NoError(t, NewFileWebhookRepository(dir).Create(ctx, webhook))
+		info, err := os.Stat(filepath.Join(dir, webhook.Id.String()+webhookFileExtension))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		// A new repository reads the webhook back from disk
+		stored, err := NewFileWebhookRepository(dir).Get(ctx, webhook.Id)
+		require.NoError(t, err)
+		assert.Equal(t, webhook, stored)
+
+		assert.ErrorIs(t, NewFileWebhookRepository(dir).Create(ctx, webhook), usecase.ErrInvalidCommand)
+	})
+
+	t.Run("lists webhooks by creation date", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewFileWebhookRepository(t.TempDir())
+		later := newWebhook(now.Add(time.Minute))
+		earlier := newWebhook(now)
+		require.NoError(t, repo.Create(ctx, later))
+		require.NoError(t, repo.Create(ctx, earlier))
+
+		webhooks, err := repo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		assert.Equal(t, earlier.Id, webhooks[0].Id)
+		assert.Equal(t, later.Id, webhooks[1].Id)
+
+		empty, err := NewFileWebhookRepository(filepath.Join(t.TempDir(), "missing")).List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, empty)
+	})
+
+	t.Run("updates and deletes webhooks", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewFileWebhookRepository(t.TempDir())
+		webhook := newWebhook(now)
+		require.NoError(t, repo.Create(ctx, webhook))
+
+		require.NoError(t, repo.Update(ctx, webhook.Id, func(existing model.Webhook) (model.Webhook, error) {
+			existing.Active = false
+			return existing, nil
+		}))
+		stored, err := repo.Get(ctx, webhook.Id)
+		require.NoError(t, err)
+		assert.False(t, stored.Active)
+
+		err = repo.Update(ctx, webhook.Id, func(existing model.Webhook) (model.Webhook, error) {
+			existing.Id = uuid.New()
+			return existing, nil
+		})
+		assert.ErrorIs(t, err, usecase.ErrInvalidCommand)
+
+		require.NoError(t, repo.Delete(ctx, webhook.Id))
+		_, err = repo.Get(ctx, webhook.Id)
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, webhook.Id), usecase.ErrNotFound)
+	})
+}
@@ -3,34 +3,79 @@ package port
 import (
 	"context"
 	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/search"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
 	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
+// PersistentDAGRepository is the durable storage behind the DAGs the hybrid repository serves from memory
+type PersistentDAGRepository interface {
+	List(ctx context.Context) ([]uuid.UUID, error)
+	Get(ctx context.Context, id uuid.UUID) (*model.DAG, error)
+	Create(ctx context.Context, dag *model.DAG) error
+	Update(ctx context.Context, id uuid.UUID, fnUpdate func(dag model.DAG) (model.DAG, error)) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Quarantine moves a corrupt DAG out of the stored DAGs, returning its new location
+	Quarantine(id uuid.UUID) (string, error)
+	// Location tells the operators where a DAG is stored
+	Location(id uuid.UUID) string
+}
+
+// tempFileRemover is implemented by the storages left with temporary files by interrupted writes
+type tempFileRemover interface {
+	RemoveTempFiles() ([]string, error)
+}
+
 // HybridDAGRepository combines file-based persistence with in-memory performance
 // It loads DAGs from files at startup and serves them from memory for fast access
 // Changes are persisted back to files for durability
+// A full-text index of the DAGs in memory is updated on every write
+// DAGs changed in memory but not in their file are tracked as dirty until the next sync
 type HybridDAGRepository struct {
-	fileRepo   *FileDAGRepository
+	fileRepo   PersistentDAGRepository
 	memoryRepo *InMemoryDAGRepository
+	index      *search.Index
 	logger     zerolog.Logger
 	// writeThrough determines if changes are immediately persisted to file
 	writeThrough bool
+
+	// updateMu serializes the write-through updates
+	updateMu sync.Mutex
+
+	dirtyMu sync.Mutex
+	// dirty holds when each DAG was first changed since it was last persisted
+	dirty      map[uuid.UUID]time.Time
+	lastSyncAt *time.Time
 }
 
 // HybridDAGRepositoryConfig configures the hybrid repository behavior
 type HybridDAGRepositoryConfig struct {
 	FilePath     string
 	WriteThrough bool            // If true, changes are immediately persisted to file
+	Locking      bool            // If true, DAG files are locked while written, against other processes
 	Logger       *zerolog.Logger // Optional logger, if nil a default will be created
+	// Store optionally persists the DAGs elsewhere than in the files of FilePath, e.g. in object storage
+	Store PersistentDAGRepository
 }
 
 // NewHybridDAGRepository creates a new hybrid repository
 func NewHybridDAGRepository(config HybridDAGRepositoryConfig) *HybridDAGRepository {
-	fileRepo := NewFileDAGRepository(config.FilePath)
+	fileRepo := config.Store
+	if fileRepo == nil {
+		files := NewFileDAGRepository(config.FilePath)
+		if config.Locking {
+			files.WithLocking()
+		}
+		fileRepo = files
+	}
 	memoryRepo := NewInMemoryDAGRepository()
 
 	var logger zerolog.Logger
@@ -43,33 +88,67 @@ func NewHybridDAGRepository(config HybridDAGRepositoryConfig) *HybridDAGReposito
 	return &HybridDAGRepository{
 		fileRepo:     fileRepo,
 		memoryRepo:   memoryRepo,
+		index:        search.NewIndex(),
 		logger:       logger,
 		writeThrough: config.WriteThrough,
+		dirty:        make(map[uuid.UUID]time.Time),
 	}
 }
 
+// LoadFailure describes a DAG file which could not be loaded into memory
+type LoadFailure struct {
+	DAGId uuid.UUID
+	File  string
+	Err   error
+	// QuarantinedTo is where the file was moved when it is corrupt, empty when it was left in place
+	QuarantinedTo string
+}
+
 // Initialize loads all DAGs from the file repository into memory
 // This should be called once during application startup
-func (r *HybridDAGRepository) Initialize(ctx context.Context) error {
+// Files which cannot be loaded are skipped and reported as failures, so that operators see data issues
+func (r *HybridDAGRepository) Initialize(ctx context.Context) ([]LoadFailure, error) {
 	r.logger.Info().Msg("Initializing hybrid DAG repository: loading DAGs from files into memory")
 
+	// Writes interrupted by a crash leave temporary files next to the intact DAG files
+	if remover, ok := r.fileRepo.(tempFileRemover); ok {
+		removed, err := remover.RemoveTempFiles()
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			r.logger.Warn().Err(err).Msg("Failed to remove temporary DAG files")
+		}
+		if len(removed) > 0 {
+			r.logger.Warn().Strs("files", removed).Msg("Removed temporary files of interrupted DAG writes")
+		}
+	}
+
 	// List all DAGs from file system
 	dagIds, err := r.fileRepo.List(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to list DAGs from file repository: %w", err)
+		return nil, fmt.Errorf("failed to list DAGs from file repository: %w", err)
 	}
 
 	r.logger.Info().Int("count", len(dagIds)).Msg("Found DAGs in file system")
 
 	// Load each DAG from file into memory
 	loadedCount := 0
+	var failures []LoadFailure
 	for _, dagId := range dagIds {
+		// Stop promptly when the caller gives up on the initialization
+		if err := ctx.Err(); err != nil {
+			r.logger.Warn().
+				Int("successfully_loaded", loadedCount).
+				Err(err).
+				Msg("DAG repository initialization cancelled")
+			return failures, err
+		}
+
 		dagObj, err := r.fileRepo.Get(ctx, dagId)
 		if err != nil {
 			r.logger.Warn().
 				Str("dag_id", dagId.String()).
 				Err(err).
 				Msg("Failed to load DAG from file, skipping")
+			failures = append(failures, r.loadFailure(dagId, err))
 			continue
 		}
 
@@ -80,8 +159,10 @@ func (r *HybridDAGRepository) Initialize(ctx context.Context) error {
 				Str("dag_id", dagId.String()).
 				Err(err).
 				Msg("Failed to store DAG in memory, skipping")
+			failures = append(failures, r.loadFailure(dagId, err))
 			continue
 		}
+		r.index.Add(dagObj)
 
 		loadedCount++
 	}
@@ -89,13 +170,36 @@ func (r *HybridDAGRepository) Initialize(ctx context.Context) error {
 	r.logger.Info().
 		Int("total_found", len(dagIds)).
 		Int("successfully_loaded", loadedCount).
+		Int("failed", len(failures)).
 		Msg("DAG repository initialization completed")
 
-	return nil
+	return failures, nil
+}
+
+// loadFailure reports a DAG file which could not be loaded, corrupt files are moved to the quarantine so that
+// they are not loaded again while their DAG can be created anew
+func (r *HybridDAGRepository) loadFailure(dagId uuid.UUID, err error) LoadFailure {
+	failure := LoadFailure{
+		DAGId: dagId,
+		File:  r.fileRepo.Location(dagId),
+		Err:   err,
+	}
+
+	if errors.Is(err, errCorruptFile) {
+		quarantined, qErr := r.fileRepo.Quarantine(dagId)
+		if qErr != nil {
+			r.logger.Warn().Str("dag_id", dagId.String()).Err(qErr).Msg("Failed to quarantine corrupt DAG file")
+			return failure
+		}
+		failure.QuarantinedTo = quarantined
+	}
+
+	return failure
 }
 
-// Sync persists all in-memory DAGs back to the file system
+// Sync persists all in-memory DAGs back to the file system, and removes the files of the DAGs deleted from memory
 // Useful for batch persistence or shutdown procedures
+// Cancelling the context stops the sync before the next DAG and returns the context error
 func (r *HybridDAGRepository) Sync(ctx context.Context) error {
 	r.logger.Info().Msg("Syncing in-memory DAGs to file system")
 
@@ -105,46 +209,188 @@ func (r *HybridDAGRepository) Sync(ctx context.Context) error {
 		return fmt.Errorf("failed to list DAGs from memory: %w", err)
 	}
 
-	syncedCount := 0
+	// The DAGs deleted from memory are only known as dirty
+	dirty := r.takeDirty()
+	inMemory := make(map[uuid.UUID]bool, len(dagIds))
 	for _, dagId := range dagIds {
-		dagObj, err := r.memoryRepo.Get(ctx, dagId)
-		if err != nil {
-			r.logger.Warn().
-				Str("dag_id", dagId.String()).
-				Err(err).
-				Msg("Failed to get DAG from memory during sync")
-			continue
+		inMemory[dagId] = true
+	}
+	for dagId := range dirty {
+		if !inMemory[dagId] {
+			dagIds = append(dagIds, dagId)
 		}
+	}
 
-		// Try to get from file first to determine if it's create or update
-		_, err = r.fileRepo.Get(ctx, dagId)
-		if err != nil {
-			// DAG doesn't exist in file, create it
-			err = r.fileRepo.Create(ctx, dagObj)
-		} else {
-			// DAG exists in file, update it
-			err = r.fileRepo.Update(ctx, dagId, func(existing model.DAG) (model.DAG, error) {
-				return *dagObj, nil
-			})
+	syncedCount, _, err := r.syncDAGs(ctx, dagIds, dirty)
+	if err != nil {
+		r.logger.Warn().
+			Int("total_dags", len(dagIds)).
+			Int("successfully_synced", syncedCount).
+			Err(err).
+			Msg("DAG sync cancelled")
+		return err
+	}
+
+	r.logger.Info().
+		Int("total_dags", len(dagIds)).
+		Int("successfully_synced", syncedCount).
+		Msg("DAG sync completed")
+
+	return nil
+}
+
+// SyncDirty persists the DAGs changed in memory since they were last persisted, and removes the files of the
+// deleted ones. It returns the number of DAGs synced, the DAGs which could not be synced stay dirty.
+func (r *HybridDAGRepository) SyncDirty(ctx context.Context) (int, error) {
+	dirty := r.takeDirty()
+	dagIds := make([]uuid.UUID, 0, len(dirty))
+	for dagId := range dirty {
+		dagIds = append(dagIds, dagId)
+	}
+
+	synced, failed, err := r.syncDAGs(ctx, dagIds, dirty)
+	if err != nil {
+		return synced, err
+	}
+	if failed > 0 {
+		return synced, fmt.Errorf("%w: %d DAGs could not be synced to files", usecase.ErrInternal, failed)
+	}
+
+	return synced, nil
+}
+
+// RunSync syncs the dirty DAGs at every interval until ctx is cancelled
+func (r *HybridDAGRepository) RunSync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.SyncStatus().Dirty == 0 {
+				continue
+			}
+
+			synced, err := r.SyncDirty(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.logger.Warn().Err(err).Int("synced", synced).Msg("Periodic DAG sync failed")
+				continue
+			}
+			r.logger.Debug().Int("synced", synced).Msg("Periodic DAG sync completed")
 		}
+	}
+}
 
-		if err != nil {
+// SyncStatus returns the DAGs waiting to be persisted and the time of the last sync
+func (r *HybridDAGRepository) SyncStatus() model.SyncStatus {
+	r.dirtyMu.Lock()
+	defer r.dirtyMu.Unlock()
+
+	status := model.SyncStatus{Dirty: len(r.dirty), LastSyncAt: r.lastSyncAt}
+	for _, since := range r.dirty {
+		if status.OldestDirtyAt == nil || since.Before(*status.OldestDirtyAt) {
+			oldest := since
+			status.OldestDirtyAt = &oldest
+		}
+	}
+	return status
+}
+
+// syncDAGs persists the DAGs, returning the number of DAGs synced and failed. The DAGs which are not synced,
+// because they failed or the context was cancelled, are marked dirty again, since their time in dirty if known.
+func (r *HybridDAGRepository) syncDAGs(ctx context.Context, dagIds []uuid.UUID, dirty map[uuid.UUID]time.Time) (int, int, error) {
+	synced, failed := 0, 0
+	for i, dagId := range dagIds {
+		if err := ctx.Err(); err != nil {
+			r.restoreDirty(dagIds[i:], dirty)
+			return synced, failed, err
+		}
+
+		if err := r.syncDAG(ctx, dagId); err != nil {
 			r.logger.Warn().
 				Str("dag_id", dagId.String()).
 				Err(err).
 				Msg("Failed to sync DAG to file")
+			r.restoreDirty(dagIds[i:i+1], dirty)
+			failed++
 			continue
 		}
 
-		syncedCount++
+		synced++
 	}
 
-	r.logger.Info().
-		Int("total_dags", len(dagIds)).
-		Int("successfully_synced", syncedCount).
-		Msg("DAG sync completed")
+	now := time.Now()
+	r.dirtyMu.Lock()
+	r.lastSyncAt = &now
+	r.dirtyMu.Unlock()
 
-	return nil
+	return synced, failed, nil
+}
+
+// syncDAG writes the DAG in memory to its file, or removes its file when it was deleted from memory
+func (r *HybridDAGRepository) syncDAG(ctx context.Context, dagId uuid.UUID) error {
+	dagObj, err := r.memoryRepo.Get(ctx, dagId)
+	if errors.Is(err, usecase.ErrNotFound) {
+		err = r.fileRepo.Delete(ctx, dagId)
+		if err != nil && !errors.Is(err, usecase.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get DAG from memory: %w", err)
+	}
+
+	// Try to get from file first to determine if it's create or update
+	_, err = r.fileRepo.Get(ctx, dagId)
+	if err != nil {
+		// DAG doesn't exist in file, create it
+		return r.fileRepo.Create(ctx, dagObj)
+	}
+
+	// DAG exists in file, update it
+	return r.fileRepo.Update(ctx, dagId, func(existing model.DAG) (model.DAG, error) {
+		return *dagObj, nil
+	})
+}
+
+// markDirty records that the DAG changed in memory, keeping the time of its first change
+func (r *HybridDAGRepository) markDirty(dagId uuid.UUID) {
+	r.dirtyMu.Lock()
+	defer r.dirtyMu.Unlock()
+
+	if _, ok := r.dirty[dagId]; !ok {
+		r.dirty[dagId] = time.Now()
+	}
+}
+
+// takeDirty returns the dirty DAGs and clears them, the DAGs changed during the sync are marked dirty again
+func (r *HybridDAGRepository) takeDirty() map[uuid.UUID]time.Time {
+	r.dirtyMu.Lock()
+	defer r.dirtyMu.Unlock()
+
+	dirty := r.dirty
+	r.dirty = make(map[uuid.UUID]time.Time)
+	return dirty
+}
+
+// restoreDirty marks the DAGs which could not be synced dirty again
+func (r *HybridDAGRepository) restoreDirty(dagIds []uuid.UUID, dirty map[uuid.UUID]time.Time) {
+	r.dirtyMu.Lock()
+	defer r.dirtyMu.Unlock()
+
+	now := time.Now()
+	for _, dagId := range dagIds {
+		since, ok := dirty[dagId]
+		if !ok {
+			since = now
+		}
+		if current, ok := r.dirty[dagId]; !ok || since.Before(current) {
+			r.dirty[dagId] = since
+		}
+	}
 }
 
 // List returns all DAG IDs from memory (fast operation)
@@ -152,6 +398,11 @@ func (r *HybridDAGRepository) List(ctx context.Context) ([]uuid.UUID, error) {
 	return r.memoryRepo.List(ctx)
 }
 
+// Query runs the query over the DAGs in memory (fast operation)
+func (r *HybridDAGRepository) Query(ctx context.Context, query model.DAGQuery) (*model.DAGPage, error) {
+	return r.memoryRepo.Query(ctx, query)
+}
+
 // Get retrieves a DAG from memory (fast operation)
 func (r *HybridDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG, error) {
 	return r.memoryRepo.Get(ctx, id)
@@ -172,7 +423,7 @@ func (r *HybridDAGRepository) Create(ctx context.Context, dagObj *model.DAG) err
 			// Rollback memory operation on file failure
 			deleteErr := r.memoryRepo.Delete(ctx, dagObj.Id)
 			if deleteErr != nil {
-				r.logger.Error().
+				xlog.With(ctx, r.logger).Error().
 					Str("dag_id", dagObj.Id.String()).
 					Err(deleteErr).
 					Msg("Failed to rollback memory operation after file create failure")
@@ -180,42 +431,87 @@ func (r *HybridDAGRepository) Create(ctx context.Context, dagObj *model.DAG) err
 			return fmt.Errorf("failed to create DAG in file (memory rolled back): %w", err)
 		}
 
-		r.logger.Debug().
+		xlog.With(ctx, r.logger).Debug().
 			Str("dag_id", dagObj.Id.String()).
 			Msg("DAG created in both memory and file")
 	} else {
-		r.logger.Debug().
+		r.markDirty(dagObj.Id)
+		xlog.With(ctx, r.logger).Debug().
 			Str("dag_id", dagObj.Id.String()).
 			Msg("DAG created in memory (write-through disabled)")
 	}
+	r.index.Add(dagObj)
 
 	return nil
 }
 
 // Update modifies a DAG in memory and optionally persists to file
 func (r *HybridDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(dag model.DAG) (model.DAG, error)) error {
-	// Update in memory first
-	err := r.memoryRepo.Update(ctx, id, fnUpdate)
+	// Write-through updates are serialized, a conflicting update reloading memory from the file must not undo
+	// another update not yet written to the file
+	if r.writeThrough {
+		r.updateMu.Lock()
+		defer r.updateMu.Unlock()
+	}
+
+	// Update in memory first, fnUpdate runs once so that what it generates, e.g. new IDs, is the same in the file
+	var previousRevision uint64
+	var updated model.DAG
+	err := r.memoryRepo.Update(ctx, id, func(existing model.DAG) (model.DAG, error) {
+		previousRevision = existing.Revision
+		result, err := fnUpdate(existing)
+		if err != nil {
+			return result, err
+		}
+		updated = result
+		return result, nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update DAG in memory: %w", err)
 	}
+	// Memory serves the reads, the index follows it even when persisting to file fails
+	r.reindex(ctx, id)
 
 	// Persist to file if write-through is enabled
 	if r.writeThrough {
-		err = r.fileRepo.Update(ctx, id, fnUpdate)
+		// A dirty DAG is ahead of its file, which the write catches up with as the next sync would
+		r.dirtyMu.Lock()
+		_, dirty := r.dirty[id]
+		r.dirtyMu.Unlock()
+
+		err = r.fileRepo.Update(ctx, id, func(stored model.DAG) (model.DAG, error) {
+			// The update was made on a stale copy when another server changed the DAG since memory was loaded
+			if !dirty && stored.Revision != previousRevision {
+				return stored, fmt.Errorf("%w: DAG %s is at revision %d in the storage, not %d", usecase.ErrConflict, id, stored.Revision, previousRevision)
+			}
+			return updated, nil
+		})
+		if errors.Is(err, usecase.ErrConflict) {
+			// The storage holds the latest DAG, memory is reloaded from it rather than overwriting it on the next sync
+			if reloadErr := r.reload(ctx, id); reloadErr != nil {
+				xlog.With(ctx, r.logger).Error().
+					Str("dag_id", id.String()).
+					Err(reloadErr).
+					Msg("Failed to reload DAG from file after a conflicting update")
+			}
+			return fmt.Errorf("failed to update DAG in file: %w", err)
+		}
 		if err != nil {
-			r.logger.Error().
+			// The next sync writes the DAG in memory to its file
+			r.markDirty(id)
+			xlog.With(ctx, r.logger).Error().
 				Str("dag_id", id.String()).
 				Err(err).
 				Msg("Failed to update DAG in file, memory and file are now inconsistent")
 			return fmt.Errorf("failed to update DAG in file: %w", err)
 		}
 
-		r.logger.Debug().
+		xlog.With(ctx, r.logger).Debug().
 			Str("dag_id", id.String()).
 			Msg("DAG updated in both memory and file")
 	} else {
-		r.logger.Debug().
+		r.markDirty(id)
+		xlog.With(ctx, r.logger).Debug().
 			Str("dag_id", id.String()).
 			Msg("DAG updated in memory (write-through disabled)")
 	}
@@ -230,23 +526,27 @@ func (r *HybridDAGRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err != nil {
 		return fmt.Errorf("failed to delete DAG from memory: %w", err)
 	}
+	r.index.Remove(id)
 
 	// Delete from file if write-through is enabled
 	if r.writeThrough {
 		err = r.fileRepo.Delete(ctx, id)
 		if err != nil {
-			r.logger.Error().
+			// The next sync removes the file of the DAG deleted from memory
+			r.markDirty(id)
+			xlog.With(ctx, r.logger).Error().
 				Str("dag_id", id.String()).
 				Err(err).
 				Msg("Failed to delete DAG from file, memory and file are now inconsistent")
 			return fmt.Errorf("failed to delete DAG from file: %w", err)
 		}
 
-		r.logger.Debug().
+		xlog.With(ctx, r.logger).Debug().
 			Str("dag_id", id.String()).
 			Msg("DAG deleted from both memory and file")
 	} else {
-		r.logger.Debug().
+		r.markDirty(id)
+		xlog.With(ctx, r.logger).Debug().
 			Str("dag_id", id.String()).
 			Msg("DAG deleted from memory (write-through disabled)")
 	}
@@ -254,6 +554,81 @@ func (r *HybridDAGRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// Refresh reloads a DAG from the storage into memory, after another server changed it. A DAG changed in memory
+// and not yet persisted is kept as is, it overwrites the storage on the next sync.
+func (r *HybridDAGRepository) Refresh(ctx context.Context, id uuid.UUID) error {
+	r.dirtyMu.Lock()
+	_, dirty := r.dirty[id]
+	r.dirtyMu.Unlock()
+	if dirty {
+		return nil
+	}
+
+	return r.reload(ctx, id)
+}
+
+// reload replaces the DAG in memory with the one in the storage, whether or not it was changed in memory since.
+// A DAG missing from the storage is removed from memory.
+func (r *HybridDAGRepository) reload(ctx context.Context, id uuid.UUID) error {
+	r.dirtyMu.Lock()
+	delete(r.dirty, id)
+	r.dirtyMu.Unlock()
+
+	dagObj, err := r.fileRepo.Get(ctx, id)
+	if errors.Is(err, usecase.ErrNotFound) {
+		err = r.memoryRepo.Delete(ctx, id)
+		if err != nil && !errors.Is(err, usecase.ErrNotFound) {
+			return err
+		}
+		r.index.Remove(id)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = r.memoryRepo.Update(ctx, id, func(model.DAG) (model.DAG, error) { return *dagObj, nil })
+	if errors.Is(err, usecase.ErrNotFound) {
+		err = r.memoryRepo.Create(ctx, dagObj)
+	}
+	if err != nil {
+		return err
+	}
+	r.index.Add(dagObj)
+
+	return nil
+}
+
+// Each yields the DAGs from memory, which holds every loaded DAG
+func (r *HybridDAGRepository) Each(ctx context.Context, fn func(dag *model.DAG) error) error {
+	return r.memoryRepo.Each(ctx, fn)
+}
+
+// Search runs a full-text search over the questions, answers and user contexts of the DAGs in memory
+func (r *HybridDAGRepository) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	return r.index.Search(ctx, query, limit)
+}
+
+// reindex replaces the index entries of a DAG with its content in memory
+func (r *HybridDAGRepository) reindex(ctx context.Context, id uuid.UUID) {
+	dagObj, err := r.memoryRepo.Get(ctx, id)
+	if err != nil {
+		r.index.Remove(id)
+		return
+	}
+	r.index.Add(dagObj)
+}
+
+// Watch streams change events from memory, which is updated on every write
+func (r *HybridDAGRepository) Watch(ctx context.Context) (<-chan model.ChangeEvent, error) {
+	return r.memoryRepo.Watch(ctx)
+}
+
+// Observe calls fn on every change made in memory, before the write returns
+func (r *HybridDAGRepository) Observe(fn func(model.ChangeEvent)) (stop func()) {
+	return r.memoryRepo.Observe(fn)
+}
+
 // GetStats returns statistics about the repository state
 func (r *HybridDAGRepository) GetStats(ctx context.Context) (HybridRepositoryStats, error) {
 	memoryIds, err := r.memoryRepo.List(ctx)
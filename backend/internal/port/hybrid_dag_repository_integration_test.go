@@ -37,11 +37,11 @@ func TestHybridDAGRepository_FullIntegration(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := hybridRepo.Initialize(ctx)
+	_, err := hybridRepo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Step 3: Create application layer using hybrid repository
-	appLayer := pkg.New(hybridRepo)
+	appLayer := pkg.New(hybridRepo, hybridRepo, NewFileWalkAnalyticsRepository(t.TempDir()), NewFileDAGVersionRepository(t.TempDir()), NewFileSessionRepository(t.TempDir()), nil, NewFileAttachmentStorage(t.TempDir()), usecase.DefaultAttachmentPolicy(), NewFileWebhookRepository(t.TempDir()), NewInMemoryWebhookDeliveryRepository(0), NewFileTemplateRepository(t.TempDir()), nil, nil, nil, usecase.DefaultValidationProfile())
 
 	// Step 4: Test that existing DAGs are available through the app
 	listCmd := usecase.CmdListDAGs{}
@@ -117,7 +117,7 @@ func TestHybridDAGRepository_PerformanceComparison(t *testing.T) {
 		WriteThrough: false, // Disable for performance test
 		Logger:       &logger,
 	})
-	err := hybridRepo.Initialize(ctx)
+	_, err := hybridRepo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Benchmark repeated reads from each repository
@@ -159,7 +159,7 @@ func TestHybridDAGRepository_StartupRecovery(t *testing.T) {
 			Logger:       &logger,
 		})
 
-		err := repo1.Initialize(ctx)
+		_, err := repo1.Initialize(ctx)
 		require.NoError(t, err)
 
 		// Add some DAGs
@@ -181,7 +181,7 @@ func TestHybridDAGRepository_StartupRecovery(t *testing.T) {
 		})
 
 		// Initialize should load all existing DAGs from files
-		err := repo2.Initialize(ctx)
+		_, err := repo2.Initialize(ctx)
 		require.NoError(t, err)
 
 		// Verify all DAGs were recovered
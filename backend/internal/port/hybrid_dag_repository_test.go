@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -41,7 +42,7 @@ func TestHybridDAGRepository_Initialize_EmptyDirectory(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Should have no DAGs in memory
@@ -73,7 +74,7 @@ func TestHybridDAGRepository_Initialize_WithExistingFiles(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Should have all DAGs loaded in memory
@@ -89,6 +90,56 @@ func TestHybridDAGRepository_Initialize_WithExistingFiles(t *testing.T) {
 	}
 }
 
+func TestHybridDAGRepository_Initialize_ReportsLoadFailures(t *testing.T) {
+	tempDir := t.TempDir()
+
+	validDAGs := createTestDAGs(t, 2)
+	for _, testDAG := range validDAGs {
+		data, err := testDAG.MarshalJSON()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, testDAG.Id.String()+".json"), data, 0644))
+	}
+
+	// Corrupt files named after a DAG ID
+	corruptIds := []uuid.UUID{uuid.New(), uuid.New()}
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, corruptIds[0].String()+".json"), []byte("{not json"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, corruptIds[1].String()+".json"), []byte(`{"id": 42}`), 0644))
+
+	logger := zerolog.Nop()
+	repo := NewHybridDAGRepository(HybridDAGRepositoryConfig{
+		FilePath:     tempDir,
+		WriteThrough: true,
+		Logger:       &logger,
+	})
+
+	ctx := context.Background()
+	failures, err := repo.Initialize(ctx)
+	require.NoError(t, err)
+
+	// Corrupt files are reported with their path and cause
+	require.Len(t, failures, 2)
+	failedIds := make([]uuid.UUID, len(failures))
+	for i, failure := range failures {
+		failedIds[i] = failure.DAGId
+		assert.Equal(t, filepath.Join(tempDir, failure.DAGId.String()+".json"), failure.File)
+		assert.ErrorIs(t, failure.Err, usecase.ErrInternal)
+
+		// Corrupt files are moved out of the DAG files
+		assert.NoFileExists(t, failure.File)
+		assert.FileExists(t, failure.QuarantinedTo)
+	}
+	assert.ElementsMatch(t, corruptIds, failedIds)
+
+	// Valid files are loaded
+	dagIds, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, dagIds, 2)
+	for _, testDAG := range validDAGs {
+		_, err := repo.Get(ctx, testDAG.Id)
+		assert.NoError(t, err)
+	}
+}
+
 func TestHybridDAGRepository_CreateWithWriteThrough(t *testing.T) {
 	tempDir := t.TempDir()
 	logger := zerolog.Nop()
@@ -99,7 +150,7 @@ func TestHybridDAGRepository_CreateWithWriteThrough(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Create a new DAG
@@ -132,7 +183,7 @@ func TestHybridDAGRepository_CreateWithoutWriteThrough(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Create a new DAG
@@ -160,7 +211,7 @@ func TestHybridDAGRepository_UpdateWithWriteThrough(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Create a DAG
@@ -194,6 +245,115 @@ func TestHybridDAGRepository_UpdateWithWriteThrough(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestHybridDAGRepository_UpdateWithWriteThrough_SameIDs(t *testing.T) {
+	tempDir := t.TempDir()
+	logger := zerolog.Nop()
+	repo := NewHybridDAGRepository(HybridDAGRepositoryConfig{
+		FilePath:     tempDir,
+		WriteThrough: true,
+		Logger:       &logger,
+	})
+
+	ctx := context.Background()
+	_, err := repo.Initialize(ctx)
+	require.NoError(t, err)
+
+	// Each update generates IDs, the same IDs must reach memory and file
+	generated := createTestDAG(t)
+	require.NoError(t, repo.Create(ctx, generated))
+	calls := 0
+	err = repo.Update(ctx, generated.Id, func(existing model.DAG) (model.DAG, error) {
+		calls++
+		nodeID := uuid.New()
+		existing.Nodes[nodeID] = model.Node{Id: nodeID, Question: "Generated question"}
+		return existing, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	inserted := createTestDAG(t)
+	inserted.Title = "Dismissal"
+	answerID := inserted.Nodes[firstNodeID(inserted)].Answers[0].Id
+	require.NoError(t, repo.Create(ctx, inserted))
+	_, err = usecase.NewInsertNodeUseCase(repo, usecase.NewDAGValidator(), nil).Execute(ctx, usecase.CmdInsertNode{
+		DAGId:     inserted.Id.String(),
+		AnswerId:  answerID.String(),
+		Question:  "Were you notified in writing?",
+		Statement: "Yes",
+	})
+	require.NoError(t, err)
+
+	for _, id := range []uuid.UUID{generated.Id, inserted.Id} {
+		memoryDAG, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		fileDAG, err := repo.fileRepo.Get(ctx, id)
+		require.NoError(t, err)
+
+		require.Len(t, fileDAG.Nodes, 2)
+		require.Len(t, memoryDAG.Nodes, 2)
+		for nodeID, node := range memoryDAG.Nodes {
+			require.Contains(t, fileDAG.Nodes, nodeID)
+			require.Len(t, fileDAG.Nodes[nodeID].Answers, len(node.Answers))
+			for i, answer := range node.Answers {
+				assert.Equal(t, answer.Id, fileDAG.Nodes[nodeID].Answers[i].Id)
+				assert.Equal(t, answer.NextNode, fileDAG.Nodes[nodeID].Answers[i].NextNode)
+			}
+		}
+	}
+}
+
+// firstNodeID returns the ID of the node of a DAG created by createTestDAG
+func firstNodeID(d *model.DAG) uuid.UUID {
+	for id := range d.Nodes {
+		return id
+	}
+	return uuid.Nil
+}
+
+func TestHybridDAGRepository_UpdateStaleReplica(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileDAGRepository(t.TempDir())
+	testDAG := createTestDAG(t)
+	require.NoError(t, store.Create(ctx, testDAG))
+
+	// Two servers share the storage, each serving its own copy of the DAGs from memory
+	logger := zerolog.Nop()
+	replicas := make([]*HybridDAGRepository, 2)
+	for i := range replicas {
+		replicas[i] = NewHybridDAGRepository(HybridDAGRepositoryConfig{Store: store, WriteThrough: true, Logger: &logger})
+		_, err := replicas[i].Initialize(ctx)
+		require.NoError(t, err)
+	}
+
+	rename := func(title string) func(model.DAG) (model.DAG, error) {
+		return func(dag model.DAG) (model.DAG, error) {
+			dag.Title = title
+			dag.Revision++
+			return dag, nil
+		}
+	}
+	require.NoError(t, replicas[0].Update(ctx, testDAG.Id, rename("First")))
+
+	// The second replica still holds the DAG before the first update
+	err := replicas[1].Update(ctx, testDAG.Id, rename("Second"))
+	require.ErrorIs(t, err, usecase.ErrConflict)
+	assert.Equal(t, 0, replicas[1].SyncStatus().Dirty, "the conflicting change is not synced later")
+
+	reloaded, err := replicas[1].Get(ctx, testDAG.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "First", reloaded.Title)
+
+	stored, err := store.Get(ctx, testDAG.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "First", stored.Title)
+
+	// Once reloaded, the replica updates the latest DAG
+	require.NoError(t, replicas[1].Update(ctx, testDAG.Id, rename("Second")))
+	stored, err = store.Get(ctx, testDAG.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Second", stored.Title)
+}
+
 func TestHybridDAGRepository_Delete(t *testing.T) {
 	tempDir := t.TempDir()
 	logger := zerolog.Nop()
@@ -204,7 +364,7 @@ func TestHybridDAGRepository_Delete(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Create a DAG
@@ -239,7 +399,7 @@ func TestHybridDAGRepository_Sync(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Create several DAGs in memory only
@@ -276,7 +436,7 @@ func TestHybridDAGRepository_GetStats(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	err := repo.Initialize(ctx)
+	_, err := repo.Initialize(ctx)
 	require.NoError(t, err)
 
 	// Initial stats
@@ -332,3 +492,313 @@ func createTestDAGs(t *testing.T, count int) []*model.DAG {
 	}
 	return dags
 }
+
+func TestHybridDAGRepository_Watch(t *testing.T) {
+	tempDir := t.TempDir()
+	logger := zerolog.Nop()
+	repo := NewHybridDAGRepository(HybridDAGRepositoryConfig{
+		FilePath:     tempDir,
+		WriteThrough: true,
+		Logger:       &logger,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := repo.Watch(ctx)
+	require.NoError(t, err)
+
+	testDAG := createTestDAG(t)
+	require.NoError(t, repo.Create(ctx, testDAG))
+	require.NoError(t, repo.Delete(ctx, testDAG.Id))
+
+	for _, changeType := range []model.ChangeType{model.ChangeTypeCreated, model.ChangeTypeDeleted} {
+		select {
+		case event := <-events:
+			assert.Equal(t, changeType, event.Type)
+			assert.Equal(t, testDAG.Id, event.DAGId)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s event", changeType)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after context cancellation")
+	}
+}
+
+// cancelAfterContext reports cancellation once Err has been called more than allowed times
+type cancelAfterContext struct {
+	context.Context
+	allowed int
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.allowed <= 0 {
+		return context.Canceled
+	}
+	c.allowed--
+	return nil
+}
+
+func TestHybridDAGRepository_Sync_Cancelled(t *testing.T) {
+	tempDir := t.TempDir()
+	logger := zerolog.Nop()
+	repo := NewHybridDAGRepository(HybridDAGRepositoryConfig{
+		FilePath:     tempDir,
+		WriteThrough: false,
+		Logger:       &logger,
+	})
+
+	ctx := context.Background()
+	for _, testDAG := range createTestDAGs(t, 5) {
+		require.NoError(t, repo.Create(ctx, testDAG))
+	}
+
+	t.Run("stops mid-sync", func(t *testing.T) {
+		err := repo.Sync(&cancelAfterContext{Context: ctx, allowed: 2})
+		assert.ErrorIs(t, err, context.Canceled)
+
+		files, err := filepath.Glob(filepath.Join(tempDir, "*.json"))
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+
+	t.Run("does nothing once cancelled", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := repo.Sync(cancelledCtx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		files, err := filepath.Glob(filepath.Join(tempDir, "*.json"))
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+
+	t.Run("completes when not cancelled", func(t *testing.T) {
+		require.NoError(t, repo.Sync(ctx))
+
+		files, err := filepath.Glob(filepath.Join(tempDir, "*.json"))
+		require.NoError(t, err)
+		assert.Len(t, files, 5)
+	})
+}
+
+func TestHybridDAGRepository_Query(t *testing.T) {
+	logger := zerolog.Nop()
+	repo := NewHybridDAGRepository(HybridDAGRepositoryConfig{
+		FilePath:     t.TempDir(),
+		WriteThrough: false,
+		Logger:       &logger,
+	})
+
+	ctx := context.Background()
+	_, err := repo.Initialize(ctx)
+	require.NoError(t, err)
+
+	for _, title := range []string{"Employment dismissal", "Lease termination"} {
+		require.NoError(t, repo.Create(ctx, model.NewDAG(title)))
+	}
+
+	// DAGs are served from memory, even before being synced to files
+	page, err := repo.Query(ctx, model.DAGQuery{TitleContains: "lease"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	require.Len(t, page.DAGs, 1)
+	assert.Equal(t, "Lease termination", page.DAGs[0].Title)
+}
+
+func TestHybridDAGRepository_Search(t *testing.T) {
+	tempDir := t.TempDir()
+	logger := zerolog.Nop()
+	ctx := context.Background()
+
+	// DAGs already on disk are indexed on initialization
+	stored := createTestDAG(t)
+	require.NoError(t, NewFileDAGRepository(tempDir).Create(ctx, stored))
+
+	repo := NewHybridDAGRepository(HybridDAGRepositoryConfig{
+		FilePath:     tempDir,
+		WriteThrough: true,
+		Logger:       &logger,
+	})
+	_, err := repo.Initialize(ctx)
+	require.NoError(t, err)
+
+	hits, err := repo.Search(ctx, "test question", 0)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, stored.Id, hits[0].DAGId)
+
+	// Created DAGs are indexed
+	created := createTestDAG(t)
+	for id, node := range created.Nodes {
+		node.Question = "Was the lease terminated?"
+		created.Nodes[id] = node
+	}
+	require.NoError(t, repo.Create(ctx, created))
+
+	hits, err = repo.Search(ctx, "lease", 0)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, created.Id, hits[0].DAGId)
+
+	// Updated DAGs are reindexed
+	require.NoError(t, repo.Update(ctx, created.Id, func(d model.DAG) (model.DAG, error) {
+		nodes := make(map[uuid.UUID]model.Node, len(d.Nodes))
+		for id, node := range d.Nodes {
+			node.Question = "Was the contract renewed?"
+			nodes[id] = node
+		}
+		d.Nodes = nodes
+		return d, nil
+	}))
+
+	hits, err = repo.Search(ctx, "lease", 0)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+	hits, err = repo.Search(ctx, "renewed", 0)
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+
+	// Deleted DAGs are dropped from the index
+	require.NoError(t, repo.Delete(ctx, created.Id))
+	hits, err = repo.Search(ctx, "renewed", 0)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestHybridDAGRepository_SyncDirty(t *testing.T) {
+	ctx := context.Background()
+	newRepo := func(t *testing.T, writeThrough bool) (*HybridDAGRepository, string) {
+		tempDir := t.TempDir()
+		logger := zerolog.Nop()
+		return NewHybridDAGRepository(HybridDAGRepositoryConfig{
+			FilePath:     tempDir,
+			WriteThrough: writeThrough,
+			Logger:       &logger,
+		}), tempDir
+	}
+
+	t.Run("persists only the changed DAGs", func(t *testing.T) {
+		repo, tempDir := newRepo(t, false)
+		testDAGs := createTestDAGs(t, 3)
+		for _, testDAG := range testDAGs {
+			require.NoError(t, repo.Create(ctx, testDAG))
+		}
+
+		status := repo.SyncStatus()
+		assert.Equal(t, 3, status.Dirty)
+		require.NotNil(t, status.OldestDirtyAt)
+		assert.Nil(t, status.LastSyncAt)
+		assert.Positive(t, status.Lag(time.Now().Add(time.Second)))
+
+		synced, err := repo.SyncDirty(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, synced)
+		for _, testDAG := range testDAGs {
+			assert.FileExists(t, filepath.Join(tempDir, testDAG.Id.String()+".json"))
+		}
+
+		status = repo.SyncStatus()
+		assert.Equal(t, 0, status.Dirty)
+		assert.Nil(t, status.OldestDirtyAt)
+		assert.NotNil(t, status.LastSyncAt)
+		assert.Zero(t, status.Lag(time.Now()))
+
+		require.NoError(t, repo.Update(ctx, testDAGs[0].Id, func(dag model.DAG) (model.DAG, error) {
+			dag.Title = "Updated title"
+			return dag, nil
+		}))
+		synced, err = repo.SyncDirty(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, synced)
+
+		stored, err := repo.fileRepo.Get(ctx, testDAGs[0].Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated title", stored.Title)
+	})
+
+	t.Run("removes the files of deleted DAGs", func(t *testing.T) {
+		repo, tempDir := newRepo(t, false)
+		testDAG := createTestDAGs(t, 1)[0]
+		require.NoError(t, repo.Create(ctx, testDAG))
+		_, err := repo.SyncDirty(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, testDAG.Id))
+		assert.Equal(t, 1, repo.SyncStatus().Dirty)
+
+		synced, err := repo.SyncDirty(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, synced)
+		assert.NoFileExists(t, filepath.Join(tempDir, testDAG.Id.String()+".json"))
+	})
+
+	t.Run("full sync removes the files of deleted DAGs", func(t *testing.T) {
+		repo, tempDir := newRepo(t, false)
+		testDAGs := createTestDAGs(t, 2)
+		for _, testDAG := range testDAGs {
+			require.NoError(t, repo.Create(ctx, testDAG))
+		}
+		require.NoError(t, repo.Sync(ctx))
+		require.NoError(t, repo.Delete(ctx, testDAGs[0].Id))
+
+		require.NoError(t, repo.Sync(ctx))
+		assert.NoFileExists(t, filepath.Join(tempDir, testDAGs[0].Id.String()+".json"))
+		assert.FileExists(t, filepath.Join(tempDir, testDAGs[1].Id.String()+".json"))
+		assert.Equal(t, 0, repo.SyncStatus().Dirty)
+	})
+
+	t.Run("keeps the DAGs dirty when cancelled", func(t *testing.T) {
+		repo, _ := newRepo(t, false)
+		for _, testDAG := range createTestDAGs(t, 3) {
+			require.NoError(t, repo.Create(ctx, testDAG))
+		}
+		oldest := repo.SyncStatus().OldestDirtyAt
+
+		synced, err := repo.SyncDirty(&cancelAfterContext{Context: ctx, allowed: 1})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, synced)
+
+		status := repo.SyncStatus()
+		assert.Equal(t, 2, status.Dirty)
+		assert.False(t, status.OldestDirtyAt.Before(*oldest), "the DAGs keep the time of their change")
+	})
+
+	t.Run("tracks nothing with write-through", func(t *testing.T) {
+		repo, _ := newRepo(t, true)
+		require.NoError(t, repo.Create(ctx, createTestDAGs(t, 1)[0]))
+
+		assert.Equal(t, 0, repo.SyncStatus().Dirty)
+		synced, err := repo.SyncDirty(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, synced)
+	})
+}
+
+func TestHybridDAGRepository_RunSync(t *testing.T) {
+	tempDir := t.TempDir()
+	logger := zerolog.Nop()
+	repo := NewHybridDAGRepository(HybridDAGRepositoryConfig{
+		FilePath:     tempDir,
+		WriteThrough: false,
+		Logger:       &logger,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go repo.RunSync(ctx, 10*time.Millisecond)
+
+	testDAG := createTestDAGs(t, 1)[0]
+	require.NoError(t, repo.Create(context.Background(), testDAG))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(tempDir, testDAG.Id.String()+".json"))
+		return err == nil && repo.SyncStatus().Dirty == 0
+	}, 5*time.Second, 5*time.Millisecond)
+}
@@ -5,6 +5,7 @@ import (
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 type InMemoryDAGRepository struct {
 	dags map[uuid.UUID]*model.DAG
 	mu   sync.RWMutex // Protects concurrent access to the dags map
+	changeNotifier
 }
 
 // NewInMemoryDAGRepository creates a new instance of InMemoryDAGRepository
@@ -50,6 +52,7 @@ func (r *InMemoryDAGRepository) Create(ctx context.Context, dagObj *model.DAG) e
 	defer r.mu.Unlock()
 
 	r.dags[dagObj.Id] = dagObj
+	r.notify(model.ChangeTypeCreated, dagObj.Id)
 	return nil
 }
 
@@ -67,6 +70,7 @@ func (r *InMemoryDAGRepository) Delete(ctx context.Context, id uuid.UUID) error
 	}
 
 	delete(r.dags, id)
+	r.notify(model.ChangeTypeDeleted, id)
 	return nil
 }
 
@@ -83,6 +87,53 @@ func (r *InMemoryDAGRepository) List(ctx context.Context) ([]uuid.UUID, error) {
 	return ids, nil
 }
 
+// Query runs the query over the DAGs stored in memory, returning shallow copies of the stored DAGs
+func (r *InMemoryDAGRepository) Query(ctx context.Context, query model.DAGQuery) (*model.DAGPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	dags := make([]*model.DAG, 0, len(r.dags))
+	for _, dagObj := range r.dags {
+		if query.Matches(dagObj) {
+			dagCopy := *dagObj
+			dags = append(dags, &dagCopy)
+		}
+	}
+	r.mu.RUnlock()
+
+	return query.Apply(dags), nil
+}
+
+// Each yields the DAGs stored in memory in ID order, stopping on the first error returned by fn.
+// The DAGs are collected under the read lock and yielded once it is released, so fn may write to the repository.
+// As with Get, the yielded DAGs are the stored ones, sharing their nodes and answers: fn must not modify them.
+func (r *InMemoryDAGRepository) Each(ctx context.Context, fn func(dag *model.DAG) error) error {
+	r.mu.RLock()
+	dags := make([]*model.DAG, 0, len(r.dags))
+	for _, dagObj := range r.dags {
+		dags = append(dags, dagObj)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(dags, func(i, j int) bool {
+		return dags[i].Id.String() < dags[j].Id.String()
+	})
+
+	for _, dagObj := range dags {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := fn(dagObj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Update modifies an existing DAG in memory using the provided function
 func (r *InMemoryDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(dag model.DAG) (model.DAG, error)) error {
 	r.mu.Lock()
@@ -116,5 +167,16 @@ func (r *InMemoryDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpda
 
 	// Store the updated DAG
 	r.dags[id] = &updatedDAG
+	r.notify(model.ChangeTypeUpdated, id)
 	return nil
 }
+
+// Quarantine is not supported, the DAGs in memory are never corrupt
+func (r *InMemoryDAGRepository) Quarantine(id uuid.UUID) (string, error) {
+	return "", fmt.Errorf("%w: DAG %s is held in memory and cannot be quarantined", usecase.ErrInvalidCommand, id)
+}
+
+// Location identifies the DAG in memory, which does not outlive the process
+func (r *InMemoryDAGRepository) Location(id uuid.UUID) string {
+	return "memory:" + id.String()
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -262,3 +264,137 @@ func TestInMemoryDAGRepository_ConcurrentAccess(t *testing.T) {
 		}()
 	}
 }
+
+func TestInMemoryDAGRepository_Watch(t *testing.T) {
+	repo := NewInMemoryDAGRepository()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := repo.Watch(ctx)
+	require.NoError(t, err)
+
+	testDAG := model.NewDAG("Watched DAG")
+	require.NoError(t, repo.Create(ctx, testDAG))
+	require.NoError(t, repo.Update(ctx, testDAG.Id, func(d model.DAG) (model.DAG, error) {
+		d.Title = "Updated"
+		return d, nil
+	}))
+	require.NoError(t, repo.Delete(ctx, testDAG.Id))
+
+	expected := []model.ChangeType{model.ChangeTypeCreated, model.ChangeTypeUpdated, model.ChangeTypeDeleted}
+	for _, changeType := range expected {
+		select {
+		case event := <-events:
+			assert.Equal(t, changeType, event.Type)
+			assert.Equal(t, testDAG.Id, event.DAGId)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s event", changeType)
+		}
+	}
+
+	// Failed operations do not emit events
+	assert.Error(t, repo.Delete(ctx, testDAG.Id))
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "channel should be closed without further events")
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after context cancellation")
+	}
+}
+
+func TestInMemoryDAGRepository_Each(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryDAGRepository()
+
+	stored := map[uuid.UUID]bool{}
+	for i := 0; i < 3; i++ {
+		d := model.NewDAG(fmt.Sprintf("DAG %d", i))
+		require.NoError(t, repo.Create(ctx, d))
+		stored[d.Id] = true
+	}
+
+	t.Run("yields every DAG once", func(t *testing.T) {
+		seen := map[uuid.UUID]int{}
+		err := repo.Each(ctx, func(d *model.DAG) error {
+			seen[d.Id]++
+			// Writing from fn must not deadlock
+			return repo.Update(ctx, d.Id, func(existing model.DAG) (model.DAG, error) {
+				return existing, nil
+			})
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, seen, len(stored))
+		for id := range stored {
+			assert.Equal(t, 1, seen[id])
+		}
+	})
+
+	t.Run("stops on the first error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := repo.Each(ctx, func(d *model.DAG) error {
+			calls++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		calls := 0
+		err := repo.Each(cancelled, func(d *model.DAG) error {
+			calls++
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, calls)
+	})
+}
+
+func TestInMemoryDAGRepository_Query(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryDAGRepository()
+
+	for _, title := range []string{"Employment dismissal", "Lease termination", "Employment overtime"} {
+		require.NoError(t, repo.Create(ctx, model.NewDAG(title)))
+	}
+
+	t.Run("filters, sorts and paginates", func(t *testing.T) {
+		page, err := repo.Query(ctx, model.DAGQuery{
+			TitleContains: "employment",
+			Sort:          model.SortTitleDesc,
+			Page:          1,
+			PageSize:      1,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, page.Total)
+		require.Len(t, page.DAGs, 1)
+		assert.Equal(t, "Employment overtime", page.DAGs[0].Title)
+	})
+
+	t.Run("returns copies of the stored DAGs", func(t *testing.T) {
+		page, err := repo.Query(ctx, model.DAGQuery{TitleContains: "lease"})
+		require.NoError(t, err)
+		require.Len(t, page.DAGs, 1)
+
+		page.DAGs[0].Title = "Altered"
+		stored, err := repo.Get(ctx, page.DAGs[0].Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Lease termination", stored.Title)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := repo.Query(cancelled, model.DAGQuery{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
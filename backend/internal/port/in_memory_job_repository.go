@@ -0,0 +1,77 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// DefaultJobHistorySize is the number of jobs kept by default
+const DefaultJobHistorySize = 1000
+
+// InMemoryJobRepository keeps the latest jobs in memory. Jobs are polled shortly after they are enqueued,
+// they are lost on restart.
+type InMemoryJobRepository struct {
+	mu   sync.RWMutex
+	jobs map[uuid.UUID]model.Job
+	// order holds the job IDs, oldest first
+	order       []uuid.UUID
+	historySize int
+}
+
+// NewInMemoryJobRepository keeps historySize jobs, DefaultJobHistorySize when not positive
+func NewInMemoryJobRepository(historySize int) *InMemoryJobRepository {
+	if historySize <= 0 {
+		historySize = DefaultJobHistorySize
+	}
+
+	return &InMemoryJobRepository{
+		jobs:        make(map[uuid.UUID]model.Job),
+		historySize: historySize,
+	}
+}
+
+// Save replaces a known job, or adds it dropping the oldest finished job once the history is full
+func (r *InMemoryJobRepository) Save(ctx context.Context, job model.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.jobs[job.Id]; !ok {
+		r.order = append(r.order, job.Id)
+		r.evict()
+	}
+	r.jobs[job.Id] = job
+
+	return nil
+}
+
+func (r *InMemoryJobRepository) Get(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: job with id %s not found", usecase.ErrNotFound, id)
+	}
+
+	return &job, nil
+}
+
+// evict drops the oldest finished jobs beyond the history size, jobs in progress are kept
+func (r *InMemoryJobRepository) evict() {
+	excess := len(r.order) - r.historySize
+	kept := r.order[:0]
+	for _, id := range r.order {
+		if excess > 0 && r.jobs[id].Done() {
+			delete(r.jobs, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	r.order = kept
+}
@@ -0,0 +1,62 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryJobRepository(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("saves and replaces jobs", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewInMemoryJobRepository(0)
+		job := model.NewJob(model.JobTypeValidateDAG, uuid.New(), time.Now())
+		require.NoError(t, repo.Save(ctx, job))
+
+		job.Start(time.Now())
+		require.NoError(t, repo.Save(ctx, job))
+
+		stored, err := repo.Get(ctx, job.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.JobStatusRunning, stored.Status)
+	})
+
+	t.Run("returns not found for unknown jobs", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewInMemoryJobRepository(0).Get(ctx, uuid.New())
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+	})
+
+	t.Run("drops the oldest finished jobs once full", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewInMemoryJobRepository(2)
+		running := model.NewJob(model.JobTypeValidateDAG, uuid.New(), time.Now())
+		running.Start(time.Now())
+		finished := model.NewJob(model.JobTypeValidateDAG, uuid.New(), time.Now())
+		finished.Finish(nil, errors.New("failed"), time.Now())
+		latest := model.NewJob(model.JobTypeValidateDAG, uuid.New(), time.Now())
+		for _, job := range []model.Job{running, finished, latest} {
+			require.NoError(t, repo.Save(ctx, job))
+		}
+
+		_, err := repo.Get(ctx, finished.Id)
+		assert.ErrorIs(t, err, usecase.ErrNotFound)
+		for _, id := range []uuid.UUID{running.Id, latest.Id} {
+			_, err := repo.Get(ctx, id)
+			assert.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// DefaultDeliveryHistorySize is the number of deliveries kept per webhook by default
+const DefaultDeliveryHistorySize = 100
+
+// InMemoryWebhookDeliveryRepository keeps the latest deliveries of each webhook in memory.
+// The delivery history is a troubleshooting aid, it is lost on restart.
+type InMemoryWebhookDeliveryRepository struct {
+	mu sync.RWMutex
+	// deliveries holds the deliveries of each webhook, oldest first
+	deliveries  map[uuid.UUID][]model.WebhookDelivery
+	historySize int
+}
+
+// NewInMemoryWebhookDeliveryRepository keeps historySize deliveries per webhook, DefaultDeliveryHistorySize when not positive
+func NewInMemoryWebhookDeliveryRepository(historySize int) *InMemoryWebhookDeliveryRepository {
+	if historySize <= 0 {
+		historySize = DefaultDeliveryHistorySize
+	}
+
+	return &InMemoryWebhookDeliveryRepository{
+		deliveries:  make(map[uuid.UUID][]model.WebhookDelivery),
+		historySize: historySize,
+	}
+}
+
+// Save replaces a known delivery, or appends it dropping the oldest delivery once the history is full
+func (r *InMemoryWebhookDeliveryRepository) Save(ctx context.Context, delivery model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := r.deliveries[delivery.WebhookId]
+	for i := range deliveries {
+		if deliveries[i].Id == delivery.Id {
+			deliveries[i] = delivery
+			return nil
+		}
+	}
+
+	deliveries = append(deliveries, delivery)
+	if len(deliveries) > r.historySize {
+		deliveries = deliveries[len(deliveries)-r.historySize:]
+	}
+	r.deliveries[delivery.WebhookId] = deliveries
+
+	return nil
+}
+
+// List returns the latest deliveries of a webhook, most recent first
+func (r *InMemoryWebhookDeliveryRepository) List(ctx context.Context, webhookId uuid.UUID, limit int) ([]model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := r.deliveries[webhookId]
+	count := len(deliveries)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+
+	latest := make([]model.WebhookDelivery, 0, count)
+	for i := len(deliveries) - 1; i >= len(deliveries)-count; i-- {
+		latest = append(latest, deliveries[i])
+	}
+
+	return latest, nil
+}
+
+// DeleteAll drops the deliveries of a webhook
+func (r *InMemoryWebhookDeliveryRepository) DeleteAll(ctx context.Context, webhookId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.deliveries, webhookId)
+	return nil
+}
+
+// Pending returns the pending deliveries of every webhook, oldest first
+func (r *InMemoryWebhookDeliveryRepository) Pending(ctx context.Context) ([]model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var pending []model.WebhookDelivery
+	for _, deliveries := range r.deliveries {
+		pending = appendPending(pending, deliveries)
+	}
+	sortDeliveries(pending)
+
+	return pending, nil
+}
+
+func appendPending(pending []model.WebhookDelivery, deliveries []model.WebhookDelivery) []model.WebhookDelivery {
+	for _, delivery := range deliveries {
+		if delivery.Status == model.DeliveryStatusPending {
+			pending = append(pending, delivery)
+		}
+	}
+	return pending
+}
+
+// sortDeliveries orders deliveries by creation date, oldest first
+func sortDeliveries(deliveries []model.WebhookDelivery) {
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].Id.String() < deliveries[j].Id.String()
+	})
+}
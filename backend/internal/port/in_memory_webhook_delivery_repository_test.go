@@ -0,0 +1,54 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryWebhookDeliveryRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	webhookId := uuid.New()
+	event := model.NewEvent(model.EventDAGCreated, uuid.New(), now)
+
+	repo := NewInMemoryWebhookDeliveryRepository(3)
+	var deliveries []model.WebhookDelivery
+	for i := 0; i < 4; i++ {
+		delivery := model.NewWebhookDelivery(webhookId, event, now.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, repo.Save(ctx, delivery))
+		deliveries = append(deliveries, delivery)
+	}
+
+	// Saving a known delivery replaces it
+	deliveries[3].Status = model.DeliveryStatusSucceeded
+	require.NoError(t, repo.Save(ctx, deliveries[3]))
+
+	latest, err := repo.List(ctx, webhookId, 0)
+	require.NoError(t, err)
+	require.Len(t, latest, 3, "the oldest delivery is dropped")
+	assert.Equal(t, deliveries[3], latest[0])
+	assert.Equal(t, deliveries[1].Id, latest[2].Id)
+
+	limited, err := repo.List(ctx, webhookId, 2)
+	require.NoError(t, err)
+	assert.Len(t, limited, 2)
+
+	pending, err := repo.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, deliveries[1].Id, pending[0].Id)
+	assert.Equal(t, deliveries[2].Id, pending[1].Id)
+
+	require.NoError(t, repo.DeleteAll(ctx, webhookId))
+	latest, err = repo.List(ctx, webhookId, 0)
+	require.NoError(t, err)
+	assert.Empty(t, latest)
+}
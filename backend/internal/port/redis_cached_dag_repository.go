@@ -0,0 +1,272 @@
+package port
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"davidterranova/jurigen/backend/pkg/xredis"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// redisKeyPrefix namespaces the cache entries and the invalidation channel in the Redis database
+const redisKeyPrefix = "jurigen:"
+
+// resubscribeDelay is the wait before subscribing again to the invalidations after the connection was lost
+const resubscribeDelay = time.Second
+
+// DAGRefresher reloads a DAG from its storage, e.g. after another server changed it
+type DAGRefresher interface {
+	Refresh(ctx context.Context, id uuid.UUID) error
+}
+
+// RedisCachedDAGRepository caches the DAGs and the DAG listing of a repository in Redis, shared by the servers.
+// Each write deletes the entries it outdates and publishes an invalidation, on which the other servers refresh
+// the DAG in the repository they wrap, when it implements DAGRefresher, and notify their watchers.
+// Each write also records the revision it wrote, a server still holding an older revision of the DAG does not
+// cache it. Redis failures are logged and the reads fall back to the wrapped repository.
+type RedisCachedDAGRepository struct {
+	usecase.DAGRepository
+	client *xredis.Client
+	ttl    time.Duration
+	logger zerolog.Logger
+	// replica identifies this server in the invalidations, so that it ignores its own
+	replica uuid.UUID
+	changeNotifier
+}
+
+// cacheInvalidation is published on every write
+type cacheInvalidation struct {
+	Replica uuid.UUID        `json:"replica"`
+	Type    model.ChangeType `json:"type"`
+	DAGId   uuid.UUID        `json:"dag_id"`
+}
+
+// NewRedisCachedDAGRepository caches the reads of repository for ttl, Run must be started to receive the
+// invalidations of the other servers
+func NewRedisCachedDAGRepository(repository usecase.DAGRepository, client *xredis.Client, ttl time.Duration, logger zerolog.Logger) *RedisCachedDAGRepository {
+	return &RedisCachedDAGRepository{
+		DAGRepository: repository,
+		client:        client,
+		ttl:           ttl,
+		logger:        logger,
+		replica:       uuid.New(),
+	}
+}
+
+func (r *RedisCachedDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG, error) {
+	data, err := r.client.Get(ctx, dagCacheKey(id))
+	if err == nil {
+		dagObj := model.NewDAG("Untitled DAG")
+		if err := dagObj.UnmarshalJSON(data); err == nil {
+			return dagObj, nil
+		}
+	} else if !errors.Is(err, xredis.ErrNil) {
+		xlog.With(ctx, r.logger).Warn().Err(err).Str("dag_id", id.String()).Msg("Failed to read DAG from cache")
+	}
+
+	dagObj, err := r.DAGRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.fill(ctx, dagObj)
+	return dagObj, nil
+}
+
+// fill caches a DAG read from the wrapped repository unless another server wrote a later revision of it. The
+// revision is checked again once cached, a write recording its revision meanwhile may have missed the entry
+// when deleting it.
+func (r *RedisCachedDAGRepository) fill(ctx context.Context, dagObj *model.DAG) {
+	if !r.current(ctx, dagObj) {
+		return
+	}
+
+	data, err := dagObj.MarshalJSON()
+	if err != nil {
+		return
+	}
+	if err := r.client.Set(ctx, dagCacheKey(dagObj.Id), data, r.ttl); err != nil {
+		xlog.With(ctx, r.logger).Warn().Err(err).Str("dag_id", dagObj.Id.String()).Msg("Failed to cache DAG")
+		return
+	}
+
+	if !r.current(ctx, dagObj) {
+		if err := r.client.Del(ctx, dagCacheKey(dagObj.Id)); err != nil {
+			xlog.With(ctx, r.logger).Error().Err(err).Str("dag_id", dagObj.Id.String()).Msg("Failed to delete stale cached DAG, it is served stale until it expires")
+		}
+	}
+}
+
+// current reports whether a DAG is at the latest revision written through the cache, or at a later one. DAGs
+// without recorded revision are current, DAGs deleted meanwhile are not.
+func (r *RedisCachedDAGRepository) current(ctx context.Context, dagObj *model.DAG) bool {
+	data, err := r.client.Get(ctx, revisionKey(dagObj.Id))
+	if errors.Is(err, xredis.ErrNil) {
+		return true
+	}
+	if err != nil {
+		xlog.With(ctx, r.logger).Warn().Err(err).Str("dag_id", dagObj.Id.String()).Msg("Failed to read DAG revision from cache")
+		return false
+	}
+
+	revision, err := strconv.ParseUint(string(data), 10, 64)
+	return err == nil && dagObj.Revision >= revision
+}
+
+func (r *RedisCachedDAGRepository) List(ctx context.Context) ([]uuid.UUID, error) {
+	data, err := r.client.Get(ctx, listCacheKey)
+	if err == nil {
+		var ids []uuid.UUID
+		if err := json.Unmarshal(data, &ids); err == nil {
+			return ids, nil
+		}
+	} else if !errors.Is(err, xredis.ErrNil) {
+		xlog.With(ctx, r.logger).Warn().Err(err).Msg("Failed to read DAG list from cache")
+	}
+
+	ids, err := r.DAGRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(ids); err == nil {
+		if err := r.client.Set(ctx, listCacheKey, data, r.ttl); err != nil {
+			xlog.With(ctx, r.logger).Warn().Err(err).Msg("Failed to cache DAG list")
+		}
+	}
+	return ids, nil
+}
+
+func (r *RedisCachedDAGRepository) Create(ctx context.Context, dagObj *model.DAG) error {
+	if err := r.DAGRepository.Create(ctx, dagObj); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, model.ChangeTypeCreated, dagObj.Id, strconv.FormatUint(dagObj.Revision, 10))
+	return nil
+}
+
+func (r *RedisCachedDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(dag model.DAG) (model.DAG, error)) error {
+	var revision uint64
+	err := r.DAGRepository.Update(ctx, id, func(dag model.DAG) (model.DAG, error) {
+		updated, err := fnUpdate(dag)
+		revision = updated.Revision
+		return updated, err
+	})
+	if err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, model.ChangeTypeUpdated, id, strconv.FormatUint(revision, 10))
+	return nil
+}
+
+func (r *RedisCachedDAGRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.DAGRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, model.ChangeTypeDeleted, id, revisionDeleted)
+	return nil
+}
+
+// Watch streams the changes made through this server and the other servers sharing the cache
+func (r *RedisCachedDAGRepository) Watch(ctx context.Context) (<-chan model.ChangeEvent, error) {
+	return r.changeNotifier.Watch(ctx)
+}
+
+// Observe calls fn on every change made through this server and the other servers sharing the cache
+func (r *RedisCachedDAGRepository) Observe(fn func(model.ChangeEvent)) (stop func()) {
+	return r.changeNotifier.Observe(fn)
+}
+
+// Run receives the invalidations published by the other servers until ctx is cancelled, subscribing again
+// whenever the connection is lost
+func (r *RedisCachedDAGRepository) Run(ctx context.Context) {
+	for {
+		messages, err := r.client.Subscribe(ctx, invalidationChannel)
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to subscribe to DAG cache invalidations")
+		} else {
+			for message := range messages {
+				r.receive(ctx, message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+// invalidate records the revision written, deletes the cache entries it outdates and tells the other servers
+// about it. The revision is recorded first, so that the entries cached meanwhile are either deleted or checked
+// against it.
+func (r *RedisCachedDAGRepository) invalidate(ctx context.Context, changeType model.ChangeType, id uuid.UUID, revision string) {
+	r.notify(changeType, id)
+
+	if err := r.client.Set(ctx, revisionKey(id), []byte(revision), 0); err != nil {
+		xlog.With(ctx, r.logger).Error().Err(err).Str("dag_id", id.String()).Msg("Failed to record DAG revision in cache")
+	}
+
+	if err := r.client.Del(ctx, dagCacheKey(id), listCacheKey); err != nil {
+		xlog.With(ctx, r.logger).Error().Err(err).Str("dag_id", id.String()).Msg("Failed to invalidate cached DAG, it is served stale until it expires")
+	}
+
+	message, err := json.Marshal(cacheInvalidation{Replica: r.replica, Type: changeType, DAGId: id})
+	if err != nil {
+		return
+	}
+	if err := r.client.Publish(ctx, invalidationChannel, message); err != nil {
+		xlog.With(ctx, r.logger).Error().Err(err).Str("dag_id", id.String()).Msg("Failed to publish DAG cache invalidation")
+	}
+}
+
+// receive applies the invalidation published by another server
+func (r *RedisCachedDAGRepository) receive(ctx context.Context, message []byte) {
+	var invalidation cacheInvalidation
+	if err := json.Unmarshal(message, &invalidation); err != nil {
+		r.logger.Warn().Err(err).Msg("Ignoring malformed DAG cache invalidation")
+		return
+	}
+	if invalidation.Replica == r.replica {
+		return
+	}
+
+	if refresher, ok := r.DAGRepository.(DAGRefresher); ok {
+		if err := refresher.Refresh(ctx, invalidation.DAGId); err != nil {
+			r.logger.Warn().Err(err).Str("dag_id", invalidation.DAGId.String()).Msg("Failed to refresh DAG changed by another server")
+		}
+	}
+	// The DAG may have been cached from this server before it was refreshed
+	if err := r.client.Del(ctx, dagCacheKey(invalidation.DAGId)); err != nil {
+		r.logger.Error().Err(err).Str("dag_id", invalidation.DAGId.String()).Msg("Failed to invalidate cached DAG, it is served stale until it expires")
+	}
+	r.notify(invalidation.Type, invalidation.DAGId)
+}
+
+const (
+	listCacheKey        = redisKeyPrefix + "dags"
+	invalidationChannel = redisKeyPrefix + "invalidations"
+)
+
+// revisionDeleted is recorded as the revision of the deleted DAGs, no copy of them is current
+const revisionDeleted = "deleted"
+
+func dagCacheKey(id uuid.UUID) string {
+	return redisKeyPrefix + "dag:" + id.String()
+}
+
+// revisionKey holds the latest revision of a DAG written through the cache, it does not expire
+func revisionKey(id uuid.UUID) string {
+	return redisKeyPrefix + "revision:" + id.String()
+}
@@ -0,0 +1,301 @@
+package port
+
+import (
+	"bufio"
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xredis"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves the commands of the cache in memory over the RESP protocol
+type fakeRedis struct {
+	mu          sync.Mutex
+	values      map[string]string
+	subscribers map[string][]net.Conn
+	listener    net.Listener
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	fake := &fakeRedis{values: make(map[string]string), subscribers: make(map[string][]net.Conn), listener: listener}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+			go fake.serve(conn)
+		}
+	}()
+	return fake
+}
+
+func (f *fakeRedis) client() *xredis.Client {
+	return xredis.New(f.listener.Addr().String(), xredis.Options{})
+}
+
+func (f *fakeRedis) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.values))
+	for key := range f.values {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (f *fakeRedis) subscribed(channel string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subscribers[channel])
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		var reply string
+		switch args[0] {
+		case "GET":
+			value, ok := f.values[args[1]]
+			reply = "$-1\r\n"
+			if ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			}
+		case "SET":
+			f.values[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case "DEL":
+			for _, key := range args[1:] {
+				delete(f.values, key)
+			}
+			reply = fmt.Sprintf(":%d\r\n", len(args)-1)
+		case "PUBLISH":
+			message := fmt.Sprintf("*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(args[1]), args[1], len(args[2]), args[2])
+			for _, subscriber := range f.subscribers[args[1]] {
+				_, _ = io.WriteString(subscriber, message)
+			}
+			reply = fmt.Sprintf(":%d\r\n", len(f.subscribers[args[1]]))
+		case "SUBSCRIBE":
+			f.subscribers[args[1]] = append(f.subscribers[args[1]], conn)
+			reply = fmt.Sprintf("*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(args[1]), args[1])
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		_, err = io.WriteString(conn, reply)
+		f.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(line[1 : len(line)-2])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(line[1 : len(line)-2])
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+// countingDAGRepository counts the reads reaching the repository
+type countingDAGRepository struct {
+	*InMemoryDAGRepository
+	gets atomic.Int32
+}
+
+func (r *countingDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG, error) {
+	r.gets.Add(1)
+	return r.InMemoryDAGRepository.Get(ctx, id)
+}
+
+func TestRedisCachedDAGRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves the reads from the cache until a write", func(t *testing.T) {
+		fake := newFakeRedis(t)
+		inner := &countingDAGRepository{InMemoryDAGRepository: NewInMemoryDAGRepository()}
+		repo := NewRedisCachedDAGRepository(inner, fake.client(), time.Minute, zerolog.Nop())
+
+		testDAG := createTestDAG(t)
+		require.NoError(t, repo.Create(ctx, testDAG))
+
+		for i := 0; i < 3; i++ {
+			stored, err := repo.Get(ctx, testDAG.Id)
+			require.NoError(t, err)
+			assert.Equal(t, testDAG.Title, stored.Title)
+		}
+		assert.Equal(t, int32(1), inner.gets.Load())
+
+		require.NoError(t, repo.Update(ctx, testDAG.Id, func(dag model.DAG) (model.DAG, error) {
+			dag.Title = "Updated"
+			return dag, nil
+		}))
+		stored, err := repo.Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated", stored.Title)
+
+		// The listing is cached, then outdated by the creation
+		other := createTestDAG(t)
+		_, err = repo.List(ctx)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, other))
+		ids, err := repo.List(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{testDAG.Id, other.Id}, ids)
+	})
+
+	t.Run("falls back to the repository when Redis is unavailable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		require.NoError(t, listener.Close())
+
+		inner := NewInMemoryDAGRepository()
+		repo := NewRedisCachedDAGRepository(inner, xredis.New(listener.Addr().String(), xredis.Options{}), time.Minute, zerolog.Nop())
+
+		testDAG := createTestDAG(t)
+		require.NoError(t, repo.Create(ctx, testDAG))
+		stored, err := repo.Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		assert.Equal(t, testDAG.Id, stored.Id)
+	})
+
+	t.Run("refreshes the DAGs changed by another server", func(t *testing.T) {
+		fake := newFakeRedis(t)
+		dir := t.TempDir()
+		testDAG := createTestDAG(t)
+		require.NoError(t, NewFileDAGRepository(dir).Create(ctx, testDAG))
+
+		newServer := func() (*HybridDAGRepository, *RedisCachedDAGRepository) {
+			logger := zerolog.Nop()
+			hybrid := NewHybridDAGRepository(HybridDAGRepositoryConfig{FilePath: dir, WriteThrough: true, Logger: &logger})
+			_, err := hybrid.Initialize(ctx)
+			require.NoError(t, err)
+			return hybrid, NewRedisCachedDAGRepository(hybrid, fake.client(), time.Minute, logger)
+		}
+		hybridA, cacheA := newServer()
+		_, cacheB := newServer()
+
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go cacheA.Run(runCtx)
+		go cacheB.Run(runCtx)
+		require.Eventually(t, func() bool { return fake.subscribed(invalidationChannel) == 2 }, time.Second, 10*time.Millisecond)
+
+		events, err := cacheA.Watch(runCtx)
+		require.NoError(t, err)
+
+		require.NoError(t, cacheB.Update(ctx, testDAG.Id, func(dag model.DAG) (model.DAG, error) {
+			dag.Title = "Changed by B"
+			return dag, nil
+		}))
+
+		select {
+		case event := <-events:
+			assert.Equal(t, model.ChangeEvent{Type: model.ChangeTypeUpdated, DAGId: testDAG.Id}, event)
+		case <-time.After(time.Second):
+			t.Fatal("the change of the other server is not notified")
+		}
+
+		inMemory, err := hybridA.Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Changed by B", inMemory.Title)
+	})
+	t.Run("does not cache the DAGs of a server missing a later revision", func(t *testing.T) {
+		fake := newFakeRedis(t)
+		dir := t.TempDir()
+		testDAG := createTestDAG(t)
+		require.NoError(t, NewFileDAGRepository(dir).Create(ctx, testDAG))
+
+		newServer := func() *RedisCachedDAGRepository {
+			logger := zerolog.Nop()
+			hybrid := NewHybridDAGRepository(HybridDAGRepositoryConfig{FilePath: dir, WriteThrough: true, Logger: &logger})
+			_, err := hybrid.Initialize(ctx)
+			require.NoError(t, err)
+			return NewRedisCachedDAGRepository(hybrid, fake.client(), time.Minute, logger)
+		}
+		// The invalidations are not received, as when published while B was disconnected
+		cacheA, cacheB := newServer(), newServer()
+
+		require.NoError(t, cacheA.Update(ctx, testDAG.Id, func(dag model.DAG) (model.DAG, error) {
+			dag.Title = "Changed by A"
+			dag.Revision++
+			return dag, nil
+		}))
+
+		stale, err := cacheB.Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		assert.Equal(t, testDAG.Title, stale.Title)
+		assert.NotContains(t, fake.keys(), dagCacheKey(testDAG.Id))
+
+		latest, err := cacheA.Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Changed by A", latest.Title)
+		assert.Contains(t, fake.keys(), dagCacheKey(testDAG.Id))
+
+		// Deleted DAGs are not cached again
+		require.NoError(t, cacheA.Delete(ctx, testDAG.Id))
+		_, err = cacheB.Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		assert.NotContains(t, fake.keys(), dagCacheKey(testDAG.Id))
+	})
+
+	t.Run("deletes the cached DAG once refreshed", func(t *testing.T) {
+		fake := newFakeRedis(t)
+		repo := NewRedisCachedDAGRepository(NewInMemoryDAGRepository(), fake.client(), time.Minute, zerolog.Nop())
+		testDAG := createTestDAG(t)
+		require.NoError(t, repo.Create(ctx, testDAG))
+		_, err := repo.Get(ctx, testDAG.Id)
+		require.NoError(t, err)
+		require.Contains(t, fake.keys(), dagCacheKey(testDAG.Id))
+
+		message, err := json.Marshal(cacheInvalidation{Replica: uuid.New(), Type: model.ChangeTypeUpdated, DAGId: testDAG.Id})
+		require.NoError(t, err)
+		repo.receive(ctx, message)
+		assert.NotContains(t, fake.keys(), dagCacheKey(testDAG.Id))
+	})
+}
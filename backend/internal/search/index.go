@@ -0,0 +1,274 @@
+// Package search indexes the texts of DAG nodes for full-text search.
+// The index is held in memory and updated one DAG at a time, so that repositories keep it in sync on every write.
+package search
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// snippetRadius is the number of characters kept on each side of a match in snippets
+const snippetRadius = 40
+
+// document is a single indexed text of a node
+type document struct {
+	dagId    uuid.UUID
+	nodeId   uuid.UUID
+	answerId *uuid.UUID
+	field    string
+	text     string
+}
+
+// Index is an inverted index from terms to the node texts holding them, safe for concurrent use
+type Index struct {
+	mu sync.RWMutex
+	// postings maps each term to the documents holding it
+	postings map[string]map[*document]struct{}
+	// dagDocuments lists the documents of each DAG, to drop them when the DAG changes
+	dagDocuments map[uuid.UUID][]*document
+}
+
+func NewIndex() *Index {
+	return &Index{
+		postings:     make(map[string]map[*document]struct{}),
+		dagDocuments: make(map[uuid.UUID][]*document),
+	}
+}
+
+// Add indexes the questions, answer statements and user contexts of a DAG, replacing its previous entries.
+// Soft-deleted DAGs are not searchable, adding one only drops its entries.
+func (i *Index) Add(d *model.DAG) {
+	documents := dagDocuments(d)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(d.Id)
+	for _, doc := range documents {
+		for _, term := range uniqueTerms(doc.text) {
+			docs, ok := i.postings[term]
+			if !ok {
+				docs = make(map[*document]struct{})
+				i.postings[term] = docs
+			}
+			docs[doc] = struct{}{}
+		}
+	}
+	if len(documents) > 0 {
+		i.dagDocuments[d.Id] = documents
+	}
+}
+
+// Remove drops the entries of a DAG, removing a DAG never indexed is a no-op
+func (i *Index) Remove(dagId uuid.UUID) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(dagId)
+}
+
+func (i *Index) remove(dagId uuid.UUID) {
+	for _, doc := range i.dagDocuments[dagId] {
+		for _, term := range uniqueTerms(doc.text) {
+			delete(i.postings[term], doc)
+			if len(i.postings[term]) == 0 {
+				delete(i.postings, term)
+			}
+		}
+	}
+	delete(i.dagDocuments, dagId)
+}
+
+// Search returns the node texts holding every term of the query, each query term matching the
+// indexed terms it prefixes. Hits are ordered by decreasing score, limit zero returns every hit.
+func (i *Index) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	queryTerms := uniqueTerms(query)
+	if len(queryTerms) == 0 {
+		return []model.SearchHit{}, nil
+	}
+
+	i.mu.RLock()
+	var matches map[*document]struct{}
+	for _, queryTerm := range queryTerms {
+		if err := ctx.Err(); err != nil {
+			i.mu.RUnlock()
+			return nil, err
+		}
+
+		termMatches := make(map[*document]struct{})
+		for term, docs := range i.postings {
+			if !strings.HasPrefix(term, queryTerm) {
+				continue
+			}
+			for doc := range docs {
+				if _, ok := matches[doc]; matches == nil || ok {
+					termMatches[doc] = struct{}{}
+				}
+			}
+		}
+		matches = termMatches
+	}
+	i.mu.RUnlock()
+
+	// Documents are never modified once indexed, hits are built without holding the lock
+	hits := make([]model.SearchHit, 0, len(matches))
+	for doc := range matches {
+		hits = append(hits, model.SearchHit{
+			DAGId:    doc.dagId,
+			NodeId:   doc.nodeId,
+			AnswerId: doc.answerId,
+			Field:    doc.field,
+			Snippet:  snippet(doc.text, queryTerms[0]),
+			Score:    score(doc.text, queryTerms),
+		})
+	}
+
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		if hits[a].DAGId != hits[b].DAGId {
+			return hits[a].DAGId.String() < hits[b].DAGId.String()
+		}
+		if hits[a].NodeId != hits[b].NodeId {
+			return hits[a].NodeId.String() < hits[b].NodeId.String()
+		}
+		return hitKey(hits[a]) < hitKey(hits[b])
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// hitKey orders the hits of a node, questions first
+func hitKey(hit model.SearchHit) string {
+	if hit.AnswerId == nil {
+		return hit.Field
+	}
+	return hit.AnswerId.String() + hit.Field
+}
+
+func dagDocuments(d *model.DAG) []*document {
+	if d.IsDeleted() {
+		return nil
+	}
+
+	var documents []*document
+	for _, node := range d.Nodes {
+		if strings.TrimSpace(node.Question) != "" {
+			documents = append(documents, &document{
+				dagId:  d.Id,
+				nodeId: node.Id,
+				field:  model.SearchFieldQuestion,
+				text:   node.Question,
+			})
+		}
+
+		for _, answer := range node.Answers {
+			answerId := answer.Id
+			if strings.TrimSpace(answer.Statement) != "" {
+				documents = append(documents, &document{
+					dagId:    d.Id,
+					nodeId:   node.Id,
+					answerId: &answerId,
+					field:    model.SearchFieldAnswer,
+					text:     answer.Statement,
+				})
+			}
+			if strings.TrimSpace(answer.UserContext) != "" {
+				documents = append(documents, &document{
+					dagId:    d.Id,
+					nodeId:   node.Id,
+					answerId: &answerId,
+					field:    model.SearchFieldUserContext,
+					text:     answer.UserContext,
+				})
+			}
+		}
+	}
+	return documents
+}
+
+// terms splits a text into lower case words made of letters and digits
+func terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func uniqueTerms(text string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, term := range terms(text) {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
+
+// score counts the terms of text prefixed by a query term
+func score(text string, queryTerms []string) int {
+	count := 0
+	for _, term := range terms(text) {
+		for _, queryTerm := range queryTerms {
+			if strings.HasPrefix(term, queryTerm) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// snippet returns the text around the first occurrence of term, trimmed with ellipses
+func snippet(text, term string) string {
+	runes := []rune(text)
+	lowered := make([]rune, len(runes))
+	for i, r := range runes {
+		lowered[i] = unicode.ToLower(r)
+	}
+
+	start := indexRunes(lowered, []rune(term))
+	if start < 0 {
+		start = 0
+	}
+
+	from := max(start-snippetRadius, 0)
+	to := min(start+len([]rune(term))+snippetRadius, len(runes))
+
+	result := strings.TrimSpace(string(runes[from:to]))
+	if from > 0 {
+		result = "…" + result
+	}
+	if to < len(runes) {
+		result += "…"
+	}
+	return result
+}
+
+func indexRunes(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j, r := range needle {
+			if haystack[i+j] != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,158 @@
+package search
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func employmentDAG() *model.DAG {
+	answerYes := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	answerNo := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	nodeId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	d := model.NewDAG("Employment")
+	d.Id = uuid.MustParse("10000000-0000-0000-0000-000000000000")
+	d.Nodes[nodeId] = model.Node{
+		Id:       nodeId,
+		Question: "Were you dismissed by your employer?",
+		Answers: []model.Answer{
+			{Id: answerYes, Statement: "Yes, without notice", UserContext: "Dismissal happened during the probation period"},
+			{Id: answerNo, Statement: "No"},
+		},
+	}
+	return d
+}
+
+func leaseDAG() *model.DAG {
+	nodeId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	d := model.NewDAG("Lease")
+	d.Id = uuid.MustParse("20000000-0000-0000-0000-000000000000")
+	d.Nodes[nodeId] = model.Node{
+		Id:       nodeId,
+		Question: "Did the landlord give notice before ending the lease?",
+		Answers:  []model.Answer{{Id: uuid.New(), Statement: "Notice was given, notice was late"}},
+	}
+	return d
+}
+
+func TestIndex_Search(t *testing.T) {
+	ctx := context.Background()
+	index := NewIndex()
+	index.Add(employmentDAG())
+	index.Add(leaseDAG())
+
+	t.Run("matches questions by term prefix, ignoring case", func(t *testing.T) {
+		hits, err := index.Search(ctx, "EMPLOY", 0)
+		require.NoError(t, err)
+
+		require.Len(t, hits, 1)
+		assert.Equal(t, employmentDAG().Id, hits[0].DAGId)
+		assert.Equal(t, model.SearchFieldQuestion, hits[0].Field)
+		assert.Nil(t, hits[0].AnswerId)
+		assert.Equal(t, "Were you dismissed by your employer?", hits[0].Snippet)
+	})
+
+	t.Run("matches answers and user contexts", func(t *testing.T) {
+		hits, err := index.Search(ctx, "probation", 0)
+		require.NoError(t, err)
+
+		require.Len(t, hits, 1)
+		assert.Equal(t, model.SearchFieldUserContext, hits[0].Field)
+		require.NotNil(t, hits[0].AnswerId)
+		assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-00000000000a"), *hits[0].AnswerId)
+	})
+
+	t.Run("requires every term and orders by score", func(t *testing.T) {
+		hits, err := index.Search(ctx, "notice", 0)
+		require.NoError(t, err)
+
+		require.Len(t, hits, 3)
+		assert.Equal(t, 2, hits[0].Score)
+		assert.Equal(t, "Notice was given, notice was late", hits[0].Snippet)
+
+		hits, err = index.Search(ctx, "notice landlord", 0)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, leaseDAG().Id, hits[0].DAGId)
+	})
+
+	t.Run("limits the hits", func(t *testing.T) {
+		hits, err := index.Search(ctx, "notice", 2)
+		require.NoError(t, err)
+		assert.Len(t, hits, 2)
+	})
+
+	t.Run("returns no hit for a query without terms", func(t *testing.T) {
+		hits, err := index.Search(ctx, " ?! ", 0)
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := index.Search(cancelled, "notice", 0)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestIndex_Updates(t *testing.T) {
+	ctx := context.Background()
+	index := NewIndex()
+
+	d := employmentDAG()
+	index.Add(d)
+
+	// Re-adding a DAG replaces its entries
+	node := d.Nodes[uuid.MustParse("00000000-0000-0000-0000-000000000001")]
+	node.Question = "Was your contract terminated?"
+	d.Nodes[node.Id] = node
+	index.Add(d)
+
+	hits, err := index.Search(ctx, "dismissed", 0)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = index.Search(ctx, "terminated", 0)
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+
+	// Soft-deleted DAGs drop out of the index
+	deletedAt := time.Now()
+	d.DeletedAt = &deletedAt
+	index.Add(d)
+	hits, err = index.Search(ctx, "terminated", 0)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+	d.DeletedAt = nil
+	index.Add(d)
+
+	index.Remove(d.Id)
+	hits, err = index.Search(ctx, "terminated", 0)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+	assert.Empty(t, index.postings)
+
+	// Removing a DAG never indexed is a no-op
+	index.Remove(uuid.New())
+}
+
+func TestSnippet(t *testing.T) {
+	text := strings.Repeat("a ", 40) + "Termination clause" + strings.Repeat(" b", 40)
+
+	result := snippet(text, "termination")
+
+	assert.True(t, strings.HasPrefix(result, "…"))
+	assert.True(t, strings.HasSuffix(result, "…"))
+	assert.Contains(t, result, "Termination clause")
+	assert.Equal(t, "Short text", snippet("Short text", "short"))
+}
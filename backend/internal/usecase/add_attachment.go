@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdAddAttachment struct {
+	SessionId string `validate:"required,uuid"`
+	AnswerId  string `validate:"required,uuid"`
+	FileName  string `validate:"required,max=255"`
+	Data      []byte `validate:"min=1"`
+}
+
+type AddAttachmentUseCase struct {
+	sessionRepository SessionRepository
+	storage           AttachmentStorage
+	policy            AttachmentPolicy
+	validator         *validator.Validate
+}
+
+// NewAddAttachmentUseCase returns the use case storing attachments in storage, attachments cannot be added when it is nil
+func NewAddAttachmentUseCase(sessionRepository SessionRepository, storage AttachmentStorage, policy AttachmentPolicy) *AddAttachmentUseCase {
+	return &AddAttachmentUseCase{
+		sessionRepository: sessionRepository,
+		storage:           storage,
+		policy:            policy,
+		validator:         validator.New(),
+	}
+}
+
+// Execute stores a piece of evidence for an answer given during a session. The content type is detected from the
+// content and must be accepted by the attachment policy, along with the size.
+func (u *AddAttachmentUseCase) Execute(ctx context.Context, cmd CmdAddAttachment) (*model.Attachment, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd.FileName)
+	}
+
+	if u.storage == nil {
+		return nil, fmt.Errorf("%w: no attachment storage is configured", ErrUnavailable)
+	}
+
+	sessionId, err := uuid.Parse(cmd.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+	answerId, err := uuid.Parse(cmd.AnswerId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	fileName := path.Base(strings.ReplaceAll(cmd.FileName, "\\", "/"))
+	if fileName == "." || fileName == "/" {
+		return nil, fmt.Errorf("%w: invalid file name %q", ErrInvalidCommand, cmd.FileName)
+	}
+	if size := int64(len(cmd.Data)); u.policy.MaxSize > 0 && size > u.policy.MaxSize {
+		return nil, fmt.Errorf("%w: attachment of %d bytes exceeds the limit of %d bytes", ErrInvalidCommand, size, u.policy.MaxSize)
+	}
+	contentType := http.DetectContentType(cmd.Data)
+	if !u.policy.Allows(contentType) {
+		return nil, fmt.Errorf("%w: content type %s is not accepted, expected one of %v", ErrInvalidCommand, contentType, u.policy.ContentTypes)
+	}
+
+	session, err := u.sessionRepository.Get(ctx, sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session: %w", err)
+	}
+
+	now := time.Now()
+	attachment := model.Attachment{
+		Id:          uuid.New(),
+		AnswerId:    answerId,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(cmd.Data)),
+		Checksum:    model.Checksum(cmd.Data),
+		UploadedAt:  now,
+	}
+	// Checked before storing the content, and again when recording the attachment
+	if !session.HasAnswer(answerId) {
+		return nil, fmt.Errorf("%w: answer %s was not given during session %s", ErrNotFound, answerId, sessionId)
+	}
+
+	key := attachment.StorageKey(sessionId)
+	err = u.storage.Put(ctx, key, cmd.Data, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to store attachment: %w", ErrUnavailable, err)
+	}
+
+	err = u.sessionRepository.Update(ctx, sessionId, func(existing model.CaseSession) (model.CaseSession, error) {
+		if err := existing.Attach(attachment, now); err != nil {
+			return existing, fmt.Errorf("%w: %s", ErrNotFound, err)
+		}
+		return existing, nil
+	})
+	if err != nil {
+		// The content is not referenced by any session
+		if deleteErr := u.storage.Delete(ctx, key); deleteErr != nil {
+			xlog.Ctx(ctx).Warn().Err(deleteErr).Str("key", key).Msg("failed to delete unreferenced attachment")
+		}
+		return nil, fmt.Errorf("failed to record attachment: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("session_id", sessionId.String()).
+		Str("attachment_id", attachment.Id.String()).
+		Int64("size", attachment.Size).
+		Msg("attachment added")
+
+	return &attachment, nil
+}
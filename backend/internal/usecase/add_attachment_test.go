@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAttachmentUseCase_Execute(t *testing.T) {
+	answerId := uuid.New()
+	session := &model.CaseSession{
+		Id:      uuid.New(),
+		Status:  model.SessionStatusInProgress,
+		Answers: []model.SessionAnswer{{NodeId: uuid.New(), AnswerId: answerId}},
+	}
+	pdf := []byte("%PDF-1.4\n%dismissal letter")
+	applyUpdate := func(updated *model.CaseSession) func(context.Context, uuid.UUID, func(model.CaseSession) (model.CaseSession, error)) error {
+		return func(_ context.Context, _ uuid.UUID, fn func(model.CaseSession) (model.CaseSession, error)) error {
+			result, err := fn(*session)
+			*updated = result
+			return err
+		}
+	}
+	cmd := func(data []byte) CmdAddAttachment {
+		return CmdAddAttachment{
+			SessionId: session.Id.String(),
+			AnswerId:  answerId.String(),
+			FileName:  "../letters/dismissal.pdf",
+			Data:      data,
+		}
+	}
+
+	t.Run("stores the content and records the attachment", func(t *testing.T) {
+		var updated model.CaseSession
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+		sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(applyUpdate(&updated))
+		storage := mocks.NewMockAttachmentStorage(ctrl)
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), pdf, "application/pdf").Return(nil)
+
+		attachment, err := NewAddAttachmentUseCase(sessionRepo, storage, DefaultAttachmentPolicy()).Execute(context.Background(), cmd(pdf))
+		require.NoError(t, err)
+		assert.Equal(t, answerId, attachment.AnswerId)
+		assert.Equal(t, "dismissal.pdf", attachment.FileName)
+		assert.Equal(t, "application/pdf", attachment.ContentType)
+		assert.Equal(t, int64(len(pdf)), attachment.Size)
+		assert.Equal(t, model.Checksum(pdf), attachment.Checksum)
+		assert.Equal(t, []model.Attachment{*attachment}, updated.Attachments)
+	})
+
+	t.Run("rejects content types outside the policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewAddAttachmentUseCase(mocks.NewMockSessionRepository(ctrl), mocks.NewMockAttachmentStorage(ctrl), DefaultAttachmentPolicy()).
+			Execute(context.Background(), cmd([]byte("<html><body>page</body></html>")))
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+		assert.ErrorContains(t, err, "text/html")
+	})
+
+	t.Run("rejects content larger than the policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewAddAttachmentUseCase(mocks.NewMockSessionRepository(ctrl), mocks.NewMockAttachmentStorage(ctrl), AttachmentPolicy{MaxSize: 8}).
+			Execute(context.Background(), cmd(pdf))
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("returns not found for an answer not given during the session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+
+		command := cmd(pdf)
+		command.AnswerId = uuid.NewString()
+		_, err := NewAddAttachmentUseCase(sessionRepo, mocks.NewMockAttachmentStorage(ctrl), DefaultAttachmentPolicy()).Execute(context.Background(), command)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("deletes the content when the attachment cannot be recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+		sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).Return(errors.New("disk full"))
+		storage := mocks.NewMockAttachmentStorage(ctrl)
+		var key string
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), pdf, "application/pdf").DoAndReturn(func(_ context.Context, k string, _ []byte, _ string) error {
+			key = k
+			return nil
+		})
+		storage.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k string) error {
+			assert.Equal(t, key, k)
+			return nil
+		})
+
+		_, err := NewAddAttachmentUseCase(sessionRepo, storage, DefaultAttachmentPolicy()).Execute(context.Background(), cmd(pdf))
+		assert.Error(t, err)
+	})
+
+	t.Run("is unavailable without storage", func(t *testing.T) {
+		_, err := NewAddAttachmentUseCase(mocks.NewMockSessionRepository(gomock.NewController(t)), nil, DefaultAttachmentPolicy()).
+			Execute(context.Background(), cmd(pdf))
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		useCase := NewAddAttachmentUseCase(mocks.NewMockSessionRepository(ctrl), mocks.NewMockAttachmentStorage(ctrl), DefaultAttachmentPolicy())
+
+		command := cmd(pdf)
+		command.SessionId = "invalid"
+		_, err := useCase.Execute(context.Background(), command)
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+
+		_, err = useCase.Execute(context.Background(), cmd(nil))
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
+
+func TestAttachmentPolicy_Allows(t *testing.T) {
+	policy := DefaultAttachmentPolicy()
+
+	assert.True(t, policy.Allows("application/pdf"))
+	assert.True(t, policy.Allows("text/plain; charset=utf-8"))
+	assert.False(t, policy.Allows("text/html; charset=utf-8"))
+	assert.False(t, policy.Allows("invalid;;"))
+	assert.True(t, AttachmentPolicy{}.Allows("application/zip"))
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdAnswerSession struct {
+	SessionId string `validate:"required,uuid"`
+	AnswerId  string `validate:"omitempty,uuid"`
+	// AnswerIds selects several answers of a multi-select question, along with AnswerId when it is set
+	AnswerIds []string `validate:"dive,uuid"`
+	// Value is the value entered on an input question, given instead of answers
+	Value       *string
+	UserContext string
+	Metadata    map[string]interface{}
+}
+
+type AnswerSessionUseCase struct {
+	dagRepository     DAGRepository
+	sessionRepository SessionRepository
+	validator         *validator.Validate
+}
+
+func NewAnswerSessionUseCase(dagRepository DAGRepository, sessionRepository SessionRepository) *AnswerSessionUseCase {
+	return &AnswerSessionUseCase{
+		dagRepository:     dagRepository,
+		sessionRepository: sessionRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute answers the current question of a session, with several answers on multi-select questions or
+// the value entered on input questions, and moves it to the next one
+func (u *AnswerSessionUseCase) Execute(ctx context.Context, cmd CmdAnswerSession) (*model.CaseSession, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	sessionId, err := uuid.Parse(cmd.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+	var answerIds []uuid.UUID
+	if cmd.Value == nil {
+		answerIds, err = parseAnswerIds(cmd)
+		if err != nil {
+			return nil, err
+		}
+	} else if cmd.AnswerId != "" || len(cmd.AnswerIds) > 0 {
+		return nil, fmt.Errorf("%w: a value cannot be given along with answers", ErrInvalidCommand)
+	}
+
+	session, err := u.sessionRepository.Get(ctx, sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session: %w", err)
+	}
+
+	d, err := u.dagRepository.Get(ctx, session.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG of session: %w", err)
+	}
+	// Metadata given along the answer replaces the metadata of the answer on the session path
+	if len(cmd.Metadata) > 0 {
+		if err := d.MetadataSchema.Validate(cmd.Metadata); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		}
+	}
+
+	var answered model.CaseSession
+	err = u.sessionRepository.Update(ctx, sessionId, func(existing model.CaseSession) (model.CaseSession, error) {
+		if existing.Status != model.SessionStatusInProgress || existing.CurrentNodeId == nil {
+			return existing, fmt.Errorf("%w: session %s has no question left to answer", ErrConflict, sessionId)
+		}
+		var err error
+		if cmd.Value != nil {
+			err = existing.AnswerInput(d, *cmd.Value, cmd.UserContext, cmd.Metadata, time.Now())
+		} else {
+			err = existing.AnswerMany(d, answerIds, cmd.UserContext, cmd.Metadata, time.Now())
+		}
+		if err != nil {
+			return existing, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		}
+
+		answered = existing
+		return existing, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to answer session: %w", err)
+	}
+
+	return &answered, nil
+}
+
+// parseAnswerIds collects the selected answers of the command, at least one answer must be selected
+func parseAnswerIds(cmd CmdAnswerSession) ([]uuid.UUID, error) {
+	ids := cmd.AnswerIds
+	if cmd.AnswerId != "" {
+		ids = append([]string{cmd.AnswerId}, ids...)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: at least one answer must be selected", ErrInvalidCommand)
+	}
+
+	answerIds := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		answerId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+		}
+		answerIds = append(answerIds, answerId)
+	}
+	return answerIds, nil
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnswerSessionUseCase_Execute(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+	root := dagtest.Root(d)
+	newSession := func() *model.CaseSession {
+		session, err := model.NewCaseSession(d, time.Now())
+		require.NoError(t, err)
+		return session
+	}
+	applyUpdate := func(session *model.CaseSession) func(context.Context, uuid.UUID, func(model.CaseSession) (model.CaseSession, error)) error {
+		return func(_ context.Context, _ uuid.UUID, fn func(model.CaseSession) (model.CaseSession, error)) error {
+			_, err := fn(*session)
+			return err
+		}
+	}
+
+	tests := []struct {
+		name       string
+		answerId   uuid.UUID
+		session    func() *model.CaseSession
+		setupMocks func(*mocks.MockDAGRepository, *mocks.MockSessionRepository, *model.CaseSession)
+		errorType  error
+	}{
+		{
+			name:     "records the answer and moves to the next question",
+			answerId: root.Answers[0].Id,
+			session:  newSession,
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, sessionRepo *mocks.MockSessionRepository, session *model.CaseSession) {
+				sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(applyUpdate(session))
+			},
+		},
+		{
+			name:     "rejects an answer of another question",
+			answerId: uuid.New(),
+			session:  newSession,
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, sessionRepo *mocks.MockSessionRepository, session *model.CaseSession) {
+				sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(applyUpdate(session))
+			},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:     "rejects answers once the walk ended",
+			answerId: root.Answers[0].Id,
+			session: func() *model.CaseSession {
+				session := newSession()
+				session.CurrentNodeId = nil
+				return session
+			},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, sessionRepo *mocks.MockSessionRepository, session *model.CaseSession) {
+				sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(applyUpdate(session))
+			},
+			errorType: ErrConflict,
+		},
+		{
+			name:     "returns not found for unknown session",
+			answerId: root.Answers[0].Id,
+			session:  newSession,
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, sessionRepo *mocks.MockSessionRepository, session *model.CaseSession) {
+				sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dagRepo := mocks.NewMockDAGRepository(ctrl)
+			sessionRepo := mocks.NewMockSessionRepository(ctrl)
+			session := tt.session()
+			tt.setupMocks(dagRepo, sessionRepo, session)
+
+			answered, err := NewAnswerSessionUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdAnswerSession{
+				SessionId:   session.Id.String(),
+				AnswerId:    tt.answerId.String(),
+				UserContext: "Dismissed by email",
+			})
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, answered.Answers, 1)
+			assert.Equal(t, root.Id, answered.Answers[0].NodeId)
+			assert.Equal(t, "Dismissed by email", answered.Answers[0].UserContext)
+			assert.Equal(t, root.Answers[0].NextNode, answered.CurrentNodeId)
+		})
+	}
+
+	t.Run("rejects invalid identifiers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewAnswerSessionUseCase(mocks.NewMockDAGRepository(ctrl), mocks.NewMockSessionRepository(ctrl)).
+			Execute(context.Background(), CmdAnswerSession{SessionId: "invalid", AnswerId: uuid.NewString()})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("records the answers of a multi-select question", func(t *testing.T) {
+		multi := dagtest.ValidSingleRoot()
+		multiRoot := dagtest.Root(multi)
+		multiRoot.MultiSelect = true
+		multi.Nodes[multiRoot.Id] = multiRoot
+		session, err := model.NewCaseSession(multi, time.Now())
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+		dagRepo.EXPECT().Get(gomock.Any(), multi.Id).Return(multi, nil)
+		sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(applyUpdate(session))
+
+		answered, err := NewAnswerSessionUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdAnswerSession{
+			SessionId: session.Id.String(),
+			AnswerId:  multiRoot.Answers[1].Id.String(),
+			AnswerIds: []string{multiRoot.Answers[0].Id.String()},
+		})
+		require.NoError(t, err)
+		require.Len(t, answered.Answers, 2)
+		assert.Equal(t, multiRoot.Answers[1].Id, answered.Answers[0].AnswerId)
+		assert.Equal(t, multiRoot.Answers[0].Id, answered.Answers[1].AnswerId)
+		// Priority follows the first selected answer in node order
+		assert.Equal(t, multiRoot.Answers[0].NextNode, answered.CurrentNodeId)
+	})
+
+	t.Run("rejects a command without answer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewAnswerSessionUseCase(mocks.NewMockDAGRepository(ctrl), mocks.NewMockSessionRepository(ctrl)).
+			Execute(context.Background(), CmdAnswerSession{SessionId: uuid.NewString()})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("records the value entered on an input question", func(t *testing.T) {
+		input := dagtest.ValidSingleRoot()
+		inputRoot := dagtest.Root(input)
+		inputRoot.Type = model.NodeTypeInput
+		inputRoot.Input = &model.Input{Kind: model.InputKindDate, Variable: "termination_date"}
+		inputRoot.Answers = inputRoot.Answers[:1]
+		input.Nodes[inputRoot.Id] = inputRoot
+		session, err := model.NewCaseSession(input, time.Now())
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+		dagRepo.EXPECT().Get(gomock.Any(), input.Id).Return(input, nil)
+		sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(applyUpdate(session))
+
+		value := "2024-03-03"
+		answered, err := NewAnswerSessionUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdAnswerSession{
+			SessionId: session.Id.String(),
+			Value:     &value,
+		})
+		require.NoError(t, err)
+		require.Len(t, answered.Answers, 1)
+		assert.Equal(t, inputRoot.Answers[0].Id, answered.Answers[0].AnswerId)
+		assert.Equal(t, "2024-03-03", answered.Answers[0].Value)
+	})
+
+	t.Run("rejects a value given along with answers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		value := "2024-03-03"
+		_, err := NewAnswerSessionUseCase(mocks.NewMockDAGRepository(ctrl), mocks.NewMockSessionRepository(ctrl)).
+			Execute(context.Background(), CmdAnswerSession{SessionId: uuid.NewString(), AnswerId: uuid.NewString(), Value: &value})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("rejects metadata not matching the schema", func(t *testing.T) {
+		schema := dagtest.ValidSingleRoot()
+		schema.MetadataSchema = &model.MetadataSchema{Fields: []model.MetadataField{
+			{Name: "confidence", Type: model.MetadataFieldNumber},
+		}}
+		session, err := model.NewCaseSession(schema, time.Now())
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+		dagRepo.EXPECT().Get(gomock.Any(), schema.Id).Return(schema, nil)
+
+		_, err = NewAnswerSessionUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdAnswerSession{
+			SessionId: session.Id.String(),
+			AnswerId:  dagtest.Root(schema).Answers[0].Id.String(),
+			Metadata:  map[string]interface{}{"confidence": "high"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
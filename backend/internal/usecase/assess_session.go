@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdAssessSession struct {
+	SessionId string `validate:"required,uuid"`
+	// Task is what the model is asked to do with the case context, a legal assessment when empty
+	Task string `validate:"max=2000"`
+}
+
+type AssessSessionUseCase struct {
+	dagRepository     DAGRepository
+	sessionRepository SessionRepository
+	provider          AssessmentProvider
+	template          llmprompt.Template
+	validator         *validator.Validate
+}
+
+// NewAssessSessionUseCase returns the use case assessing sessions with provider, sessions cannot be assessed when it is nil
+func NewAssessSessionUseCase(dagRepository DAGRepository, sessionRepository SessionRepository, provider AssessmentProvider) *AssessSessionUseCase {
+	return &AssessSessionUseCase{
+		dagRepository:     dagRepository,
+		sessionRepository: sessionRepository,
+		provider:          provider,
+		template:          llmprompt.DefaultTemplate(),
+		validator:         validator.New(),
+	}
+}
+
+// Execute sends the prompt built from a completed session to the language model and stores its assessment on the session
+func (u *AssessSessionUseCase) Execute(ctx context.Context, cmd CmdAssessSession) (*model.CaseSession, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	if u.provider == nil {
+		return nil, fmt.Errorf("%w: no LLM provider is configured", ErrUnavailable)
+	}
+
+	doc, err := loadSessionDocument(ctx, u.dagRepository, u.sessionRepository, cmd.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := llmprompt.Build(u.template, llmprompt.Data{ContextDocument: doc, Task: cmd.Task})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to build prompt: %s", ErrInternal, err)
+	}
+
+	content, err := u.provider.Complete(ctx, messages)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s provider failed to assess session: %s", ErrUnavailable, u.provider.Name(), err)
+	}
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("%w: %s provider returned an empty assessment", ErrUnavailable, u.provider.Name())
+	}
+
+	assessment := model.Assessment{
+		Provider:  u.provider.Name(),
+		Model:     u.provider.Model(),
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+
+	sessionId := uuid.MustParse(cmd.SessionId)
+	var assessed model.CaseSession
+	err = u.sessionRepository.Update(ctx, sessionId, func(existing model.CaseSession) (model.CaseSession, error) {
+		if err := existing.Assess(assessment); err != nil {
+			return existing, fmt.Errorf("%w: %s", ErrConflict, err)
+		}
+
+		assessed = existing
+		return existing, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store session assessment: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("session_id", sessionId.String()).
+		Str("provider", assessment.Provider).
+		Str("model", assessment.Model).
+		Msg("case session assessed")
+
+	return &assessed, nil
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssessSessionUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	d := dagtest.ValidSingleRoot()
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+
+	completed, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+	for _, step := range paths[0].Steps {
+		require.NoError(t, completed.Answer(d, step.Answer.Id, "", nil, now))
+	}
+	require.NoError(t, completed.Complete(now))
+
+	inProgress, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (*mocks.MockDAGRepository, *mocks.MockSessionRepository, *mocks.MockAssessmentProvider) {
+		ctrl := gomock.NewController(t)
+		provider := mocks.NewMockAssessmentProvider(ctrl)
+		provider.EXPECT().Name().Return("openai").AnyTimes()
+		provider.EXPECT().Model().Return("gpt-4o").AnyTimes()
+		return mocks.NewMockDAGRepository(ctrl), mocks.NewMockSessionRepository(ctrl), provider
+	}
+
+	t.Run("stores the assessment of a completed session", func(t *testing.T) {
+		dagRepo, sessionRepo, provider := setup(t)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+		provider.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, messages []llmprompt.Message) (string, error) {
+				require.Len(t, messages, 2)
+				assert.Contains(t, messages[1].Content, "Task: Assess the claims.")
+				return "The claims are well founded.", nil
+			},
+		)
+		sessionRepo.EXPECT().Update(gomock.Any(), completed.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.CaseSession) (model.CaseSession, error)) error {
+				_, err := fn(*completed)
+				return err
+			},
+		)
+
+		assessed, err := NewAssessSessionUseCase(dagRepo, sessionRepo, provider).Execute(context.Background(), CmdAssessSession{
+			SessionId: completed.Id.String(),
+			Task:      "Assess the claims.",
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, assessed.Assessment)
+		assert.Equal(t, "openai", assessed.Assessment.Provider)
+		assert.Equal(t, "gpt-4o", assessed.Assessment.Model)
+		assert.Equal(t, "The claims are well founded.", assessed.Assessment.Content)
+		assert.Nil(t, completed.Assessment, "the stored session is only changed through the repository")
+	})
+
+	t.Run("reports provider failures as unavailable", func(t *testing.T) {
+		dagRepo, sessionRepo, provider := setup(t)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+		provider.EXPECT().Complete(gomock.Any(), gomock.Any()).Return("", errors.New("status 429"))
+
+		_, err := NewAssessSessionUseCase(dagRepo, sessionRepo, provider).Execute(context.Background(), CmdAssessSession{SessionId: completed.Id.String()})
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("rejects a session in progress", func(t *testing.T) {
+		dagRepo, sessionRepo, provider := setup(t)
+		sessionRepo.EXPECT().Get(gomock.Any(), inProgress.Id).Return(inProgress, nil)
+
+		_, err := NewAssessSessionUseCase(dagRepo, sessionRepo, provider).Execute(context.Background(), CmdAssessSession{SessionId: inProgress.Id.String()})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("requires a provider", func(t *testing.T) {
+		dagRepo, sessionRepo, _ := setup(t)
+
+		_, err := NewAssessSessionUseCase(dagRepo, sessionRepo, nil).Execute(context.Background(), CmdAssessSession{SessionId: completed.Id.String()})
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		dagRepo, sessionRepo, provider := setup(t)
+
+		_, err := NewAssessSessionUseCase(dagRepo, sessionRepo, provider).Execute(context.Background(), CmdAssessSession{SessionId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=assessment_provider.go -destination=testdata/mocks/assessment_provider_mock.go -package=mocks
+
+// AssessmentProvider is the language model case sessions are assessed by
+type AssessmentProvider interface {
+	Name() string
+	Model() string
+	Complete(ctx context.Context, messages []llmprompt.Message) (string, error)
+}
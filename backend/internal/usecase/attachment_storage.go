@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"mime"
+	"slices"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=attachment_storage.go -destination=testdata/mocks/attachment_storage_mock.go -package=mocks
+
+// AttachmentStorage keeps the content of the attachments, by key. Get returns ErrNotFound for unknown keys.
+type AttachmentStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// AttachmentPolicy restricts the attachments accepted on the answers of case sessions
+type AttachmentPolicy struct {
+	// MaxSize is the largest attachment accepted, in bytes
+	MaxSize int64
+	// ContentTypes lists the media types accepted, e.g. "application/pdf", any type is accepted when empty
+	ContentTypes []string
+}
+
+// DefaultAttachmentPolicy accepts documents and pictures of up to 10 MiB
+func DefaultAttachmentPolicy() AttachmentPolicy {
+	return AttachmentPolicy{
+		MaxSize:      10 << 20,
+		ContentTypes: []string{"application/pdf", "image/png", "image/jpeg", "text/plain"},
+	}
+}
+
+// Allows tells whether the policy accepts a content type, its parameters such as the charset being ignored
+func (p AttachmentPolicy) Allows(contentType string) bool {
+	if len(p.ContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(p.ContentTypes, mediaType)
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdBuildPrompt struct {
+	SessionId string `validate:"required,uuid"`
+	// SystemTemplate and UserTemplate override the default text/template sources when set
+	SystemTemplate string `validate:"max=20000"`
+	UserTemplate   string `validate:"max=20000"`
+	// Task is what the model is asked to do with the case context
+	Task string `validate:"max=2000"`
+}
+
+// BuiltPrompt is the prompt built from a completed session, ready to send to a language model
+type BuiltPrompt struct {
+	SessionId uuid.UUID
+	DAGId     uuid.UUID
+	Messages  []llmprompt.Message
+}
+
+type BuildPromptUseCase struct {
+	dagRepository     DAGRepository
+	sessionRepository SessionRepository
+	template          llmprompt.Template
+	validator         *validator.Validate
+}
+
+func NewBuildPromptUseCase(dagRepository DAGRepository, sessionRepository SessionRepository) *BuildPromptUseCase {
+	return &BuildPromptUseCase{
+		dagRepository:     dagRepository,
+		sessionRepository: sessionRepository,
+		template:          llmprompt.DefaultTemplate(),
+		validator:         validator.New(),
+	}
+}
+
+// Execute builds the system and user messages of a prompt from the path answered in a completed session
+func (u *BuildPromptUseCase) Execute(ctx context.Context, cmd CmdBuildPrompt) (*BuiltPrompt, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	doc, err := loadSessionDocument(ctx, u.dagRepository, u.sessionRepository, cmd.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := u.template.WithOverrides(llmprompt.Template{System: cmd.SystemTemplate, User: cmd.UserTemplate})
+	messages, err := llmprompt.Build(tmpl, llmprompt.Data{ContextDocument: doc, Task: cmd.Task})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return &BuiltPrompt{
+		SessionId: *doc.SessionId,
+		DAGId:     doc.DAGId,
+		Messages:  messages,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/llmprompt"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPromptUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	d := dagtest.ValidSingleRoot()
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+
+	completed, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+	for _, step := range paths[0].Steps {
+		require.NoError(t, completed.Answer(d, step.Answer.Id, "", nil, now))
+	}
+	require.NoError(t, completed.Complete(now))
+
+	inProgress, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (*mocks.MockDAGRepository, *mocks.MockSessionRepository) {
+		ctrl := gomock.NewController(t)
+		return mocks.NewMockDAGRepository(ctrl), mocks.NewMockSessionRepository(ctrl)
+	}
+
+	t.Run("builds the default prompt of a completed session", func(t *testing.T) {
+		dagRepo, sessionRepo := setup(t)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+
+		result, err := NewBuildPromptUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdBuildPrompt{
+			SessionId: completed.Id.String(),
+			Task:      "List the claims.",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, completed.Id, result.SessionId)
+		assert.Equal(t, d.Id, result.DAGId)
+		require.Len(t, result.Messages, 2)
+		assert.Equal(t, llmprompt.RoleSystem, result.Messages[0].Role)
+		assert.Equal(t, llmprompt.RoleUser, result.Messages[1].Role)
+		for _, step := range paths[0].Steps {
+			assert.Contains(t, result.Messages[1].Content, "Question: "+step.Question)
+		}
+		assert.Contains(t, result.Messages[1].Content, "Task: List the claims.")
+	})
+
+	t.Run("uses the given templates", func(t *testing.T) {
+		dagRepo, sessionRepo := setup(t)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+
+		result, err := NewBuildPromptUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdBuildPrompt{
+			SessionId:    completed.Id.String(),
+			UserTemplate: "{{len .Entries}} answers",
+		})
+		require.NoError(t, err)
+
+		require.Len(t, result.Messages, 2)
+		assert.Equal(t, llmprompt.Message{Role: llmprompt.RoleUser, Content: "2 answers"}, result.Messages[1])
+	})
+
+	t.Run("rejects invalid templates", func(t *testing.T) {
+		dagRepo, sessionRepo := setup(t)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+
+		_, err := NewBuildPromptUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdBuildPrompt{
+			SessionId:      completed.Id.String(),
+			SystemTemplate: "{{.Missing}}",
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("rejects a session in progress", func(t *testing.T) {
+		dagRepo, sessionRepo := setup(t)
+		sessionRepo.EXPECT().Get(gomock.Any(), inProgress.Id).Return(inProgress, nil)
+
+		_, err := NewBuildPromptUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdBuildPrompt{SessionId: inProgress.Id.String()})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		dagRepo, sessionRepo := setup(t)
+
+		_, err := NewBuildPromptUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdBuildPrompt{SessionId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
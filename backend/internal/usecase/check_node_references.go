@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// CmdCheckNodeReferences checks a node, typically copied from another DAG, against a stored target DAG
+type CmdCheckNodeReferences struct {
+	DAGId string      `validate:"required,uuid"`
+	Node  *model.Node `validate:"required"`
+}
+
+// NodeReferences splits the next_node references of a node between the ones resolving in the target DAG
+// and the dangling ones
+type NodeReferences struct {
+	Existing []uuid.UUID
+	Dangling []uuid.UUID
+}
+
+type CheckNodeReferencesUseCase struct {
+	dagRepository DAGRepository
+	validator     *validator.Validate
+}
+
+func NewCheckNodeReferencesUseCase(dagRepository DAGRepository) *CheckNodeReferencesUseCase {
+	return &CheckNodeReferencesUseCase{
+		dagRepository: dagRepository,
+		validator:     validator.New(),
+	}
+}
+
+// Execute reports which next_node references of the node exist in the stored DAG, without modifying it
+func (u *CheckNodeReferencesUseCase) Execute(ctx context.Context, cmd CmdCheckNodeReferences) (*NodeReferences, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	existing, dangling := dag.CheckReferences(*cmd.Node)
+
+	return &NodeReferences{Existing: existing, Dangling: dangling}, nil
+}
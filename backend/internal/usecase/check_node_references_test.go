@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckNodeReferencesUseCase_Execute(t *testing.T) {
+	target := dagtest.ValidSingleRoot()
+	root := dagtest.Root(target)
+	existingId := *root.Answers[0].NextNode
+	danglingId := uuid.New()
+	copiedId := uuid.New()
+
+	// Copied node whose references partially exist in the target
+	copied := model.Node{
+		Id:       copiedId,
+		Question: "Copied question?",
+		Answers: []model.Answer{
+			{Id: uuid.New(), Statement: "Known", NextNode: &existingId},
+			{Id: uuid.New(), Statement: "Unknown", NextNode: &danglingId},
+			{Id: uuid.New(), Statement: "Unknown again", NextNode: &danglingId},
+			{Id: uuid.New(), Statement: "Loop", NextNode: &copiedId},
+			{Id: uuid.New(), Statement: "Stop"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		cmd              CmdCheckNodeReferences
+		setupMock        func(*mocks.MockDAGRepository)
+		expectedExisting []uuid.UUID
+		expectedDangling []uuid.UUID
+		errorType        error
+	}{
+		{
+			name: "splits existing and dangling references",
+			cmd:  CmdCheckNodeReferences{DAGId: target.Id.String(), Node: &copied},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), target.Id).Return(target, nil)
+			},
+			expectedExisting: []uuid.UUID{existingId, copiedId},
+			expectedDangling: []uuid.UUID{danglingId},
+		},
+		{
+			name: "reports no reference for a node without next nodes",
+			cmd:  CmdCheckNodeReferences{DAGId: target.Id.String(), Node: &model.Node{Id: uuid.New(), Answers: []model.Answer{{Id: uuid.New()}}}},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), target.Id).Return(target, nil)
+			},
+			expectedExisting: []uuid.UUID{},
+			expectedDangling: []uuid.UUID{},
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdCheckNodeReferences{DAGId: target.Id.String(), Node: &copied},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), target.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:      "rejects a missing node",
+			cmd:       CmdCheckNodeReferences{DAGId: target.Id.String()},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			references, err := NewCheckNodeReferencesUseCase(mockRepo).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedExisting, references.Existing)
+			assert.Equal(t, tt.expectedDangling, references.Dangling)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdCloneDAG struct {
+	DAGId string `validate:"required,uuid"`
+	// Title of the clone, the title of the cloned DAG when empty
+	Title string `validate:"max=200"`
+}
+
+type CloneDAGUseCase struct {
+	dagRepository DAGRepository
+	createDAG     *CreateDAGUseCase
+	validator     *validator.Validate
+}
+
+// NewCloneDAGUseCase reads the cloned DAG from liveRepository, so that DAGs in the trash cannot be cloned,
+// and stores the clone in dagRepository
+func NewCloneDAGUseCase(liveRepository DAGRepository, dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *CloneDAGUseCase {
+	return &CloneDAGUseCase{
+		dagRepository: liveRepository,
+		createDAG:     NewCreateDAGUseCase(dagRepository, dagValidator, eventPublisher),
+		validator:     validator.New(),
+	}
+}
+
+// Execute stores a deep copy of a DAG with fresh DAG, node and answer IDs. The clone is validated and
+// announced like any created DAG.
+func (u *CloneDAGUseCase) Execute(ctx context.Context, cmd CmdCloneDAG) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	clone := model.CloneDAG(dag)
+	if cmd.Title != "" {
+		clone.Title = cmd.Title
+	}
+
+	created, err := u.createDAG.Execute(ctx, CmdCreateDAG{DAG: clone})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone DAG %s: %w", id, err)
+	}
+
+	return created, nil
+}
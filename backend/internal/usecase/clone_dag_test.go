@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneDAGUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores a clone with fresh IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		source := dagtest.ValidSingleRoot()
+
+		dagRepo.EXPECT().Get(gomock.Any(), source.Id).Return(source, nil)
+		dagRepo.EXPECT().Get(gomock.Any(), gomock.Not(source.Id)).Return(nil, ErrNotFound)
+		dagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		clone, err := NewCloneDAGUseCase(dagRepo, dagRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdCloneDAG{
+			DAGId: source.Id.String(),
+			Title: "Copy of the case",
+		})
+		require.NoError(t, err)
+
+		assert.NotEqual(t, source.Id, clone.Id)
+		assert.Equal(t, "Copy of the case", clone.Title)
+		require.Len(t, clone.Nodes, len(source.Nodes))
+		for id := range clone.Nodes {
+			assert.NotContains(t, source.Nodes, id)
+		}
+	})
+
+	t.Run("keeps the title of the cloned DAG by default", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		source := dagtest.ValidSingleRoot()
+
+		dagRepo.EXPECT().Get(gomock.Any(), source.Id).Return(source, nil)
+		dagRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, ErrNotFound)
+		dagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		clone, err := NewCloneDAGUseCase(dagRepo, dagRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdCloneDAG{
+			DAGId: source.Id.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, source.Title, clone.Title)
+	})
+
+	t.Run("rejects clones failing validation", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		source := dagtest.Cyclic()
+		dagRepo.EXPECT().Get(gomock.Any(), source.Id).Return(source, nil)
+
+		_, err := NewCloneDAGUseCase(dagRepo, dagRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdCloneDAG{
+			DAGId: source.Id.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		t.Parallel()
+
+		dagRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		useCase := NewCloneDAGUseCase(dagRepo, dagRepo, NewDAGValidator(), nil)
+
+		for _, cmd := range []CmdCloneDAG{{}, {DAGId: "not-a-uuid"}} {
+			_, err := useCase.Execute(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand, cmd)
+		}
+	})
+
+	t.Run("fails when the DAG does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		dagRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		dagRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, ErrNotFound)
+
+		_, err := NewCloneDAGUseCase(dagRepo, dagRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdCloneDAG{
+			DAGId: uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
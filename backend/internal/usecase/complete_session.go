@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdCompleteSession struct {
+	SessionId string `validate:"required,uuid"`
+}
+
+type CompleteSessionUseCase struct {
+	sessionRepository SessionRepository
+	eventPublisher    EventPublisher
+	validator         *validator.Validate
+}
+
+// NewCompleteSessionUseCase returns the use case completing sessions, eventPublisher may be nil
+func NewCompleteSessionUseCase(sessionRepository SessionRepository, eventPublisher EventPublisher) *CompleteSessionUseCase {
+	return &CompleteSessionUseCase{
+		sessionRepository: sessionRepository,
+		eventPublisher:    eventPublisher,
+		validator:         validator.New(),
+	}
+}
+
+// Execute closes a session whose walk reached its end
+func (u *CompleteSessionUseCase) Execute(ctx context.Context, cmd CmdCompleteSession) (*model.CaseSession, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	sessionId, err := uuid.Parse(cmd.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	var completed model.CaseSession
+	err = u.sessionRepository.Update(ctx, sessionId, func(existing model.CaseSession) (model.CaseSession, error) {
+		if err := existing.Complete(time.Now()); err != nil {
+			return existing, fmt.Errorf("%w: %s", ErrConflict, err)
+		}
+
+		completed = existing
+		return existing, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete session: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("session_id", sessionId.String()).
+		Str("dag_id", completed.DAGId.String()).
+		Int("answers", len(completed.Answers)).
+		Msg("case session completed")
+
+	event := model.NewEvent(model.EventSessionCompleted, completed.DAGId, completed.UpdatedAt)
+	event.SessionId = &completed.Id
+	event.Data = map[string]interface{}{"answers": len(completed.Answers)}
+	publishEvent(ctx, u.eventPublisher, event)
+
+	return &completed, nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteSessionUseCase_Execute(t *testing.T) {
+	pending := uuid.New()
+
+	tests := []struct {
+		name      string
+		session   model.CaseSession
+		errorType error
+	}{
+		{
+			name:    "completes a session whose walk ended",
+			session: model.CaseSession{Id: uuid.New(), Status: model.SessionStatusInProgress},
+		},
+		{
+			name:      "rejects a session with questions left",
+			session:   model.CaseSession{Id: uuid.New(), Status: model.SessionStatusInProgress, CurrentNodeId: &pending},
+			errorType: ErrConflict,
+		},
+		{
+			name:      "rejects an already completed session",
+			session:   model.CaseSession{Id: uuid.New(), Status: model.SessionStatusCompleted},
+			errorType: ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sessionRepo := mocks.NewMockSessionRepository(ctrl)
+			sessionRepo.EXPECT().Update(gomock.Any(), tt.session.Id, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ uuid.UUID, fn func(model.CaseSession) (model.CaseSession, error)) error {
+					_, err := fn(tt.session)
+					return err
+				},
+			)
+
+			completed, err := NewCompleteSessionUseCase(sessionRepo, nil).Execute(context.Background(), CmdCompleteSession{
+				SessionId: tt.session.Id.String(),
+			})
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, model.SessionStatusCompleted, completed.Status)
+		})
+	}
+
+	t.Run("publishes the completion", func(t *testing.T) {
+		session := model.CaseSession{Id: uuid.New(), DAGId: uuid.New(), Status: model.SessionStatusInProgress, Answers: []model.SessionAnswer{{}, {}}}
+
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.CaseSession) (model.CaseSession, error)) error {
+				_, err := fn(session)
+				return err
+			},
+		)
+		publisher := mocks.NewMockEventPublisher(ctrl)
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event model.Event) error {
+			assert.Equal(t, model.EventSessionCompleted, event.Type)
+			assert.Equal(t, session.DAGId, event.DAGId)
+			require.NotNil(t, event.SessionId)
+			assert.Equal(t, session.Id, *event.SessionId)
+			assert.Equal(t, 2, event.Data["answers"])
+			return nil
+		})
+
+		_, err := NewCompleteSessionUseCase(sessionRepo, publisher).Execute(context.Background(), CmdCompleteSession{SessionId: session.Id.String()})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects invalid session ID", func(t *testing.T) {
+		_, err := NewCompleteSessionUseCase(mocks.NewMockSessionRepository(gomock.NewController(t)), nil).
+			Execute(context.Background(), CmdCompleteSession{SessionId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
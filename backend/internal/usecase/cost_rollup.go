@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
+)
+
+const (
+	// damagesEstimateKey is the answer metadata holding the monetary estimate of the answer
+	damagesEstimateKey = "damages_estimate"
+	// durationEstimateKey is the answer metadata holding the estimated duration of the answer
+	durationEstimateKey = "duration_estimate"
+)
+
+// calculateCostRollup sums the damages and duration estimates of every answer of the DAG,
+// regardless of the paths they belong to
+func (v *DAGValidator) calculateCostRollup(d *model.DAG, result *ValidationResult) {
+	for _, node := range d.Nodes {
+		for _, answer := range node.Answers {
+			result.Statistics.TotalEstimatedDamages += numericMetadata(answer, damagesEstimateKey)
+			result.Statistics.TotalEstimatedDuration += numericMetadata(answer, durationEstimateKey)
+		}
+	}
+}
+
+// numericMetadata returns the numeric value of an answer metadata, zero when missing or not a number
+func numericMetadata(answer model.Answer, key string) float64 {
+	switch value := answer.Metadata[key].(type) {
+	case float64:
+		return value
+	case float32:
+		return float64(value)
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return 0
+		}
+		return f
+	default:
+		return 0
+	}
+}
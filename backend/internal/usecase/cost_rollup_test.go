@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDAGValidator_CostRollup(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.New()
+	middleID := uuid.New()
+	leafID := uuid.New()
+
+	d := &model.DAG{
+		Id:    uuid.New(),
+		Title: "Cost DAG",
+		Nodes: map[uuid.UUID]model.Node{
+			rootID: {
+				Id:       rootID,
+				Question: "Were you dismissed?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Yes", NextNode: &middleID, Metadata: map[string]interface{}{"damages_estimate": 50000.0, "duration_estimate": 90.0}},
+					{Id: uuid.New(), Statement: "No", NextNode: &leafID, Metadata: map[string]interface{}{"damages_estimate": 1500}},
+				},
+			},
+			middleID: {
+				Id:       middleID,
+				Question: "Did you receive notice?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Yes", NextNode: &leafID, Metadata: map[string]interface{}{"duration_estimate": 30.5}},
+					// Non numeric values count as zero
+					{Id: uuid.New(), Statement: "No", NextNode: &leafID, Metadata: map[string]interface{}{"damages_estimate": "unknown", "duration_estimate": "6_months"}},
+					{Id: uuid.New(), Statement: "Unsure", NextNode: &leafID},
+				},
+			},
+			leafID: {
+				Id:       leafID,
+				Question: "Any other claim?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Harassment", Metadata: map[string]interface{}{"damages_estimate": 25000.0, "duration_estimate": 60.0}},
+				},
+			},
+		},
+	}
+
+	result := NewDAGValidator().ValidateDAG(d)
+
+	assert.True(t, result.IsValid, "errors: %v", result.Errors)
+	assert.Equal(t, 76500.0, result.Statistics.TotalEstimatedDamages)
+	assert.Equal(t, 180.5, result.Statistics.TotalEstimatedDuration)
+
+	t.Run("DAG without estimates totals zero", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(createValidTestDAG())
+		assert.Zero(t, result.Statistics.TotalEstimatedDamages)
+		assert.Zero(t, result.Statistics.TotalEstimatedDuration)
+	})
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdCreateDAG struct {
+	DAG *model.DAG `validate:"required"`
+	// SkipValidationMetadata keeps the validation metadata of the DAG instead of recording its validation,
+	// e.g. for bulk imports validated afterwards
+	SkipValidationMetadata bool
+}
+
+type CreateDAGUseCase struct {
+	dagRepository  DAGRepository
+	dagValidator   *DAGValidator
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewCreateDAGUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *CreateDAGUseCase {
+	return &CreateDAGUseCase{
+		dagRepository:  dagRepository,
+		dagValidator:   dagValidator,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// Execute stores a new DAG, assigning it an ID when the command carries none
+func (u *CreateDAGUseCase) Execute(ctx context.Context, cmd CmdCreateDAG) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	if cmd.DAG.Id == uuid.Nil {
+		cmd.DAG.Id = uuid.New()
+	}
+
+	result := u.dagValidator.ValidateDAG(cmd.DAG)
+	if !result.IsValid {
+		var errorMessages []string
+		for _, err := range result.Errors {
+			errorMessages = append(errorMessages, err.Message)
+		}
+		return nil, fmt.Errorf("%w: DAG validation failed: %v", ErrInvalidCommand, errorMessages)
+	}
+
+	// Repositories may overwrite an existing DAG on create, an ID already in use is rejected beforehand
+	_, err = u.dagRepository.Get(ctx, cmd.DAG.Id)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("%w: DAG with id %s already exists", ErrInvalidCommand, cmd.DAG.Id)
+	case !errors.Is(err, ErrNotFound):
+		return nil, fmt.Errorf("failed to create DAG: %w", err)
+	}
+
+	cmd.DAG.UpdatedAt = time.Now()
+	if !cmd.SkipValidationMetadata {
+		recordValidation(cmd.DAG, result, cmd.DAG.UpdatedAt)
+	}
+	if err := u.dagRepository.Create(ctx, cmd.DAG); err != nil {
+		return nil, fmt.Errorf("failed to create DAG: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", cmd.DAG.Id.String()).
+		Msg("DAG created")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGCreated, cmd.DAG.Id, time.Now()))
+
+	return cmd.DAG, nil
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDAGUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	withoutId := func() *model.DAG {
+		d := dagtest.ValidSingleRoot()
+		d.Id = uuid.Nil
+		return d
+	}
+
+	tests := []struct {
+		name        string
+		dag         *model.DAG
+		setupMock   func(*mocks.MockDAGRepository, *model.DAG)
+		expectError error
+	}{
+		{
+			name: "creates a DAG with its own ID",
+			dag:  dagtest.ValidSingleRoot(),
+			setupMock: func(mockRepo *mocks.MockDAGRepository, d *model.DAG) {
+				mockRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+				mockRepo.EXPECT().Create(gomock.Any(), d).Return(nil)
+			},
+		},
+		{
+			name: "assigns an ID when missing",
+			dag:  withoutId(),
+			setupMock: func(mockRepo *mocks.MockDAGRepository, d *model.DAG) {
+				mockRepo.EXPECT().Get(gomock.Any(), gomock.Not(uuid.Nil)).Return(nil, ErrNotFound)
+				mockRepo.EXPECT().Create(gomock.Any(), d).Return(nil)
+			},
+		},
+		{
+			name:        "rejects a missing DAG",
+			setupMock:   func(mockRepo *mocks.MockDAGRepository, d *model.DAG) {},
+			expectError: ErrInvalidCommand,
+		},
+		{
+			name:        "rejects an invalid DAG",
+			dag:         dagtest.Cyclic(),
+			setupMock:   func(mockRepo *mocks.MockDAGRepository, d *model.DAG) {},
+			expectError: ErrInvalidCommand,
+		},
+		{
+			name: "rejects an ID already in use",
+			dag:  dagtest.ValidSingleRoot(),
+			setupMock: func(mockRepo *mocks.MockDAGRepository, d *model.DAG) {
+				mockRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+			},
+			expectError: ErrInvalidCommand,
+		},
+		{
+			name: "fails when the repository fails",
+			dag:  dagtest.ValidSingleRoot(),
+			setupMock: func(mockRepo *mocks.MockDAGRepository, d *model.DAG) {
+				mockRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+				mockRepo.EXPECT().Create(gomock.Any(), d).Return(ErrInternal)
+			},
+			expectError: ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			tt.setupMock(mockRepo, tt.dag)
+
+			created, err := NewCreateDAGUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdCreateDAG{DAG: tt.dag})
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				assert.Nil(t, created)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, created.Id)
+			assert.False(t, created.UpdatedAt.IsZero())
+			require.NotNil(t, created.Metadata)
+			assert.True(t, created.Metadata.IsValid)
+			assert.Equal(t, created.UpdatedAt, created.Metadata.LastValidatedAt)
+			assert.Equal(t, len(created.Nodes), created.Metadata.Statistics.TotalNodes)
+		})
+	}
+
+	t.Run("keeps the metadata when asked to skip validation metadata", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		d := dagtest.ValidSingleRoot()
+		d.Metadata = model.NewDAGMetadata()
+		mockRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+		mockRepo.EXPECT().Create(gomock.Any(), d).Return(nil)
+
+		created, err := NewCreateDAGUseCase(mockRepo, NewDAGValidator(), nil).
+			Execute(context.Background(), CmdCreateDAG{DAG: d, SkipValidationMetadata: true})
+		require.NoError(t, err)
+		assert.False(t, created.Metadata.IsValid)
+		assert.True(t, created.Metadata.LastValidatedAt.IsZero())
+	})
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// webhookSecretSize is the number of random bytes of generated webhook secrets
+const webhookSecretSize = 32
+
+type CmdCreateWebhook struct {
+	URL string `validate:"required,url,max=2048"`
+	// Events are the event types to subscribe to, every event type when empty
+	Events []string `validate:"dive,oneof=dag.created dag.updated dag.deleted dag.restored dag.validated session.completed"`
+	// Secret signs the delivered payloads, generated when empty
+	Secret string `validate:"omitempty,min=16,max=256"`
+	// Active defaults to true
+	Active *bool
+}
+
+type CreateWebhookUseCase struct {
+	webhookRepository WebhookRepository
+	validator         *validator.Validate
+}
+
+func NewCreateWebhookUseCase(webhookRepository WebhookRepository) *CreateWebhookUseCase {
+	return &CreateWebhookUseCase{
+		webhookRepository: webhookRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute registers a webhook, the returned webhook holds the secret signing its payloads
+func (u *CreateWebhookUseCase) Execute(ctx context.Context, cmd CmdCreateWebhook) (*model.Webhook, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd.URL)
+	}
+	if err := validateWebhookURL(cmd.URL); err != nil {
+		return nil, err
+	}
+
+	secret := cmd.Secret
+	if secret == "" {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to generate webhook secret: %s", ErrInternal, err)
+		}
+	}
+
+	now := time.Now()
+	webhook := &model.Webhook{
+		Id:        uuid.New(),
+		URL:       cmd.URL,
+		Events:    cmd.Events,
+		Secret:    secret,
+		Active:    cmd.Active == nil || *cmd.Active,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := u.webhookRepository.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// validateWebhookURL only accepts absolute HTTP and HTTPS URLs
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: invalid webhook URL: %s", ErrInvalidCommand, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: webhook URL must be an absolute http or https URL", ErrInvalidCommand)
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhookUseCase_Execute(t *testing.T) {
+	inactive := false
+
+	t.Run("registers an active webhook with a generated secret", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		webhook, err := NewCreateWebhookUseCase(webhookRepo).Execute(context.Background(), CmdCreateWebhook{
+			URL:    "https://cases.example.com/hooks/jurigen",
+			Events: []string{model.EventSessionCompleted},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://cases.example.com/hooks/jurigen", webhook.URL)
+		assert.Equal(t, []string{model.EventSessionCompleted}, webhook.Events)
+		assert.True(t, webhook.Active)
+		assert.Len(t, webhook.Secret, 2*webhookSecretSize)
+		assert.False(t, webhook.CreatedAt.IsZero())
+	})
+
+	t.Run("keeps the given secret and state", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		webhook, err := NewCreateWebhookUseCase(webhookRepo).Execute(context.Background(), CmdCreateWebhook{
+			URL:    "http://localhost:9000/hooks",
+			Secret: "0123456789abcdef",
+			Active: &inactive,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "0123456789abcdef", webhook.Secret)
+		assert.False(t, webhook.Active)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		useCase := NewCreateWebhookUseCase(mocks.NewMockWebhookRepository(gomock.NewController(t)))
+
+		for _, cmd := range []CmdCreateWebhook{
+			{},
+			{URL: "not a url"},
+			{URL: "ftp://example.com/hooks"},
+			{URL: "https://example.com/hooks", Events: []string{"dag.renamed"}},
+			{URL: "https://example.com/hooks", Secret: "short"},
+		} {
+			_, err := useCase.Execute(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand, cmd)
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"bytes"
+	"davidterranova/jurigen/backend/internal/model"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// maxCyclePaths bounds the number of cycles enumerated in a DAG, a densely connected DAG holding exponentially many
+const maxCyclePaths = 100
+
+// dagCycle is an elementary cycle of a DAG
+type dagCycle struct {
+	// nodes lists the nodes of the cycle, closed on its first node
+	nodes []uuid.UUID
+	// closingAnswer leads from the last node of the cycle back to its first node
+	closingAnswer uuid.UUID
+}
+
+// cycleGraph is the graph of a DAG with nodes numbered in ID order, so that cycles are enumerated in the same order
+// on every run
+type cycleGraph struct {
+	ids []uuid.UUID
+	// edges holds the distinct targets of each node, in answer order
+	edges [][]int
+	// answers holds the first answer leading from a node to each of its targets, in the order of edges
+	answers [][]uuid.UUID
+}
+
+func newCycleGraph(d *model.DAG) cycleGraph {
+	ids := make([]uuid.UUID, 0, len(d.Nodes))
+	for id := range d.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+
+	index := make(map[uuid.UUID]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	g := cycleGraph{
+		ids:     ids,
+		edges:   make([][]int, len(ids)),
+		answers: make([][]uuid.UUID, len(ids)),
+	}
+	for i, id := range ids {
+		for _, answer := range d.Nodes[id].Answers {
+			for _, target := range answer.Targets() {
+				j, exists := index[target]
+				if exists && !slices.Contains(g.edges[i], j) {
+					g.edges[i] = append(g.edges[i], j)
+					g.answers[i] = append(g.answers[i], answer.Id)
+				}
+			}
+		}
+	}
+
+	return g
+}
+
+// findCycles enumerates the distinct elementary cycles of the DAG using Johnson's algorithm, at most maxCyclePaths
+// of them. It returns whether cycles were left out. The walks keep explicit stacks rather than recursing so that
+// very deep DAGs cannot exhaust the goroutine stack.
+func findCycles(d *model.DAG) ([]dagCycle, bool) {
+	g := newCycleGraph(d)
+	cycles := []dagCycle{}
+
+	all := make(map[int]bool, len(g.ids))
+	for i := range g.ids {
+		all[i] = true
+	}
+
+	// Each component holding cycles is searched for the cycles through its first node, which is then dropped
+	// and the remaining nodes split again into components
+	pending := g.cyclicComponents(all)
+	for len(pending) > 0 {
+		component := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		start := len(g.ids)
+		for i := range component {
+			start = min(start, i)
+		}
+
+		if g.circuits(start, component, &cycles) {
+			return cycles, true
+		}
+
+		delete(component, start)
+		pending = append(pending, g.cyclicComponents(component)...)
+	}
+
+	return cycles, false
+}
+
+// cyclicComponents splits the given nodes into strongly connected components using Tarjan's algorithm and returns
+// the components holding at least one cycle
+func (g cycleGraph) cyclicComponents(nodes map[int]bool) []map[int]bool {
+	type frame struct {
+		node int
+		next int
+	}
+
+	counter := 0
+	indices := make(map[int]int, len(nodes))
+	lowLinks := make(map[int]int, len(nodes))
+	onStack := make(map[int]bool, len(nodes))
+	stack := []int{}
+	components := []map[int]bool{}
+
+	// Nodes are visited in order so that components are found in the same order on every run
+	ordered := make([]int, 0, len(nodes))
+	for node := range nodes {
+		ordered = append(ordered, node)
+	}
+	sort.Ints(ordered)
+
+	for _, root := range ordered {
+		if _, visited := indices[root]; visited {
+			continue
+		}
+
+		calls := []frame{{node: root}}
+		indices[root], lowLinks[root] = counter, counter
+		counter++
+		stack = append(stack, root)
+		onStack[root] = true
+
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			if top.next < len(g.edges[top.node]) {
+				next := g.edges[top.node][top.next]
+				top.next++
+				if !nodes[next] {
+					continue
+				}
+
+				if _, visited := indices[next]; !visited {
+					indices[next], lowLinks[next] = counter, counter
+					counter++
+					stack = append(stack, next)
+					onStack[next] = true
+					calls = append(calls, frame{node: next})
+				} else if onStack[next] {
+					lowLinks[top.node] = min(lowLinks[top.node], indices[next])
+				}
+				continue
+			}
+
+			node := top.node
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].node
+				lowLinks[parent] = min(lowLinks[parent], lowLinks[node])
+			}
+
+			if lowLinks[node] != indices[node] {
+				continue
+			}
+
+			component := map[int]bool{}
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				component[member] = true
+				if member == node {
+					break
+				}
+			}
+
+			// A single node component holds a cycle only when the node leads to itself
+			if len(component) > 1 || slices.Contains(g.edges[node], node) {
+				components = append(components, component)
+			}
+		}
+	}
+
+	return components
+}
+
+// circuits appends the elementary cycles through start within the component to cycles, it returns true once
+// maxCyclePaths cycles are found and the enumeration must stop
+func (g cycleGraph) circuits(start int, component map[int]bool, cycles *[]dagCycle) bool {
+	type frame struct {
+		node  int
+		next  int
+		found bool
+	}
+
+	blocked := map[int]bool{start: true}
+	// blockedBy holds, for each blocked node, the nodes to unblock along with it
+	blockedBy := map[int][]int{}
+
+	unblock := func(node int) {
+		queue := []int{node}
+		for len(queue) > 0 {
+			current := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			if !blocked[current] {
+				continue
+			}
+			blocked[current] = false
+			queue = append(queue, blockedBy[current]...)
+			delete(blockedBy, current)
+		}
+	}
+
+	path := []frame{{node: start}}
+	for len(path) > 0 {
+		top := &path[len(path)-1]
+		if top.next < len(g.edges[top.node]) {
+			next := g.edges[top.node][top.next]
+			top.next++
+			if !component[next] {
+				continue
+			}
+
+			if next == start {
+				nodes := make([]uuid.UUID, 0, len(path)+1)
+				for _, f := range path {
+					nodes = append(nodes, g.ids[f.node])
+				}
+				*cycles = append(*cycles, dagCycle{
+					nodes:         append(nodes, g.ids[start]),
+					closingAnswer: g.answers[top.node][top.next-1],
+				})
+				if len(*cycles) >= maxCyclePaths {
+					return true
+				}
+				top.found = true
+			} else if !blocked[next] {
+				blocked[next] = true
+				path = append(path, frame{node: next})
+			}
+			continue
+		}
+
+		done := *top
+		path = path[:len(path)-1]
+		if done.found {
+			unblock(done.node)
+		} else {
+			// The node stays blocked until one of its targets takes part in a cycle
+			for _, next := range g.edges[done.node] {
+				if component[next] {
+					blockedBy[next] = append(blockedBy[next], done.node)
+				}
+			}
+		}
+		if len(path) > 0 && done.found {
+			path[len(path)-1].found = true
+		}
+	}
+
+	return false
+}
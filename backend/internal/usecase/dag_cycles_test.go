@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCycles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		nodes          int
+		edges          [][2]int
+		expectedCycles int
+	}{
+		{
+			name:           "acyclic",
+			nodes:          3,
+			edges:          [][2]int{{0, 1}, {1, 2}, {0, 2}},
+			expectedCycles: 0,
+		},
+		{
+			name:           "self loop",
+			nodes:          2,
+			edges:          [][2]int{{0, 1}, {1, 1}},
+			expectedCycles: 1,
+		},
+		{
+			name:           "cycles sharing a node",
+			nodes:          3,
+			edges:          [][2]int{{0, 1}, {1, 0}, {0, 2}, {2, 0}},
+			expectedCycles: 2,
+		},
+		{
+			name:           "cycles in the same tree",
+			nodes:          5,
+			edges:          [][2]int{{0, 1}, {1, 2}, {2, 1}, {1, 3}, {3, 4}, {4, 3}},
+			expectedCycles: 2,
+		},
+		{
+			name:           "complete graph of three nodes",
+			nodes:          3,
+			edges:          [][2]int{{0, 1}, {1, 0}, {1, 2}, {2, 1}, {0, 2}, {2, 0}},
+			expectedCycles: 5,
+		},
+		{
+			name:           "parallel answers",
+			nodes:          2,
+			edges:          [][2]int{{0, 1}, {0, 1}, {1, 0}},
+			expectedCycles: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := graphDAG(tt.nodes, tt.edges)
+
+			cycles, truncated := findCycles(d)
+			assert.False(t, truncated)
+			require.Len(t, cycles, tt.expectedCycles)
+
+			seen := map[string]bool{}
+			for _, cycle := range cycles {
+				assertElementaryCycle(t, d, cycle)
+
+				key := fmt.Sprint(cycle.nodes)
+				assert.False(t, seen[key], "cycle %s is reported once", key)
+				seen[key] = true
+			}
+		})
+	}
+}
+
+func TestFindCycles_Truncated(t *testing.T) {
+	t.Parallel()
+
+	// A complete graph of six nodes holds 409 elementary cycles
+	edges := [][2]int{}
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 6; j++ {
+			if i != j {
+				edges = append(edges, [2]int{i, j})
+			}
+		}
+	}
+
+	cycles, truncated := findCycles(graphDAG(6, edges))
+	assert.True(t, truncated)
+	assert.Len(t, cycles, maxCyclePaths)
+
+	result := NewDAGValidator().ValidateDAG(graphDAG(6, edges))
+	assert.Len(t, result.Statistics.CyclePaths, maxCyclePaths)
+	require.NotEmpty(t, result.Errors)
+	assert.Contains(t, result.Errors[len(result.Errors)-1].Message, "at least 100 cycles")
+}
+
+func TestDAGValidator_CyclePaths(t *testing.T) {
+	t.Parallel()
+
+	d := graphDAG(3, [][2]int{{0, 1}, {1, 2}, {2, 0}})
+
+	result := NewDAGValidator().ValidateDAG(d)
+	require.Len(t, result.Statistics.CyclePaths, 1)
+	cyclePath := result.Statistics.CyclePaths[0]
+
+	require.Len(t, cyclePath.NodeIDs, 4)
+	assert.Equal(t, cyclePath.NodeIDs[0], cyclePath.NodeIDs[3])
+
+	last := d.Nodes[uuid.MustParse(cyclePath.NodeIDs[2])]
+	require.Len(t, last.Answers, 1)
+	assert.Equal(t, last.Answers[0].Id.String(), cyclePath.ClosingAnswerID)
+	assert.Equal(t, cyclePath.NodeIDs[0], last.Answers[0].NextNode.String())
+}
+
+func TestDAGValidator_CycleDetection_DeepChain(t *testing.T) {
+	t.Parallel()
+
+	const length = 100_000
+	validator := NewDAGValidator()
+
+	t.Run("acyclic", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, validator.Cycles(dagtest.LinearChain(length)))
+	})
+
+	t.Run("closed on its root", func(t *testing.T) {
+		t.Parallel()
+
+		d, root := closedChain(length)
+
+		cycles := validator.Cycles(d)
+		require.Len(t, cycles, 1)
+		cycle := cycles[0]
+		require.Len(t, cycle, length+1)
+		assert.Equal(t, cycle[0], cycle[length])
+
+		// The cycle follows the chain
+		start := 0
+		for i, id := range cycle[:length] {
+			if id == root {
+				start = i
+			}
+		}
+		nodeId := root
+		for i := 0; i < length; i++ {
+			assert.Equal(t, nodeId, cycle[(start+i)%length])
+			nodeId = d.Nodes[nodeId].Answers[0].Targets()[0]
+		}
+
+		result := validator.ValidateDAG(d)
+		assert.True(t, result.Statistics.HasCycles)
+		assert.Len(t, result.Statistics.CyclePaths, 1)
+	})
+}
+
+func BenchmarkFindCycles(b *testing.B) {
+	for _, length := range []int{1_000, 100_000} {
+		acyclic := dagtest.LinearChain(length)
+		cyclic, _ := closedChain(length)
+
+		b.Run(fmt.Sprintf("acyclic chain %d", length), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				findCycles(acyclic)
+			}
+		})
+		b.Run(fmt.Sprintf("cyclic chain %d", length), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				findCycles(cyclic)
+			}
+		})
+	}
+
+	edges := [][2]int{}
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			if i != j {
+				edges = append(edges, [2]int{i, j})
+			}
+		}
+	}
+	complete := graphDAG(8, edges)
+	b.Run("complete graph 8", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			findCycles(complete)
+		}
+	})
+}
+
+// assertElementaryCycle checks that the cycle is closed on its first node, visits its nodes once
+// and follows the answers of the DAG
+func assertElementaryCycle(t *testing.T, d *model.DAG, cycle dagCycle) {
+	t.Helper()
+
+	require.GreaterOrEqual(t, len(cycle.nodes), 2)
+	assert.Equal(t, cycle.nodes[0], cycle.nodes[len(cycle.nodes)-1])
+
+	visited := map[uuid.UUID]bool{}
+	for i, nodeId := range cycle.nodes[:len(cycle.nodes)-1] {
+		assert.False(t, visited[nodeId], "node %s is visited once", nodeId)
+		visited[nodeId] = true
+
+		next := cycle.nodes[i+1]
+		leads := false
+		for _, answer := range d.Nodes[nodeId].Answers {
+			if *answer.NextNode == next {
+				leads = true
+				if i == len(cycle.nodes)-2 {
+					assert.Equal(t, answer.Id, cycle.closingAnswer, "the closing answer is the first leading back")
+					break
+				}
+			}
+		}
+		assert.True(t, leads, "node %s leads to node %s", nodeId, next)
+	}
+}
+
+// graphDAG returns a DAG of the given number of nodes with an answer for each edge
+func graphDAG(nodes int, edges [][2]int) *model.DAG {
+	ids := make([]uuid.UUID, nodes)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	d := &model.DAG{Id: uuid.New(), Title: "Graph DAG", Nodes: map[uuid.UUID]model.Node{}}
+	for i, id := range ids {
+		d.Nodes[id] = model.Node{Id: id, Question: fmt.Sprintf("Question %d?", i+1)}
+	}
+	for _, edge := range edges {
+		node := d.Nodes[ids[edge[0]]]
+		node.Answers = append(node.Answers, model.Answer{
+			Id:        uuid.New(),
+			Statement: fmt.Sprintf("Go to node %d", edge[1]+1),
+			NextNode:  &ids[edge[1]],
+		})
+		d.Nodes[node.Id] = node
+	}
+
+	return dagtest.Wire(d)
+}
+
+// closedChain returns a linear chain of length nodes whose last node leads back to the first one, and the first node ID
+func closedChain(length int) (*model.DAG, uuid.UUID) {
+	d := dagtest.LinearChain(length)
+	root := dagtest.Root(d).Id
+
+	last := root
+	for len(d.Nodes[last].Answers) > 0 {
+		last = *d.Nodes[last].Answers[0].NextNode
+	}
+
+	node := d.Nodes[last]
+	node.Answers = []model.Answer{{Id: uuid.New(), Statement: "Go back to the first node", NextNode: &root}}
+	d.Nodes[last] = node
+
+	return d, root
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// DAGChangeSet lists the structural changes turning a DAG into another one
+type DAGChangeSet struct {
+	// Title is nil when the title did not change
+	Title         *TextChange
+	AddedNodes    []NodeRef
+	RemovedNodes  []NodeRef
+	ModifiedNodes []NodeChange
+}
+
+// TextChange is a text before and after a change
+type TextChange struct {
+	Before string
+	After  string
+}
+
+// NodeRef identifies a node added or removed by a change
+type NodeRef struct {
+	NodeId   uuid.UUID
+	Question string
+}
+
+// AnswerRef identifies an answer added or removed by a change
+type AnswerRef struct {
+	AnswerId  uuid.UUID
+	Statement string
+	NextNode  *uuid.UUID
+}
+
+// NodeChange lists the changes of a node present on both sides
+type NodeChange struct {
+	NodeId uuid.UUID
+	// Question is nil when the question did not change
+	Question        *TextChange
+	AddedAnswers    []AnswerRef
+	RemovedAnswers  []AnswerRef
+	ModifiedAnswers []AnswerChange
+}
+
+// AnswerChange lists the changes of an answer present on both sides
+type AnswerChange struct {
+	AnswerId uuid.UUID
+	// Statement is nil when the statement did not change
+	Statement *TextChange
+	// NextNode is nil when the answer still leads to the same node
+	NextNode *LinkChange
+	// Disabled is nil when the answer was neither enabled nor disabled
+	Disabled *bool
+}
+
+// LinkChange is the node an answer leads to before and after a change, nil for terminal answers
+type LinkChange struct {
+	Before *uuid.UUID
+	After  *uuid.UUID
+}
+
+// IsEmpty tells whether both DAGs have the same structure
+func (c DAGChangeSet) IsEmpty() bool {
+	return c.Title == nil && len(c.AddedNodes) == 0 && len(c.RemovedNodes) == 0 && len(c.ModifiedNodes) == 0
+}
+
+// DAGDiff compares the structure of DAGs. Nodes and answers are matched by ID, metadata is ignored.
+type DAGDiff struct{}
+
+func NewDAGDiff() *DAGDiff {
+	return &DAGDiff{}
+}
+
+// Compare returns the changes turning before into after, nodes sorted by ID
+func (d *DAGDiff) Compare(before *model.DAG, after *model.DAG) DAGChangeSet {
+	changes := DAGChangeSet{
+		AddedNodes:    []NodeRef{},
+		RemovedNodes:  []NodeRef{},
+		ModifiedNodes: []NodeChange{},
+	}
+
+	if before.Title != after.Title {
+		changes.Title = &TextChange{Before: before.Title, After: after.Title}
+	}
+
+	for _, id := range sortedNodeIds(before) {
+		beforeNode := before.Nodes[id]
+		afterNode, ok := after.Nodes[id]
+		if !ok {
+			changes.RemovedNodes = append(changes.RemovedNodes, NodeRef{NodeId: id, Question: beforeNode.Question})
+			continue
+		}
+		if change, changed := compareNodes(beforeNode, afterNode); changed {
+			changes.ModifiedNodes = append(changes.ModifiedNodes, change)
+		}
+	}
+
+	for _, id := range sortedNodeIds(after) {
+		if _, ok := before.Nodes[id]; !ok {
+			changes.AddedNodes = append(changes.AddedNodes, NodeRef{NodeId: id, Question: after.Nodes[id].Question})
+		}
+	}
+
+	return changes
+}
+
+// compareNodes returns the changes of a node, answers are reported in the order of the node they belong to
+func compareNodes(before model.Node, after model.Node) (NodeChange, bool) {
+	change := NodeChange{NodeId: before.Id}
+	if before.Question != after.Question {
+		change.Question = &TextChange{Before: before.Question, After: after.Question}
+	}
+
+	afterAnswers := make(map[uuid.UUID]model.Answer, len(after.Answers))
+	for _, answer := range after.Answers {
+		afterAnswers[answer.Id] = answer
+	}
+
+	beforeAnswers := make(map[uuid.UUID]struct{}, len(before.Answers))
+	for _, beforeAnswer := range before.Answers {
+		beforeAnswers[beforeAnswer.Id] = struct{}{}
+
+		afterAnswer, ok := afterAnswers[beforeAnswer.Id]
+		if !ok {
+			change.RemovedAnswers = append(change.RemovedAnswers, newAnswerRef(beforeAnswer))
+			continue
+		}
+		if answerChange, changed := compareAnswers(beforeAnswer, afterAnswer); changed {
+			change.ModifiedAnswers = append(change.ModifiedAnswers, answerChange)
+		}
+	}
+
+	for _, afterAnswer := range after.Answers {
+		if _, ok := beforeAnswers[afterAnswer.Id]; !ok {
+			change.AddedAnswers = append(change.AddedAnswers, newAnswerRef(afterAnswer))
+		}
+	}
+
+	changed := change.Question != nil || len(change.AddedAnswers) > 0 || len(change.RemovedAnswers) > 0 || len(change.ModifiedAnswers) > 0
+	return change, changed
+}
+
+func compareAnswers(before model.Answer, after model.Answer) (AnswerChange, bool) {
+	change := AnswerChange{AnswerId: before.Id}
+	if before.Statement != after.Statement {
+		change.Statement = &TextChange{Before: before.Statement, After: after.Statement}
+	}
+	if !sameNextNode(before.NextNode, after.NextNode) {
+		change.NextNode = &LinkChange{Before: before.NextNode, After: after.NextNode}
+	}
+	if before.Disabled != after.Disabled {
+		disabled := after.Disabled
+		change.Disabled = &disabled
+	}
+
+	return change, change.Statement != nil || change.NextNode != nil || change.Disabled != nil
+}
+
+func newAnswerRef(answer model.Answer) AnswerRef {
+	return AnswerRef{
+		AnswerId:  answer.Id,
+		Statement: answer.Statement,
+		NextNode:  answer.NextNode,
+	}
+}
+
+func sameNextNode(a *uuid.UUID, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sortedNodeIds(d *model.DAG) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(d.Nodes))
+	for id := range d.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	return ids
+}
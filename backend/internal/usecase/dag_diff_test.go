@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGDiff_Compare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("identical DAGs have no changes", func(t *testing.T) {
+		t.Parallel()
+
+		before := dagtest.ValidSingleRoot()
+		changes := NewDAGDiff().Compare(before, cloneTestDAG(before))
+
+		assert.True(t, changes.IsEmpty())
+	})
+
+	t.Run("reports title and node changes", func(t *testing.T) {
+		t.Parallel()
+
+		before := dagtest.ValidSingleRoot()
+		root := dagtest.Root(before)
+		after := cloneTestDAG(before)
+		after.Title = "Renamed"
+
+		// Remove the leaf, add a new node and make the shortcut lead to it
+		var leafId, middleId uuid.UUID
+		for id, node := range before.Nodes {
+			switch {
+			case len(node.Answers) == 0:
+				leafId = id
+			case id != root.Id:
+				middleId = id
+			}
+		}
+		delete(after.Nodes, leafId)
+		newId := uuid.New()
+		after.Nodes[newId] = model.Node{Id: newId, Question: "New question?"}
+
+		afterRoot := after.Nodes[root.Id]
+		afterRoot.Question = "Reworded root?"
+		afterRoot.Answers[1].NextNode = &newId
+		afterRoot.Answers[1].Statement = "Go to new"
+		after.Nodes[root.Id] = afterRoot
+
+		afterMiddle := after.Nodes[middleId]
+		removed := afterMiddle.Answers[0]
+		added := model.Answer{Id: uuid.New(), Statement: "Go to new", NextNode: &newId}
+		afterMiddle.Answers = []model.Answer{afterMiddle.Answers[1], added}
+		afterMiddle.Answers[0].Disabled = true
+		after.Nodes[middleId] = afterMiddle
+
+		changes := NewDAGDiff().Compare(before, after)
+		require.False(t, changes.IsEmpty())
+
+		require.NotNil(t, changes.Title)
+		assert.Equal(t, TextChange{Before: before.Title, After: "Renamed"}, *changes.Title)
+		assert.Equal(t, []NodeRef{{NodeId: newId, Question: "New question?"}}, changes.AddedNodes)
+		assert.Equal(t, []NodeRef{{NodeId: leafId, Question: "Leaf question?"}}, changes.RemovedNodes)
+
+		modified := make(map[uuid.UUID]NodeChange)
+		for _, change := range changes.ModifiedNodes {
+			modified[change.NodeId] = change
+		}
+		require.Len(t, modified, 2)
+
+		rootChange := modified[root.Id]
+		require.NotNil(t, rootChange.Question)
+		assert.Equal(t, "Reworded root?", rootChange.Question.After)
+		require.Len(t, rootChange.ModifiedAnswers, 1)
+		answerChange := rootChange.ModifiedAnswers[0]
+		assert.Equal(t, root.Answers[1].Id, answerChange.AnswerId)
+		require.NotNil(t, answerChange.Statement)
+		require.NotNil(t, answerChange.NextNode)
+		assert.Equal(t, leafId, *answerChange.NextNode.Before)
+		assert.Equal(t, newId, *answerChange.NextNode.After)
+		assert.Nil(t, answerChange.Disabled)
+
+		middleChange := modified[middleId]
+		assert.Nil(t, middleChange.Question)
+		assert.Equal(t, []AnswerRef{{AnswerId: removed.Id, Statement: removed.Statement, NextNode: removed.NextNode}}, middleChange.RemovedAnswers)
+		assert.Equal(t, []AnswerRef{{AnswerId: added.Id, Statement: added.Statement, NextNode: &newId}}, middleChange.AddedAnswers)
+		require.Len(t, middleChange.ModifiedAnswers, 1)
+		require.NotNil(t, middleChange.ModifiedAnswers[0].Disabled)
+		assert.True(t, *middleChange.ModifiedAnswers[0].Disabled)
+	})
+
+	t.Run("a terminal answer gaining a next node is a link change", func(t *testing.T) {
+		t.Parallel()
+
+		before := dagtest.LinearChain(2)
+		after := cloneTestDAG(before)
+		for id, node := range after.Nodes {
+			if len(node.Answers) == 0 {
+				continue
+			}
+			node.Answers[0].NextNode = nil
+			after.Nodes[id] = node
+		}
+
+		changes := NewDAGDiff().Compare(before, after)
+		require.Len(t, changes.ModifiedNodes, 1)
+		require.Len(t, changes.ModifiedNodes[0].ModifiedAnswers, 1)
+		link := changes.ModifiedNodes[0].ModifiedAnswers[0].NextNode
+		require.NotNil(t, link)
+		assert.NotNil(t, link.Before)
+		assert.Nil(t, link.After)
+	})
+}
@@ -11,8 +11,17 @@ import (
 
 type DAGRepository interface {
 	List(ctx context.Context) ([]uuid.UUID, error)
+	// Query returns the page of DAGs matching the query along with the number of matching DAGs
+	Query(ctx context.Context, query model.DAGQuery) (*model.DAGPage, error)
 	Get(ctx context.Context, id uuid.UUID) (*model.DAG, error)
 	Create(ctx context.Context, dag *model.DAG) error
 	Update(ctx context.Context, id uuid.UUID, fnUpdate func(dag model.DAG) (model.DAG, error)) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Each yields the stored DAGs one at a time, stopping on the first error returned by fn. The yielded DAGs may
+	// be shared with the repository and must not be modified, changes go through Update
+	Each(ctx context.Context, fn func(dag *model.DAG) error) error
+	// Watch streams change events until the context is cancelled, at which point the channel is closed
+	Watch(ctx context.Context) (<-chan model.ChangeEvent, error)
+	// Observe calls fn on every change before the write returns, until stop is called; unlike Watch no change is missed
+	Observe(fn func(event model.ChangeEvent)) (stop func())
 }
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=dag_search_index.go -destination=testdata/mocks/dag_search_index_mock.go -package=mocks
+
+type DAGSearchIndex interface {
+	// Search returns the node texts matching every term of the query, at most limit hits unless limit is zero
+	Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error)
+}
@@ -3,6 +3,9 @@ package usecase
 import (
 	"davidterranova/jurigen/backend/internal/model"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -34,23 +37,52 @@ type ValidationWarning struct {
 
 // ValidationStatistics provides DAG structure statistics
 type ValidationStatistics struct {
-	TotalNodes   int      `json:"total_nodes"`
-	RootNodes    int      `json:"root_nodes"`
-	LeafNodes    int      `json:"leaf_nodes"`
-	TotalAnswers int      `json:"total_answers"`
-	MaxDepth     int      `json:"max_depth"`
-	HasCycles    bool     `json:"has_cycles"`
-	RootNodeIDs  []string `json:"root_node_ids,omitempty"`
-	LeafNodeIDs  []string `json:"leaf_node_ids,omitempty"`
-	CyclePaths   []string `json:"cycle_paths,omitempty"`
+	TotalNodes         int     `json:"total_nodes"`
+	RootNodes          int     `json:"root_nodes"`
+	LeafNodes          int     `json:"leaf_nodes"`
+	TotalAnswers       int     `json:"total_answers"`
+	MaxDepth           int     `json:"max_depth"`
+	HasCycles          bool    `json:"has_cycles"`
+	AvgBranchingFactor float64 `json:"avg_branching_factor"`
+	// TotalEstimatedDamages and TotalEstimatedDuration sum the estimates of every answer of the DAG
+	TotalEstimatedDamages  float64           `json:"total_estimated_damages,omitempty"`
+	TotalEstimatedDuration float64           `json:"total_estimated_duration,omitempty"`
+	BranchingHistogram     map[int]int       `json:"branching_histogram,omitempty"`
+	RootNodeIDs            []string          `json:"root_node_ids,omitempty"`
+	LeafNodeIDs            []string          `json:"leaf_node_ids,omitempty"`
+	CyclePaths             []model.CyclePath `json:"cycle_paths,omitempty"`
+	// TotalPaths, MinPathLength, MaxPathLength and AvgPathLength describe the root to leaf paths,
+	// their length being the number of answers given
+	TotalPaths    int     `json:"total_paths,omitempty"`
+	MinPathLength int     `json:"min_path_length,omitempty"`
+	MaxPathLength int     `json:"max_path_length,omitempty"`
+	AvgPathLength float64 `json:"avg_path_length,omitempty"`
+	// LeafPaths describes the paths ending on each node, sorted by node ID
+	LeafPaths []model.LeafPaths `json:"leaf_paths,omitempty"`
 }
 
+// excessiveBranchingFactor is the number of answers above which a question is hard to answer, nodes beyond it
+// are reported with a NODE_EXCESSIVE_BRANCHING warning
+const excessiveBranchingFactor = 10
+
 // DAGValidator provides comprehensive DAG validation functionality
-type DAGValidator struct{}
+type DAGValidator struct {
+	profile ValidationProfile
+}
 
-// NewDAGValidator creates a new DAG validator instance
+// NewDAGValidator creates a new DAG validator instance using the default profile
 func NewDAGValidator() *DAGValidator {
-	return &DAGValidator{}
+	return NewDAGValidatorFromProfile(DefaultValidationProfile())
+}
+
+// NewDAGValidatorFromProfile creates a new DAG validator applying the rules of the given profile
+func NewDAGValidatorFromProfile(profile ValidationProfile) *DAGValidator {
+	return &DAGValidator{profile: profile}
+}
+
+// Profile returns the validation profile applied by the validator
+func (v *DAGValidator) Profile() ValidationProfile {
+	return v.profile
 }
 
 // ValidateDAG performs comprehensive validation of a DAG structure
@@ -75,9 +107,17 @@ func (v *DAGValidator) ValidateDAG(d *model.DAG) ValidationResult {
 	// Perform all validations
 	v.validateBasicStructure(d, &result)
 	v.validateNodes(d, &result)
+	v.validateConditions(d, &result)
+	v.validateScoring(d, &result)
+	v.validateMetadataSchema(d, &result)
 	v.validateRootNode(d, &result)
 	v.validateCycles(d, &result)
+	v.validateLeafReachability(d, &result)
+	v.validateNodeReachability(d, &result)
+	v.validateEnabledReachability(d, &result)
 	v.calculateStatistics(d, &result)
+	v.validateProfile(d, &result)
+	v.applyRules(&result)
 
 	return result
 }
@@ -139,6 +179,126 @@ func (v *DAGValidator) validateNodes(d *model.DAG, result *ValidationResult) {
 
 		// Validate answers
 		v.validateAnswers(d, node, result)
+		v.validateRedundantAnswers(node, result)
+		v.validateMixedTerminalAnswers(node, result)
+		v.validateDuplicateStatements(node, result)
+		v.validateBranching(node, result)
+		v.validateNodeType(node, result)
+		v.validateMultiSelectNext(node, result)
+	}
+}
+
+// validateMultiSelectNext checks the rule resolving the next node of multi-select nodes, the answers of
+// convergence nodes must all lead to the same nodes
+func (v *DAGValidator) validateMultiSelectNext(node model.Node, result *ValidationResult) {
+	switch node.NextRule() {
+	case model.MultiSelectNextPriority:
+	case model.MultiSelectNextConvergence:
+		if !node.MultiSelect || len(node.Answers) < 2 {
+			return
+		}
+		first := node.Answers[0].Targets()
+		for _, answer := range node.Answers[1:] {
+			if reflect.DeepEqual(first, answer.Targets()) {
+				continue
+			}
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "NODE_MULTI_SELECT_DIVERGENT",
+				Message:  fmt.Sprintf("answers of convergence node %s lead to different nodes", node.Id),
+				NodeID:   node.Id.String(),
+				AnswerID: answer.Id.String(),
+				Severity: "error",
+			})
+			return
+		}
+	default:
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "NODE_INVALID_MULTI_SELECT_NEXT",
+			Message:  fmt.Sprintf("node %s has unknown multi-select next rule %q", node.Id, node.MultiSelectNext),
+			NodeID:   node.Id.String(),
+			Severity: "error",
+		})
+	}
+}
+
+// validateNodeType checks the answers of information, outcome and input nodes: information nodes advance through
+// at most one answer and outcome nodes end the walk, so they must be leaves carrying an assessment
+func (v *DAGValidator) validateNodeType(node model.Node, result *ValidationResult) {
+	switch node.Kind() {
+	case model.NodeTypeInformation:
+		if len(node.Answers) > 1 {
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "INFORMATION_NODE_MULTIPLE_ANSWERS",
+				Message:  fmt.Sprintf("information node %s has %d answers, it can advance through one answer at most", node.Id, len(node.Answers)),
+				NodeID:   node.Id.String(),
+				Severity: "error",
+			})
+		}
+	case model.NodeTypeOutcome:
+		if len(node.Answers) > 0 {
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "OUTCOME_NODE_NOT_LEAF",
+				Message:  fmt.Sprintf("outcome node %s has %d answers, outcome nodes must be leaves", node.Id, len(node.Answers)),
+				NodeID:   node.Id.String(),
+				Severity: "error",
+			})
+		}
+		if node.Outcome == nil || node.Outcome.Assessment == "" {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Code:    "OUTCOME_MISSING_ASSESSMENT",
+				Message: fmt.Sprintf("outcome node %s has no assessment", node.Id),
+				NodeID:  node.Id.String(),
+			})
+		}
+	case model.NodeTypeInput:
+		v.validateInput(node, result)
+	case model.NodeTypeQuestion:
+	default:
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "NODE_INVALID_TYPE",
+			Message:  fmt.Sprintf("node %s has unknown type %q", node.Id, node.Type),
+			NodeID:   node.Id.String(),
+			Severity: "error",
+		})
+	}
+}
+
+// validateInput checks input nodes advance through exactly one answer and declare a consistent input, with
+// the variable conditions refer to the entered value by
+func (v *DAGValidator) validateInput(node model.Node, result *ValidationResult) {
+	if len(node.Answers) != 1 {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "INPUT_NODE_ANSWERS",
+			Message:  fmt.Sprintf("input node %s has %d answers, it must advance through exactly one answer", node.Id, len(node.Answers)),
+			NodeID:   node.Id.String(),
+			Severity: "error",
+		})
+	}
+
+	if node.Input == nil || node.Input.Variable == "" {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "INPUT_MISSING_VARIABLE",
+			Message:  fmt.Sprintf("input node %s must declare the variable its value is stored under", node.Id),
+			NodeID:   node.Id.String(),
+			Severity: "error",
+		})
+		return
+	}
+	if err := node.Input.Check(); err != nil {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "INPUT_INVALID_DEFINITION",
+			Message:  fmt.Sprintf("input node %s has an invalid input: %s", node.Id, err),
+			NodeID:   node.Id.String(),
+			Severity: "error",
+		})
 	}
 }
 
@@ -181,7 +341,331 @@ func (v *DAGValidator) validateAnswers(d *model.DAG, node model.Node, result *Va
 				})
 			}
 		}
+
+		for _, branch := range answer.Conditions {
+			if _, exists := d.Nodes[branch.NextNode]; !exists {
+				result.IsValid = false
+				result.Errors = append(result.Errors, ValidationError{
+					Code:     "ANSWER_INVALID_REFERENCE",
+					Message:  fmt.Sprintf("answer %s references non-existent next node %s when %q", answer.Id, branch.NextNode, branch.When),
+					NodeID:   node.Id.String(),
+					AnswerID: answer.Id.String(),
+					Severity: "error",
+				})
+			}
+		}
+	}
+}
+
+// validateConditions rejects answer conditions which cannot be parsed and warns about conditions referring to
+// variables that no answer leading to them sets in its metadata, such variables are only known at runtime
+func (v *DAGValidator) validateConditions(d *model.DAG, result *ValidationResult) {
+	var parents map[uuid.UUID][]uuid.UUID
+
+	for _, nodeId := range sortedNodeIds(d) {
+		node := d.Nodes[nodeId]
+		for _, answer := range node.Answers {
+			var known map[string]bool
+			for _, branch := range answer.Conditions {
+				condition, err := model.ParseCondition(branch.When)
+				if err != nil {
+					result.IsValid = false
+					result.Errors = append(result.Errors, ValidationError{
+						Code:     "ANSWER_INVALID_CONDITION",
+						Message:  fmt.Sprintf("answer %s has an invalid condition: %s", answer.Id, err),
+						NodeID:   node.Id.String(),
+						AnswerID: answer.Id.String(),
+						Severity: "error",
+					})
+					continue
+				}
+
+				if parents == nil {
+					parents = parentNodes(d)
+				}
+				if known == nil {
+					known = knownVariables(d, parents, node.Id, answer)
+				}
+				for _, variable := range condition.Variables() {
+					if known[variable] {
+						continue
+					}
+					result.Warnings = append(result.Warnings, ValidationWarning{
+						Code:     "ANSWER_UNKNOWN_CONDITION_VARIABLE",
+						Message:  fmt.Sprintf("condition %q of answer %s refers to variable %q which no preceding answer sets", branch.When, answer.Id, variable),
+						NodeID:   node.Id.String(),
+						AnswerID: answer.Id.String(),
+					})
+				}
+			}
+		}
+	}
+}
+
+// validateScoring checks the declared score dimensions are named once with a non-negative weight, and warns
+// about answers contributing to dimensions the scoring configuration does not declare
+func (v *DAGValidator) validateScoring(d *model.DAG, result *ValidationResult) {
+	if d.Scoring == nil {
+		return
+	}
+
+	declared := make(map[string]bool, len(d.Scoring.Dimensions))
+	for i, dimension := range d.Scoring.Dimensions {
+		switch {
+		case dimension.Name == "":
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "SCORING_INVALID_DIMENSION",
+				Message:  fmt.Sprintf("score dimension %d must have a name", i),
+				Severity: "error",
+			})
+		case declared[dimension.Name]:
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "SCORING_INVALID_DIMENSION",
+				Message:  fmt.Sprintf("score dimension %q is declared more than once", dimension.Name),
+				Severity: "error",
+			})
+		case dimension.Weight < 0:
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "SCORING_INVALID_DIMENSION",
+				Message:  fmt.Sprintf("score dimension %q has a negative weight", dimension.Name),
+				Severity: "error",
+			})
+		}
+		declared[dimension.Name] = true
+	}
+
+	for _, nodeId := range sortedNodeIds(d) {
+		node := d.Nodes[nodeId]
+		for _, answer := range node.Answers {
+			names := make([]string, 0, len(answer.Scores))
+			for name := range answer.Scores {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				if declared[name] {
+					continue
+				}
+				result.Warnings = append(result.Warnings, ValidationWarning{
+					Code:     "ANSWER_UNKNOWN_SCORE_DIMENSION",
+					Message:  fmt.Sprintf("answer %s contributes to score dimension %q which the scoring does not declare", answer.Id, name),
+					NodeID:   node.Id.String(),
+					AnswerID: answer.Id.String(),
+				})
+			}
+		}
+	}
+}
+
+// validateMetadataSchema checks the metadata schema definition and the metadata of every answer against it
+func (v *DAGValidator) validateMetadataSchema(d *model.DAG, result *ValidationResult) {
+	if d.MetadataSchema == nil {
+		return
+	}
+
+	if err := d.MetadataSchema.Check(); err != nil {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "METADATA_SCHEMA_INVALID",
+			Message:  fmt.Sprintf("metadata schema is invalid: %s", err),
+			Severity: "error",
+		})
+		return
+	}
+
+	for _, nodeId := range sortedNodeIds(d) {
+		node := d.Nodes[nodeId]
+		for _, answer := range node.Answers {
+			if err := d.MetadataSchema.Validate(answer.Metadata); err != nil {
+				result.IsValid = false
+				result.Errors = append(result.Errors, ValidationError{
+					Code:     "ANSWER_METADATA_SCHEMA_VIOLATION",
+					Message:  fmt.Sprintf("answer %s metadata does not match the schema: %s", answer.Id, strings.ReplaceAll(err.Error(), "\n", "; ")),
+					NodeID:   node.Id.String(),
+					AnswerID: answer.Id.String(),
+					Severity: "error",
+				})
+			}
+		}
+	}
+}
+
+// parentNodes maps every node to the nodes having an answer which may lead to it
+func parentNodes(d *model.DAG) map[uuid.UUID][]uuid.UUID {
+	parents := make(map[uuid.UUID][]uuid.UUID)
+	for nodeId, node := range d.Nodes {
+		seen := make(map[uuid.UUID]bool)
+		for _, answer := range node.Answers {
+			for _, target := range answer.Targets() {
+				if !seen[target] {
+					seen[target] = true
+					parents[target] = append(parents[target], nodeId)
+				}
+			}
+		}
 	}
+	return parents
+}
+
+// knownVariables collects the metadata keys of the answer and of every answer of the nodes preceding its node,
+// along with the variables of the input nodes among them
+func knownVariables(d *model.DAG, parents map[uuid.UUID][]uuid.UUID, nodeId uuid.UUID, answer model.Answer) map[string]bool {
+	known := make(map[string]bool)
+	for key := range answer.Metadata {
+		known[key] = true
+	}
+	addInputVariable(known, d.Nodes[nodeId])
+
+	visited := map[uuid.UUID]bool{nodeId: true}
+	queue := append([]uuid.UUID(nil), parents[nodeId]...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		for _, ancestorAnswer := range d.Nodes[current].Answers {
+			for key := range ancestorAnswer.Metadata {
+				known[key] = true
+			}
+		}
+		addInputVariable(known, d.Nodes[current])
+		queue = append(queue, parents[current]...)
+	}
+
+	return known
+}
+
+func addInputVariable(known map[string]bool, node model.Node) {
+	if node.Kind() == model.NodeTypeInput && node.Input != nil && node.Input.Variable != "" {
+		known[node.Input.Variable] = true
+	}
+}
+
+// validateRedundantAnswers warns about answers of a single-select node that lead to the same next node
+// without any metadata difference, which usually indicates a modeling mistake
+func (v *DAGValidator) validateRedundantAnswers(node model.Node, result *ValidationResult) {
+	// Answers of multi-select nodes are selected together, leading to the same node is expected
+	if node.MultiSelect {
+		return
+	}
+
+	answersByTarget := make(map[uuid.UUID][]model.Answer)
+	var targets []uuid.UUID
+	for _, answer := range node.Answers {
+		if answer.NextNode == nil {
+			continue
+		}
+		if _, seen := answersByTarget[*answer.NextNode]; !seen {
+			targets = append(targets, *answer.NextNode)
+		}
+		answersByTarget[*answer.NextNode] = append(answersByTarget[*answer.NextNode], answer)
+	}
+
+	for _, target := range targets {
+		answers := answersByTarget[target]
+		if len(answers) < 2 || !sameMetadata(answers) {
+			continue
+		}
+
+		answerIDs := make([]string, len(answers))
+		for i, answer := range answers {
+			answerIDs[i] = answer.Id.String()
+		}
+
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Code:    "NODE_REDUNDANT_ANSWERS",
+			Message: fmt.Sprintf("answers %v in node %s all lead to node %s", answerIDs, node.Id, target),
+			NodeID:  node.Id.String(),
+		})
+	}
+}
+
+// validateMixedTerminalAnswers warns about nodes mixing terminal and non-terminal answers,
+// such nodes are not counted as leaves so authors should confirm the mix is intentional
+func (v *DAGValidator) validateMixedTerminalAnswers(node model.Node, result *ValidationResult) {
+	terminal := 0
+	for _, answer := range node.Answers {
+		if len(answer.Targets()) == 0 {
+			terminal++
+		}
+	}
+
+	if terminal == 0 || terminal == len(node.Answers) {
+		return
+	}
+
+	result.Warnings = append(result.Warnings, ValidationWarning{
+		Code:    "NODE_MIXED_TERMINAL_ANSWERS",
+		Message: fmt.Sprintf("node %s has %d terminal and %d non-terminal answers", node.Id, terminal, len(node.Answers)-terminal),
+		NodeID:  node.Id.String(),
+	})
+}
+
+// validateDuplicateStatements warns about answers repeating the statement of an earlier answer of the same node,
+// ignoring case and whitespace, users cannot tell such answers apart
+func (v *DAGValidator) validateDuplicateStatements(node model.Node, result *ValidationResult) {
+	firstAnswers := make(map[string]uuid.UUID, len(node.Answers))
+	for _, answer := range node.Answers {
+		statement := strings.ToLower(strings.Join(strings.Fields(answer.Statement), " "))
+		if statement == "" {
+			continue
+		}
+		first, seen := firstAnswers[statement]
+		if !seen {
+			firstAnswers[statement] = answer.Id
+			continue
+		}
+
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Code:     "ANSWER_DUPLICATE_STATEMENT",
+			Message:  fmt.Sprintf("answer %s in node %s repeats the statement %q of answer %s", answer.Id, node.Id, answer.Statement, first),
+			NodeID:   node.Id.String(),
+			AnswerID: answer.Id.String(),
+		})
+	}
+}
+
+// validateBranching warns about questions offering a single answer, which ask nothing, or too many answers
+func (v *DAGValidator) validateBranching(node model.Node, result *ValidationResult) {
+	// Information nodes have a single answer by design, outcome and input nodes are not answered by choice
+	if node.Kind() != model.NodeTypeQuestion {
+		return
+	}
+
+	switch {
+	case len(node.Answers) == 1:
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Code:    "NODE_SINGLE_ANSWER",
+			Message: fmt.Sprintf("node %s offers a single answer, consider an information node", node.Id),
+			NodeID:  node.Id.String(),
+		})
+	case len(node.Answers) > excessiveBranchingFactor:
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Code:    "NODE_EXCESSIVE_BRANCHING",
+			Message: fmt.Sprintf("node %s offers %d answers, more than %d is hard to answer", node.Id, len(node.Answers), excessiveBranchingFactor),
+			NodeID:  node.Id.String(),
+		})
+	}
+}
+
+// sameMetadata reports whether all answers carry identical metadata
+func sameMetadata(answers []model.Answer) bool {
+	for _, answer := range answers[1:] {
+		if len(answer.Metadata) == 0 && len(answers[0].Metadata) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(answer.Metadata, answers[0].Metadata) {
+			return false
+		}
+	}
+	return true
 }
 
 // validateRootNode ensures the DAG has exactly one root node
@@ -196,8 +680,8 @@ func (v *DAGValidator) validateRootNode(d *model.DAG, result *ValidationResult)
 
 	for _, node := range d.Nodes {
 		for _, answer := range node.Answers {
-			if answer.NextNode != nil {
-				referencedNodes[*answer.NextNode] = true
+			for _, target := range answer.Targets() {
+				referencedNodes[target] = true
 			}
 		}
 	}
@@ -217,7 +701,7 @@ func (v *DAGValidator) validateRootNode(d *model.DAG, result *ValidationResult)
 			Message:  "DAG has no root node - this indicates a circular reference",
 			Severity: "error",
 		})
-	} else if len(rootNodes) > 1 {
+	} else if len(rootNodes) > 1 && !v.profile.AllowForest {
 		result.IsValid = false
 		rootNodeIDs := make([]string, len(rootNodes))
 		for i, id := range rootNodes {
@@ -238,81 +722,191 @@ func (v *DAGValidator) validateRootNode(d *model.DAG, result *ValidationResult)
 	}
 }
 
-// validateCycles detects cycles in the DAG using DFS
+// validateCycles detects the elementary cycles of the DAG
 func (v *DAGValidator) validateCycles(d *model.DAG, result *ValidationResult) {
-	visited := make(map[uuid.UUID]bool)
-	inStack := make(map[uuid.UUID]bool)
-	cycles := []string{}
-
-	var dfs func(uuid.UUID, []uuid.UUID) bool
-	dfs = func(nodeId uuid.UUID, path []uuid.UUID) bool {
-		if inStack[nodeId] {
-			// Found a cycle - construct cycle path
-			cycleStart := -1
-			for i, id := range path {
-				if id == nodeId {
-					cycleStart = i
-					break
-				}
-			}
-			if cycleStart >= 0 {
-				cyclePath := make([]string, len(path[cycleStart:])+1)
-				for i, id := range path[cycleStart:] {
-					cyclePath[i] = id.String()
-				}
-				cyclePath[len(cyclePath)-1] = nodeId.String() // Close the cycle
-				cycles = append(cycles, fmt.Sprintf("%v", cyclePath))
-			}
-			return true
+	cycles, truncated := findCycles(d)
+
+	cyclePaths := make([]model.CyclePath, len(cycles))
+	for i, cycle := range cycles {
+		nodeIDs := make([]string, len(cycle.nodes))
+		for j, id := range cycle.nodes {
+			nodeIDs[j] = id.String()
 		}
+		cyclePaths[i] = model.CyclePath{NodeIDs: nodeIDs, ClosingAnswerID: cycle.closingAnswer.String()}
+	}
+	hasCycles := len(cyclePaths) > 0
 
-		if visited[nodeId] {
-			return false
+	result.Statistics.HasCycles = hasCycles
+	result.Statistics.CyclePaths = cyclePaths
+
+	if hasCycles {
+		count := fmt.Sprintf("%d cycle(s)", len(cyclePaths))
+		if truncated {
+			count = fmt.Sprintf("at least %d cycles (only the first %d are reported)", maxCyclePaths, maxCyclePaths)
 		}
 
-		visited[nodeId] = true
-		inStack[nodeId] = true
-		//nolint:gocritic // This is a valid use of append
-		newPath := append(path, nodeId)
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "DAG_HAS_CYCLES",
+			Message:  fmt.Sprintf("DAG contains %s. A valid DAG must be acyclic", count),
+			Severity: "error",
+		})
+	}
+}
 
-		node, exists := d.Nodes[nodeId]
-		if exists {
-			for _, answer := range node.Answers {
-				if answer.NextNode != nil {
-					if dfs(*answer.NextNode, newPath) {
-						return true
-					}
-				}
-			}
+// Cycles returns the node IDs of the elementary cycles of the DAG, each cycle closed on its first node
+func (v *DAGValidator) Cycles(d *model.DAG) [][]uuid.UUID {
+	cycles, _ := findCycles(d)
+
+	paths := make([][]uuid.UUID, len(cycles))
+	for i, cycle := range cycles {
+		paths[i] = cycle.nodes
+	}
+	return paths
+}
+
+// validateLeafReachability warns about each leaf node that cannot be reached from the root node. A leaf detached
+// from the rest of the DAG is a root of its own, it is reported as long as a single root asks questions.
+func (v *DAGValidator) validateLeafReachability(d *model.DAG, result *ValidationResult) {
+	rootID, ok := questionRoot(d, result.Statistics.RootNodeIDs)
+	if !ok {
+		return
+	}
+
+	reachable := reachableNodes(d, rootID, false)
+
+	unreachableLeaves := []string{}
+	for nodeId, node := range d.Nodes {
+		if isLeafNode(node) && !reachable[nodeId] {
+			unreachableLeaves = append(unreachableLeaves, nodeId.String())
 		}
+	}
+	sort.Strings(unreachableLeaves)
 
-		inStack[nodeId] = false
-		return false
+	for _, nodeId := range unreachableLeaves {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Code:    "LEAF_UNREACHABLE",
+			Message: fmt.Sprintf("Leaf node %s cannot be reached from root node %s", nodeId, rootID),
+			NodeID:  nodeId,
+		})
 	}
+}
 
-	// Check for cycles from each unvisited node
-	hasCycles := false
-	for nodeId := range d.Nodes {
-		if !visited[nodeId] {
-			if dfs(nodeId, []uuid.UUID{}) {
-				hasCycles = true
-			}
+// questionRoot returns the root node of the DAG, ignoring the leaves which are roots only because they are
+// detached. It is not found when several roots ask questions.
+func questionRoot(d *model.DAG, rootNodeIDs []string) (uuid.UUID, bool) {
+	var rootID uuid.UUID
+	found := 0
+	for _, id := range rootNodeIDs {
+		nodeId, err := uuid.Parse(id)
+		if err != nil {
+			return uuid.Nil, false
+		}
+		if len(rootNodeIDs) == 1 || !isLeafNode(d.Nodes[nodeId]) {
+			rootID = nodeId
+			found++
 		}
 	}
+	return rootID, found == 1
+}
 
-	result.Statistics.HasCycles = hasCycles
-	result.Statistics.CyclePaths = cycles
+// validateNodeReachability warns about each inner node that cannot be reached from the single root node,
+// unreachable leaves being reported by validateLeafReachability
+func (v *DAGValidator) validateNodeReachability(d *model.DAG, result *ValidationResult) {
+	if len(result.Statistics.RootNodeIDs) != 1 {
+		return
+	}
 
-	if hasCycles {
-		result.IsValid = false
-		result.Errors = append(result.Errors, ValidationError{
-			Code:     "DAG_HAS_CYCLES",
-			Message:  fmt.Sprintf("DAG contains %d cycle(s). A valid DAG must be acyclic", len(cycles)),
-			Severity: "error",
+	rootID, err := uuid.Parse(result.Statistics.RootNodeIDs[0])
+	if err != nil {
+		return
+	}
+
+	reachable := reachableNodes(d, rootID, false)
+
+	unreachable := []string{}
+	for nodeId, node := range d.Nodes {
+		if !isLeafNode(node) && !reachable[nodeId] {
+			unreachable = append(unreachable, nodeId.String())
+		}
+	}
+	sort.Strings(unreachable)
+
+	for _, nodeId := range unreachable {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Code:    "NODE_UNREACHABLE",
+			Message: fmt.Sprintf("Node %s cannot be reached from root node %s", nodeId, rootID),
+			NodeID:  nodeId,
 		})
 	}
 }
 
+// validateEnabledReachability warns about nodes reachable from the single root node only through disabled answers,
+// such nodes are structurally referenced but can never be asked at runtime
+func (v *DAGValidator) validateEnabledReachability(d *model.DAG, result *ValidationResult) {
+	if len(result.Statistics.RootNodeIDs) != 1 {
+		return
+	}
+
+	rootID, err := uuid.Parse(result.Statistics.RootNodeIDs[0])
+	if err != nil {
+		return
+	}
+
+	reachable := reachableNodes(d, rootID, false)
+	reachableViaEnabled := reachableNodes(d, rootID, true)
+
+	unreachable := []string{}
+	for nodeId := range reachable {
+		if !reachableViaEnabled[nodeId] {
+			unreachable = append(unreachable, nodeId.String())
+		}
+	}
+	sort.Strings(unreachable)
+
+	for _, nodeId := range unreachable {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Code:    "NODE_UNREACHABLE_VIA_ENABLED",
+			Message: fmt.Sprintf("Node %s can only be reached from root node %s through disabled answers", nodeId, rootID),
+			NodeID:  nodeId,
+		})
+	}
+}
+
+// reachableNodes collects every node reachable from the root, following disabled answers unless skipDisabled is set
+func reachableNodes(d *model.DAG, rootID uuid.UUID, skipDisabled bool) map[uuid.UUID]bool {
+	reachable := map[uuid.UUID]bool{rootID: true}
+	queue := []uuid.UUID{rootID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, answer := range d.Nodes[current].Answers {
+			if skipDisabled && answer.Disabled {
+				continue
+			}
+			for _, target := range answer.Targets() {
+				if !reachable[target] {
+					reachable[target] = true
+					queue = append(queue, target)
+				}
+			}
+		}
+	}
+
+	return reachable
+}
+
+// isLeafNode reports whether a node has no answers leading to another node
+func isLeafNode(node model.Node) bool {
+	for _, answer := range node.Answers {
+		if len(answer.Targets()) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // calculateStatistics computes various DAG statistics
 func (v *DAGValidator) calculateStatistics(d *model.DAG, result *ValidationResult) {
 	result.Statistics.TotalNodes = len(d.Nodes)
@@ -320,33 +914,40 @@ func (v *DAGValidator) calculateStatistics(d *model.DAG, result *ValidationResul
 	// Count leaf nodes and total answers
 	leafNodes := []string{}
 	totalAnswers := 0
+	branchingHistogram := make(map[int]int)
+	nonLeafNodes := 0
+	nonLeafAnswers := 0
 
 	for nodeId, node := range d.Nodes {
 		totalAnswers += len(node.Answers)
+		branchingHistogram[len(node.Answers)]++
 
 		// A leaf node is one where all answers have no next_node
-		isLeaf := len(node.Answers) == 0 || func() bool {
-			for _, answer := range node.Answers {
-				if answer.NextNode != nil {
-					return false
-				}
-			}
-			return true
-		}()
-
-		if isLeaf {
+		if isLeafNode(node) {
 			leafNodes = append(leafNodes, nodeId.String())
+		} else {
+			nonLeafNodes++
+			nonLeafAnswers += len(node.Answers)
 		}
 	}
 
 	result.Statistics.LeafNodes = len(leafNodes)
 	result.Statistics.LeafNodeIDs = leafNodes
 	result.Statistics.TotalAnswers = totalAnswers
+	result.Statistics.BranchingHistogram = branchingHistogram
+
+	// Average branching factor only considers nodes that lead somewhere
+	if nonLeafNodes > 0 {
+		result.Statistics.AvgBranchingFactor = float64(nonLeafAnswers) / float64(nonLeafNodes)
+	}
+
+	v.calculateCostRollup(d, result)
 
 	// Calculate maximum depth using BFS from root nodes
 	if len(result.Statistics.RootNodeIDs) > 0 && !result.Statistics.HasCycles {
 		maxDepth := v.calculateMaxDepth(d, result.Statistics.RootNodeIDs[0])
 		result.Statistics.MaxDepth = maxDepth
+		v.calculatePathStatistics(d, result.Statistics.RootNodeIDs[0], result)
 	}
 }
 
@@ -385,11 +986,14 @@ func (v *DAGValidator) calculateMaxDepth(d *model.DAG, rootNodeID string) int {
 		node, exists := d.Nodes[current.nodeId]
 		if exists {
 			for _, answer := range node.Answers {
-				if answer.NextNode != nil && !visited[*answer.NextNode] {
+				for _, target := range answer.Targets() {
+					if visited[target] {
+						continue
+					}
 					queue = append(queue, struct {
 						nodeId uuid.UUID
 						depth  int
-					}{*answer.NextNode, current.depth + 1})
+					}{target, current.depth + 1})
 				}
 			}
 		}
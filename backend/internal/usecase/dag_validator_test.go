@@ -1,12 +1,14 @@
 package usecase
 
 import (
+	"davidterranova/jurigen/backend/internal/dagtest"
 	"davidterranova/jurigen/backend/internal/model"
 	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDAGValidator(t *testing.T) {
@@ -34,7 +36,7 @@ func TestDAGValidator_ValidateDAG(t *testing.T) {
 		},
 		{
 			name:               "valid single root DAG",
-			dag:                createValidSingleRootDAG(),
+			dag:                dagtest.ValidSingleRoot(),
 			expectValid:        true,
 			expectedErrorCodes: []string{},
 			expectedStats: ValidationStatistics{
@@ -78,13 +80,13 @@ func TestDAGValidator_ValidateDAG(t *testing.T) {
 		},
 		{
 			name:               "DAG with multiple root nodes",
-			dag:                createMultipleRootDAG(),
+			dag:                dagtest.MultipleRoots(),
 			expectValid:        false,
 			expectedErrorCodes: []string{"DAG_MULTIPLE_ROOTS"},
 		},
 		{
 			name:               "DAG with cycles",
-			dag:                createCyclicDAG(),
+			dag:                dagtest.Cyclic(),
 			expectValid:        false,
 			expectedErrorCodes: []string{"DAG_HAS_CYCLES"},
 		},
@@ -145,11 +147,11 @@ func TestDAGValidator_IsValidDAG(t *testing.T) {
 	validator := NewDAGValidator()
 
 	// Test valid DAG
-	validDAG := createValidSingleRootDAG()
+	validDAG := dagtest.ValidSingleRoot()
 	assert.True(t, validator.IsValidDAG(validDAG))
 
 	// Test invalid DAG
-	invalidDAG := createCyclicDAG()
+	invalidDAG := dagtest.Cyclic()
 	assert.False(t, validator.IsValidDAG(invalidDAG))
 
 	// Test nil DAG
@@ -167,7 +169,7 @@ func TestDAGValidator_CycleDetection(t *testing.T) {
 	}{
 		{
 			name:          "no cycles",
-			dag:           createValidSingleRootDAG(),
+			dag:           dagtest.ValidSingleRoot(),
 			expectCycles:  false,
 			expectedPaths: 0,
 		},
@@ -195,6 +197,12 @@ func TestDAGValidator_CycleDetection(t *testing.T) {
 			assert.Equal(t, tt.expectCycles, result.Statistics.HasCycles)
 			assert.Equal(t, tt.expectedPaths, len(result.Statistics.CyclePaths))
 
+			cycles := validator.Cycles(tt.dag)
+			require.Len(t, cycles, tt.expectedPaths)
+			for _, cycle := range cycles {
+				assert.Equal(t, cycle[0], cycle[len(cycle)-1], "cycles are closed on their first node")
+			}
+
 			if tt.expectCycles {
 				assert.False(t, result.IsValid)
 				// Should have DAG_HAS_CYCLES error
@@ -221,17 +229,17 @@ func TestDAGValidator_DepthCalculation(t *testing.T) {
 	}{
 		{
 			name:          "single node",
-			dag:           createSingleNodeDAG(),
+			dag:           dagtest.SingleNode(),
 			expectedDepth: 0,
 		},
 		{
 			name:          "linear chain",
-			dag:           createLinearChainDAG(5),
+			dag:           dagtest.LinearChain(5),
 			expectedDepth: 4,
 		},
 		{
 			name:          "branched DAG",
-			dag:           createValidSingleRootDAG(),
+			dag:           dagtest.ValidSingleRoot(),
 			expectedDepth: 1,
 		},
 	}
@@ -250,149 +258,883 @@ func TestDAGValidator_DepthCalculation(t *testing.T) {
 	}
 }
 
-// Helper functions to create test DAGs
+func TestDAGValidator_BranchingStatistics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		dag               *model.DAG
+		expectedAvg       float64
+		expectedHistogram map[int]int
+	}{
+		{
+			name:              "single node",
+			dag:               dagtest.SingleNode(),
+			expectedAvg:       0,
+			expectedHistogram: map[int]int{0: 1},
+		},
+		{
+			name:              "linear chain",
+			dag:               dagtest.LinearChain(4),
+			expectedAvg:       1,
+			expectedHistogram: map[int]int{0: 1, 1: 3},
+		},
+		{
+			name:              "varied fan-out",
+			dag:               createVariedFanOutDAG(),
+			expectedAvg:       2.5,
+			expectedHistogram: map[int]int{0: 3, 2: 1, 3: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			validator := NewDAGValidator()
+			result := validator.ValidateDAG(tt.dag)
+
+			assert.InDelta(t, tt.expectedAvg, result.Statistics.AvgBranchingFactor, 0.0001)
+			assert.Equal(t, tt.expectedHistogram, result.Statistics.BranchingHistogram)
+		})
+	}
+}
+
+func TestDAGValidator_LeafReachability(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no warning when every leaf is reachable", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(dagtest.ValidSingleRoot())
+
+		for _, warning := range result.Warnings {
+			assert.NotEqual(t, "LEAF_UNREACHABLE", warning.Code)
+		}
+	})
+
+	leafWarnings := func(result ValidationResult) []ValidationWarning {
+		warnings := []ValidationWarning{}
+		for _, warning := range result.Warnings {
+			if warning.Code == "LEAF_UNREACHABLE" {
+				warnings = append(warnings, warning)
+			}
+		}
+		return warnings
+	}
+
+	t.Run("warns about leaf reachable only from a side cycle", func(t *testing.T) {
+		t.Parallel()
+
+		d, detachedLeafID := createDetachedLeafDAG()
+		result := NewDAGValidator().ValidateDAG(d)
+
+		warnings := leafWarnings(result)
+		require.Len(t, warnings, 1, "Expected LEAF_UNREACHABLE warning")
+		assert.Equal(t, detachedLeafID.String(), warnings[0].NodeID)
+		assert.Contains(t, warnings[0].Message, detachedLeafID.String())
+	})
+
+	t.Run("warns about each leaf detached from the rest of the DAG", func(t *testing.T) {
+		t.Parallel()
+
+		d := dagtest.ValidSingleRoot()
+		detachedIDs := []string{}
+		for i := 0; i < 2; i++ {
+			id := uuid.New()
+			d.Nodes[id] = model.Node{Id: id, Question: "Detached leaf?", Answers: []model.Answer{}}
+			detachedIDs = append(detachedIDs, id.String())
+		}
+
+		result := NewDAGValidator().ValidateDAG(d)
+
+		// The detached leaves are roots of their own
+		require.NotEmpty(t, result.Errors)
+		assert.Equal(t, "DAG_MULTIPLE_ROOTS", result.Errors[0].Code)
+		nodeIDs := []string{}
+		for _, warning := range leafWarnings(result) {
+			nodeIDs = append(nodeIDs, warning.NodeID)
+		}
+		assert.ElementsMatch(t, detachedIDs, nodeIDs)
+	})
+
+	t.Run("skipped when DAG has multiple roots", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(dagtest.MultipleRoots())
+
+		for _, warning := range result.Warnings {
+			assert.NotEqual(t, "LEAF_UNREACHABLE", warning.Code)
+		}
+	})
+}
+
+func TestDAGValidator_StructureWarnings(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.New()
+	leafID := uuid.New()
+	toLeaf := func(statement string) model.Answer {
+		return model.Answer{Id: uuid.New(), Statement: statement, NextNode: &leafID}
+	}
+	newDAG := func(answers ...model.Answer) *model.DAG {
+		return &model.DAG{
+			Id:    uuid.New(),
+			Title: "Structure DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {Id: rootID, Question: "Root?", Answers: answers},
+				leafID: {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
+			},
+		}
+	}
+	warningsOf := func(result ValidationResult, code string) []ValidationWarning {
+		var warnings []ValidationWarning
+		for _, warning := range result.Warnings {
+			if warning.Code == code {
+				warnings = append(warnings, warning)
+			}
+		}
+		return warnings
+	}
+
+	t.Run("no warning for a well formed question", func(t *testing.T) {
+		t.Parallel()
+
+		no := toLeaf("No")
+		no.Metadata = map[string]interface{}{"confidence": 0.2}
+		result := NewDAGValidator().ValidateDAG(newDAG(toLeaf("Yes"), no))
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("warns about answers repeating a statement", func(t *testing.T) {
+		t.Parallel()
+
+		duplicate := toLeaf("  yes ")
+		result := NewDAGValidator().ValidateDAG(newDAG(toLeaf("Yes"), toLeaf("No"), duplicate))
+
+		assert.True(t, result.IsValid, "the warning is advisory")
+		warnings := warningsOf(result, "ANSWER_DUPLICATE_STATEMENT")
+		require.Len(t, warnings, 1)
+		assert.Equal(t, rootID.String(), warnings[0].NodeID)
+		assert.Equal(t, duplicate.Id.String(), warnings[0].AnswerID)
+	})
+
+	t.Run("warns about questions with a single answer", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG(toLeaf("Continue")))
+
+		warnings := warningsOf(result, "NODE_SINGLE_ANSWER")
+		require.Len(t, warnings, 1)
+		assert.Equal(t, rootID.String(), warnings[0].NodeID)
+	})
+
+	t.Run("information nodes advance through a single answer", func(t *testing.T) {
+		t.Parallel()
+
+		d := newDAG(toLeaf("Continue"))
+		root := d.Nodes[rootID]
+		root.Type = model.NodeTypeInformation
+		d.Nodes[rootID] = root
+
+		result := NewDAGValidator().ValidateDAG(d)
+		assert.Empty(t, warningsOf(result, "NODE_SINGLE_ANSWER"))
+	})
+
+	t.Run("warns about excessive branching", func(t *testing.T) {
+		t.Parallel()
+
+		answers := make([]model.Answer, excessiveBranchingFactor+1)
+		for i := range answers {
+			answers[i] = model.Answer{Id: uuid.New(), Statement: fmt.Sprintf("Option %d", i)}
+		}
+		result := NewDAGValidator().ValidateDAG(newDAG(answers...))
+
+		warnings := warningsOf(result, "NODE_EXCESSIVE_BRANCHING")
+		require.Len(t, warnings, 1)
+		assert.Equal(t, rootID.String(), warnings[0].NodeID)
+		assert.Contains(t, warnings[0].Message, "11 answers")
+	})
+
+	t.Run("warns about each inner node unreachable from the root", func(t *testing.T) {
+		t.Parallel()
+
+		d := newDAG(toLeaf("Yes"), toLeaf("No"))
+		firstID, secondID := uuid.New(), uuid.New()
+		d.Nodes[firstID] = model.Node{Id: firstID, Question: "First?", Answers: []model.Answer{
+			{Id: uuid.New(), Statement: "Yes", NextNode: &secondID},
+			{Id: uuid.New(), Statement: "No", NextNode: &leafID},
+		}}
+		d.Nodes[secondID] = model.Node{Id: secondID, Question: "Second?", Answers: []model.Answer{
+			{Id: uuid.New(), Statement: "Yes", NextNode: &firstID},
+			{Id: uuid.New(), Statement: "No", NextNode: &leafID},
+		}}
+
+		result := NewDAGValidator().ValidateDAG(d)
+
+		warnings := warningsOf(result, "NODE_UNREACHABLE")
+		require.Len(t, warnings, 2)
+		assert.ElementsMatch(t, []string{firstID.String(), secondID.String()}, []string{warnings[0].NodeID, warnings[1].NodeID})
+	})
+}
+
+func TestDAGValidator_EnabledReachability(t *testing.T) {
+	t.Parallel()
 
-func createValidSingleRootDAG() *model.DAG {
 	rootID := uuid.New()
 	middleID := uuid.New()
 	leafID := uuid.New()
 
-	answer1ID := uuid.New()
-	answer2ID := uuid.New()
-	answer3ID := uuid.New()
-	answer4ID := uuid.New()
+	newDAG := func(disabled bool) *model.DAG {
+		return &model.DAG{
+			Id:    uuid.New(),
+			Title: "Disabled Answers DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {Id: rootID, Question: "Root?", Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Continue", NextNode: &middleID, Disabled: disabled},
+					{Id: uuid.New(), Statement: "Stop"},
+				}},
+				middleID: {Id: middleID, Question: "Middle?", Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Go to leaf", NextNode: &leafID},
+				}},
+				leafID: {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
+			},
+		}
+	}
 
-	return &model.DAG{
-		Id:    uuid.New(),
-		Title: "Valid Single Root DAG",
-		Nodes: map[uuid.UUID]model.Node{
-			rootID: {
-				Id:       rootID,
-				Question: "Root question?",
-				Answers: []model.Answer{
-					{
-						Id:        answer1ID,
-						Statement: "Go to middle",
-						NextNode:  &middleID,
-					},
-					{
-						Id:        answer2ID,
-						Statement: "Go to leaf",
-						NextNode:  &leafID,
-					},
-				},
+	enabledReachabilityWarnings := func(result ValidationResult) []ValidationWarning {
+		var warnings []ValidationWarning
+		for _, warning := range result.Warnings {
+			if warning.Code == "NODE_UNREACHABLE_VIA_ENABLED" {
+				warnings = append(warnings, warning)
+			}
+		}
+		return warnings
+	}
+
+	t.Run("no warning when every answer is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG(false))
+		assert.Empty(t, enabledReachabilityWarnings(result))
+	})
+
+	t.Run("warns about nodes only reached through a disabled answer", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG(true))
+
+		assert.True(t, result.IsValid, "the warning is advisory")
+		for _, warning := range result.Warnings {
+			assert.NotEqual(t, "LEAF_UNREACHABLE", warning.Code, "structural reachability still holds")
+		}
+
+		warnings := enabledReachabilityWarnings(result)
+		require.Len(t, warnings, 2)
+		nodeIDs := []string{warnings[0].NodeID, warnings[1].NodeID}
+		assert.ElementsMatch(t, []string{middleID.String(), leafID.String()}, nodeIDs)
+	})
+}
+
+func TestDAGValidator_RedundantAnswers(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.New()
+	leafID := uuid.New()
+	otherLeafID := uuid.New()
+
+	newDAG := func(answers []model.Answer) *model.DAG {
+		return &model.DAG{
+			Id:    uuid.New(),
+			Title: "Redundant Answers DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID:      {Id: rootID, Question: "Root?", Answers: answers},
+				leafID:      {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
+				otherLeafID: {Id: otherLeafID, Question: "Other leaf?", Answers: []model.Answer{}},
 			},
-			middleID: {
-				Id:       middleID,
-				Question: "Middle question?",
-				Answers: []model.Answer{
-					{
-						Id:        answer3ID,
-						Statement: "Go to leaf",
-						NextNode:  &leafID,
-					},
-					{
-						Id:        answer4ID,
-						Statement: "Stay here",
-						NextNode:  nil, // Leaf answer
-					},
-				},
+		}
+	}
+
+	duplicateID1 := uuid.New()
+	duplicateID2 := uuid.New()
+
+	tests := []struct {
+		name          string
+		answers       []model.Answer
+		expectWarning bool
+	}{
+		{
+			name: "answers sharing a target",
+			answers: []model.Answer{
+				{Id: duplicateID1, Statement: "Yes", NextNode: &leafID},
+				{Id: duplicateID2, Statement: "Absolutely", NextNode: &leafID},
+				{Id: uuid.New(), Statement: "No", NextNode: &otherLeafID},
+			},
+			expectWarning: true,
+		},
+		{
+			name: "answers with distinct targets",
+			answers: []model.Answer{
+				{Id: uuid.New(), Statement: "Yes", NextNode: &leafID},
+				{Id: uuid.New(), Statement: "No", NextNode: &otherLeafID},
 			},
-			leafID: {
-				Id:       leafID,
-				Question: "Leaf question?",
-				Answers:  []model.Answer{}, // Leaf node
+			expectWarning: false,
+		},
+		{
+			name: "answers sharing a target with different metadata",
+			answers: []model.Answer{
+				{Id: uuid.New(), Statement: "Yes", NextNode: &leafID, Metadata: map[string]interface{}{"severity": "high"}},
+				{Id: uuid.New(), Statement: "Somewhat", NextNode: &leafID, Metadata: map[string]interface{}{"severity": "low"}},
+				{Id: uuid.New(), Statement: "No", NextNode: &otherLeafID},
 			},
+			expectWarning: false,
 		},
 	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewDAGValidator().ValidateDAG(newDAG(tt.answers))
+
+			var warnings []ValidationWarning
+			for _, warning := range result.Warnings {
+				if warning.Code == "NODE_REDUNDANT_ANSWERS" {
+					warnings = append(warnings, warning)
+				}
+			}
+
+			if !tt.expectWarning {
+				assert.Empty(t, warnings)
+				return
+			}
+
+			require.Len(t, warnings, 1)
+			assert.Equal(t, rootID.String(), warnings[0].NodeID)
+			assert.Contains(t, warnings[0].Message, duplicateID1.String())
+			assert.Contains(t, warnings[0].Message, duplicateID2.String())
+			assert.True(t, result.IsValid, "redundant answers are advisory only")
+		})
+	}
 }
 
-func createMultipleRootDAG() *model.DAG {
-	root1ID := uuid.New()
-	root2ID := uuid.New()
+func TestDAGValidator_MixedTerminalAnswers(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.New()
 	leafID := uuid.New()
+	otherLeafID := uuid.New()
 
-	return &model.DAG{
-		Id:    uuid.New(),
-		Title: "Multiple Root DAG",
-		Nodes: map[uuid.UUID]model.Node{
-			root1ID: {
-				Id:       root1ID,
-				Question: "Root 1 question?",
-				Answers: []model.Answer{
-					{
-						Id:        uuid.New(),
-						Statement: "Go to leaf",
-						NextNode:  &leafID,
-					},
-				},
+	newDAG := func(answers []model.Answer) *model.DAG {
+		return &model.DAG{
+			Id:    uuid.New(),
+			Title: "Mixed Terminal Answers DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID:      {Id: rootID, Question: "Root?", Answers: answers},
+				leafID:      {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
+				otherLeafID: {Id: otherLeafID, Question: "Other leaf?", Answers: []model.Answer{}},
 			},
-			root2ID: {
-				Id:       root2ID,
-				Question: "Root 2 question?",
-				Answers: []model.Answer{
-					{
-						Id:        uuid.New(),
-						Statement: "Go to leaf",
-						NextNode:  &leafID,
-					},
-				},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		answers       []model.Answer
+		expectWarning bool
+	}{
+		{
+			name: "all terminal answers",
+			answers: []model.Answer{
+				{Id: uuid.New(), Statement: "Yes"},
+				{Id: uuid.New(), Statement: "No"},
 			},
-			leafID: {
-				Id:       leafID,
-				Question: "Leaf question?",
-				Answers:  []model.Answer{},
+			expectWarning: false,
+		},
+		{
+			name: "all linked answers",
+			answers: []model.Answer{
+				{Id: uuid.New(), Statement: "Yes", NextNode: &leafID},
+				{Id: uuid.New(), Statement: "No", NextNode: &otherLeafID},
 			},
+			expectWarning: false,
 		},
+		{
+			name: "mixed terminal and linked answers",
+			answers: []model.Answer{
+				{Id: uuid.New(), Statement: "Yes", NextNode: &leafID},
+				{Id: uuid.New(), Statement: "Maybe", NextNode: &otherLeafID},
+				{Id: uuid.New(), Statement: "No"},
+			},
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewDAGValidator().ValidateDAG(newDAG(tt.answers))
+
+			var warnings []ValidationWarning
+			for _, warning := range result.Warnings {
+				if warning.Code == "NODE_MIXED_TERMINAL_ANSWERS" {
+					warnings = append(warnings, warning)
+				}
+			}
+
+			if !tt.expectWarning {
+				assert.Empty(t, warnings)
+				return
+			}
+
+			require.Len(t, warnings, 1)
+			assert.Equal(t, rootID.String(), warnings[0].NodeID)
+			assert.Contains(t, warnings[0].Message, "1 terminal and 2 non-terminal")
+		})
 	}
 }
 
-func createCyclicDAG() *model.DAG {
-	node1ID := uuid.New()
-	node2ID := uuid.New()
-	node3ID := uuid.New()
+func TestDAGValidator_Conditions(t *testing.T) {
+	t.Parallel()
 
-	return &model.DAG{
-		Id:    uuid.New(),
-		Title: "Cyclic DAG",
-		Nodes: map[uuid.UUID]model.Node{
-			node1ID: {
-				Id:       node1ID,
-				Question: "Node 1 question?",
-				Answers: []model.Answer{
-					{
-						Id:        uuid.New(),
-						Statement: "Go to node 2",
-						NextNode:  &node2ID,
-					},
-				},
+	rootID := uuid.New()
+	middleID := uuid.New()
+	urgentID := uuid.New()
+	leafID := uuid.New()
+
+	newDAG := func(conditions []model.Branch) *model.DAG {
+		return &model.DAG{
+			Id:    uuid.New(),
+			Title: "Conditional DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {Id: rootID, Question: "Root?", Type: model.NodeTypeInformation, Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Yes", NextNode: &middleID, Metadata: map[string]interface{}{"confidence": 0.9}},
+				}},
+				middleID: {Id: middleID, Question: "Middle?", Type: model.NodeTypeInformation, Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Go on", NextNode: &leafID, Conditions: conditions},
+				}},
+				urgentID: {Id: urgentID, Question: "Urgent?", Answers: []model.Answer{}},
+				leafID:   {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
 			},
-			node2ID: {
-				Id:       node2ID,
-				Question: "Node 2 question?",
-				Answers: []model.Answer{
-					{
-						Id:        uuid.New(),
-						Statement: "Go to node 3",
-						NextNode:  &node3ID,
-					},
-				},
+		}
+	}
+
+	codes := func(result ValidationResult) []string {
+		var codes []string
+		for _, err := range result.Errors {
+			codes = append(codes, err.Code)
+		}
+		for _, warning := range result.Warnings {
+			codes = append(codes, warning.Code)
+		}
+		return codes
+	}
+
+	t.Run("condition targets are part of the structure", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG([]model.Branch{{When: "confidence > 0.7", NextNode: urgentID}}))
+		assert.True(t, result.IsValid, codes(result))
+		assert.Equal(t, 1, result.Statistics.RootNodes)
+		assert.Equal(t, 2, result.Statistics.LeafNodes)
+		assert.Empty(t, codes(result))
+	})
+
+	t.Run("rejects invalid conditions", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG([]model.Branch{{When: "confidence >", NextNode: urgentID}}))
+		assert.False(t, result.IsValid)
+		assert.Contains(t, codes(result), "ANSWER_INVALID_CONDITION")
+	})
+
+	t.Run("rejects conditions leading to unknown nodes", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG([]model.Branch{{When: "confidence > 0.7", NextNode: uuid.New()}}))
+		assert.False(t, result.IsValid)
+		assert.Contains(t, codes(result), "ANSWER_INVALID_REFERENCE")
+	})
+
+	t.Run("warns about variables no preceding answer sets", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG([]model.Branch{{When: `confidence > 0.7 && "urgent" in tags`, NextNode: urgentID}}))
+		assert.True(t, result.IsValid)
+		require.Len(t, result.Warnings, 1)
+		assert.Equal(t, "ANSWER_UNKNOWN_CONDITION_VARIABLE", result.Warnings[0].Code)
+		assert.Contains(t, result.Warnings[0].Message, `"tags"`)
+	})
+
+	t.Run("detects cycles through conditions", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(newDAG([]model.Branch{{When: "confidence > 0.7", NextNode: rootID}}))
+		assert.False(t, result.IsValid)
+		assert.True(t, result.Statistics.HasCycles)
+	})
+}
+
+func TestDAGValidator_NodeTypes(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.New()
+	nodeID := uuid.New()
+	leafID := uuid.New()
+
+	newDAG := func(node model.Node) *model.DAG {
+		node.Id = nodeID
+		node.Question = "Typed node"
+		return &model.DAG{
+			Id:    uuid.New(),
+			Title: "Typed DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {Id: rootID, Question: "Root?", Type: model.NodeTypeInformation, Answers: []model.Answer{{Id: uuid.New(), Statement: "Yes", NextNode: &nodeID}}},
+				nodeID: node,
+				leafID: {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
 			},
-			node3ID: {
-				Id:       node3ID,
-				Question: "Node 3 question?",
-				Answers: []model.Answer{
-					{
-						Id:        uuid.New(),
-						Statement: "Go back to node 1", // Creates cycle
-						NextNode:  &node1ID,
+		}
+	}
+	toLeaf := func(statement string) model.Answer {
+		return model.Answer{Id: uuid.New(), Statement: statement, NextNode: &leafID}
+	}
+
+	tests := []struct {
+		name        string
+		node        model.Node
+		expectValid bool
+		expectCode  string
+	}{
+		{
+			name:        "information node advancing through one answer",
+			node:        model.Node{Type: model.NodeTypeInformation, Answers: []model.Answer{toLeaf("Continue")}},
+			expectValid: true,
+		},
+		{
+			name:        "information node with several answers",
+			node:        model.Node{Type: model.NodeTypeInformation, Answers: []model.Answer{toLeaf("Continue"), toLeaf("Skip")}},
+			expectValid: false,
+			expectCode:  "INFORMATION_NODE_MULTIPLE_ANSWERS",
+		},
+		{
+			name:        "outcome leaf",
+			node:        model.Node{Type: model.NodeTypeOutcome, Outcome: &model.Outcome{Assessment: "Likely unfair dismissal"}},
+			expectValid: true,
+		},
+		{
+			name:        "outcome with answers",
+			node:        model.Node{Type: model.NodeTypeOutcome, Outcome: &model.Outcome{Assessment: "Likely unfair dismissal"}, Answers: []model.Answer{toLeaf("Continue")}},
+			expectValid: false,
+			expectCode:  "OUTCOME_NODE_NOT_LEAF",
+		},
+		{
+			name:        "outcome without assessment",
+			node:        model.Node{Type: model.NodeTypeOutcome},
+			expectValid: true,
+			expectCode:  "OUTCOME_MISSING_ASSESSMENT",
+		},
+		{
+			name:        "unknown type",
+			node:        model.Node{Type: "checklist", Answers: []model.Answer{toLeaf("Done")}},
+			expectValid: false,
+			expectCode:  "NODE_INVALID_TYPE",
+		},
+		{
+			name:        "input node storing its value",
+			node:        model.Node{Type: model.NodeTypeInput, Input: &model.Input{Kind: model.InputKindDate, Variable: "termination_date"}, Answers: []model.Answer{toLeaf("Termination date")}},
+			expectValid: true,
+		},
+		{
+			name: "input variable known to conditions",
+			node: model.Node{
+				Type:  model.NodeTypeInput,
+				Input: &model.Input{Kind: model.InputKindNumber, Variable: "seniority"},
+				Answers: []model.Answer{{
+					Id:         uuid.New(),
+					Statement:  "Seniority",
+					NextNode:   &leafID,
+					Conditions: []model.Branch{{When: "seniority > 2", NextNode: leafID}},
+				}},
+			},
+			expectValid: true,
+		},
+		{
+			name:        "input node without variable",
+			node:        model.Node{Type: model.NodeTypeInput, Answers: []model.Answer{toLeaf("Termination date")}},
+			expectValid: false,
+			expectCode:  "INPUT_MISSING_VARIABLE",
+		},
+		{
+			name:        "input node with invalid range",
+			node:        model.Node{Type: model.NodeTypeInput, Input: &model.Input{Kind: model.InputKindNumber, Variable: "salary", Min: "10", Max: "1"}, Answers: []model.Answer{toLeaf("Salary")}},
+			expectValid: false,
+			expectCode:  "INPUT_INVALID_DEFINITION",
+		},
+		{
+			name:        "input node with several answers",
+			node:        model.Node{Type: model.NodeTypeInput, Input: &model.Input{Variable: "employer"}, Answers: []model.Answer{toLeaf("Employer"), toLeaf("Other")}},
+			expectValid: false,
+			expectCode:  "INPUT_NODE_ANSWERS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := newDAG(tt.node)
+			if len(tt.node.Answers) == 0 {
+				delete(d.Nodes, leafID)
+			}
+			result := NewDAGValidator().ValidateDAG(d)
+
+			var codes []string
+			for _, err := range result.Errors {
+				codes = append(codes, err.Code)
+			}
+			for _, warning := range result.Warnings {
+				codes = append(codes, warning.Code)
+			}
+
+			assert.Equal(t, tt.expectValid, result.IsValid, codes)
+			if tt.expectCode == "" {
+				assert.Empty(t, codes)
+				return
+			}
+			assert.Contains(t, codes, tt.expectCode)
+		})
+	}
+}
+
+func TestDAGValidator_MultiSelectNext(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.New()
+	leafID := uuid.New()
+	otherID := uuid.New()
+
+	newDAG := func(rule model.MultiSelectNext, secondTarget uuid.UUID) *model.DAG {
+		d := &model.DAG{
+			Id:    uuid.New(),
+			Title: "Multi-select DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {
+					Id:              rootID,
+					Question:        "Which damages did you suffer?",
+					MultiSelect:     true,
+					MultiSelectNext: rule,
+					Answers: []model.Answer{
+						{Id: uuid.New(), Statement: "Financial", NextNode: &leafID},
+						{Id: uuid.New(), Statement: "Moral", NextNode: &secondTarget},
 					},
 				},
+				leafID: {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
 			},
+		}
+		if secondTarget != leafID {
+			d.Nodes[secondTarget] = model.Node{Id: secondTarget, Question: "Other leaf?", Answers: []model.Answer{}}
+		}
+		return d
+	}
+
+	tests := []struct {
+		name        string
+		dag         *model.DAG
+		expectValid bool
+		expectCode  string
+	}{
+		{
+			name:        "priority answers leading to different nodes",
+			dag:         newDAG(model.MultiSelectNextPriority, otherID),
+			expectValid: true,
+		},
+		{
+			name:        "convergence answers leading to the same node",
+			dag:         newDAG(model.MultiSelectNextConvergence, leafID),
+			expectValid: true,
+		},
+		{
+			name:        "convergence answers leading to different nodes",
+			dag:         newDAG(model.MultiSelectNextConvergence, otherID),
+			expectValid: false,
+			expectCode:  "NODE_MULTI_SELECT_DIVERGENT",
+		},
+		{
+			name:        "unknown rule",
+			dag:         newDAG("random", otherID),
+			expectValid: false,
+			expectCode:  "NODE_INVALID_MULTI_SELECT_NEXT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewDAGValidator().ValidateDAG(tt.dag)
+
+			var codes []string
+			for _, err := range result.Errors {
+				codes = append(codes, err.Code)
+			}
+
+			assert.Equal(t, tt.expectValid, result.IsValid, codes)
+			if tt.expectCode != "" {
+				assert.Contains(t, codes, tt.expectCode)
+			}
+		})
+	}
+}
+
+func TestDAGValidator_Scoring(t *testing.T) {
+	t.Parallel()
+
+	newDAG := func(scoring *model.Scoring, scores map[string]float64) *model.DAG {
+		d := dagtest.ValidSingleRoot()
+		d.Scoring = scoring
+		root := dagtest.Root(d)
+		root.Answers[0].Scores = scores
+		d.Nodes[root.Id] = root
+		return d
+	}
+
+	tests := []struct {
+		name        string
+		dag         *model.DAG
+		expectValid bool
+		expectCode  string
+	}{
+		{
+			name:        "scores without scoring configuration",
+			dag:         newDAG(nil, map[string]float64{"evidence": 1}),
+			expectValid: true,
+		},
+		{
+			name:        "scores along declared dimensions",
+			dag:         newDAG(&model.Scoring{Dimensions: []model.ScoreDimension{{Name: "evidence", Weight: 2}}}, map[string]float64{"evidence": 1}),
+			expectValid: true,
+		},
+		{
+			name:        "score along an undeclared dimension",
+			dag:         newDAG(&model.Scoring{Dimensions: []model.ScoreDimension{{Name: "evidence"}}}, map[string]float64{"damages": 1}),
+			expectValid: true,
+			expectCode:  "ANSWER_UNKNOWN_SCORE_DIMENSION",
+		},
+		{
+			name:        "dimension declared twice",
+			dag:         newDAG(&model.Scoring{Dimensions: []model.ScoreDimension{{Name: "evidence"}, {Name: "evidence"}}}, nil),
+			expectValid: false,
+			expectCode:  "SCORING_INVALID_DIMENSION",
+		},
+		{
+			name:        "dimension without name",
+			dag:         newDAG(&model.Scoring{Dimensions: []model.ScoreDimension{{Label: "Evidence"}}}, nil),
+			expectValid: false,
+			expectCode:  "SCORING_INVALID_DIMENSION",
+		},
+		{
+			name:        "dimension with negative weight",
+			dag:         newDAG(&model.Scoring{Dimensions: []model.ScoreDimension{{Name: "evidence", Weight: -1}}}, nil),
+			expectValid: false,
+			expectCode:  "SCORING_INVALID_DIMENSION",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewDAGValidator().ValidateDAG(tt.dag)
+
+			var codes []string
+			for _, err := range result.Errors {
+				codes = append(codes, err.Code)
+			}
+			for _, warning := range result.Warnings {
+				codes = append(codes, warning.Code)
+			}
+
+			assert.Equal(t, tt.expectValid, result.IsValid, codes)
+			if tt.expectCode != "" {
+				assert.Contains(t, codes, tt.expectCode)
+			}
+		})
+	}
+}
+
+func TestDAGValidator_MetadataSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := &model.MetadataSchema{Fields: []model.MetadataField{
+		{Name: "confidence", Type: model.MetadataFieldNumber},
+		{Name: "severity", Type: model.MetadataFieldString, Enum: []string{"low", "high"}},
+	}}
+	newDAG := func(schema *model.MetadataSchema, metadata map[string]interface{}) *model.DAG {
+		d := dagtest.ValidSingleRoot()
+		d.MetadataSchema = schema
+		root := dagtest.Root(d)
+		root.Answers[0].Metadata = metadata
+		d.Nodes[root.Id] = root
+		return d
+	}
+
+	tests := []struct {
+		name        string
+		dag         *model.DAG
+		expectValid bool
+		expectCode  string
+	}{
+		{
+			name:        "metadata without schema",
+			dag:         newDAG(nil, map[string]interface{}{"anything": true}),
+			expectValid: true,
+		},
+		{
+			name:        "metadata matching the schema",
+			dag:         newDAG(schema, map[string]interface{}{"confidence": 0.9, "severity": "high"}),
+			expectValid: true,
+		},
+		{
+			name:        "metadata value outside the enum",
+			dag:         newDAG(schema, map[string]interface{}{"severity": "critical"}),
+			expectValid: false,
+			expectCode:  "ANSWER_METADATA_SCHEMA_VIOLATION",
+		},
+		{
+			name:        "metadata field not declared",
+			dag:         newDAG(schema, map[string]interface{}{"tags": "urgent"}),
+			expectValid: false,
+			expectCode:  "ANSWER_METADATA_SCHEMA_VIOLATION",
 		},
+		{
+			name:        "field with unknown type",
+			dag:         newDAG(&model.MetadataSchema{Fields: []model.MetadataField{{Name: "confidence", Type: "percentage"}}}, nil),
+			expectValid: false,
+			expectCode:  "METADATA_SCHEMA_INVALID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewDAGValidator().ValidateDAG(tt.dag)
+
+			var codes []string
+			for _, err := range result.Errors {
+				codes = append(codes, err.Code)
+			}
+
+			assert.Equal(t, tt.expectValid, result.IsValid, codes)
+			if tt.expectCode != "" {
+				assert.Contains(t, codes, tt.expectCode)
+			}
+		})
 	}
 }
 
+// Helper functions to create test DAGs
+
 func createNoRootDAG() *model.DAG {
 	node1ID := uuid.New()
 	node2ID := uuid.New()
@@ -569,57 +1311,74 @@ func createMultipleCyclesDAG() *model.DAG {
 	}
 }
 
-func createSingleNodeDAG() *model.DAG {
-	nodeID := uuid.New()
+func createVariedFanOutDAG() *model.DAG {
+	rootID := uuid.New()
+	middleID := uuid.New()
+	leaf1ID := uuid.New()
+	leaf2ID := uuid.New()
+	leaf3ID := uuid.New()
 
 	return &model.DAG{
 		Id:    uuid.New(),
-		Title: "Single Node DAG",
+		Title: "Varied Fan-Out DAG",
 		Nodes: map[uuid.UUID]model.Node{
-			nodeID: {
-				Id:       nodeID,
-				Question: "Only question?",
-				Answers:  []model.Answer{},
+			rootID: {
+				Id:       rootID,
+				Question: "Root question?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Go to middle", NextNode: &middleID},
+					{Id: uuid.New(), Statement: "Go to leaf 1", NextNode: &leaf1ID},
+					{Id: uuid.New(), Statement: "Go to leaf 2", NextNode: &leaf2ID},
+				},
+			},
+			middleID: {
+				Id:       middleID,
+				Question: "Middle question?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Go to leaf 2", NextNode: &leaf2ID},
+					{Id: uuid.New(), Statement: "Go to leaf 3", NextNode: &leaf3ID},
+				},
 			},
+			leaf1ID: {Id: leaf1ID, Question: "Leaf 1?", Answers: []model.Answer{}},
+			leaf2ID: {Id: leaf2ID, Question: "Leaf 2?", Answers: []model.Answer{}},
+			leaf3ID: {Id: leaf3ID, Question: "Leaf 3?", Answers: []model.Answer{}},
 		},
 	}
 }
 
-func createLinearChainDAG(length int) *model.DAG {
-	if length <= 0 {
-		return createSingleNodeDAG()
-	}
-
-	nodes := make(map[uuid.UUID]model.Node)
-	nodeIDs := make([]uuid.UUID, length)
-
-	// Create node IDs
-	for i := 0; i < length; i++ {
-		nodeIDs[i] = uuid.New()
-	}
-
-	// Create nodes with linear chain structure
-	for i, nodeID := range nodeIDs {
-		answers := []model.Answer{}
-		if i < length-1 {
-			// Not the last node, create answer pointing to next node
-			answers = append(answers, model.Answer{
-				Id:        uuid.New(),
-				Statement: fmt.Sprintf("Go to node %d", i+2),
-				NextNode:  &nodeIDs[i+1],
-			})
-		}
-
-		nodes[nodeID] = model.Node{
-			Id:       nodeID,
-			Question: fmt.Sprintf("Question %d?", i+1),
-			Answers:  answers,
-		}
-	}
+// createDetachedLeafDAG creates a single-root DAG where a leaf is only referenced
+// from a cycle that the root never leads to
+func createDetachedLeafDAG() (*model.DAG, uuid.UUID) {
+	rootID := uuid.New()
+	leafID := uuid.New()
+	loopAID := uuid.New()
+	loopBID := uuid.New()
+	detachedLeafID := uuid.New()
 
 	return &model.DAG{
 		Id:    uuid.New(),
-		Title: fmt.Sprintf("Linear Chain DAG (length %d)", length),
-		Nodes: nodes,
-	}
+		Title: "Detached Leaf DAG",
+		Nodes: map[uuid.UUID]model.Node{
+			rootID: {
+				Id:       rootID,
+				Question: "Root question?",
+				Answers:  []model.Answer{{Id: uuid.New(), Statement: "Go to leaf", NextNode: &leafID}},
+			},
+			leafID: {Id: leafID, Question: "Leaf?", Answers: []model.Answer{}},
+			loopAID: {
+				Id:       loopAID,
+				Question: "Loop A?",
+				Answers: []model.Answer{
+					{Id: uuid.New(), Statement: "Go to B", NextNode: &loopBID},
+					{Id: uuid.New(), Statement: "Go to detached leaf", NextNode: &detachedLeafID},
+				},
+			},
+			loopBID: {
+				Id:       loopBID,
+				Question: "Loop B?",
+				Answers:  []model.Answer{{Id: uuid.New(), Statement: "Go to A", NextNode: &loopAID}},
+			},
+			detachedLeafID: {Id: detachedLeafID, Question: "Detached leaf?", Answers: []model.Answer{}},
+		},
+	}, detachedLeafID
 }
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=dag_version_repository.go -destination=testdata/mocks/dag_version_repository_mock.go -package=mocks
+
+type DAGVersionRepository interface {
+	// Append records a snapshot of the DAG as its next version
+	Append(ctx context.Context, d *model.DAG, createdAt time.Time, author string) (*model.DAGVersion, error)
+	// List returns the versions of a DAG in ascending order, empty when none was recorded yet
+	List(ctx context.Context, dagId uuid.UUID) ([]model.DAGVersion, error)
+	Get(ctx context.Context, dagId uuid.UUID, number int) (*model.DAGVersion, error)
+	// Delete removes the versions of a DAG, deleting versions never recorded is not an error
+	Delete(ctx context.Context, dagId uuid.UUID) error
+}
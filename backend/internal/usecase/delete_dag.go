@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdDeleteDAG struct {
+	DAGId string `validate:"required,uuid"`
+	// Force deletes the DAG even when walks were recorded on it
+	Force bool
+}
+
+type DeleteDAGUseCase struct {
+	dagRepository       DAGRepository
+	analyticsRepository WalkAnalyticsRepository
+	eventPublisher      EventPublisher
+	validator           *validator.Validate
+}
+
+func NewDeleteDAGUseCase(dagRepository DAGRepository, analyticsRepository WalkAnalyticsRepository, eventPublisher EventPublisher) *DeleteDAGUseCase {
+	return &DeleteDAGUseCase{
+		dagRepository:       dagRepository,
+		analyticsRepository: analyticsRepository,
+		eventPublisher:      eventPublisher,
+		validator:           validator.New(),
+	}
+}
+
+// Execute moves a DAG to the trash, its walk analytics and versions are kept until it is purged.
+// Unless forced, DAGs with recorded walks are kept.
+func (u *DeleteDAGUseCase) Execute(ctx context.Context, cmd CmdDeleteDAG) error {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	if _, err := u.dagRepository.Get(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete DAG: %w", err)
+	}
+
+	if !cmd.Force {
+		analytics, err := u.analyticsRepository.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check walk analytics: %w", err)
+		}
+		if walks := analytics.TotalWalks(); walks > 0 {
+			return fmt.Errorf("%w: %d walk(s) recorded on DAG %s, force the deletion to discard them", ErrConflict, walks, id)
+		}
+	}
+
+	now := time.Now()
+	err = u.dagRepository.Update(ctx, id, func(existingDAG model.DAG) (model.DAG, error) {
+		if err := existingDAG.SoftDelete(now); err != nil {
+			return existingDAG, fmt.Errorf("%w: %s", ErrNotFound, err)
+		}
+		// The ETags taken before the deletion must not match the DAG once restored
+		existingDAG.Revision++
+		return existingDAG, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete DAG: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", id.String()).
+		Bool("force", cmd.Force).
+		Msg("DAG moved to trash")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGDeleted, id, now))
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteDAGUseCase_Execute(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+
+	walked := model.NewWalkAnalytics(d.Id)
+	walked.Record([]uuid.UUID{uuid.New()})
+
+	tests := []struct {
+		name       string
+		cmd        CmdDeleteDAG
+		setupMocks func(*mocks.MockDAGRepository, *mocks.MockWalkAnalyticsRepository) *model.DAG
+		errorType  error
+	}{
+		{
+			name: "moves a DAG without walks to the trash",
+			cmd:  CmdDeleteDAG{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, analyticsRepo *mocks.MockWalkAnalyticsRepository) *model.DAG {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				analyticsRepo.EXPECT().Get(gomock.Any(), d.Id).Return(model.NewWalkAnalytics(d.Id), nil)
+				return updateThrough(dagRepo, d)
+			},
+		},
+		{
+			name: "keeps a DAG with recorded walks",
+			cmd:  CmdDeleteDAG{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, analyticsRepo *mocks.MockWalkAnalyticsRepository) *model.DAG {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				analyticsRepo.EXPECT().Get(gomock.Any(), d.Id).Return(walked, nil)
+				return nil
+			},
+			errorType: ErrConflict,
+		},
+		{
+			name: "force moves a DAG with recorded walks to the trash",
+			cmd:  CmdDeleteDAG{DAGId: d.Id.String(), Force: true},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, analyticsRepo *mocks.MockWalkAnalyticsRepository) *model.DAG {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				return updateThrough(dagRepo, d)
+			},
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdDeleteDAG{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, analyticsRepo *mocks.MockWalkAnalyticsRepository) *model.DAG {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+				return nil
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:       "rejects invalid DAG ID",
+			cmd:        CmdDeleteDAG{DAGId: "invalid"},
+			setupMocks: func(*mocks.MockDAGRepository, *mocks.MockWalkAnalyticsRepository) *model.DAG { return nil },
+			errorType:  ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dagRepo := mocks.NewMockDAGRepository(ctrl)
+			analyticsRepo := mocks.NewMockWalkAnalyticsRepository(ctrl)
+			trashed := tt.setupMocks(dagRepo, analyticsRepo)
+
+			err := NewDeleteDAGUseCase(dagRepo, analyticsRepo, nil).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, trashed.IsDeleted())
+			assert.Equal(t, d.Revision+1, trashed.Revision)
+		})
+	}
+}
+
+func TestDeleteDAGUseCase_PublishesDeletion(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+	ctrl := gomock.NewController(t)
+	dagRepo := mocks.NewMockDAGRepository(ctrl)
+	dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+	updateThrough(dagRepo, d)
+	publisher := mocks.NewMockEventPublisher(ctrl)
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event model.Event) error {
+		assert.Equal(t, model.EventDAGDeleted, event.Type)
+		assert.Equal(t, d.Id, event.DAGId)
+		return nil
+	})
+
+	err := NewDeleteDAGUseCase(dagRepo, mocks.NewMockWalkAnalyticsRepository(ctrl), publisher).Execute(context.Background(), CmdDeleteDAG{DAGId: d.Id.String(), Force: true})
+	require.NoError(t, err)
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdDeleteWebhook struct {
+	WebhookId string `validate:"required,uuid"`
+}
+
+type DeleteWebhookUseCase struct {
+	webhookRepository  WebhookRepository
+	deliveryRepository WebhookDeliveryRepository
+	validator          *validator.Validate
+}
+
+func NewDeleteWebhookUseCase(webhookRepository WebhookRepository, deliveryRepository WebhookDeliveryRepository) *DeleteWebhookUseCase {
+	return &DeleteWebhookUseCase{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		validator:          validator.New(),
+	}
+}
+
+// Execute unregisters a webhook along with its delivery history
+func (u *DeleteWebhookUseCase) Execute(ctx context.Context, cmd CmdDeleteWebhook) error {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.WebhookId)
+	if err != nil {
+		return fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	if err := u.webhookRepository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if err := u.deliveryRepository.DeleteAll(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	return nil
+}
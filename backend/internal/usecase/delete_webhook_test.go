@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteWebhookUseCase_Execute(t *testing.T) {
+	webhookId := uuid.New()
+
+	t.Run("deletes the webhook and its deliveries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Delete(gomock.Any(), webhookId).Return(nil)
+		deliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+		deliveryRepo.EXPECT().DeleteAll(gomock.Any(), webhookId).Return(nil)
+
+		err := NewDeleteWebhookUseCase(webhookRepo, deliveryRepo).Execute(context.Background(), CmdDeleteWebhook{WebhookId: webhookId.String()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns not found for unknown webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Delete(gomock.Any(), webhookId).Return(fmt.Errorf("%w: webhook", ErrNotFound))
+
+		err := NewDeleteWebhookUseCase(webhookRepo, mocks.NewMockWebhookDeliveryRepository(ctrl)).Execute(context.Background(), CmdDeleteWebhook{WebhookId: webhookId.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid webhook ID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		err := NewDeleteWebhookUseCase(mocks.NewMockWebhookRepository(ctrl), mocks.NewMockWebhookDeliveryRepository(ctrl)).
+			Execute(context.Background(), CmdDeleteWebhook{WebhookId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// DiffOperand is one side of a diff, either a DAG payload or a version of the DAG of the command
+type DiffOperand struct {
+	DAG     *model.DAG
+	Version int `validate:"min=0"`
+}
+
+type CmdDiffDAGs struct {
+	// DAGId is the DAG whose versions are compared, required when an operand references a version
+	DAGId  string `validate:"omitempty,uuid"`
+	Before DiffOperand
+	After  DiffOperand
+}
+
+type DiffDAGsUseCase struct {
+	versionRepository DAGVersionRepository
+	dagDiff           *DAGDiff
+	validator         *validator.Validate
+}
+
+func NewDiffDAGsUseCase(versionRepository DAGVersionRepository) *DiffDAGsUseCase {
+	return &DiffDAGsUseCase{
+		versionRepository: versionRepository,
+		dagDiff:           NewDAGDiff(),
+		validator:         validator.New(),
+	}
+}
+
+// Execute compares two DAGs, each given as a payload or as a version reference
+func (u *DiffDAGsUseCase) Execute(ctx context.Context, cmd CmdDiffDAGs) (*DAGChangeSet, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	before, err := u.resolve(ctx, cmd.DAGId, cmd.Before, "before")
+	if err != nil {
+		return nil, err
+	}
+	after, err := u.resolve(ctx, cmd.DAGId, cmd.After, "after")
+	if err != nil {
+		return nil, err
+	}
+
+	changes := u.dagDiff.Compare(before, after)
+	return &changes, nil
+}
+
+// resolve returns the DAG of an operand, reading referenced versions from the repository
+func (u *DiffDAGsUseCase) resolve(ctx context.Context, dagId string, operand DiffOperand, side string) (*model.DAG, error) {
+	switch {
+	case operand.DAG != nil && operand.Version > 0:
+		return nil, fmt.Errorf("%w: %s must be either a DAG or a version, not both", ErrInvalidCommand, side)
+	case operand.DAG != nil:
+		return operand.DAG, nil
+	case operand.Version == 0:
+		return nil, fmt.Errorf("%w: %s requires a DAG or a version", ErrInvalidCommand, side)
+	}
+
+	id, err := uuid.Parse(dagId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: a DAG ID is required to compare versions: %s", ErrInvalidCommand, err)
+	}
+
+	version, err := u.versionRepository.Get(ctx, id, operand.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve %s version: %w", side, err)
+	}
+
+	return version.DAG, nil
+}
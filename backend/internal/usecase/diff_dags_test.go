@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDAGsUseCase_Execute(t *testing.T) {
+	before := dagtest.ValidSingleRoot()
+	after := cloneTestDAG(before)
+	after.Title = "Renamed"
+
+	tests := []struct {
+		name       string
+		cmd        CmdDiffDAGs
+		setupMocks func(*mocks.MockDAGVersionRepository)
+		errorType  error
+	}{
+		{
+			name: "compares two payloads",
+			cmd: CmdDiffDAGs{
+				Before: DiffOperand{DAG: before},
+				After:  DiffOperand{DAG: after},
+			},
+			setupMocks: func(*mocks.MockDAGVersionRepository) {},
+		},
+		{
+			name: "compares two versions",
+			cmd: CmdDiffDAGs{
+				DAGId:  before.Id.String(),
+				Before: DiffOperand{Version: 1},
+				After:  DiffOperand{Version: 2},
+			},
+			setupMocks: func(versionRepo *mocks.MockDAGVersionRepository) {
+				versionRepo.EXPECT().Get(gomock.Any(), before.Id, 1).Return(&model.DAGVersion{Number: 1, DAG: before}, nil)
+				versionRepo.EXPECT().Get(gomock.Any(), before.Id, 2).Return(&model.DAGVersion{Number: 2, DAG: after}, nil)
+			},
+		},
+		{
+			name: "compares a version with a pending edit",
+			cmd: CmdDiffDAGs{
+				DAGId:  before.Id.String(),
+				Before: DiffOperand{Version: 1},
+				After:  DiffOperand{DAG: after},
+			},
+			setupMocks: func(versionRepo *mocks.MockDAGVersionRepository) {
+				versionRepo.EXPECT().Get(gomock.Any(), before.Id, 1).Return(&model.DAGVersion{Number: 1, DAG: before}, nil)
+			},
+		},
+		{
+			name: "returns not found for unknown version",
+			cmd: CmdDiffDAGs{
+				DAGId:  before.Id.String(),
+				Before: DiffOperand{Version: 1},
+				After:  DiffOperand{Version: 5},
+			},
+			setupMocks: func(versionRepo *mocks.MockDAGVersionRepository) {
+				versionRepo.EXPECT().Get(gomock.Any(), before.Id, 1).Return(&model.DAGVersion{Number: 1, DAG: before}, nil)
+				versionRepo.EXPECT().Get(gomock.Any(), before.Id, 5).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name: "requires a DAG ID to compare versions",
+			cmd: CmdDiffDAGs{
+				Before: DiffOperand{Version: 1},
+				After:  DiffOperand{Version: 2},
+			},
+			setupMocks: func(*mocks.MockDAGVersionRepository) {},
+			errorType:  ErrInvalidCommand,
+		},
+		{
+			name: "rejects an empty operand",
+			cmd: CmdDiffDAGs{
+				Before: DiffOperand{DAG: before},
+			},
+			setupMocks: func(*mocks.MockDAGVersionRepository) {},
+			errorType:  ErrInvalidCommand,
+		},
+		{
+			name: "rejects an operand with both a DAG and a version",
+			cmd: CmdDiffDAGs{
+				DAGId:  before.Id.String(),
+				Before: DiffOperand{DAG: before, Version: 1},
+				After:  DiffOperand{DAG: after},
+			},
+			setupMocks: func(*mocks.MockDAGVersionRepository) {},
+			errorType:  ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+			tt.setupMocks(versionRepo)
+
+			changes, err := NewDiffDAGsUseCase(versionRepo).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, changes.Title)
+			assert.Equal(t, "Renamed", changes.Title.After)
+			assert.Empty(t, changes.ModifiedNodes)
+		})
+	}
+}
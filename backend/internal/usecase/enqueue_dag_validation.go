@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type EnqueueDAGValidationUseCase struct {
+	dagRepository DAGRepository
+	jobQueue      JobQueue
+	validator     *validator.Validate
+}
+
+// NewEnqueueDAGValidationUseCase returns the use case validating stored DAGs in the background,
+// jobQueue may be nil when no job queue is configured
+func NewEnqueueDAGValidationUseCase(dagRepository DAGRepository, jobQueue JobQueue) *EnqueueDAGValidationUseCase {
+	return &EnqueueDAGValidationUseCase{
+		dagRepository: dagRepository,
+		jobQueue:      jobQueue,
+		validator:     validator.New(),
+	}
+}
+
+// Execute queues the validation of a stored DAG and returns the pending job, whose result is the validation once done
+func (u *EnqueueDAGValidationUseCase) Execute(ctx context.Context, cmd CmdValidateStoredDAG) (*model.Job, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	if u.jobQueue == nil {
+		return nil, fmt.Errorf("%w: no job queue is configured", ErrUnavailable)
+	}
+
+	// Unknown DAGs are reported right away rather than by a failed job
+	if _, err := u.dagRepository.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG for validation: %w", err)
+	}
+
+	job := model.NewJob(model.JobTypeValidateDAG, id, time.Now())
+	if err := u.jobQueue.Enqueue(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to enqueue DAG validation: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", id.String()).
+		Str("job_id", job.Id.String()).
+		Msg("DAG validation enqueued")
+
+	return &job, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueDAGValidationUseCase_Execute(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+
+	tests := []struct {
+		name       string
+		dagId      string
+		setupMocks func(*mocks.MockDAGRepository, *mocks.MockJobQueue)
+		errorType  error
+	}{
+		{
+			name:  "enqueues the validation of the DAG",
+			dagId: d.Id.String(),
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, jobQueue *mocks.MockJobQueue) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				jobQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job model.Job) error {
+					assert.Equal(t, model.JobTypeValidateDAG, job.Type)
+					assert.Equal(t, d.Id, job.DAGId)
+					assert.Equal(t, model.JobStatusPending, job.Status)
+					return nil
+				})
+			},
+		},
+		{
+			name:  "returns not found for unknown DAG",
+			dagId: d.Id.String(),
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, jobQueue *mocks.MockJobQueue) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:  "reports a full queue",
+			dagId: d.Id.String(),
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, jobQueue *mocks.MockJobQueue) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				jobQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).Return(ErrUnavailable)
+			},
+			errorType: ErrUnavailable,
+		},
+		{
+			name:       "rejects invalid identifiers",
+			dagId:      "invalid",
+			setupMocks: func(*mocks.MockDAGRepository, *mocks.MockJobQueue) {},
+			errorType:  ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dagRepo := mocks.NewMockDAGRepository(ctrl)
+			jobQueue := mocks.NewMockJobQueue(ctrl)
+			tt.setupMocks(dagRepo, jobQueue)
+
+			job, err := NewEnqueueDAGValidationUseCase(dagRepo, jobQueue).Execute(context.Background(), CmdValidateStoredDAG{DAGId: tt.dagId})
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, job.Id)
+			assert.Equal(t, d.Id, job.DAGId)
+		})
+	}
+
+	t.Run("is unavailable without job queue", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewEnqueueDAGValidationUseCase(mocks.NewMockDAGRepository(ctrl), nil).
+			Execute(context.Background(), CmdValidateStoredDAG{DAGId: d.Id.String()})
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+}
+
+func TestValidateStoredDAGUseCase_RunJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	d := dagtest.ValidSingleRoot()
+	d.Metadata = model.NewDAGMetadata()
+	dagRepo, current := newStoredDAGRepository(ctrl, d)
+
+	result, err := NewValidateStoredDAGUseCase(dagRepo, NewDAGValidator(), nil).
+		RunJob(context.Background(), model.NewJob(model.JobTypeValidateDAG, d.Id, d.UpdatedAt))
+	require.NoError(t, err)
+	require.IsType(t, &ValidationResult{}, result)
+	assert.True(t, result.(*ValidationResult).IsValid)
+	assert.False(t, current().Metadata.LastValidatedAt.IsZero())
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// DefaultPathLimit is the number of paths listed when the command sets no limit. The number of paths grows
+// exponentially with the depth of a DAG, they are never all listed at once.
+const DefaultPathLimit = 100
+
+type CmdEnumeratePaths struct {
+	DAGId string `validate:"required,uuid"`
+	// Limit caps the number of paths, DefaultPathLimit when zero
+	Limit int `validate:"min=0,max=1000"`
+}
+
+// PathList holds the first paths of a DAG, Truncated when the DAG has more
+type PathList struct {
+	Paths     []model.PathDetail
+	Truncated bool
+}
+
+type EnumeratePathsUseCase struct {
+	dagRepository DAGRepository
+	validator     *validator.Validate
+}
+
+func NewEnumeratePathsUseCase(dagRepository DAGRepository) *EnumeratePathsUseCase {
+	return &EnumeratePathsUseCase{
+		dagRepository: dagRepository,
+		validator:     validator.New(),
+	}
+}
+
+// Execute lists the first paths of a stored DAG with the question and answer of each step, up to the limit
+func (u *EnumeratePathsUseCase) Execute(ctx context.Context, cmd CmdEnumeratePaths) (*PathList, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	limit := cmd.Limit
+	if limit == 0 {
+		limit = DefaultPathLimit
+	}
+
+	paths, truncated, err := dag.EnumeratePathsUpTo(limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: DAG %s cannot be walked: %s", ErrInvalidCommand, id, err)
+	}
+
+	return &PathList{Paths: paths, Truncated: truncated}, nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumeratePathsUseCase_Execute(t *testing.T) {
+	chain := dagtest.LinearChain(3)
+	cyclic := dagtest.Cyclic()
+	branching := dagtest.ValidSingleRoot()
+
+	tests := []struct {
+		name              string
+		cmd               CmdEnumeratePaths
+		setupMock         func(*mocks.MockDAGRepository)
+		expectedPaths     int
+		expectedTruncated bool
+		errorType         error
+	}{
+		{
+			name: "lists the paths of a stored DAG",
+			cmd:  CmdEnumeratePaths{DAGId: chain.Id.String()},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), chain.Id).Return(chain, nil)
+			},
+			expectedPaths: 1,
+		},
+		{
+			name: "reports the paths left out by the limit",
+			cmd:  CmdEnumeratePaths{DAGId: branching.Id.String(), Limit: 1},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), branching.Id).Return(branching, nil)
+			},
+			expectedPaths:     1,
+			expectedTruncated: true,
+		},
+		{
+			name:      "rejects limits above the maximum",
+			cmd:       CmdEnumeratePaths{DAGId: chain.Id.String(), Limit: 1001},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdEnumeratePaths{DAGId: chain.Id.String()},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), chain.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name: "rejects a DAG without root",
+			cmd:  CmdEnumeratePaths{DAGId: cyclic.Id.String()},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), cyclic.Id).Return(cyclic, nil)
+			},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects invalid DAG ID",
+			cmd:       CmdEnumeratePaths{DAGId: "invalid"},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			list, err := NewEnumeratePathsUseCase(mockRepo).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, list.Paths, tt.expectedPaths)
+			assert.Equal(t, tt.expectedTruncated, list.Truncated)
+			assert.NotEmpty(t, list.Paths[0].Steps)
+			assert.NotEqual(t, uuid.Nil, list.Paths[0].LeafNodeId)
+		})
+	}
+}
@@ -6,4 +6,9 @@ var (
 	ErrInvalidCommand = errors.New("invalid command")
 	ErrNotFound       = errors.New("not found")
 	ErrInternal       = errors.New("internal server error")
+	ErrConflict       = errors.New("conflict")
+	// ErrUnavailable is returned when a dependency the command needs is not configured or fails
+	ErrUnavailable = errors.New("unavailable")
+	// ErrPreconditionFailed is returned when a conditional command was issued against an outdated state
+	ErrPreconditionFailed = errors.New("precondition failed")
 )
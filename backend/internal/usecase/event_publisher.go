@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=event_publisher.go -destination=testdata/mocks/event_publisher_mock.go -package=mocks
+
+// EventPublisher notifies events to the webhooks subscribing to them
+type EventPublisher interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+// publishEvent notifies an event when a publisher is configured.
+// The change the event reports is already done, a failure to notify it is only logged.
+func publishEvent(ctx context.Context, publisher EventPublisher, event model.Event) {
+	if publisher == nil {
+		return
+	}
+
+	if err := publisher.Publish(ctx, event); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).
+			Str("event_type", event.Type).
+			Str("dag_id", event.DAGId.String()).
+			Msg("failed to publish event")
+	}
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/export"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdExportDAG struct {
+	DAGId  string `validate:"required,uuid"`
+	Format string `validate:"required"`
+	// HighlightLeaves marks the nodes without answers leading to another node
+	HighlightLeaves bool
+	// HighlightCycles marks the edges of the cycles detected by the validator
+	HighlightCycles bool
+}
+
+// ExportedDAG is a DAG rendered in an export format
+type ExportedDAG struct {
+	Format      string
+	ContentType string
+	Data        []byte
+}
+
+type ExportDAGUseCase struct {
+	dagRepository DAGRepository
+	dagValidator  *DAGValidator
+	exporters     *export.Registry
+	validator     *validator.Validate
+}
+
+func NewExportDAGUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, exporters *export.Registry) *ExportDAGUseCase {
+	return &ExportDAGUseCase{
+		dagRepository: dagRepository,
+		dagValidator:  dagValidator,
+		exporters:     exporters,
+		validator:     validator.New(),
+	}
+}
+
+// Execute renders a stored DAG with the exporter registered for the requested format
+func (u *ExportDAGUseCase) Execute(ctx context.Context, cmd CmdExportDAG) (*ExportedDAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	exporter, ok := u.exporters.Get(cmd.Format)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported export format %q, supported formats: %v", ErrInvalidCommand, cmd.Format, u.exporters.Formats())
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	options := export.Options{HighlightLeaves: cmd.HighlightLeaves}
+	if cmd.HighlightCycles {
+		options.Cycles = u.dagValidator.Cycles(dag)
+	}
+
+	data, err := exporter.Export(dag, options)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to export DAG %s as %s: %s", ErrInternal, id, cmd.Format, err)
+	}
+
+	return &ExportedDAG{
+		Format:      exporter.Format(),
+		ContentType: exporter.ContentType(),
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/export"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDAGUseCase_Execute(t *testing.T) {
+	chain := dagtest.LinearChain(3)
+	cyclic := dagtest.Cyclic()
+
+	tests := []struct {
+		name          string
+		cmd           CmdExportDAG
+		setupMock     func(*mocks.MockDAGRepository)
+		expectedType  string
+		checkContents func(*testing.T, string)
+		errorType     error
+	}{
+		{
+			name: "renders a stored DAG as DOT",
+			cmd:  CmdExportDAG{DAGId: chain.Id.String(), Format: export.FormatDOT},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), chain.Id).Return(chain, nil)
+			},
+			expectedType: "text/vnd.graphviz; charset=utf-8",
+			checkContents: func(t *testing.T, data string) {
+				assert.Contains(t, data, "digraph")
+				for id := range chain.Nodes {
+					assert.Contains(t, data, id.String())
+				}
+			},
+		},
+		{
+			name: "renders a stored DAG as GraphML",
+			cmd:  CmdExportDAG{DAGId: chain.Id.String(), Format: export.FormatGraphML},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), chain.Id).Return(chain, nil)
+			},
+			expectedType: "application/graphml+xml; charset=utf-8",
+			checkContents: func(t *testing.T, data string) {
+				assert.Contains(t, data, "<graphml")
+			},
+		},
+		{
+			name: "highlights the cycles detected by the validator",
+			cmd:  CmdExportDAG{DAGId: cyclic.Id.String(), Format: export.FormatMermaid, HighlightCycles: true},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), cyclic.Id).Return(cyclic, nil)
+			},
+			expectedType: "text/vnd.mermaid; charset=utf-8",
+			checkContents: func(t *testing.T, data string) {
+				assert.Contains(t, data, "linkStyle 0,1,2 stroke:red")
+			},
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdExportDAG{DAGId: chain.Id.String(), Format: export.FormatDOT},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), chain.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:      "rejects unsupported format",
+			cmd:       CmdExportDAG{DAGId: chain.Id.String(), Format: "svg"},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects invalid DAG ID",
+			cmd:       CmdExportDAG{DAGId: "invalid", Format: export.FormatDOT},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			exported, err := NewExportDAGUseCase(mockRepo, NewDAGValidator(), export.DefaultRegistry()).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.cmd.Format, exported.Format)
+			assert.Equal(t, tt.expectedType, exported.ContentType)
+			tt.checkContents(t, string(exported.Data))
+		})
+	}
+}
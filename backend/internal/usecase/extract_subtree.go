@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdExtractSubtree struct {
+	DAGId  string `validate:"required,uuid"`
+	NodeId string `validate:"required,uuid"`
+	// Title of the materialized DAG, the root question of the subtree when empty
+	Title string `validate:"max=200"`
+}
+
+type ExtractSubtreeUseCase struct {
+	dagRepository DAGRepository
+	createDAG     *CreateDAGUseCase
+	validator     *validator.Validate
+}
+
+// NewExtractSubtreeUseCase reads the DAGs from liveRepository, so that DAGs in the trash cannot be extracted
+// from, and stores the materialized subtrees in dagRepository
+func NewExtractSubtreeUseCase(liveRepository DAGRepository, dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *ExtractSubtreeUseCase {
+	return &ExtractSubtreeUseCase{
+		dagRepository: liveRepository,
+		createDAG:     NewCreateDAGUseCase(dagRepository, dagValidator, eventPublisher),
+		validator:     validator.New(),
+	}
+}
+
+// Execute returns the part of a DAG reachable from a node as a standalone DAG rooted at the node. The
+// subtree keeps the node and answer IDs of the DAG and is not stored.
+func (u *ExtractSubtreeUseCase) Execute(ctx context.Context, cmd CmdExtractSubtree) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagId, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	nodeId, err := uuid.Parse(cmd.NodeId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	dag, err := u.dagRepository.Get(ctx, dagId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	subtree, err := dag.Subtree(nodeId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s in DAG %s", ErrNotFound, err, dagId)
+	}
+	if cmd.Title != "" {
+		subtree.Title = cmd.Title
+	}
+
+	return subtree, nil
+}
+
+// Materialize stores the subtree of a node as a new DAG. Its nodes and answers get fresh IDs so that the
+// new DAG shares none with the DAG it was extracted from, it is validated and announced like any created DAG.
+func (u *ExtractSubtreeUseCase) Materialize(ctx context.Context, cmd CmdExtractSubtree) (*model.DAG, error) {
+	subtree, err := u.Execute(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := u.createDAG.Execute(ctx, CmdCreateDAG{DAG: model.CloneDAG(subtree)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to materialize subtree: %w", err)
+	}
+
+	return created, nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSubtreeUseCase(t *testing.T) {
+	t.Parallel()
+
+	// middle returns the second node of a three nodes chain
+	middle := func(d *model.DAG) model.Node {
+		return d.Nodes[*dagtest.Root(d).Answers[0].NextNode]
+	}
+
+	t.Run("returns the subtree without storing it", func(t *testing.T) {
+		t.Parallel()
+
+		stored := dagtest.LinearChain(3)
+		dagRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		dagRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(stored, nil)
+
+		subtree, err := NewExtractSubtreeUseCase(dagRepo, dagRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdExtractSubtree{
+			DAGId:  stored.Id.String(),
+			NodeId: middle(stored).Id.String(),
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, subtree.Nodes, 2)
+		assert.Equal(t, middle(stored).Question, subtree.Title)
+		assert.Equal(t, middle(stored).Id, dagtest.Root(subtree).Id)
+	})
+
+	t.Run("materializes the subtree as a new DAG with fresh IDs", func(t *testing.T) {
+		t.Parallel()
+
+		stored := dagtest.LinearChain(3)
+		dagRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		dagRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(stored, nil)
+		dagRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, ErrNotFound)
+		dagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		created, err := NewExtractSubtreeUseCase(dagRepo, dagRepo, NewDAGValidator(), nil).Materialize(context.Background(), CmdExtractSubtree{
+			DAGId:  stored.Id.String(),
+			NodeId: middle(stored).Id.String(),
+			Title:  "Notice branch",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Notice branch", created.Title)
+		require.Len(t, created.Nodes, 2)
+		for id := range created.Nodes {
+			assert.NotContains(t, stored.Nodes, id)
+		}
+	})
+
+	t.Run("returns not found for unknown node", func(t *testing.T) {
+		t.Parallel()
+
+		stored := dagtest.LinearChain(3)
+		dagRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		dagRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(stored, nil)
+
+		_, err := NewExtractSubtreeUseCase(dagRepo, dagRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdExtractSubtree{
+			DAGId:  stored.Id.String(),
+			NodeId: uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		t.Parallel()
+
+		dagRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		useCase := NewExtractSubtreeUseCase(dagRepo, dagRepo, NewDAGValidator(), nil)
+
+		for _, cmd := range []CmdExtractSubtree{{}, {DAGId: uuid.New().String(), NodeId: "not-a-uuid"}} {
+			_, err := useCase.Execute(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand, cmd)
+			_, err = useCase.Materialize(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand, cmd)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdGetAttachment struct {
+	SessionId    string `validate:"required,uuid"`
+	AttachmentId string `validate:"required,uuid"`
+}
+
+// AttachmentContent is an attachment along with its content
+type AttachmentContent struct {
+	Attachment model.Attachment
+	Data       []byte
+}
+
+type GetAttachmentUseCase struct {
+	sessionRepository SessionRepository
+	storage           AttachmentStorage
+	validator         *validator.Validate
+}
+
+func NewGetAttachmentUseCase(sessionRepository SessionRepository, storage AttachmentStorage) *GetAttachmentUseCase {
+	return &GetAttachmentUseCase{
+		sessionRepository: sessionRepository,
+		storage:           storage,
+		validator:         validator.New(),
+	}
+}
+
+// Execute reads an attachment of a session, the content being checked against the checksum recorded on upload
+func (u *GetAttachmentUseCase) Execute(ctx context.Context, cmd CmdGetAttachment) (*AttachmentContent, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	if u.storage == nil {
+		return nil, fmt.Errorf("%w: no attachment storage is configured", ErrUnavailable)
+	}
+
+	sessionId, err := uuid.Parse(cmd.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+	attachmentId, err := uuid.Parse(cmd.AttachmentId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	session, err := u.sessionRepository.Get(ctx, sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session: %w", err)
+	}
+	attachment, ok := session.Attachment(attachmentId)
+	if !ok {
+		return nil, fmt.Errorf("%w: attachment %s not found in session %s", ErrNotFound, attachmentId, sessionId)
+	}
+
+	data, err := u.storage.Get(ctx, attachment.StorageKey(sessionId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if checksum := model.Checksum(data); checksum != attachment.Checksum {
+		return nil, fmt.Errorf("%w: attachment %s is corrupted, its checksum is %s instead of %s", ErrInternal, attachmentId, checksum, attachment.Checksum)
+	}
+
+	return &AttachmentContent{Attachment: attachment, Data: data}, nil
+}
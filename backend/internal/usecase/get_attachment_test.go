@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAttachmentUseCase_Execute(t *testing.T) {
+	data := []byte("%PDF-1.4\n%dismissal letter")
+	attachment := model.Attachment{Id: uuid.New(), AnswerId: uuid.New(), FileName: "dismissal.pdf", Checksum: model.Checksum(data)}
+	session := &model.CaseSession{Id: uuid.New(), Attachments: []model.Attachment{attachment}}
+	cmd := CmdGetAttachment{SessionId: session.Id.String(), AttachmentId: attachment.Id.String()}
+
+	t.Run("returns the attachment with its content", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+		storage := mocks.NewMockAttachmentStorage(ctrl)
+		storage.EXPECT().Get(gomock.Any(), attachment.StorageKey(session.Id)).Return(data, nil)
+
+		content, err := NewGetAttachmentUseCase(sessionRepo, storage).Execute(context.Background(), cmd)
+		require.NoError(t, err)
+		assert.Equal(t, attachment, content.Attachment)
+		assert.Equal(t, data, content.Data)
+	})
+
+	t.Run("rejects content not matching the checksum", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+		storage := mocks.NewMockAttachmentStorage(ctrl)
+		storage.EXPECT().Get(gomock.Any(), attachment.StorageKey(session.Id)).Return([]byte("tampered"), nil)
+
+		_, err := NewGetAttachmentUseCase(sessionRepo, storage).Execute(context.Background(), cmd)
+		assert.ErrorIs(t, err, ErrInternal)
+	})
+
+	t.Run("returns not found for an unknown attachment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+
+		_, err := NewGetAttachmentUseCase(sessionRepo, mocks.NewMockAttachmentStorage(ctrl)).Execute(context.Background(), CmdGetAttachment{
+			SessionId:    session.Id.String(),
+			AttachmentId: uuid.NewString(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewGetAttachmentUseCase(mocks.NewMockSessionRepository(ctrl), mocks.NewMockAttachmentStorage(ctrl)).
+			Execute(context.Background(), CmdGetAttachment{SessionId: session.Id.String(), AttachmentId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
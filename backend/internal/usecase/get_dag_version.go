@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdGetDAGVersion struct {
+	DAGId  string `validate:"required,uuid"`
+	Number int    `validate:"min=1"`
+}
+
+type GetDAGVersionUseCase struct {
+	versionRepository DAGVersionRepository
+	validator         *validator.Validate
+}
+
+func NewGetDAGVersionUseCase(versionRepository DAGVersionRepository) *GetDAGVersionUseCase {
+	return &GetDAGVersionUseCase{
+		versionRepository: versionRepository,
+		validator:         validator.New(),
+	}
+}
+
+func (u *GetDAGVersionUseCase) Execute(ctx context.Context, cmd CmdGetDAGVersion) (*model.DAGVersion, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	return u.versionRepository.Get(ctx, id, cmd.Number)
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDAGVersionUseCase_Execute(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+	version := &model.DAGVersion{Number: 2, DAG: d}
+
+	t.Run("returns the version", func(t *testing.T) {
+		versionRepo := mocks.NewMockDAGVersionRepository(gomock.NewController(t))
+		versionRepo.EXPECT().Get(gomock.Any(), d.Id, 2).Return(version, nil)
+
+		result, err := NewGetDAGVersionUseCase(versionRepo).Execute(context.Background(), CmdGetDAGVersion{DAGId: d.Id.String(), Number: 2})
+		require.NoError(t, err)
+		assert.Equal(t, version, result)
+	})
+
+	t.Run("returns not found for unknown version", func(t *testing.T) {
+		versionRepo := mocks.NewMockDAGVersionRepository(gomock.NewController(t))
+		versionRepo.EXPECT().Get(gomock.Any(), d.Id, 3).Return(nil, ErrNotFound)
+
+		_, err := NewGetDAGVersionUseCase(versionRepo).Execute(context.Background(), CmdGetDAGVersion{DAGId: d.Id.String(), Number: 3})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid version number", func(t *testing.T) {
+		versionRepo := mocks.NewMockDAGVersionRepository(gomock.NewController(t))
+
+		_, err := NewGetDAGVersionUseCase(versionRepo).Execute(context.Background(), CmdGetDAGVersion{DAGId: d.Id.String(), Number: 0})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
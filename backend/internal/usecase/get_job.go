@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdGetJob struct {
+	JobId string `validate:"required,uuid"`
+}
+
+type GetJobUseCase struct {
+	jobRepository JobRepository
+	validator     *validator.Validate
+}
+
+func NewGetJobUseCase(jobRepository JobRepository) *GetJobUseCase {
+	return &GetJobUseCase{
+		jobRepository: jobRepository,
+		validator:     validator.New(),
+	}
+}
+
+func (u *GetJobUseCase) Execute(ctx context.Context, cmd CmdGetJob) (*model.Job, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.JobId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	if u.jobRepository == nil {
+		return nil, fmt.Errorf("%w: job with id %s not found", ErrNotFound, id)
+	}
+
+	job, err := u.jobRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve job: %w", err)
+	}
+
+	return job, nil
+}
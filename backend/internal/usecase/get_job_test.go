@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobUseCase_Execute(t *testing.T) {
+	job := model.NewJob(model.JobTypeValidateDAG, uuid.New(), time.Now())
+
+	t.Run("returns the job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		jobRepo := mocks.NewMockJobRepository(ctrl)
+		jobRepo.EXPECT().Get(gomock.Any(), job.Id).Return(&job, nil)
+
+		got, err := NewGetJobUseCase(jobRepo).Execute(context.Background(), CmdGetJob{JobId: job.Id.String()})
+		require.NoError(t, err)
+		assert.Equal(t, job, *got)
+	})
+
+	t.Run("returns not found for unknown job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		jobRepo := mocks.NewMockJobRepository(ctrl)
+		jobRepo.EXPECT().Get(gomock.Any(), job.Id).Return(nil, ErrNotFound)
+
+		_, err := NewGetJobUseCase(jobRepo).Execute(context.Background(), CmdGetJob{JobId: job.Id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("returns not found without job repository", func(t *testing.T) {
+		_, err := NewGetJobUseCase(nil).Execute(context.Background(), CmdGetJob{JobId: job.Id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid identifiers", func(t *testing.T) {
+		_, err := NewGetJobUseCase(nil).Execute(context.Background(), CmdGetJob{JobId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdGetPathAnalytics struct {
+	DAGId string `validate:"required,uuid"`
+	// Limit caps the number of paths returned, zero returns every path
+	Limit int `validate:"min=0"`
+}
+
+// RankedPath is a complete path of a DAG and the number of recorded walks that followed it
+type RankedPath struct {
+	Path  model.PathDetail
+	Count int
+}
+
+type GetPathAnalyticsUseCase struct {
+	dagRepository       DAGRepository
+	analyticsRepository WalkAnalyticsRepository
+	validator           *validator.Validate
+}
+
+func NewGetPathAnalyticsUseCase(dagRepository DAGRepository, analyticsRepository WalkAnalyticsRepository) *GetPathAnalyticsUseCase {
+	return &GetPathAnalyticsUseCase{
+		dagRepository:       dagRepository,
+		analyticsRepository: analyticsRepository,
+		validator:           validator.New(),
+	}
+}
+
+// Execute returns the most common complete paths of a stored DAG, most followed first.
+// Paths no longer matching the DAG structure since it was edited are left out.
+func (u *GetPathAnalyticsUseCase) Execute(ctx context.Context, cmd CmdGetPathAnalytics) ([]RankedPath, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	analytics, err := u.analyticsRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to retrieve walk analytics: %s", ErrInternal, err)
+	}
+
+	ranked := make([]RankedPath, 0, len(analytics.Paths))
+	for _, pathCount := range analytics.TopPaths(0) {
+		path, err := dag.ResolvePath(pathCount.AnswerIds)
+		if err != nil {
+			continue
+		}
+
+		ranked = append(ranked, RankedPath{Path: path, Count: pathCount.Count})
+		if cmd.Limit > 0 && len(ranked) == cmd.Limit {
+			break
+		}
+	}
+
+	return ranked, nil
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPathAnalyticsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d := dagtest.ValidSingleRoot()
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+
+	// Analytics repository applying updates atomically, like the real implementation does
+	var mu sync.Mutex
+	stored := model.NewWalkAnalytics(d.Id)
+	dagRepo := mocks.NewMockDAGRepository(ctrl)
+	dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil).AnyTimes()
+	analyticsRepo := mocks.NewMockWalkAnalyticsRepository(ctrl)
+	analyticsRepo.EXPECT().Update(gomock.Any(), d.Id, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id uuid.UUID, fnUpdate func(model.WalkAnalytics) (model.WalkAnalytics, error)) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			updated, err := fnUpdate(*stored)
+			if err != nil {
+				return err
+			}
+			stored = &updated
+			return nil
+		},
+	).AnyTimes()
+	analyticsRepo.EXPECT().Get(gomock.Any(), d.Id).DoAndReturn(
+		func(ctx context.Context, id uuid.UUID) (*model.WalkAnalytics, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return stored, nil
+		},
+	).AnyTimes()
+
+	// Record the third path 5 times, the first one 3 times and the second one once
+	recordWalk := NewRecordWalkUseCase(dagRepo, analyticsRepo)
+	var wg sync.WaitGroup
+	for pathIndex, walks := range map[int]int{2: 5, 0: 3, 1: 1} {
+		for i := 0; i < walks; i++ {
+			wg.Add(1)
+			go func(path model.PathDetail) {
+				defer wg.Done()
+				_, err := recordWalk.Execute(context.Background(), CmdRecordWalk{DAGId: d.Id.String(), AnswerIds: walkAnswerIds(path)})
+				assert.NoError(t, err)
+			}(paths[pathIndex])
+		}
+	}
+	wg.Wait()
+
+	useCase := NewGetPathAnalyticsUseCase(dagRepo, analyticsRepo)
+
+	t.Run("ranks paths by number of walks", func(t *testing.T) {
+		ranked, err := useCase.Execute(context.Background(), CmdGetPathAnalytics{DAGId: d.Id.String()})
+		require.NoError(t, err)
+		require.Len(t, ranked, 3)
+
+		assert.Equal(t, paths[2], ranked[0].Path)
+		assert.Equal(t, 5, ranked[0].Count)
+		assert.Equal(t, paths[0], ranked[1].Path)
+		assert.Equal(t, 3, ranked[1].Count)
+		assert.Equal(t, paths[1], ranked[2].Path)
+		assert.Equal(t, 1, ranked[2].Count)
+	})
+
+	t.Run("limits the number of paths", func(t *testing.T) {
+		ranked, err := useCase.Execute(context.Background(), CmdGetPathAnalytics{DAGId: d.Id.String(), Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, ranked, 1)
+		assert.Equal(t, 5, ranked[0].Count)
+	})
+
+	t.Run("skips paths no longer matching the DAG", func(t *testing.T) {
+		mu.Lock()
+		stored.Record([]uuid.UUID{uuid.New()})
+		stored.Record([]uuid.UUID{uuid.New()})
+		mu.Unlock()
+
+		ranked, err := useCase.Execute(context.Background(), CmdGetPathAnalytics{DAGId: d.Id.String()})
+		require.NoError(t, err)
+		assert.Len(t, ranked, 3)
+	})
+
+	t.Run("rejects negative limit", func(t *testing.T) {
+		_, err := useCase.Execute(context.Background(), CmdGetPathAnalytics{DAGId: d.Id.String(), Limit: -1})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
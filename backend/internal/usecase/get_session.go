@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdGetSession struct {
+	SessionId string `validate:"required,uuid"`
+}
+
+type GetSessionUseCase struct {
+	sessionRepository SessionRepository
+	validator         *validator.Validate
+}
+
+func NewGetSessionUseCase(sessionRepository SessionRepository) *GetSessionUseCase {
+	return &GetSessionUseCase{
+		sessionRepository: sessionRepository,
+		validator:         validator.New(),
+	}
+}
+
+func (u *GetSessionUseCase) Execute(ctx context.Context, cmd CmdGetSession) (*model.CaseSession, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return u.sessionRepository.Get(ctx, id)
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSessionUseCase_Execute(t *testing.T) {
+	session := &model.CaseSession{Id: uuid.New(), Status: model.SessionStatusInProgress}
+
+	t.Run("returns the stored session", func(t *testing.T) {
+		sessionRepo := mocks.NewMockSessionRepository(gomock.NewController(t))
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(session, nil)
+
+		got, err := NewGetSessionUseCase(sessionRepo).Execute(context.Background(), CmdGetSession{SessionId: session.Id.String()})
+		require.NoError(t, err)
+		assert.Equal(t, session, got)
+	})
+
+	t.Run("returns not found for unknown session", func(t *testing.T) {
+		sessionRepo := mocks.NewMockSessionRepository(gomock.NewController(t))
+		sessionRepo.EXPECT().Get(gomock.Any(), session.Id).Return(nil, ErrNotFound)
+
+		_, err := NewGetSessionUseCase(sessionRepo).Execute(context.Background(), CmdGetSession{SessionId: session.Id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid session ID", func(t *testing.T) {
+		_, err := NewGetSessionUseCase(mocks.NewMockSessionRepository(gomock.NewController(t))).
+			Execute(context.Background(), CmdGetSession{SessionId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
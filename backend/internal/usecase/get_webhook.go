@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdGetWebhook struct {
+	WebhookId string `validate:"required,uuid"`
+}
+
+type GetWebhookUseCase struct {
+	webhookRepository WebhookRepository
+	validator         *validator.Validate
+}
+
+func NewGetWebhookUseCase(webhookRepository WebhookRepository) *GetWebhookUseCase {
+	return &GetWebhookUseCase{
+		webhookRepository: webhookRepository,
+		validator:         validator.New(),
+	}
+}
+
+func (u *GetWebhookUseCase) Execute(ctx context.Context, cmd CmdGetWebhook) (*model.Webhook, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.WebhookId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	webhook, err := u.webhookRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+
+	return webhook, nil
+}
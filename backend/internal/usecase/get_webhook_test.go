@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWebhookUseCase_Execute(t *testing.T) {
+	webhook := &model.Webhook{Id: uuid.New(), URL: "https://example.com/hooks", Active: true}
+
+	t.Run("returns the webhook", func(t *testing.T) {
+		webhookRepo := mocks.NewMockWebhookRepository(gomock.NewController(t))
+		webhookRepo.EXPECT().Get(gomock.Any(), webhook.Id).Return(webhook, nil)
+
+		result, err := NewGetWebhookUseCase(webhookRepo).Execute(context.Background(), CmdGetWebhook{WebhookId: webhook.Id.String()})
+		require.NoError(t, err)
+		assert.Equal(t, webhook, result)
+	})
+
+	t.Run("returns not found for unknown webhook", func(t *testing.T) {
+		webhookRepo := mocks.NewMockWebhookRepository(gomock.NewController(t))
+		webhookRepo.EXPECT().Get(gomock.Any(), webhook.Id).Return(nil, fmt.Errorf("%w: webhook", ErrNotFound))
+
+		_, err := NewGetWebhookUseCase(webhookRepo).Execute(context.Background(), CmdGetWebhook{WebhookId: webhook.Id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid webhook ID", func(t *testing.T) {
+		_, err := NewGetWebhookUseCase(mocks.NewMockWebhookRepository(gomock.NewController(t))).
+			Execute(context.Background(), CmdGetWebhook{WebhookId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
+
+func TestListWebhooksUseCase_Execute(t *testing.T) {
+	webhooks := []model.Webhook{{Id: uuid.New()}, {Id: uuid.New()}}
+
+	webhookRepo := mocks.NewMockWebhookRepository(gomock.NewController(t))
+	webhookRepo.EXPECT().List(gomock.Any()).Return(webhooks, nil)
+
+	result, err := NewListWebhooksUseCase(webhookRepo).Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, webhooks, result)
+}
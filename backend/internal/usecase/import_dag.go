@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/importer"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdImportDAG struct {
+	Data []byte `validate:"required,min=1"`
+	// Format of the document, detected from its content when empty
+	Format string
+	// DryRun validates the imported DAG without storing it
+	DryRun bool
+	// NormalizeText normalizes the whitespace of the imported questions and statements
+	NormalizeText bool
+	// SkipValidationMetadata stores the DAG without recording its validation in its metadata
+	SkipValidationMetadata bool
+}
+
+// ImportResult is the outcome of an import: the converted DAG, its validation and whether it was stored
+type ImportResult struct {
+	Format     string
+	DAG        *model.DAG
+	Validation ValidationResult
+	Stored     bool
+}
+
+type ImportDAGUseCase struct {
+	dagValidator *DAGValidator
+	importers    *importer.Registry
+	creator      *CreateDAGUseCase
+	validator    *validator.Validate
+}
+
+func NewImportDAGUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, importers *importer.Registry, eventPublisher EventPublisher) *ImportDAGUseCase {
+	return &ImportDAGUseCase{
+		dagValidator: dagValidator,
+		importers:    importers,
+		creator:      NewCreateDAGUseCase(dagRepository, dagValidator, eventPublisher),
+		validator:    validator.New(),
+	}
+}
+
+// Execute converts a document into a DAG and validates it. Valid DAGs are stored unless dry run,
+// invalid ones are returned with their validation errors and never stored
+func (u *ImportDAGUseCase) Execute(ctx context.Context, cmd CmdImportDAG) (*ImportResult, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	dag, format, err := u.importers.Import(cmd.Format, cmd.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	if dag.Id == uuid.Nil {
+		dag.Id = uuid.New()
+	}
+	if cmd.NormalizeText {
+		dag.NormalizeText()
+	}
+
+	result := &ImportResult{
+		Format:     format,
+		DAG:        dag,
+		Validation: u.dagValidator.ValidateDAG(dag),
+	}
+	if cmd.DryRun || !result.Validation.IsValid {
+		return result, nil
+	}
+
+	result.DAG, err = u.creator.Execute(ctx, CmdCreateDAG{DAG: dag, SkipValidationMetadata: cmd.SkipValidationMetadata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import DAG: %w", err)
+	}
+	result.Stored = true
+
+	return result, nil
+}
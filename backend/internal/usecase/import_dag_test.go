@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/importer"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportDAGUseCase_Execute(t *testing.T) {
+	valid := dagtest.ValidSingleRoot()
+	validData, err := valid.MarshalJSON()
+	require.NoError(t, err)
+
+	cyclic := dagtest.Cyclic()
+	cyclicData, err := cyclic.MarshalJSON()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		cmd            CmdImportDAG
+		setupMock      func(*mocks.MockDAGRepository)
+		expectedValid  bool
+		expectedStored bool
+		errorType      error
+	}{
+		{
+			name: "stores a valid DAG",
+			cmd:  CmdImportDAG{Data: validData},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), valid.Id).Return(nil, ErrNotFound)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedValid:  true,
+			expectedStored: true,
+		},
+		{
+			name:          "validates without storing on dry run",
+			cmd:           CmdImportDAG{Data: validData, DryRun: true},
+			setupMock:     func(mockRepo *mocks.MockDAGRepository) {},
+			expectedValid: true,
+		},
+		{
+			name:      "returns validation errors without storing an invalid DAG",
+			cmd:       CmdImportDAG{Data: cyclicData},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+		},
+		{
+			name: "rejects a DAG ID already in use",
+			cmd:  CmdImportDAG{Data: validData},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), valid.Id).Return(valid, nil)
+			},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects a malformed document",
+			cmd:       CmdImportDAG{Data: []byte("{"), Format: importer.FormatJSON},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects an unknown format",
+			cmd:       CmdImportDAG{Data: validData, Format: "csv"},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects an empty document",
+			cmd:       CmdImportDAG{Data: []byte{}},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			result, err := NewImportDAGUseCase(mockRepo, NewDAGValidator(), importer.DefaultRegistry(), nil).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, importer.FormatJSON, result.Format)
+			assert.Equal(t, tt.expectedValid, result.Validation.IsValid)
+			assert.Equal(t, tt.expectedStored, result.Stored)
+			assert.NotEmpty(t, result.DAG.Nodes)
+		})
+	}
+}
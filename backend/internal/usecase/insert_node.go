@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// CmdInsertNode inserts a new node between an answer and its current target.
+// The new node gets a single answer, with the given statement, leading to the original target.
+type CmdInsertNode struct {
+	DAGId     string `validate:"required,uuid"`
+	AnswerId  string `validate:"required,uuid"`
+	Question  string `validate:"required"`
+	Statement string `validate:"required"`
+}
+
+type InsertNodeUseCase struct {
+	dagRepository  DAGRepository
+	dagValidator   *DAGValidator
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewInsertNodeUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *InsertNodeUseCase {
+	return &InsertNodeUseCase{
+		dagRepository:  dagRepository,
+		dagValidator:   dagValidator,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// Execute repoints the answer to a freshly created node and re-validates the resulting DAG
+func (u *InsertNodeUseCase) Execute(ctx context.Context, cmd CmdInsertNode) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagId, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	answerId, err := uuid.Parse(cmd.AnswerId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	// The IDs are generated once, the repository may apply the update to several copies of the DAG
+	newNodeId := uuid.New()
+	newAnswerId := uuid.New()
+
+	var updatedDAG model.DAG
+	err = u.dagRepository.Update(ctx, dagId, func(existingDAG model.DAG) (model.DAG, error) {
+		// Copy the nodes so the stored DAG is never mutated in place
+		nodes := make(map[uuid.UUID]model.Node, len(existingDAG.Nodes)+1)
+		var originalTarget *uuid.UUID
+		found := false
+		for id, node := range existingDAG.Nodes {
+			for i, answer := range node.Answers {
+				if answer.Id != answerId {
+					continue
+				}
+
+				originalTarget = answer.NextNode
+				node.Answers = append([]model.Answer(nil), node.Answers...)
+				node.Answers[i].NextNode = &newNodeId
+				found = true
+			}
+			nodes[id] = node
+		}
+
+		if !found {
+			return existingDAG, fmt.Errorf("%w: answer %s not found in DAG %s", ErrNotFound, answerId, dagId)
+		}
+
+		// The node advances through its single answer, it is an information node rather than a question
+		// offering a single answer
+		nodes[newNodeId] = model.Node{
+			Id:       newNodeId,
+			Type:     model.NodeTypeInformation,
+			Question: cmd.Question,
+			Answers: []model.Answer{
+				{
+					Id:        newAnswerId,
+					Statement: cmd.Statement,
+					NextNode:  originalTarget,
+				},
+			},
+		}
+
+		existingDAG.Nodes = nodes
+		existingDAG.UpdatedAt = time.Now()
+		existingDAG.Revision++
+		result := u.dagValidator.ValidateDAG(&existingDAG)
+		if !result.IsValid {
+			var errorMessages []string
+			for _, err := range result.Errors {
+				errorMessages = append(errorMessages, err.Message)
+			}
+			return existingDAG, fmt.Errorf("%w: DAG validation failed: %v", ErrInvalidCommand, errorMessages)
+		}
+
+		updatedDAG = existingDAG
+		return existingDAG, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert node: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", dagId.String()).
+		Str("answer_id", answerId.String()).
+		Msg("node inserted")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, dagId, time.Now()))
+
+	return &updatedDAG, nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertNodeUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		validator  *DAGValidator
+		cmd        func(d *model.DAG, answerId uuid.UUID) CmdInsertNode
+		expectRepo bool
+		errorType  error
+	}{
+		{
+			name:      "routes the answer through the inserted node",
+			validator: NewDAGValidator(),
+			cmd: func(d *model.DAG, answerId uuid.UUID) CmdInsertNode {
+				return CmdInsertNode{DAGId: d.Id.String(), AnswerId: answerId.String(), Question: "Inserted question?", Statement: "Continue"}
+			},
+			expectRepo: true,
+		},
+		{
+			name:      "returns not found for unknown answer",
+			validator: NewDAGValidator(),
+			cmd: func(d *model.DAG, _ uuid.UUID) CmdInsertNode {
+				return CmdInsertNode{DAGId: d.Id.String(), AnswerId: uuid.New().String(), Question: "Inserted question?", Statement: "Continue"}
+			},
+			expectRepo: true,
+			errorType:  ErrNotFound,
+		},
+		{
+			name:      "rejects an insertion breaking the validation profile",
+			validator: NewDAGValidatorFromProfile(ValidationProfile{Name: "small", MaxNodes: 2}),
+			cmd: func(d *model.DAG, answerId uuid.UUID) CmdInsertNode {
+				return CmdInsertNode{DAGId: d.Id.String(), AnswerId: answerId.String(), Question: "Inserted question?", Statement: "Continue"}
+			},
+			expectRepo: true,
+			errorType:  ErrInvalidCommand,
+		},
+		{
+			name:      "returns validation error for missing question",
+			validator: NewDAGValidator(),
+			cmd: func(d *model.DAG, answerId uuid.UUID) CmdInsertNode {
+				return CmdInsertNode{DAGId: d.Id.String(), AnswerId: answerId.String(), Statement: "Continue"}
+			},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stored := dagtest.LinearChain(2)
+			root := dagtest.Root(stored)
+			answer := root.Answers[0]
+			originalTarget := *answer.NextNode
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			if tt.expectRepo {
+				mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+						_, err := fnUpdate(*stored)
+						return err
+					},
+				)
+			}
+
+			useCase := NewInsertNodeUseCase(mockRepo, tt.validator, nil)
+			updated, err := useCase.Execute(context.Background(), tt.cmd(stored, answer.Id))
+
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				assert.Nil(t, updated)
+				assert.Equal(t, originalTarget, *stored.Nodes[root.Id].Answers[0].NextNode, "stored DAG must not be mutated")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, updated.Nodes, 3)
+
+			insertedId := *updated.Nodes[root.Id].Answers[0].NextNode
+			assert.NotEqual(t, originalTarget, insertedId)
+
+			inserted := updated.Nodes[insertedId]
+			assert.Equal(t, "Inserted question?", inserted.Question)
+			assert.Equal(t, model.NodeTypeInformation, inserted.Kind())
+			require.Len(t, inserted.Answers, 1)
+			assert.Equal(t, "Continue", inserted.Answers[0].Statement)
+			assert.Equal(t, originalTarget, *inserted.Answers[0].NextNode)
+
+			// The original target is still reachable from the root, through the inserted node
+			path, err := updated.Walk(root.Id, func(node model.Node) (model.Answer, error) {
+				return node.Answers[0], nil
+			})
+			require.NoError(t, err)
+			require.Len(t, path, 2)
+			assert.Equal(t, insertedId, *path[0].NextNode)
+			assert.Equal(t, originalTarget, *path[1].NextNode)
+
+			assert.Equal(t, originalTarget, *stored.Nodes[root.Id].Answers[0].NextNode, "stored DAG must not be mutated")
+		})
+	}
+}
+
+func TestInsertNodeUseCase_Execute_ValidationWarnings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	validator := NewDAGValidator()
+	stored := dagtest.ValidSingleRoot()
+	root := dagtest.Root(stored)
+	before := validator.ValidateDAG(stored)
+	require.True(t, before.IsValid)
+
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+			_, err := fnUpdate(*stored)
+			return err
+		},
+	)
+
+	updated, err := NewInsertNodeUseCase(mockRepo, validator, nil).Execute(context.Background(), CmdInsertNode{
+		DAGId:     stored.Id.String(),
+		AnswerId:  root.Answers[0].Id.String(),
+		Question:  "Please gather your employment contract",
+		Statement: "Continue",
+	})
+	require.NoError(t, err)
+
+	// The inserted node offers a single answer without being reported as a question asking nothing
+	after := validator.ValidateDAG(updated)
+	assert.True(t, after.IsValid)
+	assert.Equal(t, before.Warnings, after.Warnings)
+	for _, warning := range after.Warnings {
+		assert.NotEqual(t, "NODE_SINGLE_ANSWER", warning.Code)
+	}
+}
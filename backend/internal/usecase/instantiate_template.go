@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdInstantiateTemplate struct {
+	TemplateId string `validate:"required,uuid"`
+	// Title of the new DAG, the title of the template DAG when empty
+	Title string `validate:"max=200"`
+}
+
+type InstantiateTemplateUseCase struct {
+	templateRepository TemplateRepository
+	createDAG          *CreateDAGUseCase
+	validator          *validator.Validate
+}
+
+func NewInstantiateTemplateUseCase(templateRepository TemplateRepository, dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *InstantiateTemplateUseCase {
+	return &InstantiateTemplateUseCase{
+		templateRepository: templateRepository,
+		createDAG:          NewCreateDAGUseCase(dagRepository, dagValidator, eventPublisher),
+		validator:          validator.New(),
+	}
+}
+
+// Execute creates a new DAG from a template, with fresh DAG, node and answer IDs. The DAG is validated and
+// announced like any created DAG.
+func (u *InstantiateTemplateUseCase) Execute(ctx context.Context, cmd CmdInstantiateTemplate) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.TemplateId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	template, err := u.templateRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve template: %w", err)
+	}
+
+	dag, err := u.createDAG.Execute(ctx, CmdCreateDAG{DAG: template.Instantiate(cmd.Title)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate template %s: %w", id, err)
+	}
+
+	return dag, nil
+}
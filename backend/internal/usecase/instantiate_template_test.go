@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstantiateTemplateUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	template := model.NewTemplate("Employment", "", dagtest.ValidSingleRoot(), time.Now())
+
+	t.Run("creates a DAG with fresh IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		templateRepo := mocks.NewMockTemplateRepository(ctrl)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		publisher := mocks.NewMockEventPublisher(ctrl)
+
+		templateRepo.EXPECT().Get(gomock.Any(), template.Id).Return(&template, nil)
+		dagRepo.EXPECT().Get(gomock.Any(), gomock.Not(template.DAG.Id)).Return(nil, ErrNotFound)
+		dagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		dag, err := NewInstantiateTemplateUseCase(templateRepo, dagRepo, NewDAGValidator(), publisher).Execute(context.Background(), CmdInstantiateTemplate{
+			TemplateId: template.Id.String(),
+			Title:      "Smith v. Acme",
+		})
+		require.NoError(t, err)
+
+		assert.NotEqual(t, template.DAG.Id, dag.Id)
+		assert.Equal(t, "Smith v. Acme", dag.Title)
+		assert.Len(t, dag.Nodes, len(template.DAG.Nodes))
+		for id := range dag.Nodes {
+			assert.NotContains(t, template.DAG.Nodes, id)
+		}
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		useCase := NewInstantiateTemplateUseCase(mocks.NewMockTemplateRepository(ctrl), mocks.NewMockDAGRepository(ctrl), NewDAGValidator(), nil)
+
+		for _, cmd := range []CmdInstantiateTemplate{{}, {TemplateId: "not-a-uuid"}} {
+			_, err := useCase.Execute(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand, cmd)
+		}
+	})
+
+	t.Run("fails when the template does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		templateRepo := mocks.NewMockTemplateRepository(ctrl)
+		templateRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, ErrNotFound)
+
+		_, err := NewInstantiateTemplateUseCase(templateRepo, mocks.NewMockDAGRepository(ctrl), NewDAGValidator(), nil).Execute(context.Background(), CmdInstantiateTemplate{
+			TemplateId: uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=job_queue.go -destination=testdata/mocks/job_queue_mock.go -package=mocks
+
+// JobQueue runs long-running tasks in the background with the handler registered for their type
+type JobQueue interface {
+	// Handle registers the handler running the jobs of a type and returning their result,
+	// before jobs of that type are enqueued
+	Handle(jobType string, handler func(ctx context.Context, job model.Job) (interface{}, error))
+	// Enqueue records the pending job and queues it for a worker, ErrUnavailable is returned when the queue is full
+	Enqueue(ctx context.Context, job model.Job) error
+}
+
+// JobRepository keeps the jobs, Get returns ErrNotFound for unknown jobs
+type JobRepository interface {
+	// Save creates or replaces a job
+	Save(ctx context.Context, job model.Job) error
+	Get(ctx context.Context, id uuid.UUID) (*model.Job, error)
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdListDAGVersions struct {
+	DAGId string `validate:"required,uuid"`
+}
+
+type ListDAGVersionsUseCase struct {
+	dagRepository     DAGRepository
+	versionRepository DAGVersionRepository
+	validator         *validator.Validate
+}
+
+func NewListDAGVersionsUseCase(dagRepository DAGRepository, versionRepository DAGVersionRepository) *ListDAGVersionsUseCase {
+	return &ListDAGVersionsUseCase{
+		dagRepository:     dagRepository,
+		versionRepository: versionRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute returns the versions of a stored DAG, oldest first
+func (u *ListDAGVersionsUseCase) Execute(ctx context.Context, cmd CmdListDAGVersions) ([]model.DAGVersion, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	if _, err := u.dagRepository.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to list DAG versions: %w", err)
+	}
+
+	versions, err := u.versionRepository.List(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DAG versions: %w", err)
+	}
+
+	return versions, nil
+}
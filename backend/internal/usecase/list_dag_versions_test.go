@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDAGVersionsUseCase_Execute(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+	versions := []model.DAGVersion{{Number: 1, DAG: d}, {Number: 2, DAG: d}}
+
+	tests := []struct {
+		name       string
+		cmd        CmdListDAGVersions
+		setupMocks func(*mocks.MockDAGRepository, *mocks.MockDAGVersionRepository)
+		expected   []model.DAGVersion
+		errorType  error
+	}{
+		{
+			name: "lists the versions of a DAG",
+			cmd:  CmdListDAGVersions{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, versionRepo *mocks.MockDAGVersionRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				versionRepo.EXPECT().List(gomock.Any(), d.Id).Return(versions, nil)
+			},
+			expected: versions,
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdListDAGVersions{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, versionRepo *mocks.MockDAGVersionRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:       "rejects invalid DAG ID",
+			cmd:        CmdListDAGVersions{DAGId: "invalid"},
+			setupMocks: func(*mocks.MockDAGRepository, *mocks.MockDAGVersionRepository) {},
+			errorType:  ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dagRepo := mocks.NewMockDAGRepository(ctrl)
+			versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+			tt.setupMocks(dagRepo, versionRepo)
+
+			result, err := NewListDAGVersionsUseCase(dagRepo, versionRepo).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -3,21 +3,46 @@ package usecase
 import (
 	"context"
 	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"time"
 
+	"github.com/go-playground/validator"
 	"github.com/google/uuid"
 )
 
+const (
+	// SortUpdatedAsc orders DAGs from the least to the most recently updated, kept as an alias of SortUpdatedAtAsc
+	SortUpdatedAsc = "updated"
+	// SortUpdatedDesc orders DAGs from the most to the least recently updated, kept as an alias of SortUpdatedAtDesc
+	SortUpdatedDesc = "-updated"
+	// DefaultPageSize is the page size used when a page is requested without a size
+	DefaultPageSize = 20
+	// MaxPageSize caps the number of DAGs returned in a page
+	MaxPageSize = 100
+)
+
 type CmdListDAGs struct {
-	// No parameters needed for listing all DAGs
+	// Sort orders the DAGs by title or update time, empty orders them by ID
+	Sort string `validate:"omitempty,oneof=title -title updated_at -updated_at updated -updated"`
+	// UpdatedSince keeps only the DAGs updated at or after the given time, zero keeps every DAG
+	UpdatedSince time.Time
+	// TitleContains keeps only the DAGs whose title contains the text, case insensitive
+	TitleContains string
+	// Page is the 1-based page to return, zero returns every DAG unless a page size is set
+	Page int `validate:"min=0"`
+	// PageSize is the number of DAGs per page up to MaxPageSize, DefaultPageSize when only a page is requested
+	PageSize int `validate:"min=0,max=100"`
 }
 
 type ListDAGsUseCase struct {
 	dagRepository DAGRepository
+	validator     *validator.Validate
 }
 
 func NewListDAGsUseCase(dagRepository DAGRepository) *ListDAGsUseCase {
 	return &ListDAGsUseCase{
 		dagRepository: dagRepository,
+		validator:     validator.New(),
 	}
 }
 
@@ -25,22 +50,35 @@ func (u *ListDAGsUseCase) List(ctx context.Context, cmd CmdListDAGs) ([]uuid.UUI
 	return u.dagRepository.List(ctx)
 }
 
-// ListDAGs returns full DAG objects instead of just IDs
-func (u *ListDAGsUseCase) ListDAGs(ctx context.Context, cmd CmdListDAGs) ([]*model.DAG, error) {
-	dagIds, err := u.dagRepository.List(ctx)
+// ListDAGs returns a page of full DAG objects instead of just IDs along with the number of matching DAGs.
+// DAGs are filtered, ordered and paginated by the repository, every matching DAG is returned when no page is requested.
+func (u *ListDAGsUseCase) ListDAGs(ctx context.Context, cmd CmdListDAGs) (*model.DAGPage, error) {
+	err := u.validator.Struct(cmd)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
 	}
 
-	dags := make([]*model.DAG, 0, len(dagIds))
-	for _, id := range dagIds {
-		dag, err := u.dagRepository.Get(ctx, id)
-		if err != nil {
-			// Skip DAGs that can't be loaded, but log the error
-			continue
-		}
-		dags = append(dags, dag)
+	query := model.DAGQuery{
+		TitleContains: cmd.TitleContains,
+		UpdatedSince:  cmd.UpdatedSince,
+		Sort:          cmd.Sort,
+		Page:          cmd.Page,
+		PageSize:      cmd.PageSize,
+	}
+	if query.Page > 0 && query.PageSize == 0 {
+		query.PageSize = DefaultPageSize
+	}
+	switch cmd.Sort {
+	case SortUpdatedAsc:
+		query.Sort = model.SortUpdatedAtAsc
+	case SortUpdatedDesc:
+		query.Sort = model.SortUpdatedAtDesc
+	}
+
+	page, err := u.dagRepository.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DAGs: %w", err)
 	}
 
-	return dags, nil
+	return page, nil
 }
@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -82,3 +84,107 @@ func TestListDAGsUseCase_List(t *testing.T) {
 		})
 	}
 }
+
+func TestListDAGsUseCase_ListDAGs_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().Query(gomock.Any(), model.DAGQuery{}).Return(nil, context.Canceled)
+
+	result, err := NewListDAGsUseCase(mockRepo).ListDAGs(context.Background(), CmdListDAGs{})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, result)
+}
+
+func TestListDAGsUseCase_ListDAGs_Query(t *testing.T) {
+	since := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		cmd           CmdListDAGs
+		expectedQuery model.DAGQuery
+		errorType     error
+	}{
+		{
+			name:          "lists every DAG by default",
+			cmd:           CmdListDAGs{},
+			expectedQuery: model.DAGQuery{},
+		},
+		{
+			name: "forwards filters, sort and page",
+			cmd: CmdListDAGs{
+				Sort:          model.SortTitleDesc,
+				UpdatedSince:  since,
+				TitleContains: "employment",
+				Page:          2,
+				PageSize:      10,
+			},
+			expectedQuery: model.DAGQuery{
+				Sort:          model.SortTitleDesc,
+				UpdatedSince:  since,
+				TitleContains: "employment",
+				Page:          2,
+				PageSize:      10,
+			},
+		},
+		{
+			name:          "maps the updated alias",
+			cmd:           CmdListDAGs{Sort: SortUpdatedAsc},
+			expectedQuery: model.DAGQuery{Sort: model.SortUpdatedAtAsc},
+		},
+		{
+			name:          "maps the -updated alias",
+			cmd:           CmdListDAGs{Sort: SortUpdatedDesc},
+			expectedQuery: model.DAGQuery{Sort: model.SortUpdatedAtDesc},
+		},
+		{
+			name:          "uses the default page size when only a page is requested",
+			cmd:           CmdListDAGs{Page: 3},
+			expectedQuery: model.DAGQuery{Page: 3, PageSize: DefaultPageSize},
+		},
+		{
+			name:          "returns the first page when only a page size is requested",
+			cmd:           CmdListDAGs{PageSize: 5},
+			expectedQuery: model.DAGQuery{PageSize: 5},
+		},
+		{
+			name:      "rejects unknown sort",
+			cmd:       CmdListDAGs{Sort: "name"},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects negative page",
+			cmd:       CmdListDAGs{Page: -1},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects page size above the maximum",
+			cmd:       CmdListDAGs{PageSize: MaxPageSize + 1},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			expected := &model.DAGPage{DAGs: []*model.DAG{{Id: uuid.New(), Title: "stored"}}, Total: 1}
+			if tt.errorType == nil {
+				mockRepo.EXPECT().Query(gomock.Any(), tt.expectedQuery).Return(expected, nil)
+			}
+
+			result, err := NewListDAGsUseCase(mockRepo).ListDAGs(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, expected, result)
+		})
+	}
+}
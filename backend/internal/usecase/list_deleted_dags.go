@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"sort"
+)
+
+type ListDeletedDAGsUseCase struct {
+	dagRepository DAGRepository
+}
+
+func NewListDeletedDAGsUseCase(dagRepository DAGRepository) *ListDeletedDAGsUseCase {
+	return &ListDeletedDAGsUseCase{
+		dagRepository: dagRepository,
+	}
+}
+
+// Execute returns the DAGs in the trash, the most recently deleted first
+func (u *ListDeletedDAGsUseCase) Execute(ctx context.Context) ([]*model.DAG, error) {
+	page, err := u.dagRepository.Query(ctx, model.DAGQuery{Deleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted DAGs: %w", err)
+	}
+
+	dags := page.DAGs
+	sort.SliceStable(dags, func(i, j int) bool {
+		return dags[i].DeletedAt.After(*dags[j].DeletedAt)
+	})
+
+	return dags, nil
+}
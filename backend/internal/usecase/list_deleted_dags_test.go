@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDeletedDAGsUseCase_Execute(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	first := model.NewDAG("Deleted first")
+	first.DeletedAt = &earlier
+	last := model.NewDAG("Deleted last")
+	last.DeletedAt = &now
+
+	t.Run("lists the trash most recently deleted first", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Query(gomock.Any(), model.DAGQuery{Deleted: true}).Return(&model.DAGPage{DAGs: []*model.DAG{first, last}, Total: 2}, nil)
+
+		dags, err := NewListDeletedDAGsUseCase(mockRepo).Execute(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []*model.DAG{last, first}, dags)
+	})
+
+	t.Run("propagates repository errors", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, ErrInternal)
+
+		_, err := NewListDeletedDAGsUseCase(mockRepo).Execute(context.Background())
+		assert.ErrorIs(t, err, ErrInternal)
+	})
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+)
+
+type ListTemplatesUseCase struct {
+	templateRepository TemplateRepository
+}
+
+func NewListTemplatesUseCase(templateRepository TemplateRepository) *ListTemplatesUseCase {
+	return &ListTemplatesUseCase{
+		templateRepository: templateRepository,
+	}
+}
+
+// Execute returns every template, ordered by name
+func (u *ListTemplatesUseCase) Execute(ctx context.Context) ([]model.Template, error) {
+	templates, err := u.templateRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// DefaultDeliveriesLimit is the number of deliveries returned when no limit is given
+const DefaultDeliveriesLimit = 20
+
+type CmdListWebhookDeliveries struct {
+	WebhookId string `validate:"required,uuid"`
+	// Limit bounds the number of deliveries, DefaultDeliveriesLimit when zero
+	Limit int `validate:"min=0,max=100"`
+}
+
+type ListWebhookDeliveriesUseCase struct {
+	webhookRepository  WebhookRepository
+	deliveryRepository WebhookDeliveryRepository
+	validator          *validator.Validate
+}
+
+func NewListWebhookDeliveriesUseCase(webhookRepository WebhookRepository, deliveryRepository WebhookDeliveryRepository) *ListWebhookDeliveriesUseCase {
+	return &ListWebhookDeliveriesUseCase{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		validator:          validator.New(),
+	}
+}
+
+// Execute returns the latest deliveries of a webhook, most recent first
+func (u *ListWebhookDeliveriesUseCase) Execute(ctx context.Context, cmd CmdListWebhookDeliveries) ([]model.WebhookDelivery, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.WebhookId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	// Unknown webhooks are not found rather than without deliveries
+	if _, err := u.webhookRepository.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+
+	limit := cmd.Limit
+	if limit == 0 {
+		limit = DefaultDeliveriesLimit
+	}
+
+	deliveries, err := u.deliveryRepository.List(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
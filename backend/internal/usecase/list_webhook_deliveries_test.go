@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWebhookDeliveriesUseCase_Execute(t *testing.T) {
+	webhook := &model.Webhook{Id: uuid.New()}
+	deliveries := []model.WebhookDelivery{{Id: uuid.New(), WebhookId: webhook.Id, Status: model.DeliveryStatusSucceeded}}
+
+	t.Run("returns the latest deliveries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Get(gomock.Any(), webhook.Id).Return(webhook, nil)
+		deliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+		deliveryRepo.EXPECT().List(gomock.Any(), webhook.Id, DefaultDeliveriesLimit).Return(deliveries, nil)
+
+		result, err := NewListWebhookDeliveriesUseCase(webhookRepo, deliveryRepo).Execute(context.Background(), CmdListWebhookDeliveries{WebhookId: webhook.Id.String()})
+		require.NoError(t, err)
+		assert.Equal(t, deliveries, result)
+	})
+
+	t.Run("returns not found for unknown webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Get(gomock.Any(), webhook.Id).Return(nil, fmt.Errorf("%w: webhook", ErrNotFound))
+
+		_, err := NewListWebhookDeliveriesUseCase(webhookRepo, mocks.NewMockWebhookDeliveryRepository(ctrl)).
+			Execute(context.Background(), CmdListWebhookDeliveries{WebhookId: webhook.Id.String(), Limit: 5})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		useCase := NewListWebhookDeliveriesUseCase(mocks.NewMockWebhookRepository(ctrl), mocks.NewMockWebhookDeliveryRepository(ctrl))
+
+		_, err := useCase.Execute(context.Background(), CmdListWebhookDeliveries{WebhookId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+
+		_, err = useCase.Execute(context.Background(), CmdListWebhookDeliveries{WebhookId: webhook.Id.String(), Limit: 500})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
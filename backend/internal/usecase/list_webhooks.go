@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+)
+
+type ListWebhooksUseCase struct {
+	webhookRepository WebhookRepository
+}
+
+func NewListWebhooksUseCase(webhookRepository WebhookRepository) *ListWebhooksUseCase {
+	return &ListWebhooksUseCase{
+		webhookRepository: webhookRepository,
+	}
+}
+
+// Execute returns every registered webhook, ordered by creation date
+func (u *ListWebhooksUseCase) Execute(ctx context.Context) ([]model.Webhook, error) {
+	webhooks, err := u.webhookRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// LiveDAGRepository hides the soft-deleted DAGs of a repository, they are only reachable through the trash use cases
+type LiveDAGRepository struct {
+	DAGRepository
+}
+
+func NewLiveDAGRepository(dagRepository DAGRepository) *LiveDAGRepository {
+	return &LiveDAGRepository{DAGRepository: dagRepository}
+}
+
+// List returns the IDs of the live DAGs
+func (r *LiveDAGRepository) List(ctx context.Context) ([]uuid.UUID, error) {
+	page, err := r.DAGRepository.Query(ctx, model.DAGQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(page.DAGs))
+	for i, dag := range page.DAGs {
+		ids[i] = dag.Id
+	}
+	return ids, nil
+}
+
+// Query runs the query over the live DAGs only
+func (r *LiveDAGRepository) Query(ctx context.Context, query model.DAGQuery) (*model.DAGPage, error) {
+	query.Deleted = false
+	return r.DAGRepository.Query(ctx, query)
+}
+
+// Get reports soft-deleted DAGs as not found
+func (r *LiveDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG, error) {
+	dag, err := r.DAGRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dag.IsDeleted() {
+		return nil, fmt.Errorf("%w: DAG %s is deleted", ErrNotFound, id)
+	}
+	return dag, nil
+}
+
+// Update reports soft-deleted DAGs as not found, the check runs inside the repository update
+func (r *LiveDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(dag model.DAG) (model.DAG, error)) error {
+	return r.DAGRepository.Update(ctx, id, func(dag model.DAG) (model.DAG, error) {
+		if dag.IsDeleted() {
+			return dag, fmt.Errorf("%w: DAG %s is deleted", ErrNotFound, id)
+		}
+		return fnUpdate(dag)
+	})
+}
+
+// Each yields the live DAGs only
+func (r *LiveDAGRepository) Each(ctx context.Context, fn func(dag *model.DAG) error) error {
+	return r.DAGRepository.Each(ctx, func(dag *model.DAG) error {
+		if dag.IsDeleted() {
+			return nil
+		}
+		return fn(dag)
+	})
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveDAGRepository(t *testing.T) {
+	ctx := context.Background()
+	live := model.NewDAG("Live")
+	deletedAt := time.Now()
+	trashed := model.NewDAG("Trashed")
+	trashed.DeletedAt = &deletedAt
+
+	t.Run("hides soft-deleted DAGs on get", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(gomock.Any(), live.Id).Return(live, nil)
+		mockRepo.EXPECT().Get(gomock.Any(), trashed.Id).Return(trashed, nil)
+		repo := NewLiveDAGRepository(mockRepo)
+
+		dag, err := repo.Get(ctx, live.Id)
+		require.NoError(t, err)
+		assert.Equal(t, live, dag)
+
+		_, err = repo.Get(ctx, trashed.Id)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("lists and queries live DAGs only", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Query(gomock.Any(), model.DAGQuery{}).Return(&model.DAGPage{DAGs: []*model.DAG{live}, Total: 1}, nil).Times(2)
+		repo := NewLiveDAGRepository(mockRepo)
+
+		ids, err := repo.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{live.Id}, ids)
+
+		page, err := repo.Query(ctx, model.DAGQuery{Deleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+	})
+
+	t.Run("rejects updates of soft-deleted DAGs", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Update(gomock.Any(), trashed.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.DAG) (model.DAG, error)) error {
+				_, err := fn(*trashed)
+				return err
+			},
+		)
+		repo := NewLiveDAGRepository(mockRepo)
+
+		err := repo.Update(ctx, trashed.Id, func(dag model.DAG) (model.DAG, error) {
+			t.Fatal("update function called on a soft-deleted DAG")
+			return dag, nil
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("skips soft-deleted DAGs on iteration", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Each(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, fn func(*model.DAG) error) error {
+				for _, dag := range []*model.DAG{live, trashed} {
+					if err := fn(dag); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		)
+		repo := NewLiveDAGRepository(mockRepo)
+
+		var yielded []uuid.UUID
+		require.NoError(t, repo.Each(ctx, func(dag *model.DAG) error {
+			yielded = append(yielded, dag.Id)
+			return nil
+		}))
+		assert.Equal(t, []uuid.UUID{live.Id}, yielded)
+	})
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// CmdMergeAnswerMetadata merges metadata keys into a single answer.
+// Keys with a nil value are removed from the answer metadata.
+type CmdMergeAnswerMetadata struct {
+	DAGId    string                 `validate:"required,uuid"`
+	AnswerId string                 `validate:"required,uuid"`
+	Metadata map[string]interface{} `validate:"required"`
+}
+
+type MergeAnswerMetadataUseCase struct {
+	dagRepository  DAGRepository
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewMergeAnswerMetadataUseCase(dagRepository DAGRepository, eventPublisher EventPublisher) *MergeAnswerMetadataUseCase {
+	return &MergeAnswerMetadataUseCase{
+		dagRepository:  dagRepository,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// Execute merges the metadata inside the repository update so that concurrent merges
+// on different answers of the same DAG do not overwrite each other. The merged metadata
+// must match the metadata schema of the DAG.
+func (u *MergeAnswerMetadataUseCase) Execute(ctx context.Context, cmd CmdMergeAnswerMetadata) (*model.Answer, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagId, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	answerId, err := uuid.Parse(cmd.AnswerId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	var mergedAnswer model.Answer
+	err = u.dagRepository.Update(ctx, dagId, func(existingDAG model.DAG) (model.DAG, error) {
+		now := time.Now()
+
+		// Copy the nodes so the stored DAG is never mutated in place
+		nodes := make(map[uuid.UUID]model.Node, len(existingDAG.Nodes))
+		found := false
+		for id, node := range existingDAG.Nodes {
+			for i, answer := range node.Answers {
+				if answer.Id != answerId {
+					continue
+				}
+
+				node.Answers = append([]model.Answer(nil), node.Answers...)
+				merged := mergeMetadata(answer, cmd.Metadata, now, actorFromContext(ctx))
+				if err := existingDAG.MetadataSchema.Validate(merged.Metadata); err != nil {
+					return existingDAG, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+				}
+				node.Answers[i] = merged
+				mergedAnswer = node.Answers[i]
+				found = true
+			}
+			nodes[id] = node
+		}
+
+		if !found {
+			return existingDAG, fmt.Errorf("%w: answer %s not found in DAG %s", ErrNotFound, answerId, dagId)
+		}
+
+		existingDAG.Nodes = nodes
+		existingDAG.UpdatedAt = now
+		existingDAG.Revision++
+		return existingDAG, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge answer metadata: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", dagId.String()).
+		Str("answer_id", answerId.String()).
+		Msg("answer metadata merged")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, dagId, time.Now()))
+
+	return &mergedAnswer, nil
+}
+
+// mergeMetadata returns a copy of the answer with the given keys merged into its metadata
+func mergeMetadata(answer model.Answer, metadata map[string]interface{}, at time.Time, by string) model.Answer {
+	previous := answer.Metadata
+
+	merged := make(map[string]interface{}, len(previous)+len(metadata))
+	for key, value := range previous {
+		merged[key] = value
+	}
+	for key, value := range metadata {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+
+	answer.Metadata = merged
+	answer.MetadataHistory = append([]model.MetadataSnapshot(nil), answer.MetadataHistory...)
+	answer.RecordMetadataChange(previous, at, by)
+
+	return answer
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMergeAnswerMetadataUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	useCase := NewMergeAnswerMetadataUseCase(mockRepo, nil)
+
+	assert.NotNil(t, useCase)
+	assert.Equal(t, mockRepo, useCase.dagRepository)
+	assert.NotNil(t, useCase.validator)
+}
+
+func TestMergeAnswerMetadataUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmd         func(d *model.DAG, answerId uuid.UUID) CmdMergeAnswerMetadata
+		expectRepo  bool
+		errorType   error
+		checkAnswer func(*testing.T, *model.Answer)
+	}{
+		{
+			name: "merges keys into existing metadata",
+			cmd: func(d *model.DAG, answerId uuid.UUID) CmdMergeAnswerMetadata {
+				return CmdMergeAnswerMetadata{
+					DAGId:    d.Id.String(),
+					AnswerId: answerId.String(),
+					Metadata: map[string]interface{}{"confidence": 0.9, "severity": nil},
+				}
+			},
+			expectRepo: true,
+			checkAnswer: func(t *testing.T, answer *model.Answer) {
+				assert.Equal(t, map[string]interface{}{"confidence": 0.9, "tags": "initial"}, answer.Metadata)
+				assert.Len(t, answer.MetadataHistory, 1)
+			},
+		},
+		{
+			name: "returns not found for unknown answer",
+			cmd: func(d *model.DAG, _ uuid.UUID) CmdMergeAnswerMetadata {
+				return CmdMergeAnswerMetadata{
+					DAGId:    d.Id.String(),
+					AnswerId: uuid.New().String(),
+					Metadata: map[string]interface{}{"confidence": 0.9},
+				}
+			},
+			expectRepo: true,
+			errorType:  ErrNotFound,
+		},
+		{
+			name: "returns validation error for invalid answer ID",
+			cmd: func(d *model.DAG, _ uuid.UUID) CmdMergeAnswerMetadata {
+				return CmdMergeAnswerMetadata{
+					DAGId:    d.Id.String(),
+					AnswerId: "invalid",
+					Metadata: map[string]interface{}{"confidence": 0.9},
+				}
+			},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name: "returns validation error for missing metadata",
+			cmd: func(d *model.DAG, answerId uuid.UUID) CmdMergeAnswerMetadata {
+				return CmdMergeAnswerMetadata{
+					DAGId:    d.Id.String(),
+					AnswerId: answerId.String(),
+				}
+			},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stored := createValidTestDAG()
+			answerId := firstAnswerId(stored)
+			for _, node := range stored.Nodes {
+				for i := range node.Answers {
+					if node.Answers[i].Id == answerId {
+						node.Answers[i].Metadata = map[string]interface{}{"severity": "high", "tags": "initial"}
+					}
+				}
+			}
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			if tt.expectRepo {
+				mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+						_, err := fnUpdate(*stored)
+						return err
+					},
+				)
+			}
+
+			useCase := NewMergeAnswerMetadataUseCase(mockRepo, nil)
+			answer, err := useCase.Execute(context.Background(), tt.cmd(stored, answerId))
+
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				assert.Nil(t, answer)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.checkAnswer(t, answer)
+		})
+	}
+}
+
+func TestMergeAnswerMetadataUseCase_Execute_MetadataSchema(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := createValidTestDAG()
+	stored.MetadataSchema = &model.MetadataSchema{Fields: []model.MetadataField{
+		{Name: "severity", Type: model.MetadataFieldString, Enum: []string{"low", "high"}},
+	}}
+
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+			_, err := fnUpdate(*stored)
+			return err
+		},
+	).Times(2)
+	useCase := NewMergeAnswerMetadataUseCase(mockRepo, nil)
+
+	answer, err := useCase.Execute(context.Background(), CmdMergeAnswerMetadata{
+		DAGId:    stored.Id.String(),
+		AnswerId: firstAnswerId(stored).String(),
+		Metadata: map[string]interface{}{"severity": "high"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "high", answer.Metadata["severity"])
+
+	_, err = useCase.Execute(context.Background(), CmdMergeAnswerMetadata{
+		DAGId:    stored.Id.String(),
+		AnswerId: firstAnswerId(stored).String(),
+		Metadata: map[string]interface{}{"severity": "critical"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidCommand)
+}
+
+func TestMergeAnswerMetadataUseCase_Execute_ConcurrentMerges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := createValidTestDAG()
+	var answerIds []uuid.UUID
+	for _, node := range stored.Nodes {
+		for _, answer := range node.Answers {
+			answerIds = append(answerIds, answer.Id)
+		}
+	}
+	require.GreaterOrEqual(t, len(answerIds), 2)
+
+	// Simulate a repository applying updates atomically, like the real implementations do
+	var mu sync.Mutex
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			updated, err := fnUpdate(*stored)
+			if err != nil {
+				return err
+			}
+			stored = &updated
+			return nil
+		},
+	).Times(2)
+
+	useCase := NewMergeAnswerMetadataUseCase(mockRepo, nil)
+	dagId := stored.Id.String()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, reviewer := range []string{"alice", "bob"} {
+		wg.Add(1)
+		go func(i int, reviewer string) {
+			defer wg.Done()
+			_, errs[i] = useCase.Execute(context.Background(), CmdMergeAnswerMetadata{
+				DAGId:    dagId,
+				AnswerId: answerIds[i].String(),
+				Metadata: map[string]interface{}{"reviewed_by": reviewer},
+			})
+		}(i, reviewer)
+	}
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+
+	reviewers := make(map[uuid.UUID]interface{})
+	for _, node := range stored.Nodes {
+		for _, answer := range node.Answers {
+			if reviewer, ok := answer.Metadata["reviewed_by"]; ok {
+				reviewers[answer.Id] = reviewer
+			}
+		}
+	}
+	assert.Equal(t, map[uuid.UUID]interface{}{answerIds[0]: "alice", answerIds[1]: "bob"}, reviewers)
+}
+
+// firstAnswerId returns the ID of an answer of the DAG
+func firstAnswerId(d *model.DAG) uuid.UUID {
+	for _, node := range d.Nodes {
+		for _, answer := range node.Answers {
+			return answer.Id
+		}
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// CmdMergeDAG grafts a copy of the source DAG onto the target DAG, beneath an answer of the target which
+// leads nowhere yet. The source DAG is left unchanged.
+type CmdMergeDAG struct {
+	TargetId string `validate:"required,uuid"`
+	SourceId string `validate:"required,uuid"`
+	AnswerId string `validate:"required,uuid"`
+}
+
+// MergeResult is the target DAG after the merge
+type MergeResult struct {
+	DAG *model.DAG
+	// GraftedRootId is the ID, in the merged DAG, of the root node of the source DAG
+	GraftedRootId uuid.UUID
+	// Remapped maps the source node and answer IDs colliding with IDs of the target to the IDs they were given
+	Remapped map[uuid.UUID]uuid.UUID
+}
+
+type MergeDAGUseCase struct {
+	dagRepository  DAGRepository
+	dagValidator   *DAGValidator
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewMergeDAGUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *MergeDAGUseCase {
+	return &MergeDAGUseCase{
+		dagRepository:  dagRepository,
+		dagValidator:   dagValidator,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// Execute points the target answer to the root of a copy of the source DAG and re-validates the resulting DAG.
+// Source IDs already used by the target are given fresh IDs, the others are kept.
+func (u *MergeDAGUseCase) Execute(ctx context.Context, cmd CmdMergeDAG) (*MergeResult, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	targetId, err := uuid.Parse(cmd.TargetId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	sourceId, err := uuid.Parse(cmd.SourceId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	answerId, err := uuid.Parse(cmd.AnswerId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	if sourceId == targetId {
+		return nil, fmt.Errorf("%w: a DAG cannot be merged into itself", ErrInvalidCommand)
+	}
+
+	source, err := u.dagRepository.Get(ctx, sourceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve source DAG: %w", err)
+	}
+
+	sourceRoot, err := source.GetRootNode()
+	if err != nil {
+		return nil, fmt.Errorf("%w: source DAG %s cannot be grafted: %s", ErrInvalidCommand, sourceId, err)
+	}
+
+	// The replacement of every source ID is drawn once, the repository may apply the update to several copies
+	// of the target, which must remap the colliding IDs alike
+	replacements := make(map[uuid.UUID]uuid.UUID)
+	source.Reidentify(func(id uuid.UUID) uuid.UUID {
+		if _, ok := replacements[id]; !ok {
+			replacements[id] = uuid.New()
+		}
+		return id
+	})
+
+	var result MergeResult
+	err = u.dagRepository.Update(ctx, targetId, func(existingDAG model.DAG) (model.DAG, error) {
+		// The IDs are collected on every attempt, the target may have changed between attempts
+		used := make(map[uuid.UUID]bool)
+		for id, node := range existingDAG.Nodes {
+			used[id] = true
+			for _, answer := range node.Answers {
+				used[answer.Id] = true
+			}
+		}
+
+		remapped := make(map[uuid.UUID]uuid.UUID)
+		graft := source.Reidentify(func(id uuid.UUID) uuid.UUID {
+			if !used[id] {
+				return id
+			}
+			remapped[id] = replacements[id]
+			return remapped[id]
+		})
+		graftedRootId := sourceRoot.Id
+		if newId, ok := remapped[graftedRootId]; ok {
+			graftedRootId = newId
+		}
+
+		// Copy the nodes so the stored DAG is never mutated in place
+		nodes := make(map[uuid.UUID]model.Node, len(existingDAG.Nodes)+len(graft.Nodes))
+		found := false
+		for id, node := range existingDAG.Nodes {
+			for i, answer := range node.Answers {
+				if answer.Id != answerId {
+					continue
+				}
+
+				if answer.NextNode != nil {
+					return existingDAG, fmt.Errorf("%w: answer %s already leads to node %s", ErrConflict, answerId, *answer.NextNode)
+				}
+				node.Answers = append([]model.Answer(nil), node.Answers...)
+				node.Answers[i].NextNode = &graftedRootId
+				found = true
+			}
+			nodes[id] = node
+		}
+
+		if !found {
+			return existingDAG, fmt.Errorf("%w: answer %s not found in DAG %s", ErrNotFound, answerId, targetId)
+		}
+
+		for id, node := range graft.Nodes {
+			nodes[id] = node
+		}
+
+		existingDAG.Nodes = nodes
+		existingDAG.UpdatedAt = time.Now()
+		existingDAG.Revision++
+		validation := u.dagValidator.ValidateDAG(&existingDAG)
+		if !validation.IsValid {
+			var errorMessages []string
+			for _, err := range validation.Errors {
+				errorMessages = append(errorMessages, err.Message)
+			}
+			return existingDAG, fmt.Errorf("%w: DAG validation failed: %v", ErrInvalidCommand, errorMessages)
+		}
+
+		result = MergeResult{
+			DAG:           &existingDAG,
+			GraftedRootId: graftedRootId,
+			Remapped:      remapped,
+		}
+		return existingDAG, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge DAG: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", targetId.String()).
+		Str("source_dag_id", sourceId.String()).
+		Str("answer_id", answerId.String()).
+		Int("remapped_ids", len(result.Remapped)).
+		Msg("DAG merged")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, targetId, time.Now()))
+
+	return &result, nil
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeDAGUseCase_Execute(t *testing.T) {
+	// leafAnswer returns the answer of the target leading nowhere, which the source is grafted beneath
+	leafAnswer := func(d *model.DAG) model.Answer {
+		for _, node := range d.Nodes {
+			for _, answer := range node.Answers {
+				if answer.Statement == "Stay here" {
+					return answer
+				}
+			}
+		}
+		t.Fatal("leaf answer not found")
+		return model.Answer{}
+	}
+
+	// setup serves the target through Update and the source through Get
+	setup := func(t *testing.T, target, source *model.DAG) *mocks.MockDAGRepository {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(gomock.Any(), source.Id).Return(source, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), target.Id, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+				_, err := fnUpdate(*target)
+				return err
+			},
+		).AnyTimes()
+		return mockRepo
+	}
+
+	t.Run("grafts the source root beneath the answer", func(t *testing.T) {
+		target := dagtest.ValidSingleRoot()
+		source := dagtest.LinearChain(2)
+		answer := leafAnswer(target)
+
+		result, err := NewMergeDAGUseCase(setup(t, target, source), NewDAGValidator(), nil).Execute(context.Background(), CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: source.Id.String(),
+			AnswerId: answer.Id.String(),
+		})
+		require.NoError(t, err)
+
+		assert.Empty(t, result.Remapped)
+		assert.Equal(t, dagtest.Root(source).Id, result.GraftedRootId)
+		assert.Len(t, result.DAG.Nodes, len(target.Nodes)+len(source.Nodes))
+		assert.Equal(t, target.Revision+1, result.DAG.Revision)
+		assert.Equal(t, &result.GraftedRootId, leafAnswer(result.DAG).NextNode)
+		// The stored DAG is not mutated in place
+		assert.Nil(t, leafAnswer(target).NextNode)
+	})
+
+	t.Run("remaps the source IDs used by the target", func(t *testing.T) {
+		target := dagtest.ValidSingleRoot()
+		// The source is a copy of the target under another DAG ID, so that every node and answer ID collides
+		source := target.Reidentify(func(id uuid.UUID) uuid.UUID { return id })
+		source.Id = uuid.New()
+		answer := leafAnswer(target)
+
+		result, err := NewMergeDAGUseCase(setup(t, target, source), NewDAGValidator(), nil).Execute(context.Background(), CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: source.Id.String(),
+			AnswerId: answer.Id.String(),
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, result.DAG.Nodes, 2*len(target.Nodes))
+		for id, node := range target.Nodes {
+			require.Contains(t, result.Remapped, id)
+			assert.Contains(t, result.DAG.Nodes, result.Remapped[id])
+			for _, answer := range node.Answers {
+				assert.Contains(t, result.Remapped, answer.Id)
+			}
+		}
+		assert.Equal(t, result.Remapped[dagtest.Root(target).Id], result.GraftedRootId)
+	})
+
+	t.Run("remaps alike on every copy of the target", func(t *testing.T) {
+		target := dagtest.ValidSingleRoot()
+		source := target.Reidentify(func(id uuid.UUID) uuid.UUID { return id })
+		source.Id = uuid.New()
+		answer := leafAnswer(target)
+
+		// Repositories keeping several copies, e.g. memory and file, may apply the update to each of them
+		var copies []model.DAG
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(gomock.Any(), source.Id).Return(source, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), target.Id, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+				for i := 0; i < 2; i++ {
+					updated, err := fnUpdate(*target)
+					if err != nil {
+						return err
+					}
+					copies = append(copies, updated)
+				}
+				return nil
+			},
+		)
+
+		result, err := NewMergeDAGUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: source.Id.String(),
+			AnswerId: answer.Id.String(),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, copies, 2)
+		require.Len(t, copies[1].Nodes, len(copies[0].Nodes))
+		for id := range copies[0].Nodes {
+			assert.Contains(t, copies[1].Nodes, id)
+		}
+		// The answer the source is grafted beneath keeps its ID, the colliding copy of it in the source is remapped
+		for _, copied := range copies {
+			for _, node := range copied.Nodes {
+				for _, a := range node.Answers {
+					if a.Id == answer.Id {
+						assert.Equal(t, &result.GraftedRootId, a.NextNode)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("rejects answers already leading to a node", func(t *testing.T) {
+		target := dagtest.ValidSingleRoot()
+		source := dagtest.LinearChain(2)
+		linked := dagtest.Root(target).Answers[0]
+
+		_, err := NewMergeDAGUseCase(setup(t, target, source), NewDAGValidator(), nil).Execute(context.Background(), CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: source.Id.String(),
+			AnswerId: linked.Id.String(),
+		})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("returns not found for unknown answer", func(t *testing.T) {
+		target := dagtest.ValidSingleRoot()
+		source := dagtest.LinearChain(2)
+
+		_, err := NewMergeDAGUseCase(setup(t, target, source), NewDAGValidator(), nil).Execute(context.Background(), CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: source.Id.String(),
+			AnswerId: uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects a merge breaking the validation profile", func(t *testing.T) {
+		target := dagtest.ValidSingleRoot()
+		source := dagtest.LinearChain(2)
+
+		_, err := NewMergeDAGUseCase(setup(t, target, source), NewDAGValidatorFromProfile(ValidationProfile{Name: "small", MaxNodes: 4}), nil).Execute(context.Background(), CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: source.Id.String(),
+			AnswerId: leafAnswer(target).Id.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("rejects sources without a single root", func(t *testing.T) {
+		target := dagtest.ValidSingleRoot()
+		source := dagtest.MultipleRoots()
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(gomock.Any(), source.Id).Return(source, nil)
+
+		_, err := NewMergeDAGUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdMergeDAG{
+			TargetId: target.Id.String(),
+			SourceId: source.Id.String(),
+			AnswerId: leafAnswer(target).Id.String(),
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		useCase := NewMergeDAGUseCase(mocks.NewMockDAGRepository(gomock.NewController(t)), NewDAGValidator(), nil)
+		id := uuid.New().String()
+
+		for _, cmd := range []CmdMergeDAG{
+			{},
+			{TargetId: id, SourceId: "not-a-uuid", AnswerId: id},
+			{TargetId: id, SourceId: id, AnswerId: uuid.New().String()},
+		} {
+			_, err := useCase.Execute(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand, cmd)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdGetMetadataSchema struct {
+	DAGId string `validate:"required,uuid"`
+}
+
+type CmdSetMetadataSchema struct {
+	DAGId  string               `validate:"required,uuid"`
+	Schema model.MetadataSchema `validate:"-"`
+	// IfRevision rejects the change when the stored DAG is at another revision, unchecked when nil
+	IfRevision *uint64
+}
+
+type CmdDeleteMetadataSchema struct {
+	DAGId string `validate:"required,uuid"`
+	// IfRevision rejects the change when the stored DAG is at another revision, unchecked when nil
+	IfRevision *uint64
+}
+
+type MetadataSchemaUseCase struct {
+	dagRepository  DAGRepository
+	dagValidator   *DAGValidator
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewMetadataSchemaUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *MetadataSchemaUseCase {
+	return &MetadataSchemaUseCase{
+		dagRepository:  dagRepository,
+		dagValidator:   dagValidator,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// GetSchema returns the metadata schema of a DAG, not found when the DAG declares none
+func (u *MetadataSchemaUseCase) GetSchema(ctx context.Context, cmd CmdGetMetadataSchema) (*model.MetadataSchema, error) {
+	dagId, err := u.parseDAGId(cmd, cmd.DAGId)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := u.dagRepository.Get(ctx, dagId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+	if d.MetadataSchema == nil {
+		return nil, fmt.Errorf("%w: DAG %s has no metadata schema", ErrNotFound, dagId)
+	}
+
+	return d.MetadataSchema, nil
+}
+
+// SetSchema replaces the metadata schema of a DAG. The DAG is re-validated, so the schema is rejected when the
+// metadata of existing answers does not match it.
+func (u *MetadataSchemaUseCase) SetSchema(ctx context.Context, cmd CmdSetMetadataSchema) (*model.MetadataSchema, error) {
+	dagId, err := u.parseDAGId(cmd, cmd.DAGId)
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Schema.Check(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	schema := cmd.Schema
+	err = u.updateSchema(ctx, dagId, cmd.IfRevision, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set metadata schema: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", dagId.String()).
+		Int("fields", len(schema.Fields)).
+		Msg("metadata schema set")
+
+	return &schema, nil
+}
+
+// DeleteSchema removes the metadata schema of a DAG, after which any answer metadata is accepted
+func (u *MetadataSchemaUseCase) DeleteSchema(ctx context.Context, cmd CmdDeleteMetadataSchema) error {
+	dagId, err := u.parseDAGId(cmd, cmd.DAGId)
+	if err != nil {
+		return err
+	}
+
+	err = u.updateSchema(ctx, dagId, cmd.IfRevision, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete metadata schema: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", dagId.String()).
+		Msg("metadata schema deleted")
+
+	return nil
+}
+
+func (u *MetadataSchemaUseCase) parseDAGId(cmd interface{}, rawDAGId string) (uuid.UUID, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagId, err := uuid.Parse(rawDAGId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+	return dagId, nil
+}
+
+// updateSchema replaces the schema of the stored DAG and re-validates it
+func (u *MetadataSchemaUseCase) updateSchema(ctx context.Context, dagId uuid.UUID, ifRevision *uint64, schema *model.MetadataSchema) error {
+	err := u.dagRepository.Update(ctx, dagId, func(existingDAG model.DAG) (model.DAG, error) {
+		if err := checkRevision(existingDAG, ifRevision); err != nil {
+			return existingDAG, err
+		}
+
+		updated := existingDAG
+		updated.MetadataSchema = schema
+		updated.UpdatedAt = time.Now()
+		updated.Revision++
+		result := u.dagValidator.ValidateDAG(&updated)
+		if !result.IsValid {
+			var errorMessages []string
+			for _, err := range result.Errors {
+				errorMessages = append(errorMessages, err.Message)
+			}
+			return existingDAG, fmt.Errorf("%w: DAG validation failed: %v", ErrInvalidCommand, errorMessages)
+		}
+
+		return updated, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, dagId, time.Now()))
+	return nil
+}
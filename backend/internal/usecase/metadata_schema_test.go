@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataSchemaUseCase(t *testing.T) {
+	schema := model.MetadataSchema{Fields: []model.MetadataField{
+		{Name: "confidence", Type: model.MetadataFieldNumber, Required: true},
+		{Name: "severity", Type: model.MetadataFieldString, Enum: []string{"low", "high"}},
+	}}
+	newDAG := func(metadata map[string]interface{}) *model.DAG {
+		d := dagtest.ValidSingleRoot()
+		for id, node := range d.Nodes {
+			for i := range node.Answers {
+				node.Answers[i].Metadata = metadata
+			}
+			d.Nodes[id] = node
+		}
+		return d
+	}
+	applyUpdate := func(stored *model.DAG, updated **model.DAG) func(context.Context, uuid.UUID, func(model.DAG) (model.DAG, error)) error {
+		return func(_ context.Context, _ uuid.UUID, fn func(model.DAG) (model.DAG, error)) error {
+			d, err := fn(*stored)
+			if err != nil {
+				return err
+			}
+			*updated = &d
+			return nil
+		}
+	}
+
+	t.Run("sets a schema matching the answer metadata", func(t *testing.T) {
+		stored := newDAG(map[string]interface{}{"confidence": 0.9})
+		var updated *model.DAG
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(applyUpdate(stored, &updated))
+		publisher := mocks.NewMockEventPublisher(ctrl)
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any())
+
+		set, err := NewMetadataSchemaUseCase(dagRepo, NewDAGValidator(), publisher).SetSchema(context.Background(), CmdSetMetadataSchema{
+			DAGId:  stored.Id.String(),
+			Schema: schema,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, schema, *set)
+		require.NotNil(t, updated)
+		assert.Equal(t, &schema, updated.MetadataSchema)
+		assert.Equal(t, stored.Revision+1, updated.Revision)
+	})
+
+	t.Run("rejects a schema the answer metadata does not match", func(t *testing.T) {
+		stored := newDAG(map[string]interface{}{"severity": "high"})
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(applyUpdate(stored, new(*model.DAG)))
+
+		_, err := NewMetadataSchemaUseCase(dagRepo, NewDAGValidator(), nil).SetSchema(context.Background(), CmdSetMetadataSchema{
+			DAGId:  stored.Id.String(),
+			Schema: schema,
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+		assert.ErrorContains(t, err, `metadata field "confidence" is required`)
+	})
+
+	t.Run("rejects an invalid schema", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewMetadataSchemaUseCase(mocks.NewMockDAGRepository(ctrl), NewDAGValidator(), nil).SetSchema(context.Background(), CmdSetMetadataSchema{
+			DAGId:  uuid.NewString(),
+			Schema: model.MetadataSchema{Fields: []model.MetadataField{{Name: "confidence", Type: model.MetadataFieldNumber, Enum: []string{"1"}}}},
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("rejects a change of another revision", func(t *testing.T) {
+		stored := newDAG(nil)
+		stored.Revision = 3
+		revision := uint64(2)
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(applyUpdate(stored, new(*model.DAG)))
+
+		err := NewMetadataSchemaUseCase(dagRepo, NewDAGValidator(), nil).DeleteSchema(context.Background(), CmdDeleteMetadataSchema{
+			DAGId:      stored.Id.String(),
+			IfRevision: &revision,
+		})
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+	})
+
+	t.Run("deletes the schema", func(t *testing.T) {
+		stored := newDAG(nil)
+		stored.MetadataSchema = &schema
+		var updated *model.DAG
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(applyUpdate(stored, &updated))
+
+		err := NewMetadataSchemaUseCase(dagRepo, NewDAGValidator(), nil).DeleteSchema(context.Background(), CmdDeleteMetadataSchema{
+			DAGId: stored.Id.String(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.Nil(t, updated.MetadataSchema)
+	})
+
+	t.Run("gets the schema", func(t *testing.T) {
+		stored := newDAG(nil)
+		stored.MetadataSchema = &schema
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(stored, nil)
+
+		got, err := NewMetadataSchemaUseCase(dagRepo, NewDAGValidator(), nil).GetSchema(context.Background(), CmdGetMetadataSchema{
+			DAGId: stored.Id.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &schema, got)
+	})
+
+	t.Run("returns not found without schema", func(t *testing.T) {
+		stored := newDAG(nil)
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(stored, nil)
+
+		_, err := NewMetadataSchemaUseCase(dagRepo, NewDAGValidator(), nil).GetSchema(context.Background(), CmdGetMetadataSchema{
+			DAGId: stored.Id.String(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewMetadataSchemaUseCase(mocks.NewMockDAGRepository(ctrl), NewDAGValidator(), nil).
+			GetSchema(context.Background(), CmdGetMetadataSchema{DAGId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
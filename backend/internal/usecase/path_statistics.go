@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// pathLengths sums up the lengths of the paths reaching a node
+type pathLengths struct {
+	// count saturates at math.MaxInt, the number of paths growing exponentially with the depth
+	count int
+	min   int
+	max   int
+	sum   float64
+}
+
+// add merges the paths of other, extended by the given number of answers
+func (p *pathLengths) add(other pathLengths, extra int) {
+	if p.count == 0 {
+		p.min, p.max = other.min+extra, other.max+extra
+	} else {
+		p.min = min(p.min, other.min+extra)
+		p.max = max(p.max, other.max+extra)
+	}
+	p.count = addPaths(p.count, other.count)
+	p.sum += other.sum + float64(extra)*float64(other.count)
+}
+
+// calculatePathStatistics counts the paths from the root node the same way EnumeratePaths lists them,
+// without listing them: a path ends on a node without answers or on a terminal answer. The DAG must be acyclic.
+func (v *DAGValidator) calculatePathStatistics(d *model.DAG, rootNodeID string, result *ValidationResult) {
+	rootID, err := uuid.Parse(rootNodeID)
+	if err != nil {
+		return
+	}
+	if _, exists := d.Nodes[rootID]; !exists {
+		return
+	}
+
+	// Nodes are visited in topological order so that every path reaching a node is known before leaving it
+	reached := map[uuid.UUID]*pathLengths{rootID: {count: 1}}
+	ends := map[uuid.UUID]*pathLengths{}
+	for _, nodeId := range topologicalOrder(d, rootID) {
+		paths := *reached[nodeId]
+		node := d.Nodes[nodeId]
+
+		if len(node.Answers) == 0 {
+			pathsOf(ends, nodeId).add(paths, 0)
+			continue
+		}
+
+		for _, answer := range node.Answers {
+			if answer.NextNode == nil {
+				pathsOf(ends, nodeId).add(paths, 1)
+			}
+			for _, target := range answer.Targets() {
+				if _, exists := d.Nodes[target]; !exists {
+					continue
+				}
+				pathsOf(reached, target).add(paths, 1)
+			}
+		}
+	}
+
+	if len(ends) == 0 {
+		return
+	}
+
+	var total pathLengths
+	leafPaths := make([]model.LeafPaths, 0, len(ends))
+	for nodeId, paths := range ends {
+		total.add(*paths, 0)
+		leafPaths = append(leafPaths, model.LeafPaths{
+			LeafNodeID: nodeId.String(),
+			Paths:      paths.count,
+			MinDepth:   paths.min,
+			MaxDepth:   paths.max,
+			AvgDepth:   paths.sum / float64(paths.count),
+		})
+	}
+	sort.Slice(leafPaths, func(i, j int) bool {
+		return leafPaths[i].LeafNodeID < leafPaths[j].LeafNodeID
+	})
+
+	result.Statistics.TotalPaths = total.count
+	result.Statistics.MinPathLength = total.min
+	result.Statistics.MaxPathLength = total.max
+	result.Statistics.AvgPathLength = total.sum / float64(total.count)
+	result.Statistics.LeafPaths = leafPaths
+}
+
+// pathsOf returns the paths of a node, adding them when missing
+func pathsOf(paths map[uuid.UUID]*pathLengths, nodeId uuid.UUID) *pathLengths {
+	if _, exists := paths[nodeId]; !exists {
+		paths[nodeId] = &pathLengths{}
+	}
+	return paths[nodeId]
+}
+
+// topologicalOrder returns the nodes reachable from the root node, each one after every node leading to it.
+// Nodes on a cycle are left out.
+func topologicalOrder(d *model.DAG, rootID uuid.UUID) []uuid.UUID {
+	reachable := reachableNodes(d, rootID, false)
+
+	inDegrees := make(map[uuid.UUID]int, len(reachable))
+	for nodeId := range reachable {
+		for _, answer := range d.Nodes[nodeId].Answers {
+			for _, target := range answer.Targets() {
+				inDegrees[target]++
+			}
+		}
+	}
+
+	order := make([]uuid.UUID, 0, len(reachable))
+	queue := []uuid.UUID{rootID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if _, exists := d.Nodes[current]; !exists {
+			continue
+		}
+		order = append(order, current)
+
+		for _, answer := range d.Nodes[current].Answers {
+			for _, target := range answer.Targets() {
+				inDegrees[target]--
+				if inDegrees[target] == 0 {
+					queue = append(queue, target)
+				}
+			}
+		}
+	}
+
+	return order
+}
+
+// addPaths adds two numbers of paths, saturating at math.MaxInt
+func addPaths(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGValidator_PathStatistics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("diamond with a terminal answer", func(t *testing.T) {
+		t.Parallel()
+
+		rootID := uuid.New()
+		leftID := uuid.New()
+		rightID := uuid.New()
+		leafID := uuid.New()
+
+		d := dagtest.Wire(&model.DAG{
+			Id:    uuid.New(),
+			Title: "Diamond DAG",
+			Nodes: map[uuid.UUID]model.Node{
+				rootID: {
+					Id:       rootID,
+					Question: "Were you dismissed?",
+					Answers: []model.Answer{
+						{Id: uuid.New(), Statement: "Yes", NextNode: &leftID},
+						{Id: uuid.New(), Statement: "No", NextNode: &rightID},
+						{Id: uuid.New(), Statement: "I resigned"},
+					},
+				},
+				leftID: {
+					Id:       leftID,
+					Question: "Did you receive notice?",
+					Answers:  []model.Answer{{Id: uuid.New(), Statement: "Yes", NextNode: &leafID}},
+				},
+				rightID: {
+					Id:       rightID,
+					Question: "Are you still employed?",
+					Answers:  []model.Answer{{Id: uuid.New(), Statement: "Yes", NextNode: &leafID}},
+				},
+				leafID: {
+					Id:       leafID,
+					Question: "Any other claim?",
+				},
+			},
+		})
+
+		stats := NewDAGValidator().ValidateDAG(d).Statistics
+
+		assert.Equal(t, 3, stats.TotalPaths)
+		assert.Equal(t, 1, stats.MinPathLength)
+		assert.Equal(t, 2, stats.MaxPathLength)
+		assert.InDelta(t, 5.0/3.0, stats.AvgPathLength, 1e-9)
+
+		expected := []model.LeafPaths{
+			{LeafNodeID: rootID.String(), Paths: 1, MinDepth: 1, MaxDepth: 1, AvgDepth: 1},
+			{LeafNodeID: leafID.String(), Paths: 2, MinDepth: 2, MaxDepth: 2, AvgDepth: 2},
+		}
+		if expected[1].LeafNodeID < expected[0].LeafNodeID {
+			expected[0], expected[1] = expected[1], expected[0]
+		}
+		assert.Equal(t, expected, stats.LeafPaths)
+	})
+
+	t.Run("matches the enumerated paths", func(t *testing.T) {
+		t.Parallel()
+
+		d := dagtest.ValidSingleRoot()
+		paths, err := d.EnumeratePaths()
+		require.NoError(t, err)
+
+		stats := NewDAGValidator().ValidateDAG(d).Statistics
+
+		assert.Equal(t, len(paths), stats.TotalPaths)
+		minLength, maxLength, sum := math.MaxInt, 0, 0
+		for _, path := range paths {
+			minLength = min(minLength, len(path.Steps))
+			maxLength = max(maxLength, len(path.Steps))
+			sum += len(path.Steps)
+		}
+		assert.Equal(t, minLength, stats.MinPathLength)
+		assert.Equal(t, maxLength, stats.MaxPathLength)
+		assert.InDelta(t, float64(sum)/float64(len(paths)), stats.AvgPathLength, 1e-9)
+	})
+
+	t.Run("linear chain", func(t *testing.T) {
+		t.Parallel()
+
+		stats := NewDAGValidator().ValidateDAG(dagtest.LinearChain(4)).Statistics
+
+		assert.Equal(t, 1, stats.TotalPaths)
+		assert.Equal(t, 3, stats.MinPathLength)
+		assert.Equal(t, 3, stats.MaxPathLength)
+		require.Len(t, stats.LeafPaths, 1)
+		assert.Equal(t, 3, stats.LeafPaths[0].MaxDepth)
+	})
+
+	t.Run("cyclic DAG has no path statistics", func(t *testing.T) {
+		t.Parallel()
+
+		stats := NewDAGValidator().ValidateDAG(dagtest.Cyclic()).Statistics
+
+		assert.Zero(t, stats.TotalPaths)
+		assert.Empty(t, stats.LeafPaths)
+	})
+
+	t.Run("number of paths saturates", func(t *testing.T) {
+		t.Parallel()
+
+		// Each node leads twice to the next one, doubling the number of paths
+		d := dagtest.LinearChain(70)
+		for id, node := range d.Nodes {
+			if len(node.Answers) > 0 {
+				node.Answers = append(node.Answers, model.Answer{Id: uuid.New(), Statement: "Also", NextNode: node.Answers[0].NextNode})
+				d.Nodes[id] = node
+			}
+		}
+
+		stats := NewDAGValidator().ValidateDAG(d).Statistics
+
+		assert.Equal(t, math.MaxInt, stats.TotalPaths)
+		assert.Equal(t, 69, stats.MaxPathLength)
+	})
+}
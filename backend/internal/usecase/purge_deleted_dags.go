@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdPurgeDeletedDAGs struct {
+	// Retention is how long a DAG stays in the trash before it is purged
+	Retention time.Duration `validate:"min=0"`
+	// DryRun reports the DAGs that would be purged without removing them
+	DryRun bool
+}
+
+type PurgeDeletedDAGsUseCase struct {
+	dagRepository       DAGRepository
+	analyticsRepository WalkAnalyticsRepository
+	versionRepository   DAGVersionRepository
+	validator           *validator.Validate
+}
+
+func NewPurgeDeletedDAGsUseCase(dagRepository DAGRepository, analyticsRepository WalkAnalyticsRepository, versionRepository DAGVersionRepository) *PurgeDeletedDAGsUseCase {
+	return &PurgeDeletedDAGsUseCase{
+		dagRepository:       dagRepository,
+		analyticsRepository: analyticsRepository,
+		versionRepository:   versionRepository,
+		validator:           validator.New(),
+	}
+}
+
+// Execute permanently removes the DAGs kept in the trash for longer than the retention, along with their
+// walk analytics and versions, and returns the IDs of the purged DAGs
+func (u *PurgeDeletedDAGsUseCase) Execute(ctx context.Context, cmd CmdPurgeDeletedDAGs) ([]uuid.UUID, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	page, err := u.dagRepository.Query(ctx, model.DAGQuery{Deleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted DAGs: %w", err)
+	}
+
+	now := time.Now()
+	purged := []uuid.UUID{}
+	for _, dag := range page.DAGs {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		if !dag.PurgeableAt(now, cmd.Retention) {
+			continue
+		}
+		if cmd.DryRun {
+			purged = append(purged, dag.Id)
+			continue
+		}
+
+		if err := u.dagRepository.Delete(ctx, dag.Id); err != nil {
+			return purged, fmt.Errorf("failed to purge DAG %s: %w", dag.Id, err)
+		}
+
+		// The DAG is gone at this point, leftover analytics and versions are only logged
+		if err := u.analyticsRepository.Delete(ctx, dag.Id); err != nil {
+			xlog.Ctx(ctx).Warn().Err(err).
+				Str("dag_id", dag.Id.String()).
+				Msg("failed to delete walk analytics of purged DAG")
+		}
+		if err := u.versionRepository.Delete(ctx, dag.Id); err != nil {
+			xlog.Ctx(ctx).Warn().Err(err).
+				Str("dag_id", dag.Id.String()).
+				Msg("failed to delete versions of purged DAG")
+		}
+
+		xlog.Ctx(ctx).Info().
+			Str("dag_id", dag.Id.String()).
+			Msg("DAG purged from trash")
+		purged = append(purged, dag.Id)
+	}
+
+	return purged, nil
+}
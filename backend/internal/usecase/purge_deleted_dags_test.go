@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeDeletedDAGsUseCase_Execute(t *testing.T) {
+	retention := 24 * time.Hour
+	expiredAt := time.Now().Add(-2 * retention)
+	keptAt := time.Now().Add(-time.Hour)
+	expired := model.NewDAG("Expired")
+	expired.DeletedAt = &expiredAt
+	kept := model.NewDAG("Kept")
+	kept.DeletedAt = &keptAt
+	trash := &model.DAGPage{DAGs: []*model.DAG{expired, kept}, Total: 2}
+
+	t.Run("purges DAGs past the retention with their analytics and versions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Query(gomock.Any(), model.DAGQuery{Deleted: true}).Return(trash, nil)
+		dagRepo.EXPECT().Delete(gomock.Any(), expired.Id).Return(nil)
+		analyticsRepo := mocks.NewMockWalkAnalyticsRepository(ctrl)
+		analyticsRepo.EXPECT().Delete(gomock.Any(), expired.Id).Return(nil)
+		versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+		versionRepo.EXPECT().Delete(gomock.Any(), expired.Id).Return(ErrInternal)
+
+		purged, err := NewPurgeDeletedDAGsUseCase(dagRepo, analyticsRepo, versionRepo).Execute(context.Background(), CmdPurgeDeletedDAGs{Retention: retention})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{expired.Id}, purged)
+	})
+
+	t.Run("only reports DAGs on dry runs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Query(gomock.Any(), gomock.Any()).Return(trash, nil)
+
+		purged, err := NewPurgeDeletedDAGsUseCase(dagRepo, mocks.NewMockWalkAnalyticsRepository(ctrl), mocks.NewMockDAGVersionRepository(ctrl)).Execute(context.Background(), CmdPurgeDeletedDAGs{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{expired.Id, kept.Id}, purged)
+	})
+
+	t.Run("stops on repository errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Query(gomock.Any(), gomock.Any()).Return(trash, nil)
+		dagRepo.EXPECT().Delete(gomock.Any(), expired.Id).Return(ErrInternal)
+
+		_, err := NewPurgeDeletedDAGsUseCase(dagRepo, mocks.NewMockWalkAnalyticsRepository(ctrl), mocks.NewMockDAGVersionRepository(ctrl)).Execute(context.Background(), CmdPurgeDeletedDAGs{Retention: retention})
+		assert.ErrorIs(t, err, ErrInternal)
+	})
+
+	t.Run("rejects negative retention", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		_, err := NewPurgeDeletedDAGsUseCase(mocks.NewMockDAGRepository(ctrl), mocks.NewMockWalkAnalyticsRepository(ctrl), mocks.NewMockDAGVersionRepository(ctrl)).Execute(context.Background(), CmdPurgeDeletedDAGs{Retention: -time.Hour})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
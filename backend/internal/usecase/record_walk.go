@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdRecordWalk struct {
+	DAGId     string   `validate:"required,uuid"`
+	AnswerIds []string `validate:"required,min=1,dive,uuid"`
+}
+
+// WalkResult is a recorded walk along with its coverage of the required nodes
+type WalkResult struct {
+	Path     model.PathDetail
+	Coverage model.CoverageReport
+}
+
+type RecordWalkUseCase struct {
+	dagRepository       DAGRepository
+	analyticsRepository WalkAnalyticsRepository
+	validator           *validator.Validate
+}
+
+func NewRecordWalkUseCase(dagRepository DAGRepository, analyticsRepository WalkAnalyticsRepository) *RecordWalkUseCase {
+	return &RecordWalkUseCase{
+		dagRepository:       dagRepository,
+		analyticsRepository: analyticsRepository,
+		validator:           validator.New(),
+	}
+}
+
+// Execute records a completed walk of a stored DAG, the answers must describe a complete path from the root node
+func (u *RecordWalkUseCase) Execute(ctx context.Context, cmd CmdRecordWalk) (*WalkResult, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	answerIds := make([]uuid.UUID, len(cmd.AnswerIds))
+	for i, answerId := range cmd.AnswerIds {
+		answerIds[i], err = uuid.Parse(answerId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid answer UUID format: %s", ErrInvalidCommand, err)
+		}
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	path, err := dag.ResolvePath(answerIds)
+	if err != nil {
+		return nil, fmt.Errorf("%w: walk is not a complete path of DAG %s: %s", ErrInvalidCommand, id, err)
+	}
+
+	err = u.analyticsRepository.Update(ctx, id, func(analytics model.WalkAnalytics) (model.WalkAnalytics, error) {
+		analytics.Record(answerIds)
+		return analytics, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to record walk: %s", ErrInternal, err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", id.String()).
+		Int("answers", len(answerIds)).
+		Msg("walk recorded")
+
+	answers := make([]model.Answer, len(path.Steps))
+	for i, step := range path.Steps {
+		answers[i] = step.Answer
+	}
+
+	return &WalkResult{
+		Path:     path,
+		Coverage: dag.Coverage(answers),
+	}, nil
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordWalkUseCase_Execute(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+	complete := walkAnswerIds(paths[0])
+
+	tests := []struct {
+		name        string
+		cmd         CmdRecordWalk
+		setupMocks  func(*mocks.MockDAGRepository, *mocks.MockWalkAnalyticsRepository)
+		errorType   error
+		checkRecord func(*testing.T, model.WalkAnalytics)
+	}{
+		{
+			name: "records a complete walk",
+			cmd:  CmdRecordWalk{DAGId: d.Id.String(), AnswerIds: complete},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, analyticsRepo *mocks.MockWalkAnalyticsRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+			},
+			checkRecord: func(t *testing.T, analytics model.WalkAnalytics) {
+				require.Len(t, analytics.Paths, 1)
+				assert.Equal(t, 1, analytics.Paths[0].Count)
+				assert.Len(t, analytics.EdgeCounts, len(complete))
+			},
+		},
+		{
+			name: "rejects an incomplete walk",
+			cmd:  CmdRecordWalk{DAGId: d.Id.String(), AnswerIds: complete[:1]},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, analyticsRepo *mocks.MockWalkAnalyticsRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+			},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdRecordWalk{DAGId: d.Id.String(), AnswerIds: complete},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, analyticsRepo *mocks.MockWalkAnalyticsRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:       "rejects invalid answer ID",
+			cmd:        CmdRecordWalk{DAGId: d.Id.String(), AnswerIds: []string{"invalid"}},
+			setupMocks: func(*mocks.MockDAGRepository, *mocks.MockWalkAnalyticsRepository) {},
+			errorType:  ErrInvalidCommand,
+		},
+		{
+			name:       "rejects empty walk",
+			cmd:        CmdRecordWalk{DAGId: d.Id.String()},
+			setupMocks: func(*mocks.MockDAGRepository, *mocks.MockWalkAnalyticsRepository) {},
+			errorType:  ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dagRepo := mocks.NewMockDAGRepository(ctrl)
+			analyticsRepo := mocks.NewMockWalkAnalyticsRepository(ctrl)
+			tt.setupMocks(dagRepo, analyticsRepo)
+
+			var recorded model.WalkAnalytics
+			if tt.checkRecord != nil {
+				analyticsRepo.EXPECT().Update(gomock.Any(), d.Id, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, id uuid.UUID, fnUpdate func(model.WalkAnalytics) (model.WalkAnalytics, error)) error {
+						updated, err := fnUpdate(*model.NewWalkAnalytics(id))
+						recorded = updated
+						return err
+					},
+				)
+			}
+
+			result, err := NewRecordWalkUseCase(dagRepo, analyticsRepo).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, paths[0].LeafNodeId, result.Path.LeafNodeId)
+			assert.Len(t, result.Coverage.Answered, len(paths[0].Steps))
+			assert.Empty(t, result.Coverage.MissedRequired)
+			tt.checkRecord(t, recorded)
+		})
+	}
+}
+
+// walkAnswerIds returns the IDs of the answers chosen along a path
+func walkAnswerIds(path model.PathDetail) []string {
+	ids := make([]string, len(path.Steps))
+	for i, step := range path.Steps {
+		ids[i] = step.Answer.Id.String()
+	}
+	return ids
+}
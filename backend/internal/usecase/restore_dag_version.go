@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdRestoreDAGVersion struct {
+	DAGId  string `validate:"required,uuid"`
+	Number int    `validate:"min=1"`
+}
+
+type RestoreDAGVersionUseCase struct {
+	dagRepository     DAGRepository
+	versionRepository DAGVersionRepository
+	dagValidator      *DAGValidator
+	eventPublisher    EventPublisher
+	validator         *validator.Validate
+}
+
+func NewRestoreDAGVersionUseCase(dagRepository DAGRepository, versionRepository DAGVersionRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *RestoreDAGVersionUseCase {
+	return &RestoreDAGVersionUseCase{
+		dagRepository:     dagRepository,
+		versionRepository: versionRepository,
+		dagValidator:      dagValidator,
+		eventPublisher:    eventPublisher,
+		validator:         validator.New(),
+	}
+}
+
+// Execute replaces a DAG with one of its versions. The history is kept as is,
+// the restored structure is recorded as a new version.
+func (u *RestoreDAGVersionUseCase) Execute(ctx context.Context, cmd CmdRestoreDAGVersion) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	version, err := u.versionRepository.Get(ctx, id, cmd.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore DAG version: %w", err)
+	}
+
+	// The validation profile may have changed since the version was recorded
+	result := u.dagValidator.ValidateDAG(version.DAG)
+	if !result.IsValid {
+		var errorMessages []string
+		for _, err := range result.Errors {
+			errorMessages = append(errorMessages, err.Message)
+		}
+		return nil, fmt.Errorf("%w: version %d does not pass validation: %v", ErrInvalidCommand, cmd.Number, errorMessages)
+	}
+
+	restored := version.DAG
+	restored.UpdatedAt = time.Now()
+	err = u.dagRepository.Update(ctx, id, func(existingDAG model.DAG) (model.DAG, error) {
+		restored.Revision = existingDAG.Revision + 1
+		return *restored, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore DAG version: %w", err)
+	}
+
+	recordDAGVersion(ctx, u.versionRepository, nil, restored)
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", id.String()).
+		Int("version", cmd.Number).
+		Msg("DAG version restored")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, id, time.Now()))
+
+	return restored, nil
+}
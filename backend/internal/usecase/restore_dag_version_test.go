@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreDAGVersionUseCase_Execute(t *testing.T) {
+	current := dagtest.ValidSingleRoot()
+
+	tests := []struct {
+		name       string
+		snapshot   func() *model.DAG
+		setupMocks func(*mocks.MockDAGRepository, *mocks.MockDAGVersionRepository, *model.DAG)
+		errorType  error
+	}{
+		{
+			name: "replaces the DAG and records the restored version",
+			snapshot: func() *model.DAG {
+				d := dagtest.LinearChain(2)
+				d.Id = current.Id
+				return d
+			},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, versionRepo *mocks.MockDAGVersionRepository, snapshot *model.DAG) {
+				versionRepo.EXPECT().Get(gomock.Any(), current.Id, 1).Return(&model.DAGVersion{Number: 1, DAG: snapshot}, nil)
+				dagRepo.EXPECT().Update(gomock.Any(), current.Id, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, fn func(model.DAG) (model.DAG, error)) error {
+						restored, err := fn(*current)
+						assert.Len(t, restored.Nodes, len(snapshot.Nodes))
+						return err
+					},
+				)
+				versionRepo.EXPECT().List(gomock.Any(), current.Id).Return([]model.DAGVersion{{Number: 1}, {Number: 2}}, nil)
+				versionRepo.EXPECT().Append(gomock.Any(), snapshot, gomock.Any(), "").Return(&model.DAGVersion{Number: 3}, nil)
+			},
+		},
+		{
+			name: "rejects a version which no longer passes validation",
+			snapshot: func() *model.DAG {
+				d := dagtest.Cyclic()
+				d.Id = current.Id
+				return d
+			},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, versionRepo *mocks.MockDAGVersionRepository, snapshot *model.DAG) {
+				versionRepo.EXPECT().Get(gomock.Any(), current.Id, 1).Return(&model.DAGVersion{Number: 1, DAG: snapshot}, nil)
+			},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:     "returns not found for unknown version",
+			snapshot: func() *model.DAG { return nil },
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, versionRepo *mocks.MockDAGVersionRepository, _ *model.DAG) {
+				versionRepo.EXPECT().Get(gomock.Any(), current.Id, 1).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dagRepo := mocks.NewMockDAGRepository(ctrl)
+			versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+			snapshot := tt.snapshot()
+			tt.setupMocks(dagRepo, versionRepo, snapshot)
+
+			before := time.Now()
+			restored, err := NewRestoreDAGVersionUseCase(dagRepo, versionRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdRestoreDAGVersion{
+				DAGId:  current.Id.String(),
+				Number: 1,
+			})
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, current.Id, restored.Id)
+			assert.False(t, restored.UpdatedAt.Before(before))
+		})
+	}
+}
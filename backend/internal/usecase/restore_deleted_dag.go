@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdRestoreDeletedDAG struct {
+	DAGId string `validate:"required,uuid"`
+}
+
+type RestoreDeletedDAGUseCase struct {
+	dagRepository  DAGRepository
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewRestoreDeletedDAGUseCase(dagRepository DAGRepository, eventPublisher EventPublisher) *RestoreDeletedDAGUseCase {
+	return &RestoreDeletedDAGUseCase{
+		dagRepository:  dagRepository,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// Execute takes a DAG out of the trash and returns it, restoring a live DAG is a conflict
+func (u *RestoreDeletedDAGUseCase) Execute(ctx context.Context, cmd CmdRestoreDeletedDAG) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	var restored model.DAG
+	err = u.dagRepository.Update(ctx, id, func(existingDAG model.DAG) (model.DAG, error) {
+		if err := existingDAG.Undelete(); err != nil {
+			return existingDAG, fmt.Errorf("%w: %s", ErrConflict, err)
+		}
+		existingDAG.Revision++
+		restored = existingDAG
+		return existingDAG, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore DAG: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", id.String()).
+		Msg("DAG restored from trash")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGRestored, id, time.Now()))
+
+	return &restored, nil
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreDeletedDAGUseCase_Execute(t *testing.T) {
+	live := dagtest.ValidSingleRoot()
+	trashed := dagtest.ValidSingleRoot()
+	deletedAt := time.Now()
+	trashed.DeletedAt = &deletedAt
+
+	t.Run("takes a DAG out of the trash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		stored := updateThrough(mockRepo, trashed)
+		publisher := mocks.NewMockEventPublisher(ctrl)
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event model.Event) error {
+			assert.Equal(t, model.EventDAGRestored, event.Type)
+			assert.Equal(t, trashed.Id, event.DAGId)
+			return nil
+		})
+
+		restored, err := NewRestoreDeletedDAGUseCase(mockRepo, publisher).Execute(context.Background(), CmdRestoreDeletedDAG{DAGId: trashed.Id.String()})
+		require.NoError(t, err)
+		assert.False(t, restored.IsDeleted())
+		assert.False(t, stored.IsDeleted())
+		assert.Equal(t, trashed.Revision+1, stored.Revision)
+	})
+
+	t.Run("rejects the revisions taken before the deletion", func(t *testing.T) {
+		stored := *dagtest.ValidSingleRoot()
+		before := stored.Revision
+
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		mockRepo.EXPECT().Get(gomock.Any(), stored.Id).DoAndReturn(func(context.Context, uuid.UUID) (*model.DAG, error) {
+			current := stored
+			return &current, nil
+		}).AnyTimes()
+		mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.DAG) (model.DAG, error)) error {
+				updated, err := fn(stored)
+				if err != nil {
+					return err
+				}
+				stored = updated
+				return nil
+			},
+		).AnyTimes()
+		analyticsRepo := mocks.NewMockWalkAnalyticsRepository(ctrl)
+		analyticsRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(model.NewWalkAnalytics(stored.Id), nil)
+
+		require.NoError(t, NewDeleteDAGUseCase(mockRepo, analyticsRepo, nil).Execute(context.Background(), CmdDeleteDAG{DAGId: stored.Id.String()}))
+		_, err := NewRestoreDeletedDAGUseCase(mockRepo, nil).Execute(context.Background(), CmdRestoreDeletedDAG{DAGId: stored.Id.String()})
+		require.NoError(t, err)
+
+		// An update sent with the ETag of the DAG before its deletion is rejected
+		replacement := stored
+		replacement.Title = "Edited before the deletion"
+		_, err = NewUpdateDAGUseCase(mockRepo, nil, NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateDAG{
+			DAGId:      stored.Id.String(),
+			DAG:        &replacement,
+			IfRevision: &before,
+		})
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		assert.Equal(t, before+2, stored.Revision)
+	})
+
+	t.Run("rejects live DAGs", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		updateThrough(mockRepo, live)
+
+		_, err := NewRestoreDeletedDAGUseCase(mockRepo, nil).Execute(context.Background(), CmdRestoreDeletedDAG{DAGId: live.Id.String()})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("returns not found for unknown DAG", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Update(gomock.Any(), live.Id, gomock.Any()).Return(ErrNotFound)
+
+		_, err := NewRestoreDeletedDAGUseCase(mockRepo, nil).Execute(context.Background(), CmdRestoreDeletedDAG{DAGId: live.Id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid DAG ID", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+
+		_, err := NewRestoreDeletedDAGUseCase(mockRepo, nil).Execute(context.Background(), CmdRestoreDeletedDAG{DAGId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdReviseSessionAnswer struct {
+	SessionId string `validate:"required,uuid"`
+	NodeId    string `validate:"required,uuid"`
+}
+
+type ReviseSessionAnswerUseCase struct {
+	sessionRepository SessionRepository
+	validator         *validator.Validate
+}
+
+func NewReviseSessionAnswerUseCase(sessionRepository SessionRepository) *ReviseSessionAnswerUseCase {
+	return &ReviseSessionAnswerUseCase{
+		sessionRepository: sessionRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute discards the answer given to a node of a session along with the answers given after it, so that the
+// node is asked again and the walk may take another branch
+func (u *ReviseSessionAnswerUseCase) Execute(ctx context.Context, cmd CmdReviseSessionAnswer) (*model.CaseSession, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	sessionId, err := uuid.Parse(cmd.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+	nodeId, err := uuid.Parse(cmd.NodeId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	var revised model.CaseSession
+	var discarded int
+	err = u.sessionRepository.Update(ctx, sessionId, func(existing model.CaseSession) (model.CaseSession, error) {
+		answers := len(existing.Answers)
+		if err := existing.Revise(nodeId, time.Now()); err != nil {
+			return existing, fmt.Errorf("%w: %s", ErrNotFound, err)
+		}
+
+		discarded = answers - len(existing.Answers)
+		revised = existing
+		return existing, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revise session answer: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("session_id", sessionId.String()).
+		Str("node_id", nodeId.String()).
+		Int("discarded_answers", discarded).
+		Msg("case session answer revised")
+
+	return &revised, nil
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviseSessionAnswerUseCase_Execute(t *testing.T) {
+	rootId := uuid.New()
+	followUpId := uuid.New()
+	newSession := func() model.CaseSession {
+		return model.CaseSession{
+			Id:     uuid.New(),
+			Status: model.SessionStatusCompleted,
+			Answers: []model.SessionAnswer{
+				{NodeId: rootId, AnswerId: uuid.New()},
+				{NodeId: followUpId, AnswerId: uuid.New()},
+			},
+			Assessment: &model.Assessment{Content: "Unfair dismissal"},
+		}
+	}
+
+	t.Run("discards the answers from the node on", func(t *testing.T) {
+		session := newSession()
+
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.CaseSession) (model.CaseSession, error)) error {
+				_, err := fn(session)
+				return err
+			},
+		)
+
+		revised, err := NewReviseSessionAnswerUseCase(sessionRepo).Execute(context.Background(), CmdReviseSessionAnswer{
+			SessionId: session.Id.String(),
+			NodeId:    followUpId.String(),
+		})
+		require.NoError(t, err)
+		require.Len(t, revised.Answers, 1)
+		assert.Equal(t, rootId, revised.Answers[0].NodeId)
+		require.NotNil(t, revised.CurrentNodeId)
+		assert.Equal(t, followUpId, *revised.CurrentNodeId)
+		assert.Equal(t, model.SessionStatusInProgress, revised.Status)
+		assert.Nil(t, revised.Assessment)
+	})
+
+	t.Run("rejects a node which was not answered", func(t *testing.T) {
+		session := newSession()
+
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Update(gomock.Any(), session.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.CaseSession) (model.CaseSession, error)) error {
+				_, err := fn(session)
+				return err
+			},
+		)
+
+		_, err := NewReviseSessionAnswerUseCase(sessionRepo).Execute(context.Background(), CmdReviseSessionAnswer{
+			SessionId: session.Id.String(),
+			NodeId:    uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid identifiers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+
+		_, err := NewReviseSessionAnswerUseCase(sessionRepo).Execute(context.Background(), CmdReviseSessionAnswer{
+			SessionId: uuid.New().String(),
+			NodeId:    "not-a-uuid",
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdSaveAsTemplate struct {
+	DAGId       string `validate:"required,uuid"`
+	Name        string `validate:"required,max=200"`
+	Description string `validate:"max=2000"`
+}
+
+type SaveAsTemplateUseCase struct {
+	dagRepository      DAGRepository
+	templateRepository TemplateRepository
+	validator          *validator.Validate
+}
+
+func NewSaveAsTemplateUseCase(dagRepository DAGRepository, templateRepository TemplateRepository) *SaveAsTemplateUseCase {
+	return &SaveAsTemplateUseCase{
+		dagRepository:      dagRepository,
+		templateRepository: templateRepository,
+		validator:          validator.New(),
+	}
+}
+
+// Execute snapshots a DAG into a new template, later changes to the DAG are not reflected in the template
+func (u *SaveAsTemplateUseCase) Execute(ctx context.Context, cmd CmdSaveAsTemplate) (*model.Template, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG: %w", err)
+	}
+
+	template := model.NewTemplate(cmd.Name, cmd.Description, dag, time.Now())
+	if err := u.templateRepository.Create(ctx, &template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", id.String()).
+		Str("template_id", template.Id.String()).
+		Msg("DAG saved as template")
+
+	return &template, nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAsTemplateUseCase_Execute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("snapshots the DAG into a template", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		templateRepo := mocks.NewMockTemplateRepository(ctrl)
+
+		source := dagtest.ValidSingleRoot()
+		dagRepo.EXPECT().Get(gomock.Any(), source.Id).Return(source, nil)
+		templateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		template, err := NewSaveAsTemplateUseCase(dagRepo, templateRepo).Execute(context.Background(), CmdSaveAsTemplate{
+			DAGId:       source.Id.String(),
+			Name:        "Employment",
+			Description: "Employment dispute intake",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Employment", template.Name)
+		assert.Equal(t, "Employment dispute intake", template.Description)
+		assert.Equal(t, source.Id, template.SourceDAGId)
+		assert.Len(t, template.DAG.Nodes, len(source.Nodes))
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		useCase := NewSaveAsTemplateUseCase(mocks.NewMockDAGRepository(ctrl), mocks.NewMockTemplateRepository(ctrl))
+
+		for _, cmd := range []CmdSaveAsTemplate{
+			{Name: "Employment"},
+			{DAGId: "not-a-uuid", Name: "Employment"},
+			{DAGId: dagtest.ValidSingleRoot().Id.String()},
+		} {
+			_, err := useCase.Execute(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand, cmd)
+		}
+	})
+
+	t.Run("fails when the DAG does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, ErrNotFound)
+
+		_, err := NewSaveAsTemplateUseCase(dagRepo, mocks.NewMockTemplateRepository(ctrl)).Execute(context.Background(), CmdSaveAsTemplate{
+			DAGId: model.NewDAG("missing").Id.String(),
+			Name:  "Employment",
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdScoreSession struct {
+	SessionId string `validate:"required,uuid"`
+}
+
+type ScoreSessionUseCase struct {
+	dagRepository     DAGRepository
+	sessionRepository SessionRepository
+	validator         *validator.Validate
+}
+
+func NewScoreSessionUseCase(dagRepository DAGRepository, sessionRepository SessionRepository) *ScoreSessionUseCase {
+	return &ScoreSessionUseCase{
+		dagRepository:     dagRepository,
+		sessionRepository: sessionRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute computes the aggregate scores of the path answered during a completed session, along the
+// scoring dimensions of its DAG
+func (u *ScoreSessionUseCase) Execute(ctx context.Context, cmd CmdScoreSession) (*model.Score, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	sessionId, err := uuid.Parse(cmd.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	session, err := u.sessionRepository.Get(ctx, sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session: %w", err)
+	}
+	if session.Status != model.SessionStatusCompleted {
+		return nil, fmt.Errorf("%w: session %s is %s, only completed sessions are scored", ErrConflict, session.Id, session.Status)
+	}
+
+	d, err := u.dagRepository.Get(ctx, session.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG of session: %w", err)
+	}
+
+	steps, err := session.Steps(d)
+	if err != nil {
+		return nil, fmt.Errorf("%w: session %s no longer matches its DAG: %s", ErrConflict, session.Id, err)
+	}
+
+	path := make([]model.Answer, 0, len(steps))
+	for _, step := range steps {
+		path = append(path, step.Answer)
+	}
+	score := d.Score(path)
+
+	return &score, nil
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreSessionUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	d := dagtest.ValidSingleRoot()
+	d.Scoring = &model.Scoring{Dimensions: []model.ScoreDimension{
+		{Name: "claim_strength", Weight: 2, Bands: []model.ScoreBand{{Min: 3, Label: "strong"}}},
+	}}
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+	for _, step := range paths[0].Steps {
+		node := d.Nodes[step.NodeId]
+		for i := range node.Answers {
+			if node.Answers[i].Id == step.Answer.Id {
+				node.Answers[i].Scores = map[string]float64{"claim_strength": 2}
+			}
+		}
+		d.Nodes[node.Id] = node
+	}
+
+	completed, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+	for _, step := range paths[0].Steps {
+		require.NoError(t, completed.Answer(d, step.Answer.Id, "", nil, now))
+	}
+	require.NoError(t, completed.Complete(now))
+
+	inProgress, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+
+	t.Run("scores the path of a completed session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+
+		score, err := NewScoreSessionUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdScoreSession{
+			SessionId: completed.Id.String(),
+		})
+		require.NoError(t, err)
+
+		value := 2 * float64(len(paths[0].Steps))
+		require.Len(t, score.Dimensions, 1)
+		assert.Equal(t, value, score.Dimensions[0].Value)
+		assert.Equal(t, "strong", score.Dimensions[0].Band)
+		assert.Equal(t, 2*value, score.Total)
+	})
+
+	t.Run("rejects a session in progress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), inProgress.Id).Return(inProgress, nil)
+
+		_, err := NewScoreSessionUseCase(mocks.NewMockDAGRepository(ctrl), sessionRepo).Execute(context.Background(), CmdScoreSession{
+			SessionId: inProgress.Id.String(),
+		})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("returns not found for unknown session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(nil, ErrNotFound)
+
+		_, err := NewScoreSessionUseCase(mocks.NewMockDAGRepository(ctrl), sessionRepo).Execute(context.Background(), CmdScoreSession{
+			SessionId: completed.Id.String(),
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewScoreSessionUseCase(mocks.NewMockDAGRepository(ctrl), mocks.NewMockSessionRepository(ctrl)).
+			Execute(context.Background(), CmdScoreSession{SessionId: "invalid"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
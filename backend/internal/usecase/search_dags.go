@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator"
+)
+
+// DefaultSearchLimit is the number of hits returned when the search sets no limit
+const DefaultSearchLimit = 20
+
+type CmdSearchDAGs struct {
+	Query string `validate:"required,max=200"`
+	// Limit caps the number of hits, DefaultSearchLimit when zero
+	Limit int `validate:"min=0,max=100"`
+}
+
+type SearchDAGsUseCase struct {
+	searchIndex DAGSearchIndex
+	validator   *validator.Validate
+}
+
+func NewSearchDAGsUseCase(searchIndex DAGSearchIndex) *SearchDAGsUseCase {
+	return &SearchDAGsUseCase{
+		searchIndex: searchIndex,
+		validator:   validator.New(),
+	}
+}
+
+// Execute searches the questions, answer statements and user contexts of every DAG
+func (u *SearchDAGsUseCase) Execute(ctx context.Context, cmd CmdSearchDAGs) ([]model.SearchHit, error) {
+	cmd.Query = strings.TrimSpace(cmd.Query)
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	limit := cmd.Limit
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+
+	hits, err := u.searchIndex.Search(ctx, cmd.Query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to search DAGs: %s", ErrInternal, err)
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchDAGsUseCase_Execute(t *testing.T) {
+	hits := []model.SearchHit{{DAGId: uuid.New(), NodeId: uuid.New(), Field: model.SearchFieldQuestion, Snippet: "Were you dismissed?"}}
+
+	tests := []struct {
+		name      string
+		cmd       CmdSearchDAGs
+		setupMock func(*mocks.MockDAGSearchIndex)
+		errorType error
+	}{
+		{
+			name: "searches with the default limit",
+			cmd:  CmdSearchDAGs{Query: "  dismissed "},
+			setupMock: func(index *mocks.MockDAGSearchIndex) {
+				index.EXPECT().Search(gomock.Any(), "dismissed", DefaultSearchLimit).Return(hits, nil)
+			},
+		},
+		{
+			name: "searches with the requested limit",
+			cmd:  CmdSearchDAGs{Query: "dismissed", Limit: 5},
+			setupMock: func(index *mocks.MockDAGSearchIndex) {
+				index.EXPECT().Search(gomock.Any(), "dismissed", 5).Return(hits, nil)
+			},
+		},
+		{
+			name:      "rejects a blank query",
+			cmd:       CmdSearchDAGs{Query: "   "},
+			setupMock: func(index *mocks.MockDAGSearchIndex) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects a query too long",
+			cmd:       CmdSearchDAGs{Query: strings.Repeat("a", 201)},
+			setupMock: func(index *mocks.MockDAGSearchIndex) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects a limit above the maximum",
+			cmd:       CmdSearchDAGs{Query: "dismissed", Limit: 101},
+			setupMock: func(index *mocks.MockDAGSearchIndex) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name: "returns internal error when the index fails",
+			cmd:  CmdSearchDAGs{Query: "dismissed"},
+			setupMock: func(index *mocks.MockDAGSearchIndex) {
+				index.EXPECT().Search(gomock.Any(), "dismissed", DefaultSearchLimit).Return(nil, errors.New("boom"))
+			},
+			errorType: ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			index := mocks.NewMockDAGSearchIndex(ctrl)
+			tt.setupMock(index)
+
+			result, err := NewSearchDAGsUseCase(index).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, hits, result)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/document"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// AttachmentLink is the API path an attachment of a session is downloaded from
+func AttachmentLink(sessionId uuid.UUID, attachmentId uuid.UUID) string {
+	return fmt.Sprintf("/v1/sessions/%s/attachments/%s", sessionId, attachmentId)
+}
+
+type CmdGetSessionDocument struct {
+	SessionId string `validate:"required,uuid"`
+	Format    string `validate:"required,oneof=md pdf"`
+}
+
+// SessionDocument is the context document of a session rendered in a file format
+type SessionDocument struct {
+	Format      string
+	ContentType string
+	FileName    string
+	Data        []byte
+}
+
+type GetSessionDocumentUseCase struct {
+	dagRepository     DAGRepository
+	sessionRepository SessionRepository
+	validator         *validator.Validate
+}
+
+func NewGetSessionDocumentUseCase(dagRepository DAGRepository, sessionRepository SessionRepository) *GetSessionDocumentUseCase {
+	return &GetSessionDocumentUseCase{
+		dagRepository:     dagRepository,
+		sessionRepository: sessionRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute renders the questions and answers of a completed session as a context document
+func (u *GetSessionDocumentUseCase) Execute(ctx context.Context, cmd CmdGetSessionDocument) (*SessionDocument, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	doc, err := loadSessionDocument(ctx, u.dagRepository, u.sessionRepository, cmd.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := document.Render(doc, cmd.Format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to render session document: %s", ErrInternal, err)
+	}
+
+	return &SessionDocument{
+		Format:      rendered.Format,
+		ContentType: rendered.ContentType,
+		FileName:    document.FileName(doc, rendered.Format),
+		Data:        rendered.Data,
+	}, nil
+}
+
+// loadSessionDocument builds the context document of a completed session
+func loadSessionDocument(ctx context.Context, dagRepository DAGRepository, sessionRepository SessionRepository, id string) (*model.ContextDocument, error) {
+	sessionId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	session, err := sessionRepository.Get(ctx, sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session: %w", err)
+	}
+	if session.Status != model.SessionStatusCompleted {
+		return nil, fmt.Errorf("%w: session %s is %s, only completed sessions have a document", ErrConflict, session.Id, session.Status)
+	}
+
+	dag, err := dagRepository.Get(ctx, session.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG of session: %w", err)
+	}
+
+	steps, err := session.Steps(dag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: session %s no longer matches its DAG: %s", ErrConflict, session.Id, err)
+	}
+
+	doc := model.NewContextDocument(dag, steps, time.Now())
+	doc.SessionId = &session.Id
+	// Entries follow the steps, each one links the evidence uploaded for its answer
+	for i, step := range steps {
+		for _, attachment := range session.AttachmentsOf(step.Answer.Id) {
+			doc.Entries[i].Attachments = append(doc.Entries[i].Attachments, model.ContextDocumentAttachment{
+				FileName:    attachment.FileName,
+				ContentType: attachment.ContentType,
+				Size:        attachment.Size,
+				Checksum:    attachment.Checksum,
+				Link:        AttachmentLink(session.Id, attachment.Id),
+			})
+		}
+	}
+
+	return doc, nil
+}
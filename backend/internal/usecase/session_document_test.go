@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSessionDocumentUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	d := dagtest.ValidSingleRoot()
+	paths, err := d.EnumeratePaths()
+	require.NoError(t, err)
+
+	completed, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+	for _, step := range paths[0].Steps {
+		require.NoError(t, completed.Answer(d, step.Answer.Id, "Context of "+step.Answer.Statement, nil, now))
+	}
+	require.NoError(t, completed.Complete(now))
+
+	inProgress, err := model.NewCaseSession(d, now)
+	require.NoError(t, err)
+
+	t.Run("renders a completed session as markdown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+
+		result, err := NewGetSessionDocumentUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdGetSessionDocument{
+			SessionId: completed.Id.String(),
+			Format:    "md",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "md", result.Format)
+		assert.Equal(t, "text/markdown; charset=utf-8", result.ContentType)
+		assert.Equal(t, "case-context-"+completed.Id.String()+".md", result.FileName)
+		content := string(result.Data)
+		assert.Contains(t, content, "# Case context: "+d.Title)
+		assert.Contains(t, content, "- Session: `"+completed.Id.String()+"`")
+		for _, step := range paths[0].Steps {
+			assert.Contains(t, content, step.Question)
+			assert.Contains(t, content, "**Context:** Context of "+step.Answer.Statement)
+		}
+	})
+
+	t.Run("renders a completed session as pdf", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(completed, nil)
+
+		result, err := NewGetSessionDocumentUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdGetSessionDocument{
+			SessionId: completed.Id.String(),
+			Format:    "pdf",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "application/pdf", result.ContentType)
+		assert.True(t, strings.HasPrefix(string(result.Data), "%PDF-"))
+	})
+
+	t.Run("links the attachments of the answers", func(t *testing.T) {
+		attached := *completed
+		attachment := model.Attachment{
+			Id:          uuid.New(),
+			AnswerId:    paths[0].Steps[0].Answer.Id,
+			FileName:    "dismissal.pdf",
+			ContentType: "application/pdf",
+			Size:        2048,
+			Checksum:    "sha256:9f86d081",
+		}
+		attached.Attachments = []model.Attachment{attachment}
+
+		ctrl := gomock.NewController(t)
+		dagRepo := mocks.NewMockDAGRepository(ctrl)
+		dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(&attached, nil)
+
+		result, err := NewGetSessionDocumentUseCase(dagRepo, sessionRepo).Execute(context.Background(), CmdGetSessionDocument{
+			SessionId: completed.Id.String(),
+			Format:    "md",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(result.Data), "- [dismissal.pdf]("+AttachmentLink(completed.Id, attachment.Id)+") (application/pdf, 2048 bytes, sha256:9f86d081)")
+	})
+
+	t.Run("rejects a session in progress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), inProgress.Id).Return(inProgress, nil)
+
+		_, err := NewGetSessionDocumentUseCase(mocks.NewMockDAGRepository(ctrl), sessionRepo).Execute(context.Background(), CmdGetSessionDocument{
+			SessionId: inProgress.Id.String(),
+			Format:    "md",
+		})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("returns not found for unknown session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionRepo := mocks.NewMockSessionRepository(ctrl)
+		sessionRepo.EXPECT().Get(gomock.Any(), completed.Id).Return(nil, ErrNotFound)
+
+		_, err := NewGetSessionDocumentUseCase(mocks.NewMockDAGRepository(ctrl), sessionRepo).Execute(context.Background(), CmdGetSessionDocument{
+			SessionId: completed.Id.String(),
+			Format:    "md",
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		useCase := NewGetSessionDocumentUseCase(mocks.NewMockDAGRepository(ctrl), mocks.NewMockSessionRepository(ctrl))
+
+		_, err := useCase.Execute(context.Background(), CmdGetSessionDocument{SessionId: "invalid", Format: "md"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+
+		_, err = useCase.Execute(context.Background(), CmdGetSessionDocument{SessionId: completed.Id.String(), Format: "docx"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=session_repository.go -destination=testdata/mocks/session_repository_mock.go -package=mocks
+
+type SessionRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*model.CaseSession, error)
+	Create(ctx context.Context, session *model.CaseSession) error
+	// Update applies fnUpdate atomically to a session and persists the result
+	Update(ctx context.Context, id uuid.UUID, fnUpdate func(session model.CaseSession) (model.CaseSession, error)) error
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// CmdSplitNode splits the answers of a node into buckets, each bucket becoming an intermediate sub-question.
+// Every answer of the node must belong to exactly one bucket.
+type CmdSplitNode struct {
+	DAGId   string        `validate:"required,uuid"`
+	NodeId  string        `validate:"required,uuid"`
+	Buckets []SplitBucket `validate:"min=2,dive"`
+}
+
+// SplitBucket groups answers of the split node under a new sub-question
+type SplitBucket struct {
+	// Statement is the answer of the split node leading to the sub-question
+	Statement string `validate:"required"`
+	// Question is the sub-question offering the grouped answers
+	Question  string   `validate:"required"`
+	AnswerIds []string `validate:"min=1,dive,uuid"`
+}
+
+type SplitNodeUseCase struct {
+	dagRepository  DAGRepository
+	dagValidator   *DAGValidator
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewSplitNodeUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *SplitNodeUseCase {
+	return &SplitNodeUseCase{
+		dagRepository:  dagRepository,
+		dagValidator:   dagValidator,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// Execute moves the answers of the node to one new node per bucket, gives the node one answer per bucket
+// leading to it and re-validates the resulting DAG. The moved answers keep their ID, target and metadata.
+func (u *SplitNodeUseCase) Execute(ctx context.Context, cmd CmdSplitNode) (*model.DAG, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagId, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	nodeId, err := uuid.Parse(cmd.NodeId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	// The IDs are generated once, the repository may apply the update to several copies of the DAG
+	subNodeIds := make([]uuid.UUID, len(cmd.Buckets))
+	answerIds := make([]uuid.UUID, len(cmd.Buckets))
+	for i := range cmd.Buckets {
+		subNodeIds[i] = uuid.New()
+		answerIds[i] = uuid.New()
+	}
+
+	var updatedDAG model.DAG
+	err = u.dagRepository.Update(ctx, dagId, func(existingDAG model.DAG) (model.DAG, error) {
+		node, ok := existingDAG.Nodes[nodeId]
+		if !ok {
+			return existingDAG, fmt.Errorf("%w: node %s not found in DAG %s", ErrNotFound, nodeId, dagId)
+		}
+
+		buckets, err := groupAnswers(node, cmd.Buckets)
+		if err != nil {
+			return existingDAG, err
+		}
+
+		// Copy the nodes so the stored DAG is never mutated in place
+		nodes := make(map[uuid.UUID]model.Node, len(existingDAG.Nodes)+len(buckets))
+		for id, n := range existingDAG.Nodes {
+			nodes[id] = n
+		}
+
+		node.Answers = make([]model.Answer, 0, len(buckets))
+		for i, answers := range buckets {
+			subNodeId := subNodeIds[i]
+			nodes[subNodeId] = model.Node{
+				Id:       subNodeId,
+				Question: cmd.Buckets[i].Question,
+				Answers:  answers,
+			}
+			node.Answers = append(node.Answers, model.Answer{
+				Id:        answerIds[i],
+				Statement: cmd.Buckets[i].Statement,
+				NextNode:  &subNodeId,
+			})
+		}
+		nodes[nodeId] = node
+
+		existingDAG.Nodes = nodes
+		existingDAG.UpdatedAt = time.Now()
+		existingDAG.Revision++
+		result := u.dagValidator.ValidateDAG(&existingDAG)
+		if !result.IsValid {
+			var errorMessages []string
+			for _, err := range result.Errors {
+				errorMessages = append(errorMessages, err.Message)
+			}
+			return existingDAG, fmt.Errorf("%w: DAG validation failed: %v", ErrInvalidCommand, errorMessages)
+		}
+
+		updatedDAG = existingDAG
+		return existingDAG, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to split node: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", dagId.String()).
+		Str("node_id", nodeId.String()).
+		Int("buckets", len(cmd.Buckets)).
+		Msg("node split")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, dagId, time.Now()))
+
+	return &updatedDAG, nil
+}
+
+// groupAnswers returns the answers of the node grouped by bucket, in bucket order,
+// rejecting unknown, duplicated or unassigned answers
+func groupAnswers(node model.Node, buckets []SplitBucket) ([][]model.Answer, error) {
+	answers := make(map[uuid.UUID]model.Answer, len(node.Answers))
+	for _, answer := range node.Answers {
+		answers[answer.Id] = answer
+	}
+
+	grouped := make([][]model.Answer, len(buckets))
+	assigned := make(map[uuid.UUID]bool, len(node.Answers))
+	for i, bucket := range buckets {
+		for _, rawId := range bucket.AnswerIds {
+			answerId, err := uuid.Parse(rawId)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+			}
+
+			answer, ok := answers[answerId]
+			if !ok {
+				return nil, fmt.Errorf("%w: answer %s does not belong to node %s", ErrInvalidCommand, answerId, node.Id)
+			}
+			if assigned[answerId] {
+				return nil, fmt.Errorf("%w: answer %s is assigned to several buckets", ErrInvalidCommand, answerId)
+			}
+
+			assigned[answerId] = true
+			grouped[i] = append(grouped[i], answer)
+		}
+	}
+
+	for _, answer := range node.Answers {
+		if !assigned[answer.Id] {
+			return nil, fmt.Errorf("%w: answer %s is not assigned to a bucket", ErrInvalidCommand, answer.Id)
+		}
+	}
+
+	return grouped, nil
+}
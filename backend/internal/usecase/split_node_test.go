@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitNodeUseCase_Execute(t *testing.T) {
+	bucket := func(statement string, answerIds ...uuid.UUID) SplitBucket {
+		ids := make([]string, len(answerIds))
+		for i, id := range answerIds {
+			ids[i] = id.String()
+		}
+		return SplitBucket{Statement: statement, Question: statement + " question?", AnswerIds: ids}
+	}
+
+	tests := []struct {
+		name       string
+		cmd        func(d *model.DAG, root model.Node) CmdSplitNode
+		expectRepo bool
+		errorType  error
+	}{
+		{
+			name: "moves the answers under one sub-node per bucket",
+			cmd: func(d *model.DAG, root model.Node) CmdSplitNode {
+				return CmdSplitNode{DAGId: d.Id.String(), NodeId: root.Id.String(), Buckets: []SplitBucket{
+					bucket("Middle", root.Answers[0].Id),
+					bucket("Leaf", root.Answers[1].Id),
+				}}
+			},
+			expectRepo: true,
+		},
+		{
+			name: "returns not found for unknown node",
+			cmd: func(d *model.DAG, root model.Node) CmdSplitNode {
+				return CmdSplitNode{DAGId: d.Id.String(), NodeId: uuid.New().String(), Buckets: []SplitBucket{
+					bucket("Middle", root.Answers[0].Id),
+					bucket("Leaf", root.Answers[1].Id),
+				}}
+			},
+			expectRepo: true,
+			errorType:  ErrNotFound,
+		},
+		{
+			name: "rejects an answer left out of every bucket",
+			cmd: func(d *model.DAG, root model.Node) CmdSplitNode {
+				return CmdSplitNode{DAGId: d.Id.String(), NodeId: root.Id.String(), Buckets: []SplitBucket{
+					bucket("Middle", root.Answers[0].Id),
+					bucket("Other", uuid.New()),
+				}}
+			},
+			expectRepo: true,
+			errorType:  ErrInvalidCommand,
+		},
+		{
+			name: "rejects an answer assigned to several buckets",
+			cmd: func(d *model.DAG, root model.Node) CmdSplitNode {
+				return CmdSplitNode{DAGId: d.Id.String(), NodeId: root.Id.String(), Buckets: []SplitBucket{
+					bucket("Middle", root.Answers[0].Id, root.Answers[1].Id),
+					bucket("Leaf", root.Answers[1].Id),
+				}}
+			},
+			expectRepo: true,
+			errorType:  ErrInvalidCommand,
+		},
+		{
+			name: "returns validation error for a single bucket",
+			cmd: func(d *model.DAG, root model.Node) CmdSplitNode {
+				return CmdSplitNode{DAGId: d.Id.String(), NodeId: root.Id.String(), Buckets: []SplitBucket{
+					bucket("All", root.Answers[0].Id, root.Answers[1].Id),
+				}}
+			},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stored := dagtest.ValidSingleRoot()
+			root := dagtest.Root(stored)
+			originalAnswers := append([]model.Answer(nil), root.Answers...)
+
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			if tt.expectRepo {
+				mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+						_, err := fnUpdate(*stored)
+						return err
+					},
+				)
+			}
+
+			useCase := NewSplitNodeUseCase(mockRepo, NewDAGValidator(), nil)
+			updated, err := useCase.Execute(context.Background(), tt.cmd(stored, root))
+
+			assert.Equal(t, originalAnswers, stored.Nodes[root.Id].Answers, "stored DAG must not be mutated")
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				assert.Nil(t, updated)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, updated.Nodes, len(stored.Nodes)+2)
+
+			// The split node leads to one sub-node per bucket, each offering the original answer untouched
+			split := updated.Nodes[root.Id]
+			require.Len(t, split.Answers, 2)
+			for i, answer := range split.Answers {
+				require.NotNil(t, answer.NextNode)
+				subNode := updated.Nodes[*answer.NextNode]
+				assert.Equal(t, answer.Statement+" question?", subNode.Question)
+				assert.Equal(t, []model.Answer{originalAnswers[i]}, subNode.Answers)
+			}
+
+			result := NewDAGValidator().ValidateDAG(updated)
+			assert.True(t, result.IsValid, "errors: %v", result.Errors)
+			assert.False(t, result.Statistics.HasCycles)
+		})
+	}
+}
+
+func TestSplitNodeUseCase_Execute_SameIDsOnEveryCopy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	stored := dagtest.ValidSingleRoot()
+	root := dagtest.Root(stored)
+
+	// Repositories keeping several copies, e.g. memory and file, may apply the update to each of them
+	var copies []model.DAG
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+			for i := 0; i < 2; i++ {
+				updated, err := fnUpdate(*stored)
+				if err != nil {
+					return err
+				}
+				copies = append(copies, updated)
+			}
+			return nil
+		},
+	)
+
+	_, err := NewSplitNodeUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdSplitNode{
+		DAGId:  stored.Id.String(),
+		NodeId: root.Id.String(),
+		Buckets: []SplitBucket{
+			{Statement: "Middle", Question: "Middle question?", AnswerIds: []string{root.Answers[0].Id.String()}},
+			{Statement: "Leaf", Question: "Leaf question?", AnswerIds: []string{root.Answers[1].Id.String()}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, copies, 2)
+	assert.Equal(t, copies[0].Nodes[root.Id].Answers, copies[1].Nodes[root.Id].Answers)
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdStartSession struct {
+	DAGId string `validate:"required,uuid"`
+}
+
+type StartSessionUseCase struct {
+	dagRepository     DAGRepository
+	sessionRepository SessionRepository
+	validator         *validator.Validate
+}
+
+func NewStartSessionUseCase(dagRepository DAGRepository, sessionRepository SessionRepository) *StartSessionUseCase {
+	return &StartSessionUseCase{
+		dagRepository:     dagRepository,
+		sessionRepository: sessionRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute starts a case session on the root node of a DAG
+func (u *StartSessionUseCase) Execute(ctx context.Context, cmd CmdStartSession) (*model.CaseSession, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	d, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve DAG for session: %w", err)
+	}
+
+	session, err := model.NewCaseSession(d, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	if err := u.sessionRepository.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("session_id", session.Id.String()).
+		Str("dag_id", id.String()).
+		Msg("case session started")
+
+	return session, nil
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartSessionUseCase_Execute(t *testing.T) {
+	d := dagtest.ValidSingleRoot()
+
+	tests := []struct {
+		name       string
+		cmd        CmdStartSession
+		setupMocks func(*mocks.MockDAGRepository, *mocks.MockSessionRepository)
+		errorType  error
+	}{
+		{
+			name: "starts a session on the root node",
+			cmd:  CmdStartSession{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, sessionRepo *mocks.MockSessionRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(d, nil)
+				sessionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "rejects a DAG without single root",
+			cmd:  CmdStartSession{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, sessionRepo *mocks.MockSessionRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(dagtest.MultipleRoots(), nil)
+			},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdStartSession{DAGId: d.Id.String()},
+			setupMocks: func(dagRepo *mocks.MockDAGRepository, sessionRepo *mocks.MockSessionRepository) {
+				dagRepo.EXPECT().Get(gomock.Any(), d.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:       "rejects invalid DAG ID",
+			cmd:        CmdStartSession{DAGId: "invalid"},
+			setupMocks: func(*mocks.MockDAGRepository, *mocks.MockSessionRepository) {},
+			errorType:  ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dagRepo := mocks.NewMockDAGRepository(ctrl)
+			sessionRepo := mocks.NewMockSessionRepository(ctrl)
+			tt.setupMocks(dagRepo, sessionRepo)
+
+			session, err := NewStartSessionUseCase(dagRepo, sessionRepo).Execute(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, d.Id, session.DAGId)
+			assert.Equal(t, model.SessionStatusInProgress, session.Status)
+			require.NotNil(t, session.CurrentNodeId)
+			assert.Equal(t, dagtest.Root(d).Id, *session.CurrentNodeId)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=template_repository.go -destination=testdata/mocks/template_repository_mock.go -package=mocks
+
+type TemplateRepository interface {
+	// List returns every template, ordered by name
+	List(ctx context.Context) ([]model.Template, error)
+	Get(ctx context.Context, id uuid.UUID) (*model.Template, error)
+	Create(ctx context.Context, template *model.Template) error
+}
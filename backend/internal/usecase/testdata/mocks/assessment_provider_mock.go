@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: assessment_provider.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	llmprompt "davidterranova/jurigen/backend/internal/llmprompt"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAssessmentProvider is a mock of AssessmentProvider interface.
+type MockAssessmentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssessmentProviderMockRecorder
+}
+
+// MockAssessmentProviderMockRecorder is the mock recorder for MockAssessmentProvider.
+type MockAssessmentProviderMockRecorder struct {
+	mock *MockAssessmentProvider
+}
+
+// NewMockAssessmentProvider creates a new mock instance.
+func NewMockAssessmentProvider(ctrl *gomock.Controller) *MockAssessmentProvider {
+	mock := &MockAssessmentProvider{ctrl: ctrl}
+	mock.recorder = &MockAssessmentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssessmentProvider) EXPECT() *MockAssessmentProviderMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockAssessmentProvider) Complete(ctx context.Context, messages []llmprompt.Message) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, messages)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockAssessmentProviderMockRecorder) Complete(ctx, messages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockAssessmentProvider)(nil).Complete), ctx, messages)
+}
+
+// Model mocks base method.
+func (m *MockAssessmentProvider) Model() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Model")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Model indicates an expected call of Model.
+func (mr *MockAssessmentProviderMockRecorder) Model() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Model", reflect.TypeOf((*MockAssessmentProvider)(nil).Model))
+}
+
+// Name mocks base method.
+func (m *MockAssessmentProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockAssessmentProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAssessmentProvider)(nil).Name))
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment_storage.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentStorage is a mock of AttachmentStorage interface.
+type MockAttachmentStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentStorageMockRecorder
+}
+
+// MockAttachmentStorageMockRecorder is the mock recorder for MockAttachmentStorage.
+type MockAttachmentStorageMockRecorder struct {
+	mock *MockAttachmentStorage
+}
+
+// NewMockAttachmentStorage creates a new mock instance.
+func NewMockAttachmentStorage(ctrl *gomock.Controller) *MockAttachmentStorage {
+	mock := &MockAttachmentStorage{ctrl: ctrl}
+	mock.recorder = &MockAttachmentStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentStorage) EXPECT() *MockAttachmentStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentStorageMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentStorage)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockAttachmentStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAttachmentStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAttachmentStorage)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockAttachmentStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, data, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockAttachmentStorageMockRecorder) Put(ctx, key, data, contentType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockAttachmentStorage)(nil).Put), ctx, key, data, contentType)
+}
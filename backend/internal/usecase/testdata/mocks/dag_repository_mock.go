@@ -64,6 +64,20 @@ func (mr *MockDAGRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDAGRepository)(nil).Delete), ctx, id)
 }
 
+// Each mocks base method.
+func (m *MockDAGRepository) Each(ctx context.Context, fn func(*model.DAG) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockDAGRepositoryMockRecorder) Each(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockDAGRepository)(nil).Each), ctx, fn)
+}
+
 // Get mocks base method.
 func (m *MockDAGRepository) Get(ctx context.Context, id uuid.UUID) (*model.DAG, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +108,35 @@ func (mr *MockDAGRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDAGRepository)(nil).List), ctx)
 }
 
+// Observe mocks base method.
+func (m *MockDAGRepository) Observe(fn func(model.ChangeEvent)) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Observe", fn)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// Observe indicates an expected call of Observe.
+func (mr *MockDAGRepositoryMockRecorder) Observe(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Observe", reflect.TypeOf((*MockDAGRepository)(nil).Observe), fn)
+}
+
+// Query mocks base method.
+func (m *MockDAGRepository) Query(ctx context.Context, query model.DAGQuery) (*model.DAGPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].(*model.DAGPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockDAGRepositoryMockRecorder) Query(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockDAGRepository)(nil).Query), ctx, query)
+}
+
 // Update mocks base method.
 func (m *MockDAGRepository) Update(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
 	m.ctrl.T.Helper()
@@ -107,3 +150,18 @@ func (mr *MockDAGRepositoryMockRecorder) Update(ctx, id, fnUpdate interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDAGRepository)(nil).Update), ctx, id, fnUpdate)
 }
+
+// Watch mocks base method.
+func (m *MockDAGRepository) Watch(ctx context.Context) (<-chan model.ChangeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx)
+	ret0, _ := ret[0].(<-chan model.ChangeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockDAGRepositoryMockRecorder) Watch(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockDAGRepository)(nil).Watch), ctx)
+}
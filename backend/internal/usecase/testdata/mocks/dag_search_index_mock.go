@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dag_search_index.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "davidterranova/jurigen/backend/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDAGSearchIndex is a mock of DAGSearchIndex interface.
+type MockDAGSearchIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockDAGSearchIndexMockRecorder
+}
+
+// MockDAGSearchIndexMockRecorder is the mock recorder for MockDAGSearchIndex.
+type MockDAGSearchIndexMockRecorder struct {
+	mock *MockDAGSearchIndex
+}
+
+// NewMockDAGSearchIndex creates a new mock instance.
+func NewMockDAGSearchIndex(ctrl *gomock.Controller) *MockDAGSearchIndex {
+	mock := &MockDAGSearchIndex{ctrl: ctrl}
+	mock.recorder = &MockDAGSearchIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDAGSearchIndex) EXPECT() *MockDAGSearchIndexMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockDAGSearchIndex) Search(ctx context.Context, query string, limit int) ([]model.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]model.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockDAGSearchIndexMockRecorder) Search(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockDAGSearchIndex)(nil).Search), ctx, query, limit)
+}
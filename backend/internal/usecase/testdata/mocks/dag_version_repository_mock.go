@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dag_version_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "davidterranova/jurigen/backend/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockDAGVersionRepository is a mock of DAGVersionRepository interface.
+type MockDAGVersionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDAGVersionRepositoryMockRecorder
+}
+
+// MockDAGVersionRepositoryMockRecorder is the mock recorder for MockDAGVersionRepository.
+type MockDAGVersionRepositoryMockRecorder struct {
+	mock *MockDAGVersionRepository
+}
+
+// NewMockDAGVersionRepository creates a new mock instance.
+func NewMockDAGVersionRepository(ctrl *gomock.Controller) *MockDAGVersionRepository {
+	mock := &MockDAGVersionRepository{ctrl: ctrl}
+	mock.recorder = &MockDAGVersionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDAGVersionRepository) EXPECT() *MockDAGVersionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockDAGVersionRepository) Append(ctx context.Context, d *model.DAG, createdAt time.Time, author string) (*model.DAGVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, d, createdAt, author)
+	ret0, _ := ret[0].(*model.DAGVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockDAGVersionRepositoryMockRecorder) Append(ctx, d, createdAt, author interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockDAGVersionRepository)(nil).Append), ctx, d, createdAt, author)
+}
+
+// Delete mocks base method.
+func (m *MockDAGVersionRepository) Delete(ctx context.Context, dagId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, dagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDAGVersionRepositoryMockRecorder) Delete(ctx, dagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDAGVersionRepository)(nil).Delete), ctx, dagId)
+}
+
+// Get mocks base method.
+func (m *MockDAGVersionRepository) Get(ctx context.Context, dagId uuid.UUID, number int) (*model.DAGVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, dagId, number)
+	ret0, _ := ret[0].(*model.DAGVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDAGVersionRepositoryMockRecorder) Get(ctx, dagId, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDAGVersionRepository)(nil).Get), ctx, dagId, number)
+}
+
+// List mocks base method.
+func (m *MockDAGVersionRepository) List(ctx context.Context, dagId uuid.UUID) ([]model.DAGVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, dagId)
+	ret0, _ := ret[0].([]model.DAGVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDAGVersionRepositoryMockRecorder) List(ctx, dagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDAGVersionRepository)(nil).List), ctx, dagId)
+}
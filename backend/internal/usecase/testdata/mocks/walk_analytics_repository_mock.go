@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: walk_analytics_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "davidterranova/jurigen/backend/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockWalkAnalyticsRepository is a mock of WalkAnalyticsRepository interface.
+type MockWalkAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalkAnalyticsRepositoryMockRecorder
+}
+
+// MockWalkAnalyticsRepositoryMockRecorder is the mock recorder for MockWalkAnalyticsRepository.
+type MockWalkAnalyticsRepositoryMockRecorder struct {
+	mock *MockWalkAnalyticsRepository
+}
+
+// NewMockWalkAnalyticsRepository creates a new mock instance.
+func NewMockWalkAnalyticsRepository(ctrl *gomock.Controller) *MockWalkAnalyticsRepository {
+	mock := &MockWalkAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockWalkAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalkAnalyticsRepository) EXPECT() *MockWalkAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWalkAnalyticsRepository) Delete(ctx context.Context, dagId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, dagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWalkAnalyticsRepositoryMockRecorder) Delete(ctx, dagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWalkAnalyticsRepository)(nil).Delete), ctx, dagId)
+}
+
+// Get mocks base method.
+func (m *MockWalkAnalyticsRepository) Get(ctx context.Context, dagId uuid.UUID) (*model.WalkAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, dagId)
+	ret0, _ := ret[0].(*model.WalkAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWalkAnalyticsRepositoryMockRecorder) Get(ctx, dagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWalkAnalyticsRepository)(nil).Get), ctx, dagId)
+}
+
+// Update mocks base method.
+func (m *MockWalkAnalyticsRepository) Update(ctx context.Context, dagId uuid.UUID, fnUpdate func(model.WalkAnalytics) (model.WalkAnalytics, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, dagId, fnUpdate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWalkAnalyticsRepositoryMockRecorder) Update(ctx, dagId, fnUpdate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWalkAnalyticsRepository)(nil).Update), ctx, dagId, fnUpdate)
+}
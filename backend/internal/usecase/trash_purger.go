@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"time"
+)
+
+// TrashPurger periodically purges the DAGs kept in the trash for longer than the retention
+type TrashPurger struct {
+	purgeDeletedDAGs *PurgeDeletedDAGsUseCase
+	retention        time.Duration
+	interval         time.Duration
+}
+
+func NewTrashPurger(purgeDeletedDAGs *PurgeDeletedDAGsUseCase, retention time.Duration, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		purgeDeletedDAGs: purgeDeletedDAGs,
+		retention:        retention,
+		interval:         interval,
+	}
+}
+
+// Run purges right away and then every interval, until the context is cancelled
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.purgeDeletedDAGs.Execute(ctx, CmdPurgeDeletedDAGs{Retention: p.retention})
+		if err != nil && ctx.Err() == nil {
+			xlog.Ctx(ctx).Error().Err(err).Msg("trash purge failed")
+		} else if len(purged) > 0 {
+			xlog.Ctx(ctx).Info().Int("purged", len(purged)).Msg("trash purge completed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestTrashPurger_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	deletedAt := time.Now().Add(-time.Hour)
+	expired := model.NewDAG("Expired")
+	expired.DeletedAt = &deletedAt
+
+	purged := make(chan uuid.UUID, 1)
+	dagRepo := mocks.NewMockDAGRepository(ctrl)
+	dagRepo.EXPECT().Query(gomock.Any(), model.DAGQuery{Deleted: true}).Return(&model.DAGPage{DAGs: []*model.DAG{expired}, Total: 1}, nil)
+	dagRepo.EXPECT().Query(gomock.Any(), model.DAGQuery{Deleted: true}).Return(&model.DAGPage{DAGs: []*model.DAG{}}, nil).AnyTimes()
+	dagRepo.EXPECT().Delete(gomock.Any(), expired.Id).DoAndReturn(func(_ context.Context, id uuid.UUID) error {
+		purged <- id
+		return nil
+	})
+	analyticsRepo := mocks.NewMockWalkAnalyticsRepository(ctrl)
+	analyticsRepo.EXPECT().Delete(gomock.Any(), expired.Id).Return(nil)
+	versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+	versionRepo.EXPECT().Delete(gomock.Any(), expired.Id).Return(nil)
+
+	purger := NewTrashPurger(NewPurgeDeletedDAGsUseCase(dagRepo, analyticsRepo, versionRepo), time.Minute, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		purger.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-purged:
+	case <-time.After(time.Second):
+		t.Fatal("expired DAG was not purged")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("purger did not stop after context cancellation")
+	}
+}
@@ -3,7 +3,10 @@ package usecase
 import (
 	"context"
 	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/xlog"
 	"fmt"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/google/uuid"
@@ -12,17 +15,35 @@ import (
 type CmdUpdateDAG struct {
 	DAGId string     `validate:"required,uuid"`
 	DAG   *model.DAG `validate:"required"`
+	// IfRevision rejects the update when the stored DAG is at another revision, unchecked when nil
+	IfRevision *uint64
+	// SkipValidationMetadata keeps the validation metadata of the payload instead of refreshing it
+	// from the validation of the update, e.g. for bulk imports validated afterwards
+	SkipValidationMetadata bool
+}
+
+// UpdatePreview describes how an update would change the DAG statistics, without persisting it
+type UpdatePreview struct {
+	DAG    *model.DAG
+	Before ValidationStatistics
+	After  ValidationStatistics
 }
 
 type UpdateDAGUseCase struct {
-	dagRepository DAGRepository
-	validator     *validator.Validate
+	dagRepository     DAGRepository
+	versionRepository DAGVersionRepository
+	dagValidator      *DAGValidator
+	eventPublisher    EventPublisher
+	validator         *validator.Validate
 }
 
-func NewUpdateDAGUseCase(dagRepository DAGRepository) *UpdateDAGUseCase {
+func NewUpdateDAGUseCase(dagRepository DAGRepository, versionRepository DAGVersionRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *UpdateDAGUseCase {
 	return &UpdateDAGUseCase{
-		dagRepository: dagRepository,
-		validator:     validator.New(),
+		dagRepository:     dagRepository,
+		versionRepository: versionRepository,
+		dagValidator:      dagValidator,
+		eventPublisher:    eventPublisher,
+		validator:         validator.New(),
 	}
 }
 
@@ -40,19 +61,36 @@ func (u *UpdateDAGUseCase) Execute(ctx context.Context, cmd CmdUpdateDAG) (*mode
 	}
 
 	// Update the DAG using repository's Update method
-	var updatedDAG *model.DAG
+	var previousDAG, updatedDAG *model.DAG
 	err = u.dagRepository.Update(ctx, id, func(existingDAG model.DAG) (model.DAG, error) {
+		if err := checkRevision(existingDAG, cmd.IfRevision); err != nil {
+			return existingDAG, err
+		}
+
 		// Validate that the DAG ID in the command matches the DAG ID in the payload
 		if cmd.DAG.Id != id {
 			return existingDAG, fmt.Errorf("%w: DAG ID mismatch - URL ID: %s, payload ID: %s", ErrInvalidCommand, id, cmd.DAG.Id)
 		}
 
 		// Validate DAG structure
-		if err := u.validateDAGStructure(cmd.DAG); err != nil {
+		result, err := u.validateDAGStructure(cmd.DAG)
+		if err != nil {
 			return existingDAG, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
 		}
 
+		now := time.Now()
+		// Refresh the validation metadata in the same write so it never describes another revision
+		if !cmd.SkipValidationMetadata {
+			recordValidation(cmd.DAG, result, now)
+		}
+
+		// Keep track of answer metadata revisions across updates
+		cmd.DAG.CarryMetadataHistory(existingDAG, now, actorFromContext(ctx))
+		cmd.DAG.UpdatedAt = now
+		cmd.DAG.Revision = existingDAG.Revision + 1
+
 		// Replace the entire DAG with the new one
+		previousDAG = &existingDAG
 		updatedDAG = cmd.DAG
 
 		return *cmd.DAG, nil
@@ -61,13 +99,101 @@ func (u *UpdateDAGUseCase) Execute(ctx context.Context, cmd CmdUpdateDAG) (*mode
 		return nil, fmt.Errorf("failed to update DAG: %w", err)
 	}
 
+	recordDAGVersion(ctx, u.versionRepository, previousDAG, cmd.DAG)
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", id.String()).
+		Msg("DAG updated")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, id, time.Now()))
+
 	return updatedDAG, nil
 }
 
-// validateDAGStructure performs comprehensive structural validation on the DAG
-func (u *UpdateDAGUseCase) validateDAGStructure(d *model.DAG) error {
-	validator := NewDAGValidator()
-	result := validator.ValidateDAG(d)
+// Preview validates the update against the stored DAG and returns the statistics before and after it,
+// leaving the stored DAG untouched
+func (u *UpdateDAGUseCase) Preview(ctx context.Context, cmd CmdUpdateDAG) (*UpdatePreview, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	id, err := uuid.Parse(cmd.DAGId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	if cmd.DAG.Id != id {
+		return nil, fmt.Errorf("%w: DAG ID mismatch - URL ID: %s, payload ID: %s", ErrInvalidCommand, id, cmd.DAG.Id)
+	}
+
+	existingDAG, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview DAG update: %w", err)
+	}
+
+	result, err := u.validateDAGStructure(cmd.DAG)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return &UpdatePreview{
+		DAG:    cmd.DAG,
+		Before: u.dagValidator.ValidateDAG(existingDAG).Statistics,
+		After:  result.Statistics,
+	}, nil
+}
+
+// checkRevision rejects changes to a DAG which is not at the expected revision, any revision is accepted when expected is nil.
+// It is called from the repository update functions, so that the check and the write are atomic.
+func checkRevision(dag model.DAG, expected *uint64) error {
+	if expected != nil && dag.Revision != *expected {
+		return fmt.Errorf("%w: DAG %s is at revision %d, not %d", ErrPreconditionFailed, dag.Id, dag.Revision, *expected)
+	}
+	return nil
+}
+
+// recordDAGVersion records the replacing DAG as a new version. DAGs without history, such as the ones
+// created before versioning, first get the replaced DAG recorded so that it can be restored.
+// The DAG is already replaced at this point, a version which cannot be recorded is only logged.
+func recordDAGVersion(ctx context.Context, versionRepository DAGVersionRepository, previous *model.DAG, current *model.DAG) {
+	logger := xlog.Ctx(ctx)
+	author := actorFromContext(ctx)
+
+	versions, err := versionRepository.List(ctx, current.Id)
+	if err != nil {
+		logger.Error().Err(err).Str("dag_id", current.Id.String()).Msg("failed to list DAG versions")
+		return
+	}
+	if len(versions) == 0 && previous != nil {
+		createdAt := previous.UpdatedAt
+		if createdAt.IsZero() {
+			createdAt = current.UpdatedAt
+		}
+		if _, err := versionRepository.Append(ctx, previous, createdAt, ""); err != nil {
+			logger.Error().Err(err).Str("dag_id", current.Id.String()).Msg("failed to record initial DAG version")
+			return
+		}
+	}
+
+	if _, err := versionRepository.Append(ctx, current, current.UpdatedAt, author); err != nil {
+		logger.Error().Err(err).Str("dag_id", current.Id.String()).Msg("failed to record DAG version")
+	}
+}
+
+// actorFromContext returns the ID of the authenticated user issuing the command, if any
+func actorFromContext(ctx context.Context) string {
+	u, err := auth.UserFromContext(ctx)
+	if err != nil || u.Id() == uuid.Nil {
+		return ""
+	}
+	return u.Id().String()
+}
+
+// validateDAGStructure performs comprehensive structural validation on the DAG, returning the validation result
+// along with an error when the DAG is invalid
+func (u *UpdateDAGUseCase) validateDAGStructure(d *model.DAG) (ValidationResult, error) {
+	result := u.dagValidator.ValidateDAG(d)
 
 	if !result.IsValid {
 		// Combine all error messages into a single error
@@ -75,8 +201,8 @@ func (u *UpdateDAGUseCase) validateDAGStructure(d *model.DAG) error {
 		for _, err := range result.Errors {
 			errorMessages = append(errorMessages, err.Message)
 		}
-		return fmt.Errorf("DAG validation failed: %v", errorMessages)
+		return result, fmt.Errorf("DAG validation failed: %v", errorMessages)
 	}
 
-	return nil
+	return result, nil
 }
@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -17,7 +21,7 @@ func TestNewUpdateDAGUseCase(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockDAGRepository(ctrl)
-	useCase := NewUpdateDAGUseCase(mockRepo)
+	useCase := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil)
 
 	assert.NotNil(t, useCase)
 	assert.Equal(t, mockRepo, useCase.dagRepository)
@@ -134,7 +138,7 @@ func TestUpdateDAGUseCase_Execute(t *testing.T) {
 			mockRepo := mocks.NewMockDAGRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			useCase := NewUpdateDAGUseCase(mockRepo)
+			useCase := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil)
 			ctx := context.Background()
 
 			result, err := useCase.Execute(ctx, tt.cmd)
@@ -151,6 +155,7 @@ func TestUpdateDAGUseCase_Execute(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotNil(t, result)
+			assert.False(t, result.UpdatedAt.IsZero(), "update time should be recorded")
 
 			if tt.expectedDAG != nil {
 				assert.Equal(t, tt.expectedDAG.Id, result.Id)
@@ -165,7 +170,7 @@ func TestUpdateDAGUseCase_ValidateDAGStructure(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockDAGRepository(ctrl)
-	useCase := NewUpdateDAGUseCase(mockRepo)
+	useCase := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil)
 
 	tests := []struct {
 		name      string
@@ -308,7 +313,7 @@ func TestUpdateDAGUseCase_ValidateDAGStructure(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := useCase.validateDAGStructure(tt.dag)
+			_, err := useCase.validateDAGStructure(tt.dag)
 
 			if tt.wantError {
 				require.Error(t, err)
@@ -336,7 +341,7 @@ func TestUpdateDAGUseCase_Execute_ContextPropagation(t *testing.T) {
 
 	mockRepo.EXPECT().Update(expectedCtx, testDAG.Id, gomock.Any()).Return(nil)
 
-	useCase := NewUpdateDAGUseCase(mockRepo)
+	useCase := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil)
 
 	_, err := useCase.Execute(expectedCtx, CmdUpdateDAG{
 		DAGId: testDAG.Id.String(),
@@ -393,3 +398,289 @@ func createValidTestDAG() *model.DAG {
 		},
 	}
 }
+
+func TestUpdateDAGUseCase_Execute_RecordsMetadataHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := createValidTestDAG()
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+			updated, err := fnUpdate(*stored)
+			if err != nil {
+				return err
+			}
+			stored = &updated
+			return nil
+		},
+	).Times(2)
+
+	useCase := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil)
+	userId := uuid.New()
+	ctx := auth.ContextWithUser(context.Background(), user.New(userId, user.UserTypeAuthenticated))
+
+	for _, confidence := range []float64{0.4, 0.9} {
+		edit := cloneTestDAG(stored)
+		for _, node := range edit.Nodes {
+			node.Answers[0].Metadata = map[string]interface{}{"confidence": confidence}
+		}
+
+		_, err := useCase.Execute(ctx, CmdUpdateDAG{DAGId: stored.Id.String(), DAG: edit})
+		require.NoError(t, err)
+	}
+
+	for _, node := range stored.Nodes {
+		history := node.Answers[0].MetadataHistory
+		require.Len(t, history, 2)
+		assert.Equal(t, 0.4, history[0].Metadata["confidence"])
+		assert.Equal(t, 0.9, history[1].Metadata["confidence"])
+		assert.Equal(t, userId.String(), history[1].By)
+		assert.Empty(t, node.Answers[1].MetadataHistory)
+	}
+}
+
+func TestUpdateDAGUseCase_Execute_RefreshesValidationMetadata(t *testing.T) {
+	update := func(t *testing.T, skip bool) model.DAG {
+		ctrl := gomock.NewController(t)
+		stored := createValidTestDAG()
+		stored.Metadata = model.NewDAGMetadata()
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+				updated, err := fnUpdate(*stored)
+				if err != nil {
+					return err
+				}
+				stored = &updated
+				return nil
+			},
+		)
+
+		edit := cloneTestDAG(stored)
+		edit.Metadata = model.NewDAGMetadata()
+		_, err := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil).
+			Execute(context.Background(), CmdUpdateDAG{DAGId: stored.Id.String(), DAG: edit, SkipValidationMetadata: skip})
+		require.NoError(t, err)
+		return *stored
+	}
+
+	t.Run("persists the validation of the update", func(t *testing.T) {
+		stored := update(t, false)
+		assert.True(t, stored.Metadata.IsValid)
+		assert.Equal(t, stored.UpdatedAt, stored.Metadata.LastValidatedAt)
+		assert.Equal(t, len(stored.Nodes), stored.Metadata.Statistics.TotalNodes)
+	})
+
+	t.Run("keeps the metadata of the payload when skipped", func(t *testing.T) {
+		stored := update(t, true)
+		assert.False(t, stored.Metadata.IsValid)
+		assert.True(t, stored.Metadata.LastValidatedAt.IsZero())
+	})
+}
+
+// cloneTestDAG copies the DAG nodes and answers so edits do not alias the original
+func cloneTestDAG(d *model.DAG) *model.DAG {
+	clone := &model.DAG{Id: d.Id, Title: d.Title, Nodes: make(map[uuid.UUID]model.Node, len(d.Nodes))}
+	for id, node := range d.Nodes {
+		node.Answers = append([]model.Answer(nil), node.Answers...)
+		clone.Nodes[id] = node
+	}
+	return clone
+}
+
+func TestUpdateDAGUseCase_Preview(t *testing.T) {
+	stored := dagtest.LinearChain(2)
+
+	// The incoming DAG appends a node after the current leaf
+	incoming := cloneTestDAG(stored)
+	newNodeId := uuid.New()
+	for id, node := range incoming.Nodes {
+		if len(node.Answers) == 0 {
+			node.Answers = []model.Answer{{Id: uuid.New(), Statement: "Continue", NextNode: &newNodeId}}
+			incoming.Nodes[id] = node
+		}
+	}
+	incoming.Nodes[newNodeId] = model.Node{Id: newNodeId, Question: "New question?", Answers: []model.Answer{}}
+
+	tests := []struct {
+		name      string
+		cmd       CmdUpdateDAG
+		setupMock func(*mocks.MockDAGRepository)
+		errorType error
+	}{
+		{
+			name: "returns statistics before and after the update",
+			cmd:  CmdUpdateDAG{DAGId: stored.Id.String(), DAG: incoming},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(stored, nil)
+			},
+		},
+		{
+			name: "returns not found for unknown DAG",
+			cmd:  CmdUpdateDAG{DAGId: stored.Id.String(), DAG: incoming},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(nil, ErrNotFound)
+			},
+			errorType: ErrNotFound,
+		},
+		{
+			name:      "rejects DAG ID mismatch",
+			cmd:       CmdUpdateDAG{DAGId: uuid.New().String(), DAG: incoming},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name: "rejects an invalid incoming DAG",
+			cmd:  CmdUpdateDAG{DAGId: stored.Id.String(), DAG: &model.DAG{Id: stored.Id, Nodes: map[uuid.UUID]model.Node{}}},
+			setupMock: func(mockRepo *mocks.MockDAGRepository) {
+				mockRepo.EXPECT().Get(gomock.Any(), stored.Id).Return(stored, nil)
+			},
+			errorType: ErrInvalidCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// No Update call is expected: a preview never persists
+			mockRepo := mocks.NewMockDAGRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			useCase := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil)
+			preview, err := useCase.Preview(context.Background(), tt.cmd)
+
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				assert.Nil(t, preview)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 2, preview.Before.TotalNodes)
+			assert.Equal(t, 3, preview.After.TotalNodes)
+			assert.Equal(t, 1, preview.Before.MaxDepth)
+			assert.Equal(t, 2, preview.After.MaxDepth)
+			assert.Equal(t, incoming, preview.DAG)
+			assert.Len(t, stored.Nodes, 2)
+		})
+	}
+}
+
+// anyVersionRepository returns a version repository accepting any version to record
+func anyVersionRepository(ctrl *gomock.Controller) *mocks.MockDAGVersionRepository {
+	versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+	versionRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	versionRepo.EXPECT().Append(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.DAGVersion{}, nil).AnyTimes()
+	return versionRepo
+}
+
+func TestUpdateDAGUseCase_Execute_RecordsVersions(t *testing.T) {
+	stored := createValidTestDAG()
+	stored.UpdatedAt = time.Now().Add(-time.Hour)
+	updateStored := func(mockRepo *mocks.MockDAGRepository) {
+		mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.DAG) (model.DAG, error)) error {
+				_, err := fn(*stored)
+				return err
+			},
+		)
+	}
+
+	t.Run("records the replaced DAG first when there is no history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+		updateStored(mockRepo)
+
+		edit := cloneTestDAG(stored)
+		userId := uuid.New()
+		ctx := auth.ContextWithUser(context.Background(), user.New(userId, user.UserTypeAuthenticated))
+
+		versionRepo.EXPECT().List(gomock.Any(), stored.Id).Return(nil, nil)
+		gomock.InOrder(
+			versionRepo.EXPECT().Append(gomock.Any(), gomock.Any(), stored.UpdatedAt, "").DoAndReturn(
+				func(_ context.Context, d *model.DAG, _ time.Time, _ string) (*model.DAGVersion, error) {
+					assert.Equal(t, stored.Id, d.Id)
+					return &model.DAGVersion{Number: 1, DAG: d}, nil
+				},
+			),
+			versionRepo.EXPECT().Append(gomock.Any(), edit, gomock.Any(), userId.String()).Return(&model.DAGVersion{Number: 2}, nil),
+		)
+
+		_, err := NewUpdateDAGUseCase(mockRepo, versionRepo, NewDAGValidator(), nil).Execute(ctx, CmdUpdateDAG{
+			DAGId: stored.Id.String(),
+			DAG:   edit,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("appends a version to an existing history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+		updateStored(mockRepo)
+
+		edit := cloneTestDAG(stored)
+		versionRepo.EXPECT().List(gomock.Any(), stored.Id).Return([]model.DAGVersion{{Number: 1}}, nil)
+		versionRepo.EXPECT().Append(gomock.Any(), edit, gomock.Any(), "").Return(&model.DAGVersion{Number: 2}, nil)
+
+		_, err := NewUpdateDAGUseCase(mockRepo, versionRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateDAG{
+			DAGId: stored.Id.String(),
+			DAG:   edit,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("succeeds when the version cannot be recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		versionRepo := mocks.NewMockDAGVersionRepository(ctrl)
+		updateStored(mockRepo)
+
+		versionRepo.EXPECT().List(gomock.Any(), stored.Id).Return(nil, ErrInternal)
+
+		_, err := NewUpdateDAGUseCase(mockRepo, versionRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateDAG{
+			DAGId: stored.Id.String(),
+			DAG:   cloneTestDAG(stored),
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestUpdateDAGUseCase_Execute_Revision(t *testing.T) {
+	stored := createValidTestDAG()
+	stored.Revision = 3
+	current := uint64(3)
+	outdated := uint64(2)
+
+	t.Run("bumps the revision of a DAG at the expected revision", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		result := updateThrough(mockRepo, stored)
+
+		updated, err := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateDAG{
+			DAGId:      stored.Id.String(),
+			DAG:        cloneTestDAG(stored),
+			IfRevision: &current,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), updated.Revision)
+		assert.Equal(t, uint64(4), result.Revision)
+	})
+
+	t.Run("rejects an update of an outdated revision", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		updateThrough(mockRepo, stored)
+
+		_, err := NewUpdateDAGUseCase(mockRepo, anyVersionRepository(ctrl), NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateDAG{
+			DAGId:      stored.Id.String(),
+			DAG:        cloneTestDAG(stored),
+			IfRevision: &outdated,
+		})
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+	})
+}
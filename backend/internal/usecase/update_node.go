@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// CmdUpdateNode changes the fields of a node which are set, leaving the others untouched
+type CmdUpdateNode struct {
+	DAGId       string  `validate:"required,uuid"`
+	NodeId      string  `validate:"required,uuid"`
+	Question    *string `validate:"omitempty,min=1"`
+	Required    *bool
+	MultiSelect *bool
+	// MultiSelectNext sets the rule resolving the next node of a multi-select node, an empty string resets it to priority
+	MultiSelectNext *string `validate:"omitempty,oneof=priority convergence"`
+	// IfRevision rejects the change when the stored DAG is at another revision, unchecked when nil
+	IfRevision *uint64
+}
+
+// CmdUpdateAnswer changes the fields of an answer which are set, leaving the others untouched
+type CmdUpdateAnswer struct {
+	DAGId     string  `validate:"required,uuid"`
+	NodeId    string  `validate:"required,uuid"`
+	AnswerId  string  `validate:"required,uuid"`
+	Statement *string `validate:"omitempty,min=1"`
+	// NextNode repoints the answer, an empty string makes it terminal
+	NextNode    *string
+	UserContext *string
+	Disabled    *bool
+	// IfRevision rejects the change when the stored DAG is at another revision, unchecked when nil
+	IfRevision *uint64
+}
+
+type UpdateNodeUseCase struct {
+	dagRepository  DAGRepository
+	dagValidator   *DAGValidator
+	eventPublisher EventPublisher
+	validator      *validator.Validate
+}
+
+func NewUpdateNodeUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *UpdateNodeUseCase {
+	return &UpdateNodeUseCase{
+		dagRepository:  dagRepository,
+		dagValidator:   dagValidator,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
+	}
+}
+
+// Execute edits a single node inside the repository update, so that concurrent edits of
+// different nodes of the same DAG do not overwrite each other
+func (u *UpdateNodeUseCase) Execute(ctx context.Context, cmd CmdUpdateNode) (*model.Node, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagId, nodeId, err := parseNodeIds(cmd.DAGId, cmd.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedNode model.Node
+	err = u.updateNode(ctx, dagId, nodeId, cmd.IfRevision, func(node model.Node) (model.Node, error) {
+		if cmd.Question != nil {
+			node.Question = *cmd.Question
+		}
+		if cmd.Required != nil {
+			node.Required = *cmd.Required
+		}
+		if cmd.MultiSelect != nil {
+			node.MultiSelect = *cmd.MultiSelect
+		}
+		if cmd.MultiSelectNext != nil {
+			node.MultiSelectNext = model.MultiSelectNext(*cmd.MultiSelectNext)
+		}
+
+		updatedNode = node
+		return node, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update node: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", dagId.String()).
+		Str("node_id", nodeId.String()).
+		Msg("node updated")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, dagId, time.Now()))
+
+	return &updatedNode, nil
+}
+
+// UpdateAnswer edits a single answer of a node. Repointing the answer re-validates the DAG,
+// rejecting dangling references and cycles.
+func (u *UpdateNodeUseCase) UpdateAnswer(ctx context.Context, cmd CmdUpdateAnswer) (*model.Answer, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagId, nodeId, err := parseNodeIds(cmd.DAGId, cmd.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	answerId, err := uuid.Parse(cmd.AnswerId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	var nextNode *uuid.UUID
+	if cmd.NextNode != nil && *cmd.NextNode != "" {
+		id, err := uuid.Parse(*cmd.NextNode)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid next node UUID format: %s", ErrInvalidCommand, err)
+		}
+		nextNode = &id
+	}
+
+	var updatedAnswer model.Answer
+	err = u.updateNode(ctx, dagId, nodeId, cmd.IfRevision, func(node model.Node) (model.Node, error) {
+		index := -1
+		for i, answer := range node.Answers {
+			if answer.Id == answerId {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return node, fmt.Errorf("%w: answer %s not found in node %s", ErrNotFound, answerId, nodeId)
+		}
+
+		node.Answers = append([]model.Answer(nil), node.Answers...)
+		answer := &node.Answers[index]
+		if cmd.Statement != nil {
+			answer.Statement = *cmd.Statement
+		}
+		if cmd.NextNode != nil {
+			answer.NextNode = nextNode
+		}
+		if cmd.UserContext != nil {
+			answer.UserContext = *cmd.UserContext
+		}
+		if cmd.Disabled != nil {
+			answer.Disabled = *cmd.Disabled
+		}
+
+		updatedAnswer = *answer
+		return node, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update answer: %w", err)
+	}
+
+	xlog.Ctx(ctx).Info().
+		Str("dag_id", dagId.String()).
+		Str("node_id", nodeId.String()).
+		Str("answer_id", answerId.String()).
+		Msg("answer updated")
+
+	publishEvent(ctx, u.eventPublisher, model.NewEvent(model.EventDAGUpdated, dagId, time.Now()))
+
+	return &updatedAnswer, nil
+}
+
+// updateNode applies fnUpdate to a node of the stored DAG and re-validates the resulting DAG
+func (u *UpdateNodeUseCase) updateNode(ctx context.Context, dagId uuid.UUID, nodeId uuid.UUID, ifRevision *uint64, fnUpdate func(node model.Node) (model.Node, error)) error {
+	return u.dagRepository.Update(ctx, dagId, func(existingDAG model.DAG) (model.DAG, error) {
+		if err := checkRevision(existingDAG, ifRevision); err != nil {
+			return existingDAG, err
+		}
+
+		node, ok := existingDAG.Nodes[nodeId]
+		if !ok {
+			return existingDAG, fmt.Errorf("%w: node %s not found in DAG %s", ErrNotFound, nodeId, dagId)
+		}
+
+		node, err := fnUpdate(node)
+		if err != nil {
+			return existingDAG, err
+		}
+
+		// Copy the nodes so the stored DAG is never mutated in place
+		nodes := make(map[uuid.UUID]model.Node, len(existingDAG.Nodes))
+		for id, n := range existingDAG.Nodes {
+			nodes[id] = n
+		}
+		nodes[nodeId] = node
+
+		existingDAG.Nodes = nodes
+		existingDAG.UpdatedAt = time.Now()
+		existingDAG.Revision++
+		result := u.dagValidator.ValidateDAG(&existingDAG)
+		if !result.IsValid {
+			var errorMessages []string
+			for _, err := range result.Errors {
+				errorMessages = append(errorMessages, err.Message)
+			}
+			return existingDAG, fmt.Errorf("%w: DAG validation failed: %v", ErrInvalidCommand, errorMessages)
+		}
+
+		return existingDAG, nil
+	})
+}
+
+func parseNodeIds(rawDAGId string, rawNodeId string) (uuid.UUID, uuid.UUID, error) {
+	dagId, err := uuid.Parse(rawDAGId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	nodeId, err := uuid.Parse(rawNodeId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	return dagId, nodeId, nil
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateThrough makes the mock repository apply update functions to the stored DAG, keeping the result
+func updateThrough(mockRepo *mocks.MockDAGRepository, stored *model.DAG) *model.DAG {
+	result := &model.DAG{}
+	mockRepo.EXPECT().Update(gomock.Any(), stored.Id, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, fn func(model.DAG) (model.DAG, error)) error {
+			updated, err := fn(*stored)
+			if err != nil {
+				return err
+			}
+			*result = updated
+			return nil
+		},
+	)
+	return result
+}
+
+func TestUpdateNodeUseCase_Execute(t *testing.T) {
+	stored := dagtest.ValidSingleRoot()
+	root := dagtest.Root(stored)
+	question := "Reworded root question?"
+	empty := ""
+	multiSelect := true
+
+	t.Run("changes the given fields only", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		result := updateThrough(mockRepo, stored)
+
+		node, err := NewUpdateNodeUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateNode{
+			DAGId:       stored.Id.String(),
+			NodeId:      root.Id.String(),
+			Question:    &question,
+			MultiSelect: &multiSelect,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, question, node.Question)
+		assert.True(t, node.MultiSelect)
+		assert.Equal(t, root.Answers, node.Answers)
+
+		assert.Equal(t, question, result.Nodes[root.Id].Question)
+		assert.False(t, result.UpdatedAt.IsZero())
+		assert.Equal(t, stored.Revision+1, result.Revision)
+		assert.Equal(t, "Root question?", stored.Nodes[root.Id].Question, "stored DAG must not be mutated in place")
+	})
+
+	t.Run("publishes the DAG update", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		updateThrough(mockRepo, stored)
+		publisher := mocks.NewMockEventPublisher(ctrl)
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event model.Event) error {
+			assert.Equal(t, model.EventDAGUpdated, event.Type)
+			assert.Equal(t, stored.Id, event.DAGId)
+			return nil
+		})
+
+		_, err := NewUpdateNodeUseCase(mockRepo, NewDAGValidator(), publisher).Execute(context.Background(), CmdUpdateNode{
+			DAGId:    stored.Id.String(),
+			NodeId:   root.Id.String(),
+			Question: &question,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects a change of an outdated revision", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		updateThrough(mockRepo, stored)
+		outdated := stored.Revision + 1
+
+		_, err := NewUpdateNodeUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateNode{
+			DAGId:      stored.Id.String(),
+			NodeId:     root.Id.String(),
+			Question:   &question,
+			IfRevision: &outdated,
+		})
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+	})
+
+	t.Run("returns not found for unknown node", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+		updateThrough(mockRepo, stored)
+
+		_, err := NewUpdateNodeUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateNode{
+			DAGId:    stored.Id.String(),
+			NodeId:   uuid.NewString(),
+			Question: &question,
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rejects an empty question", func(t *testing.T) {
+		mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+
+		_, err := NewUpdateNodeUseCase(mockRepo, NewDAGValidator(), nil).Execute(context.Background(), CmdUpdateNode{
+			DAGId:    stored.Id.String(),
+			NodeId:   root.Id.String(),
+			Question: &empty,
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
+
+func TestUpdateNodeUseCase_UpdateAnswer(t *testing.T) {
+	stored := dagtest.ValidSingleRoot()
+	root := dagtest.Root(stored)
+	middleId := *root.Answers[0].NextNode
+	leafId := *root.Answers[1].NextNode
+	middle := stored.Nodes[middleId]
+
+	statement := "Reworded"
+	terminal := ""
+	toRoot := root.Id.String()
+	unknown := uuid.NewString()
+	toLeaf := leafId.String()
+	disabled := true
+
+	tests := []struct {
+		name      string
+		nodeId    uuid.UUID
+		answerId  uuid.UUID
+		cmd       CmdUpdateAnswer
+		errorType error
+		check     func(t *testing.T, answer *model.Answer)
+	}{
+		{
+			name:     "changes the statement and disables the answer",
+			nodeId:   root.Id,
+			answerId: root.Answers[0].Id,
+			cmd:      CmdUpdateAnswer{Statement: &statement, Disabled: &disabled},
+			check: func(t *testing.T, answer *model.Answer) {
+				assert.Equal(t, statement, answer.Statement)
+				assert.True(t, answer.Disabled)
+				assert.Equal(t, middleId, *answer.NextNode)
+			},
+		},
+		{
+			name:     "repoints the answer",
+			nodeId:   middle.Id,
+			answerId: middle.Answers[1].Id,
+			cmd:      CmdUpdateAnswer{NextNode: &toLeaf},
+			check: func(t *testing.T, answer *model.Answer) {
+				require.NotNil(t, answer.NextNode)
+				assert.Equal(t, leafId, *answer.NextNode)
+			},
+		},
+		{
+			name:     "makes the answer terminal",
+			nodeId:   middle.Id,
+			answerId: middle.Answers[0].Id,
+			cmd:      CmdUpdateAnswer{NextNode: &terminal},
+			check: func(t *testing.T, answer *model.Answer) {
+				assert.Nil(t, answer.NextNode)
+			},
+		},
+		{
+			name:      "rejects a dangling reference",
+			nodeId:    root.Id,
+			answerId:  root.Answers[0].Id,
+			cmd:       CmdUpdateAnswer{NextNode: &unknown},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "rejects a reference creating a cycle",
+			nodeId:    middle.Id,
+			answerId:  middle.Answers[0].Id,
+			cmd:       CmdUpdateAnswer{NextNode: &toRoot},
+			errorType: ErrInvalidCommand,
+		},
+		{
+			name:      "returns not found for an answer of another node",
+			nodeId:    middle.Id,
+			answerId:  root.Answers[0].Id,
+			cmd:       CmdUpdateAnswer{Statement: &statement},
+			errorType: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockDAGRepository(gomock.NewController(t))
+			result := updateThrough(mockRepo, stored)
+
+			tt.cmd.DAGId = stored.Id.String()
+			tt.cmd.NodeId = tt.nodeId.String()
+			tt.cmd.AnswerId = tt.answerId.String()
+			answer, err := NewUpdateNodeUseCase(mockRepo, NewDAGValidator(), nil).UpdateAnswer(context.Background(), tt.cmd)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, answer)
+
+			for _, stored := range result.Nodes[tt.nodeId].Answers {
+				if stored.Id == tt.answerId {
+					assert.Equal(t, *answer, stored)
+				}
+			}
+		})
+	}
+
+	t.Run("rejects an invalid next node", func(t *testing.T) {
+		invalid := "not-a-uuid"
+		_, err := NewUpdateNodeUseCase(mocks.NewMockDAGRepository(gomock.NewController(t)), NewDAGValidator(), nil).UpdateAnswer(context.Background(), CmdUpdateAnswer{
+			DAGId:    stored.Id.String(),
+			NodeId:   root.Id.String(),
+			AnswerId: root.Answers[0].Id.String(),
+			NextNode: &invalid,
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type CmdUpdateWebhook struct {
+	WebhookId string `validate:"required,uuid"`
+	URL       string `validate:"required,url,max=2048"`
+	// Events replace the subscribed event types, every event type when empty
+	Events []string `validate:"dive,oneof=dag.created dag.updated dag.deleted dag.restored dag.validated session.completed"`
+	// Secret rotates the secret signing the payloads, kept when empty
+	Secret string `validate:"omitempty,min=16,max=256"`
+	// Active is kept when nil
+	Active *bool
+}
+
+type UpdateWebhookUseCase struct {
+	webhookRepository WebhookRepository
+	validator         *validator.Validate
+}
+
+func NewUpdateWebhookUseCase(webhookRepository WebhookRepository) *UpdateWebhookUseCase {
+	return &UpdateWebhookUseCase{
+		webhookRepository: webhookRepository,
+		validator:         validator.New(),
+	}
+}
+
+// Execute replaces the URL and subscriptions of a webhook
+func (u *UpdateWebhookUseCase) Execute(ctx context.Context, cmd CmdUpdateWebhook) (*model.Webhook, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd.WebhookId)
+	}
+	if err := validateWebhookURL(cmd.URL); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(cmd.WebhookId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid UUID format: %s", ErrInvalidCommand, err)
+	}
+
+	var updated model.Webhook
+	err = u.webhookRepository.Update(ctx, id, func(existing model.Webhook) (model.Webhook, error) {
+		existing.URL = cmd.URL
+		existing.Events = cmd.Events
+		if cmd.Secret != "" {
+			existing.Secret = cmd.Secret
+		}
+		if cmd.Active != nil {
+			existing.Active = *cmd.Active
+		}
+		existing.UpdatedAt = time.Now()
+
+		updated = existing
+		return existing, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return &updated, nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateWebhookUseCase_Execute(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	existing := model.Webhook{
+		Id:        uuid.New(),
+		URL:       "https://example.com/hooks",
+		Events:    []string{model.EventDAGCreated},
+		Secret:    "0123456789abcdef",
+		Active:    true,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	inactive := false
+
+	setup := func(t *testing.T) *mocks.MockWebhookRepository {
+		webhookRepo := mocks.NewMockWebhookRepository(gomock.NewController(t))
+		webhookRepo.EXPECT().Update(gomock.Any(), existing.Id, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, fn func(model.Webhook) (model.Webhook, error)) error {
+				_, err := fn(existing)
+				return err
+			},
+		)
+		return webhookRepo
+	}
+
+	t.Run("replaces URL and subscriptions, keeping the secret", func(t *testing.T) {
+		updated, err := NewUpdateWebhookUseCase(setup(t)).Execute(context.Background(), CmdUpdateWebhook{
+			WebhookId: existing.Id.String(),
+			URL:       "https://example.com/v2/hooks",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://example.com/v2/hooks", updated.URL)
+		assert.Empty(t, updated.Events)
+		assert.Equal(t, existing.Secret, updated.Secret)
+		assert.True(t, updated.Active)
+		assert.Equal(t, createdAt, updated.CreatedAt)
+		assert.True(t, updated.UpdatedAt.After(createdAt))
+	})
+
+	t.Run("rotates the secret and deactivates", func(t *testing.T) {
+		updated, err := NewUpdateWebhookUseCase(setup(t)).Execute(context.Background(), CmdUpdateWebhook{
+			WebhookId: existing.Id.String(),
+			URL:       existing.URL,
+			Secret:    "fedcba9876543210",
+			Active:    &inactive,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "fedcba9876543210", updated.Secret)
+		assert.False(t, updated.Active)
+	})
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		useCase := NewUpdateWebhookUseCase(mocks.NewMockWebhookRepository(gomock.NewController(t)))
+
+		_, err := useCase.Execute(context.Background(), CmdUpdateWebhook{WebhookId: "invalid", URL: existing.URL})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+
+		_, err = useCase.Execute(context.Background(), CmdUpdateWebhook{WebhookId: existing.Id.String(), URL: "mailto:legal@example.com"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
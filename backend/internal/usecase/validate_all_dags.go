@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// topErrorCodes is the number of error codes listed for each invalid DAG of a bulk validation report
+const topErrorCodes = 3
+
+// MaxValidationWorkers bounds the DAGs validated in parallel by a bulk validation
+const MaxValidationWorkers = 32
+
+type CmdValidateAllDAGs struct {
+	// Workers is the number of DAGs validated in parallel, one at a time when 0
+	Workers int `validate:"min=0,max=32"`
+}
+
+// BulkValidationReport aggregates the validation of every stored DAG
+type BulkValidationReport struct {
+	Total   int                `json:"total"`
+	Valid   int                `json:"valid"`
+	Invalid []InvalidDAGReport `json:"invalid"`
+	// Failed lists the DAGs which could not be validated, e.g. when their metadata could not be persisted
+	Failed []FailedDAGValidation `json:"failed,omitempty"`
+	// ErrorCodes counts the errors of the invalid DAGs by code, most frequent first
+	ErrorCodes []ErrorCodeCount `json:"error_codes,omitempty"`
+}
+
+// InvalidDAGReport summarizes the errors of an invalid DAG
+type InvalidDAGReport struct {
+	DAGId    uuid.UUID `json:"dag_id"`
+	Title    string    `json:"title"`
+	Errors   int       `json:"errors"`
+	Warnings int       `json:"warnings"`
+	// TopErrorCodes lists the most frequent error codes of the DAG, most frequent first
+	TopErrorCodes []string `json:"top_error_codes"`
+}
+
+// FailedDAGValidation is a DAG which could not be validated
+type FailedDAGValidation struct {
+	DAGId uuid.UUID `json:"dag_id"`
+	Error string    `json:"error"`
+}
+
+// ErrorCodeCount is the number of errors of a code
+type ErrorCodeCount struct {
+	Code  string `json:"code"`
+	Count int    `json:"count"`
+}
+
+type ValidateAllDAGsUseCase struct {
+	dagRepository     DAGRepository
+	validateStoredDAG *ValidateStoredDAGUseCase
+	validator         *validator.Validate
+}
+
+func NewValidateAllDAGsUseCase(dagRepository DAGRepository, validateStoredDAG *ValidateStoredDAGUseCase) *ValidateAllDAGsUseCase {
+	return &ValidateAllDAGsUseCase{
+		dagRepository:     dagRepository,
+		validateStoredDAG: validateStoredDAG,
+		validator:         validator.New(),
+	}
+}
+
+// Execute validates every stored DAG, persisting its validation metadata, and reports the invalid ones.
+// DAGs deleted while the validation runs are skipped.
+func (u *ValidateAllDAGsUseCase) Execute(ctx context.Context, cmd CmdValidateAllDAGs) (*BulkValidationReport, error) {
+	err := u.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrInvalidCommand, err, cmd)
+	}
+
+	dagIds, err := u.dagRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DAGs: %w", err)
+	}
+
+	report := &BulkValidationReport{Invalid: []InvalidDAGReport{}}
+	errorCodes := make(map[string]int)
+	var mu sync.Mutex
+	record := func(id uuid.UUID, title string, result *ValidationResult, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return
+		case err != nil:
+			report.Failed = append(report.Failed, FailedDAGValidation{DAGId: id, Error: err.Error()})
+		case result.IsValid:
+			report.Valid++
+		default:
+			codes := make(map[string]int)
+			for _, validationErr := range result.Errors {
+				codes[validationErr.Code]++
+				errorCodes[validationErr.Code]++
+			}
+			report.Invalid = append(report.Invalid, InvalidDAGReport{
+				DAGId:         id,
+				Title:         title,
+				Errors:        len(result.Errors),
+				Warnings:      len(result.Warnings),
+				TopErrorCodes: topCodes(codes, topErrorCodes),
+			})
+		}
+		report.Total++
+	}
+
+	ids := make(chan uuid.UUID)
+	var wg sync.WaitGroup
+	for range max(cmd.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				title, result, err := u.validate(ctx, id)
+				record(id, title, result, err)
+			}
+		}()
+	}
+
+	for _, id := range dagIds {
+		if ctx.Err() != nil {
+			break
+		}
+		ids <- id
+	}
+	close(ids)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(report.Invalid, func(i, j int) bool { return report.Invalid[i].DAGId.String() < report.Invalid[j].DAGId.String() })
+	sort.Slice(report.Failed, func(i, j int) bool { return report.Failed[i].DAGId.String() < report.Failed[j].DAGId.String() })
+	for _, code := range topCodes(errorCodes, len(errorCodes)) {
+		report.ErrorCodes = append(report.ErrorCodes, ErrorCodeCount{Code: code, Count: errorCodes[code]})
+	}
+
+	xlog.Ctx(ctx).Info().
+		Int("total", report.Total).
+		Int("valid", report.Valid).
+		Int("invalid", len(report.Invalid)).
+		Int("failed", len(report.Failed)).
+		Msg("DAGs validated")
+
+	return report, nil
+}
+
+// validate validates a stored DAG through the ValidateStoredDAG use case, which persists its metadata
+func (u *ValidateAllDAGsUseCase) validate(ctx context.Context, id uuid.UUID) (string, *ValidationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+
+	dag, err := u.dagRepository.Get(ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	result, err := u.validateStoredDAG.Execute(ctx, CmdValidateStoredDAG{DAGId: id.String()})
+	if err != nil {
+		return "", nil, err
+	}
+	return dag.Title, result, nil
+}
+
+// topCodes returns at most n codes, most frequent first and in alphabetical order among equally frequent ones
+func topCodes(counts map[string]int, n int) []string {
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if counts[codes[i]] != counts[codes[j]] {
+			return counts[codes[i]] > counts[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+
+	if len(codes) > n {
+		codes = codes[:n]
+	}
+	return codes
+}
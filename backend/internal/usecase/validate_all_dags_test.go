@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStoredDAGsRepository returns a mocked repository serving the given DAGs and applying updates to them
+func newStoredDAGsRepository(ctrl *gomock.Controller, dags ...*model.DAG) (*mocks.MockDAGRepository, func(uuid.UUID) model.DAG) {
+	var mu sync.Mutex
+	stored := make(map[uuid.UUID]model.DAG, len(dags))
+	ids := make([]uuid.UUID, 0, len(dags))
+	for _, d := range dags {
+		stored[d.Id] = *d
+		ids = append(ids, d.Id)
+	}
+
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().List(gomock.Any()).Return(ids, nil).AnyTimes()
+	mockRepo.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uuid.UUID) (*model.DAG, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		current, ok := stored[id]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return &current, nil
+	}).AnyTimes()
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+			mu.Lock()
+			defer mu.Unlock()
+			updated, err := fnUpdate(stored[id])
+			if err != nil {
+				return err
+			}
+			stored[id] = updated
+			return nil
+		},
+	).AnyTimes()
+
+	return mockRepo, func(id uuid.UUID) model.DAG {
+		mu.Lock()
+		defer mu.Unlock()
+		return stored[id]
+	}
+}
+
+func TestValidateAllDAGsUseCase_Execute(t *testing.T) {
+	newDAGs := func() []*model.DAG {
+		valid := dagtest.ValidSingleRoot()
+		valid.Metadata = model.NewDAGMetadata()
+		forest := dagtest.MultipleRoots()
+		forest.Metadata = model.NewDAGMetadata()
+		untitled := dagtest.MultipleRoots()
+		untitled.Title = ""
+		untitled.Metadata = model.NewDAGMetadata()
+		return []*model.DAG{valid, forest, untitled}
+	}
+
+	for _, workers := range []int{0, 1, 4} {
+		t.Run(fmt.Sprintf("validates every DAG with %d workers", workers), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dags := newDAGs()
+			mockRepo, current := newStoredDAGsRepository(ctrl, dags...)
+
+			report, err := NewValidateAllDAGsUseCase(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil)).
+				Execute(context.Background(), CmdValidateAllDAGs{Workers: workers})
+			require.NoError(t, err)
+
+			assert.Equal(t, 3, report.Total)
+			assert.Equal(t, 1, report.Valid)
+			require.Len(t, report.Invalid, 2)
+			assert.Empty(t, report.Failed)
+			for _, invalid := range report.Invalid {
+				assert.Contains(t, invalid.TopErrorCodes, "DAG_MULTIPLE_ROOTS")
+				assert.LessOrEqual(t, len(invalid.TopErrorCodes), topErrorCodes)
+			}
+			require.NotEmpty(t, report.ErrorCodes)
+			assert.Equal(t, ErrorCodeCount{Code: "DAG_MULTIPLE_ROOTS", Count: 2}, report.ErrorCodes[0])
+
+			// The validation metadata is persisted on every DAG
+			for _, d := range dags {
+				assert.False(t, current(d.Id).Metadata.LastValidatedAt.IsZero())
+			}
+			assert.True(t, current(dags[0].Id).Metadata.IsValid)
+			assert.False(t, current(dags[1].Id).Metadata.IsValid)
+		})
+	}
+
+	t.Run("skips DAGs deleted since listed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		valid := dagtest.ValidSingleRoot()
+		valid.Metadata = model.NewDAGMetadata()
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		deleted := uuid.New()
+		mockRepo.EXPECT().List(gomock.Any()).Return([]uuid.UUID{valid.Id, deleted}, nil)
+		mockRepo.EXPECT().Get(gomock.Any(), deleted).Return(nil, ErrNotFound)
+		mockRepo.EXPECT().Get(gomock.Any(), valid.Id).Return(valid, nil).Times(2)
+		mockRepo.EXPECT().Update(gomock.Any(), valid.Id, gomock.Any()).Return(nil)
+
+		report, err := NewValidateAllDAGsUseCase(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil)).
+			Execute(context.Background(), CmdValidateAllDAGs{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Total)
+		assert.Equal(t, 1, report.Valid)
+	})
+
+	t.Run("reports the DAGs which could not be validated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		valid := dagtest.ValidSingleRoot()
+		valid.Metadata = model.NewDAGMetadata()
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		mockRepo.EXPECT().List(gomock.Any()).Return([]uuid.UUID{valid.Id}, nil)
+		mockRepo.EXPECT().Get(gomock.Any(), valid.Id).Return(valid, nil).Times(2)
+		mockRepo.EXPECT().Update(gomock.Any(), valid.Id, gomock.Any()).Return(assert.AnError)
+
+		report, err := NewValidateAllDAGsUseCase(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil)).
+			Execute(context.Background(), CmdValidateAllDAGs{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Total)
+		require.Len(t, report.Failed, 1)
+		assert.Equal(t, valid.Id, report.Failed[0].DAGId)
+	})
+
+	t.Run("rejects too many workers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewValidateAllDAGsUseCase(mocks.NewMockDAGRepository(ctrl), nil).
+			Execute(context.Background(), CmdValidateAllDAGs{Workers: MaxValidationWorkers + 1})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo, _ := newStoredDAGsRepository(ctrl, newDAGs()...)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := NewValidateAllDAGsUseCase(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil)).
+			Execute(ctx, CmdValidateAllDAGs{Workers: 2})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
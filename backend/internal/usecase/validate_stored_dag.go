@@ -15,14 +15,19 @@ type CmdValidateStoredDAG struct {
 }
 
 type ValidateStoredDAGUseCase struct {
-	dagRepository DAGRepository
-	validator     *validator.Validate
+	dagRepository  DAGRepository
+	dagValidator   *DAGValidator
+	eventPublisher EventPublisher
+	validator      *validator.Validate
 }
 
-func NewValidateStoredDAGUseCase(dagRepository DAGRepository) *ValidateStoredDAGUseCase {
+// NewValidateStoredDAGUseCase returns the use case validating stored DAGs, eventPublisher may be nil
+func NewValidateStoredDAGUseCase(dagRepository DAGRepository, dagValidator *DAGValidator, eventPublisher EventPublisher) *ValidateStoredDAGUseCase {
 	return &ValidateStoredDAGUseCase{
-		dagRepository: dagRepository,
-		validator:     validator.New(),
+		dagRepository:  dagRepository,
+		dagValidator:   dagValidator,
+		eventPublisher: eventPublisher,
+		validator:      validator.New(),
 	}
 }
 
@@ -47,41 +52,66 @@ func (u *ValidateStoredDAGUseCase) Execute(ctx context.Context, cmd CmdValidateS
 	}
 
 	// Validate the DAG
-	dagValidator := NewDAGValidator()
-	validationResult := dagValidator.ValidateDAG(dag)
+	validationResult := u.dagValidator.ValidateDAG(dag)
 
 	// Update DAG metadata with validation results and persist
+	validatedAt := time.Now()
 	err = u.dagRepository.Update(ctx, id, func(existingDAG model.DAG) (model.DAG, error) {
-		// Initialize metadata if it doesn't exist
-		if existingDAG.Metadata == nil {
-			existingDAG.Metadata = model.NewDAGMetadata()
-		}
-
-		// Update metadata with validation results
-		existingDAG.Metadata.IsValid = validationResult.IsValid
-		existingDAG.Metadata.Statistics = u.convertValidationStatsToModel(validationResult.Statistics)
-		existingDAG.Metadata.LastValidatedAt = time.Now()
-
+		recordValidation(&existingDAG, validationResult, validatedAt)
 		return existingDAG, nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to persist validation metadata: %w", err)
 	}
 
+	event := model.NewEvent(model.EventDAGValidated, id, validatedAt)
+	event.Data = map[string]interface{}{
+		"is_valid": validationResult.IsValid,
+		"errors":   len(validationResult.Errors),
+		"warnings": len(validationResult.Warnings),
+	}
+	publishEvent(ctx, u.eventPublisher, event)
+
 	return &validationResult, nil
 }
 
+// RunJob is the handler of the model.JobTypeValidateDAG jobs, their result is the validation of their DAG
+func (u *ValidateStoredDAGUseCase) RunJob(ctx context.Context, job model.Job) (interface{}, error) {
+	return u.Execute(ctx, CmdValidateStoredDAG{DAGId: job.DAGId.String()})
+}
+
+// recordValidation updates the validation metadata of a DAG with the result of its validation
+func recordValidation(d *model.DAG, result ValidationResult, validatedAt time.Time) {
+	// Initialize metadata if it doesn't exist
+	if d.Metadata == nil {
+		d.Metadata = model.NewDAGMetadata()
+	}
+
+	d.Metadata.IsValid = result.IsValid
+	d.Metadata.Statistics = convertValidationStatsToModel(result.Statistics)
+	d.Metadata.LastValidatedAt = validatedAt
+}
+
 // convertValidationStatsToModel converts usecase ValidationStatistics to model ValidationStatistics
-func (u *ValidateStoredDAGUseCase) convertValidationStatsToModel(stats ValidationStatistics) model.ValidationStatistics {
+func convertValidationStatsToModel(stats ValidationStatistics) model.ValidationStatistics {
 	return model.ValidationStatistics{
-		TotalNodes:   stats.TotalNodes,
-		RootNodes:    stats.RootNodes,
-		LeafNodes:    stats.LeafNodes,
-		TotalAnswers: stats.TotalAnswers,
-		MaxDepth:     stats.MaxDepth,
-		HasCycles:    stats.HasCycles,
-		RootNodeIDs:  stats.RootNodeIDs,
-		LeafNodeIDs:  stats.LeafNodeIDs,
-		CyclePaths:   stats.CyclePaths,
+		TotalNodes:             stats.TotalNodes,
+		RootNodes:              stats.RootNodes,
+		LeafNodes:              stats.LeafNodes,
+		TotalAnswers:           stats.TotalAnswers,
+		MaxDepth:               stats.MaxDepth,
+		HasCycles:              stats.HasCycles,
+		AvgBranchingFactor:     stats.AvgBranchingFactor,
+		TotalEstimatedDamages:  stats.TotalEstimatedDamages,
+		TotalEstimatedDuration: stats.TotalEstimatedDuration,
+		BranchingHistogram:     stats.BranchingHistogram,
+		RootNodeIDs:            stats.RootNodeIDs,
+		LeafNodeIDs:            stats.LeafNodeIDs,
+		CyclePaths:             stats.CyclePaths,
+		TotalPaths:             stats.TotalPaths,
+		MinPathLength:          stats.MinPathLength,
+		MaxPathLength:          stats.MaxPathLength,
+		AvgPathLength:          stats.AvgPathLength,
+		LeafPaths:              stats.LeafPaths,
 	}
 }
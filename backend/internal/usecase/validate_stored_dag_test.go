@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
 	"davidterranova/jurigen/backend/internal/model"
 	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
 	"testing"
@@ -18,7 +19,7 @@ func TestValidateStoredDAGUseCase_Execute(t *testing.T) {
 	dagId := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
 
 	// Create a test DAG without metadata initially
-	testDAG := createValidTestDAGForValidation()
+	testDAG := dagtest.ValidSingleRoot()
 	testDAG.Id = dagId
 	testDAG.Metadata = nil // Start without metadata
 
@@ -154,7 +155,7 @@ func TestValidateStoredDAGUseCase_Execute(t *testing.T) {
 			mockRepo := mocks.NewMockDAGRepository(ctrl)
 			tt.setupMocks(mockRepo)
 
-			useCase := NewValidateStoredDAGUseCase(mockRepo)
+			useCase := NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil)
 			result, err := useCase.Execute(ctx, tt.cmd)
 
 			tt.expectedResult(t, result, err)
@@ -162,8 +163,7 @@ func TestValidateStoredDAGUseCase_Execute(t *testing.T) {
 	}
 }
 
-func TestValidateStoredDAGUseCase_ConvertValidationStatsToModel(t *testing.T) {
-	useCase := &ValidateStoredDAGUseCase{}
+func TestConvertValidationStatsToModel(t *testing.T) {
 
 	usecaseStats := ValidationStatistics{
 		TotalNodes:   5,
@@ -174,10 +174,10 @@ func TestValidateStoredDAGUseCase_ConvertValidationStatsToModel(t *testing.T) {
 		HasCycles:    false,
 		RootNodeIDs:  []string{"root-1"},
 		LeafNodeIDs:  []string{"leaf-1", "leaf-2"},
-		CyclePaths:   []string{},
+		CyclePaths:   []model.CyclePath{},
 	}
 
-	modelStats := useCase.convertValidationStatsToModel(usecaseStats)
+	modelStats := convertValidationStatsToModel(usecaseStats)
 
 	assert.Equal(t, usecaseStats.TotalNodes, modelStats.TotalNodes)
 	assert.Equal(t, usecaseStats.RootNodes, modelStats.RootNodes)
@@ -195,7 +195,7 @@ func TestValidateStoredDAGUseCase_UpdateExistingMetadata(t *testing.T) {
 	dagId := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
 
 	// Create a test DAG with existing metadata
-	testDAG := createValidTestDAGForValidation()
+	testDAG := dagtest.ValidSingleRoot()
 	testDAG.Id = dagId
 	testDAG.Metadata = &model.DAGMetadata{
 		IsValid:         false,                                     // Previously invalid
@@ -225,7 +225,7 @@ func TestValidateStoredDAGUseCase_UpdateExistingMetadata(t *testing.T) {
 			return nil
 		})
 
-	useCase := NewValidateStoredDAGUseCase(mockRepo)
+	useCase := NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil)
 	result, err := useCase.Execute(ctx, CmdValidateStoredDAG{
 		DAGId: dagId.String(),
 	})
@@ -235,52 +235,25 @@ func TestValidateStoredDAGUseCase_UpdateExistingMetadata(t *testing.T) {
 	assert.True(t, result.IsValid)
 }
 
-// Helper function to create a valid test DAG for validation testing
-func createValidTestDAGForValidation() *model.DAG {
-	rootNodeId := uuid.New()
-	leafNodeId := uuid.New()
-
-	dag := model.NewDAG("Test Valid DAG")
-
-	rootNode := model.Node{
-		Id:       rootNodeId,
-		Question: "Is this a test?",
-		Answers: []model.Answer{
-			{
-				Id:        uuid.New(),
-				Statement: "Yes",
-				NextNode:  &leafNodeId,
-			},
-			{
-				Id:        uuid.New(),
-				Statement: "No",
-				NextNode:  nil,
-			},
-		},
-	}
-
-	leafNode := model.Node{
-		Id:       leafNodeId,
-		Question: "Final question?",
-		Answers: []model.Answer{
-			{
-				Id:        uuid.New(),
-				Statement: "Done",
-				NextNode:  nil,
-			},
-		},
-	}
-
-	// Set parent pointers
-	for i := range rootNode.Answers {
-		rootNode.Answers[i].ParentNode = &rootNode
-	}
-	for i := range leafNode.Answers {
-		leafNode.Answers[i].ParentNode = &leafNode
-	}
+func TestValidateStoredDAGUseCase_PublishesEvent(t *testing.T) {
+	ctx := context.Background()
+	testDAG := dagtest.ValidSingleRoot()
 
-	dag.Nodes[rootNodeId] = rootNode
-	dag.Nodes[leafNodeId] = leafNode
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().Get(ctx, testDAG.Id).Return(testDAG, nil)
+	mockRepo.EXPECT().Update(ctx, testDAG.Id, gomock.Any()).Return(nil)
+
+	// A failure to publish the event does not fail the validation
+	publisher := mocks.NewMockEventPublisher(ctrl)
+	publisher.EXPECT().Publish(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event model.Event) error {
+		assert.Equal(t, model.EventDAGValidated, event.Type)
+		assert.Equal(t, testDAG.Id, event.DAGId)
+		assert.Equal(t, true, event.Data["is_valid"])
+		return assert.AnError
+	})
 
-	return dag
+	result, err := NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), publisher).Execute(ctx, CmdValidateStoredDAG{DAGId: testDAG.Id.String()})
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
 }
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/model"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/google/uuid"
+)
+
+// ValidationProfile bundles the validation rules applying to a given legal domain.
+// Zero values disable the corresponding rule, so the zero profile matches the default validator.
+type ValidationProfile struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Limits
+	MaxNodes          int `json:"max_nodes,omitempty" yaml:"max_nodes,omitempty"`
+	MaxAnswersPerNode int `json:"max_answers_per_node,omitempty" yaml:"max_answers_per_node,omitempty"`
+	MaxDepth          int `json:"max_depth,omitempty" yaml:"max_depth,omitempty"`
+
+	// AllowForest accepts DAGs with several root nodes instead of exactly one
+	AllowForest bool `json:"allow_forest,omitempty" yaml:"allow_forest,omitempty"`
+	// RequireTree rejects nodes reached by more than one answer
+	RequireTree bool `json:"require_tree,omitempty" yaml:"require_tree,omitempty"`
+
+	// AllowedTags restricts the vocabulary of the "tags" answer metadata, empty allows any tag
+	AllowedTags []string `json:"allowed_tags,omitempty" yaml:"allowed_tags,omitempty"`
+	// RequiredMetadata lists the metadata keys every answer must carry (e.g. evidence)
+	RequiredMetadata []string `json:"required_metadata,omitempty" yaml:"required_metadata,omitempty"`
+
+	// MaxMetadataBytes warns about answers whose JSON encoded metadata exceeds the size
+	MaxMetadataBytes int `json:"max_metadata_bytes,omitempty" yaml:"max_metadata_bytes,omitempty"`
+
+	// MinConfidence warns about answers whose "confidence" metadata is below the threshold
+	MinConfidence float64 `json:"min_confidence,omitempty" yaml:"min_confidence,omitempty"`
+
+	// Rules overrides the severity of the validation rules by code, e.g. DAG_MULTIPLE_ROOTS: warning for draft DAGs
+	Rules map[string]Severity `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// DefaultValidationProfile returns the profile used when none is configured
+func DefaultValidationProfile() ValidationProfile {
+	return ValidationProfile{Name: "default"}
+}
+
+// Check reports the rule overrides of the profile naming unknown rules or severities
+func (p ValidationProfile) Check() error {
+	return checkRules(p.Rules)
+}
+
+// WithRules returns a copy of the profile whose rule overrides are replaced by rules where they overlap
+func (p ValidationProfile) WithRules(rules map[string]Severity) ValidationProfile {
+	merged := make(map[string]Severity, len(p.Rules)+len(rules))
+	maps.Copy(merged, p.Rules)
+	maps.Copy(merged, rules)
+	p.Rules = merged
+	return p
+}
+
+// severity returns the severity of a rule in the profile, its default severity when the profile does not override
+// it, and the severity its issue was reported with for issues outside the registry
+func (p ValidationProfile) severity(code string, reported Severity) Severity {
+	if severity, ok := p.Rules[code]; ok {
+		return severity
+	}
+	if rule, ok := lookupValidationRule(code); ok {
+		return rule.Severity
+	}
+	return reported
+}
+
+// validateProfile enforces the domain specific rules of the validator profile
+func (v *DAGValidator) validateProfile(d *model.DAG, result *ValidationResult) {
+	v.validateLimits(d, result)
+	if v.profile.RequireTree {
+		v.validateTree(d, result)
+	}
+	v.validateAnswerMetadata(d, result)
+}
+
+// validateLimits checks the DAG size against the profile limits
+func (v *DAGValidator) validateLimits(d *model.DAG, result *ValidationResult) {
+	if v.profile.MaxNodes > 0 && len(d.Nodes) > v.profile.MaxNodes {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:     "DAG_TOO_MANY_NODES",
+			Message:  fmt.Sprintf("DAG has %d nodes, profile %s allows at most %d", len(d.Nodes), v.profile.Name, v.profile.MaxNodes),
+			Severity: "error",
+		})
+	}
+
+	if v.profile.MaxAnswersPerNode > 0 {
+		for _, node := range d.Nodes {
+			if len(node.Answers) > v.profile.MaxAnswersPerNode {
+				result.IsValid = false
+				result.Errors = append(result.Errors, ValidationError{
+					Code:     "NODE_TOO_MANY_ANSWERS",
+					Message:  fmt.Sprintf("node %s has %d answers, profile %s allows at most %d", node.Id, len(node.Answers), v.profile.Name, v.profile.MaxAnswersPerNode),
+					NodeID:   node.Id.String(),
+					Severity: "error",
+				})
+			}
+		}
+	}
+
+	if v.profile.MaxDepth > 0 && !result.Statistics.HasCycles {
+		maxDepth := 0
+		for _, rootNodeID := range result.Statistics.RootNodeIDs {
+			maxDepth = max(maxDepth, v.calculateMaxDepth(d, rootNodeID))
+		}
+
+		if maxDepth > v.profile.MaxDepth {
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "DAG_TOO_DEEP",
+				Message:  fmt.Sprintf("DAG has a depth of %d, profile %s allows at most %d", maxDepth, v.profile.Name, v.profile.MaxDepth),
+				Severity: "error",
+			})
+		}
+	}
+}
+
+// validateTree rejects nodes reached by more than one answer
+func (v *DAGValidator) validateTree(d *model.DAG, result *ValidationResult) {
+	parents := make(map[uuid.UUID]int)
+	for _, node := range d.Nodes {
+		for _, answer := range node.Answers {
+			for _, target := range answer.Targets() {
+				parents[target]++
+			}
+		}
+	}
+
+	for nodeId, count := range parents {
+		if count > 1 {
+			result.IsValid = false
+			result.Errors = append(result.Errors, ValidationError{
+				Code:     "NODE_MULTIPLE_PARENTS",
+				Message:  fmt.Sprintf("node %s is reached by %d answers, profile %s requires a tree", nodeId, count, v.profile.Name),
+				NodeID:   nodeId.String(),
+				Severity: "error",
+			})
+		}
+	}
+}
+
+// validateAnswerMetadata checks answer metadata against the profile vocabulary, schema and thresholds
+func (v *DAGValidator) validateAnswerMetadata(d *model.DAG, result *ValidationResult) {
+	allowedTags := make(map[string]bool, len(v.profile.AllowedTags))
+	for _, tag := range v.profile.AllowedTags {
+		allowedTags[tag] = true
+	}
+
+	for _, node := range d.Nodes {
+		for _, answer := range node.Answers {
+			for _, key := range v.profile.RequiredMetadata {
+				if _, ok := answer.Metadata[key]; !ok {
+					result.IsValid = false
+					result.Errors = append(result.Errors, ValidationError{
+						Code:     "ANSWER_MISSING_METADATA",
+						Message:  fmt.Sprintf("answer %s in node %s is missing required metadata %q", answer.Id, node.Id, key),
+						NodeID:   node.Id.String(),
+						AnswerID: answer.Id.String(),
+						Severity: "error",
+					})
+				}
+			}
+
+			if len(allowedTags) > 0 {
+				for _, tag := range answerTags(answer) {
+					if !allowedTags[tag] {
+						result.IsValid = false
+						result.Errors = append(result.Errors, ValidationError{
+							Code:     "ANSWER_TAG_NOT_ALLOWED",
+							Message:  fmt.Sprintf("answer %s in node %s uses tag %q not allowed by profile %s", answer.Id, node.Id, tag, v.profile.Name),
+							NodeID:   node.Id.String(),
+							AnswerID: answer.Id.String(),
+							Severity: "error",
+						})
+					}
+				}
+			}
+
+			v.validateMetadataSize(node, answer, result)
+
+			if confidence, ok := answer.Metadata["confidence"].(float64); ok && confidence < v.profile.MinConfidence {
+				result.Warnings = append(result.Warnings, ValidationWarning{
+					Code:     "ANSWER_LOW_CONFIDENCE",
+					Message:  fmt.Sprintf("answer %s in node %s has confidence %.2f below threshold %.2f", answer.Id, node.Id, confidence, v.profile.MinConfidence),
+					NodeID:   node.Id.String(),
+					AnswerID: answer.Id.String(),
+				})
+			}
+		}
+	}
+}
+
+// validateMetadataSize warns about answers whose metadata exceeds the profile size limit
+func (v *DAGValidator) validateMetadataSize(node model.Node, answer model.Answer, result *ValidationResult) {
+	if v.profile.MaxMetadataBytes <= 0 || len(answer.Metadata) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(answer.Metadata)
+	if err != nil || len(data) <= v.profile.MaxMetadataBytes {
+		return
+	}
+
+	result.Warnings = append(result.Warnings, ValidationWarning{
+		Code:     "ANSWER_METADATA_TOO_LARGE",
+		Message:  fmt.Sprintf("answer %s in node %s has %d bytes of metadata, profile %s recommends at most %d", answer.Id, node.Id, len(data), v.profile.Name, v.profile.MaxMetadataBytes),
+		NodeID:   node.Id.String(),
+		AnswerID: answer.Id.String(),
+	})
+}
+
+// answerTags returns the "tags" metadata of an answer, whether decoded from JSON or set in memory
+func answerTags(answer model.Answer) []string {
+	switch tags := answer.Metadata["tags"].(type) {
+	case []string:
+		return tags
+	case []interface{}:
+		result := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
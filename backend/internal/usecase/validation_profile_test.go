@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDAGValidatorFromProfile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		profile              ValidationProfile
+		dag                  func() *model.DAG
+		expectValid          bool
+		expectedErrorCodes   []string
+		expectedWarningCodes []string
+	}{
+		{
+			name:        "default profile accepts a valid DAG",
+			profile:     DefaultValidationProfile(),
+			dag:         dagtest.ValidSingleRoot,
+			expectValid: true,
+		},
+		{
+			name:        "forest profile accepts several roots",
+			profile:     ValidationProfile{Name: "forest", AllowForest: true},
+			dag:         dagtest.MultipleRoots,
+			expectValid: true,
+		},
+		{
+			name:               "tree profile rejects shared children",
+			profile:            ValidationProfile{Name: "tree", RequireTree: true},
+			dag:                dagtest.ValidSingleRoot,
+			expectValid:        false,
+			expectedErrorCodes: []string{"NODE_MULTIPLE_PARENTS"},
+		},
+		{
+			name:               "limits reject oversized DAGs",
+			profile:            ValidationProfile{Name: "small", MaxNodes: 2, MaxAnswersPerNode: 1},
+			dag:                dagtest.ValidSingleRoot,
+			expectValid:        false,
+			expectedErrorCodes: []string{"DAG_TOO_MANY_NODES", "NODE_TOO_MANY_ANSWERS"},
+		},
+		{
+			name:               "depth limit rejects deep DAGs",
+			profile:            ValidationProfile{Name: "shallow", MaxDepth: 2},
+			dag:                func() *model.DAG { return dagtest.LinearChain(5) },
+			expectValid:        false,
+			expectedErrorCodes: []string{"DAG_TOO_DEEP"},
+		},
+		{
+			name:               "required metadata rejects answers without evidence",
+			profile:            ValidationProfile{Name: "evidence", RequiredMetadata: []string{"evidence"}},
+			dag:                dagtest.ValidSingleRoot,
+			expectValid:        false,
+			expectedErrorCodes: []string{"ANSWER_MISSING_METADATA"},
+		},
+		{
+			name:    "allowed tags reject tags outside the vocabulary",
+			profile: ValidationProfile{Name: "tags", AllowedTags: []string{"contract"}},
+			dag: func() *model.DAG {
+				return createTaggedDAG(map[string]interface{}{"tags": []interface{}{"contract", "tort"}})
+			},
+			expectValid:        false,
+			expectedErrorCodes: []string{"ANSWER_TAG_NOT_ALLOWED"},
+		},
+		{
+			name:    "confidence threshold warns about uncertain answers",
+			profile: ValidationProfile{Name: "confidence", MinConfidence: 0.5},
+			dag: func() *model.DAG {
+				return createTaggedDAG(map[string]interface{}{"confidence": 0.2})
+			},
+			expectValid:          true,
+			expectedWarningCodes: []string{"ANSWER_LOW_CONFIDENCE"},
+		},
+		{
+			name:    "metadata size limit warns about large metadata",
+			profile: ValidationProfile{Name: "compact", MaxMetadataBytes: 64},
+			dag: func() *model.DAG {
+				return createTaggedDAG(map[string]interface{}{"document": strings.Repeat("x", 1024)})
+			},
+			expectValid:          true,
+			expectedWarningCodes: []string{"ANSWER_METADATA_TOO_LARGE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			validator := NewDAGValidatorFromProfile(tt.profile)
+			assert.Equal(t, tt.profile, validator.Profile())
+
+			result := validator.ValidateDAG(tt.dag())
+			assert.Equal(t, tt.expectValid, result.IsValid, "errors: %v", result.Errors)
+
+			errorCodes := make([]string, len(result.Errors))
+			for i, err := range result.Errors {
+				errorCodes[i] = err.Code
+			}
+			for _, code := range tt.expectedErrorCodes {
+				assert.Contains(t, errorCodes, code)
+			}
+
+			warningCodes := make([]string, len(result.Warnings))
+			for i, warning := range result.Warnings {
+				warningCodes[i] = warning.Code
+			}
+			for _, code := range tt.expectedWarningCodes {
+				assert.Contains(t, warningCodes, code)
+			}
+		})
+	}
+}
+
+func TestDAGValidator_MetadataSize(t *testing.T) {
+	t.Parallel()
+
+	d := createTaggedDAG(map[string]interface{}{"document": strings.Repeat("x", 1024)})
+	root := dagtest.Root(d)
+	answer := root.Answers[0]
+
+	t.Run("warns with answer ID and size above the limit", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidatorFromProfile(ValidationProfile{Name: "compact", MaxMetadataBytes: 100}).ValidateDAG(d)
+
+		require.Len(t, result.Warnings, 1)
+		warning := result.Warnings[0]
+		assert.Equal(t, "ANSWER_METADATA_TOO_LARGE", warning.Code)
+		assert.Equal(t, answer.Id.String(), warning.AnswerID)
+		assert.Equal(t, root.Id.String(), warning.NodeID)
+		assert.Contains(t, warning.Message, "1039 bytes")
+		assert.True(t, result.IsValid)
+	})
+
+	t.Run("ignores metadata within the limit", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidatorFromProfile(ValidationProfile{Name: "large", MaxMetadataBytes: 4096}).ValidateDAG(d)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("default profile does not limit metadata size", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidator().ValidateDAG(d)
+		assert.Empty(t, result.Warnings)
+	})
+}
+
+func createTaggedDAG(metadata map[string]interface{}) *model.DAG {
+	rootID := uuid.New()
+	leafID := uuid.New()
+
+	return &model.DAG{
+		Id:    uuid.New(),
+		Title: "Tagged DAG",
+		Nodes: map[uuid.UUID]model.Node{
+			rootID: {
+				Id:       rootID,
+				Question: "Root question?",
+				Type:     model.NodeTypeInformation,
+				Answers: []model.Answer{
+					{
+						Id:        uuid.New(),
+						Statement: "Go to leaf",
+						NextNode:  &leafID,
+						Metadata:  metadata,
+					},
+				},
+			},
+			leafID: {
+				Id:       leafID,
+				Question: "Leaf question?",
+				Answers:  []model.Answer{},
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Severity of a validation rule: its issues are reported as errors, which make the DAG invalid, as warnings,
+// or not at all
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityOff     Severity = "off"
+)
+
+// ValidationRule is a check of the DAG validator, named by the code of the issues it reports
+type ValidationRule struct {
+	Code        string   `json:"code"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+}
+
+// validationRules registers the rules of the validator with their default severity, which the profiles override.
+// A new check only needs an entry here to be configurable.
+var validationRules = []ValidationRule{
+	{Code: "DAG_INVALID_ID", Severity: SeverityError, Description: "The DAG has no ID"},
+	{Code: "DAG_EMPTY_TITLE", Severity: SeverityError, Description: "The DAG has no title"},
+	{Code: "DAG_NO_NODES", Severity: SeverityError, Description: "The DAG has no node"},
+	{Code: "DAG_NO_ROOT", Severity: SeverityError, Description: "Every node is the target of an answer"},
+	{Code: "DAG_MULTIPLE_ROOTS", Severity: SeverityError, Description: "Several nodes are the target of no answer"},
+	{Code: "DAG_HAS_CYCLES", Severity: SeverityError, Description: "Answers lead back to a node already asked"},
+	{Code: "DAG_TOO_MANY_NODES", Severity: SeverityError, Description: "The DAG has more nodes than the profile allows"},
+	{Code: "DAG_TOO_DEEP", Severity: SeverityError, Description: "The DAG is deeper than the profile allows"},
+	{Code: "NODE_ID_MISMATCH", Severity: SeverityError, Description: "A node is stored under the ID of another node"},
+	{Code: "NODE_EMPTY_QUESTION", Severity: SeverityError, Description: "A node has no question"},
+	{Code: "NODE_INVALID_TYPE", Severity: SeverityError, Description: "A node has an unknown type"},
+	{Code: "NODE_INVALID_MULTI_SELECT_NEXT", Severity: SeverityError, Description: "A multi-select node has an unknown next node rule"},
+	{Code: "NODE_MULTI_SELECT_DIVERGENT", Severity: SeverityError, Description: "The answers of a convergence node lead to different nodes"},
+	{Code: "NODE_TOO_MANY_ANSWERS", Severity: SeverityError, Description: "A node has more answers than the profile allows"},
+	{Code: "NODE_MULTIPLE_PARENTS", Severity: SeverityError, Description: "A node is reached by several answers while the profile requires a tree"},
+	{Code: "NODE_REDUNDANT_ANSWERS", Severity: SeverityWarning, Description: "Answers of a node lead to the same node with the same metadata"},
+	{Code: "NODE_MIXED_TERMINAL_ANSWERS", Severity: SeverityWarning, Description: "A node mixes answers ending the walk and answers leading further"},
+	{Code: "NODE_SINGLE_ANSWER", Severity: SeverityWarning, Description: "A question offers a single answer"},
+	{Code: "NODE_EXCESSIVE_BRANCHING", Severity: SeverityWarning, Description: "A question offers too many answers"},
+	{Code: "NODE_UNREACHABLE", Severity: SeverityWarning, Description: "An inner node cannot be reached from the root"},
+	{Code: "NODE_UNREACHABLE_VIA_ENABLED", Severity: SeverityWarning, Description: "A node can only be reached through disabled answers"},
+	{Code: "LEAF_UNREACHABLE", Severity: SeverityWarning, Description: "Leaves cannot be reached from the root"},
+	{Code: "INFORMATION_NODE_MULTIPLE_ANSWERS", Severity: SeverityError, Description: "An information node has several answers"},
+	{Code: "OUTCOME_NODE_NOT_LEAF", Severity: SeverityError, Description: "An outcome node has answers"},
+	{Code: "OUTCOME_MISSING_ASSESSMENT", Severity: SeverityWarning, Description: "An outcome node has no assessment"},
+	{Code: "INPUT_NODE_ANSWERS", Severity: SeverityError, Description: "An input node does not have a single answer"},
+	{Code: "INPUT_MISSING_VARIABLE", Severity: SeverityError, Description: "An input node stores its value in no variable"},
+	{Code: "INPUT_INVALID_DEFINITION", Severity: SeverityError, Description: "An input node has an invalid definition"},
+	{Code: "ANSWER_INVALID_ID", Severity: SeverityError, Description: "An answer has no ID"},
+	{Code: "ANSWER_EMPTY_STATEMENT", Severity: SeverityError, Description: "An answer has no statement"},
+	{Code: "ANSWER_INVALID_REFERENCE", Severity: SeverityError, Description: "An answer leads to an unknown node"},
+	{Code: "ANSWER_INVALID_CONDITION", Severity: SeverityError, Description: "A condition of an answer cannot be compiled"},
+	{Code: "ANSWER_UNKNOWN_CONDITION_VARIABLE", Severity: SeverityWarning, Description: "A condition refers to a variable no preceding answer sets"},
+	{Code: "ANSWER_DUPLICATE_STATEMENT", Severity: SeverityWarning, Description: "An answer repeats the statement of another answer of its node"},
+	{Code: "ANSWER_UNKNOWN_SCORE_DIMENSION", Severity: SeverityWarning, Description: "An answer contributes to an undeclared scoring dimension"},
+	{Code: "ANSWER_METADATA_SCHEMA_VIOLATION", Severity: SeverityError, Description: "The metadata of an answer does not match the metadata schema"},
+	{Code: "ANSWER_MISSING_METADATA", Severity: SeverityError, Description: "An answer lacks metadata the profile requires"},
+	{Code: "ANSWER_TAG_NOT_ALLOWED", Severity: SeverityError, Description: "An answer uses a tag outside the profile vocabulary"},
+	{Code: "ANSWER_METADATA_TOO_LARGE", Severity: SeverityWarning, Description: "The metadata of an answer exceeds the profile size"},
+	{Code: "ANSWER_LOW_CONFIDENCE", Severity: SeverityWarning, Description: "The confidence of an answer is below the profile threshold"},
+	{Code: "SCORING_INVALID_DIMENSION", Severity: SeverityError, Description: "A scoring dimension is invalid"},
+	{Code: "METADATA_SCHEMA_INVALID", Severity: SeverityError, Description: "The metadata schema is invalid"},
+}
+
+// ValidationRules returns the rules of the validator with their default severity
+func ValidationRules() []ValidationRule {
+	return slices.Clone(validationRules)
+}
+
+func lookupValidationRule(code string) (ValidationRule, bool) {
+	i := slices.IndexFunc(validationRules, func(rule ValidationRule) bool { return rule.Code == code })
+	if i < 0 {
+		return ValidationRule{}, false
+	}
+	return validationRules[i], true
+}
+
+// checkRules reports the overrides naming unknown rules or severities
+func checkRules(rules map[string]Severity) error {
+	var errs []error
+	for code, severity := range rules {
+		if _, ok := lookupValidationRule(code); !ok {
+			errs = append(errs, fmt.Errorf("unknown validation rule %q", code))
+		}
+		if severity != SeverityError && severity != SeverityWarning && severity != SeverityOff {
+			errs = append(errs, fmt.Errorf("invalid severity %q of validation rule %q, expected %s, %s or %s", severity, code, SeverityError, SeverityWarning, SeverityOff))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applyRules reports the issues of the result with the severity of their rule in the profile, the DAG being valid
+// when no error remains
+func (v *DAGValidator) applyRules(result *ValidationResult) {
+	errs := []ValidationError{}
+	warnings := []ValidationWarning{}
+
+	for _, err := range result.Errors {
+		switch v.profile.severity(err.Code, SeverityError) {
+		case SeverityError:
+			errs = append(errs, err)
+		case SeverityWarning:
+			warnings = append(warnings, ValidationWarning{Code: err.Code, Message: err.Message, NodeID: err.NodeID, AnswerID: err.AnswerID})
+		}
+	}
+	for _, warning := range result.Warnings {
+		switch v.profile.severity(warning.Code, SeverityWarning) {
+		case SeverityError:
+			errs = append(errs, ValidationError{Code: warning.Code, Message: warning.Message, NodeID: warning.NodeID, AnswerID: warning.AnswerID, Severity: string(SeverityError)})
+		case SeverityWarning:
+			warnings = append(warnings, warning)
+		}
+	}
+
+	result.Errors = errs
+	result.Warnings = warnings
+	result.IsValid = len(errs) == 0
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationRules(t *testing.T) {
+	t.Parallel()
+
+	codes := make(map[string]bool)
+	for _, rule := range ValidationRules() {
+		assert.False(t, codes[rule.Code], "rule %s is registered twice", rule.Code)
+		codes[rule.Code] = true
+		assert.Contains(t, []Severity{SeverityError, SeverityWarning}, rule.Severity, rule.Code)
+		assert.NotEmpty(t, rule.Description, rule.Code)
+	}
+}
+
+func TestValidationProfile_Check(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultValidationProfile().Check())
+	assert.NoError(t, ValidationProfile{Rules: map[string]Severity{"DAG_MULTIPLE_ROOTS": SeverityOff}}.Check())
+
+	err := ValidationProfile{Rules: map[string]Severity{"DAG_TOO_COLORFUL": SeverityOff, "DAG_HAS_CYCLES": "fatal"}}.Check()
+	assert.ErrorContains(t, err, `unknown validation rule "DAG_TOO_COLORFUL"`)
+	assert.ErrorContains(t, err, `invalid severity "fatal" of validation rule "DAG_HAS_CYCLES"`)
+}
+
+func TestValidationProfile_WithRules(t *testing.T) {
+	t.Parallel()
+
+	profile := ValidationProfile{Name: "draft", Rules: map[string]Severity{"DAG_MULTIPLE_ROOTS": SeverityWarning, "NODE_SINGLE_ANSWER": SeverityOff}}
+	merged := profile.WithRules(map[string]Severity{"NODE_SINGLE_ANSWER": SeverityError})
+
+	assert.Equal(t, map[string]Severity{"DAG_MULTIPLE_ROOTS": SeverityWarning, "NODE_SINGLE_ANSWER": SeverityError}, merged.Rules)
+	assert.Equal(t, SeverityOff, profile.Rules["NODE_SINGLE_ANSWER"], "the profile is left unchanged")
+}
+
+func TestDAGValidator_RuleSeverities(t *testing.T) {
+	t.Parallel()
+
+	codesOf := func(result ValidationResult) (errors, warnings []string) {
+		for _, err := range result.Errors {
+			errors = append(errors, err.Code)
+		}
+		for _, warning := range result.Warnings {
+			warnings = append(warnings, warning.Code)
+		}
+		return errors, warnings
+	}
+
+	t.Run("downgrades errors to warnings", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidatorFromProfile(ValidationProfile{Name: "draft", Rules: map[string]Severity{
+			"DAG_MULTIPLE_ROOTS": SeverityWarning,
+		}}).ValidateDAG(dagtest.MultipleRoots())
+
+		errors, warnings := codesOf(result)
+		assert.True(t, result.IsValid)
+		assert.NotContains(t, errors, "DAG_MULTIPLE_ROOTS")
+		assert.Contains(t, warnings, "DAG_MULTIPLE_ROOTS")
+	})
+
+	t.Run("disables rules", func(t *testing.T) {
+		t.Parallel()
+
+		result := NewDAGValidatorFromProfile(ValidationProfile{Name: "lenient", Rules: map[string]Severity{
+			"DAG_MULTIPLE_ROOTS": SeverityOff,
+		}}).ValidateDAG(dagtest.MultipleRoots())
+
+		errors, warnings := codesOf(result)
+		assert.True(t, result.IsValid)
+		assert.NotContains(t, errors, "DAG_MULTIPLE_ROOTS")
+		assert.NotContains(t, warnings, "DAG_MULTIPLE_ROOTS")
+		assert.Equal(t, 2, result.Statistics.RootNodes, "statistics are not affected")
+	})
+
+	t.Run("upgrades warnings to errors", func(t *testing.T) {
+		t.Parallel()
+
+		d := createTaggedDAG(nil)
+		root := dagtest.Root(d)
+		root.Type = ""
+		d.Nodes[root.Id] = root
+
+		result := NewDAGValidatorFromProfile(ValidationProfile{Name: "strict", Rules: map[string]Severity{
+			"NODE_SINGLE_ANSWER": SeverityError,
+		}}).ValidateDAG(d)
+
+		errors, _ := codesOf(result)
+		assert.False(t, result.IsValid)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, []string{"NODE_SINGLE_ANSWER"}, errors)
+		assert.Equal(t, string(SeverityError), result.Errors[0].Severity)
+		assert.Equal(t, root.Id.String(), result.Errors[0].NodeID)
+	})
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"time"
+)
+
+// ValidationSweeper periodically re-validates the stored DAGs so their validation metadata stays current
+// without manual triggers
+type ValidationSweeper struct {
+	dagRepository     DAGRepository
+	validateStoredDAG *ValidateStoredDAGUseCase
+	interval          time.Duration
+}
+
+func NewValidationSweeper(dagRepository DAGRepository, validateStoredDAG *ValidateStoredDAGUseCase, interval time.Duration) *ValidationSweeper {
+	return &ValidationSweeper{
+		dagRepository:     dagRepository,
+		validateStoredDAG: validateStoredDAG,
+		interval:          interval,
+	}
+}
+
+// Run sweeps right away and then every interval, until the context is cancelled
+func (s *ValidationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		validated, err := s.Sweep(ctx)
+		if err != nil && ctx.Err() == nil {
+			xlog.Ctx(ctx).Error().Err(err).Msg("validation sweep failed")
+		} else if validated > 0 {
+			xlog.Ctx(ctx).Debug().Int("validated", validated).Msg("validation sweep completed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep validates once every DAG whose metadata is stale and returns how many were validated.
+// DAGs are validated one at a time and fresh ones are skipped, so a sweep only takes the write lock when needed.
+func (s *ValidationSweeper) Sweep(ctx context.Context) (int, error) {
+	dagIds, err := s.dagRepository.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	validated := 0
+	for _, id := range dagIds {
+		if err := ctx.Err(); err != nil {
+			return validated, err
+		}
+
+		dag, err := s.dagRepository.Get(ctx, id)
+		if err != nil {
+			// The DAG may have been deleted since it was listed
+			continue
+		}
+		if !hasStaleValidation(dag) {
+			continue
+		}
+
+		_, err = s.validateStoredDAG.Execute(ctx, CmdValidateStoredDAG{DAGId: id.String()})
+		if err != nil {
+			xlog.Ctx(ctx).Warn().Err(err).Str("dag_id", id.String()).Msg("failed to validate DAG during sweep")
+			continue
+		}
+		validated++
+	}
+
+	return validated, nil
+}
+
+// hasStaleValidation reports whether the DAG was never validated or changed since its last validation
+func hasStaleValidation(dag *model.DAG) bool {
+	if dag.Metadata == nil || dag.Metadata.LastValidatedAt.IsZero() {
+		return true
+	}
+
+	return dag.Metadata.LastValidatedAt.Before(dag.UpdatedAt)
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/dagtest"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase/testdata/mocks"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStoredDAGRepository returns a mocked repository serving a single DAG and applying updates to it
+func newStoredDAGRepository(ctrl *gomock.Controller, d *model.DAG) (*mocks.MockDAGRepository, func() model.DAG) {
+	var mu sync.Mutex
+	stored := *d
+
+	// Validation updates the metadata in place, hand out copies like the real repositories
+	snapshot := func() model.DAG {
+		current := stored
+		if stored.Metadata != nil {
+			metadata := *stored.Metadata
+			current.Metadata = &metadata
+		}
+		return current
+	}
+
+	mockRepo := mocks.NewMockDAGRepository(ctrl)
+	mockRepo.EXPECT().List(gomock.Any()).Return([]uuid.UUID{d.Id}, nil).AnyTimes()
+	mockRepo.EXPECT().Get(gomock.Any(), d.Id).DoAndReturn(func(context.Context, uuid.UUID) (*model.DAG, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		current := snapshot()
+		return &current, nil
+	}).AnyTimes()
+	mockRepo.EXPECT().Update(gomock.Any(), d.Id, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, fnUpdate func(model.DAG) (model.DAG, error)) error {
+			mu.Lock()
+			defer mu.Unlock()
+			updated, err := fnUpdate(snapshot())
+			if err != nil {
+				return err
+			}
+			stored = updated
+			return nil
+		},
+	).AnyTimes()
+
+	return mockRepo, func() model.DAG {
+		mu.Lock()
+		defer mu.Unlock()
+		return snapshot()
+	}
+}
+
+func TestValidationSweeper_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := dagtest.ValidSingleRoot()
+	created.Metadata = model.NewDAGMetadata()
+	mockRepo, current := newStoredDAGRepository(ctrl, created)
+
+	sweeper := NewValidationSweeper(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil), 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sweeper.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return !current().Metadata.LastValidatedAt.IsZero()
+	}, time.Second, 5*time.Millisecond)
+
+	metadata := current().Metadata
+	assert.True(t, metadata.IsValid)
+	assert.Equal(t, len(created.Nodes), metadata.Statistics.TotalNodes)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop after context cancellation")
+	}
+}
+
+func TestValidationSweeper_Sweep(t *testing.T) {
+	t.Run("skips DAGs validated since their last update", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fresh := dagtest.ValidSingleRoot()
+		fresh.UpdatedAt = time.Now().Add(-time.Hour)
+		fresh.Metadata = model.NewDAGMetadata()
+		fresh.Metadata.LastValidatedAt = time.Now()
+
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		mockRepo.EXPECT().List(gomock.Any()).Return([]uuid.UUID{fresh.Id}, nil)
+		mockRepo.EXPECT().Get(gomock.Any(), fresh.Id).Return(fresh, nil)
+
+		validated, err := NewValidationSweeper(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil), time.Minute).Sweep(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, validated)
+	})
+
+	t.Run("revalidates DAGs updated since their last validation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stale := dagtest.ValidSingleRoot()
+		stale.Metadata = model.NewDAGMetadata()
+		stale.Metadata.LastValidatedAt = time.Now().Add(-time.Hour)
+		stale.UpdatedAt = time.Now()
+		mockRepo, current := newStoredDAGRepository(ctrl, stale)
+
+		validated, err := NewValidationSweeper(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil), time.Minute).Sweep(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, validated)
+		assert.False(t, current().Metadata.LastValidatedAt.Before(stale.UpdatedAt))
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockDAGRepository(ctrl)
+		mockRepo.EXPECT().List(gomock.Any()).Return([]uuid.UUID{uuid.New()}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := NewValidationSweeper(mockRepo, NewValidateStoredDAGUseCase(mockRepo, NewDAGValidator(), nil), time.Minute).Sweep(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=walk_analytics_repository.go -destination=testdata/mocks/walk_analytics_repository_mock.go -package=mocks
+
+type WalkAnalyticsRepository interface {
+	// Get returns the analytics of a DAG, empty analytics when no walk was recorded yet
+	Get(ctx context.Context, dagId uuid.UUID) (*model.WalkAnalytics, error)
+	// Update applies fnUpdate atomically to the analytics of a DAG and persists the result
+	Update(ctx context.Context, dagId uuid.UUID, fnUpdate func(analytics model.WalkAnalytics) (model.WalkAnalytics, error)) error
+	// Delete removes the analytics of a DAG, deleting analytics never recorded is not an error
+	Delete(ctx context.Context, dagId uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/golang/mock/mockgen -source=webhook_repository.go -destination=testdata/mocks/webhook_repository_mock.go -package=mocks
+
+type WebhookRepository interface {
+	// List returns every registered webhook, ordered by creation date
+	List(ctx context.Context) ([]model.Webhook, error)
+	Get(ctx context.Context, id uuid.UUID) (*model.Webhook, error)
+	Create(ctx context.Context, webhook *model.Webhook) error
+	// Update applies fnUpdate atomically to a webhook and persists the result
+	Update(ctx context.Context, id uuid.UUID, fnUpdate func(webhook model.Webhook) (model.Webhook, error)) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Save creates or replaces a delivery
+	Save(ctx context.Context, delivery model.WebhookDelivery) error
+	// List returns the latest deliveries of a webhook, most recent first, limit zero returns every delivery
+	List(ctx context.Context, webhookId uuid.UUID, limit int) ([]model.WebhookDelivery, error)
+	// DeleteAll drops the deliveries of a webhook
+	DeleteAll(ctx context.Context, webhookId uuid.UUID) error
+	// Pending returns the deliveries of every webhook still to be sent, oldest first
+	Pending(ctx context.Context) ([]model.WebhookDelivery, error)
+}
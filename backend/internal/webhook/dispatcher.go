@@ -0,0 +1,374 @@
+// Package webhook delivers events to the webhooks subscribing to them.
+// Deliveries are queued and sent by a pool of workers, failed attempts are scheduled for a retry with an exponential
+// backoff and every payload is signed with the secret of its webhook. The deliveries still pending when the server
+// stops are resumed by the next run.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/usecase"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers sent along with every payload
+const (
+	HeaderEvent     = "X-Jurigen-Event"
+	HeaderDelivery  = "X-Jurigen-Delivery"
+	HeaderTimestamp = "X-Jurigen-Timestamp"
+	// HeaderSignature holds "sha256=" followed by the hex encoded signature computed by Sign
+	HeaderSignature = "X-Jurigen-Signature"
+)
+
+// errorBodyLimit is the number of bytes of a failed response kept in the delivery error
+const errorBodyLimit = 256
+
+var errQueueFull = errors.New("delivery queue is full")
+
+// Config tunes deliveries, zero values select the defaults
+type Config struct {
+	// Workers is the number of deliveries sent at the same time, 4 by default
+	Workers int
+	// QueueSize is the number of deliveries waiting for a worker, 256 by default
+	QueueSize int
+	// MaxAttempts is the number of attempts before a delivery fails, 5 by default
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled on each retry, 1 second by default
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries, 5 minutes by default
+	MaxBackoff time.Duration
+	// Timeout bounds each attempt, 10 seconds by default
+	Timeout time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.Workers <= 0 {
+		c.Workers = 4
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 256
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 5 * time.Minute
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	return c
+}
+
+// Dispatcher publishes events to the subscribed webhooks and delivers them in the background
+type Dispatcher struct {
+	webhooks   usecase.WebhookRepository
+	deliveries usecase.WebhookDeliveryRepository
+	config     Config
+	client     *http.Client
+	queue      chan model.WebhookDelivery
+	// retries holds the deliveries waiting for their next attempt, queued again once it is due
+	retries *retrySchedule
+	// started is the creation time of the dispatcher, the pending deliveries created before are left by a previous run
+	started time.Time
+
+	// draining is closed on shutdown, the workers then stop once the queue is empty
+	draining  chan struct{}
+	drainOnce sync.Once
+	// interrupt is closed when the shutdown deadline is reached, cancelling the attempts in progress
+	interrupt     chan struct{}
+	interruptOnce sync.Once
+	// stopped is closed when Run returns
+	stopped chan struct{}
+}
+
+func NewDispatcher(webhooks usecase.WebhookRepository, deliveries usecase.WebhookDeliveryRepository, config Config) *Dispatcher {
+	config = config.withDefaults()
+
+	return &Dispatcher{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		config:     config,
+		client:     &http.Client{Timeout: config.Timeout},
+		queue:      make(chan model.WebhookDelivery, config.QueueSize),
+		retries:    newRetrySchedule(),
+		started:    time.Now(),
+		draining:   make(chan struct{}),
+		interrupt:  make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+}
+
+// Publish records a pending delivery of the event for each subscribed webhook and queues it.
+// Deliveries which cannot be queued are recorded as failed.
+func (d *Dispatcher) Publish(ctx context.Context, event model.Event) error {
+	webhooks, err := d.webhooks.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+
+		// The pending delivery is saved before being queued, a worker saving its outcome first would be overwritten
+		delivery := model.NewWebhookDelivery(webhook.Id, event, time.Now())
+		if err := d.deliveries.Save(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save delivery to webhook %s: %w", webhook.Id, err))
+			continue
+		}
+
+		select {
+		case d.queue <- delivery:
+			continue
+		default:
+		}
+
+		delivery.Status = model.DeliveryStatusFailed
+		delivery.LastError = errQueueFull.Error()
+		errs = append(errs, fmt.Errorf("webhook %s: %w", webhook.Id, errQueueFull))
+		if err := d.deliveries.Save(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save delivery to webhook %s: %w", webhook.Id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run delivers the queued events until ctx is cancelled or the dispatcher is shut down, resuming first the
+// deliveries left pending by a previous run
+func (d *Dispatcher) Run(ctx context.Context) {
+	defer close(d.stopped)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-d.interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	d.resume(ctx)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.schedule(ctx)
+	}()
+	for i := 0; i < d.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case delivery := <-d.queue:
+					d.deliver(ctx, delivery)
+				case <-d.draining:
+					select {
+					case delivery := <-d.queue:
+						d.deliver(ctx, delivery)
+					default:
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Shutdown sends the queued deliveries and waits for Run to return, it must have been started.
+// Deliveries waiting for a retry are left pending, for the next run to resume them. When ctx is done first, the attempts in progress
+// are cancelled and an error is returned.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.drainOnce.Do(func() { close(d.draining) })
+
+	select {
+	case <-d.stopped:
+		return nil
+	case <-ctx.Done():
+		d.interruptOnce.Do(func() { close(d.interrupt) })
+		return fmt.Errorf("webhook deliveries interrupted: %w", ctx.Err())
+	}
+}
+
+// resume schedules the pending deliveries left by a previous run, at their next attempt
+func (d *Dispatcher) resume(ctx context.Context) {
+	pending, err := d.deliveries.Pending(ctx)
+	if err != nil {
+		xlog.Ctx(ctx).Error().Err(err).Msg("failed to resume pending webhook deliveries")
+		return
+	}
+
+	now := time.Now()
+	for _, delivery := range pending {
+		// The deliveries published since the dispatcher was created are queued already
+		if !delivery.CreatedAt.Before(d.started) {
+			continue
+		}
+
+		at := now
+		if delivery.NextAttemptAt != nil {
+			at = *delivery.NextAttemptAt
+		}
+		d.retries.add(delivery, at)
+	}
+}
+
+// schedule queues the deliveries whose next attempt is due until ctx is cancelled or the dispatcher is shut
+// down. When the queue is full the attempts are postponed by the initial backoff.
+func (d *Dispatcher) schedule(ctx context.Context) {
+	for {
+		now := time.Now()
+		due, wait := d.retries.due(now)
+		for _, delivery := range due {
+			select {
+			case d.queue <- delivery:
+			default:
+				d.retries.add(delivery, now.Add(d.config.InitialBackoff))
+			}
+		}
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.draining:
+			return
+		case <-d.retries.wake:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// deliver attempts to send a delivery, scheduling the next attempt when it fails and attempts are left.
+// The webhook is read before each attempt so that retries follow URL and secret changes.
+func (d *Dispatcher) deliver(ctx context.Context, delivery model.WebhookDelivery) {
+	webhook, err := d.webhooks.Get(ctx, delivery.WebhookId)
+	if err != nil || !webhook.Active {
+		delivery.Status = model.DeliveryStatusFailed
+		delivery.LastError = "webhook was deleted or deactivated"
+		delivery.NextAttemptAt = nil
+		delivery.UpdatedAt = time.Now()
+		d.save(ctx, delivery)
+		return
+	}
+
+	delivery.Attempts++
+	delivery.StatusCode, err = d.send(ctx, webhook, delivery)
+	delivery.UpdatedAt = time.Now()
+	delivery.NextAttemptAt = nil
+
+	switch {
+	case err == nil:
+		delivery.Status = model.DeliveryStatusSucceeded
+		delivery.LastError = ""
+		d.save(ctx, delivery)
+		return
+	case delivery.Attempts >= d.config.MaxAttempts:
+		delivery.Status = model.DeliveryStatusFailed
+		delivery.LastError = err.Error()
+		d.save(ctx, delivery)
+		return
+	}
+
+	nextAttemptAt := delivery.UpdatedAt.Add(d.backoff(delivery.Attempts))
+	delivery.LastError = err.Error()
+	delivery.NextAttemptAt = &nextAttemptAt
+	d.save(ctx, delivery)
+	d.retries.add(delivery, nextAttemptAt)
+}
+
+// send posts the signed event to the webhook, returning the response status
+func (d *Dispatcher) send(ctx context.Context, webhook *model.Webhook, delivery model.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "jurigen-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event.Type)
+	req.Header.Set(HeaderDelivery, delivery.Id.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.config.InitialBackoff
+	for i := 1; i < attempts && backoff < d.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, d.config.MaxBackoff)
+}
+
+func (d *Dispatcher) save(ctx context.Context, delivery model.WebhookDelivery) {
+	// The delivery outcome is recorded even when the dispatcher is stopping
+	if err := d.deliveries.Save(context.WithoutCancel(ctx), delivery); err != nil {
+		xlog.Ctx(ctx).Error().Err(err).
+			Str("delivery_id", delivery.Id.String()).
+			Str("webhook_id", delivery.WebhookId.String()).
+			Msg("failed to save webhook delivery")
+	}
+}
+
+// Sign computes the hex encoded HMAC-SHA256 of "<timestamp>.<body>" with the secret of a webhook.
+// Receivers recompute it to check that a payload was sent by this server and was not replayed.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,379 @@
+package webhook
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/internal/model"
+	"davidterranova/jurigen/backend/internal/port"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "0123456789abcdef"
+
+// receiver records the requests received by a webhook endpoint, answering the given statuses in turn then 200
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("unavailable"))
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// queueObserver records the number of queued deliveries on every save
+type queueObserver struct {
+	*port.InMemoryWebhookDeliveryRepository
+	queue        chan model.WebhookDelivery
+	queuedOnSave []int
+}
+
+func (o *queueObserver) Save(ctx context.Context, delivery model.WebhookDelivery) error {
+	o.queuedOnSave = append(o.queuedOnSave, len(o.queue))
+	return o.InMemoryWebhookDeliveryRepository.Save(ctx, delivery)
+}
+
+type fixture struct {
+	dispatcher *Dispatcher
+	webhooks   *port.FileWebhookRepository
+	deliveries *port.InMemoryWebhookDeliveryRepository
+}
+
+func newFixture(t *testing.T, config Config) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		webhooks:   port.NewFileWebhookRepository(t.TempDir()),
+		deliveries: port.NewInMemoryWebhookDeliveryRepository(0),
+	}
+	f.dispatcher = NewDispatcher(f.webhooks, f.deliveries, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.dispatcher.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return f
+}
+
+func (f *fixture) register(t *testing.T, url string, events ...string) *model.Webhook {
+	t.Helper()
+
+	webhook := &model.Webhook{Id: uuid.New(), URL: url, Events: events, Secret: secret, Active: true}
+	require.NoError(t, f.webhooks.Create(context.Background(), webhook))
+	return webhook
+}
+
+// waitForDelivery waits until the latest delivery to the webhook has the status
+func (f *fixture) waitForDelivery(t *testing.T, webhookId uuid.UUID, status model.DeliveryStatus) model.WebhookDelivery {
+	t.Helper()
+
+	var latest model.WebhookDelivery
+	require.Eventually(t, func() bool {
+		deliveries, err := f.deliveries.List(context.Background(), webhookId, 1)
+		require.NoError(t, err)
+		if len(deliveries) == 0 {
+			return false
+		}
+		latest = deliveries[0]
+		return latest.Status == status
+	}, 5*time.Second, 5*time.Millisecond)
+
+	return latest
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	t.Run("delivers signed events to the subscribed webhooks", func(t *testing.T) {
+		f := newFixture(t, Config{})
+		endpoint := &receiver{}
+		server := httptest.NewServer(endpoint)
+		defer server.Close()
+
+		subscribed := f.register(t, server.URL, model.EventSessionCompleted)
+		other := f.register(t, server.URL, model.EventDAGDeleted)
+
+		event := model.NewEvent(model.EventSessionCompleted, uuid.New(), time.Now().UTC().Truncate(time.Second))
+		require.NoError(t, f.dispatcher.Publish(context.Background(), event))
+
+		delivery := f.waitForDelivery(t, subscribed.Id, model.DeliveryStatusSucceeded)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+
+		others, err := f.deliveries.List(context.Background(), other.Id, 0)
+		require.NoError(t, err)
+		assert.Empty(t, others)
+
+		require.Equal(t, 1, endpoint.count())
+		req, body := endpoint.requests[0], endpoint.bodies[0]
+		assert.Equal(t, model.EventSessionCompleted, req.Header.Get(HeaderEvent))
+		assert.Equal(t, delivery.Id.String(), req.Header.Get(HeaderDelivery))
+		assert.Equal(t, "sha256="+Sign(secret, req.Header.Get(HeaderTimestamp), body), req.Header.Get(HeaderSignature))
+
+		var received model.Event
+		require.NoError(t, json.Unmarshal(body, &received))
+		assert.Equal(t, event, received)
+	})
+
+	t.Run("retries failed attempts", func(t *testing.T) {
+		f := newFixture(t, Config{InitialBackoff: time.Millisecond})
+		endpoint := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}}
+		server := httptest.NewServer(endpoint)
+		defer server.Close()
+
+		webhook := f.register(t, server.URL)
+		require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+
+		delivery := f.waitForDelivery(t, webhook.Id, model.DeliveryStatusSucceeded)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Empty(t, delivery.LastError)
+		assert.Nil(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("fails once the attempts are exhausted", func(t *testing.T) {
+		f := newFixture(t, Config{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+		endpoint := &receiver{statuses: []int{http.StatusBadGateway, http.StatusBadGateway}}
+		server := httptest.NewServer(endpoint)
+		defer server.Close()
+
+		webhook := f.register(t, server.URL)
+		require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+
+		delivery := f.waitForDelivery(t, webhook.Id, model.DeliveryStatusFailed)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, http.StatusBadGateway, delivery.StatusCode)
+		assert.Contains(t, delivery.LastError, "status 502: unavailable")
+	})
+
+	t.Run("stops retrying once the webhook is deactivated", func(t *testing.T) {
+		f := newFixture(t, Config{InitialBackoff: 200 * time.Millisecond})
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		webhook := f.register(t, server.URL)
+		require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+		require.Eventually(t, func() bool { return calls.Load() == 1 }, 5*time.Second, time.Millisecond)
+
+		require.NoError(t, f.webhooks.Update(context.Background(), webhook.Id, func(existing model.Webhook) (model.Webhook, error) {
+			existing.Active = false
+			return existing, nil
+		}))
+
+		delivery := f.waitForDelivery(t, webhook.Id, model.DeliveryStatusFailed)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("sends other deliveries while a retry is waiting", func(t *testing.T) {
+		f := newFixture(t, Config{Workers: 1, InitialBackoff: time.Hour})
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+		endpoint := &receiver{}
+		server := httptest.NewServer(endpoint)
+		defer server.Close()
+
+		retried := f.register(t, failing.URL, model.EventDAGDeleted)
+		require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGDeleted, uuid.New(), time.Now())))
+		require.Eventually(t, func() bool { return f.dispatcher.retries.len() == 1 }, 5*time.Second, time.Millisecond)
+
+		other := f.register(t, server.URL, model.EventDAGCreated)
+		require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+		f.waitForDelivery(t, other.Id, model.DeliveryStatusSucceeded)
+
+		deliveries, err := f.deliveries.List(context.Background(), retried.Id, 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.DeliveryStatusPending, deliveries[0].Status)
+		assert.NotNil(t, deliveries[0].NextAttemptAt)
+	})
+
+	t.Run("saves the pending delivery before queueing it", func(t *testing.T) {
+		// No worker runs, a delivery saved once queued could overwrite the outcome saved by a worker
+		webhooks := port.NewFileWebhookRepository(t.TempDir())
+		deliveries := &queueObserver{InMemoryWebhookDeliveryRepository: port.NewInMemoryWebhookDeliveryRepository(0)}
+		dispatcher := NewDispatcher(webhooks, deliveries, Config{QueueSize: 1})
+		deliveries.queue = dispatcher.queue
+		webhook := &model.Webhook{Id: uuid.New(), URL: "http://localhost", Secret: secret, Active: true}
+		require.NoError(t, webhooks.Create(context.Background(), webhook))
+
+		require.NoError(t, dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+
+		assert.Equal(t, []int{0}, deliveries.queuedOnSave)
+		assert.Len(t, dispatcher.queue, 1)
+	})
+
+	t.Run("fails deliveries which cannot be queued", func(t *testing.T) {
+		// No worker runs, the queue of one delivery fills up
+		webhooks := port.NewFileWebhookRepository(t.TempDir())
+		deliveries := port.NewInMemoryWebhookDeliveryRepository(0)
+		dispatcher := NewDispatcher(webhooks, deliveries, Config{QueueSize: 1})
+		webhook := &model.Webhook{Id: uuid.New(), URL: "http://localhost", Secret: secret, Active: true}
+		require.NoError(t, webhooks.Create(context.Background(), webhook))
+
+		event := model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())
+		require.NoError(t, dispatcher.Publish(context.Background(), event))
+		assert.ErrorIs(t, dispatcher.Publish(context.Background(), event), errQueueFull)
+
+		latest, err := deliveries.List(context.Background(), webhook.Id, 0)
+		require.NoError(t, err)
+		require.Len(t, latest, 2)
+		assert.Equal(t, model.DeliveryStatusFailed, latest[0].Status)
+		assert.Equal(t, model.DeliveryStatusPending, latest[1].Status)
+	})
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	t.Run("resumes the deliveries left pending by a previous run", func(t *testing.T) {
+		endpoint := &receiver{}
+		server := httptest.NewServer(endpoint)
+		defer server.Close()
+
+		webhooks := port.NewFileWebhookRepository(t.TempDir())
+		deliveries := port.NewFileWebhookDeliveryRepository(t.TempDir(), 0)
+		webhook := &model.Webhook{Id: uuid.New(), URL: server.URL, Secret: secret, Active: true}
+		require.NoError(t, webhooks.Create(context.Background(), webhook))
+
+		// The previous run stopped with a delivery waiting for a retry, and another not attempted yet
+		createdAt := time.Now().Add(-time.Minute)
+		retried := model.NewWebhookDelivery(webhook.Id, model.NewEvent(model.EventDAGCreated, uuid.New(), createdAt), createdAt)
+		retried.Attempts = 1
+		nextAttemptAt := time.Now().Add(50 * time.Millisecond)
+		retried.NextAttemptAt = &nextAttemptAt
+		queued := model.NewWebhookDelivery(webhook.Id, model.NewEvent(model.EventDAGDeleted, uuid.New(), createdAt), createdAt)
+		require.NoError(t, deliveries.Save(context.Background(), retried))
+		require.NoError(t, deliveries.Save(context.Background(), queued))
+
+		dispatcher := NewDispatcher(webhooks, deliveries, Config{})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go dispatcher.Run(ctx)
+
+		require.Eventually(t, func() bool {
+			pending, err := deliveries.Pending(context.Background())
+			return err == nil && len(pending) == 0
+		}, 5*time.Second, 5*time.Millisecond)
+		assert.Equal(t, 2, endpoint.count())
+
+		latest, err := deliveries.List(context.Background(), webhook.Id, 0)
+		require.NoError(t, err)
+		attempts := map[uuid.UUID]int{}
+		for _, delivery := range latest {
+			assert.Equal(t, model.DeliveryStatusSucceeded, delivery.Status)
+			attempts[delivery.Id] = delivery.Attempts
+		}
+		assert.Equal(t, map[uuid.UUID]int{retried.Id: 2, queued.Id: 1}, attempts)
+	})
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	dispatcher := NewDispatcher(nil, nil, Config{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second})
+
+	assert.Equal(t, time.Second, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Second, dispatcher.backoff(2))
+	assert.Equal(t, 4*time.Second, dispatcher.backoff(3))
+	assert.Equal(t, 5*time.Second, dispatcher.backoff(4))
+}
+
+func TestDispatcher_Shutdown(t *testing.T) {
+	t.Run("sends the queued deliveries", func(t *testing.T) {
+		f := newFixture(t, Config{Workers: 1})
+		endpoint := &receiver{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(20 * time.Millisecond)
+			endpoint.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		webhook := f.register(t, server.URL)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, f.dispatcher.Shutdown(ctx))
+
+		assert.Equal(t, 3, endpoint.count())
+		deliveries, err := f.deliveries.List(context.Background(), webhook.Id, 0)
+		require.NoError(t, err)
+		for _, delivery := range deliveries {
+			assert.Equal(t, model.DeliveryStatusSucceeded, delivery.Status)
+		}
+	})
+
+	t.Run("leaves the deliveries waiting for a retry", func(t *testing.T) {
+		f := newFixture(t, Config{InitialBackoff: time.Hour})
+		endpoint := &receiver{statuses: []int{http.StatusServiceUnavailable}}
+		server := httptest.NewServer(endpoint)
+		defer server.Close()
+
+		webhook := f.register(t, server.URL)
+		require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+		require.Eventually(t, func() bool {
+			deliveries, err := f.deliveries.List(context.Background(), webhook.Id, 1)
+			return err == nil && len(deliveries) == 1 && deliveries[0].NextAttemptAt != nil
+		}, 5*time.Second, 5*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, f.dispatcher.Shutdown(ctx))
+		assert.Equal(t, 1, endpoint.count())
+	})
+
+	t.Run("interrupts the deliveries at the deadline", func(t *testing.T) {
+		f := newFixture(t, Config{Workers: 1})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The closed connection is only noticed once the body is read
+			_, _ = io.ReadAll(r.Body)
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		f.register(t, server.URL)
+		require.NoError(t, f.dispatcher.Publish(context.Background(), model.NewEvent(model.EventDAGCreated, uuid.New(), time.Now())))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, f.dispatcher.Shutdown(ctx), context.DeadlineExceeded)
+	})
+}
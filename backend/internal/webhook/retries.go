@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"container/heap"
+	"davidterranova/jurigen/backend/internal/model"
+	"sync"
+	"time"
+)
+
+// retry is a delivery waiting for its next attempt
+type retry struct {
+	delivery model.WebhookDelivery
+	at       time.Time
+}
+
+// retryHeap orders the retries by next attempt, earliest first
+type retryHeap []retry
+
+func (h retryHeap) Len() int           { return len(h) }
+func (h retryHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h retryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *retryHeap) Push(x any)        { *h = append(*h, x.(retry)) }
+func (h *retryHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// retrySchedule holds the deliveries waiting for their next attempt, so that the workers do not wait for them
+type retrySchedule struct {
+	mu      sync.Mutex
+	retries retryHeap
+	// wake is signalled when a retry is added, it may be due before the ones waited for
+	wake chan struct{}
+}
+
+func newRetrySchedule() *retrySchedule {
+	return &retrySchedule{wake: make(chan struct{}, 1)}
+}
+
+// add schedules the next attempt of a delivery at the given time
+func (s *retrySchedule) add(delivery model.WebhookDelivery, at time.Time) {
+	s.mu.Lock()
+	heap.Push(&s.retries, retry{delivery: delivery, at: at})
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// due removes and returns the deliveries whose next attempt is due at now, along with the wait until the
+// next one, 0 when none is left
+func (s *retrySchedule) due(now time.Time) ([]model.WebhookDelivery, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deliveries []model.WebhookDelivery
+	for len(s.retries) > 0 && !s.retries[0].at.After(now) {
+		deliveries = append(deliveries, heap.Pop(&s.retries).(retry).delivery)
+	}
+	if len(s.retries) == 0 {
+		return deliveries, 0
+	}
+	return deliveries, s.retries[0].at.Sub(now)
+}
+
+// len returns the number of deliveries waiting for their next attempt
+func (s *retrySchedule) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.retries)
+}
@@ -20,6 +20,9 @@
 // @tag.name DAGs
 // @tag.description Operations for managing and retrieving Legal Case DAGs
 //
+// @tag.name Version
+// @tag.description Build information of the running server
+//
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
@@ -14,6 +14,7 @@ const RequestCtxUserKey RequestCtxKey = "user"
 var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
 )
 
 func UserFromContext(ctx context.Context) (user.User, error) {
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"davidterranova/jurigen/backend/pkg/user"
+
+	"github.com/google/uuid"
+)
+
+// StaticUser is a user account declared in the server configuration along with its role
+type StaticUser struct {
+	Username string    `json:"username" yaml:"username"`
+	Password string    `json:"password" yaml:"password"`
+	Role     user.Role `json:"role" yaml:"role"`
+}
+
+// StaticUsers authenticates the users declared in the configuration with their role
+func StaticUsers(users []StaticUser) func(authToken string) (user.User, error) {
+	byUsername := make(map[string]StaticUser, len(users))
+	for _, u := range users {
+		byUsername[u.Username] = u
+	}
+
+	return func(authToken string) (user.User, error) {
+		reqUsername, reqPassword, ok := parseBasicAuth(authToken)
+		if !ok {
+			return user.NewUnauthenticated(), ErrUnauthorized
+		}
+
+		account, ok := byUsername[reqUsername]
+		if !ok || subtle.ConstantTimeCompare([]byte(reqPassword), []byte(account.Password)) != 1 {
+			return user.NewUnauthenticated(), ErrUnauthorized
+		}
+
+		id := uuid.NewSHA1(uuid.NameSpaceOID, []byte(account.Username))
+		return user.NewWithRole(id, account.Role), nil
+	}
+}
@@ -0,0 +1,30 @@
+package buildinfo
+
+import "runtime"
+
+// Build information injected at compile time, e.g.
+//
+//	go build -ldflags "-X davidterranova/jurigen/backend/pkg/buildinfo.version=1.2.0 -X davidterranova/jurigen/backend/pkg/buildinfo.commit=$(git rev-parse --short HEAD)"
+var (
+	version   = "na"
+	commit    = "na"
+	buildDate = "na"
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	GoVersion string
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	return Info{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}
+}
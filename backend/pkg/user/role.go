@@ -0,0 +1,36 @@
+package user
+
+import "fmt"
+
+// Role grants a set of permissions, each role includes the permissions of the roles below it
+type Role string
+
+const (
+	// RoleViewer reads DAGs and runs case sessions
+	RoleViewer Role = "viewer"
+	// RoleEditor also changes DAGs
+	RoleEditor Role = "editor"
+	// RoleAdmin also deletes DAGs and manages webhooks and users
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// ParseRole returns the role with the given name
+func ParseRole(name string) (Role, error) {
+	role := Role(name)
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("unknown role %q, expected viewer, editor or admin", name)
+	}
+	return role, nil
+}
+
+// Allows reports whether the role grants the permissions of the required role
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
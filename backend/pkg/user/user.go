@@ -13,12 +13,14 @@ const (
 type User interface {
 	Id() uuid.UUID
 	Type() UserType
+	Role() Role
 }
 
+// New returns a user of the given type, authenticated users are granted every permission
 func New(id uuid.UUID, userType UserType) User {
 	switch userType {
 	case UserTypeAuthenticated:
-		return &UserAuthenticated{id: id}
+		return &UserAuthenticated{id: id, role: RoleAdmin}
 	case UserTypeSystem:
 		return &UserSystem{id: id}
 	default:
@@ -26,12 +28,18 @@ func New(id uuid.UUID, userType UserType) User {
 	}
 }
 
+// NewWithRole returns an authenticated user restricted to the permissions of the role
+func NewWithRole(id uuid.UUID, role Role) User {
+	return &UserAuthenticated{id: id, role: role}
+}
+
 func NewUnauthenticated() *UserAuthenticated {
 	return &UserAuthenticated{id: uuid.Nil}
 }
 
 type UserAuthenticated struct {
-	id uuid.UUID
+	id   uuid.UUID
+	role Role
 }
 
 func (u UserAuthenticated) Id() uuid.UUID {
@@ -42,6 +50,10 @@ func (u UserAuthenticated) Type() UserType {
 	return UserTypeAuthenticated
 }
 
+func (u UserAuthenticated) Role() Role {
+	return u.role
+}
+
 type UserSystem struct {
 	id uuid.UUID
 }
@@ -54,6 +66,10 @@ func (u UserSystem) Type() UserType {
 	return UserTypeSystem
 }
 
+func (u UserSystem) Role() Role {
+	return RoleAdmin
+}
+
 type UserUnauthenticated struct{}
 
 func (u UserUnauthenticated) Id() uuid.UUID {
@@ -63,3 +79,7 @@ func (u UserUnauthenticated) Id() uuid.UUID {
 func (u UserUnauthenticated) Type() UserType {
 	return UserTypeUnauthenticated
 }
+
+func (u UserUnauthenticated) Role() Role {
+	return ""
+}
@@ -0,0 +1,94 @@
+package xhttp
+
+import (
+	"context"
+	"davidterranova/jurigen/backend/pkg/user"
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type accessLogCtxKey struct{}
+
+// accessLogEntry collects what the inner middlewares learn about the request, such as the authenticated user
+type accessLogEntry struct {
+	userID uuid.UUID
+}
+
+// recordUser makes the access log of the request name the authenticated user
+func recordUser(ctx context.Context, u user.User) {
+	if entry, ok := ctx.Value(accessLogCtxKey{}).(*accessLogEntry); ok && u != nil {
+		entry.userID = u.Id()
+	}
+}
+
+// AccessLogMiddleware logs a line per request with its method, path, status and latency, along with the
+// request ID and the authenticated user. fieldsFn adds fields known from the matched route, it may be nil.
+// It is mounted after RequestIDMiddleware so that the line can be correlated with the logs of the request.
+func AccessLogMiddleware(fieldsFn func(r *http.Request) map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			entry := &accessLogEntry{}
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), accessLogCtxKey{}, entry)))
+
+			logger := xlog.Ctx(r.Context())
+			var event *zerolog.Event
+			switch {
+			case recorder.status >= http.StatusInternalServerError:
+				event = logger.Error()
+			case recorder.status >= http.StatusBadRequest:
+				event = logger.Warn()
+			default:
+				event = logger.Info()
+			}
+
+			event = event.
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Int("status", recorder.status).
+				Dur("latency_ms", time.Since(start))
+			if entry.userID != uuid.Nil {
+				event = event.Str("user_id", entry.userID.String())
+			}
+			if fieldsFn != nil {
+				for key, value := range fieldsFn(r) {
+					if value != "" {
+						event = event.Str(key, value)
+					}
+				}
+			}
+			event.Msg("request completed")
+		})
+	}
+}
+
+// statusRecorder keeps the status code written by the handlers
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(data)
+}
+
+// Unwrap gives http.ResponseController access to the flushing and deadlines of the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
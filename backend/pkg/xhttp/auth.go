@@ -21,12 +21,31 @@ func AuthMiddleware(authFn AuthFn) func(http.Handler) http.Handler {
 				return
 			}
 
+			recordUser(ctx, user)
 			reqWithCtx := r.WithContext(auth.ContextWithUser(ctx, user))
 			next.ServeHTTP(w, reqWithCtx)
 		})
 	}
 }
 
+// RequireRole rejects the requests of users lacking the permissions of the role. Requests carrying no user
+// are let through, authentication is disabled on their routes.
+func RequireRole(role user.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			u, err := auth.UserFromContext(ctx)
+			if err == nil && !u.Role().Allows(role) {
+				WriteError(ctx, w, http.StatusForbidden, "forbidden", fmt.Errorf("%w: %s role required", auth.ErrForbidden, role))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func BasicAuthFn(username string, password string) AuthFn {
 	return func(r *http.Request) (user.User, error) {
 		user, err := auth.BasicAuth(username, password)(r.Header.Get("Authorization"))
@@ -48,3 +67,16 @@ func GrantAnyFn() AuthFn {
 		return user, nil
 	}
 }
+
+// StaticUsersFn authenticates the users declared in the configuration, each with its own role
+func StaticUsersFn(users []auth.StaticUser) AuthFn {
+	authenticate := auth.StaticUsers(users)
+	return func(r *http.Request) (user.User, error) {
+		user, err := authenticate(r.Header.Get("Authorization"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", auth.ErrUnauthorized, err.Error())
+		}
+
+		return user, nil
+	}
+}
@@ -0,0 +1,58 @@
+package xhttp
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ConcurrencyLimitMiddleware serves at most limit concurrent requests sharing the same key, as returned by keyFn.
+// Requests beyond the limit are rejected with 503 and a Retry-After header, a limit below 1 disables the middleware.
+func ConcurrencyLimitMiddleware(limit int, retryAfter time.Duration, keyFn func(r *http.Request) string) func(http.Handler) http.Handler {
+	var mu sync.Mutex
+	inFlight := make(map[string]int)
+
+	acquire := func(key string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if inFlight[key] >= limit {
+			return false
+		}
+		inFlight[key]++
+		return true
+	}
+
+	release := func(key string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		inFlight[key]--
+		if inFlight[key] == 0 {
+			delete(inFlight, key)
+		}
+	}
+
+	retryAfterSeconds := strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+
+	return func(next http.Handler) http.Handler {
+		if limit < 1 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := keyFn(r)
+			if !acquire(key) {
+				w.Header().Set("Retry-After", retryAfterSeconds)
+				WriteError(r.Context(), w, http.StatusServiceUnavailable, "too many concurrent requests", fmt.Errorf("%d concurrent requests already in flight for %s", limit, key))
+				return
+			}
+			defer release(key)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
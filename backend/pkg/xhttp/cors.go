@@ -7,8 +7,26 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func CORS() func(http.Handler) http.Handler {
-	return cors.AllowAll().Handler
+// AnyOrigin allows the requests of every origin
+const AnyOrigin = "*"
+
+// CORS allows the cross-origin requests of the allowed origins, "*" allowing any origin
+func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
+	return cors.New(cors.Options{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{
+			http.MethodHead,
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		},
+		AllowedHeaders: []string{"*"},
+		// Editors read the DAG revision to send it back in If-Match
+		ExposedHeaders:   []string{"ETag", "Retry-After"},
+		AllowCredentials: false,
+	}).Handler
 }
 
 type CORSLogger struct{}
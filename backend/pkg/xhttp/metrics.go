@@ -0,0 +1,76 @@
+package xhttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// MetricsWriter writes its metrics in the Prometheus text format
+type MetricsWriter interface {
+	WriteMetrics(w io.Writer)
+}
+
+// MetricsHandler serves the metrics of the writers for Prometheus to scrape
+func MetricsHandler(writers ...MetricsWriter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		for _, writer := range writers {
+			writer.WriteMetrics(w)
+		}
+	})
+}
+
+// ThrottleCounter counts the throttled requests per route group and exposes them in the Prometheus text format
+type ThrottleCounter struct {
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+func NewThrottleCounter() *ThrottleCounter {
+	return &ThrottleCounter{counts: make(map[string]uint64)}
+}
+
+// Inc counts a throttled request of the route group
+func (c *ThrottleCounter) Inc(group string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts[group]++
+}
+
+// Count returns the number of throttled requests of the route group
+func (c *ThrottleCounter) Count(group string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[group]
+}
+
+// ServeHTTP writes the counters for Prometheus to scrape
+func (c *ThrottleCounter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	MetricsHandler(c).ServeHTTP(w, r)
+}
+
+// WriteMetrics writes the counters in the Prometheus text format
+func (c *ThrottleCounter) WriteMetrics(w io.Writer) {
+	c.mu.Lock()
+	groups := make([]string, 0, len(c.counts))
+	for group := range c.counts {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	counts := make([]uint64, len(groups))
+	for i, group := range groups {
+		counts[i] = c.counts[group]
+	}
+	c.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP jurigen_http_throttled_requests_total Requests rejected by the rate limiter.")
+	fmt.Fprintln(w, "# TYPE jurigen_http_throttled_requests_total counter")
+	for i, group := range groups {
+		fmt.Fprintf(w, "jurigen_http_throttled_requests_total{group=%q} %d\n", group, counts[i])
+	}
+}
@@ -0,0 +1,138 @@
+package xhttp
+
+import (
+	"davidterranova/jurigen/backend/pkg/auth"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxBuckets bounds the token buckets kept for clients, refilled buckets are dropped first then the least
+// recently used ones
+const maxBuckets = 10000
+
+// RateLimit configures a token bucket refilled with Rate tokens per second and holding at most Burst tokens
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled reports whether the limit throttles requests
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// tokenBuckets holds the bucket of each client, at most capacity of them
+type tokenBuckets struct {
+	limit    RateLimit
+	burst    float64
+	capacity int
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newTokenBuckets(limit RateLimit, capacity int) *tokenBuckets {
+	return &tokenBuckets{
+		limit:    limit,
+		burst:    float64(max(limit.Burst, 1)),
+		capacity: capacity,
+		buckets:  make(map[string]*tokenBucket),
+	}
+}
+
+// take consumes a token of the client bucket or returns how long until one is available
+func (t *tokenBuckets) take(key string, now time.Time) (bool, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket, ok := t.buckets[key]
+	if !ok {
+		if len(t.buckets) >= t.capacity {
+			t.evict(now)
+		}
+		bucket = &tokenBucket{tokens: t.burst, updated: now}
+		t.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(t.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*t.limit.Rate)
+	bucket.updated = now
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / t.limit.Rate * float64(time.Second))
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// evict makes room for a new bucket, dropping the refilled buckets, which are the same as new ones, or the least
+// recently used bucket when none is refilled
+func (t *tokenBuckets) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, bucket := range t.buckets {
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*t.limit.Rate >= t.burst {
+			delete(t.buckets, key)
+			continue
+		}
+		if oldestKey == "" || bucket.updated.Before(oldest) {
+			oldestKey, oldest = key, bucket.updated
+		}
+	}
+
+	if len(t.buckets) >= t.capacity {
+		delete(t.buckets, oldestKey)
+	}
+}
+
+// RateLimitMiddleware throttles the requests of each client, as identified by keyFn, with a token bucket.
+// Requests finding the bucket empty are rejected with 429 and a Retry-After header, and reported to onThrottle
+// when set. A disabled limit disables the middleware.
+func RateLimitMiddleware(limit RateLimit, keyFn func(r *http.Request) string, onThrottle func(r *http.Request)) func(http.Handler) http.Handler {
+	buckets := newTokenBuckets(limit, maxBuckets)
+
+	return func(next http.Handler) http.Handler {
+		if !limit.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, retryAfter := buckets.take(keyFn(r), time.Now())
+			if !allowed {
+				if onThrottle != nil {
+					onThrottle(r)
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				WriteError(r.Context(), w, http.StatusTooManyRequests, "too many requests", fmt.Errorf("rate limit of %g requests per second exceeded", limit.Rate))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientKey identifies the client of a request by the user it authenticated as, falling back to its IP address
+// on routes without authentication. It must run after the authentication, the credentials sent by a client
+// are not trusted before, a client sending new ones on each request would get a new bucket each time.
+func ClientKey(r *http.Request) string {
+	if u, err := auth.UserFromContext(r.Context()); err == nil && u.Id() != uuid.Nil {
+		return "user:" + u.Id().String()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,65 @@
+package xhttp
+
+import (
+	"davidterranova/jurigen/backend/pkg/auth"
+	"davidterranova/jurigen/backend/pkg/user"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBuckets(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("keeps at most capacity buckets", func(t *testing.T) {
+		buckets := newTokenBuckets(RateLimit{Rate: 1.0 / 3600, Burst: 1}, 3)
+		for i := 0; i < 100; i++ {
+			allowed, _ := buckets.take(fmt.Sprintf("ip:10.0.0.%d", i), now.Add(time.Duration(i)*time.Second))
+			assert.True(t, allowed)
+		}
+		assert.Len(t, buckets.buckets, 3)
+	})
+
+	t.Run("drops the least recently used bucket", func(t *testing.T) {
+		buckets := newTokenBuckets(RateLimit{Rate: 1.0 / 3600, Burst: 1}, 2)
+		buckets.take("old", now)
+		buckets.take("recent", now.Add(time.Second))
+		buckets.take("new", now.Add(2*time.Second))
+
+		assert.NotContains(t, buckets.buckets, "old")
+		allowed, retryAfter := buckets.take("recent", now.Add(3*time.Second))
+		assert.False(t, allowed, "the recent bucket is kept empty")
+		assert.Greater(t, retryAfter, time.Duration(0))
+	})
+
+	t.Run("drops the refilled buckets first", func(t *testing.T) {
+		buckets := newTokenBuckets(RateLimit{Rate: 1, Burst: 1}, 2)
+		buckets.take("refilled", now)
+		buckets.take("empty", now.Add(time.Minute))
+		buckets.take("new", now.Add(time.Minute))
+
+		assert.NotContains(t, buckets.buckets, "refilled")
+		assert.Contains(t, buckets.buckets, "empty")
+	})
+}
+
+func TestClientKey(t *testing.T) {
+	t.Run("identifies authenticated users", func(t *testing.T) {
+		id := uuid.New()
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req = req.WithContext(auth.ContextWithUser(req.Context(), user.NewWithRole(id, user.RoleViewer)))
+		assert.Equal(t, "user:"+id.String(), ClientKey(req))
+	})
+
+	t.Run("falls back to the IP address", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-API-Key", uuid.NewString())
+		assert.Equal(t, "ip:10.0.0.1", ClientKey(req))
+	})
+}
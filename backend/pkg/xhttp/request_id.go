@@ -0,0 +1,42 @@
+package xhttp
+
+import (
+	"davidterranova/jurigen/backend/pkg/xlog"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID, it is reused when sent by the client and echoed in the response
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the client request IDs copied to the logs
+const maxRequestIDLength = 128
+
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID(requestID) {
+				requestID = uuid.NewString()
+			}
+
+			w.Header().Set(RequestIDHeader, requestID)
+			next.ServeHTTP(w, r.WithContext(xlog.ContextWithRequestID(r.Context(), requestID)))
+		})
+	}
+}
+
+// validRequestID accepts the non-empty client request IDs made of printable ASCII characters, longer or
+// binary values are replaced so that clients cannot forge log lines
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -15,38 +15,69 @@ const (
 
 	// DefaultReadTimeout for the http server
 	DefaultReadTimeout = 5 * time.Second
+
+	// DefaultShutdownTimeout bounds the wait for the in-flight requests on shutdown
+	DefaultShutdownTimeout = 5 * time.Second
 )
 
 // Server is a filestorage http server
 type Server struct {
-	host    string
-	port    int
-	handler http.Handler
+	host            string
+	port            int
+	handler         http.Handler
+	shutdownTimeout time.Duration
+	allowedOrigins  []string
+	onShutdown      []func()
 }
 
 // NewServer creates a new http server given a handler and a configuration
 func NewServer(handler http.Handler, host string, port int) *Server {
 	return &Server{
-		host:    host,
-		port:    port,
-		handler: handler,
+		host:            host,
+		port:            port,
+		handler:         handler,
+		shutdownTimeout: DefaultShutdownTimeout,
+		allowedOrigins:  []string{AnyOrigin},
 	}
 }
 
+// WithShutdownTimeout sets how long the in-flight requests are waited for once the server stops
+func (s *Server) WithShutdownTimeout(timeout time.Duration) *Server {
+	s.shutdownTimeout = timeout
+	return s
+}
+
+// WithAllowedOrigins restricts the cross-origin requests to the origins, e.g. https://app.example.com
+func (s *Server) WithAllowedOrigins(origins []string) *Server {
+	s.allowedOrigins = origins
+	return s
+}
+
 // Address returns the host and port expected from an http server
 func (s Server) Address() string {
 	return fmt.Sprintf("%s:%d", s.host, s.port)
 }
 
-// Serve starts the server
+// OnShutdown registers a function called when the server starts shutting down, e.g. to end long-lived
+// responses which would otherwise hold the shutdown until its timeout
+func (s *Server) OnShutdown(f func()) *Server {
+	s.onShutdown = append(s.onShutdown, f)
+	return s
+}
+
+// Serve starts the server. Once ctx is cancelled, the server stops accepting connections and waits for the
+// in-flight requests up to the shutdown timeout, the connections still active are then closed.
 func (s Server) Serve(ctx context.Context) error {
 	srv := http.Server{
 		Addr:              s.Address(),
-		Handler:           CORS()(s.handler),
+		Handler:           CORS(s.allowedOrigins)(s.handler),
 		WriteTimeout:      DefaultWriteTimeout,
 		ReadTimeout:       DefaultReadTimeout,
 		ReadHeaderTimeout: DefaultReadTimeout,
 	}
+	for _, f := range s.onShutdown {
+		srv.RegisterOnShutdown(f)
+	}
 
 	go func() {
 		err := srv.ListenAndServe()
@@ -65,12 +96,13 @@ func (s Server) Serve(ctx context.Context) error {
 
 	<-ctx.Done()
 
-	ctxShutDown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctxShutDown, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
 	err := srv.Shutdown(ctxShutDown)
 	if err != nil && err != http.ErrServerClosed {
-		return fmt.Errorf("failed to shutdown http server properly: %s", err)
+		_ = srv.Close()
+		return fmt.Errorf("failed to shutdown http server properly: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,41 @@
+package xlog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type RequestCtxKey string
+
+const RequestCtxRequestIDKey RequestCtxKey = "request_id"
+
+// RequestIDField is the log field carrying the request ID
+const RequestIDField = "request_id"
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestCtxRequestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestCtxRequestIDKey).(string)
+	return requestID
+}
+
+// With returns logger annotated with the request ID carried by ctx, if any
+func With(ctx context.Context, logger zerolog.Logger) *zerolog.Logger {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		return &logger
+	}
+
+	annotated := logger.With().Str(RequestIDField, requestID).Logger()
+	return &annotated
+}
+
+// Ctx returns the global logger annotated with the request ID carried by ctx, if any
+func Ctx(ctx context.Context) *zerolog.Logger {
+	return With(ctx, log.Logger)
+}
@@ -0,0 +1,280 @@
+// Package xredis is a minimal Redis client speaking the RESP protocol, covering the commands of a cache shared by
+// several servers: reads and writes with expiry, deletions and publish/subscribe
+package xredis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultTimeout bounds each command when its context has no deadline
+const defaultTimeout = 5 * time.Second
+
+// ErrNil is returned when the key of a read does not exist
+var ErrNil = errors.New("redis: nil")
+
+// Error is an error reply of the server
+type Error string
+
+func (e Error) Error() string { return "redis: " + string(e) }
+
+// Options configure the connections to the server
+type Options struct {
+	Password string
+	DB       int
+}
+
+// Client sends the commands over a single connection, established on the first command and again after
+// a network error
+type Client struct {
+	addr    string
+	options Options
+	dialer  net.Dialer
+
+	mu   sync.Mutex
+	conn *conn
+}
+
+func New(addr string, options Options) *Client {
+	return &Client{
+		addr:    addr,
+		options: options,
+	}
+}
+
+// Close closes the connection of the client, the next command opens a new one
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// Do sends a command and returns its reply: a string, an int64, a []byte, nil or a []any of them
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		cn, err := c.dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.conn = cn
+	}
+
+	reply, err := c.conn.do(ctx, args...)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection is in an unknown state after a network error
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	return reply, err
+}
+
+// Get reads the value of a key, ErrNil when it does not exist
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := c.Do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, nil
+}
+
+// Set writes the value of a key, expiring after ttl unless ttl is 0
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := c.Do(ctx, args...)
+	return err
+}
+
+// Del deletes keys, the missing ones are ignored
+func (c *Client) Del(ctx context.Context, keys ...string) error {
+	_, err := c.Do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// Publish sends a message to the subscribers of a channel
+func (c *Client) Publish(ctx context.Context, channel string, message []byte) error {
+	_, err := c.Do(ctx, "PUBLISH", channel, string(message))
+	return err
+}
+
+// Subscribe receives the messages of a channel on a dedicated connection. The returned channel is closed when
+// ctx is cancelled or the connection is lost, the messages published meanwhile are missed.
+func (c *Client) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	cn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cn.do(ctx, "SUBSCRIBE", channel); err != nil {
+		_ = cn.Close()
+		return nil, fmt.Errorf("redis: failed to subscribe to %s: %w", channel, err)
+	}
+	// Messages are awaited without deadline, closing the connection stops the wait
+	_ = cn.SetDeadline(time.Time{})
+
+	messages := make(chan []byte)
+	stop := context.AfterFunc(ctx, func() { _ = cn.Close() })
+	go func() {
+		defer close(messages)
+		defer stop()
+		defer cn.Close()
+
+		for {
+			reply, err := cn.read()
+			if err != nil {
+				return
+			}
+			parts, ok := reply.([]any)
+			if !ok || len(parts) != 3 || !isBulk(parts[0], "message") {
+				continue
+			}
+			payload, ok := parts[2].([]byte)
+			if !ok {
+				continue
+			}
+			select {
+			case messages <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// dial opens a connection, authenticated and on the configured database
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	netConn, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis: failed to connect to %s: %w", c.addr, err)
+	}
+	cn := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+
+	if c.options.Password != "" {
+		if _, err := cn.do(ctx, "AUTH", c.options.Password); err != nil {
+			_ = cn.Close()
+			return nil, fmt.Errorf("redis: failed to authenticate: %w", err)
+		}
+	}
+	if c.options.DB != 0 {
+		if _, err := cn.do(ctx, "SELECT", strconv.Itoa(c.options.DB)); err != nil {
+			_ = cn.Close()
+			return nil, fmt.Errorf("redis: failed to select database %d: %w", c.options.DB, err)
+		}
+	}
+	return cn, nil
+}
+
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// do writes a command and reads its reply, within the deadline of ctx
+func (cn *conn) do(ctx context.Context, args ...string) (any, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	if err := cn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if _, err := cn.Write(encodeCommand(args)); err != nil {
+		return nil, fmt.Errorf("redis: failed to send %s: %w", args[0], err)
+	}
+	return cn.read()
+}
+
+func encodeCommand(args []string) []byte {
+	buf := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		buf = fmt.Appendf(buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return buf
+}
+
+// read reads a reply, an error reply is returned as an Error
+func (cn *conn) read() (any, error) {
+	line, err := cn.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, Error(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", payload)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(cn.reader, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		count, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", payload)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]any, count)
+		for i := range items {
+			item, err := cn.read()
+			var replyErr Error
+			if err != nil && !errors.As(err, &replyErr) {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", kind)
+	}
+}
+
+func isBulk(value any, expected string) bool {
+	data, ok := value.([]byte)
+	return ok && string(data) == expected
+}
@@ -0,0 +1,428 @@
+package xredis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer speaks enough RESP to serve the client: GET, SET, DEL, AUTH, SELECT, PUBLISH and SUBSCRIBE.
+// Replies are written one byte at a time, so that the client reads them in parts.
+type fakeServer struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	values      map[string]string
+	commands    [][]string
+	connections int
+	subscribers map[net.Conn]string
+	// reply overrides the reply to a command when it returns ok, closing the connection instead when reply is empty
+	reply func(args []string) (reply string, ok bool)
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	f := &fakeServer{
+		listener:    listener,
+		values:      make(map[string]string),
+		subscribers: make(map[net.Conn]string),
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			cn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.connections++
+			f.mu.Unlock()
+			go f.serve(cn)
+		}
+	}()
+
+	return f
+}
+
+func (f *fakeServer) addr() string {
+	return f.listener.Addr().String()
+}
+
+func (f *fakeServer) serve(cn net.Conn) {
+	defer cn.Close()
+	reader := &conn{Conn: cn, reader: bufio.NewReader(cn)}
+
+	for {
+		command, err := reader.read()
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range command.([]any) {
+			args = append(args, string(arg.([]byte)))
+		}
+
+		reply, keep := f.handle(cn, args)
+		if !keep {
+			return
+		}
+		for i := range len(reply) {
+			if _, err := cn.Write([]byte{reply[i]}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeServer) handle(cn net.Conn, args []string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.commands = append(f.commands, args)
+	if f.reply != nil {
+		if reply, ok := f.reply(args); ok {
+			return reply, reply != ""
+		}
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "SELECT":
+		return "+OK\r\n", true
+	case "SET":
+		f.values[args[1]] = args[2]
+		return "+OK\r\n", true
+	case "GET":
+		value, ok := f.values[args[1]]
+		if !ok {
+			return "$-1\r\n", true
+		}
+		return bulk(value), true
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := f.values[key]; ok {
+				delete(f.values, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted), true
+	case "SUBSCRIBE":
+		f.subscribers[cn] = args[1]
+		return "*3\r\n" + bulk("subscribe") + bulk(args[1]) + ":1\r\n", true
+	case "PUBLISH":
+		received := 0
+		for subscriber, channel := range f.subscribers {
+			if channel == args[1] {
+				_, _ = subscriber.Write([]byte("*3\r\n" + bulk("message") + bulk(channel) + bulk(args[2])))
+				received++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", received), true
+	default:
+		return "-ERR unknown command '" + args[0] + "'\r\n", true
+	}
+}
+
+// dropSubscribers closes the connections of the subscribers, as when the server restarts
+func (f *fakeServer) dropSubscribers() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for subscriber := range f.subscribers {
+		_ = subscriber.Close()
+		delete(f.subscribers, subscriber)
+	}
+}
+
+func (f *fakeServer) subscriberCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subscribers)
+}
+
+func (f *fakeServer) connectionCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.connections
+}
+
+func (f *fakeServer) receivedCommands() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.commands...)
+}
+
+func bulk(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func TestConn_Read(t *testing.T) {
+	testCases := []struct {
+		name     string
+		reply    string
+		expected any
+		err      string
+	}{
+		{name: "simple string", reply: "+OK\r\n", expected: "OK"},
+		{name: "integer", reply: ":-42\r\n", expected: int64(-42)},
+		{name: "bulk string", reply: "$5\r\nhello\r\n", expected: []byte("hello")},
+		{name: "bulk string holding a line break", reply: "$4\r\na\r\nb\r\n", expected: []byte("a\r\nb")},
+		{name: "empty bulk string", reply: "$0\r\n\r\n", expected: []byte{}},
+		{name: "nil bulk string", reply: "$-1\r\n", expected: nil},
+		{name: "nil array", reply: "*-1\r\n", expected: nil},
+		{
+			name:     "nested array",
+			reply:    "*4\r\n$3\r\nfoo\r\n:1\r\n$-1\r\n*1\r\n+OK\r\n",
+			expected: []any{[]byte("foo"), int64(1), nil, []any{"OK"}},
+		},
+		{name: "error reply", reply: "-WRONGTYPE Operation against a key\r\n", err: "redis: WRONGTYPE Operation against a key"},
+		{name: "unknown reply type", reply: "?1\r\n", err: `unexpected reply type '?'`},
+		{name: "malformed bulk length", reply: "$x\r\n", err: `malformed bulk length "x"`},
+		{name: "malformed array length", reply: "*x\r\n", err: `malformed array length "x"`},
+		{name: "line without carriage return", reply: "+OK\n", err: "malformed reply"},
+		{name: "truncated bulk string", reply: "$5\r\nhel", err: "EOF"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Read at once, then in parts of one byte as replies split across TCP segments
+			for _, partial := range []bool{false, true} {
+				source := io.Reader(strings.NewReader(tc.reply))
+				if partial {
+					source = iotest.OneByteReader(source)
+				}
+				cn := &conn{reader: bufio.NewReader(source)}
+
+				reply, err := cn.read()
+				if tc.err != "" {
+					assert.ErrorContains(t, err, tc.err, "partial reads: %v", partial)
+					continue
+				}
+				require.NoError(t, err, "partial reads: %v", partial)
+				assert.Equal(t, tc.expected, reply, "partial reads: %v", partial)
+			}
+		})
+	}
+
+	t.Run("error replies are returned as Error", func(t *testing.T) {
+		cn := &conn{reader: bufio.NewReader(strings.NewReader("-ERR no such key\r\n"))}
+		_, err := cn.read()
+
+		var replyErr Error
+		require.ErrorAs(t, err, &replyErr)
+		assert.Equal(t, Error("ERR no such key"), replyErr)
+	})
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads, writes and deletes values", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		_, err := client.Get(ctx, "dag")
+		assert.ErrorIs(t, err, ErrNil)
+
+		require.NoError(t, client.Set(ctx, "dag", []byte("line\r\nbreak"), 1500*time.Millisecond))
+		value, err := client.Get(ctx, "dag")
+		require.NoError(t, err)
+		assert.Equal(t, "line\r\nbreak", string(value))
+
+		require.NoError(t, client.Del(ctx, "dag", "missing"))
+		_, err = client.Get(ctx, "dag")
+		assert.ErrorIs(t, err, ErrNil)
+
+		assert.Contains(t, server.receivedCommands(), []string{"SET", "dag", "line\r\nbreak", "PX", "1500"})
+		assert.Contains(t, server.receivedCommands(), []string{"DEL", "dag", "missing"})
+		assert.Equal(t, 1, server.connectionCount(), "the connection is reused")
+	})
+
+	t.Run("authenticates and selects the database on connection", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := New(server.addr(), Options{Password: "secret", DB: 2})
+		defer client.Close()
+
+		require.NoError(t, client.Set(ctx, "key", []byte("value"), 0))
+
+		assert.Equal(t, [][]string{
+			{"AUTH", "secret"},
+			{"SELECT", "2"},
+			{"SET", "key", "value"},
+		}, server.receivedCommands())
+	})
+
+	t.Run("fails to connect when the password is rejected", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.reply = func(args []string) (string, bool) {
+			if args[0] == "AUTH" {
+				return "-WRONGPASS invalid password\r\n", true
+			}
+			return "", false
+		}
+		client := New(server.addr(), Options{Password: "wrong"})
+		defer client.Close()
+
+		_, err := client.Get(ctx, "key")
+		assert.ErrorContains(t, err, "failed to authenticate: redis: WRONGPASS invalid password")
+	})
+
+	t.Run("keeps the connection after an error reply", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		_, err := client.Do(ctx, "FLUSHALL")
+		var replyErr Error
+		require.ErrorAs(t, err, &replyErr)
+		assert.Equal(t, Error("ERR unknown command 'FLUSHALL'"), replyErr)
+
+		require.NoError(t, client.Set(ctx, "key", []byte("value"), 0))
+		assert.Equal(t, 1, server.connectionCount())
+	})
+
+	t.Run("connects again after a network error", func(t *testing.T) {
+		server := newFakeServer(t)
+		dropped := false
+		server.reply = func(args []string) (string, bool) {
+			if args[0] == "GET" && !dropped {
+				dropped = true
+				return "", true
+			}
+			return "", false
+		}
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		require.NoError(t, client.Set(ctx, "key", []byte("value"), 0))
+		_, err := client.Get(ctx, "key")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNil)
+
+		value, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "value", string(value))
+		assert.Equal(t, 2, server.connectionCount())
+	})
+
+	t.Run("times out on a server which does not reply", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.reply = func(args []string) (string, bool) {
+			return "$5\r\nhel", true
+		}
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := client.Get(timeoutCtx, "key")
+
+		var netErr net.Error
+		require.True(t, errors.As(err, &netErr), "got %v", err)
+		assert.True(t, netErr.Timeout())
+	})
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	receive := func(t *testing.T, messages <-chan []byte) string {
+		t.Helper()
+		select {
+		case message, ok := <-messages:
+			require.True(t, ok, "the subscription is closed")
+			return string(message)
+		case <-time.After(time.Second):
+			t.Fatal("no message received")
+			return ""
+		}
+	}
+
+	waitClosed := func(t *testing.T, messages <-chan []byte) {
+		t.Helper()
+		select {
+		case _, ok := <-messages:
+			require.False(t, ok, "no message is expected")
+		case <-time.After(time.Second):
+			t.Fatal("the subscription is not closed")
+		}
+	}
+
+	t.Run("receives the messages published on the channel", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		messages, err := client.Subscribe(ctx, "invalidations")
+		require.NoError(t, err)
+
+		require.NoError(t, client.Publish(ctx, "invalidations", []byte("first")))
+		require.NoError(t, client.Publish(ctx, "other", []byte("ignored")))
+		require.NoError(t, client.Publish(ctx, "invalidations", []byte("second")))
+
+		assert.Equal(t, "first", receive(t, messages))
+		assert.Equal(t, "second", receive(t, messages))
+	})
+
+	t.Run("closes the subscription when the context is cancelled", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		subscribeCtx, cancel := context.WithCancel(ctx)
+		messages, err := client.Subscribe(subscribeCtx, "invalidations")
+		require.NoError(t, err)
+
+		cancel()
+		waitClosed(t, messages)
+	})
+
+	t.Run("closes the subscription when the connection is lost, after which it subscribes again", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		messages, err := client.Subscribe(ctx, "invalidations")
+		require.NoError(t, err)
+
+		server.dropSubscribers()
+		waitClosed(t, messages)
+
+		messages, err = client.Subscribe(ctx, "invalidations")
+		require.NoError(t, err)
+		require.Equal(t, 1, server.subscriberCount())
+
+		require.NoError(t, client.Publish(ctx, "invalidations", []byte("after reconnection")))
+		assert.Equal(t, "after reconnection", receive(t, messages))
+	})
+
+	t.Run("fails when the subscription is rejected", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.reply = func(args []string) (string, bool) {
+			if args[0] == "SUBSCRIBE" {
+				return "-NOPERM this user has no permissions to access the channel\r\n", true
+			}
+			return "", false
+		}
+		client := New(server.addr(), Options{})
+		defer client.Close()
+
+		_, err := client.Subscribe(ctx, "invalidations")
+		assert.ErrorContains(t, err, "failed to subscribe to invalidations: redis: NOPERM")
+	})
+}